@@ -1,8 +1,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 
@@ -12,7 +18,11 @@ import (
 	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
 	"github.com/LimeChain/Hederium/internal/infrastructure/logger"
 	"github.com/LimeChain/Hederium/internal/infrastructure/startup"
+	"github.com/LimeChain/Hederium/internal/service"
+	"github.com/LimeChain/Hederium/internal/transport/grpc_server"
 	"github.com/LimeChain/Hederium/internal/transport/http_server"
+	"github.com/LimeChain/Hederium/internal/transport/rpc"
+	"github.com/LimeChain/Hederium/internal/util"
 )
 
 func main() {
@@ -20,40 +30,544 @@ func main() {
 		fmt.Printf("Failed to load configuration: %v\n", err)
 		return
 	}
-	log := logger.InitLogger(viper.GetString("logging.level"))
+	operatorKeyProvider, err := config.ResolveOperatorKey(viper.GetViper())
+	if err != nil {
+		fmt.Printf("Refusing to start, %v\n", err)
+		return
+	}
+	if err := config.Validate(viper.GetViper()); err != nil {
+		fmt.Printf("Refusing to start, %v\n", err)
+		return
+	}
+	log, err := logger.InitLogger(logger.Config{
+		Level:         viper.GetString("logging.level"),
+		Encoding:      viper.GetString("logging.encoding"),
+		DisableCaller: viper.GetBool("logging.DisableCaller"),
+		Sampling: logger.SamplingConfig{
+			Enabled:    viper.GetBool("logging.sampling.enabled"),
+			Initial:    viper.GetInt("logging.sampling.initial"),
+			Thereafter: viper.GetInt("logging.sampling.thereafter"),
+			Tick:       viper.GetDuration("logging.sampling.tick"),
+		},
+		File: logger.FileConfig{
+			Path:       viper.GetString("logging.file.path"),
+			MaxSizeMB:  viper.GetInt("logging.file.maxSizeMB"),
+			MaxBackups: viper.GetInt("logging.file.maxBackups"),
+		},
+		Sink: logger.SinkConfig{
+			URL:           viper.GetString("logging.sink.url"),
+			BatchSize:     viper.GetInt("logging.sink.batchSize"),
+			FlushInterval: viper.GetDuration("logging.sink.flushInterval"),
+		},
+	})
+	if err != nil {
+		fmt.Printf("Failed to initialize logger: %v\n", err)
+		return
+	}
 	defer func() { _ = log.Sync() }()
 
 	// Log startup information
 	startup.LogStartup()
 
-	hClient, err := hedera.NewHederaClient(
-		viper.GetString("hedera.network"),
-		viper.GetString("hedera.operatorId"),
-		viper.GetString("hedera.operatorKey"),
-	)
+	operatorPool, err := newOperatorPool()
+	if err != nil {
+		log.Error("Failed to initialize operator pool", zap.Error(err))
+		return
+	}
+	logOperators(log, operatorPool)
+
+	hClient, err := hedera.NewHederaClient(viper.GetString("hedera.network"), operatorPool)
 	if err != nil {
 		// log.Fatal exits immediately; ensure sync happens before exiting
 		log.Error("Failed to initialize Hedera client", zap.Error(err))
 		return
 	}
+	hClient.MonitorOperatorBalances(viper.GetDuration("hedera.operatorBalanceCheckInterval"), log)
+	hClient.MonitorConsensusNodes(consensusHealthConfig(), log)
+	config.WatchSecret(operatorKeyProvider, viper.GetDuration("hedera.operatorKeySource.rotationInterval"), viper.GetString("hedera.operatorKey"), func(key string) error {
+		return hClient.RotateOperatorKey(key, viper.GetString("hedera.operatorKeyType"))
+	}, log.With(zap.String("secret", "hedera.operatorKey")))
+
+	startupReport := startup.NewReport(viper.GetViper(), operatorPool.Balances())
+	startupReport.Log(log)
+	if !viper.GetBool("startupReport.endpoint") {
+		startupReport = nil
+	}
 
 	applicationVersion := viper.GetString("application.version")
 	chainId := viper.GetString("hedera.chainId")
-	apiKeyStore := limiter.NewAPIKeyStore(viper.Get("apiKeys"))
-	tieredLimiter := limiter.NewTieredLimiter(viper.GetStringMap("limiter"), viper.GetInt("hedera.hbarBudget"))
+	apiKeyStore, err := newAPIKeyStore(log)
+	if err != nil {
+		log.Error("Failed to initialize API key store", zap.Error(err))
+		return
+	}
+	tieredLimiter := limiter.NewTieredLimiter(viper.GetStringMap("limiter"), viper.GetInt("hedera.hbarBudget"), viper.GetDuration("hedera.hbarBudgetResetWindow"))
+	if viper.GetBool("apiKeyUsage.enabled") {
+		usageSink, err := newUsageSink()
+		if err != nil {
+			log.Error("Failed to initialize API key usage sink", zap.Error(err))
+			return
+		}
+		usageTracker := limiter.NewUsageTracker(usageSink, viper.GetDuration("apiKeyUsage.flushInterval"), log)
+		// Usage of the requests served before shutdown is flushed on return
+		defer usageTracker.Stop()
+		tieredLimiter.TrackUsage(usageTracker)
+	}
 
-	cacheService := cache.NewMemoryCache(viper.GetDuration("cache.defaultExpiration"), viper.GetDuration("cache.cleanupInterval"))
+	cacheService := newCacheService()
 
-	mClient := hedera.NewMirrorClient(viper.GetString("mirrorNode.baseUrl"), viper.GetInt("mirrorNode.timeoutSeconds"), log, cacheService)
+	mirrorNodeURLs := viper.GetStringSlice("mirrorNode.baseUrl")
+	if len(mirrorNodeURLs) == 0 {
+		log.Error("No mirror node base URL configured")
+		return
+	}
+	mClient := hedera.NewMirrorClient(mirrorNodeURLs, viper.GetInt("mirrorNode.timeoutSeconds"), log, cacheService)
+	mClient.UseWeb3URLs(viper.GetStringSlice("mirrorNode.web3Url"))
+	mClient.MonitorEndpoints(viper.GetDuration("mirrorNode.healthCheckInterval"))
+	mClient.MaxPages = viper.GetInt("mirrorNode.maxPages")
+	mClient.Retry = mirrorRetryPolicy()
+	mClient.CacheTTLs = cacheTTLs()
+	mClient.NotFoundTTL = viper.GetDuration("cache.notFoundTtl")
+	mClient.SchemaAliases = hedera.SchemaAliases(viper.GetStringMapStringSlice("mirrorNode.schemaAliases"))
+	mClient.DetectVersion(context.Background())
 
 	enforceAPIKey := viper.GetBool("features.enforceApiKey")
 	enableBatchRequests := viper.GetBool("features.enableBatchRequests")
 
-	port := viper.GetString("server.port")
+	serviceConfig := service.Config{
+		FilterAPIEnabled: viper.GetBool("filters.enabled"),
+		FilterTTL:        viper.GetDuration("filters.ttl"),
+		DebugAPIEnabled:  viper.GetBool("debug.enabled"),
+		MaxLogResults:    viper.GetInt("logs.maxResults"),
+
+		EstimateGasFallback: viper.GetBool("estimateGas.fallbackEnabled"),
+		ContractCallGas:     viper.GetUint64("estimateGas.contractCallGas"),
+		ContractCreationGas: viper.GetUint64("estimateGas.contractCreationGas"),
+
+		DeployGasPerByte:      viper.GetUint64("estimateGas.deployGasPerByte"),
+		MirrorNodeMaxDataSize: viper.GetInt("estimateGas.mirrorNodeMaxDataSize"),
+
+		BlockGasLimit: viper.GetUint64("gas.blockGasLimit"),
+		MaxCallGas:    viper.GetUint64("gas.maxCallGas"),
+
+		SyncingCheckEnabled: viper.GetBool("syncing.enabled"),
+		SyncingLagThreshold: viper.GetDuration("syncing.lagThreshold"),
+
+		MaxPriorityFeePerGas: viper.GetUint64("fees.maxPriorityFeePerGas"),
+
+		AsyncSendRawTransaction: viper.GetBool("sendRawTransaction.async"),
+		WarmReceiptCache:        viper.GetBool("sendRawTransaction.warmReceiptCache"),
+		NonceOrderingEnabled:    viper.GetBool("sendRawTransaction.nonceOrdering"),
+		NonceGapTimeout:         viper.GetDuration("sendRawTransaction.nonceGapTimeout"),
+
+		GetCodeConsensusFallback: viper.GetBool("getCode.consensusFallback"),
+
+		GetStorageAtLatestFallback: viper.GetBool("getStorageAt.latestFallback"),
+
+		MicroCacheTTL:      viper.GetDuration("microCache.ttl"),
+		MicroCacheMaxStale: viper.GetDuration("microCache.maxStale"),
+
+		BlockPollerInterval: viper.GetDuration("blockPoller.interval"),
+
+		CallCacheTTL: viper.GetDuration("callCache.ttl"),
+		CacheTTLs:    cacheTTLs(),
+
+		ConfigurationAPIEnabled: viper.GetBool("configurationApi.enabled"),
+
+		ChecksumAddresses: viper.GetBool("responses.checksumAddresses"),
+
+		AccountsEnabled: viper.GetBool("accounts.enabled"),
+		Accounts:        viper.GetStringSlice("accounts.addresses"),
+	}
+	for _, account := range serviceConfig.Accounts {
+		if !util.IsAddress(account) {
+			log.Error("Invalid address in accounts.addresses", zap.String("address", account))
+			return
+		}
+	}
+
+	corsConfig := http_server.CORSConfig{
+		AllowedOrigins: viper.GetStringSlice("server.cors.allowedOrigins"),
+		AllowedMethods: viper.GetStringSlice("server.cors.allowedMethods"),
+		AllowedHeaders: viper.GetStringSlice("server.cors.allowedHeaders"),
+		MaxAge:         viper.GetDuration("server.cors.maxAge"),
+	}
+
+	adminConfig := http_server.AdminConfig{
+		Enabled: viper.GetBool("admin.enabled"),
+		APIKey:  viper.GetString("admin.apiKey"),
+	}
+
+	devModeConfig := http_server.DevModeConfig{
+		Enabled: viper.GetBool("devMode.enabled"),
+	}
+
+	requestLogConfig := http_server.RequestLogConfig{
+		Enabled: viper.GetBool("logging.requests"),
+	}
+
+	pipelineConfig := http_server.PipelineConfig{
+		Order: viper.GetStringSlice("middleware.order"),
+	}
+
+	listeners, err := http_server.ParseListeners(viper.Get("server.listeners"))
+	if err != nil {
+		log.Error("Invalid server listeners", zap.Error(err))
+		return
+	}
+	if len(listeners) == 0 {
+		listeners = []http_server.ListenerConfig{{
+			Network:  "tcp",
+			Address:  ":" + viper.GetString("server.port"),
+			CertFile: viper.GetString("server.tls.certFile"),
+			KeyFile:  viper.GetString("server.tls.keyFile"),
+		}}
+		if (listeners[0].CertFile == "") != (listeners[0].KeyFile == "") {
+			log.Error("Invalid server TLS configuration, server.tls.certFile and server.tls.keyFile must be set together")
+			return
+		}
+	}
+
+	tlsConfig := http_server.TLSConfig{
+		ReloadInterval: viper.GetDuration("server.tls.reloadInterval"),
+	}
+
+	networks, err := newNetworks(log, cacheService)
+	if err != nil {
+		log.Error("Invalid networks configuration", zap.Error(err))
+		return
+	}
+
+	concurrencyConfig := limiter.ConcurrencyConfig{
+		QueueTimeout: viper.GetDuration("methodConcurrency.queueTimeout"),
+	}
+	if err := viper.UnmarshalKey("methodConcurrency.limits", &concurrencyConfig.Limits); err != nil {
+		log.Error("Invalid method concurrency limits", zap.Error(err))
+		return
+	}
+
+	timeoutConfig := rpc.TimeoutConfig{
+		Default: viper.GetDuration("methodTimeouts.default"),
+	}
+	if err := viper.UnmarshalKey("methodTimeouts.methods", &timeoutConfig.Methods); err != nil {
+		log.Error("Invalid method timeouts", zap.Error(err))
+		return
+	}
+
+	httpCacheConfig := http_server.HTTPCacheConfig{
+		Enabled: viper.GetBool("responses.httpCache.enabled"),
+		MaxAge:  viper.GetDuration("responses.httpCache.maxAge"),
+	}
+
+	var overload *limiter.OverloadController
+	if viper.GetBool("loadShedding.enabled") {
+		overload = limiter.NewOverloadController(overloadConfig(), log)
+		defer overload.Stop()
+		// Failures of every network's mirror node count towards the error rate
+		mClient.OnResult = overload.RecordMirrorResult
+		mClient.OnRateLimited = overload.RecordMirrorRateLimit
+		for _, network := range networks {
+			network.MirrorClient.OnResult = overload.RecordMirrorResult
+			network.MirrorClient.OnRateLimited = overload.RecordMirrorRateLimit
+		}
+	}
+
+	var admission *limiter.AdmissionQueue
+	if viper.GetBool("admissionQueue.enabled") {
+		admission = limiter.NewAdmissionQueue(limiter.AdmissionConfig{
+			MaxConcurrent: viper.GetInt("admissionQueue.maxConcurrent"),
+			QueueDepth:    viper.GetInt("admissionQueue.depth"),
+			QueueTimeout:  viper.GetDuration("admissionQueue.timeout"),
+		})
+	}
+
+	server := http_server.NewServer(hClient, mClient, log, applicationVersion, chainId, apiKeyStore, tieredLimiter, enforceAPIKey, enableBatchRequests, cacheService, serviceConfig, http_server.ServerOptions{
+		CORS:          corsConfig,
+		Admin:         adminConfig,
+		DevMode:       devModeConfig,
+		RequestLog:    requestLogConfig,
+		Listeners:     listeners,
+		TLS:           tlsConfig,
+		Networks:      networks,
+		Concurrency:   concurrencyConfig,
+		HTTPCache:     httpCacheConfig,
+		Overload:      overload,
+		Shadow:        shadowConfig(),
+		StartupReport: startupReport,
+		Pipeline:      pipelineConfig,
+		Admission:     admission,
+		Timeouts:      timeoutConfig,
+	})
+	watchConfig(server, log)
+
+	grpcConfig := grpc_server.Config{
+		Enabled: viper.GetBool("grpc.enabled"),
+		Address: viper.GetString("grpc.address"),
+	}
+	if grpcConfig.Enabled {
+		listener, err := net.Listen("tcp", grpcConfig.Address)
+		if err != nil {
+			log.Error("Failed to listen for gRPC", zap.String("address", grpcConfig.Address), zap.Error(err))
+			return
+		}
+		grpcServer := grpc_server.NewServer(server.RPCHandler(), log, apiKeyStore, tieredLimiter, enforceAPIKey, admission)
+		defer grpcServer.GracefulStop()
+		go func() {
+			log.Info("Starting gRPC server", zap.String("address", grpcConfig.Address))
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Error("gRPC server failed", zap.Error(err))
+			}
+		}()
+	}
 
-	server := http_server.NewServer(hClient, mClient, log, applicationVersion, chainId, apiKeyStore, tieredLimiter, enforceAPIKey, enableBatchRequests, cacheService, port)
 	if err := server.Start(); err != nil {
 		log.Error("Failed to start server", zap.Error(err))
 		return
 	}
 }
+
+// overloadConfig reads the limits above which low-priority calls are shed.
+func overloadConfig() limiter.OverloadConfig {
+	return limiter.OverloadConfig{
+		CheckInterval:      viper.GetDuration("loadShedding.checkInterval"),
+		MaxGoroutines:      viper.GetInt("loadShedding.maxGoroutines"),
+		MaxHeapBytes:       viper.GetUint64("loadShedding.maxHeapMB") << 20,
+		MaxMirrorErrorRate: viper.GetFloat64("loadShedding.maxMirrorErrorRate"),
+		MinMirrorRequests:  viper.GetInt64("loadShedding.minMirrorRequests"),
+		LowPriorityMethods: viper.GetStringSlice("loadShedding.lowPriorityMethods"),
+		MaxLogsBlockRange:  viper.GetInt64("loadShedding.maxLogsBlockRange"),
+	}
+}
+
+// shadowConfig reads where and how much read-only traffic is mirrored.
+func shadowConfig() http_server.ShadowConfig {
+	return http_server.ShadowConfig{
+		Enabled:     viper.GetBool("shadow.enabled"),
+		URL:         viper.GetString("shadow.url"),
+		Percentage:  viper.GetFloat64("shadow.percentage"),
+		Headers:     viper.GetStringMapString("shadow.headers"),
+		Timeout:     viper.GetDuration("shadow.timeout"),
+		MaxInFlight: viper.GetInt("shadow.maxInFlight"),
+	}
+}
+
+// runtimeConfig reads the settings that may change while the relay serves.
+func runtimeConfig() http_server.RuntimeConfig {
+	return http_server.RuntimeConfig{
+		LogLevel:             viper.GetString("logging.level"),
+		Tiers:                viper.GetStringMap("limiter"),
+		APIKeys:              viper.Get("apiKeys"),
+		FiltersEnabled:       viper.GetBool("filters.enabled"),
+		DebugEnabled:         viper.GetBool("debug.enabled"),
+		BatchRequestsEnabled: viper.GetBool("features.enableBatchRequests"),
+		CallCacheTTL:         viper.GetDuration("callCache.ttl"),
+	}
+}
+
+// watchConfig re-reads the config file on SIGHUP and, with
+// configReload.watchFile set, whenever the file changes, and applies the
+// settings that may change while the relay serves. Other settings still
+// require a restart.
+func watchConfig(server http_server.Server, log *zap.Logger) {
+	if viper.GetBool("configReload.watchFile") {
+		viper.OnConfigChange(func(event fsnotify.Event) {
+			log.Info("Config file changed, applying runtime settings", zap.String("file", event.Name))
+			server.ApplyRuntimeConfig(runtimeConfig())
+		})
+		viper.WatchConfig()
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			if err := viper.ReadInConfig(); err != nil {
+				log.Error("Failed to re-read the config file, keeping the current settings", zap.Error(err))
+				continue
+			}
+			server.ApplyRuntimeConfig(runtimeConfig())
+		}
+	}()
+}
+
+// newOperatorPool builds the pool of payer accounts from the primary operator
+// and any additional accounts listed under hedera.operators.
+func newOperatorPool() (*hedera.OperatorPool, error) {
+	primary, err := hedera.NewOperator(viper.GetString("hedera.operatorId"), viper.GetString("hedera.operatorKey"), viper.GetString("hedera.operatorKeyType"))
+	if err != nil {
+		return nil, err
+	}
+
+	additional, err := hedera.ParseOperators(viper.Get("hedera.operators"))
+	if err != nil {
+		return nil, err
+	}
+
+	minBalance := viper.GetInt64("hedera.operatorMinBalance") * limiter.TinybarsPerHbar
+	return hedera.NewOperatorPool(append([]hedera.Operator{primary}, additional...), viper.GetString("hedera.operatorSelection"), minBalance)
+}
+
+// logOperators logs the key type of every operator and the EVM address an
+// ECDSA key derives.
+func logOperators(log *zap.Logger, pool *hedera.OperatorPool) {
+	for _, operator := range pool.Operators() {
+		log.Info("Operator",
+			zap.String("account", operator.AccountID.String()),
+			zap.String("keyType", operator.KeyType),
+			zap.String("evmAddress", operator.EvmAddress()))
+	}
+}
+
+// consensusHealthConfig reads the consensus node health check settings, which
+// apply to every network.
+func consensusHealthConfig() hedera.ConsensusHealthConfig {
+	return hedera.ConsensusHealthConfig{
+		Interval:       viper.GetDuration("hedera.healthCheckInterval"),
+		BusyCooldown:   viper.GetDuration("hedera.busyNodeCooldown"),
+		ReconnectAfter: viper.GetInt("hedera.reconnectAfterFailedChecks"),
+	}
+}
+
+func mirrorRetryPolicy() hedera.RetryPolicy {
+	return hedera.RetryPolicy{
+		MaxAttempts: viper.GetInt("mirrorNode.retry.maxAttempts"),
+		BaseDelay:   viper.GetDuration("mirrorNode.retry.baseDelay"),
+		MaxDelay:    viper.GetDuration("mirrorNode.retry.maxDelay"),
+	}
+}
+
+// networkConfig is an entry of the networks list: a further Hedera network
+// served by the relay, with its own operator, mirror node and chain ID, and
+// optionally its own gas limits.
+type networkConfig struct {
+	Name    string
+	Hosts   []string
+	ChainID string
+	Hedera  struct {
+		Network         string
+		OperatorID      string
+		OperatorKey     string
+		OperatorKeyType string
+	}
+	MirrorNode struct {
+		BaseURL []string
+		Web3URL []string
+	}
+	Gas struct {
+		BlockGasLimit uint64
+		MaxCallGas    uint64
+	}
+}
+
+// newNetworks creates the clients of the networks served next to the default
+// one. Their mirror node responses are cached in the shared cache under keys
+// prefixed with the network name.
+func newNetworks(log *zap.Logger, cacheService cache.CacheService) ([]http_server.Network, error) {
+	var configs []networkConfig
+	if err := viper.UnmarshalKey("networks", &configs); err != nil {
+		return nil, err
+	}
+
+	minBalance := viper.GetInt64("hedera.operatorMinBalance") * limiter.TinybarsPerHbar
+	networks := make([]http_server.Network, 0, len(configs))
+	for _, config := range configs {
+		networkLog := log.With(zap.String("network", config.Name))
+
+		operator, err := hedera.NewOperator(config.Hedera.OperatorID, config.Hedera.OperatorKey, config.Hedera.OperatorKeyType)
+		if err != nil {
+			return nil, fmt.Errorf("network %s: %w", config.Name, err)
+		}
+		operatorPool, err := hedera.NewOperatorPool([]hedera.Operator{operator}, "", minBalance)
+		if err != nil {
+			return nil, fmt.Errorf("network %s: %w", config.Name, err)
+		}
+		hClient, err := hedera.NewHederaClient(config.Hedera.Network, operatorPool)
+		if err != nil {
+			return nil, fmt.Errorf("network %s: %w", config.Name, err)
+		}
+		hClient.MonitorOperatorBalances(viper.GetDuration("hedera.operatorBalanceCheckInterval"), networkLog)
+		hClient.MonitorConsensusNodes(consensusHealthConfig(), networkLog)
+
+		if len(config.MirrorNode.BaseURL) == 0 {
+			return nil, fmt.Errorf("network %s: no mirror node base URL configured", config.Name)
+		}
+		mClient := hedera.NewMirrorClient(config.MirrorNode.BaseURL, viper.GetInt("mirrorNode.timeoutSeconds"), networkLog, cache.NewPrefixedCache(cacheService, config.Name))
+		mClient.UseWeb3URLs(config.MirrorNode.Web3URL)
+		mClient.MonitorEndpoints(viper.GetDuration("mirrorNode.healthCheckInterval"))
+		mClient.MaxPages = viper.GetInt("mirrorNode.maxPages")
+		mClient.Retry = mirrorRetryPolicy()
+		mClient.CacheTTLs = cacheTTLs()
+		mClient.NotFoundTTL = viper.GetDuration("cache.notFoundTtl")
+		mClient.SchemaAliases = hedera.SchemaAliases(viper.GetStringMapStringSlice("mirrorNode.schemaAliases"))
+		mClient.DetectVersion(context.Background())
+
+		networks = append(networks, http_server.Network{
+			Name:         config.Name,
+			Hosts:        config.Hosts,
+			ChainID:      config.ChainID,
+			HederaClient: hClient,
+			MirrorClient: mClient,
+
+			BlockGasLimit: config.Gas.BlockGasLimit,
+			MaxCallGas:    config.Gas.MaxCallGas,
+		})
+	}
+	return networks, http_server.ValidateNetworks(networks)
+}
+
+// newCacheService bounds the cache by size once cache.maxEntries or
+// cache.maxMemoryMB is set, and only by expiry otherwise.
+func newCacheService() cache.CacheService {
+	defaultExpiration := viper.GetDuration("cache.defaultExpiration")
+	cleanupInterval := viper.GetDuration("cache.cleanupInterval")
+	maxEntries := viper.GetInt("cache.maxEntries")
+	maxBytes := viper.GetInt64("cache.maxMemoryMB") << 20
+
+	if maxEntries > 0 || maxBytes > 0 {
+		return cache.NewLRUCache(defaultExpiration, cleanupInterval, maxEntries, maxBytes)
+	}
+	return cache.NewMemoryCache(defaultExpiration, cleanupInterval)
+}
+
+// cacheTTLs reads the TTL of each cached entity class, falling back to
+// cache.defaultExpiration for the classes not configured.
+func cacheTTLs() cache.TTLs {
+	ttls := make(cache.TTLs, len(cache.TTLClasses))
+	for _, class := range cache.TTLClasses {
+		ttl := viper.GetDuration("cache.ttl." + string(class))
+		if ttl <= 0 {
+			ttl = viper.GetDuration("cache.defaultExpiration")
+		}
+		ttls[class] = ttl
+	}
+	return ttls
+}
+
+// newUsageSink creates the sink selected by apiKeyUsage.sink: a file of JSON
+// lines, a table in a SQL database, or a StatsD server.
+func newUsageSink() (limiter.UsageSink, error) {
+	return limiter.OpenUsageSink(limiter.UsageSinkConfig{
+		Sink:          viper.GetString("apiKeyUsage.sink"),
+		Path:          viper.GetString("apiKeyUsage.path"),
+		Driver:        viper.GetString("apiKeyUsage.driver"),
+		DSN:           viper.GetString("apiKeyUsage.dsn"),
+		Table:         viper.GetString("apiKeyUsage.table"),
+		StatsDAddress: viper.GetString("apiKeyUsage.statsdAddress"),
+		StatsDPrefix:  viper.GetString("apiKeyUsage.statsdPrefix"),
+	})
+}
+
+// newAPIKeyStore creates the API key store selected by apiKeyStore.backend:
+// the apiKeys list in this config (the default), a separate file, or a table
+// in a SQL database.
+func newAPIKeyStore(log *zap.Logger) (limiter.APIKeyStore, error) {
+	return limiter.OpenAPIKeyStore(limiter.APIKeyStoreConfig{
+		Backend:        viper.GetString("apiKeyStore.backend"),
+		Keys:           viper.Get("apiKeys"),
+		Path:           viper.GetString("apiKeyStore.path"),
+		Driver:         viper.GetString("apiKeyStore.driver"),
+		DSN:            viper.GetString("apiKeyStore.dsn"),
+		Table:          viper.GetString("apiKeyStore.table"),
+		ReloadInterval: viper.GetDuration("apiKeyStore.reloadInterval"),
+	}, log)
+}
@@ -0,0 +1,59 @@
+package domain
+
+import "fmt"
+
+// ParseBlockParameter reads a block parameter of a state query. It accepts a
+// block tag, a hex block number or a 32-byte block hash as a string, as well
+// as the EIP-1898 object forms {"blockNumber": "0x.."} and
+// {"blockHash": "0x..", "requireCanonical": bool}. The block is returned in
+// its string form; the value itself is checked later by the binding
+// validators. requireCanonical is accepted but has no effect, as every Hedera
+// block is canonical.
+func ParseBlockParameter(name string, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case map[string]interface{}:
+		return parseBlockObject(name, v)
+	default:
+		return "", fmt.Errorf("%s must be a string or an object", name)
+	}
+}
+
+func parseBlockObject(name string, object map[string]interface{}) (string, error) {
+	for key := range object {
+		if key != "blockHash" && key != "blockNumber" && key != "requireCanonical" {
+			return "", fmt.Errorf("%s has unknown field %q", name, key)
+		}
+	}
+
+	blockHash, hasHash := object["blockHash"]
+	blockNumber, hasNumber := object["blockNumber"]
+
+	switch {
+	case hasHash && hasNumber:
+		return "", fmt.Errorf("%s must specify either blockHash or blockNumber, not both", name)
+	case hasHash:
+		if requireCanonical, ok := object["requireCanonical"]; ok {
+			if _, ok := requireCanonical.(bool); !ok {
+				return "", fmt.Errorf("%s requireCanonical must be a boolean", name)
+			}
+		}
+		hash, ok := blockHash.(string)
+		if !ok {
+			return "", fmt.Errorf("%s blockHash must be a string", name)
+		}
+		return hash, nil
+	case hasNumber:
+		if _, ok := object["requireCanonical"]; ok {
+			return "", fmt.Errorf("%s requireCanonical is only allowed with blockHash", name)
+		}
+		number, ok := blockNumber.(string)
+		if !ok {
+			return "", fmt.Errorf("%s blockNumber must be a string", name)
+		}
+		return number, nil
+	default:
+		return "", fmt.Errorf("%s must specify blockHash or blockNumber", name)
+	}
+}
@@ -7,3 +7,15 @@ const (
 	BlockTagSafe      = "safe"
 	BlockTagFinalized = "finalized"
 )
+
+// HBAR amounts are kept in tinybars. The EVM sees them as weibars, scaled so
+// that one HBAR reads as 10^18 like one ether.
+const (
+	TinybarsPerHbar   = 100_000_000
+	WeibarsPerTinybar = 10_000_000_000
+)
+
+const (
+	CallTracer   = "callTracer"
+	OpcodeLogger = "opcodeLogger"
+)
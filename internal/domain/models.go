@@ -1,6 +1,9 @@
 package domain
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"strings"
+)
 
 type FeeResponse struct {
 	Fees      []Fee  `json:"fees"`
@@ -37,42 +40,12 @@ type Timestamp struct {
 	To   string `json:"to"`
 }
 
-type ContractResults struct {
-	Address              string   `json:"address"`
-	Amount               int      `json:"amount"`
-	Bloom                string   `json:"bloom"`
-	CallResult           string   `json:"call_result"`
-	ContractID           string   `json:"contract_id"`
-	CreatedContractIDs   []string `json:"created_contract_ids"`
-	ErrorMessage         *string  `json:"error_message"`
-	From                 string   `json:"from"`
-	FunctionParameters   string   `json:"function_parameters"`
-	GasConsumed          int64    `json:"gas_consumed"`
-	GasLimit             int64    `json:"gas_limit"`
-	GasUsed              int64    `json:"gas_used"`
-	Timestamp            string   `json:"timestamp"`
-	To                   string   `json:"to"`
-	Hash                 string   `json:"hash"`
-	BlockHash            string   `json:"block_hash"`
-	BlockNumber          int64    `json:"block_number"`
-	Result               string   `json:"result"`
-	TransactionIndex     int      `json:"transaction_index"`
-	Status               string   `json:"status"`
-	FailedInitcode       *string  `json:"failed_initcode"`
-	AccessList           string   `json:"access_list"`
-	BlockGasUsed         int64    `json:"block_gas_used"`
-	ChainID              string   `json:"chain_id"`
-	GasPrice             string   `json:"gas_price"`
-	MaxFeePerGas         string   `json:"max_fee_per_gas"`
-	MaxPriorityFeePerGas string   `json:"max_priority_fee_per_gas"`
-	R                    string   `json:"r"`
-	S                    string   `json:"s"`
-	Type                 int      `json:"type"`
-	V                    int      `json:"v"`
-	Nonce                int64    `json:"nonce"`
-}
-
-type ContractResultResponse struct {
+// ContractResult is a contract result of the mirror node, the one model of an
+// Ethereum transaction shared by blocks, transactions and receipts. The
+// MirrorClient normalizes it when decoding, so hashes are 32 bytes whichever
+// endpoint it came from. Type is nil for transactions that were not sent as
+// Ethereum transactions.
+type ContractResult struct {
 	Address              string          `json:"address"`
 	Amount               int             `json:"amount"`
 	Bloom                string          `json:"bloom"`
@@ -106,13 +79,31 @@ type ContractResultResponse struct {
 	Type                 *int            `json:"type"`
 	V                    int             `json:"v"`
 	Nonce                int64           `json:"nonce"`
-	StateChanges         []struct {
-		Address      string `json:"address"`
-		ContractID   string `json:"contract_id"`
-		Slot         string `json:"slot"`
-		ValueRead    string `json:"value_read"`
-		ValueWritten string `json:"value_written"`
-	} `json:"state_changes"`
+	StateChanges         []StateChange   `json:"state_changes"`
+}
+
+// TxType returns the Ethereum transaction type, treating a missing type as
+// legacy.
+func (r ContractResult) TxType() int {
+	if r.Type == nil {
+		return 0
+	}
+	return *r.Type
+}
+
+type ContractResultsResponse struct {
+	Results []ContractResult `json:"results"`
+	Links   struct {
+		Next *string `json:"next"`
+	} `json:"links"`
+}
+
+type StateChange struct {
+	Address      string `json:"address"`
+	ContractID   string `json:"contract_id"`
+	Slot         string `json:"slot"`
+	ValueRead    string `json:"value_read"`
+	ValueWritten string `json:"value_written"`
 }
 
 type MirroNodeLogs struct {
@@ -194,7 +185,7 @@ type LogParams struct {
 	FromBlock string
 	ToBlock   string
 	Address   []string
-	Topics    []string
+	Topics    TopicFilter
 }
 
 type ContractResultsLogResponse struct {
@@ -246,13 +237,13 @@ type TokenResponse struct {
 	AutoRenewPeriod   *string            `json:"auto_renew_period"`
 	CreatedTimestamp  string             `json:"created_timestamp"`
 	Deleted           bool               `json:"deleted"`
-	Decimals          int                `json:"decimals"`
+	Decimals          json.Number        `json:"decimals"`
 	ExpiryTimestamp   *string            `json:"expiry_timestamp"`
 	FreezeDefault     bool               `json:"freeze_default"`
 	FreezeKey         ProtobufEncodedKey `json:"freeze_key"`
-	InitialSupply     int                `json:"initial_supply"`
+	InitialSupply     json.Number        `json:"initial_supply"`
 	KycKey            ProtobufEncodedKey `json:"kyc_key"`
-	MaxSupply         int64              `json:"max_supply"`
+	MaxSupply         json.Number        `json:"max_supply"`
 	Memo              string             `json:"memo"`
 	ModifiedTimestamp string             `json:"modified_timestamp"`
 	Name              string             `json:"name"`
@@ -262,13 +253,85 @@ type TokenResponse struct {
 	SupplyType        string             `json:"supply_type"`
 	Symbol            string             `json:"symbol"`
 	TokenId           string             `json:"token_id"`
-	TotalSupply       int                `json:"total_supply"`
+	TotalSupply       json.Number        `json:"total_supply"`
 	TreasuryAccountId string             `json:"treasury_account_id"`
 	Type              string             `json:"type"`
 	WipeKey           ProtobufEncodedKey `json:"wipe_key"`
 	CustomFees        CustomFees         `json:"custom_fees"`
 }
 
+// ExchangeRateResponse is the HBAR to USD exchange rate of the network, as
+// returned by the mirror node under /api/v1/network/exchangerate.
+type ExchangeRateResponse struct {
+	CurrentRate Rate   `json:"current_rate"`
+	NextRate    Rate   `json:"next_rate"`
+	Timestamp   string `json:"timestamp"`
+}
+
+// Rate prices HbarEquivalent HBAR at CentEquivalent US cents until the
+// ExpirationTime in seconds since the epoch.
+type Rate struct {
+	CentEquivalent int64 `json:"cent_equivalent"`
+	ExpirationTime int64 `json:"expiration_time"`
+	HbarEquivalent int64 `json:"hbar_equivalent"`
+}
+
+// TokenRelationship is a token associated with an account, as listed by the
+// mirror node under /api/v1/accounts/{id}/tokens.
+type TokenRelationship struct {
+	AutomaticAssociation bool   `json:"automatic_association"`
+	Balance              int64  `json:"balance"`
+	CreatedTimestamp     string `json:"created_timestamp"`
+	Decimals             int64  `json:"decimals"`
+	FreezeStatus         string `json:"freeze_status"`
+	KycStatus            string `json:"kyc_status"`
+	TokenId              string `json:"token_id"`
+}
+
+// TokenAllowance is an allowance an owner granted a spender over a fungible
+// token, as listed by the mirror node under
+// /api/v1/accounts/{id}/allowances/tokens. Amount is what is left of
+// AmountGranted.
+type TokenAllowance struct {
+	Amount        int64     `json:"amount"`
+	AmountGranted int64     `json:"amount_granted"`
+	Owner         string    `json:"owner"`
+	Spender       string    `json:"spender"`
+	Timestamp     Timestamp `json:"timestamp"`
+	TokenId       string    `json:"token_id"`
+}
+
+type TokenAllowancesResponse struct {
+	Allowances []TokenAllowance `json:"allowances"`
+	Links      struct {
+		Next *string `json:"next"`
+	} `json:"links"`
+}
+
+type TokenRelationshipsResponse struct {
+	Tokens []TokenRelationship `json:"tokens"`
+	Links  struct {
+		Next *string `json:"next"`
+	} `json:"links"`
+}
+
+// HbarTransfer is an hbar amount, in tinybars, moved to (positive) or from
+// (negative) an account by a transaction.
+type HbarTransfer struct {
+	Account string `json:"account"`
+	Amount  int64  `json:"amount"`
+}
+
+type TransactionsResponse struct {
+	Transactions []struct {
+		ConsensusTimestamp string         `json:"consensus_timestamp"`
+		Transfers          []HbarTransfer `json:"transfers"`
+	} `json:"transactions"`
+	Links struct {
+		Next *string `json:"next"`
+	} `json:"links"`
+}
+
 type ProtobufEncodedKey struct {
 	Type string `json:"_type"`
 	Key  string `json:"key"`
@@ -300,15 +363,76 @@ type Amount struct {
 	Denominator int `json:"denominator"`
 }
 
+// MirrorNodeErrorResponse is the body of a non-2xx mirror node response
+type MirrorNodeErrorResponse struct {
+	Status struct {
+		Messages []MirrorNodeErrorMessage `json:"messages"`
+	} `json:"_status"`
+}
+
+type MirrorNodeErrorMessage struct {
+	Message string `json:"message"`
+	Detail  string `json:"detail"`
+	Data    string `json:"data"`
+}
+
+type ContractActionsResponse struct {
+	Actions []ContractAction `json:"actions"`
+	Links   struct {
+		Next *string `json:"next"`
+	} `json:"links"`
+}
+
+type ContractAction struct {
+	CallDepth         int    `json:"call_depth"`
+	CallOperationType string `json:"call_operation_type"`
+	CallType          string `json:"call_type"`
+	Caller            string `json:"caller"`
+	CallerType        string `json:"caller_type"`
+	From              string `json:"from"`
+	Gas               int64  `json:"gas"`
+	GasUsed           int64  `json:"gas_used"`
+	Index             int    `json:"index"`
+	Input             string `json:"input"`
+	Recipient         string `json:"recipient"`
+	RecipientType     string `json:"recipient_type"`
+	ResultData        string `json:"result_data"`
+	ResultDataType    string `json:"result_data_type"`
+	Timestamp         string `json:"timestamp"`
+	To                string `json:"to"`
+	Value             int64  `json:"value"`
+}
+
+type OpcodesResponse struct {
+	Address     string   `json:"address"`
+	ContractID  string   `json:"contract_id"`
+	Gas         int64    `json:"gas"`
+	Failed      bool     `json:"failed"`
+	ReturnValue string   `json:"return_value"`
+	Opcodes     []Opcode `json:"opcodes"`
+}
+
+type Opcode struct {
+	Pc      int64             `json:"pc"`
+	Op      string            `json:"op"`
+	Gas     int64             `json:"gas"`
+	GasCost int64             `json:"gas_cost"`
+	Depth   int64             `json:"depth"`
+	Stack   []string          `json:"stack"`
+	Memory  []string          `json:"memory"`
+	Storage map[string]string `json:"storage"`
+	Reason  *string           `json:"reason"`
+}
+
 type Filter struct {
-	ID              string   `json:"id"`
-	Type            string   `json:"type"`
-	BlockAtCreation string   `json:"blockAtCreation"`
-	FromBlock       string   `json:"fromBlock"`
-	ToBlock         string   `json:"toBlock"`
-	Address         []string `json:"address"`
-	Topics          []string `json:"topics"`
-	LastQueried     string   `json:"lastQueried"`
+	ID              string      `json:"id"`
+	Type            string      `json:"type"`
+	BlockAtCreation string      `json:"blockAtCreation"`
+	FromBlock       string      `json:"fromBlock"`
+	ToBlock         string      `json:"toBlock"`
+	Address         []string    `json:"address"`
+	Topics          TopicFilter `json:"topics"`
+	LastQueried     string      `json:"lastQueried"`
 }
 
 type Address []string
@@ -330,3 +454,75 @@ func (a *Address) UnmarshalJSON(data []byte) error {
 	*a = Address(addressArray)
 	return nil
 }
+
+// TopicFilter selects logs by topic, one entry per topic position. A log
+// matches when, at every position, its topic is one of the alternatives listed
+// there; a position without alternatives matches any topic.
+type TopicFilter [][]string
+
+// UnmarshalJSON reads topics the way eth_getLogs takes them: each position
+// may be null, a topic or an array of alternative topics.
+func (f *TopicFilter) UnmarshalJSON(data []byte) error {
+	var positions []json.RawMessage
+	if err := json.Unmarshal(data, &positions); err != nil {
+		return err
+	}
+	if positions == nil {
+		*f = nil
+		return nil
+	}
+
+	filter := make(TopicFilter, len(positions))
+	for i, position := range positions {
+		switch {
+		case string(position) == "null":
+		case len(position) > 0 && position[0] == '"':
+			var topic string
+			if err := json.Unmarshal(position, &topic); err != nil {
+				return err
+			}
+			filter[i] = []string{topic}
+		default:
+			if err := json.Unmarshal(position, &filter[i]); err != nil {
+				return err
+			}
+		}
+	}
+	*f = filter
+	return nil
+}
+
+// Matches reports whether a log with topics passes the filter. Topics are
+// compared regardless of letter case.
+func (f TopicFilter) Matches(topics []string) bool {
+	for i, alternatives := range f {
+		if len(alternatives) == 0 {
+			continue
+		}
+		if i >= len(topics) {
+			return false
+		}
+
+		found := false
+		for _, topic := range alternatives {
+			if strings.EqualFold(topic, topics[i]) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// HasAlternatives reports whether any position lists more than one topic.
+func (f TopicFilter) HasAlternatives() bool {
+	for _, alternatives := range f {
+		if len(alternatives) > 1 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// maxParamValueLength caps how much of an invalid value a ParamError quotes.
+const maxParamValueLength = 64
+
+// Descriptions of the values positional parameters expect.
+const (
+	expectedAddress         = "Expected 0x prefixed string representing the address (20 bytes)"
+	expectedBlockHash       = "Expected 0x prefixed string representing the hash (32 bytes) of a block"
+	expectedTransactionHash = "Expected 0x prefixed string representing the hash (32 bytes) of a transaction"
+	expectedTransactionID   = "Expected 0x prefixed string representing the hash (32 bytes) of a transaction, or a Hedera transaction ID"
+	expectedBlockNumber     = "Expected 0x prefixed hexadecimal block number, or the string \"latest\", \"earliest\", \"pending\", \"safe\" or \"finalized\""
+	expectedBlock           = "Expected 0x prefixed hexadecimal block number, a block hash (32 bytes), or the string \"latest\", \"earliest\", \"pending\", \"safe\" or \"finalized\""
+	expectedHexNumber       = "Expected 0x prefixed hexadecimal number"
+	expectedHexData         = "Expected 0x prefixed hexadecimal string"
+	expectedBoolean         = "Expected boolean type"
+	expectedCallObject      = "Expected a transaction call object"
+	expectedFilterObject    = "Expected a filter object"
+	expectedFilterID        = "Expected 0x prefixed hexadecimal filter ID"
+	expectedStorageKeys     = "Expected an array of 0x prefixed hexadecimal storage keys"
+	expectedPercentiles     = "Expected an array of reward percentiles"
+	expectedPercentileRange = "Expected reward percentiles between 0 and 100"
+	expectedPercentileOrder = "Expected reward percentiles in increasing order"
+	expectedTracerOptions   = "Expected a tracer options object"
+)
+
+// ParamError reports an invalid positional parameter: its index, what the
+// parameter should have been and the value received.
+type ParamError struct {
+	Index    int
+	Expected string
+	Value    interface{}
+}
+
+// NewParamError creates the error of parameter index, which is expected to
+// be as described by expected but is value.
+func NewParamError(index int, expected string, value interface{}) *ParamError {
+	return &ParamError{Index: index, Expected: expected, Value: value}
+}
+
+// Error implements the error interface
+func (e *ParamError) Error() string {
+	return fmt.Sprintf("Invalid parameter %d: %s, value: %s", e.Index, e.Expected, formatParamValue(e.Value))
+}
+
+// invalidParam reports err, found while reading parameter index, as a
+// ParamError unless it is one already.
+func invalidParam(index int, err error, value interface{}) error {
+	var paramErr *ParamError
+	if errors.As(err, &paramErr) {
+		return err
+	}
+	return NewParamError(index, err.Error(), value)
+}
+
+// formatParamValue renders value as the client sent it, cut to
+// maxParamValueLength characters so that large payloads are not echoed back.
+func formatParamValue(value interface{}) string {
+	var text string
+	switch v := value.(type) {
+	case string:
+		text = v
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			text = fmt.Sprintf("%v", v)
+		} else {
+			text = string(encoded)
+		}
+	}
+	if len(text) > maxParamValueLength {
+		text = text[:maxParamValueLength] + "..."
+	}
+	return text
+}
+
+// NewValidationParamError converts the error of validating params with the
+// binding validator to a ParamError for the first invalid field, identifying
+// the positional parameter the field was read from. Other errors are returned
+// as they are.
+func NewValidationParamError(params RPCParams, err error) error {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) == 0 {
+		return err
+	}
+	fieldErr := validationErrs[0]
+
+	// The namespace is the struct name followed by the path to the field
+	path := strings.Split(fieldErr.StructNamespace(), ".")
+	if len(path) < 2 {
+		return err
+	}
+	field, _, element := strings.Cut(path[1], "[")
+	index, inObject := paramIndex(reflect.TypeOf(params), field)
+	if index < 0 {
+		return err
+	}
+	expected := fieldExpectation(fieldErr)
+	if inObject || element || len(path) > 2 {
+		expected = fmt.Sprintf("%s for %s", expected, lowerFirst(fieldErr.StructField()))
+	}
+	return NewParamError(index, expected, fieldErr.Value())
+}
+
+// filterParamError converts the error of validating a filter object, read from
+// parameter index, to a ParamError naming the invalid property.
+func filterParamError(index int, err error, value interface{}) error {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) == 0 {
+		return invalidParam(index, err, value)
+	}
+	fieldErr := validationErrs[0]
+	expected := fmt.Sprintf("%s for %s", fieldExpectation(fieldErr), lowerFirst(fieldErr.StructField()))
+	return NewParamError(index, expected, fieldErr.Value())
+}
+
+// paramIndex returns the index of the positional parameter the field of the
+// params struct type is read from. Each field is read from its own
+// parameter, except fields sharing an rpcparam object name, which are read
+// from one object parameter; inObject reports such fields.
+func paramIndex(paramsType reflect.Type, field string) (index int, inObject bool) {
+	if paramsType.Kind() == reflect.Pointer {
+		paramsType = paramsType.Elem()
+	}
+	if paramsType.Kind() != reflect.Struct {
+		return -1, false
+	}
+
+	objects := make(map[string]int)
+	next := 0
+	for i := 0; i < paramsType.NumField(); i++ {
+		structField := paramsType.Field(i)
+		if !structField.IsExported() || structField.Tag.Get("json") == "-" {
+			continue
+		}
+
+		position := next
+		object, _, _ := strings.Cut(structField.Tag.Get("rpcparam"), ",")
+		if object != "" {
+			if seen, ok := objects[object]; ok {
+				position = seen
+			} else {
+				objects[object] = next
+				next++
+			}
+		} else {
+			next++
+		}
+
+		if structField.Name == field {
+			return position, object != ""
+		}
+	}
+	return -1, false
+}
+
+// fieldExpectation describes the value the validation rule that failed
+// requires.
+func fieldExpectation(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "Expected a value"
+	case "eth_address":
+		return expectedAddress
+	case "eth_address_or_array":
+		return "Expected an address or an array of addresses"
+	case "block_number_or_tag":
+		return expectedBlockNumber
+	case "block_number_tag_or_hash":
+		return expectedBlock
+	case "transaction_hash_or_id":
+		return expectedTransactionID
+	case "hexadecimal", "startswith":
+		return expectedHexData
+	case "len":
+		if fieldErr.Param() == "66" {
+			return "Expected 0x prefixed string representing a hash (32 bytes)"
+		}
+		return fmt.Sprintf("Expected a length of %s", fieldErr.Param())
+	case "max":
+		return fmt.Sprintf("Expected at most %s entries", fieldErr.Param())
+	case "oneof":
+		return fmt.Sprintf("Expected one of %s", strings.Join(strings.Fields(fieldErr.Param()), ", "))
+	default:
+		return fmt.Sprintf("Expected a value satisfying %s", fieldErr.Tag())
+	}
+}
+
+func lowerFirst(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
@@ -1,9 +1,15 @@
 package domain
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // Standard JSON-RPC 2.0 error codes
 const (
+	// Contract revert (3): Execution reverted, the error data holds the revert payload
+	ContractRevert = 3
+
 	// Parse error (-32700): Invalid JSON was received by the server.
 	ParseError = -32700
 
@@ -25,6 +31,18 @@ const (
 	// Filter not found (-32001): Filter not found
 	FilterNotFound = -32001
 
+	// Resource not found (-32002): Requested resource not found
+	ResourceNotFound = -32002
+
+	// Intrinsic gas too low (-32003): The gas limit does not cover the intrinsic gas of the transaction
+	IntrinsicGasTooLow = -32003
+
+	// Gas limit too high (-32005): The gas limit exceeds the network maximum
+	GasLimitTooHigh = -32005
+
+	// Request timeout (-32010): The mirror node did not answer in time
+	RequestTimeout = -32010
+
 	// Execution error (-32015): Transaction execution error
 	ExecutionError = -32015
 
@@ -37,17 +55,42 @@ const (
 	// Insufficient funds (-32018): Insufficient funds for transfer
 	InsufficientFunds = -32018
 
+	// Mirror node upstream failure (-32020): The mirror node failed or could not be reached
+	MirrorNodeUpstreamFail = -32020
+
+	// Oversized data (-32201): The transaction exceeds the maximum transaction size
+	OversizedData = -32201
+
+	// Unsupported transaction type (-32611): The transaction type cannot be submitted to Hedera
+	UnsupportedTransactionType = -32611
+
+	// Method not allowed (-32604): The method is disabled for the API key tier of the caller
+	MethodNotAllowed = -32604
+
+	// Mirror node rate limited (-32605): The mirror node throttled the relay
+	MirrorNodeRateLimited = -32605
+
+	// HBAR rate limit exceeded (-32606): The HBAR spending budget is exhausted
+	HbarRateLimitExceeded = -32606
+
+	// Request rate limit exceeded (-32029): The API key made more requests than its tier allows
+	RequestRateLimitExceeded = -32029
+
 	// Invalid block range (-39013): Invalid block range
 	InvalidBlockRange = -39013
 
 	// Timestamp range too large (-32004): The provided fromBlock and toBlock contain timestamps that exceed the maximum allowed duration of 7 days (604800 seconds)
 	InvalidTimestampRange = -32004
+
+	// Limit exceeded (-32005): The query matched more results than the relay is willing to return
+	LimitExceeded = -32005
 )
 
 // RPCError represents a JSON-RPC 2.0 error
 type RPCError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
 }
 
 // Error implements the error interface
@@ -92,22 +135,64 @@ func NewExecutionError(msg string) *RPCError {
 	return NewRPCError(ExecutionError, msg)
 }
 
-func NewNonceTooLowError() *RPCError {
-	return NewRPCError(NonceTooLow, "nonce too low")
+func NewNonceTooLowError(provided uint64, current int64) *RPCError {
+	return NewRPCError(NonceTooLow, fmt.Sprintf("Nonce too low. Provided nonce: %d, current nonce: %d", provided, current))
 }
 
-func NewGasPriceTooLowError() *RPCError {
-	return NewRPCError(GasPriceTooLow, "gas price too low")
+func NewGasPriceTooLowError(gasPrice, minGasPrice string) *RPCError {
+	return NewRPCError(GasPriceTooLow, fmt.Sprintf("Gas price '%s' is below configured minimum gas price '%s'", gasPrice, minGasPrice))
 }
 
 func NewInsufficientFundsError() *RPCError {
 	return NewRPCError(InsufficientFunds, "insufficient funds for transfer")
 }
 
+func NewUnsupportedChainIDError(chainID, expectedChainID string) *RPCError {
+	return NewRPCError(ServerError, fmt.Sprintf("ChainId (%s) not supported. The correct chainId is %s", chainID, expectedChainID))
+}
+
+func NewIntrinsicGasTooLowError(gasLimit, intrinsicGas uint64) *RPCError {
+	return NewRPCError(IntrinsicGasTooLow, fmt.Sprintf("Transaction gas limit provided '%d' is insufficient of intrinsic gas required '%d'", gasLimit, intrinsicGas))
+}
+
+func NewGasLimitTooHighError(gasLimit, maxGas uint64) *RPCError {
+	return NewRPCError(GasLimitTooHigh, fmt.Sprintf("Transaction gas limit '%d' exceeds max gas per sec limit '%d'", gasLimit, maxGas))
+}
+
+// NewGasAllowanceExceededError is returned by eth_call and eth_estimateGas for
+// calls given or needing more gas than the cap of the relay.
+func NewGasAllowanceExceededError(gasCap uint64) *RPCError {
+	return NewRPCError(ServerError, fmt.Sprintf("gas required exceeds allowance (%d)", gasCap))
+}
+
+func NewValueTooLowError() *RPCError {
+	return NewRPCError(InvalidParams, "Value below 10_000_000_000 wei which is 1 tinybar")
+}
+
+func NewOversizedDataError(size, limit int) *RPCError {
+	return NewRPCError(OversizedData, fmt.Sprintf("Oversized data: transaction size %d, transaction limit %d", size, limit))
+}
+
+func NewUnsupportedTransactionTypeError(txType uint8) *RPCError {
+	return NewRPCError(UnsupportedTransactionType, fmt.Sprintf("Transaction type %d not supported", txType))
+}
+
+func NewReceiverSignatureRequiredError() *RPCError {
+	return NewRPCError(ServerError, "Operation is not supported when receiver's signature is enabled.")
+}
+
 func NewUnsupportedMethodError(method string) *RPCError {
 	return NewRPCError(MethodNotFound, fmt.Sprintf("Method not supported: %s", method))
 }
 
+func NewAccountMethodUnsupportedError(method string) *RPCError {
+	return NewRPCError(MethodNotFound, fmt.Sprintf("Method not supported: %s. The relay does not hold accounts or private keys; sign transactions in the wallet and submit them with eth_sendRawTransaction", method))
+}
+
+func NewMethodNotAllowedError(method, tier string) *RPCError {
+	return NewRPCError(MethodNotAllowed, fmt.Sprintf("Method %s is not allowed for the %s tier", method, tier))
+}
+
 func NewInvalidBlockRangeError() *RPCError {
 	return NewRPCError(InvalidBlockRange, "Invalid block range")
 }
@@ -124,6 +209,84 @@ func NewRangeTooLarge(blockRange int) *RPCError {
 	return NewRPCError(ServerError, fmt.Sprintf("Exceeded maximum block range: %d", blockRange))
 }
 
+func NewQueryLimitExceededError(maxResults int) *RPCError {
+	return NewRPCError(LimitExceeded, fmt.Sprintf("query returned more than %d results", maxResults))
+}
+
+// NewPageLimitExceededError is returned instead of incomplete results when a
+// query spans more mirror node pages than maxPages.
+func NewPageLimitExceededError(maxPages int) *RPCError {
+	return NewRPCError(LimitExceeded, fmt.Sprintf("query spans more than %d mirror node pages; narrow the block range or filter", maxPages))
+}
+
+func NewConcurrencyLimitExceededError(method string) *RPCError {
+	return NewRPCError(LimitExceeded, fmt.Sprintf("Too many concurrent %s requests, try again later", method))
+}
+
+// NewAdmissionRejectedError is returned for requests the admission queue turns
+// away, before their method is known.
+func NewAdmissionRejectedError() *RPCError {
+	return NewRPCError(LimitExceeded, "server overloaded, try again later")
+}
+
+// NewMethodTimeoutError is returned for calls that ran past the timeout of
+// their method, after elapsed.
+func NewMethodTimeoutError(method string, elapsed time.Duration) *RPCError {
+	return NewRPCError(InternalError, fmt.Sprintf("Request timeout: %s did not complete within its budget, %s elapsed", method, elapsed.Round(time.Millisecond)))
+}
+
+func NewServerOverloadedError(method string) *RPCError {
+	return NewRPCError(LimitExceeded, fmt.Sprintf("Relay overloaded, %s requests are temporarily rejected, try again later", method))
+}
+
+// NewSystemContractCallError is returned for eth_call requests of a Hedera
+// system contract function that cannot be answered, saying why.
+func NewSystemContractCallError(contract, address, selector, reason string) *RPCError {
+	return NewRPCError(ServerError, fmt.Sprintf("Cannot call function 0x%s of the %s system contract at %s: %s", selector, contract, address, reason))
+}
+
 func NewUnsupportedJSONRPCMethodError() *RPCError {
 	return NewRPCError(MethodNotFound, "Unsupported JSON-RPC method")
 }
+
+func NewResourceNotFoundError(msg string) *RPCError {
+	return NewRPCError(ResourceNotFound, fmt.Sprintf("Requested resource not found. %s", msg))
+}
+
+func NewRequestTimeoutError() *RPCError {
+	return NewRPCError(RequestTimeout, "Request timeout. The mirror node did not respond in time")
+}
+
+func NewMirrorNodeUpstreamFailError() *RPCError {
+	return NewRPCError(MirrorNodeUpstreamFail, "Mirror node upstream failure")
+}
+
+func NewMirrorNodeRateLimitedError() *RPCError {
+	return NewRPCError(MirrorNodeRateLimited, "Mirror node rate limit exceeded")
+}
+
+func NewHbarRateLimitExceededError() *RPCError {
+	return NewRPCError(HbarRateLimitExceeded, "HBAR Rate limit exceeded")
+}
+
+// NewRequestRateLimitExceededError creates the error returned when an API key
+// used up its limit of requests per minute and may try again in retryAfter
+// seconds.
+func NewRequestRateLimitExceededError(limit, retryAfter int) *RPCError {
+	return NewRPCError(RequestRateLimitExceeded, fmt.Sprintf("Rate limit exceeded: %d requests per minute, retry in %d seconds", limit, retryAfter))
+}
+
+// NewContractRevertError creates the "execution reverted" error returned when a
+// call reverts. data is the raw revert payload, reason its decoded message.
+func NewContractRevertError(reason, data string) *RPCError {
+	message := "execution reverted"
+	if reason != "" {
+		message = fmt.Sprintf("%s: %s", message, reason)
+	}
+
+	return &RPCError{
+		Code:    ContractRevert,
+		Message: message,
+		Data:    data,
+	}
+}
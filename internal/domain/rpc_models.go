@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 // Block represents an Ethereum-compatible block structure
 type Block struct {
 	Number           *string       `json:"number"`           // The block number (hex)
@@ -57,6 +62,87 @@ type Transaction1559 struct {
 	MaxFeePerGas         string            `json:"maxFeePerGas"`
 }
 
+// Kinds of transactions recorded in a CachedTransaction.
+const (
+	TransactionKindLegacy  = "legacy"
+	TransactionKind2930    = "eip2930"
+	TransactionKind1559    = "eip1559"
+	transactionKindUnknown = ""
+)
+
+// CachedTransaction stores a transaction in the cache together with its
+// concrete type, which decoding it into an interface{} would lose. A
+// transaction read back from the cache is a Transaction, Transaction2930 or
+// Transaction1559 again, so it encodes to the same JSON as a fresh one.
+type CachedTransaction struct {
+	Transaction interface{}
+}
+
+type cachedTransactionEnvelope struct {
+	Kind        string          `json:"kind"`
+	Transaction json.RawMessage `json:"transaction"`
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (c CachedTransaction) MarshalJSON() ([]byte, error) {
+	kind := transactionKind(c.Transaction)
+	if kind == transactionKindUnknown {
+		return nil, fmt.Errorf("cannot cache a transaction of type %T", c.Transaction)
+	}
+	transaction, err := json.Marshal(c.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(cachedTransactionEnvelope{Kind: kind, Transaction: transaction})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Entries without a
+// known kind, such as transactions cached before the kind was recorded, fail
+// to decode and are fetched again.
+func (c *CachedTransaction) UnmarshalJSON(data []byte) error {
+	var envelope cachedTransactionEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return err
+	}
+
+	switch envelope.Kind {
+	case TransactionKindLegacy:
+		var tx Transaction
+		if err := json.Unmarshal(envelope.Transaction, &tx); err != nil {
+			return err
+		}
+		c.Transaction = tx
+	case TransactionKind2930:
+		var tx Transaction2930
+		if err := json.Unmarshal(envelope.Transaction, &tx); err != nil {
+			return err
+		}
+		c.Transaction = tx
+	case TransactionKind1559:
+		var tx Transaction1559
+		if err := json.Unmarshal(envelope.Transaction, &tx); err != nil {
+			return err
+		}
+		c.Transaction = tx
+	default:
+		return fmt.Errorf("unknown cached transaction kind %q", envelope.Kind)
+	}
+	return nil
+}
+
+func transactionKind(transaction interface{}) string {
+	switch transaction.(type) {
+	case Transaction, *Transaction:
+		return TransactionKindLegacy
+	case Transaction2930, *Transaction2930:
+		return TransactionKind2930
+	case Transaction1559, *Transaction1559:
+		return TransactionKind1559
+	default:
+		return transactionKindUnknown
+	}
+}
+
 // AccessListEntry represents an entry in the access list
 type AccessListEntry struct {
 	Address     string   `json:"address"`
@@ -106,7 +192,7 @@ type Log struct {
 type TransactionReceipt struct {
 	BlockHash         string  `json:"blockHash"`
 	BlockNumber       string  `json:"blockNumber"`
-	ContractAddress   string  `json:"contractAddress"`
+	ContractAddress   *string `json:"contractAddress"`
 	CumulativeGasUsed string  `json:"cumulativeGasUsed"`
 	EffectiveGasPrice string  `json:"effectiveGasPrice"`
 	From              string  `json:"from"`
@@ -115,9 +201,123 @@ type TransactionReceipt struct {
 	LogsBloom         string  `json:"logsBloom"`
 	Root              string  `json:"root"`
 	Status            string  `json:"status"`
-	To                string  `json:"to"`
+	To                *string `json:"to"`
 	TransactionHash   string  `json:"transactionHash"`
 	TransactionIndex  string  `json:"transactionIndex"`
 	Type              *string `json:"type"`
 	RevertReason      string  `json:"revertReason,omitempty"`
 }
+
+// AccountProof is the result of eth_getProof
+type AccountProof struct {
+	Address      string         `json:"address"`
+	AccountProof []string       `json:"accountProof"`
+	Balance      string         `json:"balance"`
+	CodeHash     string         `json:"codeHash"`
+	Nonce        string         `json:"nonce"`
+	StorageHash  string         `json:"storageHash"`
+	StorageProof []StorageProof `json:"storageProof"`
+}
+
+type StorageProof struct {
+	Key   string   `json:"key"`
+	Value string   `json:"value"`
+	Proof []string `json:"proof"`
+}
+
+// SyncingStatus is the result of eth_syncing while the mirror node lags behind
+type SyncingStatus struct {
+	StartingBlock string `json:"startingBlock"`
+	CurrentBlock  string `json:"currentBlock"`
+	HighestBlock  string `json:"highestBlock"`
+}
+
+// AccessListResult is the result of eth_createAccessList
+type AccessListResult struct {
+	AccessList []AccessListEntry `json:"accessList"`
+	GasUsed    string            `json:"gasUsed"`
+}
+
+// CallTrace is the result of debug_traceTransaction with the callTracer
+type CallTrace struct {
+	Type         string       `json:"type"`
+	From         string       `json:"from"`
+	To           string       `json:"to"`
+	Value        string       `json:"value"`
+	Gas          string       `json:"gas"`
+	GasUsed      string       `json:"gasUsed"`
+	Input        string       `json:"input"`
+	Output       string       `json:"output"`
+	Error        string       `json:"error,omitempty"`
+	RevertReason string       `json:"revertReason,omitempty"`
+	Calls        []*CallTrace `json:"calls,omitempty"`
+}
+
+// SupportedMethods is the result of hederium_supportedMethods
+type SupportedMethods struct {
+	Supported   []string `json:"supported"`
+	Unsupported []string `json:"unsupported"`
+}
+
+// ExchangeRate is the result of hederium_getExchangeRate: the current HBAR to
+// USD rate of the network, valid until ExpirationTime in seconds since the
+// epoch.
+type ExchangeRate struct {
+	HbarEquivalent int64   `json:"hbarEquivalent"`
+	CentEquivalent int64   `json:"centEquivalent"`
+	ExpirationTime int64   `json:"expirationTime"`
+	UsdPerHbar     float64 `json:"usdPerHbar"`
+}
+
+// TokenBalance is an entry of the result of hedera_getTokenBalances: a token
+// the account is associated with and its balance in the smallest unit of the
+// token, or the number of serials held for non-fungible tokens.
+type TokenBalance struct {
+	Token                string `json:"token"`
+	TokenID              string `json:"tokenId"`
+	Balance              string `json:"balance"`
+	Decimals             string `json:"decimals"`
+	AutomaticAssociation bool   `json:"automaticAssociation"`
+	FreezeStatus         string `json:"freezeStatus"`
+	KycStatus            string `json:"kycStatus"`
+}
+
+// TokenInfo is the result of hedera_getTokenInfo. Supplies are in the
+// smallest unit of the token; a MaxSupply of 0x0 means the supply is
+// unbounded.
+type TokenInfo struct {
+	Address           string `json:"address"`
+	TokenID           string `json:"tokenId"`
+	Name              string `json:"name"`
+	Symbol            string `json:"symbol"`
+	Decimals          string `json:"decimals"`
+	TotalSupply       string `json:"totalSupply"`
+	MaxSupply         string `json:"maxSupply"`
+	Type              string `json:"type"`
+	SupplyType        string `json:"supplyType"`
+	TreasuryAccountID string `json:"treasuryAccountId"`
+	Memo              string `json:"memo"`
+	PauseStatus       string `json:"pauseStatus"`
+	FreezeDefault     bool   `json:"freezeDefault"`
+	Deleted           bool   `json:"deleted"`
+}
+
+// OpcodeTrace is the result of debug_traceTransaction with the opcodeLogger
+type OpcodeTrace struct {
+	Gas         int64       `json:"gas"`
+	Failed      bool        `json:"failed"`
+	ReturnValue string      `json:"returnValue"`
+	StructLogs  []StructLog `json:"structLogs"`
+}
+
+type StructLog struct {
+	Pc      int64             `json:"pc"`
+	Op      string            `json:"op"`
+	Gas     int64             `json:"gas"`
+	GasCost int64             `json:"gasCost"`
+	Depth   int64             `json:"depth"`
+	Stack   []string          `json:"stack"`
+	Memory  []string          `json:"memory"`
+	Storage map[string]string `json:"storage"`
+	Reason  *string           `json:"reason"`
+}
@@ -3,12 +3,19 @@ package domain
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 )
 
-// RPCParams interface defines methods that all RPC parameter structs should implement
+// RPCParams interface defines methods that all RPC parameter structs should implement.
+// Each field of a params struct is read from one positional parameter, in
+// order, except that fields tagged with the same rpcparam name are read from
+// the properties of one object parameter, which a ",required" option on the
+// tag marks as mandatory; rpc.discover documents them so.
 type RPCParams interface {
 	// FromPositionalParams converts positional parameters (array) to struct fields
 	FromPositionalParams(params []interface{}) error
@@ -29,30 +36,38 @@ type EthGetBlockByNumberParams struct {
 // EthGetBalanceParams represents parameters for eth_getBalance
 type EthGetBalanceParams struct {
 	Address     string `json:"address" binding:"required,eth_address"`
-	BlockNumber string `json:"blockNumber" binding:"omitempty,block_number_or_tag"`
+	BlockNumber string `json:"blockNumber" binding:"omitempty,block_number_tag_or_hash"`
 }
 
 // EthGetTransactionCountParams represents parameters for eth_getTransactionCount
 type EthGetTransactionCountParams struct {
 	Address     string `json:"address" binding:"required,eth_address"`
-	BlockNumber string `json:"blockNumber" binding:"omitempty,block_number_or_tag"`
+	BlockNumber string `json:"blockNumber" binding:"omitempty,block_number_tag_or_hash"`
 }
 
 // EthEstimateGasParams represents parameters for eth_estimateGas
 type EthEstimateGasParams struct {
 	CallObject     map[string]interface{} `json:"callObject" binding:"required"`
-	BlockParameter string                 `json:"blockParameter" binding:"omitempty,block_number_or_tag"`
+	BlockParameter string                 `json:"blockParameter" binding:"omitempty,block_number_tag_or_hash"`
+}
+
+// EthCreateAccessListParams represents parameters for eth_createAccessList
+type EthCreateAccessListParams struct {
+	CallObject     map[string]interface{} `json:"callObject" binding:"required"`
+	BlockParameter string                 `json:"blockParameter" binding:"omitempty,block_number_tag_or_hash"`
 }
 
 // EthCallParams represents parameters for eth_call
 type EthCallParams struct {
 	CallObject map[string]interface{} `json:"callObject" binding:"required"`
-	Block      string                 `json:"block" binding:"required,block_number_or_tag"`
+	Block      string                 `json:"block" binding:"required,block_number_tag_or_hash"`
 }
 
-// EthGetTransactionByHashParams represents parameters for eth_getTransactionByHash
+// EthGetTransactionByHashParams represents parameters for eth_getTransactionByHash.
+// TransactionHash also accepts a Hedera transaction ID, normalized to the
+// mirror node form.
 type EthGetTransactionByHashParams struct {
-	TransactionHash string `json:"transactionHash" binding:"required,len=66,hexadecimal,startswith=0x"`
+	TransactionHash string `json:"transactionHash" binding:"required,transaction_hash_or_id"`
 }
 
 // EthGetTransactionReceiptParams represents parameters for eth_getTransactionReceipt
@@ -62,16 +77,30 @@ type EthGetTransactionReceiptParams struct {
 
 // EthFeeHistoryParams represents parameters for eth_feeHistory
 type EthFeeHistoryParams struct {
-	BlockCount        string   `json:"blockCount" binding:"required,hexadecimal,startswith=0x"`
-	NewestBlock       string   `json:"newestBlock" binding:"required,block_number_or_tag"`
+	BlockCount  string `json:"blockCount" binding:"required,hexadecimal,startswith=0x"`
+	NewestBlock string `json:"newestBlock" binding:"required,block_number_or_tag"`
+	// RewardPercentiles are increasing percentiles between 0 and 100, in
+	// decimal whether the client sent numbers or strings.
 	RewardPercentiles []string `json:"rewardPercentiles" binding:"omitempty"`
 }
 
 // EthGetStorageAtParams represents parameters for eth_getStorageAt
 type EthGetStorageAtParams struct {
 	Address         string `json:"address" binding:"required,eth_address"`
-	StoragePosition string `json:"storagePosition" binding:"required,hexadecimal,startswith=0x"`
-	BlockNumber     string `json:"blockNumber" binding:"omitempty,block_number_or_tag"`
+	StoragePosition string `json:"storagePosition" binding:"required,hexadecimal,startswith=0x,len=66"`
+	BlockNumber     string `json:"blockNumber" binding:"omitempty,block_number_tag_or_hash"`
+}
+
+// EthGetProofParams represents parameters for eth_getProof
+type EthGetProofParams struct {
+	Address     string   `json:"address" binding:"required,eth_address"`
+	StorageKeys []string `json:"storageKeys" binding:"dive,hexadecimal,startswith=0x"`
+	BlockNumber string   `json:"blockNumber" binding:"required,block_number_tag_or_hash"`
+}
+
+// Web3Sha3Params represents parameters for web3_sha3
+type Web3Sha3Params struct {
+	Data string `json:"data" binding:"required,startswith=0x"`
 }
 
 // NoParameters represents a struct with no parameters for endpoints that do not have input parameters
@@ -79,20 +108,20 @@ type NoParameters struct{}
 
 // FilterObject represents the filter object for eth_getLogs
 type FilterObject struct {
-	Address   Address  `json:"address" binding:"omitempty,eth_address_or_array"`
-	Topics    []string `json:"topics" binding:"omitempty,dive,hexadecimal,len=66"`
-	BlockHash string   `json:"blockHash" binding:"omitempty,hexadecimal,len=66"`
-	FromBlock string   `json:"fromBlock" binding:"omitempty,block_number_or_tag"`
-	ToBlock   string   `json:"toBlock" binding:"omitempty,block_number_or_tag"`
+	Address   Address     `json:"address" binding:"omitempty,eth_address_or_array"`
+	Topics    TopicFilter `json:"topics" binding:"omitempty,max=4,dive,dive,hexadecimal,len=66"`
+	BlockHash string      `json:"blockHash" binding:"omitempty,hexadecimal,len=66"`
+	FromBlock string      `json:"fromBlock" binding:"omitempty,block_number_or_tag"`
+	ToBlock   string      `json:"toBlock" binding:"omitempty,block_number_or_tag"`
 }
 
 // EthGetLogsParams represents parameters for eth_getLogs
 type EthGetLogsParams struct {
-	Address   Address  `json:"address" binding:"omitempty,dive,eth_address"`
-	Topics    []string `json:"topics" binding:"omitempty,dive,hexadecimal,len=66"`
-	BlockHash string   `json:"blockHash" binding:"omitempty,hexadecimal,len=66"`
-	FromBlock string   `json:"fromBlock" binding:"omitempty,block_number_or_tag"`
-	ToBlock   string   `json:"toBlock" binding:"omitempty,block_number_or_tag"`
+	Address   Address     `json:"address" binding:"omitempty,dive,eth_address" rpcparam:"filter,required"`
+	Topics    TopicFilter `json:"topics" binding:"omitempty,max=4,dive,dive,hexadecimal,len=66" rpcparam:"filter,required"`
+	BlockHash string      `json:"blockHash" binding:"omitempty,hexadecimal,len=66" rpcparam:"filter,required"`
+	FromBlock string      `json:"fromBlock" binding:"omitempty,block_number_or_tag" rpcparam:"filter,required"`
+	ToBlock   string      `json:"toBlock" binding:"omitempty,block_number_or_tag" rpcparam:"filter,required"`
 }
 
 // EthGetBlockTransactionCountByHashParams represents parameters for eth_getBlockTransactionCountByHash
@@ -125,7 +154,7 @@ type EthSendRawTransactionParams struct {
 // EthGetCodeParams represents parameters for eth_getCode
 type EthGetCodeParams struct {
 	Address     string `json:"address" binding:"required,eth_address"`
-	BlockNumber string `json:"blockNumber" binding:"required,block_number_or_tag"`
+	BlockNumber string `json:"blockNumber" binding:"required,block_number_tag_or_hash"`
 }
 
 // EthGetUncleCountByBlockHashParams represents parameters for eth_getUncleCountByBlockHash
@@ -164,13 +193,13 @@ func (p *EthGetBlockByHashParams) FromPositionalParams(params []interface{}) err
 
 	blockHash, ok := params[0].(string)
 	if !ok {
-		return fmt.Errorf("blockHash must be a string")
+		return NewParamError(0, expectedBlockHash, params[0])
 	}
 	p.BlockHash = blockHash
 
 	showDetails, ok := params[1].(bool)
 	if !ok {
-		return fmt.Errorf("showDetails must be a boolean")
+		return NewParamError(1, expectedBoolean, params[1])
 	}
 	p.ShowDetails = showDetails
 
@@ -185,13 +214,13 @@ func (p *EthGetBlockByNumberParams) FromPositionalParams(params []interface{}) e
 
 	blockNumber, ok := params[0].(string)
 	if !ok {
-		return fmt.Errorf("blockNumber must be a string")
+		return NewParamError(0, expectedBlockNumber, params[0])
 	}
 	p.BlockNumber = blockNumber
 
 	showDetails, ok := params[1].(bool)
 	if !ok {
-		return fmt.Errorf("showDetails must be a boolean")
+		return NewParamError(1, expectedBoolean, params[1])
 	}
 	p.ShowDetails = showDetails
 
@@ -205,7 +234,7 @@ func (p *EthGetLogsParams) FromPositionalParams(params []interface{}) error {
 
 	filterObj, ok := params[0].(map[string]interface{})
 	if !ok {
-		return fmt.Errorf("eth_getLogs expects a filter object parameter")
+		return NewParamError(0, expectedFilterObject, params[0])
 	}
 
 	validFields := map[string]bool{
@@ -218,7 +247,7 @@ func (p *EthGetLogsParams) FromPositionalParams(params []interface{}) error {
 
 	for field := range filterObj {
 		if !validFields[field] {
-			return fmt.Errorf("'%s' is not a valid parameter for eth_getLogs", field)
+			return NewParamError(0, fmt.Sprintf("Expected a filter object without the unknown property %q", field), filterObj)
 		}
 	}
 
@@ -234,7 +263,7 @@ func (p *EthGetLogsParams) FromPositionalParams(params []interface{}) error {
 
 	validate := binding.Validator.Engine().(*validator.Validate)
 	if err := validate.Struct(&filter); err != nil {
-		return fmt.Errorf("invalid filter parameters: %v", err)
+		return filterParamError(0, err, filterObj)
 	}
 
 	p.Address = filter.Address
@@ -245,7 +274,7 @@ func (p *EthGetLogsParams) FromPositionalParams(params []interface{}) error {
 
 	if p.BlockHash != "" {
 		if p.FromBlock != "" || p.ToBlock != "" {
-			return fmt.Errorf("can't use both blockHash and toBlock/fromBlock")
+			return NewParamError(0, "Expected either blockHash or fromBlock/toBlock, not both", params[0])
 		}
 	} else {
 		if p.ToBlock == "" {
@@ -278,14 +307,14 @@ func (p *EthGetBalanceParams) FromPositionalParams(params []interface{}) error {
 
 	address, ok := params[0].(string)
 	if !ok {
-		return fmt.Errorf("address must be a string")
+		return NewParamError(0, expectedAddress, params[0])
 	}
 	p.Address = address
 
 	if len(params) > 1 {
-		blockNumber, ok := params[1].(string)
-		if !ok {
-			return fmt.Errorf("blockNumber must be a string")
+		blockNumber, err := ParseBlockParameter("blockNumber", params[1])
+		if err != nil {
+			return invalidParam(1, err, params[1])
 		}
 		p.BlockNumber = blockNumber
 	} else {
@@ -303,14 +332,14 @@ func (p *EthGetTransactionCountParams) FromPositionalParams(params []interface{}
 
 	address, ok := params[0].(string)
 	if !ok {
-		return fmt.Errorf("address must be a string")
+		return NewParamError(0, expectedAddress, params[0])
 	}
 	p.Address = address
 
 	if len(params) > 1 {
-		blockNumber, ok := params[1].(string)
-		if !ok {
-			return fmt.Errorf("blockNumber must be a string")
+		blockNumber, err := ParseBlockParameter("blockNumber", params[1])
+		if err != nil {
+			return invalidParam(1, err, params[1])
 		}
 		p.BlockNumber = blockNumber
 	} else {
@@ -328,14 +357,37 @@ func (p *EthEstimateGasParams) FromPositionalParams(params []interface{}) error
 
 	callObject, ok := params[0].(map[string]interface{})
 	if !ok {
-		return fmt.Errorf("callObject must be an object")
+		return NewParamError(0, expectedCallObject, params[0])
 	}
 	p.CallObject = callObject
 
 	if len(params) > 1 {
-		blockParam, ok := params[1].(string)
-		if !ok {
-			return fmt.Errorf("blockParameter must be a string")
+		blockParam, err := ParseBlockParameter("blockParameter", params[1])
+		if err != nil {
+			return invalidParam(1, err, params[1])
+		}
+		p.BlockParameter = blockParam
+	}
+
+	return nil
+}
+
+// FromPositionalParams implements parameter conversion for EthCreateAccessListParams
+func (p *EthCreateAccessListParams) FromPositionalParams(params []interface{}) error {
+	if len(params) == 0 || len(params) > 2 {
+		return fmt.Errorf("expected 1 or 2 parameters, got %d", len(params))
+	}
+
+	callObject, ok := params[0].(map[string]interface{})
+	if !ok {
+		return NewParamError(0, expectedCallObject, params[0])
+	}
+	p.CallObject = callObject
+
+	if len(params) > 1 {
+		blockParam, err := ParseBlockParameter("blockParameter", params[1])
+		if err != nil {
+			return invalidParam(1, err, params[1])
 		}
 		p.BlockParameter = blockParam
 	}
@@ -351,13 +403,13 @@ func (p *EthCallParams) FromPositionalParams(params []interface{}) error {
 
 	callObject, ok := params[0].(map[string]interface{})
 	if !ok {
-		return fmt.Errorf("callObject must be an object")
+		return NewParamError(0, expectedCallObject, params[0])
 	}
 	p.CallObject = callObject
 
-	block, ok := params[1].(string)
-	if !ok {
-		return fmt.Errorf("block must be a string")
+	block, err := ParseBlockParameter("block", params[1])
+	if err != nil {
+		return invalidParam(1, err, params[1])
 	}
 	p.Block = block
 
@@ -372,7 +424,10 @@ func (p *EthGetTransactionByHashParams) FromPositionalParams(params []interface{
 
 	txHash, ok := params[0].(string)
 	if !ok {
-		return fmt.Errorf("transactionHash must be a string")
+		return NewParamError(0, expectedTransactionID, params[0])
+	}
+	if transactionID, ok := ParseTransactionID(txHash); ok {
+		txHash = transactionID
 	}
 	p.TransactionHash = txHash
 
@@ -387,7 +442,7 @@ func (p *EthGetTransactionReceiptParams) FromPositionalParams(params []interface
 
 	txHash, ok := params[0].(string)
 	if !ok {
-		return fmt.Errorf("transactionHash must be a string")
+		return NewParamError(0, expectedTransactionHash, params[0])
 	}
 	p.TransactionHash = txHash
 
@@ -402,7 +457,7 @@ func (p *EthGetBlockTransactionCountByHashParams) FromPositionalParams(params []
 
 	blockHash, ok := params[0].(string)
 	if !ok {
-		return fmt.Errorf("blockHash must be a string")
+		return NewParamError(0, expectedBlockHash, params[0])
 	}
 	p.BlockHash = blockHash
 
@@ -417,7 +472,7 @@ func (p *EthGetBlockTransactionCountByNumberParams) FromPositionalParams(params
 
 	blockNumber, ok := params[0].(string)
 	if !ok {
-		return fmt.Errorf("blockNumber must be a string")
+		return NewParamError(0, expectedBlockNumber, params[0])
 	}
 	p.BlockNumber = blockNumber
 
@@ -432,13 +487,13 @@ func (p *EthGetTransactionByBlockHashAndIndexParams) FromPositionalParams(params
 
 	blockHash, ok := params[0].(string)
 	if !ok {
-		return fmt.Errorf("blockHash must be a string")
+		return NewParamError(0, expectedBlockHash, params[0])
 	}
 	p.BlockHash = blockHash
 
 	transactionIndex, ok := params[1].(string)
 	if !ok {
-		return fmt.Errorf("transactionIndex must be a string")
+		return NewParamError(1, expectedHexNumber, params[1])
 	}
 	p.TransactionIndex = transactionIndex
 
@@ -453,13 +508,13 @@ func (p *EthGetTransactionByBlockNumberAndIndexParams) FromPositionalParams(para
 
 	blockNumber, ok := params[0].(string)
 	if !ok {
-		return fmt.Errorf("blockNumber must be a string")
+		return NewParamError(0, expectedBlockNumber, params[0])
 	}
 	p.BlockNumber = blockNumber
 
 	transactionIndex, ok := params[1].(string)
 	if !ok {
-		return fmt.Errorf("transactionIndex must be a string")
+		return NewParamError(1, expectedHexNumber, params[1])
 	}
 	p.TransactionIndex = transactionIndex
 
@@ -474,7 +529,7 @@ func (p *EthSendRawTransactionParams) FromPositionalParams(params []interface{})
 
 	signedTx, ok := params[0].(string)
 	if !ok {
-		return fmt.Errorf("signedTransaction must be a string")
+		return NewParamError(0, expectedHexData, params[0])
 	}
 	p.SignedTransaction = signedTx
 
@@ -489,13 +544,13 @@ func (p *EthGetCodeParams) FromPositionalParams(params []interface{}) error {
 
 	address, ok := params[0].(string)
 	if !ok {
-		return fmt.Errorf("address must be a string")
+		return NewParamError(0, expectedAddress, params[0])
 	}
 	p.Address = address
 
-	blockNumber, ok := params[1].(string)
-	if !ok {
-		return fmt.Errorf("blockNumber must be a string")
+	blockNumber, err := ParseBlockParameter("blockNumber", params[1])
+	if err != nil {
+		return invalidParam(1, err, params[1])
 	}
 	p.BlockNumber = blockNumber
 
@@ -510,20 +565,58 @@ func (p *EthGetStorageAtParams) FromPositionalParams(params []interface{}) error
 
 	address, ok := params[0].(string)
 	if !ok {
-		return fmt.Errorf("address must be a string")
+		return NewParamError(0, expectedAddress, params[0])
 	}
 	p.Address = address
 
-	storagePosition, ok := params[1].(string)
-	if !ok {
-		return fmt.Errorf("storagePosition must be a string")
+	storagePosition, err := ParseStorageSlot("storagePosition", params[1])
+	if err != nil {
+		return invalidParam(1, err, params[1])
 	}
 	p.StoragePosition = storagePosition
 
 	if len(params) > 2 {
-		blockNumber, ok := params[2].(string)
+		blockNumber, err := ParseBlockParameter("blockNumber", params[2])
+		if err != nil {
+			return invalidParam(2, err, params[2])
+		}
+		p.BlockNumber = blockNumber
+	} else {
+		p.BlockNumber = BlockTagLatest
+	}
+
+	return nil
+}
+
+// FromPositionalParams implements parameter conversion for EthGetProofParams
+func (p *EthGetProofParams) FromPositionalParams(params []interface{}) error {
+	if len(params) < 2 || len(params) > 3 {
+		return fmt.Errorf("expected 2 or 3 parameters, got %d", len(params))
+	}
+
+	address, ok := params[0].(string)
+	if !ok {
+		return NewParamError(0, expectedAddress, params[0])
+	}
+	p.Address = address
+
+	storageKeys, ok := params[1].([]interface{})
+	if !ok {
+		return NewParamError(1, expectedStorageKeys, params[1])
+	}
+	p.StorageKeys = make([]string, 0, len(storageKeys))
+	for _, key := range storageKeys {
+		keyStr, ok := key.(string)
 		if !ok {
-			return fmt.Errorf("blockNumber must be a string")
+			return NewParamError(1, expectedStorageKeys, params[1])
+		}
+		p.StorageKeys = append(p.StorageKeys, keyStr)
+	}
+
+	if len(params) > 2 {
+		blockNumber, err := ParseBlockParameter("blockNumber", params[2])
+		if err != nil {
+			return invalidParam(2, err, params[2])
 		}
 		p.BlockNumber = blockNumber
 	} else {
@@ -533,6 +626,27 @@ func (p *EthGetStorageAtParams) FromPositionalParams(params []interface{}) error
 	return nil
 }
 
+// parsePercentile reads a reward percentile, which the spec sends as a JSON
+// number but older clients send as a decimal or 0x prefixed hex string.
+func parsePercentile(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		percentile, err := v.Float64()
+		return percentile, err == nil
+	case string:
+		if strings.HasPrefix(v, "0x") || strings.HasPrefix(v, "0X") {
+			percentile, err := strconv.ParseUint(v[2:], 16, 64)
+			return float64(percentile), err == nil
+		}
+		percentile, err := strconv.ParseFloat(v, 64)
+		return percentile, err == nil && !math.IsNaN(percentile) && !math.IsInf(percentile, 0)
+	default:
+		return 0, false
+	}
+}
+
 // FromPositionalParams implements parameter conversion for EthFeeHistoryParams
 func (p *EthFeeHistoryParams) FromPositionalParams(params []interface{}) error {
 	if len(params) < 2 || len(params) > 3 {
@@ -541,29 +655,37 @@ func (p *EthFeeHistoryParams) FromPositionalParams(params []interface{}) error {
 
 	blockCount, ok := params[0].(string)
 	if !ok {
-		return fmt.Errorf("blockCount must be a string")
+		return NewParamError(0, expectedHexNumber, params[0])
 	}
 	p.BlockCount = blockCount
 
 	newestBlock, ok := params[1].(string)
 	if !ok {
-		return fmt.Errorf("newestBlock must be a string")
+		return NewParamError(1, expectedBlockNumber, params[1])
 	}
 	p.NewestBlock = newestBlock
 
 	if len(params) > 2 {
 		rawPercentiles, ok := params[2].([]interface{})
 		if !ok {
-			return fmt.Errorf("rewardPercentiles must be an array")
+			return NewParamError(2, expectedPercentiles, params[2])
 		}
 
 		rewardPercentiles := make([]string, 0, len(rawPercentiles))
+		previous := -1.0
 		for _, rawPercentile := range rawPercentiles {
-			percentile, ok := rawPercentile.(string)
+			percentile, ok := parsePercentile(rawPercentile)
 			if !ok {
-				return fmt.Errorf("each reward percentile must be a string")
+				return NewParamError(2, expectedPercentiles, params[2])
+			}
+			if percentile < 0 || percentile > 100 {
+				return NewParamError(2, expectedPercentileRange, params[2])
 			}
-			rewardPercentiles = append(rewardPercentiles, percentile)
+			if percentile <= previous {
+				return NewParamError(2, expectedPercentileOrder, params[2])
+			}
+			previous = percentile
+			rewardPercentiles = append(rewardPercentiles, strconv.FormatFloat(percentile, 'f', -1, 64))
 		}
 		p.RewardPercentiles = rewardPercentiles
 	}
@@ -579,7 +701,7 @@ func (p *EthGetUncleCountByBlockHashParams) FromPositionalParams(params []interf
 
 	blockHash, ok := params[0].(string)
 	if !ok {
-		return fmt.Errorf("blockHash must be a string")
+		return NewParamError(0, expectedBlockHash, params[0])
 	}
 	p.BlockHash = blockHash
 
@@ -594,7 +716,7 @@ func (p *EthGetUncleCountByBlockNumberParams) FromPositionalParams(params []inte
 
 	blockNumber, ok := params[0].(string)
 	if !ok {
-		return fmt.Errorf("blockNumber must be a string")
+		return NewParamError(0, expectedBlockNumber, params[0])
 	}
 	p.BlockNumber = blockNumber
 
@@ -609,13 +731,13 @@ func (p *EthGetUncleByBlockHashAndIndexParams) FromPositionalParams(params []int
 
 	blockHash, ok := params[0].(string)
 	if !ok {
-		return fmt.Errorf("blockHash must be a string")
+		return NewParamError(0, expectedBlockHash, params[0])
 	}
 	p.BlockHash = blockHash
 
 	index, ok := params[1].(string)
 	if !ok {
-		return fmt.Errorf("index must be a string")
+		return NewParamError(1, expectedHexNumber, params[1])
 	}
 	p.Index = index
 
@@ -630,13 +752,13 @@ func (p *EthGetUncleByBlockNumberAndIndexParams) FromPositionalParams(params []i
 
 	blockNumber, ok := params[0].(string)
 	if !ok {
-		return fmt.Errorf("blockNumber must be a string")
+		return NewParamError(0, expectedBlockNumber, params[0])
 	}
 	p.BlockNumber = blockNumber
 
 	index, ok := params[1].(string)
 	if !ok {
-		return fmt.Errorf("index must be a string")
+		return NewParamError(1, expectedHexNumber, params[1])
 	}
 	p.Index = index
 
@@ -644,31 +766,28 @@ func (p *EthGetUncleByBlockNumberAndIndexParams) FromPositionalParams(params []i
 }
 
 type EthNewFilterParams struct {
-	FromBlock string   `json:"fromBlock" validate:"omitempty,hexadecimal"`
-	ToBlock   string   `json:"toBlock" validate:"omitempty,hexadecimal"`
-	Address   Address  `json:"address" validate:"omitempty,dive,eth_address"`
-	Topics    []string `json:"topics" validate:"omitempty,dive,hexadecimal"`
+	FromBlock string      `json:"fromBlock" binding:"omitempty,block_number_or_tag" rpcparam:"filter"`
+	ToBlock   string      `json:"toBlock" binding:"omitempty,block_number_or_tag" rpcparam:"filter"`
+	Address   Address     `json:"address" binding:"omitempty,dive,eth_address" rpcparam:"filter"`
+	Topics    TopicFilter `json:"topics" binding:"omitempty,max=4,dive,dive,hexadecimal,len=66" rpcparam:"filter"`
 }
 
 func (p *EthNewFilterParams) FromPositionalParams(params []interface{}) error {
-	if len(params) > 0 {
+	if len(params) > 0 && params[0] != nil {
 		filterObj, ok := params[0].(map[string]interface{})
 		if !ok {
-			p.FromBlock = BlockTagLatest
-			p.ToBlock = BlockTagLatest
-			return nil
-		}
-		if fromBlock, ok := filterObj["fromBlock"].(string); ok {
-			p.FromBlock = fromBlock
+			return NewParamError(0, expectedFilterObject, params[0])
 		}
-		if toBlock, ok := filterObj["toBlock"].(string); ok {
-			p.ToBlock = toBlock
-		}
-		if address, ok := filterObj["address"].([]string); ok {
-			p.Address = address
+
+		// Round-trip through JSON so address (string or array) and topics are
+		// decoded the same way as for eth_getLogs.
+		filterBytes, err := json.Marshal(filterObj)
+		if err != nil {
+			return fmt.Errorf("failed to marshal filter object: %v", err)
 		}
-		if topics, ok := filterObj["topics"].([]string); ok {
-			p.Topics = topics
+
+		if err := json.Unmarshal(filterBytes, p); err != nil {
+			return fmt.Errorf("failed to unmarshal filter object: %v", err)
 		}
 	}
 	if p.FromBlock == "" {
@@ -692,7 +811,7 @@ func (p *EthUninstallFilterParams) FromPositionalParams(params []interface{}) er
 		p.FilterID = filterId
 		return nil
 	}
-	return fmt.Errorf("invalid filter ID parameter")
+	return NewParamError(0, expectedFilterID, params[0])
 }
 
 type EthGetFilterLogsParams struct {
@@ -707,7 +826,7 @@ func (p *EthGetFilterLogsParams) FromPositionalParams(params []interface{}) erro
 		p.FilterID = filterId
 		return nil
 	}
-	return fmt.Errorf("invalid filter ID parameter")
+	return NewParamError(0, expectedFilterID, params[0])
 }
 
 type EthGetFilterChangesParams struct {
@@ -722,5 +841,125 @@ func (p *EthGetFilterChangesParams) FromPositionalParams(params []interface{}) e
 		p.FilterID = filterId
 		return nil
 	}
-	return fmt.Errorf("invalid filter ID parameter")
+	return NewParamError(0, expectedFilterID, params[0])
+}
+
+// TracerConfig holds the options of both supported tracers. OnlyTopCall applies
+// to the callTracer, the rest to the opcodeLogger.
+type TracerConfig struct {
+	OnlyTopCall    bool `json:"onlyTopCall"`
+	EnableMemory   bool `json:"enableMemory"`
+	DisableStack   bool `json:"disableStack"`
+	DisableStorage bool `json:"disableStorage"`
+}
+
+// HederaGetTokenBalancesParams represents parameters for hedera_getTokenBalances
+type HederaGetTokenBalancesParams struct {
+	Address string `json:"address" binding:"required,eth_address"`
+}
+
+func (p *HederaGetTokenBalancesParams) FromPositionalParams(params []interface{}) error {
+	if len(params) != 1 {
+		return fmt.Errorf("expected 1 parameter, got %d", len(params))
+	}
+
+	address, ok := params[0].(string)
+	if !ok {
+		return NewParamError(0, expectedAddress, params[0])
+	}
+	p.Address = address
+
+	return nil
+}
+
+// HederaGetTokenInfoParams represents parameters for hedera_getTokenInfo
+type HederaGetTokenInfoParams struct {
+	TokenAddress string `json:"tokenAddress" binding:"required,eth_address"`
+}
+
+func (p *HederaGetTokenInfoParams) FromPositionalParams(params []interface{}) error {
+	if len(params) != 1 {
+		return fmt.Errorf("expected 1 parameter, got %d", len(params))
+	}
+
+	tokenAddress, ok := params[0].(string)
+	if !ok {
+		return NewParamError(0, expectedAddress, params[0])
+	}
+	p.TokenAddress = tokenAddress
+
+	return nil
+}
+
+// DebugTraceTransactionParams represents parameters for debug_traceTransaction
+type DebugTraceTransactionParams struct {
+	TransactionIdOrHash string       `json:"transactionIdOrHash" binding:"required"`
+	Tracer              string       `json:"tracer" binding:"omitempty,oneof=callTracer opcodeLogger" rpcparam:"tracerOptions"`
+	TracerConfig        TracerConfig `json:"tracerConfig" rpcparam:"tracerOptions"`
+}
+
+func (p *DebugTraceTransactionParams) FromPositionalParams(params []interface{}) error {
+	if len(params) < 1 {
+		return fmt.Errorf("missing transaction hash parameter")
+	}
+
+	txHash, ok := params[0].(string)
+	if !ok {
+		return NewParamError(0, expectedTransactionID, params[0])
+	}
+	p.TransactionIdOrHash = txHash
+
+	if len(params) > 1 && params[1] != nil {
+		options, ok := params[1].(map[string]interface{})
+		if !ok {
+			return NewParamError(1, expectedTracerOptions, params[1])
+		}
+
+		optionsBytes, err := json.Marshal(options)
+		if err != nil {
+			return fmt.Errorf("failed to marshal tracer options: %v", err)
+		}
+
+		// The opcodeLogger options may also be passed at the top level,
+		// next to (or instead of) the tracer field.
+		if err := json.Unmarshal(optionsBytes, &p.TracerConfig); err != nil {
+			return invalidParam(1, fmt.Errorf("invalid tracer config: %v", err), params[1])
+		}
+
+		var tracerOptions struct {
+			Tracer       string          `json:"tracer"`
+			TracerConfig json.RawMessage `json:"tracerConfig"`
+		}
+		if err := json.Unmarshal(optionsBytes, &tracerOptions); err != nil {
+			return invalidParam(1, fmt.Errorf("invalid tracer options: %v", err), params[1])
+		}
+
+		p.Tracer = tracerOptions.Tracer
+		if len(tracerOptions.TracerConfig) > 0 {
+			if err := json.Unmarshal(tracerOptions.TracerConfig, &p.TracerConfig); err != nil {
+				return invalidParam(1, fmt.Errorf("invalid tracer config: %v", err), params[1])
+			}
+		}
+	}
+
+	if p.Tracer == "" {
+		p.Tracer = OpcodeLogger
+	}
+
+	return nil
+}
+
+// FromPositionalParams implements parameter conversion for Web3Sha3Params
+func (p *Web3Sha3Params) FromPositionalParams(params []interface{}) error {
+	if len(params) != 1 {
+		return fmt.Errorf("expected 1 parameter, got %d", len(params))
+	}
+
+	data, ok := params[0].(string)
+	if !ok {
+		return NewParamError(0, expectedHexData, params[0])
+	}
+	p.Data = data
+
+	return nil
 }
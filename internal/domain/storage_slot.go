@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// ParseStorageSlot reads a storage slot parameter and returns it as a
+// 0x-prefixed, zero-padded 32-byte hex string. Like geth, it accepts hex slots
+// shorter than 32 bytes; for tooling that sends them, it also accepts decimal
+// slots as strings or JSON numbers.
+func ParseStorageSlot(name string, value interface{}) (string, error) {
+	slot := new(big.Int)
+	switch v := value.(type) {
+	case string:
+		if hexDigits, ok := strings.CutPrefix(v, "0x"); ok {
+			if len(hexDigits) == 0 || len(hexDigits) > 64 {
+				return "", fmt.Errorf("%s must be at most 32 bytes of hex", name)
+			}
+			if _, ok := slot.SetString(hexDigits, 16); !ok {
+				return "", fmt.Errorf("%s must be a hex or decimal number", name)
+			}
+		} else if _, ok := slot.SetString(v, 10); !ok || slot.Sign() < 0 {
+			return "", fmt.Errorf("%s must be a hex or decimal number", name)
+		}
+	case float64:
+		if v < 0 || v != math.Trunc(v) || v > 1<<53 {
+			return "", fmt.Errorf("%s must be a non-negative integer", name)
+		}
+		slot.SetUint64(uint64(v))
+	default:
+		return "", fmt.Errorf("%s must be a string or a number", name)
+	}
+
+	if slot.BitLen() > 256 {
+		return "", fmt.Errorf("%s must be at most 32 bytes", name)
+	}
+	return fmt.Sprintf("0x%064x", slot), nil
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var transactionIDPattern = regexp.MustCompile(`^(\d+\.\d+\.\d+)(?:@(\d+)\.(\d+)|-(\d+)-(\d+))$`)
+
+// ParseTransactionID reads a Hedera transaction ID, written either as
+// 0.0.1234@1700000000.123456789 like the SDKs do or as
+// 0.0.1234-1700000000-123456789 like the mirror node does, and returns it in
+// the mirror node form. It reports false for anything else, including
+// Ethereum transaction hashes.
+func ParseTransactionID(value string) (string, bool) {
+	match := transactionIDPattern.FindStringSubmatch(value)
+	if match == nil {
+		return "", false
+	}
+
+	seconds, nanos := match[2], match[3]
+	if seconds == "" {
+		seconds, nanos = match[4], match[5]
+	}
+	n, err := strconv.ParseUint(nanos, 10, 64)
+	if err != nil || n >= 1_000_000_000 {
+		return "", false
+	}
+	return fmt.Sprintf("%s-%s-%09d", match[1], seconds, n), true
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/LimeChain/Hederium/internal/infrastructure/timing"
 	gocache "github.com/eko/gocache/lib/v4/cache"
 	"github.com/eko/gocache/lib/v4/store"
 	"github.com/eko/gocache/store/go_cache/v4"
@@ -29,6 +30,8 @@ func NewMemoryCache(ttl, cleanupInterval time.Duration) CacheService {
 	}
 }
 func (m *MemoryCache) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	defer timing.Track(ctx, timing.Cache)()
+
 	data, err := json.Marshal(value)
 	if err != nil {
 		return err
@@ -37,6 +40,8 @@ func (m *MemoryCache) Set(ctx context.Context, key string, value any, ttl time.D
 }
 
 func (m *MemoryCache) Get(ctx context.Context, key string, out any) error {
+	defer timing.Track(ctx, timing.Cache)()
+
 	value, err := m.cache.Get(ctx, key)
 	if err != nil {
 		return err
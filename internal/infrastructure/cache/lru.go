@@ -0,0 +1,170 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/timing"
+	"github.com/eko/gocache/lib/v4/store"
+)
+
+// LRUCache is an in-memory cache bounded by entry count and by the size of
+// the stored values. Once either limit is reached, the least recently used
+// entries are evicted, so the cache stays within its budget between expiry
+// sweeps no matter how many distinct keys are requested.
+type LRUCache struct {
+	defaultExpiration time.Duration
+	maxEntries        int
+	maxBytes          int64
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// order holds the entries from most to least recently used.
+	order *list.List
+	bytes int64
+}
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewLRUCache creates a cache holding at most maxEntries entries whose keys
+// and values take up at most maxBytes. A zero limit leaves that dimension
+// unbounded. Expired entries are swept every cleanupInterval, when positive.
+func NewLRUCache(defaultExpiration, cleanupInterval time.Duration, maxEntries int, maxBytes int64) CacheService {
+	c := &LRUCache{
+		defaultExpiration: defaultExpiration,
+		maxEntries:        maxEntries,
+		maxBytes:          maxBytes,
+		entries:           make(map[string]*list.Element),
+		order:             list.New(),
+	}
+	if cleanupInterval > 0 {
+		go c.sweep(cleanupInterval)
+	}
+	return c
+}
+
+// Set stores value under key for ttl, or for the default expiration when ttl
+// is zero. A negative ttl stores the value without expiration.
+func (c *LRUCache) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	defer timing.Track(ctx, timing.Cache)()
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	if ttl == 0 {
+		ttl = c.defaultExpiration
+	}
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	entry := &lruEntry{key: key, value: data, expiresAt: expiresAt}
+	if c.maxBytes > 0 && entry.size() > c.maxBytes {
+		return errors.New("cache: value exceeds the memory budget")
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+	c.bytes += entry.size()
+	for (c.maxEntries > 0 && len(c.entries) > c.maxEntries) || (c.maxBytes > 0 && c.bytes > c.maxBytes) {
+		c.remove(c.order.Back())
+	}
+	return nil
+}
+
+func (c *LRUCache) Get(ctx context.Context, key string, out any) error {
+	defer timing.Track(ctx, timing.Cache)()
+
+	c.mu.Lock()
+	element, ok := c.entries[key]
+	if !ok {
+		c.mu.Unlock()
+		return store.NotFoundWithCause(errors.New("value not found in LRU cache"))
+	}
+	entry := element.Value.(*lruEntry)
+	if entry.expired(time.Now()) {
+		c.remove(element)
+		c.mu.Unlock()
+		return store.NotFoundWithCause(errors.New("value expired in LRU cache"))
+	}
+	c.order.MoveToFront(element)
+	value := entry.value
+	c.mu.Unlock()
+
+	return json.Unmarshal(value, out)
+}
+
+func (c *LRUCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	return nil
+}
+
+// Len returns the number of entries held, including expired ones not yet
+// swept.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Bytes returns the size of the keys and values held.
+func (c *LRUCache) Bytes() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.bytes
+}
+
+func (c *LRUCache) sweep(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		now := time.Now()
+		c.mu.Lock()
+		for element := c.order.Back(); element != nil; {
+			previous := element.Prev()
+			if element.Value.(*lruEntry).expired(now) {
+				c.remove(element)
+			}
+			element = previous
+		}
+		c.mu.Unlock()
+	}
+}
+
+// remove drops an entry. Callers must hold c.mu.
+func (c *LRUCache) remove(element *list.Element) {
+	entry := element.Value.(*lruEntry)
+	c.order.Remove(element)
+	delete(c.entries, entry.key)
+	c.bytes -= entry.size()
+}
+
+func (e *lruEntry) size() int64 {
+	return int64(len(e.key) + len(e.value))
+}
+
+func (e *lruEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
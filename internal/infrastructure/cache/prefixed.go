@@ -0,0 +1,32 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// PrefixedCache is a view of another cache whose keys all carry a prefix, so
+// that services for different networks can share one cache without reading
+// each other's entries.
+type PrefixedCache struct {
+	cache  CacheService
+	prefix string
+}
+
+// NewPrefixedCache returns a view of cacheService storing every key under
+// prefix followed by a colon.
+func NewPrefixedCache(cacheService CacheService, prefix string) CacheService {
+	return &PrefixedCache{cache: cacheService, prefix: prefix + ":"}
+}
+
+func (p *PrefixedCache) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	return p.cache.Set(ctx, p.prefix+key, value, ttl)
+}
+
+func (p *PrefixedCache) Get(ctx context.Context, key string, out any) error {
+	return p.cache.Get(ctx, p.prefix+key, out)
+}
+
+func (p *PrefixedCache) Delete(ctx context.Context, key string) error {
+	return p.cache.Delete(ctx, p.prefix+key)
+}
@@ -0,0 +1,43 @@
+package cache
+
+import "time"
+
+// DefaultTTL is how long entries of a class without a TTL of its own live.
+const DefaultTTL = 1 * time.Hour
+
+// TTLClass names a kind of cached entity whose entries share a TTL.
+type TTLClass string
+
+const (
+	ClassBlock       TTLClass = "block"
+	ClassReceipt     TTLClass = "receipt"
+	ClassTransaction TTLClass = "tx"
+	ClassAccount     TTLClass = "account"
+	ClassContract    TTLClass = "contract"
+	ClassGasPrice    TTLClass = "gasPrice"
+	ClassToken       TTLClass = "token"
+)
+
+// TTLClasses lists every class, in the order they are configured.
+var TTLClasses = []TTLClass{
+	ClassBlock,
+	ClassReceipt,
+	ClassTransaction,
+	ClassAccount,
+	ClassContract,
+	ClassGasPrice,
+	ClassToken,
+}
+
+// TTLs sets how long the cached entries of each class live. Blocks, receipts
+// and transactions do not change once recorded and may be kept for hours,
+// while the gas price follows the exchange rate and is best kept for seconds.
+type TTLs map[TTLClass]time.Duration
+
+// Of returns the TTL of class, DefaultTTL when none is set.
+func (t TTLs) Of(class TTLClass) time.Duration {
+	if ttl := t[class]; ttl > 0 {
+		return ttl
+	}
+	return DefaultTTL
+}
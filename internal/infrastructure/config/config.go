@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
 	"github.com/spf13/viper"
 )
 
@@ -11,6 +12,7 @@ func LoadConfig() error {
 	viper.AddConfigPath("./configs")
 	viper.SetConfigType("yaml")
 	viper.AutomaticEnv()
+	setDefaults()
 
 	if err := viper.ReadInConfig(); err != nil {
 		return fmt.Errorf("error reading config: %w", err)
@@ -18,3 +20,66 @@ func LoadConfig() error {
 
 	return nil
 }
+
+// setDefaults registers fallbacks for keys that may be missing from older
+// config files, so that enabling a new setting never requires a config change.
+func setDefaults() {
+	viper.SetDefault("filters.enabled", true)
+	viper.SetDefault("filters.ttl", "5m")
+	viper.SetDefault("hedera.operatorKeyType", "auto")
+	viper.SetDefault("hedera.operatorKeySource.provider", "config")
+	viper.SetDefault("hedera.operatorSelection", "round-robin")
+	viper.SetDefault("hedera.operatorBalanceCheckInterval", "5m")
+	viper.SetDefault("hedera.healthCheckInterval", "1m")
+	viper.SetDefault("hedera.busyNodeCooldown", "30s")
+	viper.SetDefault("hedera.reconnectAfterFailedChecks", 3)
+	viper.SetDefault("server.cors.allowedOrigins", []string{"*"})
+	viper.SetDefault("server.cors.allowedMethods", []string{"POST", "OPTIONS"})
+	viper.SetDefault("server.cors.allowedHeaders", []string{"Content-Type", "X-API-KEY", "Authorization"})
+	viper.SetDefault("server.cors.maxAge", "10m")
+	viper.SetDefault("server.tls.reloadInterval", "1m")
+	viper.SetDefault("grpc.address", ":7547")
+	viper.SetDefault("estimateGas.fallbackEnabled", true)
+	viper.SetDefault("getCode.consensusFallback", true)
+	viper.SetDefault("mirrorNode.healthCheckInterval", "30s")
+	viper.SetDefault("mirrorNode.maxPages", 100)
+	viper.SetDefault("mirrorNode.checkOnStartup", true)
+	viper.SetDefault("syncing.lagThreshold", "30s")
+	viper.SetDefault("sendRawTransaction.nonceGapTimeout", "2s")
+	viper.SetDefault("sendRawTransaction.warmReceiptCache", true)
+	viper.SetDefault("microCache.ttl", "500ms")
+	viper.SetDefault("microCache.maxStale", "2s")
+	viper.SetDefault("callCache.ttl", "1s")
+	viper.SetDefault("cache.notFoundTtl", "2s")
+	viper.SetDefault("responses.checksumAddresses", true)
+	viper.SetDefault("responses.httpCache.maxAge", "24h")
+	viper.SetDefault("shadow.percentage", 100)
+	viper.SetDefault("shadow.timeout", "5s")
+	viper.SetDefault("shadow.maxInFlight", 64)
+	viper.SetDefault("logging.encoding", "json")
+	viper.SetDefault("logging.requests", true)
+	viper.SetDefault("logging.sampling.initial", 100)
+	viper.SetDefault("logging.sampling.thereafter", 100)
+	viper.SetDefault("logging.sampling.tick", "1s")
+	viper.SetDefault("logging.file.maxSizeMB", 100)
+	viper.SetDefault("logging.file.maxBackups", 5)
+	viper.SetDefault("logging.sink.batchSize", 100)
+	viper.SetDefault("logging.sink.flushInterval", "5s")
+	viper.SetDefault("apiKeyStore.backend", "config")
+	viper.SetDefault("apiKeyStore.driver", limiter.PostgresDriver)
+	viper.SetDefault("apiKeyStore.table", "api_keys")
+	viper.SetDefault("apiKeyStore.reloadInterval", "30s")
+	viper.SetDefault("apiKeyUsage.flushInterval", "1m")
+	viper.SetDefault("apiKeyUsage.sink", "file")
+	viper.SetDefault("apiKeyUsage.path", "usage.jsonl")
+	viper.SetDefault("apiKeyUsage.driver", limiter.PostgresDriver)
+	viper.SetDefault("apiKeyUsage.table", "api_key_usage")
+	viper.SetDefault("apiKeyUsage.statsdPrefix", "hederium.usage")
+	viper.SetDefault("admissionQueue.maxConcurrent", 512)
+	viper.SetDefault("admissionQueue.depth", 1000)
+	viper.SetDefault("admissionQueue.timeout", "1s")
+	viper.SetDefault("loadShedding.checkInterval", "5s")
+	viper.SetDefault("loadShedding.minMirrorRequests", 20)
+	viper.SetDefault("loadShedding.lowPriorityMethods", []string{"debug_*"})
+	viper.SetDefault("loadShedding.maxLogsBlockRange", 1000)
+}
@@ -0,0 +1,433 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	hederiumlogger "github.com/LimeChain/Hederium/internal/infrastructure/logger"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// Sources of the operator key, as set by hedera.operatorKeySource.provider.
+const (
+	SecretProviderConfig = "config"
+	SecretProviderEnv    = "env"
+	SecretProviderFile   = "file"
+	SecretProviderAWS    = "aws"
+	SecretProviderGCP    = "gcp"
+	SecretProviderVault  = "vault"
+)
+
+// secretFetchTimeout bounds each read of a secret from a secret store.
+const secretFetchTimeout = 10 * time.Second
+
+// gcpMetadataTokenURL hands out access tokens of the service account of a
+// Compute Engine VM or GKE workload.
+const gcpMetadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+// SecretProvider reads a secret kept outside the config file. Fetch reads the
+// current value every time, so that rotated secrets are picked up.
+type SecretProvider interface {
+	Fetch(ctx context.Context) (string, error)
+}
+
+// SecretSource says where a secret is read from. SecretID names the secret in
+// the store of Provider: a secret name or ARN for aws, a
+// projects/<project>/secrets/<secret>/versions/<version> name for gcp, and the
+// path of the secret for vault. Secrets holding a JSON object are read from
+// their Field.
+type SecretSource struct {
+	Provider string
+	Env      string
+	File     string
+	SecretID string
+	Field    string
+	// Region is the AWS region, AWS_REGION when empty.
+	Region string
+	// Endpoint replaces the URL of the AWS or GCP secret store, e.g. for a
+	// VPC endpoint.
+	Endpoint string
+	// VaultAddress is the URL of the Vault server, VAULT_ADDR when empty.
+	VaultAddress string
+}
+
+// NewSecretProvider creates the provider reading from source, or nil for
+// SecretProviderConfig, whose secrets are kept in the config file itself.
+// Credentials are taken from the environment: AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN for aws, VAULT_TOKEN for vault,
+// and GOOGLE_OAUTH_ACCESS_TOKEN or else the metadata server for gcp.
+func NewSecretProvider(source SecretSource) (SecretProvider, error) {
+	client := &http.Client{Timeout: secretFetchTimeout}
+
+	switch source.Provider {
+	case "", SecretProviderConfig:
+		return nil, nil
+	case SecretProviderEnv:
+		if source.Env == "" {
+			return nil, fmt.Errorf("no environment variable set for the env secret provider")
+		}
+		return envSecret{name: source.Env}, nil
+	case SecretProviderFile:
+		if source.File == "" {
+			return nil, fmt.Errorf("no file set for the file secret provider")
+		}
+		return fileSecret{path: source.File}, nil
+	case SecretProviderAWS:
+		region := firstNonEmpty(source.Region, os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"))
+		if source.SecretID == "" || region == "" {
+			return nil, fmt.Errorf("the aws secret provider needs a secret ID and a region")
+		}
+		endpoint := firstNonEmpty(source.Endpoint, fmt.Sprintf("https://secretsmanager.%s.amazonaws.com", region))
+		return &awsSecret{client: client, endpoint: endpoint, region: region, secretID: source.SecretID, field: source.Field}, nil
+	case SecretProviderGCP:
+		if source.SecretID == "" {
+			return nil, fmt.Errorf("the gcp secret provider needs a secret version name")
+		}
+		endpoint := firstNonEmpty(source.Endpoint, "https://secretmanager.googleapis.com")
+		return &gcpSecret{client: client, endpoint: endpoint, name: source.SecretID, field: source.Field}, nil
+	case SecretProviderVault:
+		address := firstNonEmpty(source.VaultAddress, os.Getenv("VAULT_ADDR"))
+		if source.SecretID == "" || address == "" {
+			return nil, fmt.Errorf("the vault secret provider needs a secret path and a Vault address")
+		}
+		return &vaultSecret{client: client, address: address, path: source.SecretID, field: source.Field}, nil
+	default:
+		return nil, fmt.Errorf("unknown secret provider %q", source.Provider)
+	}
+}
+
+// ResolveOperatorKey reads hedera.operatorKey from the provider set under
+// hedera.operatorKeySource and stores it in v, so that the key is validated
+// and used as if it were in the config file. It returns the provider, to
+// watch for rotations, or nil when the key is kept in the config file.
+func ResolveOperatorKey(v *viper.Viper) (SecretProvider, error) {
+	var source SecretSource
+	if err := v.UnmarshalKey("hedera.operatorKeySource", &source); err != nil {
+		return nil, fmt.Errorf("invalid hedera.operatorKeySource: %w", err)
+	}
+	provider, err := NewSecretProvider(source)
+	if err != nil || provider == nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), secretFetchTimeout)
+	defer cancel()
+	key, err := provider.Fetch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the operator key from %s: %w", source.Provider, err)
+	}
+	v.Set("hedera.operatorKey", key)
+	return provider, nil
+}
+
+// WatchSecret reads the secret of provider every interval and calls onChange
+// with it whenever it differs from current. Failed reads and secrets onChange
+// rejects are logged and retried on the next tick; the secret itself is never
+// logged.
+func WatchSecret(provider SecretProvider, interval time.Duration, current string, onChange func(secret string) error, logger *zap.Logger) {
+	if provider == nil || interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			ctx, cancel := context.WithTimeout(context.Background(), secretFetchTimeout)
+			secret, err := provider.Fetch(ctx)
+			cancel()
+			if err != nil {
+				logger.Warn("Failed to read secret, keeping the current one", zap.Error(err))
+				continue
+			}
+			if secret == current {
+				continue
+			}
+			if err := onChange(secret); err != nil {
+				logger.Error("Failed to apply rotated secret, keeping the current one", zap.Error(err))
+				continue
+			}
+			current = secret
+			logger.Info("Applied rotated secret")
+		}
+	}()
+}
+
+type envSecret struct {
+	name string
+}
+
+func (s envSecret) Fetch(context.Context) (string, error) {
+	secret := strings.TrimSpace(os.Getenv(s.name))
+	if secret == "" {
+		return "", fmt.Errorf("environment variable %s is not set", s.name)
+	}
+	return secret, nil
+}
+
+type fileSecret struct {
+	path string
+}
+
+func (s fileSecret) Fetch(context.Context) (string, error) {
+	contents, err := os.ReadFile(s.path)
+	if err != nil {
+		return "", err
+	}
+	secret := strings.TrimSpace(string(contents))
+	if secret == "" {
+		return "", fmt.Errorf("file %s is empty", s.path)
+	}
+	return secret, nil
+}
+
+// awsSecret reads a secret from AWS Secrets Manager, signing its requests with
+// Signature Version 4.
+type awsSecret struct {
+	client   *http.Client
+	endpoint string
+	region   string
+	secretID string
+	field    string
+}
+
+func (s *awsSecret) Fetch(ctx context.Context) (string, error) {
+	accessKey, secretKey := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if accessKey == "" || secretKey == "" {
+		return "", fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are not set")
+	}
+
+	body, _ := json.Marshal(map[string]string{"SecretId": s.secretID})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	if token := os.Getenv("AWS_SESSION_TOKEN"); token != "" {
+		req.Header.Set("X-Amz-Security-Token", token)
+	}
+	signAWSRequest(req, body, accessKey, secretKey, s.region, "secretsmanager", time.Now().UTC())
+
+	var response struct {
+		SecretString string
+		SecretBinary string
+	}
+	if err := fetchJSON(s.client, req, &response); err != nil {
+		return "", err
+	}
+	secret := response.SecretString
+	if secret == "" && response.SecretBinary != "" {
+		decoded, err := base64.StdEncoding.DecodeString(response.SecretBinary)
+		if err != nil {
+			return "", fmt.Errorf("invalid binary secret: %w", err)
+		}
+		secret = string(decoded)
+	}
+	return secretField(secret, s.field)
+}
+
+// signAWSRequest adds the Signature Version 4 authorization of req, whose
+// payload is body, for service in region.
+func signAWSRequest(req *http.Request, body []byte, accessKey, secretKey, region, service string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("Host", req.URL.Host)
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, strings.TrimSpace(req.Header.Get(name)))
+	}
+	signedHeaders := strings.Join(names, ";")
+	req.Header.Del("Host")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{req.Method, path, req.URL.RawQuery, canonicalHeaders.String(), signedHeaders, hex.EncodeToString(payloadHash[:])}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", date, region, service)
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(requestHash[:])}, "\n")
+
+	key := []byte("AWS4" + secretKey)
+	for _, part := range []string{date, region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// gcpSecret reads a secret version from Google Cloud Secret Manager.
+type gcpSecret struct {
+	client   *http.Client
+	endpoint string
+	name     string
+	field    string
+}
+
+func (s *gcpSecret) Fetch(ctx context.Context) (string, error) {
+	token, err := s.accessToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/%s:access", strings.TrimSuffix(s.endpoint, "/"), s.name), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	var response struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}
+	if err := fetchJSON(s.client, req, &response); err != nil {
+		return "", err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(response.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("invalid secret payload: %w", err)
+	}
+	return secretField(string(decoded), s.field)
+}
+
+// accessToken returns GOOGLE_OAUTH_ACCESS_TOKEN, or else a token of the
+// service account the relay runs as, from the metadata server.
+func (s *gcpSecret) accessToken(ctx context.Context) (string, error) {
+	if token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		return token, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gcpMetadataTokenURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	var response struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := fetchJSON(s.client, req, &response); err != nil {
+		return "", fmt.Errorf("failed to get an access token from the metadata server: %w", err)
+	}
+	return response.AccessToken, nil
+}
+
+// vaultSecret reads a secret from HashiCorp Vault. Both versions of the KV
+// secrets engine are supported: version 2 nests the secret under data.data.
+type vaultSecret struct {
+	client  *http.Client
+	address string
+	path    string
+	field   string
+}
+
+func (s *vaultSecret) Fetch(ctx context.Context) (string, error) {
+	token := os.Getenv("VAULT_TOKEN")
+	if token == "" {
+		return "", fmt.Errorf("VAULT_TOKEN is not set")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(s.address, "/"), strings.TrimPrefix(s.path, "/")), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+
+	var response struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := fetchJSON(s.client, req, &response); err != nil {
+		return "", err
+	}
+	data := response.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, versioned := data["metadata"]; versioned {
+			data = nested
+		}
+	}
+
+	field := s.field
+	if field == "" && len(data) == 1 {
+		for name := range data {
+			field = name
+		}
+	}
+	secret, ok := data[field].(string)
+	if !ok || secret == "" {
+		return "", fmt.Errorf("secret %s has no field %q", s.path, field)
+	}
+	return secret, nil
+}
+
+// fetchJSON sends req and decodes the JSON response into v.
+func fetchJSON(client *http.Client, req *http.Request, v interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s answered %d: %s", hederiumlogger.RedactURL(req.URL.String()), resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// secretField returns field of a secret holding a JSON object, or the whole
+// secret when field is empty.
+func secretField(secret, field string) (string, error) {
+	if field == "" {
+		return strings.TrimSpace(secret), nil
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(secret), &fields); err != nil {
+		return "", fmt.Errorf("secret is not a JSON object with field %q", field)
+	}
+	value, ok := fields[field].(string)
+	if !ok || value == "" {
+		return "", fmt.Errorf("secret has no field %q", field)
+	}
+	return value, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
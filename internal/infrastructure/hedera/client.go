@@ -1,10 +1,15 @@
 package hedera
 
 import (
+	"encoding/hex"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/LimeChain/Hederium/internal/domain"
 	"github.com/hashgraph/hedera-sdk-go/v2"
+	"go.uber.org/zap"
 )
 
 type HederaNodeClient interface {
@@ -12,13 +17,29 @@ type HederaNodeClient interface {
 	SendRawTransaction(transactionData []byte, networkGasPriceInWeiBars int64, callerId string) (*TransactionResponse, error)
 	GetContractByteCode(shard, realm int64, address string) ([]byte, error)
 	GetOperatorPublicKey() string
+	GetTransactionRecord(transactionId string) (*TransactionRecord, error)
 }
 
 type HederaClient struct {
-	*hedera.Client
+	// mu guards client, which is replaced when it is re-created, and health
+	mu        sync.RWMutex
+	client    *hedera.Client
+	network   string
+	operators *OperatorPool
+	health    *ConsensusNodeHealth
 }
 
-func NewHederaClient(network, operatorId, operatorKey string) (*HederaClient, error) {
+// NewHederaClient connects to network. Transactions are paid for by operators
+// taken from pool, while queries are paid for by its primary operator.
+func NewHederaClient(network string, pool *OperatorPool) (*HederaClient, error) {
+	client, err := newSDKClient(network, pool)
+	if err != nil {
+		return nil, err
+	}
+	return &HederaClient{client: client, network: network, operators: pool}, nil
+}
+
+func newSDKClient(network string, pool *OperatorPool) (*hedera.Client, error) {
 	var client *hedera.Client
 	switch network {
 	case "mainnet":
@@ -37,16 +58,63 @@ func NewHederaClient(network, operatorId, operatorKey string) (*HederaClient, er
 		return nil, fmt.Errorf("unsupported Hedera network: %s", network)
 	}
 
-	accID, err := hedera.AccountIDFromString(operatorId)
+	primary := pool.Primary()
+	client.SetOperator(primary.AccountID, primary.PrivateKey)
+	return client, nil
+}
+
+// sdk returns the current SDK client.
+func (h *HederaClient) sdk() *hedera.Client {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.client
+}
+
+// RotateOperatorKey switches the primary operator to key, of keyType (see
+// ParsePrivateKey), and re-creates the SDK client so that queries are paid
+// for with it too. Requests in flight finish with the previous key.
+func (h *HederaClient) RotateOperatorKey(key, keyType string) error {
+	privateKey, err := ParsePrivateKey(key, keyType)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	opKey, err := hedera.PrivateKeyFromString(operatorKey)
-	if err != nil {
-		return nil, err
+	h.operators.SetPrimaryKey(privateKey)
+	return h.reconnect()
+}
+
+// MonitorOperatorBalances refreshes the balance of every pooled operator each
+// interval, so that operators running low are skipped until topped up.
+func (h *HederaClient) MonitorOperatorBalances(interval time.Duration, logger *zap.Logger) {
+	h.refreshOperatorBalances(logger)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			h.refreshOperatorBalances(logger)
+		}
+	}()
+}
+
+func (h *HederaClient) refreshOperatorBalances(logger *zap.Logger) {
+	for _, operator := range h.operators.Operators() {
+		balance, err := hedera.NewAccountBalanceQuery().
+			SetAccountID(operator.AccountID).
+			Execute(h.sdk())
+		if err != nil {
+			logger.Warn("Failed to query operator balance", zap.String("operator", operator.AccountID.String()), zap.Error(err))
+			continue
+		}
+
+		tinybars := balance.Hbars.AsTinybar()
+		h.operators.UpdateBalance(operator.AccountID, tinybars)
+		if tinybars < h.operators.MinBalance() {
+			logger.Warn("Operator balance below minimum, skipping it for new transactions",
+				zap.String("operator", operator.AccountID.String()),
+				zap.Int64("balance", tinybars),
+				zap.Int64("minBalance", h.operators.MinBalance()))
+		}
 	}
-	client.SetOperator(accID, opKey)
-	return &HederaClient{Client: client}, nil
 }
 
 func (h *HederaClient) GetNetworkFees() (int64, error) {
@@ -83,15 +151,23 @@ type TransactionResponse struct {
 // SendRawTransaction submits an Ethereum transaction to the Hedera network.
 // It handles large call data by creating a file if needed and validates gas prices.
 func (h *HederaClient) SendRawTransaction(transactionData []byte, networkGasPriceInWeiBars int64, callerId string) (*TransactionResponse, error) {
+	operator, err := h.operators.Next()
+	if err != nil {
+		return nil, err
+	}
+	client := h.sdk()
+
 	ethereumTx := hedera.NewEthereumTransaction()
+	if nodes := h.transactionNodes(client); nodes != nil {
+		ethereumTx.SetNodeAccountIDs(nodes)
+	}
 
 	var fileID *hedera.FileID
-	var err error
 
 	if len(transactionData) <= fileAppendChunkSize {
 		ethereumTx.SetEthereumData(transactionData)
 	} else {
-		fileID, err = h.createFileForCallData(transactionData)
+		fileID, err = h.createFileForCallData(client, operator, transactionData)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create file for call data: %v", err)
 		}
@@ -101,14 +177,22 @@ func (h *HederaClient) SendRawTransaction(transactionData []byte, networkGasPric
 	}
 
 	// TODO: Make this in separate function
-	networkGasPriceInTinyBars := networkGasPriceInWeiBars / 10000000000
-	maxFee := hedera.NewHbar(float64(networkGasPriceInTinyBars*maxGasPerSec) / 100000000.0)
+	networkGasPriceInTinyBars := networkGasPriceInWeiBars / domain.WeibarsPerTinybar
+	maxFee := hedera.HbarFromTinybar(networkGasPriceInTinyBars * maxGasPerSec)
 	ethereumTx.SetMaxTransactionFee(maxFee)
+	ethereumTx.SetTransactionID(hedera.TransactionIDGenerate(operator.AccountID))
 
-	response, err := ethereumTx.Execute(h.Client)
+	if _, err = ethereumTx.FreezeWith(client); err != nil {
+		if fileID != nil {
+			_ = h.deleteFile(client, operator, *fileID)
+		}
+		return nil, fmt.Errorf("failed to freeze transaction: %v", err)
+	}
+
+	response, err := ethereumTx.Sign(operator.PrivateKey).Execute(client)
 	if err != nil {
 		if fileID != nil {
-			_ = h.deleteFile(*fileID)
+			_ = h.deleteFile(client, operator, *fileID)
 		}
 		return nil, fmt.Errorf("failed to execute transaction: %v", err)
 	}
@@ -119,22 +203,28 @@ func (h *HederaClient) SendRawTransaction(transactionData []byte, networkGasPric
 	}, nil
 }
 
-// createFileForCallData creates a file to store large call data
-func (h *HederaClient) createFileForCallData(data []byte) (*hedera.FileID, error) {
+// createFileForCallData creates a file to store large call data, paid for and
+// owned by operator
+func (h *HederaClient) createFileForCallData(client *hedera.Client, operator Operator, data []byte) (*hedera.FileID, error) {
 	// TODO: EstimateTxFee
 	// TODO: hbarLimitService - check if the limit is reached
 
 	// Create initial file with first chunk
-	fileCreateTx := hedera.NewFileCreateTransaction().
+	fileCreateTx, err := hedera.NewFileCreateTransaction().
 		SetContents(data[:fileAppendChunkSize]).
-		SetKeys(h.Client.GetOperatorPublicKey())
+		SetKeys(operator.PrivateKey.PublicKey()).
+		SetTransactionID(hedera.TransactionIDGenerate(operator.AccountID)).
+		FreezeWith(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to freeze file create transaction: %v", err)
+	}
 
-	resp, err := fileCreateTx.Execute(h.Client)
+	resp, err := fileCreateTx.Sign(operator.PrivateKey).Execute(client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create file: %v", err)
 	}
 
-	receipt, err := resp.GetReceipt(h.Client)
+	receipt, err := resp.GetReceipt(client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get file creation receipt: %v", err)
 	}
@@ -153,13 +243,16 @@ func (h *HederaClient) createFileForCallData(data []byte) (*hedera.FileID, error
 			}
 
 			chunk := remaining[i:end]
-			appendTx := hedera.NewFileAppendTransaction().
+			appendTx, err := hedera.NewFileAppendTransaction().
 				SetFileID(*fileID).
-				SetContents(chunk)
-
-			_, err = appendTx.Execute(h.Client)
+				SetContents(chunk).
+				SetTransactionID(hedera.TransactionIDGenerate(operator.AccountID)).
+				FreezeWith(client)
+			if err == nil {
+				_, err = appendTx.Sign(operator.PrivateKey).Execute(client)
+			}
 			if err != nil {
-				_ = h.deleteFile(*fileID)
+				_ = h.deleteFile(client, operator, *fileID)
 				return nil, fmt.Errorf("failed to append chunk %d: %v", i/fileAppendChunkSize+1, err)
 			}
 		}
@@ -168,14 +261,16 @@ func (h *HederaClient) createFileForCallData(data []byte) (*hedera.FileID, error
 	return fileID, nil
 }
 
-func (h *HederaClient) deleteFile(fileID hedera.FileID) error {
+func (h *HederaClient) deleteFile(client *hedera.Client, operator Operator, fileID hedera.FileID) error {
 	deleteTx, err := hedera.NewFileDeleteTransaction().
-		SetFileID(fileID).SetMaxTransactionFee(hedera.NewHbar(2)).FreezeWith(h.Client)
+		SetFileID(fileID).SetMaxTransactionFee(hedera.NewHbar(2)).
+		SetTransactionID(hedera.TransactionIDGenerate(operator.AccountID)).
+		FreezeWith(client)
 	if err != nil {
 		return fmt.Errorf("failed to freeze delete transaction: %v", err)
 	}
 
-	_, err = deleteTx.Execute(h.Client)
+	_, err = deleteTx.Sign(operator.PrivateKey).Execute(client)
 	if err != nil {
 		return fmt.Errorf("failed to delete file: %v", err)
 	}
@@ -193,16 +288,17 @@ func (h *HederaClient) GetContractByteCode(shard, realm int64, address string) (
 		return nil, fmt.Errorf("failed to create contract ID from EVM address: %w", err)
 	}
 
+	client := h.sdk()
 	query := hedera.NewContractBytecodeQuery().SetContractID(contractID)
 
-	cost, err := query.GetCost(h.Client)
+	cost, err := query.GetCost(client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get query cost: %w", err)
 	}
 
 	query.SetQueryPayment(cost)
 
-	response, err := query.Execute(h.Client)
+	response, err := query.Execute(client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
@@ -210,6 +306,61 @@ func (h *HederaClient) GetContractByteCode(shard, realm int64, address string) (
 	return response, nil
 }
 
+// TransactionRecord is the outcome of a transaction as a consensus node
+// reports it.
+type TransactionRecord struct {
+	// Status is the receipt status, e.g. SUCCESS or CONTRACT_REVERT_EXECUTED.
+	Status string
+	// EthereumHash is the 0x prefixed hash of a successful Ethereum
+	// transaction, empty when the transaction failed or its record could not
+	// be fetched.
+	EthereumHash string
+}
+
+// GetTransactionRecord waits for the transaction with transactionId, as
+// returned by SendRawTransaction, to reach consensus and returns its status.
+// The free receipt is asked for first, and the paid record, which holds the
+// Ethereum hash, only for successful transactions.
+func (h *HederaClient) GetTransactionRecord(transactionId string) (*TransactionRecord, error) {
+	id, err := hedera.TransactionIdFromString(transactionId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction ID %s: %w", transactionId, err)
+	}
+
+	client := h.sdk()
+	receipt, err := hedera.NewTransactionReceiptQuery().SetTransactionID(id).Execute(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction receipt: %w", err)
+	}
+
+	result := &TransactionRecord{Status: receipt.Status.String()}
+	if receipt.Status != hedera.StatusSuccess {
+		return result, nil
+	}
+
+	query := hedera.NewTransactionRecordQuery().SetTransactionID(id)
+	cost, err := query.GetCost(client)
+	if err != nil {
+		return result, nil
+	}
+	query.SetQueryPayment(cost)
+
+	record, err := query.Execute(client)
+	if err != nil || len(record.EthereumHash) == 0 {
+		return result, nil
+	}
+	result.EthereumHash = "0x" + hex.EncodeToString(record.EthereumHash)
+
+	return result, nil
+}
+
+// GetOperatorPublicKey returns the EVM address, without 0x, of the primary
+// operator: the address derived from its key when it is an ECDSA key, or the
+// long-zero address of its account ID for ED25519 keys, which derive none.
 func (h *HederaClient) GetOperatorPublicKey() string {
-	return h.Client.GetOperatorPublicKey().ToEvmAddress()
+	operator := h.operators.Primary()
+	if address := operator.EvmAddress(); address != "" {
+		return strings.TrimPrefix(address, "0x")
+	}
+	return operator.AccountID.ToSolidityAddress()
 }
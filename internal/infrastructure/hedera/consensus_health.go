@@ -0,0 +1,284 @@
+package hedera
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/metrics"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"go.uber.org/zap"
+)
+
+// ConsensusHealthConfig controls the health checks of the consensus nodes.
+// Every Interval each node is pinged with a free balance query. Nodes that are
+// BUSY are left out of transactions for BusyCooldown, and the SDK client is
+// re-created after ReconnectAfter rounds in a row in which no node answered.
+type ConsensusHealthConfig struct {
+	Interval       time.Duration
+	BusyCooldown   time.Duration
+	ReconnectAfter int
+}
+
+// Consensus node states reported by ConsensusHealthStatus.
+const (
+	NodeHealthy     = "healthy"
+	NodeBusy        = "busy"
+	NodeUnreachable = "unreachable"
+)
+
+// ConsensusHealthStatus is the outcome of the latest consensus node checks,
+// listing node account IDs by state.
+type ConsensusHealthStatus struct {
+	Healthy     []string  `json:"healthy"`
+	Busy        []string  `json:"busy"`
+	Unreachable []string  `json:"unreachable"`
+	LastCheck   time.Time `json:"lastCheck"`
+	Reconnects  int       `json:"reconnects"`
+}
+
+// ConsensusNodeHealth tracks the state of every consensus node from the
+// results of health checks, and picks the nodes transactions are sent to.
+type ConsensusNodeHealth struct {
+	mu           sync.Mutex
+	network      string
+	busyCooldown time.Duration
+	states       map[string]nodeState
+	failedRounds int
+	lastCheck    time.Time
+	reconnects   int
+}
+
+type nodeState struct {
+	state     string
+	busyUntil time.Time
+}
+
+// NewConsensusNodeHealth creates the tracker for the nodes of network, which
+// labels the metrics.
+func NewConsensusNodeHealth(network string, busyCooldown time.Duration) *ConsensusNodeHealth {
+	return &ConsensusNodeHealth{
+		network:      network,
+		busyCooldown: busyCooldown,
+		states:       make(map[string]nodeState),
+	}
+}
+
+// Record stores the result of checking node: healthy when err is nil, busy
+// when the node is throttling and unreachable otherwise.
+func (c *ConsensusNodeHealth) Record(node string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state := nodeState{state: NodeHealthy}
+	var precheckErr hedera.ErrHederaPreCheckStatus
+	switch {
+	case err == nil:
+	case errors.As(err, &precheckErr) && (precheckErr.Status == hedera.StatusBusy || precheckErr.Status == hedera.StatusPlatformNotActive):
+		state = nodeState{state: NodeBusy, busyUntil: time.Now().Add(c.busyCooldown)}
+		metrics.ConsensusNodeBusy.WithLabelValues(c.network, node).Inc()
+	default:
+		state = nodeState{state: NodeUnreachable}
+	}
+	c.states[node] = state
+
+	up := 0.0
+	if state.state == NodeHealthy {
+		up = 1
+	}
+	metrics.ConsensusNodeUp.WithLabelValues(c.network, node).Set(up)
+}
+
+// EndRound closes a round of checks and returns how many rounds in a row no
+// node was healthy in.
+func (c *ConsensusNodeHealth) EndRound() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lastCheck = time.Now()
+	for _, state := range c.states {
+		if state.state == NodeHealthy {
+			c.failedRounds = 0
+			return 0
+		}
+	}
+	c.failedRounds++
+	return c.failedRounds
+}
+
+// Reconnected notes that the SDK client was re-created.
+func (c *ConsensusNodeHealth) Reconnected() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.reconnects++
+	c.failedRounds = 0
+	metrics.ConsensusClientReconnects.WithLabelValues(c.network).Inc()
+}
+
+// Available returns the nodes transactions should be sent to: those not known
+// to be unreachable, nor busy within the cooldown. It returns nil, leaving the
+// choice to the SDK, when every node qualifies or none does.
+func (c *ConsensusNodeHealth) Available(nodes []hedera.AccountID) []hedera.AccountID {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	available := make([]hedera.AccountID, 0, len(nodes))
+	for _, node := range nodes {
+		state, checked := c.states[node.String()]
+		switch {
+		case !checked, state.state == NodeHealthy:
+		case state.state == NodeBusy && now.After(state.busyUntil):
+		default:
+			continue
+		}
+		available = append(available, node)
+	}
+	if len(available) == 0 || len(available) == len(nodes) {
+		return nil
+	}
+	return available
+}
+
+// Status returns the state of every checked node.
+func (c *ConsensusNodeHealth) Status() ConsensusHealthStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	status := ConsensusHealthStatus{
+		Healthy:     []string{},
+		Busy:        []string{},
+		Unreachable: []string{},
+		LastCheck:   c.lastCheck,
+		Reconnects:  c.reconnects,
+	}
+	for node, state := range c.states {
+		switch state.state {
+		case NodeHealthy:
+			status.Healthy = append(status.Healthy, node)
+		case NodeBusy:
+			status.Busy = append(status.Busy, node)
+		default:
+			status.Unreachable = append(status.Unreachable, node)
+		}
+	}
+	sort.Strings(status.Healthy)
+	sort.Strings(status.Busy)
+	sort.Strings(status.Unreachable)
+	return status
+}
+
+// MonitorConsensusNodes checks every consensus node each config.Interval,
+// re-creating the SDK client when none answers for config.ReconnectAfter
+// rounds in a row. A zero interval disables the checks.
+func (h *HederaClient) MonitorConsensusNodes(config ConsensusHealthConfig, logger *zap.Logger) {
+	if config.Interval <= 0 {
+		return
+	}
+
+	h.mu.Lock()
+	h.health = NewConsensusNodeHealth(h.network, config.BusyCooldown)
+	h.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(config.Interval)
+		defer ticker.Stop()
+		for {
+			h.checkConsensusNodes(config, logger)
+			<-ticker.C
+		}
+	}()
+}
+
+func (h *HederaClient) checkConsensusNodes(config ConsensusHealthConfig, logger *zap.Logger) {
+	client := h.sdk()
+	health := h.consensusHealth()
+	deadline := config.Interval / 2
+
+	for _, node := range networkNodes(client) {
+		_, err := hedera.NewAccountBalanceQuery().
+			SetNodeAccountIDs([]hedera.AccountID{node}).
+			SetAccountID(client.GetOperatorAccountID()).
+			SetMaxRetry(1).
+			SetGrpcDeadline(&deadline).
+			Execute(client)
+		if err != nil {
+			logger.Warn("Consensus node health check failed", zap.String("node", node.String()), zap.Error(err))
+		}
+		health.Record(node.String(), err)
+	}
+
+	failedRounds := health.EndRound()
+	if config.ReconnectAfter <= 0 || failedRounds < config.ReconnectAfter {
+		return
+	}
+
+	logger.Warn("No consensus node answered, re-creating the Hedera client", zap.Int("failedRounds", failedRounds))
+	if err := h.reconnect(); err != nil {
+		logger.Error("Failed to re-create the Hedera client", zap.Error(err))
+		return
+	}
+	health.Reconnected()
+}
+
+// reconnect replaces the SDK client with a new one for the same network,
+// opening fresh gRPC channels. The old client is closed once the requests
+// still using it have had time to finish.
+func (h *HederaClient) reconnect() error {
+	client, err := newSDKClient(h.network, h.operators)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	old := h.client
+	h.client = client
+	h.mu.Unlock()
+
+	time.AfterFunc(reconnectGracePeriod, func() { _ = old.Close() })
+	return nil
+}
+
+// ConsensusHealth returns the result of the latest consensus node checks. It
+// reports false when the nodes are not monitored.
+func (h *HederaClient) ConsensusHealth() (ConsensusHealthStatus, bool) {
+	health := h.consensusHealth()
+	if health == nil {
+		return ConsensusHealthStatus{}, false
+	}
+	return health.Status(), true
+}
+
+func (h *HederaClient) consensusHealth() *ConsensusNodeHealth {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.health
+}
+
+// transactionNodes returns the nodes to send a transaction to, leaving out
+// those found busy or unreachable, or nil to let the SDK pick among all of
+// them.
+func (h *HederaClient) transactionNodes(client *hedera.Client) []hedera.AccountID {
+	health := h.consensusHealth()
+	if health == nil {
+		return nil
+	}
+	return health.Available(networkNodes(client))
+}
+
+// networkNodes returns the account IDs of the consensus nodes of client, each
+// once although nodes may have several addresses.
+func networkNodes(client *hedera.Client) []hedera.AccountID {
+	seen := make(map[string]bool)
+	var nodes []hedera.AccountID
+	for _, node := range client.GetNetwork() {
+		if !seen[node.String()] {
+			seen[node.String()] = true
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Account < nodes[j].Account })
+	return nodes
+}
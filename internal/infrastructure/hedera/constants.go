@@ -5,13 +5,16 @@ import "time"
 // Temorary file for constants
 
 const (
-	GetBlockByHashOrNumber = "getBlockByHashOrNumber"
-	GetContractResult      = "getContractResult"
-	GetContractById        = "getContractById"
-	GetAccountById         = "getAccountById"
-	GetTokenById           = "getTokenById"
+	GetBlockByHashOrNumber     = "getBlockByHashOrNumber"
+	GetContractResult          = "getContractResult"
+	GetContractById            = "getContractById"
+	GetAccountById             = "getAccountById"
+	GetTokenById               = "getTokenById"
+	GetContractsResultsActions = "getContractsResultsActions"
 
-	DefaultExpiration = 1 * time.Hour
+	// Prefix of the cache keys remembering that the mirror node had no record
+	// under the key that follows
+	notFoundPrefix = "not_found_"
 
 	// Maximum gas that can be used per second
 	maxGasPerSec = 15000000
@@ -19,9 +22,25 @@ const (
 	// Default file append chunk size
 	fileAppendChunkSize = 5120
 
-	maxRetries = 2
+	// Retry policy used where RetryPolicy leaves a field zero
+	defaultRetryAttempts  = 2
+	defaultRetryBaseDelay = 1 * time.Second
+	defaultRetryMaxDelay  = 5 * time.Second
 
-	retryDelay = 1 * time.Second
+	// Longest Retry-After of a mirror node 429 that is honored; longer waits
+	// are cut short so that a misconfigured mirror node cannot stall the relay
+	maxRetryAfter = time.Minute
+
+	// Least time a retried request is given; retries that would leave less
+	// before the deadline of the request are not attempted
+	minRetryAttemptTime = 100 * time.Millisecond
+
+	// Consecutive failures after which the active mirror node is abandoned
+	failoverThreshold = 3
+
+	// How long a re-created Hedera client keeps the old one open for the
+	// requests still using it
+	reconnectGracePeriod = time.Minute
 
 	Limit = 100
 
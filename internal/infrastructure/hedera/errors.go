@@ -0,0 +1,124 @@
+package hedera
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+)
+
+const contractRevertExecuted = "CONTRACT_REVERT_EXECUTED"
+
+// Errors returned, wrapped, by the MirrorClient methods so that callers can
+// tell a missing entity apart from a mirror node that cannot answer. Match
+// them with errors.Is.
+var (
+	// ErrNotFound means the mirror node has no such entity (HTTP 404).
+	ErrNotFound = errors.New("mirror node: not found")
+	// ErrRateLimited means the mirror node throttled the relay (HTTP 429).
+	ErrRateLimited = errors.New("mirror node: rate limited")
+	// ErrUpstreamUnavailable means the mirror node failed (HTTP 5xx) or could
+	// not be reached.
+	ErrUpstreamUnavailable = errors.New("mirror node: upstream unavailable")
+	// ErrTimeout means the mirror node did not answer within the client timeout.
+	ErrTimeout = errors.New("mirror node: request timed out")
+)
+
+// statusSentinel returns the sentinel error for an HTTP status code, or nil
+// when the status has none.
+func statusSentinel(statusCode int) error {
+	switch {
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case statusCode >= http.StatusInternalServerError:
+		return ErrUpstreamUnavailable
+	}
+	return nil
+}
+
+// StatusError is returned when the mirror node answers with a status other
+// than 200 OK. It matches the sentinel error for its status code.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("mirror node returned status %d", e.StatusCode)
+}
+
+func (e *StatusError) Unwrap() error {
+	return statusSentinel(e.StatusCode)
+}
+
+// isTransient reports whether err may go away when the request is repeated.
+func isTransient(err error) bool {
+	return errors.Is(err, ErrUpstreamUnavailable) || errors.Is(err, ErrRateLimited) || errors.Is(err, ErrTimeout)
+}
+
+func statusError(statusCode int) error {
+	return &StatusError{StatusCode: statusCode}
+}
+
+// requestError classifies an error from sending a request to the mirror node.
+// Cancellation by the caller is returned unchanged.
+func requestError(err error) error {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		return err
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	}
+	return fmt.Errorf("%w: %w", ErrUpstreamUnavailable, err)
+}
+
+// ContractCallError is returned by PostCall when the mirror node rejects a
+// contract call, most commonly because the contract reverted.
+type ContractCallError struct {
+	StatusCode int
+	Message    string
+	Detail     string
+	Data       string
+}
+
+func (e *ContractCallError) Error() string {
+	if e.Detail != "" {
+		return fmt.Sprintf("mirror node returned status %d: %s: %s", e.StatusCode, e.Message, e.Detail)
+	}
+	return fmt.Sprintf("mirror node returned status %d: %s", e.StatusCode, e.Message)
+}
+
+// IsContractRevert reports whether the call failed because the contract reverted.
+func (e *ContractCallError) IsContractRevert() bool {
+	return e.Message == contractRevertExecuted
+}
+
+// Unwrap exposes the sentinel error matching the status code, so that a call
+// failing because the mirror node is down or throttling is not mistaken for a
+// revert.
+func (e *ContractCallError) Unwrap() error {
+	return statusSentinel(e.StatusCode)
+}
+
+// newContractCallError builds a ContractCallError from the first message of a
+// mirror node error body. The body is optional.
+func newContractCallError(statusCode int, body io.Reader) *ContractCallError {
+	callErr := &ContractCallError{StatusCode: statusCode}
+
+	var errorResponse domain.MirrorNodeErrorResponse
+	if err := json.NewDecoder(body).Decode(&errorResponse); err == nil && len(errorResponse.Status.Messages) > 0 {
+		message := errorResponse.Status.Messages[0]
+		callErr.Message = message.Message
+		callErr.Detail = message.Detail
+		callErr.Data = message.Data
+	}
+
+	return callErr
+}
@@ -4,76 +4,136 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/LimeChain/Hederium/internal/domain"
 	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 )
 
+// MirrorNodeClient reads from the mirror node REST API. Errors wrap
+// ErrNotFound, ErrRateLimited, ErrUpstreamUnavailable or ErrTimeout when the
+// mirror node could not provide an answer.
 type MirrorNodeClient interface {
-	GetLatestBlock() (map[string]interface{}, error)
-	GetBlocks(blockNumber string) ([]map[string]interface{}, error)
-	GetBlockByHashOrNumber(hashOrNumber string) *domain.BlockResponse
-	GetNetworkFees(timestampTo, order string) (int64, error)
-	GetContractResults(timestamp domain.Timestamp) []domain.ContractResults
-	GetBalance(address string, timestampTo string) string
-	GetAccount(address string, timestampTo string) interface{}
-	GetContractResult(transactionId string) interface{}
-	PostCall(callObject map[string]interface{}) interface{}
-	GetContractStateByAddressAndSlot(address string, slot string, timestampTo string) (*domain.ContractStateResponse, error)
-	GetContractResultsLogsByAddress(address string, queryParams map[string]interface{}) ([]domain.LogEntry, error)
-	GetContractResultsLogsWithRetry(queryParams map[string]interface{}) ([]domain.LogEntry, error)
-	GetContractResultWithRetry(queryParams map[string]interface{}) (*domain.ContractResults, error)
-	GetContractById(contractIdOrAddress string) (*domain.ContractResponse, error)
-	GetAccountById(idOrAliasOrEvmAddress string) (*domain.AccountResponse, error)
-	GetTokenById(tokenId string) (*domain.TokenResponse, error)
-	RepeatGetContractResult(transactionIdOrHash string, retries int) *domain.ContractResultResponse
+	GetLatestBlock(ctx context.Context) (map[string]interface{}, error)
+	GetBlocks(ctx context.Context, blockNumber string) ([]map[string]interface{}, error)
+	GetBlockByHashOrNumber(ctx context.Context, hashOrNumber string) (*domain.BlockResponse, error)
+	GetNetworkFees(ctx context.Context, timestampTo, order string) (int64, error)
+	GetExchangeRate(ctx context.Context) (*domain.ExchangeRateResponse, error)
+	GetContractResults(ctx context.Context, timestamp domain.Timestamp) ([]domain.ContractResult, error)
+	GetContractResultsBySender(ctx context.Context, address string, timestampTo string) ([]domain.ContractResult, error)
+	GetBalance(ctx context.Context, address string, timestampTo string) (string, error)
+	GetAccount(ctx context.Context, address string, timestampTo string) (interface{}, error)
+	GetContractResult(ctx context.Context, transactionId string) (*domain.ContractResult, error)
+	PostCall(ctx context.Context, callObject map[string]interface{}) (interface{}, error)
+	GetContractStateByAddressAndSlot(ctx context.Context, address string, slot string, timestampTo string) (*domain.ContractStateResponse, error)
+	GetContractResultsLogsByAddress(ctx context.Context, address string, queryParams map[string]interface{}) ([]domain.LogEntry, error)
+	GetContractResultsLogsWithRetry(ctx context.Context, queryParams map[string]interface{}) ([]domain.LogEntry, error)
+	StreamContractResultsLogs(ctx context.Context, address string, queryParams map[string]interface{}, handle func([]domain.LogEntry) error) error
+	GetContractResultWithRetry(ctx context.Context, queryParams map[string]interface{}) (*domain.ContractResult, error)
+	GetContractById(ctx context.Context, contractIdOrAddress string) (*domain.ContractResponse, error)
+	GetContractByIdWithRetry(ctx context.Context, contractIdOrAddress string) (*domain.ContractResponse, error)
+	GetAccountById(ctx context.Context, idOrAliasOrEvmAddress string) (*domain.AccountResponse, error)
+	GetTokenById(ctx context.Context, tokenId string) (*domain.TokenResponse, error)
+	GetTokenRelationships(ctx context.Context, idOrAliasOrEvmAddress string) ([]domain.TokenRelationship, error)
+	GetTokenAllowance(ctx context.Context, owner, spender, tokenId string) (*domain.TokenAllowance, error)
+	RepeatGetContractResult(ctx context.Context, transactionIdOrHash string, retries int) (*domain.ContractResult, error)
+	GetContractsResultsActions(ctx context.Context, transactionIdOrHash string) (*domain.ContractActionsResponse, error)
+	GetContractsResultsOpcodes(ctx context.Context, transactionIdOrHash string, stack, memory, storage bool) (*domain.OpcodesResponse, error)
 }
 
 type MirrorClient struct {
-	BaseURL      string
-	Timeout      time.Duration
-	logger       *zap.Logger
-	cacheService cache.CacheService
+	Timeout time.Duration
+	// MaxPages bounds the pages a paginated query follows; queries with more
+	// fail with a *PageLimitError. Zero uses the MaxPages constant.
+	MaxPages int
+	// Retry controls how the WithRetry methods repeat failed requests.
+	Retry RetryPolicy
+	// OnResult, when set, is told of every request that reached or failed to
+	// reach the mirror node and whether it failed.
+	OnResult func(failed bool)
+	// OnRateLimited, when set, is told every time the mirror node throttles
+	// the relay and how long it asked the relay to wait, which is 0 when it
+	// did not say.
+	OnRateLimited func(retryAfter time.Duration)
+	// CacheTTLs sets how long the blocks, contract results, contracts,
+	// accounts and tokens fetched are cached, cache.DefaultTTL for classes
+	// left unset.
+	CacheTTLs cache.TTLs
+	// NotFoundTTL sets how long the blocks and contract results the mirror
+	// node has no record of are remembered as missing, so that clients
+	// polling for them do not reach the mirror node on every call. Zero
+	// disables it.
+	NotFoundTTL time.Duration
+	// SchemaAliases names the fields newer mirror nodes renamed, see
+	// SchemaAliases.
+	SchemaAliases SchemaAliases
+	// version is the mirror node version found by DetectVersion.
+	version atomic.Value
+	drift   schemaDrift
+	// throttledUntil is the end, in Unix nanoseconds, of the latest wait the
+	// mirror node asked for with a Retry-After header.
+	throttledUntil atomic.Int64
+	logger         *zap.Logger
+	cacheService   cache.CacheService
+	endpoints      *mirrorEndpoints
+	// web3 are the endpoints contract calls go to, endpoints unless
+	// UseWeb3URLs set others.
+	web3 *mirrorEndpoints
+	// flight collapses concurrent cache misses for the same key into a single
+	// mirror node request.
+	flight singleflight.Group
 }
 
-func NewMirrorClient(baseURL string, timeoutSeconds int, logger *zap.Logger, cacheService cache.CacheService) *MirrorClient {
+// NewMirrorClient creates a client for the given mirror node base URLs. The
+// first URL is the primary; the others are used in order when it fails.
+func NewMirrorClient(baseURLs []string, timeoutSeconds int, logger *zap.Logger, cacheService cache.CacheService) *MirrorClient {
+	urls := make([]string, 0, len(baseURLs))
+	for _, url := range baseURLs {
+		urls = append(urls, strings.TrimSuffix(url, "/"))
+	}
+
+	endpoints := newMirrorEndpoints(urls, logger)
 	return &MirrorClient{
-		BaseURL:      baseURL,
 		Timeout:      time.Duration(timeoutSeconds) * time.Second,
+		MaxPages:     MaxPages,
 		logger:       logger,
 		cacheService: cacheService,
+		endpoints:    endpoints,
+		web3:         endpoints,
 	}
 }
 
-func (m *MirrorClient) GetLatestBlock() (map[string]interface{}, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), m.Timeout)
+func (m *MirrorClient) GetLatestBlock(ctx context.Context) (map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.BaseURL+"/api/v1/blocks?order=desc&limit=1", nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.BaseURL()+"/api/v1/blocks?order=desc&limit=1", nil)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := m.do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("mirror node returned status %d", resp.StatusCode)
+		return nil, statusError(resp.StatusCode)
 	}
 
 	var result struct {
 		Blocks []map[string]interface{} `json:"blocks"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := m.decode(resp, &result); err != nil {
 		return nil, err
 	}
 	if len(result.Blocks) == 0 {
@@ -83,13 +143,13 @@ func (m *MirrorClient) GetLatestBlock() (map[string]interface{}, error) {
 	return result.Blocks[0], nil
 }
 
-func (m *MirrorClient) GetBlocks(blockNumber string) ([]map[string]interface{}, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), m.Timeout)
+func (m *MirrorClient) GetBlocks(ctx context.Context, blockNumber string) ([]map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
 	defer cancel()
 
 	str := fmt.Sprintf("block.number=gt:%s&order=asc", blockNumber)
 
-	url := fmt.Sprintf("%s/api/v1/blocks?%s", m.BaseURL, str)
+	url := fmt.Sprintf("%s/api/v1/blocks?%s", m.BaseURL(), str)
 
 	m.logger.Info("Gettting blocks", zap.String("url", url))
 
@@ -98,71 +158,111 @@ func (m *MirrorClient) GetBlocks(blockNumber string) ([]map[string]interface{},
 		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := m.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error getting blocks: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("mirror node returned status %d", resp.StatusCode)
+		return nil, statusError(resp.StatusCode)
 	}
 
 	var result struct {
 		Blocks []map[string]interface{} `json:"blocks"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := m.decode(resp, &result); err != nil {
 		return nil, fmt.Errorf("no blocks returned by mirror node")
 	}
 
 	return result.Blocks, nil
 }
 
-func (m *MirrorClient) GetBlockByHashOrNumber(hashOrNumber string) *domain.BlockResponse {
-	ctx, cancel := context.WithTimeout(context.Background(), m.Timeout)
-	defer cancel()
-
+func (m *MirrorClient) GetBlockByHashOrNumber(ctx context.Context, hashOrNumber string) (*domain.BlockResponse, error) {
 	cachedKey := fmt.Sprintf("%s_%s", GetBlockByHashOrNumber, hashOrNumber)
 
 	var cachedBlock domain.BlockResponse
 	if err := m.cacheService.Get(ctx, cachedKey, &cachedBlock); err == nil && cachedBlock.Hash != "" {
-		return &cachedBlock
+		return &cachedBlock, nil
+	}
+	if m.cachedNotFound(ctx, cachedKey) {
+		return nil, statusError(http.StatusNotFound)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.BaseURL+"/api/v1/blocks/"+hashOrNumber, nil)
+	// The shared fetch must not fail for every waiter when the first caller goes away
+	result, err, _ := m.flight.Do(cachedKey, func() (interface{}, error) {
+		return m.fetchBlockByHashOrNumber(context.WithoutCancel(ctx), cachedKey, hashOrNumber)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	blockCopy := *result.(*domain.BlockResponse)
+	return &blockCopy, nil
+}
+
+func (m *MirrorClient) fetchBlockByHashOrNumber(ctx context.Context, cachedKey, hashOrNumber string) (*domain.BlockResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.BaseURL()+"/api/v1/blocks/"+hashOrNumber, nil)
 	if err != nil {
 		m.logger.Error("Error creating request to get block by hash or number", zap.Error(err))
-		return nil
+		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := m.do(req)
 	if err != nil {
 		m.logger.Error("Error getting block by hash or number", zap.Error(err))
-		return nil
+		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 	if resp.StatusCode != http.StatusOK {
-		m.logger.Error("Mirror node returned status", zap.Int("status", resp.StatusCode))
-		return nil
+		m.logStatus(resp.StatusCode)
+		if resp.StatusCode == http.StatusNotFound {
+			m.cacheNotFound(ctx, cachedKey)
+		}
+		return nil, statusError(resp.StatusCode)
 	}
 
 	var result domain.BlockResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := m.decode(resp, &result); err != nil {
 		m.logger.Error("Error decoding response body", zap.Error(err))
-		return nil
+		return nil, err
 	}
 
-	if err := m.cacheService.Set(ctx, cachedKey, &result, DefaultExpiration); err != nil {
+	if err := m.cacheService.Set(ctx, cachedKey, &result, m.CacheTTLs.Of(cache.ClassBlock)); err != nil {
 		m.logger.Error("Error caching block", zap.Error(err))
 	}
 
 	m.logger.Debug("Block", zap.Any("block", result))
-	return &result
+	return &result, nil
 }
 
-func (m *MirrorClient) GetNetworkFees(timestampTo, order string) (int64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), m.Timeout)
+// cachedNotFound reports whether the mirror node had no record under the
+// cache key within the last NotFoundTTL.
+func (m *MirrorClient) cachedNotFound(ctx context.Context, cachedKey string) bool {
+	if m.NotFoundTTL <= 0 {
+		return false
+	}
+	var notFound bool
+	return m.cacheService.Get(ctx, notFoundPrefix+cachedKey, &notFound) == nil && notFound
+}
+
+// cacheNotFound remembers for NotFoundTTL that the mirror node had no record
+// under the cache key.
+func (m *MirrorClient) cacheNotFound(ctx context.Context, cachedKey string) {
+	if m.NotFoundTTL <= 0 {
+		return
+	}
+	if err := m.cacheService.Set(ctx, notFoundPrefix+cachedKey, true, m.NotFoundTTL); err != nil {
+		m.logger.Error("Error caching not found answer", zap.String("key", cachedKey), zap.Error(err))
+	}
+}
+
+func (m *MirrorClient) GetNetworkFees(ctx context.Context, timestampTo, order string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
 	defer cancel()
 
 	queryParams := ""
@@ -175,32 +275,26 @@ func (m *MirrorClient) GetNetworkFees(timestampTo, order string) (int64, error)
 		queryParams += "&timestamp=lte:" + timestampTo
 	}
 
-	m.logger.Debug("Asking this endpoint:", zap.String("url", m.BaseURL+"/api/v1/network/fees"+queryParams))
+	m.logger.Debug("Asking this endpoint:", zap.String("url", m.BaseURL()+"/api/v1/network/fees"+queryParams))
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.BaseURL+"/api/v1/network/fees"+queryParams, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.BaseURL()+"/api/v1/network/fees"+queryParams, nil)
 	if err != nil {
 		return 0, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := m.do(req)
 	if err != nil {
 		return 0, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 	// TODO: If the mirror node does not return fee then ask the SDK for the fee
-	var checkSDK bool
 	if resp.StatusCode != http.StatusOK {
-		m.logger.Error("Mirror node returned status", zap.Int("status", resp.StatusCode))
-		// return 0, fmt.Errorf("mirror node returned status %d", resp.StatusCode)
-		checkSDK = true
-	}
-	// For now the default fee is 23
-	if checkSDK {
-		return 23, nil
+		m.logStatus(resp.StatusCode)
+		return 0, statusError(resp.StatusCode)
 	}
 	var feeResponse domain.FeeResponse
 
-	if err := json.NewDecoder(resp.Body).Decode(&feeResponse); err != nil {
+	if err := m.decode(resp, &feeResponse); err != nil {
 		return 0, err
 	}
 
@@ -220,87 +314,167 @@ func (m *MirrorClient) GetNetworkFees(timestampTo, order string) (int64, error)
 	return gasTinybars, nil
 }
 
-func (m *MirrorClient) GetContractResults(timestamp domain.Timestamp) []domain.ContractResults {
-	var allResults []domain.ContractResults
+// GetExchangeRate returns the current and next HBAR to USD exchange rates of
+// the network.
+func (m *MirrorClient) GetExchangeRate(ctx context.Context) (*domain.ExchangeRateResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.BaseURL()+"/api/v1/network/exchangerate", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		m.logStatus(resp.StatusCode)
+		return nil, statusError(resp.StatusCode)
+	}
+
+	var result domain.ExchangeRateResponse
+	if err := m.decode(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (m *MirrorClient) GetContractResults(ctx context.Context, timestamp domain.Timestamp) ([]domain.ContractResult, error) {
+	var allResults []domain.ContractResult
 	currentURL := fmt.Sprintf("%s/api/v1/contracts/results?timestamp=gte:%s&timestamp=lte:%s&limit=100&order=asc",
-		m.BaseURL, timestamp.From, timestamp.To)
+		m.BaseURL(), timestamp.From, timestamp.To)
 
 	for currentURL != "" {
-		ctx, cancel := context.WithTimeout(context.Background(), m.Timeout)
+		ctx, cancel := context.WithTimeout(ctx, m.Timeout)
 		defer cancel()
 
 		req, err := http.NewRequestWithContext(ctx, http.MethodGet, currentURL, nil)
 		if err != nil {
 			m.logger.Error("Error creating request", zap.Error(err))
-			return []domain.ContractResults{} // Return empty array instead of nil
+			return nil, err
 		}
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := m.do(req)
 		if err != nil {
 			m.logger.Error("Error making request", zap.Error(err))
-			return []domain.ContractResults{} // Return empty array instead of nil
+			return nil, err
 		}
 		defer func() { _ = resp.Body.Close() }()
 
 		if resp.StatusCode != http.StatusOK {
-			m.logger.Error("Mirror node returned status", zap.Int("status", resp.StatusCode))
-			return []domain.ContractResults{} // Return empty array instead of nil
+			m.logStatus(resp.StatusCode)
+			return nil, statusError(resp.StatusCode)
 		}
 
-		var result struct {
-			Results []domain.ContractResults `json:"results"`
-			Links   struct {
-				Next *string `json:"next"`
-			} `json:"links"`
-		}
+		var result domain.ContractResultsResponse
 
-		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		if err := m.decode(resp, &result); err != nil {
 			m.logger.Error("Error decoding response body", zap.Error(err))
-			return []domain.ContractResults{} // Return empty array instead of nil
+			return nil, err
 		}
 
 		// It's okay if there are no results, just continue with the empty array
-		allResults = append(allResults, result.Results...)
+		allResults = append(allResults, normalizeContractResults(result.Results)...)
 
 		// Update URL for next iteration or break the loop
 		if result.Links.Next != nil {
-			currentURL = m.BaseURL + *result.Links.Next
+			currentURL = m.BaseURL() + *result.Links.Next
 		} else {
 			currentURL = ""
 		}
 	}
 
-	return allResults
+	return allResults, nil
+}
+
+// GetContractResultsBySender returns every contract result sent from address
+// up to and including timestampTo, oldest first, following pagination links.
+func (m *MirrorClient) GetContractResultsBySender(ctx context.Context, address string, timestampTo string) ([]domain.ContractResult, error) {
+	url := fmt.Sprintf("%s/api/v1/contracts/results?from=%s&timestamp=lte:%s&limit=%d&order=asc", m.BaseURL(), address, timestampTo, Limit)
+
+	var allResults []domain.ContractResult
+	maxPages := m.pageBudget(ctx)
+	for page := 1; page <= maxPages; page++ {
+		result, err := m.fetchContractResultsPage(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+
+		allResults = append(allResults, normalizeContractResults(result.Results)...)
+
+		if result.Links.Next == nil {
+			return allResults, nil
+		}
+		url = m.BaseURL() + *result.Links.Next
+	}
+
+	return nil, fmt.Errorf("contract results for %s: %w", address, &PageLimitError{MaxPages: maxPages})
+}
+
+func (m *MirrorClient) fetchContractResultsPage(ctx context.Context, url string) (*domain.ContractResultsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError(resp.StatusCode)
+	}
+
+	var result domain.ContractResultsResponse
+	if err := m.decode(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
 }
 
-func (m *MirrorClient) GetBalance(address string, timestampTo string) string {
+// GetBalance returns the balance of address in weibars as a hex string, or
+// "0x0" when the mirror node knows no balance for it. Mirror nodes snapshot
+// balances only every few minutes, so a balance at timestampTo adds the hbar
+// transfers made between the snapshot before it and timestampTo.
+func (m *MirrorClient) GetBalance(ctx context.Context, address string, timestampTo string) (string, error) {
 	m.logger.Debug("Getting balance", zap.String("address", address), zap.String("timestampTo", timestampTo))
-	ctx, cancel := context.WithTimeout(context.Background(), m.Timeout)
+	reqCtx, cancel := context.WithTimeout(ctx, m.Timeout)
 	defer cancel()
 
 	var reqUrl string
 	if timestampTo == "0" {
-		reqUrl = m.BaseURL + "/api/v1/balances?account.id=" + address
+		reqUrl = m.BaseURL() + "/api/v1/balances?account.id=" + address
 	} else {
-		reqUrl = m.BaseURL + "/api/v1/balances?account.id=" + address + "&timestamp=lte:" + timestampTo
+		reqUrl = m.BaseURL() + "/api/v1/balances?account.id=" + address + "&timestamp=lte:" + timestampTo
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqUrl, nil)
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, reqUrl, nil)
 	if err != nil {
 		m.logger.Error("Error creating request to get balance", zap.Error(err))
-		return "0x0"
+		return "", err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := m.do(req)
 	if err != nil {
 		m.logger.Error("Error getting balance", zap.Error(err))
-		return "0x0"
+		return "", err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		m.logger.Error("Mirror node returned status", zap.Int("status", resp.StatusCode))
-		return "0x0"
+		m.logStatus(resp.StatusCode)
+		return "", statusError(resp.StatusCode)
 	}
 
 	var result struct {
@@ -315,160 +489,293 @@ func (m *MirrorClient) GetBalance(address string, timestampTo string) string {
 		} `json:"links"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := m.decode(resp, &result); err != nil {
 		m.logger.Error("Error decoding response body", zap.Error(err))
-		return "0x0"
+		return "", err
 	}
 
 	m.logger.Debug("Balance", zap.Any("balance", result))
 	if len(result.Balances) == 0 {
 		m.logger.Debug("No balances found")
-		return "0x0"
+		return "0x0", nil
+	}
+
+	balance := new(big.Int).Set(result.Balances[0].Balance)
+	if timestampTo != "0" && timestampBefore(result.Timestamp, timestampTo) {
+		delta, err := m.transfersSum(ctx, result.Balances[0].Account, result.Timestamp, timestampTo)
+		if err != nil {
+			m.logger.Error("Error getting transfers since the balance snapshot", zap.Error(err))
+			return "", err
+		}
+		balance.Add(balance, big.NewInt(delta))
 	}
 
 	// Convert tinybars to weibars
-	balance := result.Balances[0].Balance.Mul(result.Balances[0].Balance, big.NewInt(10000000000))
-	return "0x" + fmt.Sprintf("%x", balance)
+	balance.Mul(balance, big.NewInt(domain.WeibarsPerTinybar))
+	return "0x" + fmt.Sprintf("%x", balance), nil
+}
+
+// transfersSum returns the net hbar amount, in tinybars, the transactions
+// after timestampFrom and up to timestampTo moved to account. Staking rewards
+// paid to it are in transfers already, as a transfer from 0.0.800, so
+// staking_reward_transfers are not added again.
+func (m *MirrorClient) transfersSum(ctx context.Context, account, timestampFrom, timestampTo string) (int64, error) {
+	url := fmt.Sprintf("%s/api/v1/transactions?account.id=%s&timestamp=gt:%s&timestamp=lte:%s&order=asc&limit=%d", m.BaseURL(), account, timestampFrom, timestampTo, Limit)
+
+	var sum int64
+	maxPages := m.pageBudget(ctx)
+	for page := 1; page <= maxPages; page++ {
+		result, err := m.fetchTransactionsPage(ctx, url)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, tx := range result.Transactions {
+			for _, transfer := range tx.Transfers {
+				if transfer.Account == account {
+					sum += transfer.Amount
+				}
+			}
+		}
+
+		if result.Links.Next == nil {
+			return sum, nil
+		}
+		url = m.BaseURL() + *result.Links.Next
+	}
+
+	return 0, fmt.Errorf("transfers of %s: %w", account, &PageLimitError{MaxPages: maxPages})
+}
+
+func (m *MirrorClient) fetchTransactionsPage(ctx context.Context, url string) (*domain.TransactionsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError(resp.StatusCode)
+	}
+
+	var result domain.TransactionsResponse
+	if err := m.decode(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
 }
 
-func (m *MirrorClient) GetAccount(address string, timestampTo string) interface{} {
-	ctx, cancel := context.WithTimeout(context.Background(), m.Timeout)
+// timestampBefore reports whether the consensus timestamp a, in
+// seconds.nanoseconds, is earlier than b. Timestamps that do not parse are
+// never earlier.
+func timestampBefore(a, b string) bool {
+	ra, ok := new(big.Rat).SetString(a)
+	if !ok {
+		return false
+	}
+	rb, ok := new(big.Rat).SetString(b)
+	if !ok {
+		return false
+	}
+	return ra.Cmp(rb) < 0
+}
+
+func (m *MirrorClient) GetAccount(ctx context.Context, address string, timestampTo string) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.BaseURL+"/api/v1/accounts/"+address+"?limit=1&order=desc&timestamp=lte:"+timestampTo+"&transactiontype=ETHEREUMTRANSACTION&transactions=true", nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.BaseURL()+"/api/v1/accounts/"+address+"?limit=1&order=desc&timestamp=lte:"+timestampTo+"&transactiontype=ETHEREUMTRANSACTION&transactions=true", nil)
 	if err != nil {
 		m.logger.Error("Error creating request to get account", zap.Error(err))
-		return nil
+		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := m.do(req)
 	if err != nil {
 		m.logger.Error("Error getting account", zap.Error(err))
-		return nil
+		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		m.logger.Error("Mirror node returned status", zap.Int("status", resp.StatusCode))
-		return nil
+		m.logStatus(resp.StatusCode)
+		return nil, statusError(resp.StatusCode)
 	}
 
 	var result domain.AccountResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := m.decode(resp, &result); err != nil {
 		m.logger.Error("Error decoding response body", zap.Error(err))
-		return nil
+		return nil, err
 	}
 
-	return result
+	return result, nil
 }
 
-func (m *MirrorClient) GetContractResult(transactionIdOrHash string) interface{} {
-	ctx, cancel := context.WithTimeout(context.Background(), m.Timeout)
+// GetContractResult returns the contract result of the transaction with a
+// transaction ID or Ethereum hash.
+func (m *MirrorClient) GetContractResult(ctx context.Context, transactionIdOrHash string) (*domain.ContractResult, error) {
+	return m.getContractResult(ctx, transactionIdOrHash, true)
+}
+
+// getContractResult returns the contract result of a transaction, answering
+// from a recent not found answer of the mirror node when useNotFound is set.
+func (m *MirrorClient) getContractResult(ctx context.Context, transactionIdOrHash string, useNotFound bool) (*domain.ContractResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
 	defer cancel()
 
 	cachedKey := fmt.Sprintf("%s_%s", GetContractResult, transactionIdOrHash)
 
-	var cachedResult domain.ContractResultResponse
+	var cachedResult domain.ContractResult
 	if err := m.cacheService.Get(ctx, cachedKey, &cachedResult); err == nil && cachedResult.BlockHash != "" {
 		m.logger.Info("Contract result found in cache", zap.Any("result", cachedResult))
-		return cachedResult
+		return &cachedResult, nil
+	}
+	if useNotFound && m.cachedNotFound(ctx, cachedKey) {
+		return nil, statusError(http.StatusNotFound)
 	}
 
-	url := fmt.Sprintf("%s/api/v1/contracts/results/%s", m.BaseURL, transactionIdOrHash)
+	url := fmt.Sprintf("%s/api/v1/contracts/results/%s", m.BaseURL(), transactionIdOrHash)
 
 	m.logger.Info("Getting contract result", zap.String("url", url))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		m.logger.Error("Error creating request to get contract result", zap.Error(err))
-		return nil
+		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := m.do(req)
 	if err != nil {
 		m.logger.Error("Error getting contract result", zap.Error(err))
-		return nil
+		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		m.logger.Error("Mirror node returned status", zap.Int("status", resp.StatusCode))
-		return nil
+		m.logStatus(resp.StatusCode)
+		if resp.StatusCode == http.StatusNotFound && useNotFound {
+			m.cacheNotFound(ctx, cachedKey)
+		}
+		return nil, statusError(resp.StatusCode)
 	}
 
-	var result domain.ContractResultResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	var result domain.ContractResult
+	if err := m.decode(resp, &result); err != nil {
 		m.logger.Error("Error decoding response body", zap.Error(err))
-		return nil
+		return nil, err
 	}
+	normalizeContractResult(&result)
 
-	if err := m.cacheService.Set(ctx, cachedKey, &result, DefaultExpiration); err != nil {
+	if err := m.cacheService.Set(ctx, cachedKey, &result, m.CacheTTLs.Of(cache.ClassTransaction)); err != nil {
 		m.logger.Error("Error caching contract result", zap.Error(err))
 	}
 
-	cachedKeyHash := fmt.Sprintf("%s_%s", GetContractResult, result.Hash[:66])
-	if err := m.cacheService.Set(ctx, cachedKeyHash, &result, DefaultExpiration); err != nil {
+	cachedKeyHash := fmt.Sprintf("%s_%s", GetContractResult, result.Hash)
+	if err := m.cacheService.Set(ctx, cachedKeyHash, &result, m.CacheTTLs.Of(cache.ClassTransaction)); err != nil {
 		m.logger.Error("Error caching contract result", zap.Error(err))
 	}
 
 	m.logger.Info("Contract result", zap.Any("result", result))
 
-	return result
+	return &result, nil
+}
+
+// normalizeContractResult converts a contract result as sent by the mirror
+// node to the form the relay works with: the 48 byte block hashes of Hedera
+// and any longer transaction hash are cut to the 32 bytes of an Ethereum hash.
+func normalizeContractResult(result *domain.ContractResult) {
+	result.Hash = trimHash(result.Hash)
+	result.BlockHash = trimHash(result.BlockHash)
+}
+
+func normalizeContractResults(results []domain.ContractResult) []domain.ContractResult {
+	for i := range results {
+		normalizeContractResult(&results[i])
+	}
+	return results
 }
 
-func (m *MirrorClient) RepeatGetContractResult(transactionIdOrHash string, retries int) *domain.ContractResultResponse {
+func trimHash(hash string) string {
+	if len(hash) > 66 {
+		return hash[:66]
+	}
+	return hash
+}
+
+// RepeatGetContractResult polls for the contract result once a second until it
+// is found, retries run out or ctx is done. It returns the error of the last
+// attempt when the result never appears.
+func (m *MirrorClient) RepeatGetContractResult(ctx context.Context, transactionIdOrHash string, retries int) (*domain.ContractResult, error) {
+	err := ErrNotFound
 	for i := 0; i < retries; i++ {
-		result := m.GetContractResult(transactionIdOrHash)
-		if result, ok := result.(domain.ContractResultResponse); ok {
-			return &result
+		// The result is expected to appear any moment, so earlier not found
+		// answers are of no use
+		var result *domain.ContractResult
+		result, err = m.getContractResult(ctx, transactionIdOrHash, false)
+		if err == nil && result != nil {
+			return result, nil
 		}
 
-		time.Sleep(1 * time.Second)
+		if err := sleepWithContext(ctx, 1*time.Second); err != nil {
+			return nil, err
+		}
 	}
-	return nil
+	return nil, err
 }
 
-func (m *MirrorClient) PostCall(callObject map[string]interface{}) interface{} {
-	ctx, cancel := context.WithTimeout(context.Background(), m.Timeout)
+// PostCall simulates a contract call through the mirror node. A call rejected
+// by the mirror node yields a *ContractCallError carrying the revert data.
+func (m *MirrorClient) PostCall(ctx context.Context, callObject map[string]interface{}) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
 	defer cancel()
 
 	jsonBody, err := json.Marshal(callObject)
 	if err != nil {
 		m.logger.Error("Error marshaling call object", zap.Error(err))
-		return nil
+		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.BaseURL+"/api/v1/contracts/call", bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.Web3URL()+"/api/v1/contracts/call", bytes.NewBuffer(jsonBody))
 	if err != nil {
 		m.logger.Error("Error creating request for contract call", zap.Error(err))
-		return nil
+		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := m.doWeb3(req)
 	if err != nil {
 		m.logger.Error("Error making contract call", zap.Error(err))
-		return nil
+		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		m.logger.Error("Mirror node returned non-OK status", zap.Int("status", resp.StatusCode))
-		return nil
+		callErr := newContractCallError(resp.StatusCode, resp.Body)
+		m.logger.Error("Mirror node returned non-OK status", zap.Int("status", resp.StatusCode), zap.Error(callErr))
+		return nil, callErr
 	}
 
 	var result struct {
 		Result string `json:"result"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := m.decode(resp, &result); err != nil {
 		m.logger.Error("Error decoding response body", zap.Error(err))
-		return nil
+		return nil, err
 	}
 
-	return result.Result
+	return result.Result, nil
 }
 
-func (m *MirrorClient) GetContractStateByAddressAndSlot(address string, slot string, timestampTo string) (*domain.ContractStateResponse, error) {
+func (m *MirrorClient) GetContractStateByAddressAndSlot(ctx context.Context, address string, slot string, timestampTo string) (*domain.ContractStateResponse, error) {
 	queryParams := make([]string, 0, 3)
 
 	// Hardcode limit and order
@@ -481,11 +788,11 @@ func (m *MirrorClient) GetContractStateByAddressAndSlot(address string, slot str
 
 	queryParams = append(queryParams, "slot="+fmt.Sprint(slot))
 
-	url := fmt.Sprintf("%s/api/v1/contracts/%s/state?%s", m.BaseURL, address, strings.Join(queryParams, "&"))
+	url := fmt.Sprintf("%s/api/v1/contracts/%s/state?%s", m.BaseURL(), address, strings.Join(queryParams, "&"))
 
 	m.logger.Info("Getting contract state", zap.String("url", url))
 
-	ctx, cancel := context.WithTimeout(context.Background(), m.Timeout)
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -494,7 +801,7 @@ func (m *MirrorClient) GetContractStateByAddressAndSlot(address string, slot str
 		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := m.do(req)
 	if err != nil {
 		m.logger.Error("Error getting contract state", zap.Error(err))
 		return nil, err
@@ -502,12 +809,12 @@ func (m *MirrorClient) GetContractStateByAddressAndSlot(address string, slot str
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		m.logger.Error("Mirror node returned status", zap.Int("status", resp.StatusCode))
-		return nil, nil // Here we return nil, nil to tell the service that the mirror node did not return a state
+		m.logStatus(resp.StatusCode)
+		return nil, statusError(resp.StatusCode)
 	}
 
 	var result domain.ContractStateResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := m.decode(resp, &result); err != nil {
 		m.logger.Error("Error decoding response body", zap.Error(err))
 		return nil, err
 	}
@@ -515,60 +822,46 @@ func (m *MirrorClient) GetContractStateByAddressAndSlot(address string, slot str
 	return &result, nil
 }
 
-func (m *MirrorClient) GetContractResultsLogsWithRetry(queryParams map[string]interface{}) ([]domain.LogEntry, error) {
+// GetContractResultsLogsWithRetry returns the logs matching queryParams,
+// repeating the query under the retry policy while the mirror node fails
+// transiently or lists logs not yet assigned to a block.
+func (m *MirrorClient) GetContractResultsLogsWithRetry(ctx context.Context, queryParams map[string]interface{}) ([]domain.LogEntry, error) {
 	queryParamsStr := formatQueryParams(queryParams)
 
-	url := fmt.Sprintf("%s/api/v1/contracts/results/logs?%s&limit=%d", m.BaseURL, queryParamsStr, Limit)
-
-	logs, err := m.getPaginatedResults(url)
-	if err != nil {
-		return nil, err
-	}
+	var logs []domain.LogEntry
+	err := m.retry(ctx, "contract results logs", func() error {
+		url := fmt.Sprintf("%s/api/v1/contracts/results/logs?%s&limit=%d", m.BaseURL(), queryParamsStr, Limit)
 
-	for i := 0; i < maxRetries; i++ {
-		isLastAttempt := i == maxRetries-1
+		var err error
+		logs, err = m.getPaginatedResults(ctx, url)
+		if err != nil {
+			return err
+		}
 
 		m.logger.Debug("Contract results logs", zap.Any("logs", logs))
 
-		foundImmatureRecord := false
 		for _, log := range logs {
 			if log.TransactionIndex == nil || log.BlockNumber == nil || log.BlockHash == "0x" || log.Index == nil {
 				m.logger.Debug("Contract results log contains nullable transaction_index or block_number, or block_hash is an empty hex (0x)",
-					zap.String("contract_result", fmt.Sprintf("%+v", log)),
-					zap.Duration("retry_delay", retryDelay))
-
-				if isLastAttempt {
-					return nil, fmt.Errorf("dependent service returned immature records")
-				}
-
-				foundImmatureRecord = true
-				break
+					zap.String("contract_result", fmt.Sprintf("%+v", log)))
+				return errImmatureRecords
 			}
 		}
-
-		if !foundImmatureRecord {
-			return logs, nil
-		}
-
-		m.logger.Debug("Found immature record, retrying", zap.Duration("retry_delay", retryDelay))
-
-		time.Sleep(retryDelay)
-
-		logs, err = m.getPaginatedResults(url)
-		if err != nil {
-			return nil, err
-		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, nil
+	return logs, nil
 }
 
-func (m *MirrorClient) GetContractResultsLogsByAddress(address string, queryParams map[string]interface{}) ([]domain.LogEntry, error) {
+func (m *MirrorClient) GetContractResultsLogsByAddress(ctx context.Context, address string, queryParams map[string]interface{}) ([]domain.LogEntry, error) {
 	queryParamsStr := formatQueryParams(queryParams)
 
-	url := fmt.Sprintf("%s/api/v1/contracts/%s/results/logs?%s&limit=%d", m.BaseURL, address, queryParamsStr, Limit)
+	url := fmt.Sprintf("%s/api/v1/contracts/%s/results/logs?%s&limit=%d", m.BaseURL(), address, queryParamsStr, Limit)
 
-	logs, err := m.getPaginatedResults(url)
+	logs, err := m.getPaginatedResults(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -576,8 +869,45 @@ func (m *MirrorClient) GetContractResultsLogsByAddress(address string, queryPara
 	return logs, nil
 }
 
-func (m *MirrorClient) fetchLogsPages(url string) (*domain.ContractResultsLogResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), m.Timeout)
+// StreamContractResultsLogs passes the logs matching queryParams to handle one
+// mirror node page at a time, following every next link rather than stopping
+// after the page budget. An empty address selects the logs of all contracts. Logs not
+// yet assigned to a block fail the stream, as they cannot be reported.
+func (m *MirrorClient) StreamContractResultsLogs(ctx context.Context, address string, queryParams map[string]interface{}, handle func([]domain.LogEntry) error) error {
+	path := "/api/v1/contracts/results/logs"
+	if address != "" {
+		path = fmt.Sprintf("/api/v1/contracts/%s/results/logs", address)
+	}
+	url := fmt.Sprintf("%s%s?%s&limit=%d", m.BaseURL(), path, formatQueryParams(queryParams), Limit)
+
+	for {
+		result, err := m.fetchLogsPages(ctx, url)
+		if err != nil {
+			return err
+		}
+
+		for _, log := range result.Logs {
+			if log.TransactionIndex == nil || log.BlockNumber == nil || log.BlockHash == "0x" || log.Index == nil {
+				return errImmatureRecords
+			}
+		}
+
+		if len(result.Logs) > 0 {
+			if err := handle(result.Logs); err != nil {
+				return err
+			}
+		}
+
+		if len(result.Logs) == 0 || result.Links.Next == nil {
+			return nil
+		}
+
+		url = fmt.Sprintf("%s%s", m.BaseURL(), *result.Links.Next)
+	}
+}
+
+func (m *MirrorClient) fetchLogsPages(ctx context.Context, url string) (*domain.ContractResultsLogResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -586,7 +916,7 @@ func (m *MirrorClient) fetchLogsPages(url string) (*domain.ContractResultsLogRes
 		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := m.do(req)
 	if err != nil {
 		m.logger.Error("Error making request", zap.Error(err))
 		return nil, err
@@ -594,12 +924,12 @@ func (m *MirrorClient) fetchLogsPages(url string) (*domain.ContractResultsLogRes
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		m.logger.Error("Mirror node returned status", zap.Int("status", resp.StatusCode))
-		return nil, fmt.Errorf("mirror node returned status %d", resp.StatusCode)
+		m.logStatus(resp.StatusCode)
+		return nil, statusError(resp.StatusCode)
 	}
 
 	var result domain.ContractResultsLogResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := m.decode(resp, &result); err != nil {
 		m.logger.Error("Error decoding response", zap.Error(err))
 		return nil, err
 	}
@@ -607,100 +937,104 @@ func (m *MirrorClient) fetchLogsPages(url string) (*domain.ContractResultsLogRes
 	return &result, nil
 }
 
-func (m *MirrorClient) getPaginatedResults(url string) ([]domain.LogEntry, error) {
+// getPaginatedResults follows the next links of a logs query for up to the
+// page budget of ctx. Rather than return a truncated set of logs, it fails with
+// a *PageLimitError when a next page remains.
+func (m *MirrorClient) getPaginatedResults(ctx context.Context, url string) ([]domain.LogEntry, error) {
 	var logs []domain.LogEntry
-	for page := 1; page <= MaxPages; page++ {
+	maxPages := m.pageBudget(ctx)
+	for page := 1; page <= maxPages; page++ {
 		m.logger.Info("", zap.String("url", url))
-		result, err := m.fetchLogsPages(url)
+		result, err := m.fetchLogsPages(ctx, url)
 		if err != nil {
 			return nil, err
 		}
 
 		if len(result.Logs) == 0 {
-			break
+			return logs, nil
 		}
 
 		logs = append(logs, result.Logs...)
 
 		if result.Links.Next == nil {
-			break
+			return logs, nil
 		}
 
-		url = fmt.Sprintf("%s%s", m.BaseURL, *result.Links.Next)
+		url = fmt.Sprintf("%s%s", m.BaseURL(), *result.Links.Next)
 	}
 
-	return logs, nil
+	m.logger.Warn("Logs query exceeds the page budget", zap.Int("maxPages", maxPages), zap.Int("logs", len(logs)))
+	return nil, &PageLimitError{MaxPages: maxPages}
 }
 
-func (m *MirrorClient) GetContractResultWithRetry(queryParams map[string]interface{}) (*domain.ContractResults, error) {
+// GetContractResultWithRetry returns the first contract result matching
+// queryParams, repeating the query under the retry policy while the mirror
+// node fails transiently or lists results not yet assigned to a block. It
+// returns nil when nothing matches or the results never mature.
+func (m *MirrorClient) GetContractResultWithRetry(ctx context.Context, queryParams map[string]interface{}) (*domain.ContractResult, error) {
 	queryParamsStr := formatQueryParams(queryParams)
 
-	url := fmt.Sprintf("%s/api/v1/contracts/results?%s", m.BaseURL, queryParamsStr)
+	var contractResult *domain.ContractResult
+	err := m.retry(ctx, "contract result", func() error {
+		url := fmt.Sprintf("%s/api/v1/contracts/results?%s", m.BaseURL(), queryParamsStr)
 
-	m.logger.Info("Getting contract result with retry", zap.String("url", url))
+		m.logger.Info("Getting contract result with retry", zap.String("url", url))
 
-	for i := 0; i < maxRetries; i++ {
-		ctx, cancel := context.WithTimeout(context.Background(), m.Timeout)
+		ctx, cancel := context.WithTimeout(ctx, m.Timeout)
 		defer cancel()
 
 		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 		if err != nil {
 			m.logger.Error("Error creating request", zap.Error(err))
-			return nil, err
+			return err
 		}
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := m.do(req)
 		if err != nil {
 			m.logger.Error("Error making request", zap.Error(err))
-			return nil, err
+			return err
 		}
 		defer func() { _ = resp.Body.Close() }()
 
 		if resp.StatusCode != http.StatusOK {
-			m.logger.Error("Mirror node returned status", zap.Int("status", resp.StatusCode))
-			return nil, fmt.Errorf("mirror node returned status %d", resp.StatusCode)
+			m.logStatus(resp.StatusCode)
+			return statusError(resp.StatusCode)
 		}
 
-		// Should make struct for this
-		var result struct {
-			Results []domain.ContractResults `json:"results"`
-			Links   struct {
-				Next *string `json:"next"`
-			} `json:"links"`
-		}
+		var result domain.ContractResultsResponse
 
-		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		if err := m.decode(resp, &result); err != nil {
 			m.logger.Error("Error decoding response", zap.Error(err))
-			return nil, err
+			return err
 		}
 
 		// Check if results are empty and links.next is null
 		if len(result.Results) == 0 && result.Links.Next == nil {
 			m.logger.Info("Empty results and no next link, returning")
-			return nil, nil
+			return nil
 		}
 
-		foundImmatureRecord := false
 		for _, res := range result.Results {
 			if res.TransactionIndex == 0 || res.BlockNumber == 0 || res.BlockHash == "0x" {
 				m.logger.Debug("Contract result contains nullable transaction_index or block_number, or block_hash is an empty hex (0x)",
-					zap.String("contract_result", fmt.Sprintf("%+v", res)),
-					zap.Duration("retry_delay", retryDelay))
-				foundImmatureRecord = true
-				break
+					zap.String("contract_result", fmt.Sprintf("%+v", res)))
+				return errImmatureRecords
 			}
 		}
 
-		if !foundImmatureRecord && len(result.Results) > 0 {
-			return &result.Results[0], nil
+		if len(result.Results) > 0 {
+			contractResult = &normalizeContractResults(result.Results)[0]
 		}
-
-		m.logger.Debug("Found immature record, retrying")
-
-		time.Sleep(retryDelay)
+		return nil
+	})
+	if errors.Is(err, errImmatureRecords) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, nil
+	return contractResult, nil
 }
 
 // Util function to format query params
@@ -716,12 +1050,12 @@ func formatQueryParams(params map[string]interface{}) string {
 	return queryParamsStr
 }
 
-func (m *MirrorClient) GetContractById(contractIdOrAddress string) (*domain.ContractResponse, error) {
-	url := fmt.Sprintf("%s/api/v1/contracts/%s", m.BaseURL, contractIdOrAddress)
+func (m *MirrorClient) GetContractById(ctx context.Context, contractIdOrAddress string) (*domain.ContractResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/contracts/%s", m.BaseURL(), contractIdOrAddress)
 
 	m.logger.Info("Getting contract by id", zap.String("url", url))
 
-	ctx, cancel := context.WithTimeout(context.Background(), m.Timeout)
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
 	defer cancel()
 
 	cachedKey := fmt.Sprintf("%s_%s", GetContractById, contractIdOrAddress)
@@ -737,7 +1071,7 @@ func (m *MirrorClient) GetContractById(contractIdOrAddress string) (*domain.Cont
 		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := m.do(req)
 	if err != nil {
 		m.logger.Error("Error making request", zap.Error(err))
 		return nil, err
@@ -745,29 +1079,46 @@ func (m *MirrorClient) GetContractById(contractIdOrAddress string) (*domain.Cont
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		m.logger.Error("Mirror node returned status", zap.Int("status", resp.StatusCode))
-		return nil, fmt.Errorf("mirror node returned status %d", resp.StatusCode)
+		m.logStatus(resp.StatusCode)
+		return nil, statusError(resp.StatusCode)
 	}
 
 	var result domain.ContractResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := m.decode(resp, &result); err != nil {
 		m.logger.Error("Error decoding response", zap.Error(err))
 		return nil, err
 	}
 
-	if err := m.cacheService.Set(ctx, cachedKey, &result, DefaultExpiration); err != nil {
+	if err := m.cacheService.Set(ctx, cachedKey, &result, m.CacheTTLs.Of(cache.ClassContract)); err != nil {
 		m.logger.Error("Error caching contract", zap.Error(err))
 	}
 
 	return &result, nil
 }
 
-func (m *MirrorClient) GetAccountById(idOrAliasOrEvmAddress string) (*domain.AccountResponse, error) {
-	url := fmt.Sprintf("%s/api/v1/accounts/%s?transactions=false", m.BaseURL, idOrAliasOrEvmAddress)
+// GetContractByIdWithRetry is GetContractById repeated under the retry policy
+// while the mirror node is unavailable, rate limited or timing out. Other
+// errors, including ErrNotFound, are returned right away.
+func (m *MirrorClient) GetContractByIdWithRetry(ctx context.Context, contractIdOrAddress string) (*domain.ContractResponse, error) {
+	var contract *domain.ContractResponse
+	err := m.retry(ctx, "contract by id", func() error {
+		var err error
+		contract, err = m.GetContractById(ctx, contractIdOrAddress)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return contract, nil
+}
+
+func (m *MirrorClient) GetAccountById(ctx context.Context, idOrAliasOrEvmAddress string) (*domain.AccountResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/accounts/%s?transactions=false", m.BaseURL(), idOrAliasOrEvmAddress)
 
 	m.logger.Info("Getting account by id", zap.String("url", url))
 
-	ctx, cancel := context.WithTimeout(context.Background(), m.Timeout)
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
 	defer cancel()
 
 	cachedKey := fmt.Sprintf("%s_%s", GetAccountById, idOrAliasOrEvmAddress)
@@ -783,7 +1134,7 @@ func (m *MirrorClient) GetAccountById(idOrAliasOrEvmAddress string) (*domain.Acc
 		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := m.do(req)
 	if err != nil {
 		m.logger.Error("Error making request", zap.Error(err))
 		return nil, err
@@ -791,29 +1142,29 @@ func (m *MirrorClient) GetAccountById(idOrAliasOrEvmAddress string) (*domain.Acc
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		m.logger.Error("Mirror node returned status", zap.Int("status", resp.StatusCode))
-		return nil, fmt.Errorf("mirror node returned status %d", resp.StatusCode)
+		m.logStatus(resp.StatusCode)
+		return nil, statusError(resp.StatusCode)
 	}
 
 	var result domain.AccountResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := m.decode(resp, &result); err != nil {
 		m.logger.Error("Error decoding response", zap.Error(err))
 		return nil, err
 	}
 
-	if err := m.cacheService.Set(ctx, cachedKey, &result, DefaultExpiration); err != nil {
+	if err := m.cacheService.Set(ctx, cachedKey, &result, m.CacheTTLs.Of(cache.ClassAccount)); err != nil {
 		m.logger.Error("Error caching account", zap.Error(err))
 	}
 
 	return &result, nil
 }
 
-func (m *MirrorClient) GetTokenById(tokenId string) (*domain.TokenResponse, error) {
-	url := fmt.Sprintf("%s/api/v1/tokens/%s", m.BaseURL, tokenId)
+func (m *MirrorClient) GetTokenById(ctx context.Context, tokenId string) (*domain.TokenResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/tokens/%s", m.BaseURL(), tokenId)
 
 	m.logger.Info("Getting token by id", zap.String("url", url))
 
-	ctx, cancel := context.WithTimeout(context.Background(), m.Timeout)
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
 	defer cancel()
 
 	cachedKey := fmt.Sprintf("%s_%s", GetTokenById, tokenId)
@@ -829,7 +1180,7 @@ func (m *MirrorClient) GetTokenById(tokenId string) (*domain.TokenResponse, erro
 		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := m.do(req)
 	if err != nil {
 		m.logger.Error("Error making request", zap.Error(err))
 		return nil, err
@@ -837,19 +1188,209 @@ func (m *MirrorClient) GetTokenById(tokenId string) (*domain.TokenResponse, erro
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		m.logger.Error("Mirror node returned status", zap.Int("status", resp.StatusCode))
-		return nil, fmt.Errorf("mirror node returned status %d", resp.StatusCode)
+		m.logStatus(resp.StatusCode)
+		return nil, statusError(resp.StatusCode)
 	}
 
 	var result domain.TokenResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := m.decode(resp, &result); err != nil {
 		m.logger.Error("Error decoding response", zap.Error(err))
 		return nil, err
 	}
 
-	if err := m.cacheService.Set(ctx, cachedKey, &result, DefaultExpiration); err != nil {
+	if err := m.cacheService.Set(ctx, cachedKey, &result, m.CacheTTLs.Of(cache.ClassToken)); err != nil {
 		m.logger.Error("Error caching token", zap.Error(err))
 	}
 
 	return &result, nil
 }
+
+// GetTokenRelationships returns every token the account is associated with
+// and its balance of each, following pagination links. Balances change with
+// every transfer, so they are not cached.
+func (m *MirrorClient) GetTokenRelationships(ctx context.Context, idOrAliasOrEvmAddress string) ([]domain.TokenRelationship, error) {
+	url := fmt.Sprintf("%s/api/v1/accounts/%s/tokens?limit=%d", m.BaseURL(), idOrAliasOrEvmAddress, Limit)
+
+	m.logger.Info("Getting token relationships", zap.String("url", url))
+
+	var relationships []domain.TokenRelationship
+	maxPages := m.pageBudget(ctx)
+	for page := 1; page <= maxPages; page++ {
+		result, err := m.fetchTokenRelationshipsPage(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+
+		relationships = append(relationships, result.Tokens...)
+
+		if result.Links.Next == nil {
+			return relationships, nil
+		}
+		url = m.BaseURL() + *result.Links.Next
+	}
+
+	return nil, fmt.Errorf("token relationships of %s: %w", idOrAliasOrEvmAddress, &PageLimitError{MaxPages: maxPages})
+}
+
+func (m *MirrorClient) fetchTokenRelationshipsPage(ctx context.Context, url string) (*domain.TokenRelationshipsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError(resp.StatusCode)
+	}
+
+	var result domain.TokenRelationshipsResponse
+	if err := m.decode(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetTokenAllowance returns the allowance owner granted spender over tokenId,
+// or nil when there is none. The owner may be given by ID, alias or EVM
+// address, the spender and token by ID. Allowances change with every transfer
+// from them, so they are not cached.
+func (m *MirrorClient) GetTokenAllowance(ctx context.Context, owner, spender, tokenId string) (*domain.TokenAllowance, error) {
+	url := fmt.Sprintf("%s/api/v1/accounts/%s/allowances/tokens?spender.id=%s&token.id=%s", m.BaseURL(), owner, spender, tokenId)
+
+	m.logger.Info("Getting token allowance", zap.String("url", url))
+
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		m.logStatus(resp.StatusCode)
+		return nil, statusError(resp.StatusCode)
+	}
+
+	var result domain.TokenAllowancesResponse
+	if err := m.decode(resp, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Allowances) == 0 {
+		return nil, nil
+	}
+
+	return &result.Allowances[0], nil
+}
+
+func (m *MirrorClient) GetContractsResultsActions(ctx context.Context, transactionIdOrHash string) (*domain.ContractActionsResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/contracts/results/%s/actions", m.BaseURL(), transactionIdOrHash)
+
+	m.logger.Info("Getting contract result actions", zap.String("url", url))
+
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+
+	cachedKey := fmt.Sprintf("%s_%s", GetContractsResultsActions, transactionIdOrHash)
+
+	var cachedActions domain.ContractActionsResponse
+	if err := m.cacheService.Get(ctx, cachedKey, &cachedActions); err == nil && len(cachedActions.Actions) > 0 {
+		return &cachedActions, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		m.logger.Error("Error creating request", zap.Error(err))
+		return nil, err
+	}
+
+	resp, err := m.do(req)
+	if err != nil {
+		m.logger.Error("Error making request", zap.Error(err))
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		m.logStatus(resp.StatusCode)
+		return nil, statusError(resp.StatusCode)
+	}
+
+	var result domain.ContractActionsResponse
+	if err := m.decode(resp, &result); err != nil {
+		m.logger.Error("Error decoding response", zap.Error(err))
+		return nil, err
+	}
+
+	if err := m.cacheService.Set(ctx, cachedKey, &result, m.CacheTTLs.Of(cache.ClassTransaction)); err != nil {
+		m.logger.Error("Error caching contract result actions", zap.Error(err))
+	}
+
+	return &result, nil
+}
+
+// GetContractsResultsOpcodes re-executes the transaction on the web3 module, so
+// the response is not cached.
+func (m *MirrorClient) GetContractsResultsOpcodes(ctx context.Context, transactionIdOrHash string, stack, memory, storage bool) (*domain.OpcodesResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/contracts/results/%s/opcodes?stack=%t&memory=%t&storage=%t", m.Web3URL(), transactionIdOrHash, stack, memory, storage)
+
+	m.logger.Info("Getting contract result opcodes", zap.String("url", url))
+
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		m.logger.Error("Error creating request", zap.Error(err))
+		return nil, err
+	}
+
+	resp, err := m.doWeb3(req)
+	if err != nil {
+		m.logger.Error("Error making request", zap.Error(err))
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		m.logStatus(resp.StatusCode)
+		return nil, statusError(resp.StatusCode)
+	}
+
+	var result domain.OpcodesResponse
+	if err := m.decode(resp, &result); err != nil {
+		m.logger.Error("Error decoding response", zap.Error(err))
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// sleepWithContext waits for d, returning early with the context error if ctx
+// is cancelled first.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,328 @@
+package hedera
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/metrics"
+	"github.com/LimeChain/Hederium/internal/infrastructure/timing"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// web3ProbeCall is the call sent to health-check the web3 module: a call to
+// the zero address, which a running web3 module answers without a 5xx
+// whether or not it accepts it.
+const web3ProbeCall = `{"to":"0x0000000000000000000000000000000000000000","data":"0x","block":"latest"}`
+
+// mirrorEndpoints tracks the health of the configured mirror node base URLs
+// of one API: the REST API, or the web3 module answering contract calls when
+// it is deployed apart. Requests go to the active endpoint until it fails
+// failoverThreshold times in a row, after which the next healthy endpoint
+// takes over and stays active until it fails in turn.
+type mirrorEndpoints struct {
+	mu        sync.Mutex
+	api       string
+	urls      []string
+	active    int
+	failures  []int
+	unhealthy []bool
+	// probe health-checks one of the urls.
+	probe          func(ctx context.Context, url string) bool
+	activeEndpoint *prometheus.GaugeVec
+	failovers      *prometheus.CounterVec
+	logger         *zap.Logger
+}
+
+func newMirrorEndpoints(urls []string, logger *zap.Logger) *mirrorEndpoints {
+	return newEndpoints("REST", urls, probeREST, metrics.MirrorNodeActiveEndpoint, metrics.MirrorNodeFailovers, logger)
+}
+
+func newWeb3Endpoints(urls []string, logger *zap.Logger) *mirrorEndpoints {
+	return newEndpoints("web3", urls, probeWeb3, metrics.MirrorNodeWeb3ActiveEndpoint, metrics.MirrorNodeWeb3Failovers, logger)
+}
+
+func newEndpoints(
+	api string,
+	urls []string,
+	probe func(ctx context.Context, url string) bool,
+	activeEndpoint *prometheus.GaugeVec,
+	failovers *prometheus.CounterVec,
+	logger *zap.Logger,
+) *mirrorEndpoints {
+	e := &mirrorEndpoints{
+		api:            api,
+		urls:           urls,
+		failures:       make([]int, len(urls)),
+		unhealthy:      make([]bool, len(urls)),
+		probe:          probe,
+		activeEndpoint: activeEndpoint,
+		failovers:      failovers,
+		logger:         logger,
+	}
+	for i, url := range urls {
+		activeEndpoint.WithLabelValues(url).Set(0)
+		if i == 0 {
+			activeEndpoint.WithLabelValues(url).Set(1)
+		}
+	}
+	return e
+}
+
+func (e *mirrorEndpoints) current() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.urls[e.active]
+}
+
+// record notes the outcome of a request sent to url.
+func (e *mirrorEndpoints) record(url string, failed bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	i := e.indexOf(url)
+	if i < 0 {
+		return
+	}
+	if !failed {
+		e.failures[i] = 0
+		e.unhealthy[i] = false
+		return
+	}
+
+	e.failures[i]++
+	if i == e.active && e.failures[i] >= failoverThreshold {
+		e.unhealthy[i] = true
+		e.failover()
+	}
+}
+
+// setHealth stores the result of a health check of url and moves off the
+// active endpoint if it turned out unhealthy.
+func (e *mirrorEndpoints) setHealth(url string, healthy bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	i := e.indexOf(url)
+	if i < 0 {
+		return
+	}
+	e.unhealthy[i] = !healthy
+	if healthy {
+		e.failures[i] = 0
+	} else if i == e.active {
+		e.failover()
+	}
+}
+
+// failover activates the next endpoint after the active one, preferring
+// endpoints that are not known to be unhealthy. Callers must hold e.mu.
+func (e *mirrorEndpoints) failover() {
+	if len(e.urls) < 2 {
+		return
+	}
+
+	next := (e.active + 1) % len(e.urls)
+	for i := 1; i < len(e.urls); i++ {
+		candidate := (e.active + i) % len(e.urls)
+		if !e.unhealthy[candidate] {
+			next = candidate
+			break
+		}
+	}
+
+	e.logger.Warn("Mirror node endpoint failing, switching to the next one",
+		zap.String("api", e.api),
+		zap.String("from", e.urls[e.active]),
+		zap.String("to", e.urls[next]))
+
+	e.activeEndpoint.WithLabelValues(e.urls[e.active]).Set(0)
+	e.activeEndpoint.WithLabelValues(e.urls[next]).Set(1)
+	e.failovers.WithLabelValues(e.urls[next]).Inc()
+
+	e.active = next
+	e.failures[next] = 0
+}
+
+// baseOf returns the base URL of the endpoint url was sent to, or url itself
+// when it belongs to none.
+func (e *mirrorEndpoints) baseOf(url string) string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if i := e.indexOf(url); i >= 0 {
+		return e.urls[i]
+	}
+	return url
+}
+
+// indexOf returns the endpoint url belongs to, or -1. Callers must hold e.mu.
+func (e *mirrorEndpoints) indexOf(url string) int {
+	for i, base := range e.urls {
+		if strings.HasPrefix(url, base) {
+			return i
+		}
+	}
+	return -1
+}
+
+// do sends req, addressed to a REST API base URL, through the default HTTP
+// client and records whether the endpoint it was sent to failed. Network
+// errors, timeouts and 5xx responses count as failures; requests abandoned by
+// the caller do not. A 429 is not a failure of the endpoint but starts a
+// backoff, see rateLimited. Failures to get a response are returned as
+// ErrTimeout or ErrUpstreamUnavailable.
+func (m *MirrorClient) do(req *http.Request) (*http.Response, error) {
+	return m.send(m.endpoints, req)
+}
+
+// doWeb3 sends req, addressed to a web3 URL, as do does, recording its
+// outcome against the web3 endpoints.
+func (m *MirrorClient) doWeb3(req *http.Request) (*http.Response, error) {
+	return m.send(m.web3, req)
+}
+
+func (m *MirrorClient) send(endpoints *mirrorEndpoints, req *http.Request) (*http.Response, error) {
+	stop := timing.Track(req.Context(), timing.Mirror)
+	resp, err := http.DefaultClient.Do(req)
+	stop()
+	if err != nil && errors.Is(req.Context().Err(), context.Canceled) {
+		return resp, err
+	}
+	failed := err != nil || resp.StatusCode >= http.StatusInternalServerError
+	endpoints.record(req.URL.String(), failed)
+	if m.OnResult != nil {
+		m.OnResult(failed)
+	}
+	if err != nil {
+		return nil, requestError(err)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		m.rateLimited(endpoints.baseOf(req.URL.String()), parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()))
+	}
+	return resp, nil
+}
+
+// logStatus logs a response other than 200 OK. Throttling is logged by do
+// when the 429 arrives.
+func (m *MirrorClient) logStatus(statusCode int) {
+	if statusCode == http.StatusTooManyRequests {
+		return
+	}
+	m.logger.Error("Mirror node returned status", zap.Int("status", statusCode))
+}
+
+// BaseURL returns the mirror node base URL requests are currently sent to.
+func (m *MirrorClient) BaseURL() string {
+	return m.endpoints.current()
+}
+
+// BaseURLs returns every configured mirror node base URL, primary first.
+func (m *MirrorClient) BaseURLs() []string {
+	return append([]string(nil), m.endpoints.urls...)
+}
+
+// UseWeb3URLs sends contract calls to the mirror node web3 module at urls,
+// primary first, rather than to the REST API base URLs. The web3 URLs fail
+// over and are health-checked on their own, so that the availability of
+// eth_call does not follow that of the REST API. It must be called before the
+// client is used; without urls the REST API base URLs are kept.
+func (m *MirrorClient) UseWeb3URLs(urls []string) {
+	if len(urls) == 0 {
+		return
+	}
+	trimmed := make([]string, 0, len(urls))
+	for _, url := range urls {
+		trimmed = append(trimmed, strings.TrimSuffix(url, "/"))
+	}
+	m.web3 = newWeb3Endpoints(trimmed, m.logger)
+}
+
+// Web3URL returns the URL contract calls are currently sent to.
+func (m *MirrorClient) Web3URL() string {
+	return m.web3.current()
+}
+
+// Web3URLs returns the web3 module URLs set by UseWeb3URLs, primary first,
+// or none when contract calls go to the REST API base URLs.
+func (m *MirrorClient) Web3URLs() []string {
+	if m.web3 == m.endpoints {
+		return nil
+	}
+	return append([]string(nil), m.web3.urls...)
+}
+
+// MonitorEndpoints health-checks the configured mirror nodes every interval
+// so that failover skips endpoints that are down: the REST API base URLs with
+// a request for the latest block, and separate web3 URLs with a contract
+// call. Each set is only checked when it has several URLs.
+func (m *MirrorClient) MonitorEndpoints(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	m.monitor(m.endpoints, interval)
+	if m.web3 != m.endpoints {
+		m.monitor(m.web3, interval)
+	}
+}
+
+func (m *MirrorClient) monitor(endpoints *mirrorEndpoints, interval time.Duration) {
+	if len(endpoints.urls) < 2 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			m.checkEndpoints(endpoints)
+		}
+	}()
+}
+
+func (m *MirrorClient) checkEndpoints(endpoints *mirrorEndpoints) {
+	for _, url := range endpoints.urls {
+		ctx, cancel := context.WithTimeout(context.Background(), m.Timeout)
+		healthy := endpoints.probe(ctx, url)
+		cancel()
+
+		if !healthy {
+			m.logger.Warn("Mirror node health check failed", zap.String("api", endpoints.api), zap.String("url", url))
+		}
+		endpoints.setHealth(url, healthy)
+	}
+}
+
+// probeREST checks that a REST API answers the latest block.
+func probeREST(ctx context.Context, url string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/api/v1/blocks?limit=1", nil)
+	if err != nil {
+		return false
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+// probeWeb3 checks that a web3 module answers a contract call without a
+// server error.
+func probeWeb3(ctx context.Context, url string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url+"/api/v1/contracts/call", strings.NewReader(web3ProbeCall))
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode < http.StatusInternalServerError
+}
@@ -0,0 +1,72 @@
+package hedera
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	// mirrorNodeNodesPath is requested from a mirror node to check that it
+	// answers and to count the consensus nodes it knows.
+	mirrorNodeNodesPath = "/api/v1/network/nodes"
+	// maxProbePages bounds the pages of consensus nodes followed.
+	maxProbePages = 10
+)
+
+// ProbeMirrorNode checks that the mirror node at baseURL answers before the
+// relay depends on it, and returns how many consensus nodes of its network it
+// lists. A page failing after the first keeps the nodes counted so far. The
+// errors leave out the URL, which may carry an API key.
+func ProbeMirrorNode(ctx context.Context, baseURL string) (int, error) {
+	nodes := 0
+	next := mirrorNodeNodesPath
+	for page := 0; next != "" && page < maxProbePages; page++ {
+		pageNodes, link, err := fetchNodesPage(ctx, baseURL+next)
+		if err != nil {
+			return nodes, err
+		}
+		nodes += pageNodes
+		next = link
+	}
+	return nodes, nil
+}
+
+func fetchNodesPage(ctx context.Context, pageURL string) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		// The error of the request would repeat its URL and any API key in it
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return 0, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return 0, "", fmt.Errorf("%s answered with status %d", mirrorNodeNodesPath, resp.StatusCode)
+	}
+
+	var page struct {
+		Nodes []json.RawMessage `json:"nodes"`
+		Links struct {
+			Next *string `json:"next"`
+		} `json:"links"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return 0, "", fmt.Errorf("%s answered with invalid JSON: %w", mirrorNodeNodesPath, err)
+	}
+
+	next := ""
+	if page.Links.Next != nil {
+		next = *page.Links.Next
+	}
+	return len(page.Nodes), next, nil
+}
@@ -0,0 +1,337 @@
+package hedera
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/metrics"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/yaml.v3"
+)
+
+// mirrorNodeOpenAPIPath serves the OpenAPI document of the mirror node REST
+// API, whose info.version is the version of the mirror node.
+const mirrorNodeOpenAPIPath = "/api/v1/docs/openapi.yml"
+
+var errNoMirrorNodeVersion = errors.New("mirror node: OpenAPI document has no info.version")
+
+// SchemaAliases maps response fields, named as the relay reads them, to the
+// names newer mirror nodes give them instead. A field missing from a response
+// is read from the first of its aliases present, so that a renamed field does
+// not break the relay before it is updated. Fields are matched by name,
+// case-insensitively, at any depth of a response.
+type SchemaAliases map[string][]string
+
+// of returns the aliases of field.
+func (a SchemaAliases) of(field string) []string {
+	if aliases, ok := a[field]; ok {
+		return aliases
+	}
+	for name, aliases := range a {
+		if strings.EqualFold(name, field) {
+			return aliases
+		}
+	}
+	return nil
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	// schemaFields caches the JSON fields of every struct type decoded, keyed
+	// by reflect.Type.
+	schemaFields sync.Map
+)
+
+// schemaDrift remembers the differences from the expected schema already
+// reported, so that each is logged once rather than with every response.
+type schemaDrift struct {
+	mu       sync.Mutex
+	reported map[string]bool
+}
+
+// firstReport reports whether key has not been reported yet, marking it so.
+func (d *schemaDrift) firstReport(key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.reported == nil {
+		d.reported = make(map[string]bool)
+	}
+	if d.reported[key] {
+		return false
+	}
+	d.reported[key] = true
+	return true
+}
+
+// decode reads the JSON body of resp into out. Fields of the body that out
+// does not know are ignored, and fields it misses are read from their
+// SchemaAliases. Either is logged once as schema drift: fields the relay does
+// not read are common and logged at debug level, while missing and renamed
+// fields, which a mirror node upgrade may cause, are warned about.
+func (m *MirrorClient) decode(resp *http.Response, out interface{}) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// Numbers are kept as written so that re-encoding a response with renamed
+	// fields does not round large ones
+	var raw interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&raw); err != nil {
+		return err
+	}
+
+	schema := reflect.TypeOf(out)
+	if m.checkSchema(schemaName(schema), raw, schema, "") {
+		if body, err = json.Marshal(raw); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(body, out)
+}
+
+// checkSchema compares data, decoded from a response, with the type t it is
+// read into, logging the fields t does not know and those data misses, and
+// copying the aliases of the latter under their names. It reports whether data
+// was changed.
+func (m *MirrorClient) checkSchema(schema string, data interface{}, t reflect.Type, path string) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(jsonUnmarshalerType) ||
+		reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return false
+	}
+
+	changed := false
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := data.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		fields := jsonFields(t)
+
+		aliases := make(map[string]bool)
+		for _, field := range fields {
+			for _, alias := range m.SchemaAliases.of(field.name) {
+				aliases[strings.ToLower(alias)] = true
+			}
+			if hasKey(object, field.name) {
+				continue
+			}
+			renamed := false
+			for _, alias := range m.SchemaAliases.of(field.name) {
+				if value, ok := object[alias]; ok {
+					object[field.name] = value
+					changed, renamed = true, true
+					m.reportDrift(zap.WarnLevel, schema+" "+joinPath(path, alias), "Mirror node response field renamed, reading it under its new name",
+						zap.String("schema", schema), zap.String("field", joinPath(path, field.name)), zap.String("renamedTo", alias))
+					break
+				}
+			}
+			if !renamed && !field.optional {
+				m.reportDrift(zap.WarnLevel, schema+" "+joinPath(path, field.name), "Mirror node response lacks a field the relay reads, it may have been renamed",
+					zap.String("schema", schema), zap.String("field", joinPath(path, field.name)))
+			}
+		}
+
+		for key, value := range object {
+			field, ok := fields[strings.ToLower(key)]
+			if !ok {
+				if !aliases[strings.ToLower(key)] {
+					m.reportDrift(zap.DebugLevel, schema+" "+joinPath(path, key), "Mirror node response has a field the relay does not know, ignoring it",
+						zap.String("schema", schema), zap.String("field", joinPath(path, key)))
+				}
+				continue
+			}
+			if m.checkSchema(schema, value, field.typ, joinPath(path, key)) {
+				changed = true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := data.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, item := range items {
+			if m.checkSchema(schema, item, t.Elem(), path+"[]") {
+				changed = true
+			}
+		}
+	case reflect.Map:
+		object, ok := data.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range object {
+			if m.checkSchema(schema, value, t.Elem(), joinPath(path, key)) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// reportDrift logs a difference from the expected schema at level the first
+// time key sees it.
+func (m *MirrorClient) reportDrift(level zapcore.Level, key, message string, fields ...zap.Field) {
+	if !m.drift.firstReport(key) {
+		return
+	}
+	m.logger.Log(level, message, append(fields, zap.String("mirrorNodeVersion", m.Version()))...)
+}
+
+// hasKey reports whether object holds name, compared case-insensitively as
+// encoding/json does.
+func hasKey(object map[string]interface{}, name string) bool {
+	if _, ok := object[name]; ok {
+		return true
+	}
+	for key := range object {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return false
+}
+
+type jsonField struct {
+	name string
+	typ  reflect.Type
+	// optional fields, tagged omitempty, are not expected in every response.
+	optional bool
+}
+
+// jsonFields returns the fields encoding/json reads into t, keyed by their
+// lowercased name as encoding/json matches them case-insensitively.
+func jsonFields(t reflect.Type) map[string]jsonField {
+	if fields, ok := schemaFields.Load(t); ok {
+		return fields.(map[string]jsonField)
+	}
+
+	fields := make(map[string]jsonField)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for key, field := range jsonFields(embedded) {
+					if _, ok := fields[key]; !ok {
+						fields[key] = field
+					}
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = jsonField{name: name, typ: f.Type, optional: strings.Contains(options, "omitempty")}
+	}
+
+	schemaFields.Store(t, fields)
+	return fields
+}
+
+// schemaName names the type a response is read into in drift warnings.
+func schemaName(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Name() != "" {
+		return t.String()
+	}
+	return "response"
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// Version returns the version of the mirror node detected by DetectVersion,
+// or an empty string while it is unknown.
+func (m *MirrorClient) Version() string {
+	version, _ := m.version.Load().(string)
+	return version
+}
+
+// DetectVersion reads the version of the mirror node in use from its OpenAPI
+// document, logs it and reports it in the hederium_mirror_node_version metric.
+// The version stays unknown when the mirror node does not serve the document.
+func (m *MirrorClient) DetectVersion(ctx context.Context) string {
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+
+	baseURL := m.BaseURL()
+	version, err := fetchMirrorNodeVersion(ctx, baseURL+mirrorNodeOpenAPIPath)
+	if err != nil {
+		m.logger.Warn("Could not detect the mirror node version", zap.String("url", baseURL), zap.Error(err))
+		return m.Version()
+	}
+
+	if previous := m.Version(); previous != "" {
+		metrics.MirrorNodeVersion.WithLabelValues(baseURL, previous).Set(0)
+	}
+	m.version.Store(version)
+	metrics.MirrorNodeVersion.WithLabelValues(baseURL, version).Set(1)
+	m.logger.Info("Detected mirror node version", zap.String("url", baseURL), zap.String("version", version))
+	return version
+}
+
+func fetchMirrorNodeVersion(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", requestError(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", statusError(resp.StatusCode)
+	}
+
+	var document struct {
+		Info struct {
+			Version string `yaml:"version"`
+		} `yaml:"info"`
+	}
+	if err := yaml.NewDecoder(resp.Body).Decode(&document); err != nil {
+		return "", err
+	}
+	if document.Info.Version == "" {
+		return "", errNoMirrorNodeVersion
+	}
+	return document.Info.Version, nil
+}
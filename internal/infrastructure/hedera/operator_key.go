@@ -0,0 +1,108 @@
+package hedera
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+)
+
+// Types of operator keys, as set by hedera.operatorKeyType.
+const (
+	// KeyTypeAuto detects the type of a key from its encoding, see
+	// ParsePrivateKey.
+	KeyTypeAuto = "auto"
+	// KeyTypeED25519 is an ED25519 key.
+	KeyTypeED25519 = "ed25519"
+	// KeyTypeECDSA is an ECDSA key on the secp256k1 curve, the kind of key
+	// Ethereum accounts have.
+	KeyTypeECDSA = "ecdsa"
+)
+
+const (
+	// ed25519KeyOID and ecdsaSecp256k1OID are the DER encoded object
+	// identifiers of the algorithms DER encoded keys name.
+	ed25519KeyOID     = "06032b6570"
+	ecdsaSecp256k1OID = "06052b8104000a"
+	// rawPrivateKeyLength is the length in bytes of raw ED25519 and ECDSA
+	// private keys.
+	rawPrivateKeyLength = 32
+	// legacyED25519KeyLength is the length in bytes of raw ED25519 keys
+	// followed by their public key, as older SDKs exported them.
+	legacyED25519KeyLength = 64
+)
+
+// ParsePrivateKey reads an operator private key of keyType, KeyTypeAuto when
+// empty. Keys may be DER encoded, as the portal and the SDK export them, or
+// raw, all in hex. DER encoded keys name their algorithm, which must match
+// keyType. Raw keys are ambiguous, so KeyTypeAuto reads them the way they are
+// usually exported: as ECDSA when 0x prefixed, like Ethereum keys, and as
+// ED25519 otherwise.
+func ParsePrivateKey(key, keyType string) (hedera.PrivateKey, error) {
+	if keyType == "" {
+		keyType = KeyTypeAuto
+	}
+	raw := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(key)), "0x")
+	bytes, err := hex.DecodeString(raw)
+	if err != nil || len(bytes) < rawPrivateKeyLength {
+		return hedera.PrivateKey{}, fmt.Errorf("not a hex encoded private key")
+	}
+
+	detected := ""
+	switch {
+	case len(bytes) > rawPrivateKeyLength && strings.Contains(raw, ed25519KeyOID):
+		detected = KeyTypeED25519
+	case len(bytes) > rawPrivateKeyLength && strings.Contains(raw, ecdsaSecp256k1OID):
+		detected = KeyTypeECDSA
+	case len(bytes) == legacyED25519KeyLength:
+		detected = KeyTypeED25519
+	case len(bytes) > rawPrivateKeyLength:
+		return hedera.PrivateKey{}, fmt.Errorf("not an ED25519 or ECDSA(secp256k1) private key: DER encoded keys must name either algorithm")
+	}
+
+	switch keyType {
+	case KeyTypeAuto:
+		if detected == "" {
+			detected = KeyTypeED25519
+			if strings.HasPrefix(strings.TrimSpace(key), "0x") {
+				detected = KeyTypeECDSA
+			}
+		}
+	case KeyTypeED25519, KeyTypeECDSA:
+		if detected != "" && detected != keyType {
+			return hedera.PrivateKey{}, fmt.Errorf("the key is DER encoded as an %s key, not an %s one", keyTypeName(detected), keyTypeName(keyType))
+		}
+		detected = keyType
+	default:
+		return hedera.PrivateKey{}, fmt.Errorf("unknown key type %q", keyType)
+	}
+
+	var privateKey hedera.PrivateKey
+	if detected == KeyTypeECDSA {
+		privateKey, err = hedera.PrivateKeyFromStringECDSA(raw)
+	} else {
+		privateKey, err = hedera.PrivateKeyFromStringEd25519(raw)
+	}
+	if err != nil {
+		return hedera.PrivateKey{}, fmt.Errorf("not a valid %s private key: %w", keyTypeName(detected), err)
+	}
+	return privateKey, nil
+}
+
+// KeyTypeOf returns the type of key, KeyTypeED25519 or KeyTypeECDSA.
+func KeyTypeOf(key hedera.PrivateKey) string {
+	// Compressed ECDSA public keys take a byte more than ED25519 ones
+	if len(key.PublicKey().BytesRaw()) > rawPrivateKeyLength {
+		return KeyTypeECDSA
+	}
+	return KeyTypeED25519
+}
+
+// keyTypeName names keyType in error messages.
+func keyTypeName(keyType string) string {
+	if keyType == KeyTypeECDSA {
+		return "ECDSA(secp256k1)"
+	}
+	return "ED25519"
+}
@@ -0,0 +1,236 @@
+package hedera
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+)
+
+const (
+	// RoundRobin hands out operators in turn.
+	RoundRobin = "round-robin"
+	// LeastRecentlyUsed hands out the operator that has been idle the longest.
+	LeastRecentlyUsed = "lru"
+)
+
+// Operator is an account that pays for transactions submitted by the relay.
+type Operator struct {
+	AccountID  hedera.AccountID
+	PrivateKey hedera.PrivateKey
+	// KeyType is KeyTypeED25519 or KeyTypeECDSA.
+	KeyType string
+}
+
+// NewOperator parses an operator account ID and a private key of keyType, see
+// ParsePrivateKey.
+func NewOperator(operatorId, operatorKey, keyType string) (Operator, error) {
+	accID, err := hedera.AccountIDFromString(operatorId)
+	if err != nil {
+		return Operator{}, fmt.Errorf("invalid operator account ID %q: %w", operatorId, err)
+	}
+	opKey, err := ParsePrivateKey(operatorKey, keyType)
+	if err != nil {
+		return Operator{}, fmt.Errorf("invalid key for operator %s: %w", operatorId, err)
+	}
+	return Operator{AccountID: accID, PrivateKey: opKey, KeyType: KeyTypeOf(opKey)}, nil
+}
+
+// EvmAddress returns the EVM address derived from the key of the operator,
+// the alias an ECDSA key gives an account, or an empty string for ED25519
+// keys, which have none.
+func (o Operator) EvmAddress() string {
+	if o.KeyType != KeyTypeECDSA {
+		return ""
+	}
+	return "0x" + o.PrivateKey.PublicKey().ToEvmAddress()
+}
+
+// ParseOperators reads a list of {id, key, keyType} entries as found under
+// hedera.operators in the config. keyType is optional.
+func ParseOperators(raw interface{}) ([]Operator, error) {
+	entries, ok := raw.([]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	operators := make([]Operator, 0, len(entries))
+	for i, entry := range entries {
+		var id, key, keyType interface{}
+		switch m := entry.(type) {
+		case map[string]interface{}:
+			id, key, keyType = m["id"], m["key"], m["keytype"]
+			if keyType == nil {
+				keyType = m["keyType"]
+			}
+		case map[interface{}]interface{}:
+			id, key, keyType = m["id"], m["key"], m["keyType"]
+		default:
+			return nil, fmt.Errorf("operator %d: expected an object with id and key", i)
+		}
+
+		idStr, _ := id.(string)
+		keyStr, _ := key.(string)
+		keyTypeStr, _ := keyType.(string)
+		operator, err := NewOperator(idStr, keyStr, keyTypeStr)
+		if err != nil {
+			return nil, fmt.Errorf("operator %d: %w", i, err)
+		}
+		operators = append(operators, operator)
+	}
+	return operators, nil
+}
+
+type pooledOperator struct {
+	Operator
+	lastUsed time.Time
+	// balance is in tinybars and only meaningful once balanceKnown is set.
+	balance      int64
+	balanceKnown bool
+}
+
+// OperatorPool spreads transaction submission over several payer accounts so
+// that a single account's throttles and balance do not cap throughput.
+// Operators whose last observed balance is below minBalance are skipped.
+type OperatorPool struct {
+	mu         sync.Mutex
+	operators  []*pooledOperator
+	strategy   string
+	next       int
+	minBalance int64
+}
+
+// NewOperatorPool creates a pool over operators. strategy is RoundRobin or
+// LeastRecentlyUsed (RoundRobin when empty) and minBalance is in tinybars.
+func NewOperatorPool(operators []Operator, strategy string, minBalance int64) (*OperatorPool, error) {
+	if len(operators) == 0 {
+		return nil, fmt.Errorf("operator pool requires at least one operator")
+	}
+
+	switch strategy {
+	case "":
+		strategy = RoundRobin
+	case RoundRobin, LeastRecentlyUsed:
+	default:
+		return nil, fmt.Errorf("unsupported operator selection strategy: %s", strategy)
+	}
+
+	pool := &OperatorPool{
+		operators:  make([]*pooledOperator, 0, len(operators)),
+		strategy:   strategy,
+		minBalance: minBalance,
+	}
+	seen := make(map[string]bool)
+	for _, operator := range operators {
+		id := operator.AccountID.String()
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		pool.operators = append(pool.operators, &pooledOperator{Operator: operator})
+	}
+	return pool, nil
+}
+
+// Primary returns the first configured operator, which pays for queries.
+func (p *OperatorPool) Primary() Operator {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.operators[0].Operator
+}
+
+// SetPrimaryKey replaces the key of the primary operator, e.g. once it has
+// been rotated. Transactions selected afterwards are signed with it.
+func (p *OperatorPool) SetPrimaryKey(key hedera.PrivateKey) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.operators[0].PrivateKey = key
+	p.operators[0].KeyType = KeyTypeOf(key)
+}
+
+// Operators returns every operator in the pool.
+func (p *OperatorPool) Operators() []Operator {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	operators := make([]Operator, len(p.operators))
+	for i, operator := range p.operators {
+		operators[i] = operator.Operator
+	}
+	return operators
+}
+
+// Next selects the operator that should pay for the next transaction.
+func (p *OperatorPool) Next() (Operator, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var selected *pooledOperator
+	switch p.strategy {
+	case LeastRecentlyUsed:
+		for _, operator := range p.operators {
+			if !p.hasFunds(operator) {
+				continue
+			}
+			if selected == nil || operator.lastUsed.Before(selected.lastUsed) {
+				selected = operator
+			}
+		}
+	default:
+		for i := 0; i < len(p.operators); i++ {
+			operator := p.operators[(p.next+i)%len(p.operators)]
+			if p.hasFunds(operator) {
+				selected = operator
+				p.next = (p.next + i + 1) % len(p.operators)
+				break
+			}
+		}
+	}
+
+	if selected == nil {
+		return Operator{}, fmt.Errorf("no operator with sufficient balance available")
+	}
+	selected.lastUsed = time.Now()
+	return selected.Operator, nil
+}
+
+// UpdateBalance records the balance in tinybars last observed for accountID.
+func (p *OperatorPool) UpdateBalance(accountID hedera.AccountID, tinybars int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, operator := range p.operators {
+		if operator.AccountID.String() == accountID.String() {
+			operator.balance = tinybars
+			operator.balanceKnown = true
+			return
+		}
+	}
+}
+
+// Balances returns the last observed balance in tinybars of every operator
+// whose balance has been checked, keyed by account ID.
+func (p *OperatorPool) Balances() map[string]int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	balances := make(map[string]int64)
+	for _, operator := range p.operators {
+		if operator.balanceKnown {
+			balances[operator.AccountID.String()] = operator.balance
+		}
+	}
+	return balances
+}
+
+// MinBalance returns the balance in tinybars below which operators are skipped.
+func (p *OperatorPool) MinBalance() int64 {
+	return p.minBalance
+}
+
+// hasFunds reports whether operator may be selected. Operators whose balance
+// has not been checked yet are assumed to be funded.
+func (p *OperatorPool) hasFunds(operator *pooledOperator) bool {
+	return !operator.balanceKnown || operator.balance >= p.minBalance
+}
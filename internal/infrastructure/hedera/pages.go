@@ -0,0 +1,48 @@
+package hedera
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrPageLimitExceeded means a query matched more mirror node pages than the
+// page budget allows, so its results would be incomplete. Match it with
+// errors.Is; the error itself is a *PageLimitError.
+var ErrPageLimitExceeded = errors.New("mirror node: page limit exceeded")
+
+// PageLimitError is returned instead of truncated results when a paginated
+// query still has a next page after MaxPages pages.
+type PageLimitError struct {
+	MaxPages int
+}
+
+func (e *PageLimitError) Error() string {
+	return fmt.Sprintf("mirror node results span more than %d pages", e.MaxPages)
+}
+
+func (e *PageLimitError) Unwrap() error {
+	return ErrPageLimitExceeded
+}
+
+type maxPagesKey struct{}
+
+// WithMaxPages returns a copy of ctx lowering the page budget of the mirror
+// node queries made with it to pages. It cannot raise the budget above the
+// MaxPages of the client; values below one are ignored.
+func WithMaxPages(ctx context.Context, pages int) context.Context {
+	return context.WithValue(ctx, maxPagesKey{}, pages)
+}
+
+// pageBudget returns the number of pages a paginated query made with ctx may
+// fetch.
+func (m *MirrorClient) pageBudget(ctx context.Context) int {
+	budget := m.MaxPages
+	if budget <= 0 {
+		budget = MaxPages
+	}
+	if pages, ok := ctx.Value(maxPagesKey{}).(int); ok && pages > 0 && pages < budget {
+		budget = pages
+	}
+	return budget
+}
@@ -0,0 +1,130 @@
+package hedera
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/metrics"
+	"go.uber.org/zap"
+)
+
+// RetryPolicy controls how the mirror client repeats requests that failed for
+// a reason that may go away: the mirror node rate limiting the relay, failing
+// with a 5xx status or timing out, or listing records it has not finished
+// importing. The wait before retry n is BaseDelay doubled n-1 times, capped at
+// MaxDelay, of which up to half is random so that relays hitting the same
+// failure do not retry in lockstep. Zero fields use the defaults.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// parseRetryAfter returns the wait a Retry-After header asks for, given in
+// seconds or as an HTTP date, capped at maxRetryAfter. It returns 0 when the
+// header is missing or invalid.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(header); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(header); err == nil {
+		wait = date.Sub(now)
+	}
+	return min(max(wait, 0), maxRetryAfter)
+}
+
+// rateLimited records that the mirror node at baseURL throttled the relay and
+// asked it to wait retryAfter before the next request.
+func (m *MirrorClient) rateLimited(baseURL string, retryAfter time.Duration) {
+	metrics.MirrorNodeRateLimited.WithLabelValues(baseURL).Inc()
+	m.logger.Warn("Mirror node is rate limiting the relay", zap.String("url", baseURL), zap.Duration("retryAfter", retryAfter))
+
+	until := time.Now().Add(retryAfter).UnixNano()
+	for {
+		current := m.throttledUntil.Load()
+		if until <= current || m.throttledUntil.CompareAndSwap(current, until) {
+			break
+		}
+	}
+
+	if m.OnRateLimited != nil {
+		m.OnRateLimited(retryAfter)
+	}
+}
+
+// throttleRemaining returns how much longer the mirror node asked the relay
+// to wait, or 0 once the wait is over.
+func (m *MirrorClient) throttleRemaining() time.Duration {
+	return max(time.Until(time.Unix(0, m.throttledUntil.Load())), 0)
+}
+
+// errImmatureRecords is returned when the mirror node lists records not yet
+// assigned to a block, which it does while it is still importing them.
+var errImmatureRecords = errors.New("dependent service returned immature records")
+
+// isRetryable reports whether a request that failed with err is worth
+// repeating.
+func isRetryable(err error) bool {
+	return isTransient(err) || errors.Is(err, errImmatureRecords)
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaultRetryAttempts
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = defaultRetryBaseDelay
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = defaultRetryMaxDelay
+	}
+	if p.MaxDelay < p.BaseDelay {
+		p.MaxDelay = p.BaseDelay
+	}
+	return p
+}
+
+// backoff returns the wait before retry number attempt, counted from one.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.MaxDelay
+	if shift := attempt - 1; shift < 32 && p.BaseDelay<<shift > 0 && p.BaseDelay<<shift < p.MaxDelay {
+		delay = p.BaseDelay << shift
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// retry runs attempt until it succeeds or fails with an error that is not
+// retryable, the policy runs out of attempts, or the deadline of ctx leaves no
+// time to wait and try again. It returns the error of the last attempt.
+func (m *MirrorClient) retry(ctx context.Context, operation string, attempt func() error) error {
+	policy := m.Retry.withDefaults()
+	for n := 1; ; n++ {
+		err := attempt()
+		if err == nil || !isRetryable(err) || n >= policy.MaxAttempts {
+			return err
+		}
+
+		delay := policy.backoff(n)
+		if errors.Is(err, ErrRateLimited) {
+			delay = max(delay, m.throttleRemaining())
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay+minRetryAttemptTime {
+			m.logger.Debug("Not enough time left to retry mirror node request", zap.String("operation", operation), zap.Error(err))
+			return err
+		}
+
+		m.logger.Debug("Retrying mirror node request", zap.String("operation", operation), zap.Int("attempt", n), zap.Duration("retry_delay", delay), zap.Error(err))
+		if err := sleepWithContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
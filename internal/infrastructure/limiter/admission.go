@@ -0,0 +1,121 @@
+package limiter
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/metrics"
+)
+
+// DefaultAdmissionMaxConcurrent is used when no number of worker slots is
+// configured.
+const DefaultAdmissionMaxConcurrent = 512
+
+var (
+	// ErrAdmissionQueueFull is returned for requests arriving while every
+	// worker slot is busy and the queue holds as many requests as it may.
+	ErrAdmissionQueueFull = errors.New("admission queue is full")
+	// ErrAdmissionTimeout is returned for requests that waited in the queue
+	// for the whole queue timeout without a worker slot freeing up.
+	ErrAdmissionTimeout = errors.New("timed out waiting in the admission queue")
+)
+
+// AdmissionConfig sets how many JSON-RPC requests are served at once and how
+// many more may wait for a worker slot, for up to QueueTimeout. A zero
+// QueueDepth or QueueTimeout rejects requests at once while every slot is
+// busy.
+type AdmissionConfig struct {
+	MaxConcurrent int
+	QueueDepth    int
+	QueueTimeout  time.Duration
+}
+
+// AdmissionQueue bounds the JSON-RPC requests the relay serves at once, across
+// all methods and networks, so that a surge queues briefly and then fails fast
+// instead of piling up goroutines and mirror node connections. It reports its
+// depth, in-flight requests and wait times as metrics for autoscaling. A nil
+// *AdmissionQueue admits every request at once.
+type AdmissionQueue struct {
+	slots        chan struct{}
+	queueDepth   int64
+	queueTimeout time.Duration
+	waiting      atomic.Int64
+}
+
+// NewAdmissionQueue creates a queue with config.MaxConcurrent worker slots,
+// DefaultAdmissionMaxConcurrent when it is below one.
+func NewAdmissionQueue(config AdmissionConfig) *AdmissionQueue {
+	if config.MaxConcurrent < 1 {
+		config.MaxConcurrent = DefaultAdmissionMaxConcurrent
+	}
+	return &AdmissionQueue{
+		slots:        make(chan struct{}, config.MaxConcurrent),
+		queueDepth:   int64(config.QueueDepth),
+		queueTimeout: config.QueueTimeout,
+	}
+}
+
+// Admit takes a worker slot for a request, waiting in the queue while none is
+// free. It returns a function giving the slot back, or ErrAdmissionQueueFull,
+// ErrAdmissionTimeout or the error of ctx when the request is not admitted.
+func (q *AdmissionQueue) Admit(ctx context.Context) (release func(), err error) {
+	if q == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case q.slots <- struct{}{}:
+		metrics.AdmissionQueueWait.Observe(0)
+		return q.admitted(), nil
+	default:
+	}
+	if q.queueTimeout <= 0 || q.queueDepth <= 0 {
+		metrics.AdmissionRejections.WithLabelValues("queue_full").Inc()
+		return nil, ErrAdmissionQueueFull
+	}
+	if q.waiting.Add(1) > q.queueDepth {
+		q.waiting.Add(-1)
+		metrics.AdmissionRejections.WithLabelValues("queue_full").Inc()
+		return nil, ErrAdmissionQueueFull
+	}
+
+	metrics.AdmissionQueueDepth.Inc()
+	start := time.Now()
+	defer func() {
+		q.waiting.Add(-1)
+		metrics.AdmissionQueueDepth.Dec()
+		metrics.AdmissionQueueWait.Observe(time.Since(start).Seconds())
+	}()
+
+	timer := time.NewTimer(q.queueTimeout)
+	defer timer.Stop()
+	select {
+	case q.slots <- struct{}{}:
+		return q.admitted(), nil
+	case <-timer.C:
+		metrics.AdmissionRejections.WithLabelValues("timeout").Inc()
+		return nil, ErrAdmissionTimeout
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Waiting returns the number of requests waiting for a worker slot.
+func (q *AdmissionQueue) Waiting() int {
+	if q == nil {
+		return 0
+	}
+	return int(q.waiting.Load())
+}
+
+// admitted counts a request taking a slot and returns the function giving it
+// back.
+func (q *AdmissionQueue) admitted() func() {
+	metrics.AdmissionInFlight.Inc()
+	return func() {
+		metrics.AdmissionInFlight.Dec()
+		<-q.slots
+	}
+}
@@ -0,0 +1,247 @@
+package limiter
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultAPIKeyReloadInterval is used when no reload interval is configured.
+const DefaultAPIKeyReloadInterval = 30 * time.Second
+
+var sqlIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// reloadingKeyStore serves keys from memory and refreshes them from a backend
+// every interval. A failed reload keeps the previous keys.
+type reloadingKeyStore struct {
+	*memoryKeyStore
+	load   func() ([]APIKey, error)
+	logger *zap.Logger
+}
+
+func newReloadingKeyStore(load func() ([]APIKey, error), interval time.Duration, logger *zap.Logger) (*reloadingKeyStore, error) {
+	keys, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &reloadingKeyStore{
+		memoryKeyStore: newMemoryKeyStore(keys),
+		load:           load,
+		logger:         logger,
+	}
+
+	if interval <= 0 {
+		interval = DefaultAPIKeyReloadInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			s.reload()
+		}
+	}()
+
+	return s, nil
+}
+
+func (s *reloadingKeyStore) reload() {
+	keys, err := s.load()
+	if err != nil {
+		s.logger.Warn("Failed to reload API keys, keeping the previous ones", zap.Error(err))
+		return
+	}
+	s.replace(keys)
+}
+
+// APIKeyStoreConfig selects and sets up an API key store, as the apiKeyStore
+// section of the config does.
+type APIKeyStoreConfig struct {
+	// Backend is config (the default), file or sql.
+	Backend string
+	// Keys are the keys of the config backend, in the format of apiKeys.
+	Keys interface{}
+	// Path is the file of the file backend.
+	Path string
+	// Driver, DSN and Table locate the keys of the sql backend, see OpenSQL.
+	Driver string
+	DSN    string
+	Table  string
+	// ReloadInterval is how often the file and sql backends are re-read.
+	ReloadInterval time.Duration
+}
+
+// OpenAPIKeyStore creates the API key store cfg selects. The sql backend
+// reads its keys once before returning, so an unreachable database fails here.
+func OpenAPIKeyStore(cfg APIKeyStoreConfig, logger *zap.Logger) (APIKeyStore, error) {
+	switch cfg.Backend {
+	case "", "config":
+		return NewAPIKeyStore(cfg.Keys), nil
+	case "file":
+		return NewFileAPIKeyStore(cfg.Path, cfg.ReloadInterval, logger)
+	case "sql":
+		db, err := OpenSQL(cfg.Driver, cfg.DSN)
+		if err != nil {
+			return nil, err
+		}
+		store, err := NewSQLAPIKeyStore(db, cfg.Table, cfg.ReloadInterval, logger)
+		if err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unsupported api key store backend: %s", cfg.Backend)
+	}
+}
+
+// NewFileAPIKeyStore serves the keys listed under apiKeys in a YAML or JSON
+// file and picks up changes to the file every reloadInterval, so keys can be
+// rotated without a restart.
+func NewFileAPIKeyStore(path string, reloadInterval time.Duration, logger *zap.Logger) (APIKeyStore, error) {
+	var lastModified time.Time
+	var keys []APIKey
+
+	load := func() ([]APIKey, error) {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if keys != nil && info.ModTime().Equal(lastModified) {
+			return keys, nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var file struct {
+			APIKeys []APIKey `yaml:"apiKeys"`
+		}
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+
+		loaded := make([]APIKey, 0, len(file.APIKeys))
+		for i, key := range file.APIKeys {
+			if (key.Key == "" && key.KeyHash == "") || key.Tier == "" {
+				continue
+			}
+			hashedKey, err := key.hashed()
+			if err != nil {
+				return nil, fmt.Errorf("parsing %s: api key %d: %w", path, i, err)
+			}
+			loaded = append(loaded, hashedKey)
+		}
+		keys = loaded
+		lastModified = info.ModTime()
+		logger.Info("Loaded API keys", zap.String("path", path), zap.Int("count", len(keys)))
+		return keys, nil
+	}
+
+	return newReloadingKeyStore(load, reloadInterval, logger)
+}
+
+// NewSQLAPIKeyStore serves the keys stored in table and re-reads it every
+// reloadInterval. The table needs the columns key_hash, holding the hex
+// SHA-256 hashes of the keys as HashAPIKey returns them, tier and the nullable
+// created_at, revoked_at and expires_at. Rows without a key_hash fall back to
+// a plain text key in api_key, so tables that hold keys in plain text keep
+// working while they are migrated. db is opened with OpenSQL.
+func NewSQLAPIKeyStore(db *sql.DB, table string, reloadInterval time.Duration, logger *zap.Logger) (APIKeyStore, error) {
+	if !sqlIdentifier.MatchString(table) {
+		return nil, fmt.Errorf("invalid api key table name: %q", table)
+	}
+
+	load := func() ([]APIKey, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		query, err := sqlAPIKeyQuery(ctx, db, table)
+		if err != nil {
+			return nil, err
+		}
+		rows, err := db.QueryContext(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = rows.Close() }()
+
+		var keys []APIKey
+		plainText := 0
+		for rows.Next() {
+			var key APIKey
+			var keyHash, apiKey sql.NullString
+			var createdAt, revokedAt, expiresAt sql.NullTime
+			if err := rows.Scan(&keyHash, &apiKey, &key.Tier, &createdAt, &revokedAt, &expiresAt); err != nil {
+				return nil, err
+			}
+			if keyHash.String != "" {
+				key.KeyHash = keyHash.String
+			} else {
+				key.Key = apiKey.String
+				plainText++
+			}
+			if createdAt.Valid {
+				key.CreatedAt = &createdAt.Time
+			}
+			if revokedAt.Valid {
+				key.RevokedAt = &revokedAt.Time
+			}
+			if expiresAt.Valid {
+				key.ExpiresAt = &expiresAt.Time
+			}
+			key, err := key.hashed()
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		if plainText > 0 {
+			logger.Warn("API keys read in plain text from api_key, store their hashes in key_hash instead",
+				zap.String("table", table), zap.Int("count", plainText))
+		}
+		return keys, nil
+	}
+
+	return newReloadingKeyStore(load, reloadInterval, logger)
+}
+
+// sqlAPIKeyQuery returns the query reading the keys of table. It selects the
+// key_hash and api_key columns, NULL in place of the one table lacks, so the
+// table can be migrated to key_hash without a restart.
+func sqlAPIKeyQuery(ctx context.Context, db *sql.DB, table string) (string, error) {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT * FROM %s WHERE 1 = 0", table))
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = rows.Close() }()
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+
+	keyHash, apiKey := "NULL", "NULL"
+	for _, column := range columns {
+		switch strings.ToLower(column) {
+		case "key_hash":
+			keyHash = "key_hash"
+		case "api_key":
+			apiKey = "api_key"
+		}
+	}
+	if keyHash == "NULL" && apiKey == "NULL" {
+		return "", fmt.Errorf("api key table %s has neither a key_hash nor an api_key column", table)
+	}
+	return fmt.Sprintf("SELECT %s, %s, tier, created_at, revoked_at, expires_at FROM %s", keyHash, apiKey, table), nil
+}
@@ -1,24 +1,219 @@
 package limiter
 
-type APIKeyStore struct {
-	keys map[string]string
-}
-
-func NewAPIKeyStore(apiKeys interface{}) *APIKeyStore {
-	keys := make(map[string]string)
-	if kArr, ok := apiKeys.([]interface{}); ok {
-		for _, kv := range kArr {
-			if keyMap, ok := kv.(map[interface{}]interface{}); ok {
-				apikey := keyMap["key"].(string)
-				tier := keyMap["tier"].(string)
-				keys[apikey] = tier
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIKey is an API key together with the tier it is assigned to. A key is
+// accepted from CreatedAt (when set) until RevokedAt or ExpiresAt, whichever
+// comes first (when set).
+//
+// Keys are configured either in plain text as Key or as the hex SHA-256 hash
+// of the key as KeyHash. Stores only keep the hash, so keys read from them
+// have KeyHash set and Key empty.
+type APIKey struct {
+	Key       string     `yaml:"key" json:"key,omitempty"`
+	KeyHash   string     `yaml:"keyHash" json:"keyHash"`
+	Tier      string     `yaml:"tier" json:"tier"`
+	CreatedAt *time.Time `yaml:"createdAt" json:"createdAt,omitempty"`
+	RevokedAt *time.Time `yaml:"revokedAt" json:"revokedAt,omitempty"`
+	ExpiresAt *time.Time `yaml:"expiresAt" json:"expiresAt,omitempty"`
+}
+
+// Active reports whether the key may be used at now.
+func (k APIKey) Active(now time.Time) bool {
+	if k.CreatedAt != nil && now.Before(*k.CreatedAt) {
+		return false
+	}
+	if k.ExpiresAt != nil && !now.Before(*k.ExpiresAt) {
+		return false
+	}
+	return k.RevokedAt == nil || now.Before(*k.RevokedAt)
+}
+
+// HashAPIKey returns the hex SHA-256 hash of apiKey, which identifies the key
+// in stores, rate limits and usage without revealing it.
+func HashAPIKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// hashed returns k with its plain text key replaced by its hash. Keys without
+// a tier, or with neither a key nor a valid hash, are reported as invalid.
+func (k APIKey) hashed() (APIKey, error) {
+	if k.Key != "" {
+		k.KeyHash = HashAPIKey(k.Key)
+		k.Key = ""
+	}
+	k.KeyHash = strings.ToLower(k.KeyHash)
+	if k.Tier == "" {
+		return k, fmt.Errorf("missing tier")
+	}
+	if decoded, err := hex.DecodeString(k.KeyHash); err != nil || len(decoded) != sha256.Size {
+		return k, fmt.Errorf("expected a key or a hex SHA-256 keyHash")
+	}
+	return k, nil
+}
+
+// APIKeyStore resolves API keys to rate-limit tiers.
+type APIKeyStore interface {
+	// GetTierForKey returns the hash and tier of an active key.
+	GetTierForKey(apiKey string) (keyHash string, tier string, ok bool)
+	// Keys returns every known key, including revoked and expired ones,
+	// sorted by hash.
+	Keys() []APIKey
+}
+
+// memoryKeyStore holds the current set of keys for every backend, by hash.
+// Backends that reload swap the whole set at once.
+//
+// Presented keys are hashed before they are looked up, so the lookup compares
+// digests the caller cannot choose byte by byte, and takes no longer for a
+// key sharing a prefix with a valid one.
+type memoryKeyStore struct {
+	mu   sync.RWMutex
+	keys map[string]APIKey
+}
+
+func newMemoryKeyStore(keys []APIKey) *memoryKeyStore {
+	s := &memoryKeyStore{}
+	s.replace(keys)
+	return s
+}
+
+func (s *memoryKeyStore) GetTierForKey(apiKey string) (string, string, bool) {
+	keyHash := HashAPIKey(apiKey)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, exists := s.keys[keyHash]
+	if !exists || !key.Active(time.Now()) {
+		return "", "", false
+	}
+	return keyHash, key.Tier, true
+}
+
+func (s *memoryKeyStore) Keys() []APIKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]APIKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].KeyHash < keys[j].KeyHash })
+	return keys
+}
+
+func (s *memoryKeyStore) replace(keys []APIKey) {
+	byKey := make(map[string]APIKey, len(keys))
+	for _, key := range keys {
+		byKey[key.KeyHash] = key
+	}
+
+	s.mu.Lock()
+	s.keys = byKey
+	s.mu.Unlock()
+}
+
+// NewAPIKeyStore creates a store over the keys listed under apiKeys in the
+// config. The keys only change when ReloadAPIKeys is called.
+func NewAPIKeyStore(apiKeys interface{}) APIKeyStore {
+	keys, _ := ParseAPIKeys(apiKeys)
+	return newMemoryKeyStore(keys)
+}
+
+// ReloadAPIKeys swaps the keys of a store created by NewAPIKeyStore for those
+// listed in apiKeys, all at once. Stores of other backends reload from their
+// own source and are left as they are; it reports whether the keys were
+// replaced.
+func ReloadAPIKeys(store APIKeyStore, apiKeys interface{}) (bool, error) {
+	memoryStore, ok := store.(*memoryKeyStore)
+	if !ok {
+		return false, nil
+	}
+	keys, err := ParseAPIKeys(apiKeys)
+	if err != nil {
+		return false, err
+	}
+	memoryStore.replace(keys)
+	return true, nil
+}
+
+// ParseAPIKeys reads a list of {key or keyHash, tier, createdAt, revokedAt,
+// expiresAt} entries as decoded by viper, returning them hashed. Timestamps
+// are RFC 3339 and optional; entries without a key or tier are skipped.
+func ParseAPIKeys(raw interface{}) ([]APIKey, error) {
+	entries, ok := raw.([]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	keys := make([]APIKey, 0, len(entries))
+	for i, entry := range entries {
+		fields := make(map[string]interface{})
+		switch m := entry.(type) {
+		case map[string]interface{}:
+			fields = m
+		case map[interface{}]interface{}:
+			for k, v := range m {
+				if name, ok := k.(string); ok {
+					fields[name] = v
+				}
 			}
+		default:
+			return nil, fmt.Errorf("api key %d: expected an object with key and tier", i)
+		}
+
+		apiKey, _ := fields["key"].(string)
+		keyHash, _ := fields["keyHash"].(string)
+		tier, _ := fields["tier"].(string)
+		if (apiKey == "" && keyHash == "") || tier == "" {
+			continue
+		}
+		key, err := APIKey{Key: apiKey, KeyHash: keyHash, Tier: tier}.hashed()
+		if err != nil {
+			return nil, fmt.Errorf("api key %d: %w", i, err)
+		}
+
+		if key.CreatedAt, err = parseKeyTimestamp(fields["createdAt"]); err != nil {
+			return nil, fmt.Errorf("api key %d: createdAt: %w", i, err)
 		}
+		if key.RevokedAt, err = parseKeyTimestamp(fields["revokedAt"]); err != nil {
+			return nil, fmt.Errorf("api key %d: revokedAt: %w", i, err)
+		}
+		if key.ExpiresAt, err = parseKeyTimestamp(fields["expiresAt"]); err != nil {
+			return nil, fmt.Errorf("api key %d: expiresAt: %w", i, err)
+		}
+
+		keys = append(keys, key)
 	}
-	return &APIKeyStore{keys: keys}
+	return keys, nil
 }
 
-func (s *APIKeyStore) GetTierForKey(apiKey string) (string, bool) {
-	tier, exists := s.keys[apiKey]
-	return tier, exists
+func parseKeyTimestamp(value interface{}) (*time.Time, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case time.Time:
+		return &v, nil
+	case string:
+		if v == "" {
+			return nil, nil
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, err
+		}
+		return &t, nil
+	default:
+		return nil, fmt.Errorf("unsupported timestamp %v", value)
+	}
 }
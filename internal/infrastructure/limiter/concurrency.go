@@ -0,0 +1,71 @@
+package limiter
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// ConcurrencyConfig sets the most calls of each JSON-RPC method in Limits that
+// may run at once. Calls over the limit wait up to QueueTimeout for a slot;
+// zero rejects them at once.
+type ConcurrencyConfig struct {
+	Limits       map[string]int
+	QueueTimeout time.Duration
+}
+
+// MethodConcurrency bounds how many calls of each configured JSON-RPC method
+// run at once, so that a burst of expensive queries such as eth_getLogs cannot
+// take every mirror node connection from cheap ones. Methods without a limit
+// are not bounded. A nil *MethodConcurrency bounds nothing.
+type MethodConcurrency struct {
+	slots        map[string]chan struct{}
+	queueTimeout time.Duration
+}
+
+// NewMethodConcurrency creates a semaphore for every method in config.Limits.
+// Method names match in any letter case, as viper lower-cases map keys.
+// Limits below one are ignored.
+func NewMethodConcurrency(config ConcurrencyConfig) *MethodConcurrency {
+	slots := make(map[string]chan struct{}, len(config.Limits))
+	for method, limit := range config.Limits {
+		if limit > 0 {
+			slots[strings.ToLower(method)] = make(chan struct{}, limit)
+		}
+	}
+	return &MethodConcurrency{slots: slots, queueTimeout: config.QueueTimeout}
+}
+
+// Acquire takes a slot for a call of method. It returns a function giving the
+// slot back, or false when no slot frees up within the queue timeout or before
+// ctx is done.
+func (c *MethodConcurrency) Acquire(ctx context.Context, method string) (release func(), ok bool) {
+	if c == nil {
+		return func() {}, true
+	}
+	slots, limited := c.slots[strings.ToLower(method)]
+	if !limited {
+		return func() {}, true
+	}
+
+	release = func() { <-slots }
+	select {
+	case slots <- struct{}{}:
+		return release, true
+	default:
+	}
+	if c.queueTimeout <= 0 {
+		return nil, false
+	}
+
+	timer := time.NewTimer(c.queueTimeout)
+	defer timer.Stop()
+	select {
+	case slots <- struct{}{}:
+		return release, true
+	case <-timer.C:
+		return nil, false
+	case <-ctx.Done():
+		return nil, false
+	}
+}
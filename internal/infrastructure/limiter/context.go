@@ -0,0 +1,24 @@
+package limiter
+
+import "context"
+
+type contextKey struct{}
+
+type caller struct {
+	apiKey string
+	tier   string
+}
+
+// WithAPIKey returns a copy of ctx carrying the API key and tier of the caller.
+func WithAPIKey(ctx context.Context, apiKey, tier string) context.Context {
+	return context.WithValue(ctx, contextKey{}, caller{apiKey: apiKey, tier: tier})
+}
+
+// APIKeyFromContext returns the API key and tier stored by WithAPIKey. Both are
+// empty when API keys are not enforced.
+func APIKeyFromContext(ctx context.Context) (apiKey string, tier string) {
+	if c, ok := ctx.Value(contextKey{}).(caller); ok {
+		return c.apiKey, c.tier
+	}
+	return "", ""
+}
@@ -1,86 +1,390 @@
 package limiter
 
 import (
+	"slices"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+)
+
+const (
+	// TinybarsPerHbar converts the HBAR amounts used in the config to tinybars.
+	TinybarsPerHbar = domain.TinybarsPerHbar
+
+	// DefaultHbarResetWindow is used when no reset window is configured.
+	DefaultHbarResetWindow = 24 * time.Hour
+
+	readBucket  = "read"
+	writeBucket = "write"
 )
 
+// WriteMethods lists the JSON-RPC methods that change state. They count
+// against the write bucket of a tier, every other method against its read
+// bucket.
+var WriteMethods = []string{"eth_sendRawTransaction"}
+
 type TierConfig struct {
 	RequestsPerMinute int
 	HbarLimit         int
+	// ReadRequestsPerMinute and WriteRequestsPerMinute, when set, limit the
+	// calls to read methods and to WriteMethods on their own, on top of
+	// RequestsPerMinute, so that reads cannot use up the calls left for
+	// transactions or the other way round. Each call of a batch counts.
+	ReadRequestsPerMinute  int
+	WriteRequestsPerMinute int
+	// AllowedMethods, when not empty, lists the only JSON-RPC methods the tier
+	// may call. DeniedMethods are refused even when allowed. An entry ending in
+	// "*" matches every method with that prefix, e.g. "debug_*".
+	AllowedMethods []string
+	DeniedMethods  []string
 }
 
 type TieredLimiter struct {
-	tierConfigs           map[string]*TierConfig
-	operatorHbarRemaining int
-	mu                    sync.Mutex
-	userRequestCounters   map[string]int
-	userHbarCounters      map[string]int
-	userLastReset         map[string]time.Time
+	tierConfigs         map[string]*TierConfig
+	mu                  sync.Mutex
+	userRequestCounters map[string]int
+	userLastReset       map[string]time.Time
+
+	// HBAR spending is tracked in tinybars, per API key and for the operator
+	// as a whole, and both counters start over every hbarResetWindow.
+	hbarResetWindow       time.Duration
+	operatorHbarBudget    int64
+	operatorHbarRemaining int64
+	operatorLastReset     time.Time
+	userHbarCounters      map[string]int64
+	userHbarLastReset     map[string]time.Time
+
+	// usage, when set, collects what every API key consumed
+	usage *UsageTracker
 }
 
-func NewTieredLimiter(cfg map[string]interface{}, operatorHbarBudget int) *TieredLimiter {
+func NewTieredLimiter(cfg map[string]interface{}, operatorHbarBudget int, hbarResetWindow time.Duration) *TieredLimiter {
+	if hbarResetWindow <= 0 {
+		hbarResetWindow = DefaultHbarResetWindow
+	}
+
 	tl := &TieredLimiter{
 		tierConfigs:           make(map[string]*TierConfig),
-		operatorHbarRemaining: operatorHbarBudget,
 		userRequestCounters:   make(map[string]int),
-		userHbarCounters:      make(map[string]int),
 		userLastReset:         make(map[string]time.Time),
+		hbarResetWindow:       hbarResetWindow,
+		operatorHbarBudget:    int64(operatorHbarBudget) * TinybarsPerHbar,
+		operatorHbarRemaining: int64(operatorHbarBudget) * TinybarsPerHbar,
+		operatorLastReset:     time.Now(),
+		userHbarCounters:      make(map[string]int64),
+		userHbarLastReset:     make(map[string]time.Time),
 	}
+	tl.tierConfigs = parseTiers(cfg)
+	return tl
+}
 
+// parseTiers reads the tiers listed under limiter in the config.
+func parseTiers(cfg map[string]interface{}) map[string]*TierConfig {
+	tiers := make(map[string]*TierConfig, len(cfg))
 	for tierName, val := range cfg {
-		if m, ok := val.(map[interface{}]interface{}); ok {
-			tl.tierConfigs[tierName] = &TierConfig{
-				RequestsPerMinute: m["requestsPerMinute"].(int),
-				HbarLimit:         m["hbarLimit"].(int),
+		if m, ok := tierFields(val); ok {
+			requestsPerMinute, _ := m["requestsperminute"].(int)
+			hbarLimit, _ := m["hbarlimit"].(int)
+			readRequestsPerMinute, _ := m["readrequestsperminute"].(int)
+			writeRequestsPerMinute, _ := m["writerequestsperminute"].(int)
+			tiers[tierName] = &TierConfig{
+				RequestsPerMinute:      requestsPerMinute,
+				HbarLimit:              hbarLimit,
+				ReadRequestsPerMinute:  readRequestsPerMinute,
+				WriteRequestsPerMinute: writeRequestsPerMinute,
+				AllowedMethods:         stringList(m["allowedmethods"]),
+				DeniedMethods:          stringList(m["deniedmethods"]),
 			}
 		}
 	}
-	return tl
+	return tiers
+}
+
+// tierFields returns the settings of a tier keyed by their lower-cased name,
+// as viper lower-cases nested keys while YAML decoded directly keeps them.
+func tierFields(val interface{}) (map[string]interface{}, bool) {
+	fields := make(map[string]interface{})
+	switch m := val.(type) {
+	case map[string]interface{}:
+		for k, v := range m {
+			fields[strings.ToLower(k)] = v
+		}
+	case map[interface{}]interface{}:
+		for k, v := range m {
+			if name, ok := k.(string); ok {
+				fields[strings.ToLower(name)] = v
+			}
+		}
+	default:
+		return nil, false
+	}
+	return fields, true
+}
+
+func stringList(val interface{}) []string {
+	switch v := val.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+// RateLimitStatus describes the request window of an API key: the requests
+// its tier may make per window, how many are left and when the window starts
+// over.
+type RateLimitStatus struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// RetryAfter returns the seconds until the window starts over, rounded up
+// and at least one.
+func (s RateLimitStatus) RetryAfter() int {
+	seconds := int((time.Until(s.Reset) + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
 }
 
 func (t *TieredLimiter) CheckLimits(apiKey string, tier string) bool {
+	_, ok := t.Allow(apiKey, tier)
+	return ok
+}
+
+// Allow counts a request by apiKey against the per-minute limit of its tier
+// and reports whether it may go ahead, together with the state of the window
+// after counting it. Requests of unknown tiers are refused.
+func (t *TieredLimiter) Allow(apiKey string, tier string) (RateLimitStatus, bool) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
 	tc, exists := t.tierConfigs[tier]
 	if !exists {
-		return false
+		return RateLimitStatus{}, false
+	}
+
+	return t.count(apiKey, tc.RequestsPerMinute)
+}
+
+// AllowMethod counts a call of the JSON-RPC method by apiKey against the read
+// or write bucket of its tier and reports whether it may go ahead, together
+// with the state of the bucket after counting it. Calls are not limited when
+// the tier is unknown or sets no limit for the bucket.
+func (t *TieredLimiter) AllowMethod(apiKey, tier, method string) (RateLimitStatus, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tc, exists := t.tierConfigs[tier]
+	if !exists {
+		return RateLimitStatus{}, true
+	}
+
+	bucket, limit := readBucket, tc.ReadRequestsPerMinute
+	if IsWriteMethod(method) {
+		bucket, limit = writeBucket, tc.WriteRequestsPerMinute
 	}
+	if limit <= 0 {
+		return RateLimitStatus{}, true
+	}
+	// Key hashes hold no "/", so buckets never share a counter with a key
+	return t.count(apiKey+"/"+bucket, limit)
+}
 
+// count counts a request against the per-minute window of counter, which
+// allows limit requests. t.mu must be held.
+func (t *TieredLimiter) count(counter string, limit int) (RateLimitStatus, bool) {
 	now := time.Now()
-	lastReset, ok := t.userLastReset[apiKey]
+	lastReset, ok := t.userLastReset[counter]
 	if !ok || now.Sub(lastReset) > time.Minute {
-		t.userRequestCounters[apiKey] = 0
-		t.userHbarCounters[apiKey] = 0
-		t.userLastReset[apiKey] = now
+		t.userRequestCounters[counter] = 0
+		t.userLastReset[counter] = now
+		lastReset = now
 	}
 
-	if t.userRequestCounters[apiKey] >= tc.RequestsPerMinute {
-		return false
+	status := RateLimitStatus{
+		Limit: limit,
+		Reset: lastReset.Add(time.Minute),
+	}
+	if t.userRequestCounters[counter] >= limit {
+		return status, false
 	}
 
-	t.userRequestCounters[apiKey]++
-	return true
+	t.userRequestCounters[counter]++
+	status.Remaining = limit - t.userRequestCounters[counter]
+	return status, true
+}
+
+// IsWriteMethod reports whether method is one of WriteMethods.
+func IsWriteMethod(method string) bool {
+	return slices.Contains(WriteMethods, method)
 }
 
-func (t *TieredLimiter) DeductHbarUsage(apiKey, tier string, amount int) bool {
+// MethodAllowed reports whether callers of the given tier may use the JSON-RPC
+// method. Tiers that are not configured have no method restrictions.
+func (t *TieredLimiter) MethodAllowed(tier, method string) bool {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
 	tc, exists := t.tierConfigs[tier]
 	if !exists {
+		return true
+	}
+	if len(tc.AllowedMethods) > 0 && !matchesMethod(tc.AllowedMethods, method) {
 		return false
 	}
+	return !matchesMethod(tc.DeniedMethods, method)
+}
 
-	if t.userHbarCounters[apiKey]+amount > tc.HbarLimit {
-		return false
+func matchesMethod(patterns []string, method string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(method, prefix) {
+				return true
+			}
+		} else if pattern == method {
+			return true
+		}
 	}
+	return false
+}
+
+// DeductHbarUsage charges amount tinybars against the operator budget and, when
+// an API key is given, against the HBAR limit of its tier. Nothing is charged
+// if either budget would be exceeded.
+func (t *TieredLimiter) DeductHbarUsage(apiKey, tier string, amount int64) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(t.operatorLastReset) > t.hbarResetWindow {
+		t.operatorHbarRemaining = t.operatorHbarBudget
+		t.operatorLastReset = now
+	}
+
 	if t.operatorHbarRemaining < amount {
 		return false
 	}
 
-	t.userHbarCounters[apiKey] += amount
+	if apiKey != "" {
+		tc, exists := t.tierConfigs[tier]
+		if !exists {
+			return false
+		}
+
+		lastReset, ok := t.userHbarLastReset[apiKey]
+		if !ok || now.Sub(lastReset) > t.hbarResetWindow {
+			t.userHbarCounters[apiKey] = 0
+			t.userHbarLastReset[apiKey] = now
+		}
+
+		if t.userHbarCounters[apiKey]+amount > int64(tc.HbarLimit)*TinybarsPerHbar {
+			return false
+		}
+
+		t.userHbarCounters[apiKey] += amount
+	}
+
 	t.operatorHbarRemaining -= amount
+	t.usage.RecordHbar(apiKey, tier, amount)
 	return true
 }
+
+// TrackUsage makes the limiter report the usage of API keys to tracker. It
+// must be called before the limiter is used.
+func (t *TieredLimiter) TrackUsage(tracker *UsageTracker) {
+	t.usage = tracker
+}
+
+// Usage returns the tracker collecting the usage of API keys, nil when usage
+// is not tracked.
+func (t *TieredLimiter) Usage() *UsageTracker {
+	if t == nil {
+		return nil
+	}
+	return t.usage
+}
+
+// OperatorHbarRemaining returns the tinybars left in the operator budget for
+// the current window.
+func (t *TieredLimiter) OperatorHbarRemaining() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if time.Since(t.operatorLastReset) > t.hbarResetWindow {
+		return t.operatorHbarBudget
+	}
+	return t.operatorHbarRemaining
+}
+
+// UserHbarUsage returns the tinybars spent by apiKey in the current window.
+func (t *TieredLimiter) UserHbarUsage(apiKey string) int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if lastReset, ok := t.userHbarLastReset[apiKey]; !ok || time.Since(lastReset) > t.hbarResetWindow {
+		return 0
+	}
+	return t.userHbarCounters[apiKey]
+}
+
+// Tiers returns a copy of the configured rate-limit tiers.
+func (t *TieredLimiter) Tiers() map[string]TierConfig {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tiers := make(map[string]TierConfig, len(t.tierConfigs))
+	for name, tc := range t.tierConfigs {
+		tiers[name] = *tc
+	}
+	return tiers
+}
+
+// SetTier adds the named tier or replaces its limits. Counters already
+// collected for the current window are kept.
+func (t *TieredLimiter) SetTier(name string, cfg TierConfig) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.tierConfigs[name] = &cfg
+}
+
+// ReplaceTiers swaps all tiers for those of cfg, in the format of the limiter
+// section of the config, at once. Tiers left out are removed, so their keys
+// are refused until they are given a tier again. Counters already collected
+// for the current window are kept.
+func (t *TieredLimiter) ReplaceTiers(cfg map[string]interface{}) {
+	tiers := parseTiers(cfg)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.tierConfigs = tiers
+}
+
+// RequestCount returns the requests made by apiKey in the current minute.
+func (t *TieredLimiter) RequestCount(apiKey string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if lastReset, ok := t.userLastReset[apiKey]; !ok || time.Since(lastReset) > time.Minute {
+		return 0
+	}
+	return t.userRequestCounters[apiKey]
+}
+
+// OperatorHbarBudget returns the operator budget in tinybars per reset window.
+func (t *TieredLimiter) OperatorHbarBudget() int64 {
+	return t.operatorHbarBudget
+}
@@ -0,0 +1,212 @@
+package limiter
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/metrics"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultOverloadCheckInterval is used when no check interval is configured.
+	DefaultOverloadCheckInterval = 5 * time.Second
+
+	// DefaultMinMirrorRequests is used when no minimum number of mirror node
+	// requests is configured for the error rate to count.
+	DefaultMinMirrorRequests = 20
+)
+
+// OverloadConfig sets when the relay sheds load. Every CheckInterval the
+// goroutine count, the heap in use and the share of mirror node requests that
+// failed since the previous check are compared with their limits; zero limits
+// are not checked, and the error rate only counts once MinMirrorRequests were
+// made. While any limit is exceeded, and while the mirror node asks the relay
+// to back off, calls of LowPriorityMethods, which may end in * to match a
+// prefix, and eth_getLogs queries spanning more than MaxLogsBlockRange blocks
+// are rejected.
+type OverloadConfig struct {
+	CheckInterval      time.Duration
+	MaxGoroutines      int
+	MaxHeapBytes       uint64
+	MaxMirrorErrorRate float64
+	MinMirrorRequests  int64
+	LowPriorityMethods []string
+	MaxLogsBlockRange  int64
+}
+
+// OverloadController watches the relay for memory and upstream pressure and
+// tells which calls to turn away while it lasts, so that transactions and
+// receipts keep being served. A nil *OverloadController never sheds load.
+type OverloadController struct {
+	config OverloadConfig
+	logger *zap.Logger
+
+	overloaded     atomic.Bool
+	mirrorRequests atomic.Int64
+	mirrorFailures atomic.Int64
+	// throttledUntil is the end, in Unix nanoseconds, of the latest backoff
+	// the mirror node asked for
+	throttledUntil atomic.Int64
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewOverloadController starts a controller checking the limits of config
+// every check interval until Stop is called.
+func NewOverloadController(config OverloadConfig, logger *zap.Logger) *OverloadController {
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = DefaultOverloadCheckInterval
+	}
+	if config.MinMirrorRequests <= 0 {
+		config.MinMirrorRequests = DefaultMinMirrorRequests
+	}
+
+	c := &OverloadController{
+		config: config,
+		logger: logger,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		defer close(c.done)
+		ticker := time.NewTicker(config.CheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.check()
+			case <-c.stop:
+				return
+			}
+		}
+	}()
+
+	return c
+}
+
+// Stop ends the periodic checks.
+func (c *OverloadController) Stop() {
+	if c == nil {
+		return
+	}
+	close(c.stop)
+	<-c.done
+}
+
+// Overloaded reports whether a limit was exceeded at the last check or the
+// mirror node is rate limiting the relay.
+func (c *OverloadController) Overloaded() bool {
+	return c != nil && (c.overloaded.Load() || c.throttled())
+}
+
+// RecordMirrorResult counts a mirror node request towards the error rate.
+func (c *OverloadController) RecordMirrorResult(failed bool) {
+	if c == nil {
+		return
+	}
+	c.mirrorRequests.Add(1)
+	if failed {
+		c.mirrorFailures.Add(1)
+	}
+}
+
+// RecordMirrorRateLimit sheds load for retryAfter, or until the next check
+// when the mirror node did not say how long to back off, so that the relay
+// sends the throttling mirror node only the calls that matter most.
+func (c *OverloadController) RecordMirrorRateLimit(retryAfter time.Duration) {
+	if c == nil {
+		return
+	}
+	if retryAfter <= 0 {
+		retryAfter = c.config.CheckInterval
+	}
+
+	now := time.Now()
+	until := now.Add(retryAfter).UnixNano()
+	for {
+		current := c.throttledUntil.Load()
+		if until <= current {
+			return
+		}
+		if c.throttledUntil.CompareAndSwap(current, until) {
+			if current <= now.UnixNano() && !c.overloaded.Load() {
+				c.logger.Warn("Mirror node is rate limiting the relay, shedding low-priority requests", zap.Duration("retryAfter", retryAfter))
+			}
+			return
+		}
+	}
+}
+
+func (c *OverloadController) throttled() bool {
+	return time.Now().UnixNano() < c.throttledUntil.Load()
+}
+
+// LowPriority reports whether calls of method are shed under load.
+func (c *OverloadController) LowPriority(method string) bool {
+	return c != nil && matchesMethod(c.config.LowPriorityMethods, method)
+}
+
+// MaxLogsBlockRange returns the most blocks an eth_getLogs query may span to
+// be served under load, or 0 when queries are not shed by span.
+func (c *OverloadController) MaxLogsBlockRange() int64 {
+	if c == nil {
+		return 0
+	}
+	return c.config.MaxLogsBlockRange
+}
+
+// check compares the current load with the limits and starts or stops
+// shedding, logging every change.
+func (c *OverloadController) check() {
+	reasons := c.exceededLimits()
+	overloaded := len(reasons) > 0
+	if c.overloaded.Swap(overloaded) == overloaded {
+		return
+	}
+
+	if overloaded {
+		metrics.LoadSheddingActive.Set(1)
+		c.logger.Warn("Relay overloaded, shedding low-priority requests", zap.String("reasons", strings.Join(reasons, ", ")))
+	} else {
+		metrics.LoadSheddingActive.Set(0)
+		c.logger.Info("Relay load back to normal, serving every request")
+	}
+}
+
+func (c *OverloadController) exceededLimits() []string {
+	var reasons []string
+
+	if limit := c.config.MaxGoroutines; limit > 0 {
+		if goroutines := runtime.NumGoroutine(); goroutines > limit {
+			reasons = append(reasons, fmt.Sprintf("%d goroutines over %d", goroutines, limit))
+		}
+	}
+
+	if limit := c.config.MaxHeapBytes; limit > 0 {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		if stats.HeapAlloc > limit {
+			reasons = append(reasons, fmt.Sprintf("%d heap bytes over %d", stats.HeapAlloc, limit))
+		}
+	}
+
+	if c.throttled() {
+		reasons = append(reasons, "mirror node rate limiting the relay")
+	}
+
+	requests := c.mirrorRequests.Swap(0)
+	failures := c.mirrorFailures.Swap(0)
+	if limit := c.config.MaxMirrorErrorRate; limit > 0 && requests >= c.config.MinMirrorRequests {
+		if rate := float64(failures) / float64(requests); rate > limit {
+			reasons = append(reasons, fmt.Sprintf("mirror node error rate %.2f over %.2f", rate, limit))
+		}
+	}
+
+	return reasons
+}
@@ -0,0 +1,30 @@
+package limiter
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	// The PostgreSQL driver the SQL API key store and usage sink run on
+	_ "github.com/lib/pq"
+)
+
+// PostgresDriver is the database/sql driver of the SQL API key store and usage
+// sink. It is linked into the relay, and the queries of both are written for
+// it, e.g. the usage sink uses its $n placeholders.
+const PostgresDriver = "postgres"
+
+// OpenSQL opens the database of the SQL API key store or usage sink. The
+// driver must be PostgresDriver, which an empty driver defaults to.
+func OpenSQL(driver, dsn string) (*sql.DB, error) {
+	if driver == "" {
+		driver = PostgresDriver
+	}
+	if driver != PostgresDriver {
+		return nil, fmt.Errorf("unsupported sql driver %q, only %q is supported", driver, PostgresDriver)
+	}
+	if dsn == "" {
+		return nil, errors.New("missing sql data source name")
+	}
+	return sql.Open(driver, dsn)
+}
@@ -0,0 +1,198 @@
+package limiter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultUsageFlushInterval is used when no flush interval is configured.
+const DefaultUsageFlushInterval = time.Minute
+
+// usageWriteTimeout bounds how long a sink may take to store one flush.
+const usageWriteTimeout = 30 * time.Second
+
+// MethodUsage counts the calls of one JSON-RPC method and how many failed.
+type MethodUsage struct {
+	Requests int64 `json:"requests"`
+	Errors   int64 `json:"errors"`
+}
+
+// KeyUsage is what an API key consumed between From and To: JSON-RPC calls,
+// in total and by method, failed calls, response bytes and HBAR spent on its
+// transactions. APIKey is the hash of the key, which callers are identified by
+// once authenticated, and KeyID a shorter identifier derived from it.
+type KeyUsage struct {
+	APIKey       string                 `json:"apiKey"`
+	KeyID        string                 `json:"keyId"`
+	Tier         string                 `json:"tier"`
+	From         time.Time              `json:"from"`
+	To           time.Time              `json:"to"`
+	Requests     int64                  `json:"requests"`
+	Errors       int64                  `json:"errors"`
+	BytesServed  int64                  `json:"bytesServed"`
+	HbarTinybars int64                  `json:"hbarTinybars"`
+	Methods      map[string]MethodUsage `json:"methods"`
+}
+
+// UsageSink stores the usage collected over a flush interval, one record per
+// API key that made requests in it.
+type UsageSink interface {
+	Write(ctx context.Context, usage []KeyUsage) error
+}
+
+// UsageTracker collects the usage of every API key and hands it to a sink
+// every flush interval, for operators billing by usage. A nil *UsageTracker
+// records nothing.
+type UsageTracker struct {
+	mu     sync.Mutex
+	sink   UsageSink
+	logger *zap.Logger
+	from   time.Time
+	keys   map[string]*KeyUsage
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewUsageTracker starts a tracker flushing to sink every interval, or every
+// DefaultUsageFlushInterval when interval is zero. Stop flushes what is left.
+func NewUsageTracker(sink UsageSink, interval time.Duration, logger *zap.Logger) *UsageTracker {
+	if interval <= 0 {
+		interval = DefaultUsageFlushInterval
+	}
+
+	t := &UsageTracker{
+		sink:   sink,
+		logger: logger,
+		from:   time.Now(),
+		keys:   make(map[string]*KeyUsage),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		defer close(t.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				t.flush()
+			case <-t.stop:
+				t.flush()
+				return
+			}
+		}
+	}()
+
+	return t
+}
+
+// RecordRequest counts a call of method by apiKey, which failed when failed
+// is set. Calls without an API key are not tracked.
+func (t *UsageTracker) RecordRequest(apiKey, tier, method string, failed bool) {
+	if t == nil || apiKey == "" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	usage := t.usage(apiKey, tier)
+	methodUsage := usage.Methods[method]
+	usage.Requests++
+	methodUsage.Requests++
+	if failed {
+		usage.Errors++
+		methodUsage.Errors++
+	}
+	usage.Methods[method] = methodUsage
+}
+
+// RecordBytes adds n response bytes served to apiKey.
+func (t *UsageTracker) RecordBytes(apiKey, tier string, n int64) {
+	if t == nil || apiKey == "" || n <= 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.usage(apiKey, tier).BytesServed += n
+}
+
+// RecordHbar adds tinybars spent on transactions of apiKey.
+func (t *UsageTracker) RecordHbar(apiKey, tier string, tinybars int64) {
+	if t == nil || apiKey == "" || tinybars <= 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.usage(apiKey, tier).HbarTinybars += tinybars
+}
+
+// usage returns the record of apiKey for the current interval, creating it on
+// first use. The caller holds t.mu.
+func (t *UsageTracker) usage(apiKey, tier string) *KeyUsage {
+	usage, ok := t.keys[apiKey]
+	if !ok {
+		usage = &KeyUsage{
+			APIKey:  apiKey,
+			KeyID:   UsageKeyID(apiKey),
+			Tier:    tier,
+			Methods: make(map[string]MethodUsage),
+		}
+		t.keys[apiKey] = usage
+	}
+	return usage
+}
+
+// Stop ends the periodic flushes after flushing the usage collected since the
+// last one.
+func (t *UsageTracker) Stop() {
+	if t == nil {
+		return
+	}
+	close(t.stop)
+	<-t.done
+}
+
+// flush hands the usage of the interval that just ended to the sink. Usage the
+// sink fails to store is logged and dropped, so that a sink outage cannot grow
+// memory without bound.
+func (t *UsageTracker) flush() {
+	t.mu.Lock()
+	now := time.Now()
+	usage := make([]KeyUsage, 0, len(t.keys))
+	for _, keyUsage := range t.keys {
+		keyUsage.From = t.from
+		keyUsage.To = now
+		usage = append(usage, *keyUsage)
+	}
+	t.keys = make(map[string]*KeyUsage)
+	t.from = now
+	t.mu.Unlock()
+
+	if len(usage) == 0 {
+		return
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].KeyID < usage[j].KeyID })
+
+	ctx, cancel := context.WithTimeout(context.Background(), usageWriteTimeout)
+	defer cancel()
+	if err := t.sink.Write(ctx, usage); err != nil {
+		t.logger.Error("Failed to write API key usage", zap.Int("keys", len(usage)), zap.Error(err))
+	}
+}
+
+// UsageKeyID returns a stable identifier of apiKey that can be shared with
+// systems that must not see the key itself.
+func UsageKeyID(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,215 @@
+package limiter
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// maxStatsDPacket keeps StatsD datagrams within a typical network MTU.
+const maxStatsDPacket = 1400
+
+// UsageSinkConfig selects and sets up a usage sink, as the apiKeyUsage
+// section of the config does.
+type UsageSinkConfig struct {
+	// Sink is file (the default), sql or statsd.
+	Sink string
+	// Path is the file of the file sink.
+	Path string
+	// Driver, DSN and Table locate the table of the sql sink, see OpenSQL.
+	Driver string
+	DSN    string
+	Table  string
+	// StatsDAddress and StatsDPrefix set up the statsd sink.
+	StatsDAddress string
+	StatsDPrefix  string
+}
+
+// OpenUsageSink creates the usage sink cfg selects.
+func OpenUsageSink(cfg UsageSinkConfig) (UsageSink, error) {
+	switch cfg.Sink {
+	case "", "file":
+		return NewFileUsageSink(cfg.Path), nil
+	case "sql":
+		db, err := OpenSQL(cfg.Driver, cfg.DSN)
+		if err != nil {
+			return nil, err
+		}
+		sink, err := NewSQLUsageSink(db, cfg.Table)
+		if err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+		return sink, nil
+	case "statsd":
+		return NewStatsDUsageSink(cfg.StatsDAddress, cfg.StatsDPrefix)
+	default:
+		return nil, fmt.Errorf("unsupported api key usage sink: %s", cfg.Sink)
+	}
+}
+
+// fileUsageSink appends every usage record as a line of JSON to a file.
+type fileUsageSink struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileUsageSink writes usage as JSON lines appended to the file at path,
+// which is created when missing.
+func NewFileUsageSink(path string) UsageSink {
+	return &fileUsageSink{path: path}
+}
+
+func (s *fileUsageSink) Write(_ context.Context, usage []KeyUsage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(file)
+	for _, keyUsage := range usage {
+		if err := encoder.Encode(keyUsage); err != nil {
+			_ = file.Close()
+			return err
+		}
+	}
+	return file.Close()
+}
+
+// sqlUsageSink inserts a row per key and flush interval into a table.
+type sqlUsageSink struct {
+	db     *sql.DB
+	insert string
+}
+
+// NewSQLUsageSink stores usage in table, one row per API key and flush
+// interval, with the columns api_key, key_id, tier, period_start, period_end,
+// requests, errors, bytes_served, hbar_tinybars and methods, the per-method
+// counts as JSON. The insert uses PostgreSQL placeholders, so db is opened
+// with OpenSQL.
+func NewSQLUsageSink(db *sql.DB, table string) (UsageSink, error) {
+	if !sqlIdentifier.MatchString(table) {
+		return nil, fmt.Errorf("invalid usage table name: %q", table)
+	}
+	insert := fmt.Sprintf("INSERT INTO %s (api_key, key_id, tier, period_start, period_end, requests, errors, bytes_served, hbar_tinybars, methods) "+
+		"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)", table)
+	return &sqlUsageSink{db: db, insert: insert}, nil
+}
+
+func (s *sqlUsageSink) Write(ctx context.Context, usage []KeyUsage) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, keyUsage := range usage {
+		methods, err := json.Marshal(keyUsage.Methods)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, s.insert,
+			keyUsage.APIKey, keyUsage.KeyID, keyUsage.Tier, keyUsage.From, keyUsage.To,
+			keyUsage.Requests, keyUsage.Errors, keyUsage.BytesServed, keyUsage.HbarTinybars, string(methods),
+		); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// statsDUsageSink sends usage as StatsD counters over UDP.
+type statsDUsageSink struct {
+	conn   net.Conn
+	prefix string
+}
+
+// NewStatsDUsageSink sends usage to the StatsD server at address as counters
+// named <prefix>.<keyId>.<counter>, with per-method counters under
+// <prefix>.<keyId>.methods.<method>. Keys are identified by KeyID, so that
+// API keys are not sent to the metrics system.
+func NewStatsDUsageSink(address, prefix string) (UsageSink, error) {
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return nil, err
+	}
+	return &statsDUsageSink{conn: conn, prefix: strings.TrimSuffix(prefix, ".")}, nil
+}
+
+func (s *statsDUsageSink) Write(_ context.Context, usage []KeyUsage) error {
+	var packet strings.Builder
+	send := func() error {
+		if packet.Len() == 0 {
+			return nil
+		}
+		_, err := s.conn.Write([]byte(packet.String()))
+		packet.Reset()
+		return err
+	}
+
+	for _, keyUsage := range usage {
+		for _, line := range statsDLines(s.prefix, keyUsage) {
+			if packet.Len() > 0 && packet.Len()+1+len(line) > maxStatsDPacket {
+				if err := send(); err != nil {
+					return err
+				}
+			}
+			if packet.Len() > 0 {
+				packet.WriteByte('\n')
+			}
+			packet.WriteString(line)
+		}
+	}
+	return send()
+}
+
+func statsDLines(prefix string, usage KeyUsage) []string {
+	name := usage.KeyID
+	if prefix != "" {
+		name = prefix + "." + name
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s.requests:%d|c", name, usage.Requests),
+		fmt.Sprintf("%s.errors:%d|c", name, usage.Errors),
+		fmt.Sprintf("%s.bytes_served:%d|c", name, usage.BytesServed),
+		fmt.Sprintf("%s.hbar_tinybars:%d|c", name, usage.HbarTinybars),
+	}
+
+	methods := make([]string, 0, len(usage.Methods))
+	for method := range usage.Methods {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	for _, method := range methods {
+		methodUsage := usage.Methods[method]
+		metric := statsDName(method)
+		lines = append(lines,
+			fmt.Sprintf("%s.methods.%s.requests:%d|c", name, metric, methodUsage.Requests),
+			fmt.Sprintf("%s.methods.%s.errors:%d|c", name, metric, methodUsage.Errors),
+		)
+	}
+	return lines
+}
+
+// statsDName replaces the characters StatsD gives a meaning to, as method
+// names are chosen by clients.
+func statsDName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.', ':', '|', '@', '\n', ' ':
+			return '_'
+		}
+		return r
+	}, name)
+}
@@ -1,17 +1,130 @@
 package logger
 
 import (
+	"fmt"
+	"os"
+	"time"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-func InitLogger(level string) *zap.Logger {
-	var l zapcore.Level
-	if err := l.Set(level); err != nil {
-		l = zapcore.InfoLevel
+// Config selects the encoding, destinations and sampling of the application
+// logger. The zero value logs JSON at info level to stderr.
+type Config struct {
+	Level string
+	// Encoding is "json" or "console".
+	Encoding      string
+	DisableCaller bool
+	Sampling      SamplingConfig
+	File          FileConfig
+	Sink          SinkConfig
+}
+
+// SamplingConfig limits repeated info and debug entries. Within each Tick the
+// first Initial entries with the same level and message are logged, then
+// every Thereafter-th one. Warnings and errors are never sampled.
+type SamplingConfig struct {
+	Enabled    bool
+	Initial    int
+	Thereafter int
+	Tick       time.Duration
+}
+
+// FileConfig writes logs to a file instead of stderr when Path is set. The
+// file is rotated once it grows past MaxSizeMB, keeping MaxBackups old files.
+type FileConfig struct {
+	Path       string
+	MaxSizeMB  int
+	MaxBackups int
+}
+
+// SinkConfig ships JSON log entries to an HTTP endpoint when URL is set, in
+// addition to the regular output.
+type SinkConfig struct {
+	URL           string
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+// level is the minimum level of the application logger, which SetLevel
+// changes while it runs.
+var level = zap.NewAtomicLevel()
+
+// SetLevel changes the minimum level of the logger built by InitLogger,
+// e.g. to debug a running relay.
+func SetLevel(name string) error {
+	return level.UnmarshalText([]byte(name))
+}
+
+// InitLogger builds the application logger described by cfg. Unknown levels
+// fall back to info.
+func InitLogger(cfg Config) (*zap.Logger, error) {
+	if err := SetLevel(cfg.Level); err != nil {
+		level.SetLevel(zapcore.InfoLevel)
 	}
-	cfg := zap.NewProductionConfig()
-	cfg.Level = zap.NewAtomicLevelAt(l)
+
+	encoderConfig := zap.NewProductionEncoderConfig()
+
+	var encoder zapcore.Encoder
+	switch cfg.Encoding {
+	case "", "json":
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+	case "console":
+		consoleConfig := encoderConfig
+		consoleConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoder = zapcore.NewConsoleEncoder(consoleConfig)
+	default:
+		return nil, fmt.Errorf("unknown log encoding %q", cfg.Encoding)
+	}
+
+	output := zapcore.Lock(os.Stderr)
+	if cfg.File.Path != "" {
+		file, err := NewRotatingFile(cfg.File.Path, int64(cfg.File.MaxSizeMB)<<20, cfg.File.MaxBackups)
+		if err != nil {
+			return nil, err
+		}
+		output = file
+	}
+
+	cores := []zapcore.Core{newCore(encoder, output, level, cfg.Sampling)}
+	if cfg.Sink.URL != "" {
+		sink := NewHTTPSink(cfg.Sink.URL, cfg.Sink.BatchSize, cfg.Sink.FlushInterval, nil)
+		cores = append(cores, newCore(zapcore.NewJSONEncoder(encoderConfig), sink, level, cfg.Sampling))
+	}
+
+	options := []zap.Option{zap.AddStacktrace(zapcore.ErrorLevel), zap.ErrorOutput(zapcore.Lock(os.Stderr))}
+	if !cfg.DisableCaller {
+		options = append(options, zap.AddCaller())
+	}
+
+	return zap.New(zapcore.NewTee(cores...), options...), nil
+}
+
+// newCore writes entries at level and above to output, sampling the entries
+// below warn level when sampling is enabled.
+func newCore(encoder zapcore.Encoder, output zapcore.WriteSyncer, level zap.AtomicLevel, sampling SamplingConfig) zapcore.Core {
+	if !sampling.Enabled {
+		return zapcore.NewCore(encoder, output, level)
+	}
+
+	low := zap.LevelEnablerFunc(func(l zapcore.Level) bool { return level.Enabled(l) && l < zapcore.WarnLevel })
+	high := zap.LevelEnablerFunc(func(l zapcore.Level) bool { return level.Enabled(l) && l >= zapcore.WarnLevel })
+
+	tick := sampling.Tick
+	if tick <= 0 {
+		tick = time.Second
+	}
+	sampled := zapcore.NewSamplerWithOptions(zapcore.NewCore(encoder.Clone(), output, low), tick, sampling.Initial, sampling.Thereafter)
+
+	return zapcore.NewTee(sampled, zapcore.NewCore(encoder, output, high))
+}
+
+// NewDevLogger returns a human-readable debug logger, independent of the
+// configured logging level, for dev mode request tracing.
+func NewDevLogger() *zap.Logger {
+	cfg := zap.NewDevelopmentConfig()
+	cfg.DisableStacktrace = true
 	logger, _ := cfg.Build()
-	return logger
+	return logger.Named("devmode")
 }
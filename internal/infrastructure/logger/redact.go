@@ -0,0 +1,22 @@
+package logger
+
+import (
+	"net/url"
+	"strings"
+)
+
+// RedactURL hides the password and drops the query of a URL, where hosted
+// mirror nodes, log sinks and secret stores carry credentials, so that it can
+// be logged or reported. Values that are not URLs are returned as they are,
+// and URLs that cannot be parsed as an empty string.
+func RedactURL(value string) string {
+	if !strings.Contains(value, "://") {
+		return value
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return ""
+	}
+	u.RawQuery = ""
+	return u.Redacted()
+}
@@ -0,0 +1,151 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSinkBatchSize     = 100
+	defaultSinkFlushInterval = 5 * time.Second
+	// sinkBufferBatches bounds the entries held while the sink is unreachable,
+	// in batches. Newer entries are dropped once the buffer is full.
+	sinkBufferBatches = 10
+	sinkTimeout       = 10 * time.Second
+)
+
+// HTTPSink ships log entries to an HTTP endpoint as newline-delimited JSON.
+// Entries are posted in batches, once batchSize entries are buffered or every
+// flushInterval, whichever comes first. Shipping never blocks logging: a batch
+// that cannot be delivered is dropped and reported on stderr.
+type HTTPSink struct {
+	url           string
+	client        *http.Client
+	batchSize     int
+	flushInterval time.Duration
+
+	mu      sync.Mutex
+	entries [][]byte
+	dropped int
+	flush   chan struct{}
+	// posting serializes deliveries, so batches arrive in order.
+	posting sync.Mutex
+}
+
+// NewHTTPSink starts a sink posting to url. Zero values of batchSize and
+// flushInterval use defaults; a nil client uses one with a 10 second timeout.
+func NewHTTPSink(url string, batchSize int, flushInterval time.Duration, client *http.Client) *HTTPSink {
+	if batchSize <= 0 {
+		batchSize = defaultSinkBatchSize
+	}
+	if flushInterval <= 0 {
+		flushInterval = defaultSinkFlushInterval
+	}
+	if client == nil {
+		client = &http.Client{Timeout: sinkTimeout}
+	}
+
+	s := &HTTPSink{
+		url:           url,
+		client:        client,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		flush:         make(chan struct{}, 1),
+	}
+	go s.run()
+	return s
+}
+
+// Write buffers one encoded log entry.
+func (s *HTTPSink) Write(p []byte) (int, error) {
+	entry := make([]byte, len(p))
+	copy(entry, p)
+
+	s.mu.Lock()
+	if len(s.entries) >= s.batchSize*sinkBufferBatches {
+		s.dropped++
+	} else {
+		s.entries = append(s.entries, entry)
+	}
+	full := len(s.entries) >= s.batchSize
+	s.mu.Unlock()
+
+	if full {
+		select {
+		case s.flush <- struct{}{}:
+		default:
+		}
+	}
+	return len(p), nil
+}
+
+// Sync delivers the buffered entries.
+func (s *HTTPSink) Sync() error {
+	return s.deliver()
+}
+
+func (s *HTTPSink) run() {
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.flush:
+		}
+		if err := s.deliver(); err != nil {
+			fmt.Fprintf(os.Stderr, "log sink: %v\n", err)
+		}
+	}
+}
+
+func (s *HTTPSink) deliver() error {
+	s.posting.Lock()
+	defer s.posting.Unlock()
+
+	for {
+		s.mu.Lock()
+		n := min(len(s.entries), s.batchSize)
+		batch := s.entries[:n]
+		s.entries = s.entries[n:]
+		dropped := s.dropped
+		s.dropped = 0
+		s.mu.Unlock()
+
+		if dropped > 0 {
+			fmt.Fprintf(os.Stderr, "log sink: dropped %d entries while the buffer was full\n", dropped)
+		}
+		if n == 0 {
+			return nil
+		}
+		if err := s.post(batch); err != nil {
+			return fmt.Errorf("dropped %d entries: %w", n, err)
+		}
+	}
+}
+
+func (s *HTTPSink) post(batch [][]byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sinkTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(bytes.Join(batch, nil)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sink returned status %d", resp.StatusCode)
+	}
+	return nil
+}
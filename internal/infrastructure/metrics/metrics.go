@@ -1 +1,155 @@
 package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// MirrorNodeActiveEndpoint is 1 for the mirror node base URL currently
+	// serving requests and 0 for the standby ones.
+	MirrorNodeActiveEndpoint = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hederium_mirror_node_active_endpoint",
+		Help: "Whether a mirror node base URL is the one currently in use.",
+	}, []string{"url"})
+
+	// MirrorNodeRateLimited counts mirror node responses that throttled the
+	// relay with a 429.
+	MirrorNodeRateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hederium_mirror_node_rate_limited_total",
+		Help: "Number of mirror node requests answered with 429 Too Many Requests, by base URL.",
+	}, []string{"url"})
+
+	// MirrorNodeFailovers counts switches from one mirror node base URL to another.
+	MirrorNodeFailovers = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hederium_mirror_node_failovers_total",
+		Help: "Number of mirror node failovers, by the base URL that took over.",
+	}, []string{"url"})
+
+	// MirrorNodeWeb3ActiveEndpoint and MirrorNodeWeb3Failovers are
+	// MirrorNodeActiveEndpoint and MirrorNodeFailovers for the web3 module
+	// URLs, when contract calls are sent apart from the REST API.
+	MirrorNodeWeb3ActiveEndpoint = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hederium_mirror_node_web3_active_endpoint",
+		Help: "Whether a mirror node web3 URL is the one currently in use for contract calls.",
+	}, []string{"url"})
+	MirrorNodeWeb3Failovers = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hederium_mirror_node_web3_failovers_total",
+		Help: "Number of mirror node web3 failovers, by the URL that took over.",
+	}, []string{"url"})
+
+	// MirrorNodeVersion is 1 for the version detected on a mirror node base
+	// URL.
+	MirrorNodeVersion = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hederium_mirror_node_version",
+		Help: "Version of the mirror node detected at a base URL.",
+	}, []string{"url", "version"})
+
+	// GetCodeConsensusFallbacks counts eth_getCode requests the mirror node
+	// could not answer and that were sent to a consensus node, at a cost in HBAR.
+	GetCodeConsensusFallbacks = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "hederium_get_code_consensus_fallbacks_total",
+		Help: "Number of eth_getCode requests served by a paid consensus node query.",
+	})
+
+	// TransactionRecordFallbacks counts the eth_sendRawTransaction calls the
+	// mirror node had not recorded in time and whose outcome was taken from a
+	// consensus node instead, by receipt status.
+	TransactionRecordFallbacks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hederium_transaction_record_fallbacks_total",
+		Help: "Number of eth_sendRawTransaction calls answered from a consensus node transaction record.",
+	}, []string{"status"})
+
+	// ConsensusNodeUp is 1 for the consensus nodes that answered their latest
+	// health check and 0 for the others.
+	ConsensusNodeUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hederium_consensus_node_up",
+		Help: "Whether a consensus node answered its latest health check.",
+	}, []string{"network", "node"})
+
+	// ConsensusNodeBusy counts health checks a consensus node answered with BUSY.
+	ConsensusNodeBusy = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hederium_consensus_node_busy_total",
+		Help: "Number of health checks a consensus node answered as busy.",
+	}, []string{"network", "node"})
+
+	// ConsensusClientReconnects counts re-creations of the Hedera SDK client
+	// after no consensus node answered.
+	ConsensusClientReconnects = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hederium_consensus_client_reconnects_total",
+		Help: "Number of times the Hedera client was re-created.",
+	}, []string{"network"})
+
+	// MethodConcurrencyRejections counts calls refused because their method
+	// had no free concurrency slot.
+	MethodConcurrencyRejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hederium_method_concurrency_rejections_total",
+		Help: "Number of JSON-RPC calls rejected by the per-method concurrency limits, by method.",
+	}, []string{"method"})
+
+	// MethodTimeouts counts calls that failed because they ran past the
+	// timeout of their method.
+	MethodTimeouts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hederium_method_timeouts_total",
+		Help: "Number of JSON-RPC calls that ran past the timeout of their method, by method.",
+	}, []string{"method"})
+
+	// AdmissionInFlight is the number of JSON-RPC requests holding a worker
+	// slot of the admission queue.
+	AdmissionInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "hederium_admission_in_flight",
+		Help: "Number of JSON-RPC requests being served by the admission queue's worker slots.",
+	})
+
+	// AdmissionQueueDepth is the number of JSON-RPC requests waiting for a
+	// worker slot.
+	AdmissionQueueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "hederium_admission_queue_depth",
+		Help: "Number of JSON-RPC requests waiting in the admission queue.",
+	})
+
+	// AdmissionQueueWait observes how long JSON-RPC requests waited for a
+	// worker slot, zero for those admitted at once.
+	AdmissionQueueWait = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "hederium_admission_queue_wait_seconds",
+		Help:    "Time JSON-RPC requests waited in the admission queue.",
+		Buckets: []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+	})
+
+	// AdmissionRejections counts requests the admission queue turned away.
+	AdmissionRejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hederium_admission_rejections_total",
+		Help: "Number of JSON-RPC requests rejected by the admission queue, by reason: queue_full or timeout.",
+	}, []string{"reason"})
+
+	// LoadSheddingActive is 1 while the relay is overloaded and turns away
+	// low-priority calls.
+	LoadSheddingActive = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "hederium_load_shedding_active",
+		Help: "Whether the relay is overloaded and shedding low-priority calls.",
+	})
+
+	// LoadShedRequests counts calls turned away while the relay was overloaded.
+	LoadShedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hederium_load_shed_requests_total",
+		Help: "Number of JSON-RPC calls rejected while shedding load, by method.",
+	}, []string{"method"})
+
+	// ShadowRequests counts requests mirrored to the shadow relay by whether
+	// its response matched.
+	ShadowRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hederium_shadow_requests_total",
+		Help: "Number of requests mirrored to the shadow relay, by method and outcome: match, mismatch, error or dropped.",
+	}, []string{"method", "outcome"})
+
+	// Panics counts the panics recovered while serving requests, by JSON-RPC
+	// method, or by route for panics outside a method call.
+	Panics = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hederium_panics_total",
+		Help: "Number of panics recovered while serving requests, by JSON-RPC method or route.",
+	}, []string{"method"})
+)
+
+func init() {
+	prometheus.MustRegister(MirrorNodeActiveEndpoint, MirrorNodeFailovers, MirrorNodeWeb3ActiveEndpoint, MirrorNodeWeb3Failovers, MirrorNodeRateLimited, MirrorNodeVersion, GetCodeConsensusFallbacks, TransactionRecordFallbacks, MethodConcurrencyRejections, MethodTimeouts,
+		AdmissionInFlight, AdmissionQueueDepth, AdmissionQueueWait, AdmissionRejections, ConsensusNodeUp, ConsensusNodeBusy, ConsensusClientReconnects, LoadSheddingActive, LoadShedRequests, ShadowRequests, Panics)
+}
@@ -0,0 +1,206 @@
+package startup
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	hederiumlogger "github.com/LimeChain/Hederium/internal/infrastructure/logger"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultProbeTimeout bounds each mirror node probe when no mirror node
+	// timeout is configured.
+	defaultProbeTimeout = 10 * time.Second
+	// redacted replaces the values of secret settings.
+	redacted = "[REDACTED]"
+)
+
+// secretSettings are the fragments of setting names whose values are never
+// reported, such as operator and API keys, DSNs holding passwords and headers
+// carrying credentials.
+var secretSettings = []string{"key", "secret", "password", "dsn", "authorization", "credential"}
+
+// Report describes the relay as it starts: its version, the Go runtime, the
+// mirror nodes it reached, the operator balances it knows, the features
+// switched on and the resolved configuration with secrets redacted.
+type Report struct {
+	Version          string                 `json:"version"`
+	StartedAt        time.Time              `json:"startedAt"`
+	Runtime          RuntimeInfo            `json:"runtime"`
+	MirrorNodes      []MirrorNodeProbe      `json:"mirrorNodes"`
+	OperatorBalances map[string]int64       `json:"operatorBalances"`
+	Features         map[string]bool        `json:"features"`
+	Config           map[string]interface{} `json:"config"`
+}
+
+// RuntimeInfo describes the Go runtime the relay runs on.
+type RuntimeInfo struct {
+	GoVersion  string `json:"goVersion"`
+	OS         string `json:"os"`
+	Arch       string `json:"arch"`
+	NumCPU     int    `json:"numCpu"`
+	GOMAXPROCS int    `json:"gomaxprocs"`
+}
+
+// MirrorNodeProbe is the outcome of asking a mirror node for the consensus
+// nodes of its network.
+type MirrorNodeProbe struct {
+	URL            string `json:"url"`
+	Reachable      bool   `json:"reachable"`
+	LatencyMs      int64  `json:"latencyMs"`
+	ConsensusNodes int    `json:"consensusNodes"`
+	Error          string `json:"error,omitempty"`
+}
+
+// NewReport builds the report from the settings in v, probing every mirror
+// node base URL. operatorBalances are the balances in tinybars already known,
+// keyed by operator account ID.
+func NewReport(v *viper.Viper, operatorBalances map[string]int64) *Report {
+	timeout := time.Duration(v.GetInt("mirrorNode.timeoutSeconds")) * time.Second
+	if timeout <= 0 {
+		timeout = defaultProbeTimeout
+	}
+
+	report := &Report{
+		Version:   v.GetString("application.version"),
+		StartedAt: time.Now().UTC(),
+		Runtime: RuntimeInfo{
+			GoVersion:  runtime.Version(),
+			OS:         runtime.GOOS,
+			Arch:       runtime.GOARCH,
+			NumCPU:     runtime.NumCPU(),
+			GOMAXPROCS: runtime.GOMAXPROCS(0),
+		},
+		OperatorBalances: operatorBalances,
+		Features:         features(v),
+		Config:           redactSettings(v.AllSettings()),
+	}
+	for _, baseURL := range v.GetStringSlice("mirrorNode.baseUrl") {
+		report.MirrorNodes = append(report.MirrorNodes, probeMirrorNode(strings.TrimSuffix(baseURL, "/"), timeout))
+	}
+
+	return report
+}
+
+// Log writes the report to logger, warning about every mirror node that could
+// not be reached.
+func (r *Report) Log(logger *zap.Logger) {
+	logger.Info("Startup report",
+		zap.String("version", r.Version),
+		zap.Any("runtime", r.Runtime),
+		zap.Any("mirrorNodes", r.MirrorNodes),
+		zap.Any("operatorBalances", r.OperatorBalances),
+		zap.Any("features", r.Features),
+		zap.Any("config", r.Config),
+	)
+	for _, probe := range r.MirrorNodes {
+		if !probe.Reachable {
+			logger.Warn("Mirror node unreachable at startup", zap.String("url", probe.URL), zap.String("error", probe.Error))
+		}
+	}
+}
+
+// Handler serves the report as JSON.
+func (r *Report) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(r)
+	})
+}
+
+// features reports the settings switching a feature on or off: every
+// features.* flag and every boolean enabled setting, named after its section.
+func features(v *viper.Viper) map[string]bool {
+	flags := make(map[string]bool)
+	for _, key := range v.AllKeys() {
+		if _, ok := v.Get(key).(bool); !ok {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(key, "features."):
+			flags[strings.TrimPrefix(key, "features.")] = v.GetBool(key)
+		case strings.HasSuffix(key, ".enabled"):
+			flags[strings.TrimSuffix(key, ".enabled")] = v.GetBool(key)
+		}
+	}
+	return flags
+}
+
+// redactSettings copies settings, replacing the values of secret settings and
+// the credentials and queries of URLs.
+func redactSettings(settings map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(settings))
+	for name, value := range settings {
+		result[name] = redactSetting(name, value)
+	}
+	return result
+}
+
+func redactSetting(name string, value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		return redactSettings(value)
+	case []interface{}:
+		items := make([]interface{}, len(value))
+		for i, item := range value {
+			items[i] = redactSetting(name, item)
+		}
+		return items
+	case []string:
+		items := make([]interface{}, len(value))
+		for i, item := range value {
+			items[i] = redactSetting(name, item)
+		}
+		return items
+	case bool, int, int64, float64, time.Duration:
+		return value
+	case string:
+		if value == "" {
+			return value
+		}
+		if isSecret(name) {
+			return redacted
+		}
+		return hederiumlogger.RedactURL(value)
+	default:
+		if isSecret(name) {
+			return redacted
+		}
+		return value
+	}
+}
+
+func isSecret(name string) bool {
+	name = strings.ToLower(name)
+	for _, fragment := range secretSettings {
+		if strings.Contains(name, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+func probeMirrorNode(baseURL string, timeout time.Duration) MirrorNodeProbe {
+	probe := MirrorNodeProbe{URL: hederiumlogger.RedactURL(baseURL)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	started := time.Now()
+	nodes, err := hedera.ProbeMirrorNode(ctx, baseURL)
+	probe.LatencyMs = time.Since(started).Milliseconds()
+	probe.ConsensusNodes = nodes
+	if err != nil {
+		probe.Error = err.Error()
+		return probe
+	}
+	probe.Reachable = true
+	return probe
+}
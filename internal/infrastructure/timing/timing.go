@@ -0,0 +1,59 @@
+package timing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Sources a request spends time waiting on.
+const (
+	Cache  = "cache"
+	Mirror = "mirror"
+	SDK    = "sdk"
+)
+
+type contextKey struct{}
+
+// Recorder accumulates how long a single request spent in each source. It is
+// safe for concurrent use, as batch requests and shared fetches record from
+// several goroutines.
+type Recorder struct {
+	mu        sync.Mutex
+	durations map[string]time.Duration
+}
+
+// NewContext returns a copy of ctx that records into a new Recorder.
+func NewContext(ctx context.Context) (context.Context, *Recorder) {
+	r := &Recorder{durations: make(map[string]time.Duration)}
+	return context.WithValue(ctx, contextKey{}, r), r
+}
+
+// Track starts timing source and returns the function that stops it. It does
+// nothing when ctx carries no Recorder.
+func Track(ctx context.Context, source string) func() {
+	r, ok := ctx.Value(contextKey{}).(*Recorder)
+	if !ok {
+		return func() {}
+	}
+
+	start := time.Now()
+	return func() {
+		elapsed := time.Since(start)
+		r.mu.Lock()
+		r.durations[source] += elapsed
+		r.mu.Unlock()
+	}
+}
+
+// Durations returns the time recorded so far per source.
+func (r *Recorder) Durations() map[string]time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	durations := make(map[string]time.Duration, len(r.durations))
+	for source, d := range r.durations {
+		durations[source] = d
+	}
+	return durations
+}
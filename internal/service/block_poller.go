@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
+	infrahedera "github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	"go.uber.org/zap"
+)
+
+// BlockPoller follows the head of the chain in the background, so that the
+// latest block is known without a mirror node round trip, and hands every new
+// block to the registered handlers to warm the caches before clients ask.
+type BlockPoller struct {
+	mClient  infrahedera.MirrorNodeClient
+	logger   *zap.Logger
+	interval time.Duration
+
+	latest atomic.Pointer[polledBlock]
+
+	mu       sync.Mutex
+	handlers []func(ctx context.Context, block *domain.BlockResponse)
+}
+
+type polledBlock struct {
+	block    *domain.BlockResponse
+	polledAt time.Time
+}
+
+// NewBlockPoller creates a poller asking the mirror node for the latest block
+// every interval once Run is called.
+func NewBlockPoller(mClient infrahedera.MirrorNodeClient, logger *zap.Logger, interval time.Duration) *BlockPoller {
+	return &BlockPoller{
+		mClient:  mClient,
+		logger:   logger,
+		interval: interval,
+	}
+}
+
+// OnNewBlock registers fn to run, on the polling goroutine, for every new
+// block the poller sees.
+func (p *BlockPoller) OnNewBlock(fn func(ctx context.Context, block *domain.BlockResponse)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.handlers = append(p.handlers, fn)
+}
+
+// Latest returns the latest block seen. It reports false before the first
+// successful poll and once polls have been failing for
+// blockPollerStaleIntervals intervals, so that callers go to the mirror node
+// rather than serve an outdated head.
+func (p *BlockPoller) Latest() (*domain.BlockResponse, bool) {
+	latest := p.latest.Load()
+	if latest == nil || time.Since(latest.polledAt) > blockPollerStaleIntervals*p.interval {
+		return nil, false
+	}
+	return latest.block, true
+}
+
+// Run polls the mirror node until ctx is cancelled.
+func (p *BlockPoller) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *BlockPoller) poll(ctx context.Context) {
+	fetchCtx, cancel := context.WithTimeout(ctx, blockPollerStaleIntervals*p.interval)
+	defer cancel()
+
+	head, err := p.mClient.GetLatestBlock(fetchCtx)
+	if err != nil {
+		p.logger.Warn("Failed to poll the latest block", zap.Error(err))
+		return
+	}
+	number, ok := head["number"].(float64)
+	if !ok {
+		p.logger.Warn("Latest block has no number", zap.Any("block", head))
+		return
+	}
+
+	now := time.Now()
+	if latest := p.latest.Load(); latest != nil && int64(latest.block.Number) >= int64(number) {
+		p.latest.Store(&polledBlock{block: latest.block, polledAt: now})
+		return
+	}
+
+	block, err := p.mClient.GetBlockByHashOrNumber(fetchCtx, strconv.FormatInt(int64(number), 10))
+	if err != nil {
+		p.logger.Warn("Failed to fetch the latest block", zap.Float64("number", number), zap.Error(err))
+		return
+	}
+	p.latest.Store(&polledBlock{block: block, polledAt: now})
+	p.logger.Debug("Polled new block", zap.Int("number", block.Number))
+
+	p.mu.Lock()
+	handlers := append([]func(context.Context, *domain.BlockResponse){}, p.handlers...)
+	p.mu.Unlock()
+	// Handlers run with the context of Run, as warming a block with many
+	// transactions may outlast a poll
+	for _, handle := range handlers {
+		handle(ctx, block)
+	}
+}
+
+// FollowBlockPoller answers the latest block number from poller and warms the
+// caches of every block it sees.
+func (s *EthService) FollowBlockPoller(poller *BlockPoller) {
+	s.blockPoller = poller
+	poller.OnNewBlock(s.warmBlockCaches)
+}
+
+// warmBlockCaches stores block under the keys eth_getBlockByNumber,
+// eth_getBlockByHash and the transaction count methods read, and refreshes the
+// cached gas price.
+func (s *EthService) warmBlockCaches(ctx context.Context, block *domain.BlockResponse) {
+	hash := block.Hash
+	if len(hash) > 66 {
+		hash = hash[:66]
+	}
+	transactionCount := fmt.Sprintf("0x%x", block.Count)
+
+	entries := map[string]interface{}{
+		fmt.Sprintf("%s_%d", GetBlockTransactionCountByNumber, block.Number): transactionCount,
+		fmt.Sprintf("%s_%s", GetBlockTransactionCountByHash, hash):           transactionCount,
+	}
+	for _, showDetails := range []bool{false, true} {
+		processedBlock, err := ProcessBlock(ctx, s, block, showDetails)
+		if err != nil {
+			s.logger.Debug("Failed to process polled block", zap.Int("number", block.Number), zap.Error(err))
+			break
+		}
+		entries[fmt.Sprintf("%s_%d_%t", GetBlockByNumber, block.Number, showDetails)] = processedBlock
+		entries[fmt.Sprintf("%s_%s_%t", GetBlockByHash, hash, showDetails)] = processedBlock
+	}
+	if weibars, err := GetFeeWeibars(ctx, s); err == nil {
+		entries[GetGasPrice] = fmt.Sprintf("0x%x", weibars)
+	} else {
+		s.logger.Debug("Failed to refresh gas price", zap.Error(err))
+	}
+
+	for key, value := range entries {
+		ttl := s.config.CacheTTLs.Of(cache.ClassBlock)
+		if key == GetGasPrice {
+			ttl = s.config.CacheTTLs.Of(cache.ClassGasPrice)
+		}
+		if err := s.cacheService.Set(ctx, key, value, ttl); err != nil {
+			s.logger.Debug("Failed to cache polled block", zap.String("key", key), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/util"
+)
+
+// bloomLength is the size in bytes of an Ethereum logs bloom filter.
+const bloomLength = 256
+
+// emptyBloom is the bloom filter matching no logs.
+var emptyBloom = "0x" + strings.Repeat("00", bloomLength)
+
+// isMissingBloom reports whether the mirror node left a bloom out, which it
+// does with an empty value or a bare 0x.
+func isMissingBloom(bloom string) bool {
+	return bloom == "" || bloom == "0x"
+}
+
+// buildLogsBloom computes the bloom filter of logs the way the EVM does, from
+// the address and topics of each log.
+func buildLogsBloom(logs []domain.MirroNodeLogs) string {
+	var bloom [bloomLength]byte
+	for _, log := range logs {
+		addToBloom(&bloom, log.Address)
+		for _, topic := range log.Topics {
+			addToBloom(&bloom, topic)
+		}
+	}
+	return "0x" + hex.EncodeToString(bloom[:])
+}
+
+// addToBloom sets the three bits selected by the Keccak-256 hash of value,
+// given in hex, in bloom.
+func addToBloom(bloom *[bloomLength]byte, value string) {
+	data, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+	if err != nil {
+		return
+	}
+	hash := util.Keccak256(data)
+	for i := 0; i < 6; i += 2 {
+		bit := (uint(hash[i])<<8 | uint(hash[i+1])) & (bloomLength*8 - 1)
+		bloom[bloomLength-1-bit/8] |= 1 << (bit % 8)
+	}
+}
+
+// mergeBlooms returns the union of bloom filters, as the bloom of a block is
+// the union of the blooms of its transactions. Missing or malformed blooms are
+// skipped.
+func mergeBlooms(blooms []string) string {
+	var merged [bloomLength]byte
+	for _, bloom := range blooms {
+		data, err := hex.DecodeString(strings.TrimPrefix(bloom, "0x"))
+		if err != nil || len(data) != bloomLength {
+			continue
+		}
+		for i := range merged {
+			merged[i] |= data[i]
+		}
+	}
+	return "0x" + hex.EncodeToString(merged[:])
+}
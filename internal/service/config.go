@@ -0,0 +1,101 @@
+package service
+
+import (
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
+)
+
+// Config holds the tunable settings of the service layer. Zero values fall
+// back to the defaults defined in constants.go.
+type Config struct {
+	// FilterAPIEnabled toggles the eth_newFilter family of methods.
+	FilterAPIEnabled bool
+	// FilterTTL is how long an installed filter lives without being polled.
+	FilterTTL time.Duration
+	// DebugAPIEnabled toggles the debug_* methods.
+	DebugAPIEnabled bool
+	// MaxLogResults caps the number of logs eth_getLogs aggregates across
+	// mirror node timestamp windows before failing the query.
+	MaxLogResults int
+	// EstimateGasFallback makes eth_estimateGas return a heuristic estimate
+	// instead of an error when the mirror node fails for reasons other than a
+	// contract revert.
+	EstimateGasFallback bool
+	// ContractCallGas is the fallback estimate for calls to contracts.
+	ContractCallGas uint64
+	// ContractCreationGas is the fallback estimate for contract deployments.
+	ContractCreationGas uint64
+	// DeployGasPerByte is added to ContractCreationGas for every byte of init
+	// code when a deployment is larger than MirrorNodeMaxDataSize bytes, which
+	// the mirror node rejects, and is therefore estimated locally.
+	DeployGasPerByte      uint64
+	MirrorNodeMaxDataSize int
+	// BlockGasLimit is the gas limit advertised in blocks and the most gas a
+	// local estimate returns, DefaultBlockGasLimit when zero. MaxCallGas is
+	// the most gas eth_call and eth_estimateGas accept or estimate; calls
+	// above it fail with "gas required exceeds allowance". It defaults to
+	// BlockGasLimit.
+	BlockGasLimit uint64
+	MaxCallGas    uint64
+	// SyncingCheckEnabled makes eth_syncing report a syncing status while the
+	// mirror node lags more than SyncingLagThreshold behind the wall clock.
+	SyncingCheckEnabled bool
+	SyncingLagThreshold time.Duration
+	// MaxPriorityFeePerGas is the tip, in weibars, reported by
+	// eth_maxPriorityFeePerGas and as the eth_feeHistory reward. Hedera ignores
+	// tips, but some wallets refuse to send EIP-1559 transactions with a zero one.
+	MaxPriorityFeePerGas uint64
+	// AsyncSendRawTransaction makes eth_sendRawTransaction return the locally
+	// computed transaction hash as soon as the consensus node accepts the
+	// transaction, instead of waiting for the mirror node to record it.
+	AsyncSendRawTransaction bool
+	// WarmReceiptCache makes eth_sendRawTransaction cache the receipt,
+	// transaction and block of each transaction it sends as soon as the
+	// mirror node records it, ahead of the receipt polls that follow.
+	WarmReceiptCache bool
+	// NonceOrderingEnabled makes eth_sendRawTransaction submit the
+	// transactions of each sender one at a time in nonce order, holding
+	// transactions that arrive ahead of a missing nonce for up to
+	// NonceGapTimeout.
+	NonceOrderingEnabled bool
+	NonceGapTimeout      time.Duration
+	// MicroCacheTTL keeps eth_blockNumber and eth_gasPrice results in memory
+	// for this long, zero disables the micro-cache. Older results are still
+	// served for up to MicroCacheMaxStale while they are refreshed in the
+	// background.
+	MicroCacheTTL      time.Duration
+	MicroCacheMaxStale time.Duration
+	// BlockPollerInterval makes a background worker poll the mirror node for
+	// new blocks this often, answering eth_blockNumber and "latest" from
+	// memory and caching each new block, its transaction count and the gas
+	// price ahead of requests. Zero disables the poller.
+	BlockPollerInterval time.Duration
+	// CallCacheTTL keeps successful eth_call results for this long, keyed by
+	// the call object and block, so that dapps polling the same view function
+	// are not each answered by the mirror node. Zero disables the cache.
+	CallCacheTTL time.Duration
+	// CacheTTLs sets how long blocks, receipts, transactions, accounts,
+	// contracts, the gas price and tokens are cached, cache.DefaultTTL for
+	// classes left unset.
+	CacheTTLs cache.TTLs
+	// ConfigurationAPIEnabled toggles hederium_getConfiguration, which reveals
+	// the mirror node URLs, feature flags and rate-limit tiers of the relay.
+	ConfigurationAPIEnabled bool
+	// ChecksumAddresses returns the addresses of blocks, transactions and
+	// receipts in EIP-55 mixed case rather than as the mirror node reports them.
+	ChecksumAddresses bool
+	// GetCodeConsensusFallback lets eth_getCode query a consensus node, which
+	// costs HBAR, when the mirror node has no usable bytecode. Without it the
+	// code is served from the mirror node alone.
+	GetCodeConsensusFallback bool
+	// GetStorageAtLatestFallback makes eth_getStorageAt answer from the latest
+	// state when the mirror node has no state for the requested block, as
+	// happens once it has pruned old history.
+	GetStorageAtLatestFallback bool
+	// AccountsEnabled makes eth_accounts return Accounts, for tools that
+	// expect a node to report an account such as the operator, instead of the
+	// empty list a relay holding no keys returns.
+	AccountsEnabled bool
+	Accounts        []string
+}
@@ -1,6 +1,10 @@
 package service
 
-import "time"
+import (
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
+)
 
 // Constants for the Ethereum JSON-RPC API methods + other constants
 // Temporary place for these constants until we have a better place for them
@@ -37,9 +41,59 @@ const (
 	NetVersion                          = "net_version"
 	NetListening                        = "net_listening"
 	NetPeerCount                        = "net_peerCount"
+	GetExchangeRate                     = "hederium_getExchangeRate"
 
-	DefaultExpiration = 1 * time.Hour
+	// DefaultExpiration is the TTL of cached entities whose class has none
+	// configured.
+	DefaultExpiration = cache.DefaultTTL
 	ShortExpiration   = 1 * time.Second
+	DefaultFilterTTL  = 5 * time.Minute
+
+	// DefaultMaxLogResults caps the number of logs a single eth_getLogs call returns.
+	DefaultMaxLogResults = 10000
+
+	// mirrorNodeMaxRepeatedParams is the most values the mirror node accepts
+	// for one repeated query parameter.
+	mirrorNodeMaxRepeatedParams = 100
+
+	// DefaultSyncingLagThreshold is how far the latest mirror node block may
+	// trail the wall clock before eth_syncing reports a syncing status.
+	DefaultSyncingLagThreshold = 30 * time.Second
+	// DefaultNonceGapTimeout is how long eth_sendRawTransaction holds a
+	// transaction whose nonce leaves a gap when nonce ordering is enabled.
+	DefaultNonceGapTimeout = 2 * time.Second
+	// addressResolveConcurrency bounds the mirror node lookups ProcessBlock
+	// runs at once to map the senders and recipients of a block to EVM
+	// addresses.
+	addressResolveConcurrency = 8
+	// blockLogOffsetsConcurrency bounds the blocks whose logs eth_getLogs
+	// fetches at once to number its logs within their blocks.
+	blockLogOffsetsConcurrency = 8
+	// blockPollerStaleIntervals is how many poll intervals the latest block
+	// seen by the block poller is trusted for when polls fail.
+	blockPollerStaleIntervals = 5
+	// blockFeedSize is how many of the latest blocks block and pending
+	// transaction filters are answered from without the mirror node.
+	blockFeedSize = 256
+	// hederaBlockInterval approximates the time between Hedera blocks.
+	hederaBlockInterval = 2 * time.Second
+
+	// DefaultBlockGasLimit is the gas limit advertised in blocks, and the gas
+	// cap of eth_call and eth_estimateGas, when none is configured: the
+	// maximum gas per second of Hedera.
+	DefaultBlockGasLimit = 15000000
+
+	// Gas returned by eth_estimateGas for contract calls and deployments when
+	// the mirror node cannot estimate.
+	DefaultContractCallGas     = 400000
+	DefaultContractCreationGas = 800000
+	// DefaultDeployGasPerByte is the gas added per byte of init code to the
+	// local estimate of deployments too large for the mirror node, the EVM
+	// code deposit cost.
+	DefaultDeployGasPerByte = 200
+	// DefaultMirrorNodeMaxDataSize is the largest init code, in bytes, sent to
+	// the mirror node for a gas estimate.
+	DefaultMirrorNodeMaxDataSize = 24576
 
 	// Fungible token creation selectors
 	CreateFungibleTokenV1         string = "0x83062e38" //nolint:gosec
@@ -66,6 +120,11 @@ const (
 	redirectBytecodePrefix  = "6080604052348015600f57600080fd5b506000610167905077618dc65e"
 	redirectBytecodePostfix = "600052366000602037600080366018016008845af43d806000803e8160008114605857816000f35b816000fdfea2646970667358221220d8378feed472ba49a0005514ef7087017f707b45fb9bf56bb81bb93ff19a238b64736f6c634300080b0033"
 	iHTSAddress             = "0x0000000000000000000000000000000000000167"
+	errorStringSelector     = "08c379a0" // Error(string)
+	panicSelector           = "4e487b71" // Panic(uint256)
+	emptyTrieRoot           = "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"
+	wrongNonceResult        = "WRONG_NONCE"                // transactions rejected for their nonce do not consume one
+	longZeroAddressPrefix   = "0x000000000000000000000000" // addresses derived from the entity number of an account, contract or token
 )
 
 var HTSCreateFuncSelectors = map[string]struct{}{
@@ -82,3 +141,17 @@ var HTSCreateFuncSelectors = map[string]struct{}{
 	CreateNonFungibleTokenWithFeesV2: {},
 	CreateNonFungibleTokenWithFeesV3: {},
 }
+
+// panicReasons describes the Solidity Panic(uint256) codes
+var panicReasons = map[uint64]string{
+	0x00: "generic panic",
+	0x01: "assert(false)",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "enum overflow",
+	0x22: "invalid encoded storage byte array accessed",
+	0x31: "out-of-bounds array access; popping on an empty array",
+	0x32: "out-of-bounds access of an array or bytesN",
+	0x41: "out of memory",
+	0x51: "uninitialized function",
+}
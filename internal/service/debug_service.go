@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	infrahedera "github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	"go.uber.org/zap"
+)
+
+type DebugServicer interface {
+	TraceTransaction(ctx context.Context, transactionIdOrHash, tracer string, tracerConfig domain.TracerConfig) (interface{}, *domain.RPCError)
+	Enabled() bool
+	SetEnabled(enabled bool)
+}
+
+type debugService struct {
+	mClient infrahedera.MirrorNodeClient
+	logger  *zap.Logger
+	enabled atomic.Bool
+}
+
+func NewDebugService(mClient infrahedera.MirrorNodeClient, logger *zap.Logger, enabled bool) DebugServicer {
+	d := &debugService{
+		mClient: mClient,
+		logger:  logger,
+	}
+	d.enabled.Store(enabled)
+	return d
+}
+
+// Enabled reports whether the debug API is currently enabled.
+func (d *debugService) Enabled() bool {
+	return d.enabled.Load()
+}
+
+// SetEnabled turns the debug API on or off at runtime.
+func (d *debugService) SetEnabled(enabled bool) {
+	d.enabled.Store(enabled)
+}
+
+// TraceTransaction replays a transaction through the mirror node and returns
+// either a call tree (callTracer) or the executed opcodes (opcodeLogger).
+func (d *debugService) TraceTransaction(ctx context.Context, transactionIdOrHash, tracer string, tracerConfig domain.TracerConfig) (interface{}, *domain.RPCError) {
+	d.logger.Info("Tracing transaction", zap.String("transactionIdOrHash", transactionIdOrHash), zap.String("tracer", tracer), zap.Any("tracerConfig", tracerConfig))
+
+	if !d.enabled.Load() {
+		return nil, domain.NewUnsupportedMethodError("debug_traceTransaction")
+	}
+
+	if tracer == domain.CallTracer {
+		return d.callTracer(ctx, transactionIdOrHash, tracerConfig)
+	}
+
+	return d.opcodeLogger(ctx, transactionIdOrHash, tracerConfig)
+}
+
+func (d *debugService) callTracer(ctx context.Context, transactionIdOrHash string, tracerConfig domain.TracerConfig) (*domain.CallTrace, *domain.RPCError) {
+	actionsResponse, err := d.mClient.GetContractsResultsActions(ctx, transactionIdOrHash)
+	if err != nil && !isNotFound(err) {
+		return nil, mirrorError(err, fmt.Sprintf("Failed to retrieve contract results for transaction %s", transactionIdOrHash))
+	}
+	if actionsResponse == nil || len(actionsResponse.Actions) == 0 {
+		return nil, domain.NewResourceNotFoundError(fmt.Sprintf("Failed to retrieve contract results for transaction %s", transactionIdOrHash))
+	}
+
+	contractResult, err := d.mClient.GetContractResult(ctx, transactionIdOrHash)
+	if err != nil && !isNotFound(err) {
+		return nil, mirrorError(err, fmt.Sprintf("Failed to retrieve contract results for transaction %s", transactionIdOrHash))
+	}
+	if contractResult == nil {
+		return nil, domain.NewResourceNotFoundError(fmt.Sprintf("Failed to retrieve contract results for transaction %s", transactionIdOrHash))
+	}
+
+	actions := actionsResponse.Actions
+
+	trace := &domain.CallTrace{
+		Type:    actionType(actions[0]),
+		From:    d.resolveAddress(ctx, contractResult.From),
+		To:      d.resolveAddress(ctx, contractResult.To),
+		Value:   fmt.Sprintf("0x%x", contractResult.Amount),
+		Gas:     fmt.Sprintf("0x%x", contractResult.GasLimit),
+		GasUsed: fmt.Sprintf("0x%x", contractResult.GasUsed),
+		Input:   contractResult.FunctionParameters,
+		Output:  contractResult.CallResult,
+	}
+
+	if contractResult.Result != "SUCCESS" {
+		trace.Error = contractResult.Result
+		if contractResult.ErrorMessage != nil {
+			trace.Output = *contractResult.ErrorMessage
+			trace.RevertReason = decodeRevertReason(*contractResult.ErrorMessage)
+		}
+	}
+
+	if tracerConfig.OnlyTopCall || len(actions) <= 1 {
+		return trace, nil
+	}
+
+	// Rebuild the call tree from the flat, depth-annotated list of actions.
+	// parents[i] is the most recent call seen at depth i.
+	parents := []*domain.CallTrace{trace}
+	for _, action := range actions[1:] {
+		call := d.formatAction(ctx, action)
+
+		depth := action.CallDepth
+		if depth < 1 {
+			depth = 1
+		}
+		if depth > len(parents) {
+			depth = len(parents)
+		}
+
+		parent := parents[depth-1]
+		parent.Calls = append(parent.Calls, call)
+		parents = append(parents[:depth], call)
+	}
+
+	return trace, nil
+}
+
+func (d *debugService) formatAction(ctx context.Context, action domain.ContractAction) *domain.CallTrace {
+	to := action.To
+	if to == "" && action.Recipient != "" {
+		to = action.Recipient
+	}
+
+	call := &domain.CallTrace{
+		Type:    actionType(action),
+		From:    d.resolveAddress(ctx, action.From),
+		To:      d.resolveAddress(ctx, to),
+		Value:   fmt.Sprintf("0x%x", action.Value),
+		Gas:     fmt.Sprintf("0x%x", action.Gas),
+		GasUsed: fmt.Sprintf("0x%x", action.GasUsed),
+		Input:   action.Input,
+		Output:  action.ResultData,
+	}
+
+	if action.ResultDataType != "" && action.ResultDataType != "OUTPUT" {
+		call.Error = action.ResultDataType
+		call.RevertReason = decodeRevertReason(action.ResultData)
+	}
+
+	return call
+}
+
+func (d *debugService) opcodeLogger(ctx context.Context, transactionIdOrHash string, tracerConfig domain.TracerConfig) (*domain.OpcodeTrace, *domain.RPCError) {
+	response, err := d.mClient.GetContractsResultsOpcodes(ctx, transactionIdOrHash, !tracerConfig.DisableStack, tracerConfig.EnableMemory, !tracerConfig.DisableStorage)
+	if err != nil || response == nil {
+		return nil, domain.NewResourceNotFoundError(fmt.Sprintf("Failed to retrieve contract results for transaction %s", transactionIdOrHash))
+	}
+
+	structLogs := make([]domain.StructLog, 0, len(response.Opcodes))
+	for _, opcode := range response.Opcodes {
+		structLog := domain.StructLog{
+			Pc:      opcode.Pc,
+			Op:      opcode.Op,
+			Gas:     opcode.Gas,
+			GasCost: opcode.GasCost,
+			Depth:   opcode.Depth,
+		}
+
+		if !tracerConfig.DisableStack {
+			structLog.Stack = strip0xAll(opcode.Stack)
+		}
+		if tracerConfig.EnableMemory {
+			structLog.Memory = strip0xAll(opcode.Memory)
+		}
+		if !tracerConfig.DisableStorage {
+			structLog.Storage = make(map[string]string, len(opcode.Storage))
+			for key, value := range opcode.Storage {
+				structLog.Storage[strings.TrimPrefix(key, "0x")] = strings.TrimPrefix(value, "0x")
+			}
+		}
+		if opcode.Reason != nil {
+			reason := strings.TrimPrefix(*opcode.Reason, "0x")
+			structLog.Reason = &reason
+		}
+
+		structLogs = append(structLogs, structLog)
+	}
+
+	return &domain.OpcodeTrace{
+		Gas:         response.Gas,
+		Failed:      response.Failed,
+		ReturnValue: strings.TrimPrefix(response.ReturnValue, "0x"),
+		StructLogs:  structLogs,
+	}, nil
+}
+
+// resolveAddress maps long-zero entity addresses to their EVM alias, when the
+// account or contract has one.
+func (d *debugService) resolveAddress(ctx context.Context, address string) string {
+	if !strings.HasPrefix(address, "0x000000000000") {
+		return address
+	}
+
+	if contract, err := d.mClient.GetContractById(ctx, address); err == nil && contract != nil && contract.EvmAddress != "" {
+		return contract.EvmAddress
+	}
+
+	if account, err := d.mClient.GetAccountById(ctx, address); err == nil && account != nil && account.EvmAddress != "" {
+		return account.EvmAddress
+	}
+
+	return address
+}
+
+// actionType prefers the EVM opcode (CALL, DELEGATECALL, CREATE2, ...) over the
+// coarser mirror node call type.
+func actionType(action domain.ContractAction) string {
+	if action.CallOperationType != "" {
+		return action.CallOperationType
+	}
+	return action.CallType
+}
+
+func strip0xAll(values []string) []string {
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = strings.TrimPrefix(value, "0x")
+	}
+	return result
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+)
+
+// Errors returned, wrapped, by the helpers of the service layer, so that
+// callers tell failures apart with errors.Is rather than by their messages.
+// The services translate them, and the mirror node client errors they wrap,
+// to JSON-RPC errors with serviceError where they return.
+var (
+	// ErrInvalidHex means a value is not the hex number expected.
+	ErrInvalidHex = errors.New("failed to parse hex value")
+	// ErrInvalidNumber means a value is not the decimal number expected.
+	ErrInvalidNumber = errors.New("failed to parse value")
+	// ErrValueOutOfRange means a value does not fit the type it is converted
+	// to.
+	ErrValueOutOfRange = errors.New("value out of range")
+	// ErrConflictingCallData means a call object holds both input and data,
+	// with different values.
+	ErrConflictingCallData = errors.New("both input and data fields are present with different values")
+	// ErrEmptyAddress means no address was given to resolve.
+	ErrEmptyAddress = errors.New("address is empty")
+	// ErrUnknownAddress means an address is no account, contract or token the
+	// mirror node knows.
+	ErrUnknownAddress = errors.New("unable to identify address type")
+	// ErrNotTokenAddress means an address is not the long-zero address of a
+	// token.
+	ErrNotTokenAddress = errors.New("not a token address")
+	// ErrInvalidTransaction means raw transaction data is empty or cannot be
+	// decoded.
+	ErrInvalidTransaction = errors.New("invalid transaction")
+	// ErrTransactionNotRecorded means neither the mirror node nor a consensus
+	// node returned the record of a submitted transaction.
+	ErrTransactionNotRecorded = errors.New("no matching transaction record retrieved")
+	// ErrFiltersDisabled means the filter API is turned off.
+	ErrFiltersDisabled = errors.New("filter API is disabled")
+	// ErrLogLimitExceeded means a log query matched more logs than the relay
+	// returns.
+	ErrLogLimitExceeded = errors.New("log query limit exceeded")
+	// ErrIncompleteLog means the mirror node returned a log without its block
+	// number, transaction index or log index.
+	ErrIncompleteLog = errors.New("log without block number, transaction index or index")
+)
+
+// serviceError translates err, returned by a helper of the service layer, to
+// the JSON-RPC error returned to the caller. A JSON-RPC error in the chain of
+// err is returned as it is, and errors of the mirror node client are mapped by
+// mirrorError, except for transactions that were submitted but not recorded,
+// which are not the caller's to retry. message describes what failed for
+// errors that have no dedicated code.
+func serviceError(err error, message string) *domain.RPCError {
+	var rpcErr *domain.RPCError
+	switch {
+	case errors.As(err, &rpcErr):
+		return rpcErr
+	case errors.Is(err, ErrTransactionNotRecorded):
+		return domain.NewRPCError(domain.ServerError, message)
+	}
+	return mirrorError(err, message)
+}
@@ -0,0 +1,396 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
+	"github.com/LimeChain/Hederium/internal/infrastructure/metrics"
+	"github.com/LimeChain/Hederium/internal/infrastructure/timing"
+	"github.com/LimeChain/Hederium/internal/util"
+	"go.uber.org/zap"
+)
+
+// AccountService answers the balance, nonce, code and storage of accounts.
+type AccountService interface {
+	GetBalance(ctx context.Context, address string, blockNumberTagOrHash string) (string, *domain.RPCError)
+	GetTransactionCount(ctx context.Context, address string, blockNumberOrTag string) (string, *domain.RPCError)
+	GetStorageAt(ctx context.Context, address, slot, blockNumberOrHash string) (interface{}, *domain.RPCError)
+	GetProof(ctx context.Context, address string, storageKeys []string, blockNumberOrTag string) (interface{}, *domain.RPCError)
+	GetCode(ctx context.Context, address string, blockNumberOrTag string) (interface{}, *domain.RPCError)
+	GetAccounts() (interface{}, *domain.RPCError)
+}
+
+type accountService struct {
+	*ethCore
+}
+
+// GetBalance returns the balance of address at the given block in weibars. A
+// block the mirror node does not know yields a zero balance, while a mirror
+// node that cannot answer yields an error.
+func (s *accountService) GetBalance(ctx context.Context, address string, blockNumberTagOrHash string) (string, *domain.RPCError) {
+	s.logger.Info("Getting balance", zap.String("address", address), zap.String("blockNumberTagOrHash", blockNumberTagOrHash))
+
+	var hashOrNumber string
+
+	switch blockNumberTagOrHash {
+	case domain.BlockTagLatest, domain.BlockTagPending, domain.BlockTagSafe, domain.BlockTagFinalized:
+		return s.getMirrorBalance(ctx, address, "0")
+	case domain.BlockTagEarliest:
+		hashOrNumber = "0"
+	default:
+		switch {
+		case isBlockHash(blockNumberTagOrHash):
+			hashOrNumber = blockNumberTagOrHash
+		case strings.HasPrefix(blockNumberTagOrHash, "0x"):
+			// If it's a hex number, convert it to decimal
+			num, err := strconv.ParseInt(blockNumberTagOrHash[2:], 16, 64)
+			if err != nil {
+				s.logger.Debug("Failed to parse block number", zap.Error(err))
+				return "0x0", nil
+			}
+			hashOrNumber = strconv.FormatInt(num, 10)
+		default:
+			hashOrNumber = blockNumberTagOrHash
+		}
+	}
+
+	block, err := s.mClient.GetBlockByHashOrNumber(ctx, hashOrNumber)
+	if isNotFound(err) {
+		s.logger.Debug("Block not found", zap.String("block", blockNumberTagOrHash))
+		return "0x0", nil
+	}
+	if err != nil {
+		s.logger.Error("Failed to get block", zap.Error(err))
+		return "", mirrorError(err, "Failed to get block")
+	}
+
+	latestBlock, err := s.mClient.GetLatestBlock(ctx)
+	if err != nil {
+		s.logger.Error("Failed to get latest block", zap.Error(err))
+		return "", mirrorError(err, "Failed to get latest block")
+	}
+	if float64(block.Number+10) >= latestBlock["number"].(float64) {
+		return s.getMirrorBalance(ctx, address, "0")
+	}
+
+	return s.getMirrorBalance(ctx, address, block.Timestamp.To)
+}
+
+func (s *accountService) getMirrorBalance(ctx context.Context, address, timestampTo string) (string, *domain.RPCError) {
+	balance, err := s.mClient.GetBalance(ctx, s.balanceAccountId(ctx, address), timestampTo)
+	if err != nil {
+		s.logger.Error("Failed to get balance", zap.String("address", address), zap.Error(err))
+		return "", mirrorError(err, "Failed to get balance")
+	}
+	return balance, nil
+}
+
+// balanceAccountId returns the entity ID to ask the mirror node for the
+// balance of address, as /balances only finds accounts by their ID or alias.
+// Long-zero addresses name their entity directly, while other EVM addresses,
+// such as those of contracts, are looked up. Tokens hold no HBAR, and their
+// balance is zero. Addresses that cannot be resolved are returned unchanged.
+func (s *accountService) balanceAccountId(ctx context.Context, address string) string {
+	if len(address) != 42 || !strings.HasPrefix(address, "0x") {
+		return address
+	}
+	if strings.HasPrefix(address, longZeroAddressPrefix) {
+		if entityId, err := checkTokenId(address); err == nil {
+			return *entityId
+		}
+		return address
+	}
+
+	cacheKey := fmt.Sprintf("balance_account_%s", strings.ToLower(address))
+	var cachedId string
+	if err := s.cacheService.Get(ctx, cacheKey, &cachedId); err == nil && cachedId != "" {
+		return cachedId
+	}
+
+	result, err := s.resolveAddressType(ctx, address)
+	if err != nil {
+		s.logger.Debug("Failed to resolve balance account", zap.String("address", address), zap.Error(err))
+		return address
+	}
+
+	var entityId string
+	switch data := result.(type) {
+	case *domain.AccountResponse:
+		entityId = data.Account
+	case *domain.ContractResponse:
+		entityId = data.ContractID
+	}
+	if entityId == "" {
+		return address
+	}
+
+	if err := s.cacheService.Set(ctx, cacheKey, entityId, s.config.CacheTTLs.Of(cache.ClassAccount)); err != nil {
+		s.logger.Debug("Failed to cache balance account", zap.Error(err))
+	}
+	return entityId
+}
+
+// GetTransactionCount returns the nonce of address at the given block. Blocks
+// and accounts the mirror node does not know yield a zero nonce, while a
+// mirror node that cannot answer yields an error.
+func (s *accountService) GetTransactionCount(ctx context.Context, address string, blockNumberOrTag string) (string, *domain.RPCError) {
+	s.logger.Info("Getting transaction count", zap.String("address", address), zap.String("blockNumberOrTag", blockNumberOrTag))
+
+	blockNumberInt, errRpc := s.commonService.GetBlockNumberByNumberOrTag(ctx, blockNumberOrTag)
+	if errRpc != nil {
+		return "", errRpc
+	}
+
+	requestingLatest := s.isLatestBlockRequest(ctx, blockNumberOrTag, blockNumberInt)
+
+	block, err := s.mClient.GetBlockByHashOrNumber(ctx, strconv.FormatInt(blockNumberInt, 10))
+	if isNotFound(err) {
+		return "0x0", nil
+	}
+	if err != nil {
+		s.logger.Error("Failed to get block", zap.Error(err))
+		return "", mirrorError(err, "Failed to get block")
+	}
+
+	if requestingLatest {
+		account, err := s.mClient.GetAccount(ctx, address, block.Timestamp.To)
+		if isNotFound(err) {
+			return "0x0", nil
+		}
+		if err != nil {
+			s.logger.Error("Failed to get account", zap.String("address", address), zap.Error(err))
+			return "", mirrorError(err, "Failed to get account")
+		}
+		return fmt.Sprintf("0x%x", account.(domain.AccountResponse).EthereumNonce), nil
+	}
+
+	// Every executed Ethereum transaction sent by the account consumed one
+	// nonce, so the historical nonce is the number of them up to the block.
+	results, err := s.mClient.GetContractResultsBySender(ctx, address, block.Timestamp.To)
+	if err != nil {
+		s.logger.Error("Failed to get contract results for sender", zap.String("address", address), zap.Error(err))
+		return "", mirrorError(err, "Failed to get contract results")
+	}
+
+	var count int64
+	for _, result := range results {
+		if result.Result != wrongNonceResult {
+			count++
+		}
+	}
+
+	nonce := fmt.Sprintf("0x%x", count)
+
+	s.logger.Info("Returning nonce", zap.String("nonce", nonce), zap.String("address", address))
+	return nonce, nil
+}
+
+func (s *accountService) GetStorageAt(ctx context.Context, address, slot, blockNumberOrHash string) (interface{}, *domain.RPCError) {
+	s.logger.Info("Getting storage at", zap.String("address", address), zap.String("slot", slot), zap.String("blockNumberOrHash", blockNumberOrHash))
+	blockInt, errRpc := s.commonService.GetBlockNumberByNumberOrTag(ctx, blockNumberOrHash)
+	if errRpc != nil {
+		return nil, errRpc
+	}
+
+	blockResponse, err := s.mClient.GetBlockByHashOrNumber(ctx, strconv.FormatInt(blockInt, 10))
+	if err != nil {
+		s.logger.Error("Failed to get block", zap.Error(err))
+		return nil, mirrorError(err, "Failed to get block data")
+	}
+
+	timestampTo := blockResponse.Timestamp.To
+
+	result, err := s.mClient.GetContractStateByAddressAndSlot(ctx, address, slot, timestampTo)
+	if err != nil && !isNotFound(err) {
+		return nil, mirrorError(err, fmt.Sprintf("Failed to get storage data: %s", err.Error()))
+	}
+
+	// Mirror nodes that prune historical state find nothing for old blocks
+	if (result == nil || len(result.State) == 0) && s.config.GetStorageAtLatestFallback && !s.isLatestBlockRequest(ctx, blockNumberOrHash, blockInt) {
+		s.logger.Info("No historical storage found, falling back to the latest state", zap.Int64("block", blockInt))
+		result, err = s.mClient.GetContractStateByAddressAndSlot(ctx, address, slot, "")
+		if err != nil && !isNotFound(err) {
+			return nil, mirrorError(err, fmt.Sprintf("Failed to get storage data: %s", err.Error()))
+		}
+	}
+
+	if result == nil || len(result.State) == 0 {
+		s.logger.Info("Returning default storage value")
+		return zeroHex32Bytes, nil // Default value
+	}
+	s.logger.Info("Returning storage", zap.Any("storage", result))
+
+	return result.State[0].Value, nil
+}
+
+// GetProof answers eth_getProof with the account balance, nonce and code hash.
+// The Hedera network cannot produce Merkle proofs, so all proofs are empty and
+// the storage hash is the root of an empty trie.
+func (s *accountService) GetProof(ctx context.Context, address string, storageKeys []string, blockNumberOrTag string) (interface{}, *domain.RPCError) {
+	s.logger.Info("Getting proof", zap.String("address", address), zap.Strings("storageKeys", storageKeys), zap.String("blockNumberOrTag", blockNumberOrTag))
+
+	balance, errRpc := s.GetBalance(ctx, address, blockNumberOrTag)
+	if errRpc != nil {
+		return nil, errRpc
+	}
+	nonce, errRpc := s.GetTransactionCount(ctx, address, blockNumberOrTag)
+	if errRpc != nil {
+		return nil, errRpc
+	}
+
+	code, errRpc := s.GetCode(ctx, address, blockNumberOrTag)
+	if errRpc != nil {
+		return nil, errRpc
+	}
+
+	codeBytes, err := util.Decode(code.(string))
+	if err != nil {
+		s.logger.Error("Failed to decode code", zap.Error(err))
+		return nil, domain.NewRPCError(domain.ServerError, "Failed to decode code")
+	}
+
+	storageProof := make([]domain.StorageProof, 0, len(storageKeys))
+	for _, key := range storageKeys {
+		value, errRpc := s.GetStorageAt(ctx, address, key, blockNumberOrTag)
+		if errRpc != nil {
+			return nil, errRpc
+		}
+
+		valueInt, ok := new(big.Int).SetString(strings.TrimPrefix(value.(string), "0x"), 16)
+		if !ok {
+			valueInt = big.NewInt(0)
+		}
+
+		storageProof = append(storageProof, domain.StorageProof{
+			Key:   key,
+			Value: fmt.Sprintf("0x%x", valueInt),
+			Proof: []string{},
+		})
+	}
+
+	return domain.AccountProof{
+		Address:      address,
+		AccountProof: []string{},
+		Balance:      balance,
+		CodeHash:     "0x" + hex.EncodeToString(util.Keccak256(codeBytes)),
+		Nonce:        nonce,
+		StorageHash:  emptyTrieRoot,
+		StorageProof: storageProof,
+	}, nil
+}
+
+func (s *accountService) GetCode(ctx context.Context, address string, blockNumberOrTag string) (interface{}, *domain.RPCError) {
+	s.logger.Info("Getting code", zap.String("address", address), zap.String("blockNumberOrTag", blockNumberOrTag))
+
+	// Check for iHTS precompile address first
+	if address == iHTSAddress {
+		s.logger.Debug("Returning iHTS contract code")
+		return "0xfe", nil
+	}
+
+	cachedKey := fmt.Sprintf("%s_%s_%s", GetCode, address, blockNumberOrTag)
+
+	var cachedCode string
+	if err := s.cacheService.Get(ctx, cachedKey, &cachedCode); err == nil && cachedCode != "" {
+		s.logger.Info("Code fetched from cache", zap.String("code", cachedCode))
+		return cachedCode, nil
+	}
+
+	// Resolve the address type (contract or token)
+	result, err := s.resolveAddressType(ctx, address)
+	if err != nil {
+		s.logger.Debug("Failed to resolve address type from Mirror node", zap.Any("error", err))
+	}
+
+	switch result := result.(type) {
+	case *domain.ContractResponse:
+		contract := result
+		if contract.RuntimeBytecode != nil && *contract.RuntimeBytecode != zeroHex32Bytes {
+			bytecode, err := util.Decode(*contract.RuntimeBytecode)
+			if err != nil {
+				s.logger.Error("Failed to decode bytecode", zap.Error(err))
+				return nil, domain.NewRPCError(domain.ServerError, "Failed to decode bytecode")
+			}
+
+			if !util.HasProhibitedOpcodes(bytecode) {
+				if err = s.cacheService.Set(ctx, cachedKey, *contract.RuntimeBytecode, s.config.CacheTTLs.Of(cache.ClassContract)); err != nil {
+					s.logger.Debug("Failed to cache contract bytecode", zap.Error(err))
+				}
+
+				return *contract.RuntimeBytecode, nil
+			}
+		}
+	case *domain.TokenResponse:
+		s.logger.Debug("Token redirect case, returning redirectBytecode")
+		redirectBytecode := redirectBytecodePrefix + address[2:] + redirectBytecodePostfix
+		return "0x" + redirectBytecode, nil
+	}
+
+	if !s.config.GetCodeConsensusFallback {
+		return s.getMirrorCode(ctx, address, cachedKey, result)
+	}
+
+	metrics.GetCodeConsensusFallbacks.Inc()
+	stop := timing.Track(ctx, timing.SDK)
+	result, err = s.hClient.GetContractByteCode(0, 0, address)
+	stop()
+	if err != nil {
+		// TODO: Handle error better
+		s.logger.Error("Failed to get contract bytecode", zap.Error(err))
+		return "0x", nil
+	}
+
+	response := fmt.Sprintf("0x%x", result)
+
+	if err := s.cacheService.Set(ctx, cachedKey, response, s.config.CacheTTLs.Of(cache.ClassContract)); err != nil {
+		s.logger.Debug("Failed to cache contract bytecode", zap.Error(err))
+	}
+
+	return response, nil
+}
+
+// GetAccounts returns an empty array of accounts, similar to Infura's
+// implementation, unless the relay is configured to report a list of them.
+func (s *accountService) GetAccounts() (interface{}, *domain.RPCError) {
+	s.logger.Info("Getting accounts")
+	if s.config.AccountsEnabled {
+		return append([]string{}, s.config.Accounts...), nil
+	}
+	s.logger.Debug("Returning empty accounts array as per specification")
+	return []string{}, nil
+}
+
+// getMirrorCode serves eth_getCode from the mirror node alone, for when the
+// consensus node fallback is disabled. resolved is the entity resolveAddressType
+// found for address; when it found none, the contract is fetched again with
+// retries, as the lookup may have failed on a transient mirror node error.
+func (s *accountService) getMirrorCode(ctx context.Context, address, cacheKey string, resolved interface{}) (interface{}, *domain.RPCError) {
+	contract, _ := resolved.(*domain.ContractResponse)
+	if resolved == nil {
+		var err error
+		contract, err = s.mClient.GetContractByIdWithRetry(ctx, address)
+		if isNotFound(err) {
+			return "0x", nil
+		}
+		if err != nil {
+			s.logger.Error("Failed to get contract from Mirror node", zap.Error(err))
+			return nil, mirrorError(err, "Failed to get contract bytecode")
+		}
+	}
+
+	if contract == nil || contract.RuntimeBytecode == nil || *contract.RuntimeBytecode == zeroHex32Bytes {
+		return "0x", nil
+	}
+
+	if err := s.cacheService.Set(ctx, cacheKey, *contract.RuntimeBytecode, s.config.CacheTTLs.Of(cache.ClassContract)); err != nil {
+		s.logger.Debug("Failed to cache contract bytecode", zap.Error(err))
+	}
+
+	return *contract.RuntimeBytecode, nil
+}
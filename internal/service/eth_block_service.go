@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
+	"go.uber.org/zap"
+)
+
+// BlockService answers the block, log and chain status methods.
+type BlockService interface {
+	GetBlockNumber(ctx context.Context) (interface{}, *domain.RPCError)
+	GetBlockByHash(ctx context.Context, hash string, showDetails bool) (interface{}, *domain.RPCError)
+	GetBlockByNumber(ctx context.Context, numberOrTag string, showDetails bool) (interface{}, *domain.RPCError)
+	GetLogs(ctx context.Context, logParams domain.LogParams) (interface{}, *domain.RPCError)
+	StreamLogs(ctx context.Context, logParams domain.LogParams, emit func([]domain.Log) error) *domain.RPCError
+	GetBlockTransactionCountByHash(ctx context.Context, blockHash string) (interface{}, *domain.RPCError)
+	GetBlockTransactionCountByNumber(ctx context.Context, blockNumberOrTag string) (interface{}, *domain.RPCError)
+	Syncing(ctx context.Context) (interface{}, *domain.RPCError)
+	GetUncleCountByBlockNumber(blockNumber string) (interface{}, *domain.RPCError)
+	GetUncleByBlockNumberAndIndex(blockNumber string, index string) (interface{}, *domain.RPCError)
+	GetUncleCountByBlockHash(blockHash string) (interface{}, *domain.RPCError)
+	GetUncleByBlockHashAndIndex(blockHash string, index string) (interface{}, *domain.RPCError)
+}
+
+type blockService struct {
+	*ethCore
+}
+
+// GetBlockNumber retrieves the latest block number from the Hedera network and returns it
+// in hexadecimal format, compatible with Ethereum JSON-RPC specifications.
+// It returns two values:
+//   - interface{}: A hex string representing the block number (e.g., "0x1234") on success,
+//     or nil on failure
+//   - map[string]interface{}: Error details if the operation fails, nil on success.
+//     Error format follows Ethereum JSON-RPC error specifications.
+func (s *blockService) GetBlockNumber(ctx context.Context) (interface{}, *domain.RPCError) {
+	if s.blockPoller != nil {
+		if latest, ok := s.blockPoller.Latest(); ok {
+			return hexify(int64(latest.Number)), nil
+		}
+	}
+	if s.blockNumberCache != nil {
+		return s.blockNumberCache.Get(ctx, s.getBlockNumber)
+	}
+	return s.getBlockNumber(ctx)
+}
+
+func (s *blockService) getBlockNumber(ctx context.Context) (interface{}, *domain.RPCError) {
+	var cachedBlockNumber string
+	err := s.cacheService.Get(ctx, GetBlockNumber, &cachedBlockNumber)
+	if err == nil && cachedBlockNumber != "" {
+		s.logger.Info("Block number fetched from cache", zap.String("blockNumber", cachedBlockNumber))
+		return cachedBlockNumber, nil
+	}
+
+	blockNumber, errRpc := s.commonService.GetBlockNumber(ctx)
+	if errRpc != nil {
+		return nil, errRpc
+	}
+
+	if err := s.cacheService.Set(ctx, GetBlockNumber, blockNumber, ShortExpiration); err != nil {
+		s.logger.Debug("Failed to cache block number", zap.Error(err))
+	}
+
+	return blockNumber, nil
+}
+
+// retrieves a block by its hash and optionally includes detailed transaction information.
+// Parameters:
+//   - hash: The hash of the block to retrieve
+//   - showDetails: If true, returns full transaction objects; if false, only transaction hashes
+//
+// Returns nil for both return values if the block is not found.
+func (s *blockService) GetBlockByHash(ctx context.Context, hash string, showDetails bool) (interface{}, *domain.RPCError) {
+	s.logger.Info("Getting block by hash", zap.String("hash", hash), zap.Bool("showDetails", showDetails))
+
+	cacheKey := fmt.Sprintf("%s_%s_%t", GetBlockByHash, hash, showDetails)
+
+	var cachedBlock domain.Block
+	if err := s.cacheService.Get(ctx, cacheKey, &cachedBlock); err == nil && cachedBlock.Hash != nil {
+		s.logger.Info("Block fetched from cache", zap.Any("block", cachedBlock))
+		return cachedBlock, nil
+	}
+
+	block, err := s.mClient.GetBlockByHashOrNumber(ctx, hash)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		s.logger.Error("Failed to get block", zap.Error(err))
+		return nil, mirrorError(err, "Failed to get block")
+	}
+
+	processedBlock, err := s.processBlock(ctx, block, showDetails)
+	if err != nil {
+		s.logger.Error("Failed to process block", zap.Error(err))
+		return nil, mirrorError(err, "Failed to process block")
+	}
+
+	if err := s.cacheService.Set(ctx, cacheKey, &processedBlock, s.config.CacheTTLs.Of(cache.ClassBlock)); err != nil {
+		s.logger.Debug("Failed to cache block", zap.Error(err))
+	}
+
+	return processedBlock, nil
+}
+
+// GetBlockByHash retrieves a block by its hash from the Hedera network and returns it
+// in an Ethereum-compatible format.
+//
+// Parameters:
+//   - hash: The hash of the block to retrieve
+//   - showDetails: If true, includes full transaction details in the response.
+//     If false, only includes transaction hashes.
+//
+// Returns:
+//   - interface{}: The block data in Ethereum format (*domain.Block), or nil if not found
+//   - map[string]interface{}: Error information if any occurred, nil otherwise
+func (s *blockService) GetBlockByNumber(ctx context.Context, numberOrTag string, showDetails bool) (interface{}, *domain.RPCError) {
+	s.logger.Info("Getting block by number", zap.String("numberOrTag", numberOrTag), zap.Bool("showDetails", showDetails))
+
+	// "latest" and the tags aliasing it, "pending" among them, name a new
+	// block every few seconds, and the mirror node may still be filling in
+	// the newest one. What they resolve to is cached under "latest" for
+	// ShortExpiration, never under its number for the block TTL.
+	tagged := blockTagIsLatestOrPending(&numberOrTag)
+	latestKey := fmt.Sprintf("%s_%s_%t", GetBlockByNumber, domain.BlockTagLatest, showDetails)
+
+	var cachedBlock domain.Block
+	if tagged {
+		if err := s.cacheService.Get(ctx, latestKey, &cachedBlock); err == nil && cachedBlock.Hash != nil {
+			s.logger.Info("Latest block fetched from cache", zap.Any("block", cachedBlock))
+			return &cachedBlock, nil
+		}
+	}
+
+	blockNumberInt, errRpc := s.commonService.GetBlockNumberByNumberOrTag(ctx, numberOrTag)
+	if errRpc != nil {
+		return nil, errRpc
+	}
+
+	cachedKey := fmt.Sprintf("%s_%d_%t", GetBlockByNumber, blockNumberInt, showDetails)
+	if err := s.cacheService.Get(ctx, cachedKey, &cachedBlock); err == nil && cachedBlock.Hash != nil {
+		s.logger.Info("Block fetched from cache", zap.Any("block", cachedBlock))
+		return &cachedBlock, nil
+	}
+	ttl := s.config.CacheTTLs.Of(cache.ClassBlock)
+	if tagged {
+		cachedKey, ttl = latestKey, ShortExpiration
+	}
+
+	block, err := s.mClient.GetBlockByHashOrNumber(ctx, strconv.FormatInt(blockNumberInt, 10))
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		s.logger.Error("Failed to get block", zap.Error(err))
+		return nil, mirrorError(err, "Failed to get block")
+	}
+
+	processedBlock, err := s.processBlock(ctx, block, showDetails)
+	if err != nil {
+		s.logger.Error("Failed to process block", zap.Error(err))
+		return nil, mirrorError(err, "Failed to process block")
+	}
+
+	if err := s.cacheService.Set(ctx, cachedKey, &processedBlock, ttl); err != nil {
+		s.logger.Debug("Failed to cache block", zap.Error(err))
+	}
+
+	return processedBlock, nil
+}
+
+func (s *blockService) GetLogs(ctx context.Context, logParams domain.LogParams) (interface{}, *domain.RPCError) {
+	s.logger.Info("Getting logs", zap.Any("logParams", logParams))
+
+	return s.commonService.GetLogs(ctx, logParams)
+}
+
+// StreamLogs passes the logs matching logParams to emit page by page, without
+// the result limit of GetLogs.
+func (s *blockService) StreamLogs(ctx context.Context, logParams domain.LogParams, emit func([]domain.Log) error) *domain.RPCError {
+	s.logger.Info("Streaming logs", zap.Any("logParams", logParams))
+
+	return s.commonService.StreamLogs(ctx, logParams, emit)
+}
+
+func (s *blockService) GetBlockTransactionCountByHash(ctx context.Context, blockHash string) (interface{}, *domain.RPCError) {
+	s.logger.Info("Getting block transaction count by hash", zap.String("blockHash", blockHash))
+
+	cacheKey := fmt.Sprintf("%s_%s", GetBlockTransactionCountByHash, blockHash)
+
+	var transactionCount string
+
+	if err := s.cacheService.Get(ctx, cacheKey, &transactionCount); err == nil && transactionCount != "" {
+		s.logger.Info("Transaction count fetched from cache", zap.String("count", transactionCount))
+		return transactionCount, nil
+	}
+
+	block, err := s.mClient.GetBlockByHashOrNumber(ctx, blockHash)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		s.logger.Error("Failed to get block", zap.Error(err))
+		return nil, mirrorError(err, "Failed to get block")
+	}
+
+	transactionCount = fmt.Sprintf("0x%x", block.Count)
+
+	if err := s.cacheService.Set(ctx, cacheKey, transactionCount, s.config.CacheTTLs.Of(cache.ClassBlock)); err != nil {
+		s.logger.Debug("Failed to cache transaction count", zap.Error(err))
+	}
+
+	return transactionCount, nil
+}
+
+func (s *blockService) GetBlockTransactionCountByNumber(ctx context.Context, blockNumberOrTag string) (interface{}, *domain.RPCError) {
+	s.logger.Info("Getting block transaction count by number", zap.String("blockNumber", blockNumberOrTag))
+	blockNumberInt, errRpc := s.commonService.GetBlockNumberByNumberOrTag(ctx, blockNumberOrTag)
+	if errRpc != nil {
+		return nil, errRpc
+	}
+
+	cachedKey := fmt.Sprintf("%s_%d", GetBlockTransactionCountByNumber, blockNumberInt)
+
+	var transactionCount string
+
+	if err := s.cacheService.Get(ctx, cachedKey, &transactionCount); err == nil && transactionCount != "" {
+		s.logger.Info("Transaction count fetched from cache", zap.String("count", transactionCount))
+		return transactionCount, nil
+	}
+
+	block, err := s.mClient.GetBlockByHashOrNumber(ctx, strconv.FormatInt(blockNumberInt, 10))
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		s.logger.Error("Failed to get block", zap.Error(err))
+		return nil, mirrorError(err, "Failed to get block")
+	}
+
+	transactionCount = fmt.Sprintf("0x%x", block.Count)
+
+	if err := s.cacheService.Set(ctx, cachedKey, transactionCount, s.config.CacheTTLs.Of(cache.ClassBlock)); err != nil {
+		s.logger.Debug("Failed to cache transaction count", zap.Error(err))
+	}
+
+	return transactionCount, nil
+}
+
+// Syncing returns false, because the Hedera network does not support syncing.
+// With SyncingCheckEnabled it instead reports a syncing status whenever the
+// latest mirror node block is older than SyncingLagThreshold, so that stale
+// mirror node data can be detected.
+func (s *blockService) Syncing(ctx context.Context) (interface{}, *domain.RPCError) {
+	s.logger.Info("Syncing")
+	if !s.config.SyncingCheckEnabled {
+		s.logger.Debug("Returning false as per specification")
+		return false, nil
+	}
+
+	block, err := s.mClient.GetLatestBlock(ctx)
+	if err != nil {
+		s.logger.Error("Failed to fetch latest block", zap.Error(err))
+		return nil, domain.NewRPCError(domain.ServerError, "Failed to fetch block data: "+err.Error())
+	}
+
+	blockNumber, _ := block["number"].(float64)
+	timestamp, _ := block["timestamp"].(map[string]interface{})
+	to, _ := timestamp["to"].(string)
+	blockTime, err := parseTimestamp(to)
+	if err != nil {
+		s.logger.Error("Failed to parse latest block timestamp", zap.Any("timestamp", block["timestamp"]), zap.Error(err))
+		return nil, domain.NewRPCError(domain.ServerError, "Failed to parse latest block timestamp")
+	}
+
+	threshold := s.config.SyncingLagThreshold
+	if threshold <= 0 {
+		threshold = DefaultSyncingLagThreshold
+	}
+
+	lag := time.Since(time.Unix(0, blockTime))
+	if lag <= threshold {
+		return false, nil
+	}
+
+	current := int64(blockNumber)
+	highest := current + int64(lag/hederaBlockInterval)
+	s.logger.Warn("Mirror node is lagging behind", zap.Duration("lag", lag), zap.Int64("block", current))
+
+	return domain.SyncingStatus{
+		StartingBlock: fmt.Sprintf("0x%x", current),
+		CurrentBlock:  fmt.Sprintf("0x%x", current),
+		HighestBlock:  fmt.Sprintf("0x%x", highest),
+	}, nil
+}
+
+// GetUncleCountByBlockNumber returns 0x0, because the Hedera network does not support it
+func (s *blockService) GetUncleCountByBlockNumber(blockNumber string) (interface{}, *domain.RPCError) {
+	s.logger.Info("GetUncleCountByBlockNumber", zap.String("blockNumber", blockNumber))
+	s.logger.Debug("Returning 0x0 as per specification")
+	return "0x0", nil
+}
+
+// GetUncleByBlockNumberAndIndex returns nil, because the Hedera network does not support it
+func (s *blockService) GetUncleByBlockNumberAndIndex(blockNumber string, index string) (interface{}, *domain.RPCError) {
+	s.logger.Info("GetUncleByBlockNumberAndIndex", zap.String("blockNumber", blockNumber), zap.String("index", index))
+	s.logger.Debug("Returning nil as per specification")
+	return nil, nil
+}
+
+// GetUncleCountByBlockHash returns 0x0, because the Hedera network does not support it
+func (s *blockService) GetUncleCountByBlockHash(blockHash string) (interface{}, *domain.RPCError) {
+	s.logger.Info("GetUncleCountByBlockHash", zap.String("blockHash", blockHash))
+	s.logger.Debug("Returning 0x0 as per specification")
+	return "0x0", nil
+}
+
+// GetUncleByBlockHashAndIndex returns nil, because the Hedera network does not support it
+func (s *blockService) GetUncleByBlockHashAndIndex(blockHash string, index string) (interface{}, *domain.RPCError) {
+	s.logger.Info("GetUncleByBlockHashAndIndex", zap.String("blockHash", blockHash), zap.String("index", index))
+	s.logger.Debug("Returning nil as per specification")
+	return nil, nil
+}
@@ -1,8 +1,13 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/LimeChain/Hederium/internal/domain"
 	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
@@ -11,66 +16,147 @@ import (
 )
 
 type CommonService interface {
-	GetLogs(logParams domain.LogParams) ([]domain.Log, *domain.RPCError)
-	ValidateBlockHashAndAddTimestampToParams(params map[string]interface{}, blockHash string) error
-	ValidateBlockRangeAndAddTimestampToParams(params map[string]interface{}, fromBlock, toBlock string, address []string) (bool, *domain.RPCError)
-	GetLogsWithParams(address []string, params map[string]interface{}) ([]domain.Log, error)
-	GetBlockNumberByNumberOrTag(blockNumberOrTag string) (int64, *domain.RPCError)
-	ValidateBlockRange(fromBlock, toBlock string) *domain.RPCError
-	GetBlockNumber() (interface{}, *domain.RPCError)
+	GetLogs(ctx context.Context, logParams domain.LogParams) ([]domain.Log, *domain.RPCError)
+	StreamLogs(ctx context.Context, logParams domain.LogParams, emit func([]domain.Log) error) *domain.RPCError
+	ValidateBlockHashAndAddTimestampToParams(ctx context.Context, params map[string]interface{}, blockHash string) error
+	ValidateBlockRangeAndAddTimestampToParams(ctx context.Context, params map[string]interface{}, fromBlock, toBlock string, address []string) (bool, *domain.RPCError)
+	GetLogsWithParams(ctx context.Context, address []string, params map[string]interface{}) ([]domain.Log, error)
+	GetBlockNumberByNumberOrTag(ctx context.Context, blockNumberOrTag string) (int64, *domain.RPCError)
+	ValidateBlockRange(ctx context.Context, fromBlock, toBlock string) *domain.RPCError
+	GetBlockNumber(ctx context.Context) (interface{}, *domain.RPCError)
 }
 
 type commonService struct {
-	mClient infrahedera.MirrorNodeClient
-	logger  *zap.Logger
-	cache   cache.CacheService
+	mClient       infrahedera.MirrorNodeClient
+	logger        *zap.Logger
+	cache         cache.CacheService
+	cacheTTLs     cache.TTLs
+	maxLogResults int
+	blockPoller   *BlockPoller
 }
 
-func NewCommonService(mClient infrahedera.MirrorNodeClient, logger *zap.Logger, cache cache.CacheService) CommonService {
+// NewCommonService creates the service shared by the eth and filter services.
+// cacheTTLs sets how long the log offsets of blocks are cached.
+// maxLogResults caps the logs returned by GetLogs; zero uses DefaultMaxLogResults.
+// When blockPoller is not nil, the latest block number is taken from it while
+// it is current.
+func NewCommonService(mClient infrahedera.MirrorNodeClient, logger *zap.Logger, cache cache.CacheService, cacheTTLs cache.TTLs, maxLogResults int, blockPoller *BlockPoller) CommonService {
+	if maxLogResults <= 0 {
+		maxLogResults = DefaultMaxLogResults
+	}
+
 	return &commonService{
-		mClient: mClient,
-		logger:  logger,
-		cache:   cache,
+		mClient:       mClient,
+		logger:        logger,
+		cache:         cache,
+		cacheTTLs:     cacheTTLs,
+		maxLogResults: maxLogResults,
+		blockPoller:   blockPoller,
 	}
 }
 
-func (s *commonService) GetLogs(logParams domain.LogParams) ([]domain.Log, *domain.RPCError) {
-	params := make(map[string]interface{})
+func (s *commonService) GetLogs(ctx context.Context, logParams domain.LogParams) ([]domain.Log, *domain.RPCError) {
+	params, ok, errRpc := s.logQueryParams(ctx, logParams)
+	if errRpc != nil {
+		return nil, errRpc
+	}
+	if !ok {
+		return []domain.Log{}, nil
+	}
 
-	if logParams.BlockHash != "" {
-		if err := s.ValidateBlockHashAndAddTimestampToParams(params, logParams.BlockHash); err != nil {
-			return []domain.Log{}, nil
+	logs, err := s.GetLogsWithParams(ctx, logParams.Address, params)
+	if errors.Is(err, ErrLogLimitExceeded) {
+		return nil, domain.NewQueryLimitExceededError(s.maxLogResults)
+	}
+	if err != nil {
+		s.logger.Error("Failed to get logs", zap.Error(err))
+		return nil, mirrorError(err, "Failed to get logs")
+	}
+
+	// Without addresses or topics the logs of every block are all there
+	logs = filterLogsByTopics(logs, logParams.Topics)
+	complete := len(logParams.Address) == 0 && len(logParams.Topics) == 0
+	if err := s.indexLogsInBlocks(ctx, logs, complete); err != nil {
+		s.logger.Error("Failed to number logs within their blocks", zap.Error(err))
+		return nil, mirrorError(err, "Failed to get logs")
+	}
+
+	return logs, nil
+}
+
+// StreamLogs passes the logs matching logParams to emit one mirror node page at
+// a time, in the order GetLogs returns them. Unlike GetLogs it is not capped by
+// maxLogResults, so that arbitrarily large ranges can be exported.
+func (s *commonService) StreamLogs(ctx context.Context, logParams domain.LogParams, emit func([]domain.Log) error) *domain.RPCError {
+	params, ok, errRpc := s.logQueryParams(ctx, logParams)
+	if errRpc != nil || !ok {
+		return errRpc
+	}
+
+	addresses := uniqueAddresses(logParams.Address)
+	if addresses == nil {
+		addresses = []string{""}
+	}
+
+	handle := func(entries []domain.LogEntry) error {
+		logs := filterLogsByTopics(appendLogEntries(make([]domain.Log, 0, len(entries)), entries), logParams.Topics)
+		if len(logs) == 0 {
+			return nil
 		}
-	} else {
-		if ok, errRpc := s.ValidateBlockRangeAndAddTimestampToParams(params, logParams.FromBlock, logParams.ToBlock, logParams.Address); errRpc != nil {
-			return nil, errRpc
-		} else if !ok {
-			return []domain.Log{}, nil
+		// A page may end within a block, so offsets are always fetched
+		if err := s.indexLogsInBlocks(ctx, logs, false); err != nil {
+			return err
 		}
+		return emit(logs)
 	}
 
-	if logParams.Topics != nil {
-		for i, topic := range logParams.Topics {
-			if topic != "" {
-				params[fmt.Sprintf("topic%d", i)] = topic
+	for _, windowParams := range timestampWindowParams(params) {
+		for _, addr := range addresses {
+			if err := s.mClient.StreamContractResultsLogs(ctx, addr, windowParams, handle); err != nil {
+				s.logger.Error("Failed to stream logs", zap.Error(err))
+				return mirrorError(err, "Failed to get logs")
 			}
 		}
 	}
 
-	logs, err := s.GetLogsWithParams(logParams.Address, params)
-	if err != nil {
-		s.logger.Error("Failed to get logs", zap.Error(err))
-		return nil, domain.NewRPCError(domain.ServerError, "Failed to get logs")
+	return nil
+}
+
+// logQueryParams turns an eth_getLogs filter into mirror node query params.
+// It returns false when the filter cannot match any log.
+func (s *commonService) logQueryParams(ctx context.Context, logParams domain.LogParams) (map[string]interface{}, bool, *domain.RPCError) {
+	params := make(map[string]interface{})
+
+	if logParams.BlockHash != "" {
+		if err := s.ValidateBlockHashAndAddTimestampToParams(ctx, params, logParams.BlockHash); err != nil {
+			if isNotFound(err) {
+				return nil, false, nil
+			}
+			s.logger.Error("Failed to get block data", zap.Error(err))
+			return nil, false, mirrorError(err, "Failed to get block data")
+		}
+	} else {
+		if ok, errRpc := s.ValidateBlockRangeAndAddTimestampToParams(ctx, params, logParams.FromBlock, logParams.ToBlock, logParams.Address); errRpc != nil || !ok {
+			return nil, false, errRpc
+		}
 	}
 
-	return logs, nil
+	// Alternative topics are sent as repeated params, which the mirror node
+	// matches as any of them, unless there are more than it accepts.
+	for i, alternatives := range logParams.Topics {
+		if len(alternatives) > 0 && len(alternatives) <= mirrorNodeMaxRepeatedParams {
+			params[fmt.Sprintf("topic%d", i)] = strings.Join(alternatives, fmt.Sprintf("&topic%d=", i))
+		}
+	}
+
+	return params, true, nil
 }
 
-func (s *commonService) ValidateBlockHashAndAddTimestampToParams(params map[string]interface{}, blockHash string) error {
-	block := s.mClient.GetBlockByHashOrNumber(blockHash)
-	if block == nil {
-		s.logger.Debug("Failed to get block data")
-		return nil
+func (s *commonService) ValidateBlockHashAndAddTimestampToParams(ctx context.Context, params map[string]interface{}, blockHash string) error {
+	block, err := s.mClient.GetBlockByHashOrNumber(ctx, blockHash)
+	if err != nil {
+		s.logger.Debug("Failed to get block data", zap.Error(err))
+		return err
 	}
 	s.logger.Debug("Received block data", zap.Any("block", block))
 
@@ -81,10 +167,10 @@ func (s *commonService) ValidateBlockHashAndAddTimestampToParams(params map[stri
 	return nil
 }
 
-func (s *commonService) ValidateBlockRangeAndAddTimestampToParams(params map[string]interface{}, fromBlock, toBlock string, address []string) (bool, *domain.RPCError) {
+func (s *commonService) ValidateBlockRangeAndAddTimestampToParams(ctx context.Context, params map[string]interface{}, fromBlock, toBlock string, address []string) (bool, *domain.RPCError) {
 
 	// We get the latestBlockNum only once to avoid multiple calls
-	latestBlockNum, errRpc := s.GetBlockNumberByNumberOrTag("latest")
+	latestBlockNum, errRpc := s.GetBlockNumberByNumberOrTag(ctx, "latest")
 	if errRpc != nil {
 		return false, errRpc
 	}
@@ -95,7 +181,7 @@ func (s *commonService) ValidateBlockRangeAndAddTimestampToParams(params map[str
 		toBlock = domain.BlockTagLatest
 		toBlockNum = latestBlockNum
 	} else {
-		toBlockNum, errRpc = s.GetBlockNumberByNumberOrTag(toBlock)
+		toBlockNum, errRpc = s.GetBlockNumberByNumberOrTag(ctx, toBlock)
 		if errRpc != nil {
 			return false, errRpc
 		}
@@ -115,16 +201,19 @@ func (s *commonService) ValidateBlockRangeAndAddTimestampToParams(params map[str
 		fromBlock = domain.BlockTagLatest
 		fromBlockNum = latestBlockNum
 	} else {
-		fromBlockNum, errRpc = s.GetBlockNumberByNumberOrTag(fromBlock)
+		fromBlockNum, errRpc = s.GetBlockNumberByNumberOrTag(ctx, fromBlock)
 		if errRpc != nil {
 			return false, errRpc
 		}
 	}
 
-	fromBlockResponse := s.mClient.GetBlockByHashOrNumber(strconv.FormatInt(fromBlockNum, 10))
-	if fromBlockResponse == nil {
-		s.logger.Debug("Failed to get from block data")
-		return false, nil
+	fromBlockResponse, err := s.mClient.GetBlockByHashOrNumber(ctx, strconv.FormatInt(fromBlockNum, 10))
+	if err != nil {
+		s.logger.Debug("Failed to get from block data", zap.Error(err))
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, mirrorError(err, "Failed to get block data")
 	}
 
 	var timestamp string
@@ -136,7 +225,11 @@ func (s *commonService) ValidateBlockRangeAndAddTimestampToParams(params map[str
 
 	} else {
 		fromBlockNum := fromBlockResponse.Number
-		toBlockResponse := s.mClient.GetBlockByHashOrNumber(strconv.FormatInt(toBlockNum, 10))
+		toBlockResponse, err := s.mClient.GetBlockByHashOrNumber(ctx, strconv.FormatInt(toBlockNum, 10))
+		if err != nil && !isNotFound(err) {
+			s.logger.Debug("Failed to get to block data", zap.Error(err))
+			return false, mirrorError(err, "Failed to get block data")
+		}
 
 		/**
 		 * If `toBlock` is not provided, the `lte` field cannot be set,
@@ -152,33 +245,42 @@ func (s *commonService) ValidateBlockRangeAndAddTimestampToParams(params map[str
 		timestamp = fmt.Sprintf("%s&timestamp=lte:%s", timestamp, toBlockResponse.Timestamp.To)
 		toBlockNum := toBlockResponse.Number
 
-		toBlockTo, err := strconv.ParseFloat(toBlockResponse.Timestamp.To, 64)
+		toBlockTo, err := parseTimestamp(toBlockResponse.Timestamp.To)
 		if err != nil {
 			return false, domain.NewRPCError(domain.InvalidParams, "Invalid timestamp")
 		}
 
-		fromBlockFrom, err := strconv.ParseFloat(fromBlockResponse.Timestamp.From, 64)
+		fromBlockFrom, err := parseTimestamp(fromBlockResponse.Timestamp.From)
 		if err != nil {
 			return false, domain.NewRPCError(domain.InvalidParams, "Invalid timestamp")
 		}
 
-		// Validate timestamp range for Mirror Node requests (maximum: 7 days or 604,800 seconds) to prevent exceeding the limit,
-		// as requests with timestamp parameters beyond 7 days are rejected by the Mirror Node.
-		timestampDiff := toBlockTo - fromBlockFrom
-		if timestampDiff > 604800 {
-			s.logger.Debug("Timestamp range is too large")
-			return false, domain.NewTimeStampRangeTooLargeError(fmt.Sprintf("0x%x", fromBlockNum), fmt.Sprintf("0x%x", toBlockNum), toBlockTo, fromBlockFrom)
-		}
-
 		if fromBlockNum > toBlockNum {
 			return false, domain.NewInvalidBlockRangeError()
 		}
 
+		// The Mirror Node rejects timestamp ranges longer than 7 days, so larger
+		// ranges are queried as several consecutive windows.
+		span := toBlockTo - fromBlockFrom
+		maxSpan := int64(MaxTimestampParamRange * time.Second)
+		windowCount := (span + maxSpan - 1) / maxSpan
+
 		// Increasing it to more then one address may degrade mirror node performance
-		// when addresses contains many log events.
+		// when addresses contains many log events, so such queries are also split
+		// into windows spanning roughly blockRangeLimit blocks each.
 		isSingleAddress := len(address) == 1
 		if !isSingleAddress && toBlockNum-fromBlockNum > blockRangeLimit {
-			return false, domain.NewRangeTooLarge(blockRangeLimit)
+			blockCount := toBlockNum - fromBlockNum + 1
+			if n := int64((blockCount + blockRangeLimit - 1) / blockRangeLimit); n > windowCount {
+				windowCount = n
+			}
+		}
+
+		if windowCount > 1 {
+			windows := splitTimestampRange(fromBlockFrom, toBlockTo, windowCount)
+			s.logger.Debug("Splitting block range into timestamp windows", zap.Int("windows", len(windows)))
+			params["timestamp"] = windows
+			return true, nil
 		}
 	}
 
@@ -188,81 +290,127 @@ func (s *commonService) ValidateBlockRangeAndAddTimestampToParams(params map[str
 	return true, nil
 }
 
-func (s *commonService) GetLogsWithParams(address []string, params map[string]interface{}) ([]domain.Log, error) {
-	addresses := address
+// GetLogsWithParams fetches the logs matching params for each address, or for
+// all contracts when address is nil. When params["timestamp"] holds several
+// timestamp windows they are queried in order and the results concatenated.
+// The logs of several addresses are merged per window, without duplicates and
+// ordered by block number, transaction index and log index. ErrLogLimitExceeded is returned once
+// more than maxLogResults logs are found across all addresses.
+func (s *commonService) GetLogsWithParams(ctx context.Context, address []string, params map[string]interface{}) ([]domain.Log, error) {
+	logs := []domain.Log{}
+	address = uniqueAddresses(address)
+
+	for _, windowParams := range timestampWindowParams(params) {
+		if address == nil {
+			logResults, err := s.mClient.GetContractResultsLogsWithRetry(ctx, windowParams)
+			if err != nil {
+				s.logger.Error("Failed to get logs", zap.Error(err))
+				return nil, err
+			}
 
-	var logs []domain.Log
+			s.logger.Debug("Received logs", zap.Any("logs", logResults))
+			if err := checkLogEntries(logResults); err != nil {
+				s.logger.Error("Failed to get logs", zap.Error(err))
+				return nil, err
+			}
 
-	if address == nil {
-		logResults, err := s.mClient.GetContractResultsLogsWithRetry(params)
-		if err != nil {
-			s.logger.Error("Failed to get logs", zap.Error(err))
-			return nil, err
+			logs = appendLogEntries(logs, logResults)
+			if len(logs) > s.maxLogResults {
+				return nil, ErrLogLimitExceeded
+			}
 		}
 
-		s.logger.Debug("Received logs", zap.Any("logs", logResults))
-
-		for _, logResult := range logResults {
-			if len(logResult.BlockHash) > 66 {
-				logResult.BlockHash = logResult.BlockHash[:66]
+		var windowLogs []domain.LogEntry
+		for _, addr := range address {
+			logResults, err := s.mClient.GetContractResultsLogsByAddress(ctx, addr, windowParams)
+			if err != nil {
+				s.logger.Error("Failed to get logs", zap.Error(err))
+				return nil, err
 			}
-			if len(logResult.TransactionHash) > 66 {
-				logResult.TransactionHash = logResult.TransactionHash[:66]
+			if err := checkLogEntries(logResults); err != nil {
+				s.logger.Error("Failed to get logs", zap.Error(err))
+				return nil, err
 			}
 
-			logs = append(logs, domain.Log{
-				Address:          logResult.Address,
-				BlockHash:        logResult.BlockHash,
-				BlockNumber:      fmt.Sprintf("0x%x", *logResult.BlockNumber),
-				Data:             logResult.Data,
-				LogIndex:         fmt.Sprintf("0x%x", *logResult.Index),
-				Removed:          false,
-				Topics:           logResult.Topics,
-				TransactionHash:  logResult.TransactionHash,
-				TransactionIndex: fmt.Sprintf("0x%x", *logResult.TransactionIndex),
-			})
+			windowLogs = append(windowLogs, logResults...)
+			if len(logs)+len(windowLogs) > s.maxLogResults {
+				return nil, ErrLogLimitExceeded
+			}
 		}
+		if len(address) > 1 {
+			windowLogs = mergeLogEntries(windowLogs)
+		}
+		logs = appendLogEntries(logs, windowLogs)
 	}
 
-	for _, addr := range addresses {
-		logResults, err := s.mClient.GetContractResultsLogsByAddress(addr, params)
-		if err != nil {
-			s.logger.Error("Failed to get logs", zap.Error(err))
-			return nil, err
-		}
-		for _, logResult := range logResults {
-			if len(logResult.BlockHash) > 66 {
-				logResult.BlockHash = logResult.BlockHash[:66]
-			}
-			if len(logResult.TransactionHash) > 66 {
-				logResult.TransactionHash = logResult.TransactionHash[:66]
-			}
-			logs = append(logs, domain.Log{
-				Address:          logResult.Address,
-				BlockHash:        logResult.BlockHash,
-				BlockNumber:      fmt.Sprintf("0x%x", *logResult.BlockNumber),
-				Data:             logResult.Data,
-				LogIndex:         fmt.Sprintf("0x%x", *logResult.Index),
-				Removed:          false,
-				Topics:           logResult.Topics,
-				TransactionHash:  logResult.TransactionHash,
-				TransactionIndex: fmt.Sprintf("0x%x", *logResult.TransactionIndex),
-			})
+	return logs, nil
+}
+
+// uniqueAddresses drops the repeated entries of addresses, which differ in
+// case at most, so that no contract is queried twice.
+func uniqueAddresses(addresses []string) []string {
+	if len(addresses) < 2 {
+		return addresses
+	}
+
+	seen := make(map[string]bool, len(addresses))
+	unique := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		key := strings.ToLower(address)
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, address)
 		}
 	}
+	return unique
+}
 
-	if logs == nil {
-		return []domain.Log{}, nil
+// checkLogEntries returns ErrIncompleteLog for the first log of entries that
+// lacks the block number, transaction index or log index it is ordered and
+// returned by.
+func checkLogEntries(entries []domain.LogEntry) error {
+	for _, entry := range entries {
+		if entry.BlockNumber == nil || entry.TransactionIndex == nil || entry.Index == nil {
+			return fmt.Errorf("%w: transaction %s", ErrIncompleteLog, entry.TransactionHash)
+		}
 	}
+	return nil
+}
 
-	return logs, nil
+// mergeLogEntries orders the logs of several contracts by block number and
+// log index, dropping the logs found for more than one of them, as the same
+// contract may be named by its EVM and its long-zero address. The entries must
+// have passed checkLogEntries.
+func mergeLogEntries(entries []domain.LogEntry) []domain.LogEntry {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if *entries[i].BlockNumber != *entries[j].BlockNumber {
+			return *entries[i].BlockNumber < *entries[j].BlockNumber
+		}
+		if *entries[i].TransactionIndex != *entries[j].TransactionIndex {
+			return *entries[i].TransactionIndex < *entries[j].TransactionIndex
+		}
+		return *entries[i].Index < *entries[j].Index
+	})
+
+	// The index of a log is only unique within its transaction
+	merged := entries[:0]
+	for _, entry := range entries {
+		if n := len(merged); n > 0 && *merged[n-1].BlockNumber == *entry.BlockNumber &&
+			*merged[n-1].TransactionIndex == *entry.TransactionIndex && *merged[n-1].Index == *entry.Index {
+			continue
+		}
+		merged = append(merged, entry)
+	}
+	return merged
 }
 
-func (s *commonService) GetBlockNumberByNumberOrTag(blockNumberOrTag string) (int64, *domain.RPCError) {
+func (s *commonService) GetBlockNumberByNumberOrTag(ctx context.Context, blockNumberOrTag string) (int64, *domain.RPCError) {
 	s.logger.Debug("Getting block number by hash or tag", zap.String("blockHashOrTag", blockNumberOrTag))
 	switch blockNumberOrTag {
-	case domain.BlockTagLatest, domain.BlockTagPending:
-		latestBlock, errMap := s.GetBlockNumber()
+	// Hedera blocks are final once created, so the safe and finalized blocks
+	// are the latest one
+	case domain.BlockTagLatest, domain.BlockTagPending, domain.BlockTagSafe, domain.BlockTagFinalized:
+		latestBlock, errMap := s.GetBlockNumber(ctx)
 		if errMap != nil {
 			s.logger.Error("Failed to get latest block number", zap.Error(errMap))
 			return 0, errMap
@@ -285,6 +433,15 @@ func (s *commonService) GetBlockNumberByNumberOrTag(blockNumberOrTag string) (in
 	case domain.BlockTagEarliest:
 		return int64(0), nil
 	default:
+		if isBlockHash(blockNumberOrTag) {
+			block, err := s.mClient.GetBlockByHashOrNumber(ctx, blockNumberOrTag)
+			if err != nil {
+				s.logger.Debug("Failed to get block by hash", zap.String("blockHash", blockNumberOrTag), zap.Error(err))
+				return 0, mirrorError(err, fmt.Sprintf("Block %s not found", blockNumberOrTag))
+			}
+			return int64(block.Number), nil
+		}
+
 		// Convert hex string to int, remove "0x" prefix
 		latestBlockNum, err := HexToDec(blockNumberOrTag)
 		if err != nil {
@@ -296,12 +453,18 @@ func (s *commonService) GetBlockNumberByNumberOrTag(blockNumberOrTag string) (in
 	}
 }
 
-func (s *commonService) GetBlockNumber() (interface{}, *domain.RPCError) {
+func (s *commonService) GetBlockNumber(ctx context.Context) (interface{}, *domain.RPCError) {
 	s.logger.Info("Getting block number")
-	block, err := s.mClient.GetLatestBlock()
+	if s.blockPoller != nil {
+		if latest, ok := s.blockPoller.Latest(); ok {
+			return "0x" + strconv.FormatInt(int64(latest.Number), 16), nil
+		}
+	}
+
+	block, err := s.mClient.GetLatestBlock(ctx)
 	if err != nil {
 		s.logger.Error("Failed to fetch latest block", zap.Error(err))
-		return nil, domain.NewRPCError(domain.ServerError, "Failed to fetch block data: "+err.Error())
+		return nil, mirrorError(err, "Failed to fetch block data: "+err.Error())
 	}
 
 	s.logger.Debug("Received block data", zap.Any("block", block))
@@ -320,10 +483,10 @@ func (s *commonService) GetBlockNumber() (interface{}, *domain.RPCError) {
 	return nil, domain.NewRPCError(domain.ServerError, "Invalid block data")
 }
 
-func (s *commonService) ValidateBlockRange(fromBlock, toBlock string) *domain.RPCError {
+func (s *commonService) ValidateBlockRange(ctx context.Context, fromBlock, toBlock string) *domain.RPCError {
 	var fromBlockNum, toBlockNum int64
 
-	latestBlockNum, errRpc := s.GetBlockNumberByNumberOrTag("latest")
+	latestBlockNum, errRpc := s.GetBlockNumberByNumberOrTag(ctx, "latest")
 	if errRpc != nil {
 		return errRpc
 	}
@@ -331,7 +494,7 @@ func (s *commonService) ValidateBlockRange(fromBlock, toBlock string) *domain.RP
 	if blockTagIsLatestOrPending(&toBlock) {
 		toBlockNum = latestBlockNum
 	} else {
-		toBlockNum, errRpc = s.GetBlockNumberByNumberOrTag(toBlock)
+		toBlockNum, errRpc = s.GetBlockNumberByNumberOrTag(ctx, toBlock)
 		if errRpc != nil {
 			return errRpc
 		}
@@ -348,7 +511,7 @@ func (s *commonService) ValidateBlockRange(fromBlock, toBlock string) *domain.RP
 	if blockTagIsLatestOrPending(&fromBlock) {
 		fromBlockNum = latestBlockNum
 	} else {
-		fromBlockNum, errRpc = s.GetBlockNumberByNumberOrTag(fromBlock)
+		fromBlockNum, errRpc = s.GetBlockNumberByNumberOrTag(ctx, fromBlock)
 		if errRpc != nil {
 			return errRpc
 		}
@@ -368,3 +531,109 @@ func blockTagIsLatestOrPending(tag *string) bool {
 		*tag == domain.BlockTagSafe ||
 		*tag == domain.BlockTagFinalized
 }
+
+// appendLogEntries converts mirror node log entries and appends them to logs.
+func appendLogEntries(logs []domain.Log, logResults []domain.LogEntry) []domain.Log {
+	for _, logResult := range logResults {
+		if len(logResult.BlockHash) > 66 {
+			logResult.BlockHash = logResult.BlockHash[:66]
+		}
+		if len(logResult.TransactionHash) > 66 {
+			logResult.TransactionHash = logResult.TransactionHash[:66]
+		}
+
+		logs = append(logs, domain.Log{
+			Address:          logResult.Address,
+			BlockHash:        logResult.BlockHash,
+			BlockNumber:      fmt.Sprintf("0x%x", *logResult.BlockNumber),
+			Data:             logResult.Data,
+			LogIndex:         fmt.Sprintf("0x%x", *logResult.Index),
+			Removed:          false,
+			Topics:           logResult.Topics,
+			TransactionHash:  logResult.TransactionHash,
+			TransactionIndex: fmt.Sprintf("0x%x", *logResult.TransactionIndex),
+		})
+	}
+	return logs
+}
+
+// filterLogsByTopics drops the logs not matching topics. Only filters with
+// alternative topics need it, as the mirror node applies single topics itself;
+// alternatives it was sent are checked again at little cost.
+func filterLogsByTopics(logs []domain.Log, topics domain.TopicFilter) []domain.Log {
+	if !topics.HasAlternatives() {
+		return logs
+	}
+
+	matching := logs[:0]
+	for _, log := range logs {
+		if topics.Matches(log.Topics) {
+			matching = append(matching, log)
+		}
+	}
+	return matching
+}
+
+// timestampWindowParams returns one copy of params per timestamp window when
+// params["timestamp"] holds several, or params itself otherwise.
+func timestampWindowParams(params map[string]interface{}) []map[string]interface{} {
+	windows, ok := params["timestamp"].([]string)
+	if !ok {
+		return []map[string]interface{}{params}
+	}
+
+	result := make([]map[string]interface{}, 0, len(windows))
+	for _, window := range windows {
+		windowParams := make(map[string]interface{}, len(params))
+		for k, v := range params {
+			windowParams[k] = v
+		}
+		windowParams["timestamp"] = window
+		result = append(result, windowParams)
+	}
+	return result
+}
+
+// splitTimestampRange splits the inclusive range [from, to], in nanoseconds,
+// into count consecutive mirror node timestamp filters of about equal length.
+func splitTimestampRange(from, to, count int64) []string {
+	size := (to - from + count) / count
+
+	windows := make([]string, 0, count)
+	for start := from; start <= to; start += size {
+		end := start + size - 1
+		if end > to {
+			end = to
+		}
+		windows = append(windows, fmt.Sprintf("gte:%s&timestamp=lte:%s", formatTimestamp(start), formatTimestamp(end)))
+	}
+	return windows
+}
+
+// parseTimestamp parses a mirror node "seconds.nanoseconds" timestamp into
+// nanoseconds without the precision loss of a float.
+func parseTimestamp(timestamp string) (int64, error) {
+	secondsPart, nanosPart, _ := strings.Cut(timestamp, ".")
+
+	seconds, err := strconv.ParseInt(secondsPart, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	var nanos int64
+	if nanosPart != "" {
+		if len(nanosPart) > 9 {
+			return 0, fmt.Errorf("invalid timestamp: %s", timestamp)
+		}
+		nanos, err = strconv.ParseInt(nanosPart+strings.Repeat("0", 9-len(nanosPart)), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return seconds*int64(time.Second) + nanos, nil
+}
+
+func formatTimestamp(nanos int64) string {
+	return fmt.Sprintf("%d.%09d", nanos/int64(time.Second), nanos%int64(time.Second))
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
+	"go.uber.org/zap"
+)
+
+// FeeService answers the gas price and fee history methods.
+type FeeService interface {
+	GetGasPrice(ctx context.Context) (interface{}, *domain.RPCError)
+	FeeHistory(ctx context.Context, blockCount string, newestBlock string, rewardPercentiles []string) (interface{}, *domain.RPCError)
+	MaxPriorityFeePerGas() (interface{}, *domain.RPCError)
+}
+
+type feeService struct {
+	*ethCore
+	blocks BlockService
+}
+
+// GetGasPrice returns the current gas price in wei with a 10% buffer added.
+// The gas price is fetched from the network in tinybars, converted to weibars,
+// and returned as a hex string with "0x" prefix.
+func (s *feeService) GetGasPrice(ctx context.Context) (interface{}, *domain.RPCError) {
+	if s.gasPriceCache != nil {
+		return s.gasPriceCache.Get(ctx, s.getGasPrice)
+	}
+	return s.getGasPrice(ctx)
+}
+
+func (s *feeService) getGasPrice(ctx context.Context) (interface{}, *domain.RPCError) {
+	s.logger.Info("Getting gas price")
+
+	cacheKey := GetGasPrice
+
+	var cachedPrice string
+	err := s.cacheService.Get(ctx, cacheKey, &cachedPrice)
+	if err == nil && cachedPrice != "" {
+		s.logger.Info("Gas price fetched from cache", zap.Any("gasPrice", cachedPrice))
+		return cachedPrice, nil
+	}
+
+	// The shared fetch must not fail for every waiter when the first caller goes away
+	result, err, _ := s.flight.Do(cacheKey, func() (interface{}, error) {
+		ctx := context.WithoutCancel(ctx)
+
+		timestampTo := "" // We pass empty, because we want gas from latest block
+		order := ""
+
+		weibars, err := s.feeWeibars(ctx, timestampTo, order)
+		if err != nil {
+			return nil, err
+		}
+
+		gasPrice := fmt.Sprintf("0x%x", weibars)
+
+		if err := s.cacheService.Set(ctx, cacheKey, gasPrice, s.config.CacheTTLs.Of(cache.ClassGasPrice)); err != nil {
+			s.logger.Debug("Failed to cache gas price", zap.Error(err))
+		}
+		return gasPrice, nil
+	})
+	if err != nil {
+		s.logger.Error("Failed to fetch gas price", zap.Error(err))
+		return nil, serviceError(err, "Failed to fetch gas price")
+	}
+
+	gasPrice := result.(string)
+	s.logger.Info("Successfully returned gas price", zap.String("gasPrice", gasPrice))
+	return gasPrice, nil
+}
+
+func (s *feeService) FeeHistory(ctx context.Context, blockCount string, newestBlock string, rewardPercentiles []string) (interface{}, *domain.RPCError) {
+	s.logger.Info("Getting fee history", zap.String("blockCount", blockCount), zap.String("newestBlock", newestBlock), zap.Any("rewardPercentiles", rewardPercentiles))
+
+	// Get the block number of the newest block
+	latestBlockNumber, errRpc := s.blocks.GetBlockNumber(ctx)
+	if errRpc != nil {
+		return nil, errRpc
+	}
+
+	latestBlockHex, ok := latestBlockNumber.(string)
+	if !ok {
+		return nil, domain.NewRPCError(domain.ServerError, "Failed to parse latest block number")
+	}
+	latestBlockInt, err := HexToDec(latestBlockHex)
+	if err != nil {
+		return nil, domain.NewRPCError(domain.ServerError, fmt.Sprintf("Failed to parse latest block number: %s", err.Error()))
+	}
+	newestBlockInt, errRpc := s.commonService.GetBlockNumberByNumberOrTag(ctx, newestBlock)
+	if errRpc != nil {
+		return nil, errRpc
+	}
+
+	// Convert the block number to decimal
+	blockCountInt, err := HexToDec(blockCount)
+	if err != nil {
+		s.logger.Error("Failed to parse block count", zap.Error(err))
+		return nil, domain.NewRPCError(domain.ServerError, "Failed to parse block count:")
+	}
+
+	// Check if the blockCount is greater then the one we need
+	if blockCountInt > int64(maxBlockCountForResult) {
+		blockCountInt = int64(maxBlockCountForResult)
+	}
+
+	if newestBlockInt > latestBlockInt {
+		newestBlockInt = latestBlockInt
+	}
+
+	oldestBlockInt := newestBlockInt - blockCountInt + 1
+
+	fixed_Fee := true // The nodejs implementation uses this flag to determine if the fee is fixed or not
+	if fixed_Fee {
+		if oldestBlockInt <= 0 {
+			blockCountInt = 1
+			oldestBlockInt = 1
+		}
+		fee, errRpc := s.GetGasPrice(ctx)
+		if errRpc != nil {
+			return nil, errRpc
+		}
+		feeHex, ok := fee.(string)
+		if !ok {
+			return nil, domain.NewRPCError(domain.ServerError, "Failed to parse fee")
+		}
+
+		feeHistory := s.getRepeatedFeeHistory(blockCountInt, oldestBlockInt, rewardPercentiles, feeHex)
+		return feeHistory, nil
+	}
+
+	feeHistory, err := s.getFeeHistory(ctx, blockCountInt, newestBlockInt, latestBlockInt, rewardPercentiles)
+	if err != nil {
+		s.logger.Error("Failed to get fee history", zap.Error(err))
+		return nil, domain.NewRPCError(domain.ServerError, "Failed to get fee history:")
+	}
+
+	return feeHistory, nil
+}
+
+// MaxPriorityFeePerGas returns the configured tip, 0x0 by default, because the
+// Hedera network does not charge priority fees
+func (s *feeService) MaxPriorityFeePerGas() (interface{}, *domain.RPCError) {
+	s.logger.Info("MaxPriorityFeePerGas")
+	return s.priorityFee(), nil
+}
+
+func (s *feeService) getFeeHistory(ctx context.Context, blockCount, newestBlockInt, latestBlockInt int64, rewardPercentiles []string) (*domain.FeeHistory, error) {
+	oldestBlockNumber := newestBlockInt - blockCount + 1
+	if oldestBlockNumber < 0 {
+		oldestBlockNumber = 0
+	}
+
+	feeHistory := &domain.FeeHistory{
+		BaseFeePerGas: []string{},
+		GasUsedRatio:  []float64{},
+		OldestBlock:   fmt.Sprintf("0x%x", oldestBlockNumber),
+	}
+
+	// Get fees from oldest to newest blocks
+	for blockNumber := oldestBlockNumber; blockNumber <= newestBlockInt; blockNumber++ {
+		fee, err := s.getFeeByBlockNumber(ctx, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		feeHistory.BaseFeePerGas = append(feeHistory.BaseFeePerGas, fee)
+		feeHistory.GasUsedRatio = append(feeHistory.GasUsedRatio, defaultUsedGasRatio)
+	}
+
+	// Get the fee for the next block if the newest block is not the latest
+	var nextBaseFeePerGas string
+	var err error
+	if latestBlockInt > newestBlockInt {
+		nextBaseFeePerGas, err = s.getFeeByBlockNumber(ctx, newestBlockInt+1)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		nextBaseFeePerGas = feeHistory.BaseFeePerGas[len(feeHistory.BaseFeePerGas)-1]
+	}
+
+	if nextBaseFeePerGas != "" {
+		feeHistory.BaseFeePerGas = append(feeHistory.BaseFeePerGas, nextBaseFeePerGas)
+	}
+
+	// Check if there are any reward percentiles
+	if len(rewardPercentiles) > 0 {
+		feeHistory.Reward = s.feeHistoryRewards(blockCount, len(rewardPercentiles))
+	}
+
+	return feeHistory, nil
+}
+
+func (s *feeService) getFeeByBlockNumber(ctx context.Context, blockNumber int64) (string, error) {
+	block, err := s.mClient.GetBlockByHashOrNumber(ctx, strconv.FormatInt(blockNumber, 10))
+	if err != nil {
+		return "", fmt.Errorf("failed to get block data: %w", err)
+	}
+
+	fee, err := s.feeWeibars(ctx, block.Timestamp.To, "desc") // Hardcode desc to be sure that we get latest
+	if err != nil {
+		return "", err
+	}
+
+	// Implement dec to hex func
+	return "0x" + strconv.FormatUint(fee.Uint64(), 16), nil
+}
+
+func (s *feeService) getRepeatedFeeHistory(blockCount, oldestBlockInt int64, rewardPercentiles []string, fee string) *domain.FeeHistory {
+	feeHistory := &domain.FeeHistory{
+		BaseFeePerGas: make([]string, blockCount+1),
+		GasUsedRatio:  make([]float64, blockCount),
+		OldestBlock:   fmt.Sprintf("0x%x", oldestBlockInt),
+	}
+
+	for i := int64(0); i < blockCount; i++ {
+		feeHistory.BaseFeePerGas[i] = fee
+		feeHistory.GasUsedRatio[i] = defaultUsedGasRatio
+	}
+
+	feeHistory.BaseFeePerGas[blockCount] = fee
+
+	// Check if there are any reward percentiles
+	if len(rewardPercentiles) > 0 {
+		feeHistory.Reward = s.feeHistoryRewards(blockCount, len(rewardPercentiles))
+	}
+
+	return feeHistory
+}
+
+// feeHistoryRewards reports the configured tip for every block and percentile,
+// since every transaction in a Hedera block pays the same gas price.
+func (s *feeService) feeHistoryRewards(blockCount int64, percentiles int) [][]string {
+	reward := s.priorityFee()
+
+	rewards := make([][]string, blockCount)
+	for i := range rewards {
+		rewards[i] = make([]string, percentiles)
+		for j := range rewards[i] {
+			rewards[i][j] = reward
+		}
+	}
+	return rewards
+}
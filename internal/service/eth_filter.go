@@ -3,6 +3,9 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/LimeChain/Hederium/internal/domain"
 	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
@@ -12,12 +15,15 @@ import (
 )
 
 type FilterServicer interface {
-	NewFilter(fromBlock, toBlock string, address, topics []string) (*string, *domain.RPCError)
-	NewBlockFilter() (*string, *domain.RPCError)
-	UninstallFilter(filterID string) (interface{}, *domain.RPCError)
-	NewPendingTransactionFilter() (interface{}, *domain.RPCError)
-	GetFilterLogs(filterID string) ([]domain.Log, *domain.RPCError)
-	GetFilterChanges(filterID string) (interface{}, *domain.RPCError)
+	NewFilter(ctx context.Context, fromBlock, toBlock string, address []string, topics domain.TopicFilter) (*string, *domain.RPCError)
+	NewBlockFilter(ctx context.Context) (*string, *domain.RPCError)
+	UninstallFilter(ctx context.Context, filterID string) (interface{}, *domain.RPCError)
+	NewPendingTransactionFilter(ctx context.Context) (*string, *domain.RPCError)
+	GetFilterLogs(ctx context.Context, filterID string) ([]domain.Log, *domain.RPCError)
+	GetFilterChanges(ctx context.Context, filterID string) (interface{}, *domain.RPCError)
+	FollowBlockPoller(poller *BlockPoller)
+	Enabled() bool
+	SetEnabled(enabled bool)
 }
 
 type filterService struct {
@@ -25,20 +31,49 @@ type filterService struct {
 	cacheService  cache.CacheService
 	logger        *zap.Logger
 	commonService CommonService
+	enabled       atomic.Bool
+	filterTTL     time.Duration
+	// feed answers block and pending transaction filters, nil unless the
+	// block poller is enabled.
+	feed *blockFeed
 }
 
-func NewFilterService(mirrorClient infrahedera.MirrorNodeClient, cacheService cache.CacheService, logger *zap.Logger, commonService CommonService) FilterServicer {
-	return &filterService{
+// NewFilterService creates the filter subsystem. Filters live in the cache and
+// expire after filterTTL without being polled; a zero TTL uses DefaultFilterTTL.
+func NewFilterService(mirrorClient infrahedera.MirrorNodeClient, cacheService cache.CacheService, logger *zap.Logger, commonService CommonService, enabled bool, filterTTL time.Duration) FilterServicer {
+	if filterTTL <= 0 {
+		filterTTL = DefaultFilterTTL
+	}
+
+	s := &filterService{
 		mirrorClient:  mirrorClient,
 		cacheService:  cacheService,
 		logger:        logger,
 		commonService: commonService,
+		filterTTL:     filterTTL,
 	}
+	s.enabled.Store(enabled)
+	return s
+}
+
+// Enabled reports whether the filter API is currently enabled.
+func (s *filterService) Enabled() bool {
+	return s.enabled.Load()
+}
+
+// SetEnabled turns the filter API on or off at runtime.
+func (s *filterService) SetEnabled(enabled bool) {
+	s.enabled.Store(enabled)
 }
 
-func (s *filterService) createFilter(filterType, fromBlock, toBlock, blockAtCreation string, address, topics []string) *string {
-	ctx := context.Background()
+// FollowBlockPoller answers block and pending transaction filters from the
+// blocks poller sees, falling back to the mirror node for filters polled too
+// rarely for the blocks they miss to be kept, or while the poller is stale.
+func (s *filterService) FollowBlockPoller(poller *BlockPoller) {
+	s.feed = newBlockFeed(s.mirrorClient, s.logger, poller)
+}
 
+func (s *filterService) createFilter(ctx context.Context, filterType, fromBlock, toBlock, blockAtCreation string, address []string, topics domain.TopicFilter) *string {
 	filterId := fmt.Sprintf("0x%s", randstr.Hex(32))
 
 	filter := &domain.Filter{
@@ -54,34 +89,42 @@ func (s *filterService) createFilter(filterType, fromBlock, toBlock, blockAtCrea
 
 	s.logger.Info("Saving:", zap.Any("filter", filter))
 
-	cacheKey := fmt.Sprintf("filterId_%s", filterId)
-	if err := s.cacheService.Set(ctx, cacheKey, filter, DefaultExpiration); err != nil {
-		s.logger.Error("failed to set filter id to cache", zap.Error(err))
-	}
+	s.saveFilter(ctx, filter)
 
 	s.logger.Info("created filter with id and type", zap.String("id", filterId), zap.String("type", filterType))
 
 	return &filterId
 }
 
-// TODO: Check it in config file
+// saveFilter stores the filter and restarts its idle timer, so filters that
+// are not polled within filterTTL are dropped by the cache.
+func (s *filterService) saveFilter(ctx context.Context, filter *domain.Filter) {
+	cacheKey := fmt.Sprintf("filterId_%s", filter.ID)
+	if err := s.cacheService.Set(ctx, cacheKey, filter, s.filterTTL); err != nil {
+		s.logger.Error("failed to set filter id to cache", zap.Error(err))
+	}
+}
+
 func (s *filterService) requireFilterEnabled() error {
+	if !s.enabled.Load() {
+		return ErrFiltersDisabled
+	}
 	return nil
 }
 
-func (s *filterService) NewFilter(fromBlock, toBlock string, address, topics []string) (*string, *domain.RPCError) {
-	s.logger.Info("creating new filter", zap.String("fromBlock", fromBlock), zap.String("toBlock", toBlock), zap.Any("address", address), zap.Strings("topics", topics))
+func (s *filterService) NewFilter(ctx context.Context, fromBlock, toBlock string, address []string, topics domain.TopicFilter) (*string, *domain.RPCError) {
+	s.logger.Info("creating new filter", zap.String("fromBlock", fromBlock), zap.String("toBlock", toBlock), zap.Any("address", address), zap.Any("topics", topics))
 
 	if err := s.requireFilterEnabled(); err != nil {
 		return nil, domain.NewUnsupportedMethodError("eth_newFilter")
 	}
 
-	if err := s.commonService.ValidateBlockRange(fromBlock, toBlock); err != nil {
+	if err := s.commonService.ValidateBlockRange(ctx, fromBlock, toBlock); err != nil {
 		return nil, domain.NewInvalidBlockRangeError()
 	}
 
-	if fromBlock == "latest" {
-		fromBlockNum, errRpc := s.commonService.GetBlockNumberByNumberOrTag(fromBlock)
+	if blockTagIsLatestOrPending(&fromBlock) {
+		fromBlockNum, errRpc := s.commonService.GetBlockNumberByNumberOrTag(ctx, fromBlock)
 		if errRpc != nil {
 			return nil, errRpc
 		}
@@ -89,31 +132,48 @@ func (s *filterService) NewFilter(fromBlock, toBlock string, address, topics []s
 		fromBlock = fmt.Sprintf("0x%x", fromBlockNum)
 	}
 
-	filterId := s.createFilter("log", fromBlock, toBlock, "", address, topics)
+	filterId := s.createFilter(ctx, "log", fromBlock, toBlock, "", address, topics)
 
 	return filterId, nil
 }
 
-func (s *filterService) NewBlockFilter() (*string, *domain.RPCError) {
+func (s *filterService) NewBlockFilter(ctx context.Context) (*string, *domain.RPCError) {
 	if err := s.requireFilterEnabled(); err != nil {
-		return nil, domain.NewUnsupportedMethodError("eth_newFilter")
+		return nil, domain.NewUnsupportedMethodError("eth_newBlockFilter")
 	}
 
-	blockAtCreation, errRpc := s.commonService.GetBlockNumberByNumberOrTag("latest")
+	blockAtCreation, errRpc := s.commonService.GetBlockNumberByNumberOrTag(ctx, "latest")
 	if errRpc != nil {
 		return nil, errRpc
 	}
 
-	filterId := s.createFilter("new_block", "", "", fmt.Sprintf("0x%x", blockAtCreation), nil, nil)
+	filterId := s.createFilter(ctx, "new_block", "", "", fmt.Sprintf("0x%x", blockAtCreation), nil, nil)
 
 	return filterId, nil
 }
 
-func (s *filterService) UninstallFilter(filterID string) (interface{}, *domain.RPCError) {
-	ctx := context.Background()
+// NewPendingTransactionFilter creates a filter returning the hashes of the
+// transactions recorded since it was last polled. Hedera has no mempool, so
+// transactions are reported once they are in a block.
+func (s *filterService) NewPendingTransactionFilter(ctx context.Context) (*string, *domain.RPCError) {
+	if err := s.requireFilterEnabled(); err != nil {
+		return nil, domain.NewUnsupportedMethodError("eth_newPendingTransactionFilter")
+	}
+
+	blockAtCreation, errRpc := s.commonService.GetBlockNumberByNumberOrTag(ctx, "latest")
+	if errRpc != nil {
+		return nil, errRpc
+	}
+
+	filterId := s.createFilter(ctx, "new_pending_transaction", "", "", fmt.Sprintf("0x%x", blockAtCreation), nil, nil)
+
+	return filterId, nil
+}
+
+func (s *filterService) UninstallFilter(ctx context.Context, filterID string) (interface{}, *domain.RPCError) {
 
 	if err := s.requireFilterEnabled(); err != nil {
-		return false, domain.NewUnsupportedMethodError("eth_newFilter")
+		return false, domain.NewUnsupportedMethodError("eth_uninstallFilter")
 	}
 
 	cacheKey := fmt.Sprintf("filterId_%s", filterID)
@@ -131,14 +191,12 @@ func (s *filterService) UninstallFilter(filterID string) (interface{}, *domain.R
 	return true, nil
 }
 
-func (s *filterService) NewPendingTransactionFilter() (interface{}, *domain.RPCError) {
-	s.logger.Info("creating new pending transaction filter")
-	return nil, domain.NewUnsupportedJSONRPCMethodError()
-}
-
-func (s *filterService) GetFilterLogs(filterID string) ([]domain.Log, *domain.RPCError) {
+func (s *filterService) GetFilterLogs(ctx context.Context, filterID string) ([]domain.Log, *domain.RPCError) {
 	s.logger.Info("getting filter logs", zap.String("filterID", filterID))
-	ctx := context.Background()
+
+	if err := s.requireFilterEnabled(); err != nil {
+		return nil, domain.NewUnsupportedMethodError("eth_getFilterLogs")
+	}
 
 	cacheKey := fmt.Sprintf("filterId_%s", filterID)
 	var filter domain.Filter
@@ -159,113 +217,193 @@ func (s *filterService) GetFilterLogs(filterID string) ([]domain.Log, *domain.RP
 		Topics:    filter.Topics,
 	}
 
-	logs, errRpc := s.commonService.GetLogs(logParams)
+	logs, errRpc := s.commonService.GetLogs(ctx, logParams)
 	if errRpc != nil {
 		return nil, errRpc
 	}
 
-	if err := s.cacheService.Set(ctx, cacheKey, filter, DefaultExpiration); err != nil {
-		s.logger.Error("failed to set filter id to cache", zap.Error(err))
-	}
+	s.saveFilter(ctx, &filter)
 
 	return logs, nil
 }
 
-func (s *filterService) GetFilterChanges(filterID string) (interface{}, *domain.RPCError) {
+func (s *filterService) GetFilterChanges(ctx context.Context, filterID string) (interface{}, *domain.RPCError) {
 	s.logger.Info("getting filter changes", zap.String("filterID", filterID))
 
 	if err := s.requireFilterEnabled(); err != nil {
-		return nil, domain.NewUnsupportedMethodError("eth_getFiltetChanges")
+		return nil, domain.NewUnsupportedMethodError("eth_getFilterChanges")
 	}
 
-	ctx := context.Background()
-
 	cacheKey := fmt.Sprintf("filterId_%s", filterID)
 	var filter domain.Filter
 	if err := s.cacheService.Get(ctx, cacheKey, &filter); err != nil {
 		return nil, domain.NewFilterNotFoundError()
 	}
 
-	var blockResult []string
 	var result interface{}
 
 	switch filter.Type {
 	case "log":
+		// Only return logs that were not part of a previous poll
+		fromBlock := filter.FromBlock
+		if filter.LastQueried != "" {
+			fromBlock = filter.LastQueried
+		}
+
+		if fromBlock == "" {
+			fromBlock = domain.BlockTagLatest
+		}
+
+		// The poll queries up to a fixed block, so the next one resumes
+		// right after it whether or not logs were found. Past the toBlock
+		// of the filter, or with no new blocks, there is nothing to query.
+		toBlock, errRpc := s.logFilterToBlock(ctx, filter.ToBlock)
+		if errRpc != nil {
+			return nil, errRpc
+		}
+		if s.isPastToBlock(fromBlock, hexify(toBlock)) {
+			s.saveFilter(ctx, &filter)
+			return []domain.Log{}, nil
+		}
+
 		logParams := domain.LogParams{
-			FromBlock: filter.FromBlock,
-			ToBlock:   filter.ToBlock,
+			FromBlock: fromBlock,
+			ToBlock:   hexify(toBlock),
 			Address:   filter.Address,
 			Topics:    filter.Topics,
 		}
 
-		logResult, errRpc := s.commonService.GetLogs(logParams)
+		logResult, errRpc := s.commonService.GetLogs(ctx, logParams)
 		if errRpc != nil {
 			return nil, errRpc
 		}
 
-		var latestBlock int64
-		var err error
-		if len(logResult) > 0 {
-			latestBlock, err = HexToDec(logResult[len(logResult)-1].BlockNumber)
-			if err != nil {
-				s.logger.Error("failed to convert block number to int64", zap.Error(err))
-				return nil, domain.NewInternalError("unexpected error")
-			}
-		} else {
-			latestBlock, errRpc = s.commonService.GetBlockNumberByNumberOrTag("latest")
-			if errRpc != nil {
-				return nil, errRpc
+		filter.LastQueried = hexify(toBlock + 1)
+
+		result = logResult
+	case "new_block", "new_pending_transaction":
+		hashes, errRpc := s.getBlockChanges(ctx, &filter)
+		if errRpc != nil {
+			return nil, errRpc
+		}
+
+		result = hashes
+
+	default:
+		return nil, domain.NewUnsupportedMethodError("eth_getFilterChanges")
+	}
+
+	s.saveFilter(ctx, &filter)
+
+	return result, nil
+}
+
+// getBlockChanges returns the hashes of the blocks, or of the transactions of
+// the blocks, after the last one a block or pending transaction filter
+// returned, and moves the filter past them.
+func (s *filterService) getBlockChanges(ctx context.Context, filter *domain.Filter) ([]string, *domain.RPCError) {
+	blockNum := filter.BlockAtCreation
+	if filter.LastQueried != "" {
+		blockNum = filter.LastQueried
+	}
+	transactions := filter.Type == "new_pending_transaction"
+
+	hashes := []string{}
+	if s.feed != nil {
+		if number, err := HexToDec(blockNum); err == nil {
+			if blocks, ok := s.feed.since(number); ok {
+				for _, block := range blocks {
+					if transactions {
+						hashes = append(hashes, block.transactions...)
+					} else {
+						hashes = append(hashes, block.hash)
+					}
+				}
+				if len(blocks) > 0 {
+					filter.LastQueried = fmt.Sprintf("0x%x", blocks[len(blocks)-1].number)
+				}
+				return hashes, nil
 			}
 		}
-		latestBlock++
-		filter.LastQueried = fmt.Sprintf("0x%x", latestBlock)
+	}
 
-		result = logResult
-	case "new_block":
+	blocks, err := s.mirrorClient.GetBlocks(ctx, blockNum)
+	if err != nil {
+		s.logger.Error("failed to get blocks from mirror node", zap.Error(err))
+		return nil, domain.NewInternalError("unexpected error")
+	}
 
-		var blockNum string
-		if filter.LastQueried != "" {
-			blockNum = filter.LastQueried
-		} else if filter.BlockAtCreation != "" {
-			blockNum = filter.BlockAtCreation
+	var latestBlock int64
+	var errRpc *domain.RPCError
+	if len(blocks) > 0 {
+		if blockNumFloat, ok := blocks[len(blocks)-1]["number"].(float64); ok {
+			latestBlock = int64(blockNumFloat)
+		} else {
+			s.logger.Error("failed to convert block number to int64")
+			return nil, domain.NewInternalError("unexpected error")
 		}
+	} else {
+		latestBlock, errRpc = s.commonService.GetBlockNumberByNumberOrTag(ctx, "latest")
+		if errRpc != nil {
+			return nil, errRpc
+		}
+	}
 
-		blocks, err := s.mirrorClient.GetBlocks(blockNum)
+	for _, raw := range blocks {
+		block, timestamp, ok := parseFeedBlock(raw)
+		if !ok {
+			s.logger.Error("failed to read block listed by mirror node", zap.Any("block", raw))
+			return nil, domain.NewInternalError("unexpected error")
+		}
+		if !transactions {
+			hashes = append(hashes, block.hash)
+			continue
+		}
+		blockTransactions, err := blockTransactionHashes(ctx, s.mirrorClient, timestamp)
 		if err != nil {
-			s.logger.Error("failed to get blocks from mirror node", zap.Error(err))
+			s.logger.Error("failed to get block transactions from mirror node", zap.Error(err))
 			return nil, domain.NewInternalError("unexpected error")
 		}
+		hashes = append(hashes, blockTransactions...)
+	}
+	filter.LastQueried = fmt.Sprintf("0x%x", latestBlock)
 
-		var latestBlock int64
-		var errRpc *domain.RPCError
-		if len(blocks) > 0 {
+	return hashes, nil
+}
 
-			if blockNumFloat, ok := blocks[len(blocks)-1]["number"].(float64); ok {
-				latestBlock = int64(blockNumFloat)
-			} else {
-				s.logger.Error("failed to convert block number to int64")
-				return nil, domain.NewInternalError("unexpected error")
-			}
-		} else {
-			latestBlock, errRpc = s.commonService.GetBlockNumberByNumberOrTag("latest")
-			if errRpc != nil {
-				return nil, errRpc
-			}
-		}
-		filter.LastQueried = fmt.Sprintf("0x%x", latestBlock)
-		for _, b := range blocks {
-			blockResult = append(blockResult, b["hash"].(string))
-		}
+// logFilterToBlock returns the block a poll of a log filter with toBlock
+// queries up to: the latest block, or toBlock when that comes before it.
+func (s *filterService) logFilterToBlock(ctx context.Context, toBlock string) (int64, *domain.RPCError) {
+	latestBlock, errRpc := s.commonService.GetBlockNumberByNumberOrTag(ctx, domain.BlockTagLatest)
+	if errRpc != nil {
+		return 0, errRpc
+	}
+	if toBlock == "" || blockTagIsLatestOrPending(&toBlock) {
+		return latestBlock, nil
+	}
 
-		result = blockResult
+	toBlockNum, errRpc := s.commonService.GetBlockNumberByNumberOrTag(ctx, toBlock)
+	if errRpc != nil {
+		return 0, errRpc
+	}
+	return min(toBlockNum, latestBlock), nil
+}
 
-	default:
-		return nil, domain.NewUnsupportedMethodError("eth_getFilterChanges")
+// isPastToBlock reports whether fromBlock is beyond a fixed (numeric) toBlock,
+// meaning the filter range has been fully consumed.
+func (s *filterService) isPastToBlock(fromBlock, toBlock string) bool {
+	if !strings.HasPrefix(toBlock, "0x") || !strings.HasPrefix(fromBlock, "0x") {
+		return false
 	}
 
-	if err := s.cacheService.Set(ctx, cacheKey, filter, DefaultExpiration); err != nil {
-		s.logger.Error("failed to set filter id to cache", zap.Error(err))
+	from, err := HexToDec(fromBlock)
+	if err != nil {
+		return false
+	}
+	to, err := HexToDec(toBlock)
+	if err != nil {
+		return false
 	}
 
-	return result, nil
+	return from > to
 }
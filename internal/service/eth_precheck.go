@@ -1,7 +1,9 @@
 package service
 
 import (
+	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
@@ -17,15 +19,14 @@ const (
 	TxDataZeroCost            = 4
 	IstanbulTxDataNonZeroCost = 16
 	MaxGasPerSec              = 15000000
-	TinybarToWeibarCoef       = 10000000000
 	GasPriceTinyBarBuffer     = 1
 )
 
 type Precheck interface {
 	ParseTxIfNeeded(transaction interface{}) *util.Tx
 	Value(tx *util.Tx) error
-	SendRawTransactionCheck(parsedTx *util.Tx, networkGasPriceInWeiBars int64) error
-	VerifyAccount(tx *util.Tx) (*domain.AccountResponse, error)
+	SendRawTransactionCheck(ctx context.Context, parsedTx *util.Tx, networkGasPriceInWeiBars int64) error
+	VerifyAccount(ctx context.Context, tx *util.Tx) (*domain.AccountResponse, error)
 	Nonce(tx *util.Tx, accountInfoNonce int64) error
 	ChainID(tx *util.Tx) error
 	GasPrice(tx *util.Tx, networkGasPriceInWeiBars int64) error
@@ -33,7 +34,7 @@ type Precheck interface {
 	GasLimit(tx *util.Tx) error
 	CheckSize(transaction string) error
 	TransactionType(tx *util.Tx) error
-	ReceiverAccount(tx *util.Tx) error
+	ReceiverAccount(ctx context.Context, tx *util.Tx) error
 }
 
 type precheck struct {
@@ -50,6 +51,17 @@ func NewPrecheck(mClient infrahedera.MirrorNodeClient, logger *zap.Logger, chain
 	}
 }
 
+// precheckError returns the RPC error of a failed precheck, so wallets can tell
+// the failures apart. Any other failure, such as a mirror node error, becomes a
+// generic rejection.
+func precheckError(err error) *domain.RPCError {
+	var rpcErr *domain.RPCError
+	if errors.As(err, &rpcErr) {
+		return rpcErr
+	}
+	return domain.NewRPCError(domain.ServerError, "Transaction rejected by precheck")
+}
+
 func (p *precheck) ParseTxIfNeeded(transaction interface{}) *util.Tx {
 	if txStr, ok := transaction.(string); ok {
 		tx, err := ParseTransaction(txStr)
@@ -66,13 +78,13 @@ func (p *precheck) ParseTxIfNeeded(transaction interface{}) *util.Tx {
 
 func (p *precheck) Value(tx *util.Tx) error {
 	value := tx.Value
-	if (value.Cmp(big.NewInt(0)) > 0 && value.Cmp(big.NewInt(TinybarToWeibarCoef)) < 0) || value.Cmp(big.NewInt(0)) < 0 {
-		return fmt.Errorf("value too low")
+	if (value.Cmp(big.NewInt(0)) > 0 && value.Cmp(big.NewInt(domain.WeibarsPerTinybar)) < 0) || value.Cmp(big.NewInt(0)) < 0 {
+		return domain.NewValueTooLowError()
 	}
 	return nil
 }
 
-func (p *precheck) SendRawTransactionCheck(parsedTx *util.Tx, networkGasPriceInWeiBars int64) error {
+func (p *precheck) SendRawTransactionCheck(ctx context.Context, parsedTx *util.Tx, networkGasPriceInWeiBars int64) error {
 
 	if err := p.TransactionType(parsedTx); err != nil {
 		return err
@@ -81,7 +93,7 @@ func (p *precheck) SendRawTransactionCheck(parsedTx *util.Tx, networkGasPriceInW
 		return err
 	}
 
-	mirrorAccountInfo, err := p.VerifyAccount(parsedTx)
+	mirrorAccountInfo, err := p.VerifyAccount(ctx, parsedTx)
 	if err != nil {
 		return err
 	}
@@ -101,20 +113,20 @@ func (p *precheck) SendRawTransactionCheck(parsedTx *util.Tx, networkGasPriceInW
 	if err := p.Balance(parsedTx, mirrorAccountInfo); err != nil {
 		return err
 	}
-	if err := p.ReceiverAccount(parsedTx); err != nil {
+	if err := p.ReceiverAccount(ctx, parsedTx); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (p *precheck) VerifyAccount(tx *util.Tx) (*domain.AccountResponse, error) {
+func (p *precheck) VerifyAccount(ctx context.Context, tx *util.Tx) (*domain.AccountResponse, error) {
 	from, err := tx.Sender()
 	if err != nil {
 		return nil, err
 	}
 
-	accountInfo, err := p.mClient.GetAccountById(from)
+	accountInfo, err := p.mClient.GetAccountById(ctx, from)
 	if err != nil {
 		p.logger.Debug("Failed to retrieve address account details",
 			zap.String("address", from),
@@ -125,7 +137,7 @@ func (p *precheck) VerifyAccount(tx *util.Tx) (*domain.AccountResponse, error) {
 	if accountInfo == nil {
 		p.logger.Debug("Failed to retrieve address account details",
 			zap.String("address", from))
-		return nil, fmt.Errorf("resource not found: address '%s'", from)
+		return nil, domain.NewResourceNotFoundError(fmt.Sprintf("address '%s'", from))
 	}
 
 	return accountInfo, nil
@@ -136,7 +148,7 @@ func (p *precheck) Nonce(tx *util.Tx, accountInfoNonce int64) error {
 	p.logger.Debug("Nonce precheck", zap.Uint64("tx.nonce", tx.Nonce), zap.Int64("accountInfoNonce", accountInfoNonce))
 
 	if accountInfoNonce < 0 || uint64(accountInfoNonce) > tx.Nonce {
-		return fmt.Errorf("nonce too low: provided nonce: %d, current nonce: %d", tx.Nonce, accountInfoNonce)
+		return domain.NewNonceTooLowError(tx.Nonce, accountInfoNonce)
 	}
 
 	return nil
@@ -156,7 +168,7 @@ func (p *precheck) ChainID(tx *util.Tx) error {
 			zap.String("chainId", txChainID),
 			zap.String("expectedChainId", p.chainID))
 
-		return fmt.Errorf("unsupported chain id: got %s, want %s", tx.ChainID.String(), p.chainID)
+		return domain.NewUnsupportedChainIDError(txChainID, p.chainID)
 	}
 
 	return nil
@@ -188,7 +200,7 @@ func (p *precheck) GasPrice(tx *util.Tx, networkGasPriceInWeiBars int64) error {
 				zap.String("gasPrice", txGasPrice.String()),
 				zap.String("requiredGasPrice", networkGasPrice.String()))
 		}
-		return fmt.Errorf("gas price too low: got %s, required %s", txGasPrice.String(), networkGasPrice.String())
+		return domain.NewGasPriceTooLowError(txGasPrice.String(), networkGasPrice.String())
 	}
 
 	return nil
@@ -196,7 +208,7 @@ func (p *precheck) GasPrice(tx *util.Tx, networkGasPriceInWeiBars int64) error {
 
 func (p *precheck) Balance(tx *util.Tx, account *domain.AccountResponse) error {
 	if account == nil {
-		return fmt.Errorf("resource not found: tx.from '%s'", tx.Hash)
+		return domain.NewResourceNotFoundError(fmt.Sprintf("tx.from '%s'", tx.Hash))
 	}
 
 	var txGasPrice *big.Int
@@ -212,7 +224,7 @@ func (p *precheck) Balance(tx *util.Tx, account *domain.AccountResponse) error {
 	gasCost := new(big.Int).Mul(txGasPrice, gasLimit)
 	totalValue := new(big.Int).Add(tx.Value, gasCost)
 
-	balance := new(big.Int).Mul(big.NewInt(account.Balance.Balance), big.NewInt(TinybarToWeibarCoef))
+	balance := new(big.Int).Mul(big.NewInt(account.Balance.Balance), big.NewInt(domain.WeibarsPerTinybar))
 
 	if balance.Cmp(totalValue) < 0 {
 		if p.logger.Core().Enabled(zap.DebugLevel) {
@@ -221,7 +233,7 @@ func (p *precheck) Balance(tx *util.Tx, account *domain.AccountResponse) error {
 				zap.String("totalValue", totalValue.String()),
 				zap.String("accountBalance", balance.String()))
 		}
-		return fmt.Errorf("insufficient account balance")
+		return domain.NewInsufficientFundsError()
 	}
 
 	return nil
@@ -261,7 +273,7 @@ func (p *precheck) GasLimit(tx *util.Tx) error {
 				zap.Uint64("gasLimit", gasLimit),
 				zap.Int("maxGasPerSec", MaxGasPerSec))
 		}
-		return fmt.Errorf("gas limit too high: got %d, max %d", gasLimit, MaxGasPerSec)
+		return domain.NewGasLimitTooHighError(gasLimit, MaxGasPerSec)
 	} else if gasLimit < intrinsicGasCost {
 		if p.logger.Core().Enabled(zap.DebugLevel) {
 			p.logger.Debug("Gas limit too low",
@@ -269,7 +281,7 @@ func (p *precheck) GasLimit(tx *util.Tx) error {
 				zap.Uint64("gasLimit", gasLimit),
 				zap.Uint64("intrinsicGasCost", intrinsicGasCost))
 		}
-		return fmt.Errorf("gas limit too low: got %d, required %d", gasLimit, intrinsicGasCost)
+		return domain.NewIntrinsicGasTooLowError(gasLimit, intrinsicGasCost)
 	}
 
 	return nil
@@ -280,12 +292,12 @@ func (p *precheck) CheckSize(transaction string) error {
 
 	transactionBytes, err := hex.DecodeString(transaction)
 	if err != nil {
-		return fmt.Errorf("invalid transaction hex: %v", err)
+		return domain.NewInvalidParamsError(fmt.Sprintf("Invalid transaction hex: %v", err))
 	}
 
 	const transactionSizeLimit = 128 * 1024 // 128KB
 	if len(transactionBytes) > transactionSizeLimit {
-		return fmt.Errorf("transaction size too big: got %d, max %d", len(transactionBytes), transactionSizeLimit)
+		return domain.NewOversizedDataError(len(transactionBytes), transactionSizeLimit)
 	}
 
 	return nil
@@ -298,20 +310,23 @@ func (p *precheck) TransactionType(tx *util.Tx) error {
 				zap.String("transaction", tx.Hash),
 				zap.Uint8("type", tx.Type))
 		}
-		return fmt.Errorf("unsupported transaction type: %d", tx.Type)
+		return domain.NewUnsupportedTransactionTypeError(tx.Type)
 	}
 	return nil
 }
 
-func (p *precheck) ReceiverAccount(tx *util.Tx) error {
+func (p *precheck) ReceiverAccount(ctx context.Context, tx *util.Tx) error {
 	if tx.To != "" {
-		verifyAccount := p.mClient.GetAccount(tx.To, "")
-		if verifyAccount == nil {
+		verifyAccount, err := p.mClient.GetAccount(ctx, tx.To, "")
+		if isNotFound(err) {
 			return nil
 		}
+		if err != nil {
+			return mirrorError(err, "Failed to get receiver account")
+		}
 
 		if account, ok := verifyAccount.(*domain.AccountResponse); ok && account.ReceiverSigRequired {
-			return fmt.Errorf("receiver signature required")
+			return domain.NewReceiverSignatureRequiredError()
 		}
 	}
 	return nil
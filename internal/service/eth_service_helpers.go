@@ -1,35 +1,40 @@
 package service
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
-	"regexp"
 	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
+	infrahedera "github.com/LimeChain/Hederium/internal/infrastructure/hedera"
 	"github.com/LimeChain/Hederium/internal/util"
 	"go.uber.org/zap"
 )
 
-// GetFeeWeibars retrieves the current network fees in tinybars from the mirror client
+// GetFeeWeibars returns the network fees of s in weibars; see feeWeibars.
+func GetFeeWeibars(ctx context.Context, s *EthService, params ...string) (*big.Int, error) {
+	return s.feeWeibars(ctx, params...)
+}
+
+// feeWeibars retrieves the current network fees in tinybars from the mirror client
 // and converts them to weibars (1 tinybar = 10^10 weibars).
 //
 // Parameters:
-//   - s: Pointer to EthService instance containing the mirror client
 //   - params: Optional parameters for timestamp and order
 //
 // Returns:
 //   - *big.Int: The fee amount in weibars, or nil if there was an error
-//   - map[string]interface{}: Error details if any occurred, nil otherwise
-//     The error map contains:
-//   - "code": -32000 for failed requests
-//   - "message": Description of the error
-func GetFeeWeibars(s *EthService, params ...string) (*big.Int, error) {
+//   - error: The error of the mirror node client, wrapped, so that callers
+//     match it with errors.Is and translate it with serviceError
+func (s *ethCore) feeWeibars(ctx context.Context, params ...string) (*big.Int, error) {
 	// Default values
 	timestampTo := ""
 	order := ""
@@ -41,19 +46,23 @@ func GetFeeWeibars(s *EthService, params ...string) (*big.Int, error) {
 		order = params[1]
 	}
 
-	gasTinybars, err := s.mClient.GetNetworkFees(timestampTo, order)
+	gasTinybars, err := s.mClient.GetNetworkFees(ctx, timestampTo, order)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch gas price: %s", err.Error())
+		return nil, fmt.Errorf("failed to fetch gas price: %w", err)
 	}
 
-	// Convert tinybars to weibars
-	weibars := big.NewInt(gasTinybars).
-		Mul(big.NewInt(gasTinybars), big.NewInt(10000000000)) // 10^10 conversion factor
+	weibars := new(big.Int).Mul(big.NewInt(gasTinybars), big.NewInt(domain.WeibarsPerTinybar))
 
 	return weibars, nil
 }
 
-func ProcessBlock(s *EthService, block *domain.BlockResponse, showDetails bool) (*domain.Block, error) {
+// ProcessBlock converts a mirror node block to an Ethereum block; see
+// processBlock.
+func ProcessBlock(ctx context.Context, s *EthService, block *domain.BlockResponse, showDetails bool) (*domain.Block, error) {
+	return s.processBlock(ctx, block, showDetails)
+}
+
+func (s *ethCore) processBlock(ctx context.Context, block *domain.BlockResponse, showDetails bool) (*domain.Block, error) {
 	// Create a new Block instance with default values
 	ethBlock := domain.NewBlock()
 
@@ -76,7 +85,7 @@ func ProcessBlock(s *EthService, block *domain.BlockResponse, showDetails bool)
 
 	ethBlock.Number = &hexNumber
 	ethBlock.GasUsed = hexGasUsed
-	ethBlock.GasLimit = hexify(15000000) // Hedera's default gas limit
+	ethBlock.GasLimit = hexify(int64(s.blockGasLimit()))
 	ethBlock.Hash = &trimmedHash
 	ethBlock.LogsBloom = block.LogsBloom
 	ethBlock.TransactionsRoot = &trimmedHash
@@ -84,24 +93,34 @@ func ProcessBlock(s *EthService, block *domain.BlockResponse, showDetails bool)
 	ethBlock.Timestamp = hexTimestamp
 	ethBlock.Size = hexSize
 
-	contractResults := s.mClient.GetContractResults(block.Timestamp)
+	contractResults, err := s.mClient.GetContractResults(ctx, block.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []string
+	var blooms []string
 	for _, contractResult := range contractResults {
 		if contractResult.Result == "WRONG_NONCE" || contractResult.Result == "INVALID_ACCOUNT_ID" {
 			continue
 		}
+		addresses = append(addresses, contractResult.To, contractResult.From)
+		blooms = append(blooms, contractResult.Bloom)
+	}
+	// Blocks without a mirror node bloom get the union of the blooms of their
+	// transactions
+	if isMissingBloom(ethBlock.LogsBloom) {
+		ethBlock.LogsBloom = mergeBlooms(blooms)
+	}
+	evmAddresses := s.resolveEvmAddresses(ctx, addresses)
 
-		to, err := s.resolveEvmAddress(contractResult.To)
-		if err != nil {
-			s.logger.Error("Failed to resolve to address", zap.Error(err))
-		}
-
-		from, err := s.resolveEvmAddress(contractResult.From)
-		if err != nil {
-			s.logger.Error("Failed to resolve from address", zap.Error(err))
+	for _, contractResult := range contractResults {
+		if contractResult.Result == "WRONG_NONCE" || contractResult.Result == "INVALID_ACCOUNT_ID" {
+			continue
 		}
 
-		contractResult.To = *to
-		contractResult.From = *from
+		contractResult.To = s.formatAddress(evmAddresses[contractResult.To])
+		contractResult.From = s.formatAddress(evmAddresses[contractResult.From])
 
 		if showDetails {
 			tx := ProcessTransaction(contractResult)
@@ -117,21 +136,12 @@ func ProcessBlock(s *EthService, block *domain.BlockResponse, showDetails bool)
 	return ethBlock, nil
 }
 
-// ProcessBlock converts a Hedera block response into an Ethereum- block format.
-// It takes a poincompatibleter to an EthService, a BlockResponse, and a boolean flag indicating whether
-// to include full transaction details.
-//
-// The function performs the following:
-// - Creates a new Ethereum block with default values
-// - Converts block numbers, gas values, and timestamps to hex format
-// - Trims hash values to standard Ethereum length (66 chars)
-// - Retrieves and filters contract results, excluding failed transactions
-// - Optionally processes full transaction details based on showDetails flag
-//
-// Returns:
-// - *domain.Block: The converted Ethereum-compatible block
-// - map[string]interface{}: Error information if any, nil on success
-func ProcessTransaction(contractResult domain.ContractResults) interface{} {
+// ProcessTransaction converts a contract result to the Ethereum transaction
+// of its type: a domain.Transaction, domain.Transaction2930 or
+// domain.Transaction1559. Every transaction the relay returns goes through it,
+// so blocks, lookups by hash and lookups by block and index format alike. From
+// and To are used as given; callers resolve them to EVM addresses first.
+func ProcessTransaction(contractResult domain.ContractResult) interface{} {
 	hexBlockNumber := hexify(contractResult.BlockNumber)
 	hexGasUsed := hexify(contractResult.GasUsed)
 	hexTransactionIndex := hexify(int64(contractResult.TransactionIndex))
@@ -175,8 +185,7 @@ func ProcessTransaction(contractResult domain.ContractResults) interface{} {
 	if contractResult.GasPrice != "" && contractResult.GasPrice != "0x" {
 		gasTinybars, err := HexToDec(contractResult.GasPrice)
 		if err == nil {
-			gasPriceInt := big.NewInt(gasTinybars).
-				Mul(big.NewInt(gasTinybars), big.NewInt(10000000000)) // 10^10 conversion factor
+			gasPriceInt := new(big.Int).Mul(big.NewInt(gasTinybars), big.NewInt(domain.WeibarsPerTinybar))
 			gasPrice = hexify(gasPriceInt.Int64())
 		}
 	}
@@ -196,7 +205,7 @@ func ProcessTransaction(contractResult domain.ContractResults) interface{} {
 		V:                hexV,
 		R:                hexR,
 		S:                hexS,
-		Type:             hexify(int64(contractResult.Type)),
+		Type:             hexify(int64(contractResult.TxType())),
 	}
 
 	// Handle chain ID
@@ -204,7 +213,7 @@ func ProcessTransaction(contractResult domain.ContractResults) interface{} {
 		commonFields.ChainId = &contractResult.ChainID
 	}
 
-	switch contractResult.Type {
+	switch contractResult.TxType() {
 	case 0:
 		return commonFields // Legacy transaction (EIP-155)
 	case 1:
@@ -224,117 +233,11 @@ func ProcessTransaction(contractResult domain.ContractResults) interface{} {
 	}
 }
 
-func (s *EthService) ProcessTransactionResponse(contractResult domain.ContractResultResponse) interface{} {
-	hexBlockNumber := hexify(contractResult.BlockNumber)
-	hexGasUsed := hexify(contractResult.GasUsed)
-	hexTransactionIndex := hexify(int64(contractResult.TransactionIndex))
-	hexValue := hexify(int64(contractResult.Amount))
-	hexV := hexify(int64(contractResult.V))
-
-	// Safe string slicing with length checks
-	hexR := contractResult.R
-	if len(contractResult.R) > 66 {
-		hexR = contractResult.R[:66]
-	}
-
-	hexS := contractResult.S
-	if len(contractResult.S) > 66 {
-		hexS = contractResult.S[:66]
-	}
-
-	hexNonce := hexify(contractResult.Nonce)
-
-	trimmedBlockHash := contractResult.BlockHash
-	if len(contractResult.BlockHash) > 66 {
-		trimmedBlockHash = contractResult.BlockHash[:66]
-	}
-
-	hexTo := contractResult.To
-	if len(contractResult.To) > 42 {
-		hexTo = contractResult.To[:42]
-	}
-
-	var toAddress string
-	evmAddressTo, err := s.resolveEvmAddress(hexTo)
-	if err != nil {
-		toAddress = hexTo
-	} else {
-		toAddress = *evmAddressTo
-	}
-
-	trimmedFrom := contractResult.From
-	if len(contractResult.From) > 42 {
-		trimmedFrom = contractResult.From[:42]
-	}
-
-	var fromAddress string
-	evmAddressFrom, err := s.resolveEvmAddress(trimmedFrom)
-	if err != nil {
-		fromAddress = trimmedFrom
-	} else {
-		fromAddress = *evmAddressFrom
-	}
-
-	trimmedHash := contractResult.Hash
-	if len(contractResult.Hash) > 66 {
-		trimmedHash = contractResult.Hash[:66]
-	}
-
-	// Ensure Type is not nil before dereferencing
-	var txType string
-	var transactionType int64
-	if contractResult.Type != nil {
-		txType = hexify(int64(*contractResult.Type))
-		transactionType = int64(*contractResult.Type)
-	} else {
-		txType = "0x0" // Default to legacy transaction type
-		transactionType = 0
-	}
-
-	commonFields := domain.Transaction{
-		BlockHash:        &trimmedBlockHash,
-		BlockNumber:      &hexBlockNumber,
-		From:             fromAddress,
-		Gas:              hexGasUsed,
-		GasPrice:         contractResult.GasPrice,
-		Hash:             trimmedHash,
-		Input:            contractResult.FunctionParameters,
-		Nonce:            hexNonce,
-		To:               &toAddress,
-		TransactionIndex: &hexTransactionIndex,
-		Value:            hexValue,
-		V:                hexV,
-		R:                hexR,
-		S:                hexS,
-		Type:             txType,
-	}
-
-	// Handle chain ID
-	if contractResult.ChainID != "0x" {
-		commonFields.ChainId = &contractResult.ChainID
-	}
-
-	switch transactionType {
-	case 0:
-		return commonFields // Legacy transaction (EIP-155)
-	case 1:
-		return domain.Transaction2930{
-			Transaction: commonFields,
-			AccessList:  []domain.AccessListEntry{}, // Empty access list for now
-		}
-	case 2:
-		return domain.Transaction1559{
-			Transaction:          commonFields,
-			AccessList:           []domain.AccessListEntry{}, // Empty access list for now
-			MaxPriorityFeePerGas: contractResult.MaxPriorityFeePerGas,
-			MaxFeePerGas:         contractResult.MaxFeePerGas,
-		}
-	default:
-		return commonFields // Default to legacy transaction
-	}
+func ParseTransactionCallObject(s *EthService, transaction interface{}) (*domain.TransactionCallObject, error) {
+	return s.parseTransactionCallObject(transaction)
 }
 
-func ParseTransactionCallObject(s *EthService, transaction interface{}) (*domain.TransactionCallObject, error) {
+func (s *ethCore) parseTransactionCallObject(transaction interface{}) (*domain.TransactionCallObject, error) {
 	var transactionCallObject domain.TransactionCallObject
 	jsonBytes, err := json.Marshal(transaction)
 	if err != nil {
@@ -351,6 +254,10 @@ func ParseTransactionCallObject(s *EthService, transaction interface{}) (*domain
 }
 
 func FormatTransactionCallObject(s *EthService, transactionCallObject *domain.TransactionCallObject, blockParam interface{}, estimate bool) (map[string]interface{}, error) {
+	return s.formatTransactionCallObject(transactionCallObject, blockParam, estimate)
+}
+
+func (s *ethCore) formatTransactionCallObject(transactionCallObject *domain.TransactionCallObject, blockParam interface{}, estimate bool) (map[string]interface{}, error) {
 	result := make(map[string]interface{})
 
 	// Handle value conversion if present
@@ -414,7 +321,7 @@ func FormatTransactionCallObject(s *EthService, transactionCallObject *domain.Tr
 	switch {
 	case transactionCallObject.Input != "" && transactionCallObject.Data != "":
 		if transactionCallObject.Input != transactionCallObject.Data {
-			return nil, fmt.Errorf("both input and data fields are present with different values")
+			return nil, ErrConflictingCallData
 		}
 		result["data"] = transactionCallObject.Input
 	case transactionCallObject.Input != "":
@@ -440,8 +347,6 @@ func FormatTransactionCallObject(s *EthService, transactionCallObject *domain.Tr
 }
 
 // Helper function to convert weibar hex to tinybar int
-const TINYBAR_TO_WEIBAR_COEF = 10000000000 // 10^10
-
 func WeibarHexToTinyBarInt(value string) (int64, error) {
 	// Handle "0x" case
 	if value == "0x" {
@@ -453,30 +358,30 @@ func WeibarHexToTinyBarInt(value string) (int64, error) {
 	if strings.HasPrefix(value, "0x") {
 		_, success := weiBigInt.SetString(value[2:], 16)
 		if !success {
-			return 0, fmt.Errorf("failed to parse hex value: %s", value)
+			return 0, fmt.Errorf("%w: %s", ErrInvalidHex, value)
 		}
 	} else {
 		_, success := weiBigInt.SetString(value, 10)
 		if !success {
-			return 0, fmt.Errorf("failed to parse value: %s", value)
+			return 0, fmt.Errorf("%w: %s", ErrInvalidNumber, value)
 		}
 	}
 
 	// Create coefficient as big.Int
-	coefBigInt := big.NewInt(TINYBAR_TO_WEIBAR_COEF)
+	coefBigInt := big.NewInt(domain.WeibarsPerTinybar)
 
 	// Calculate tinybar value
 	tinybarValue := new(big.Int).Div(weiBigInt, coefBigInt)
 
 	// Only round up if the value is significant enough
 	remainder := new(big.Int).Mod(weiBigInt, coefBigInt)
-	if tinybarValue.Cmp(big.NewInt(0)) == 0 && remainder.Cmp(big.NewInt(TINYBAR_TO_WEIBAR_COEF/2)) > 0 {
+	if tinybarValue.Cmp(big.NewInt(0)) == 0 && remainder.Cmp(big.NewInt(domain.WeibarsPerTinybar/2)) > 0 {
 		return 1, nil // Round up to the smallest unit of tinybar only if remainder is significant
 	}
 
 	// Convert to int64 and check if it fits
 	if !tinybarValue.IsInt64() {
-		return 0, fmt.Errorf("tinybar value exceeds int64 range: %s", tinybarValue.String())
+		return 0, fmt.Errorf("%w: tinybar value %s exceeds int64", ErrValueOutOfRange, tinybarValue.String())
 	}
 
 	return tinybarValue.Int64(), nil
@@ -505,124 +410,76 @@ func hexify(n int64) string {
 func HexToDec(hexStr string) (int64, error) {
 	dec, err := strconv.ParseInt(strings.TrimPrefix(hexStr, "0x"), 16, 64)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse hex value: %s", err)
+		return 0, fmt.Errorf("%w: %w", ErrInvalidHex, err)
 	}
 	return dec, nil
 }
 
-func (s *EthService) getFeeHistory(blockCount, newestBlockInt, latestBlockInt int64, rewardPercentiles []string) (*domain.FeeHistory, error) {
-	oldestBlockNumber := newestBlockInt - blockCount + 1
-	if oldestBlockNumber < 0 {
-		oldestBlockNumber = 0
-	}
-
-	feeHistory := &domain.FeeHistory{
-		BaseFeePerGas: []string{},
-		GasUsedRatio:  []float64{},
-		OldestBlock:   fmt.Sprintf("0x%x", oldestBlockNumber),
-	}
-
-	// Get fees from oldest to newest blocks
-	for blockNumber := oldestBlockNumber; blockNumber <= newestBlockInt; blockNumber++ {
-		fee, err := s.getFeeByBlockNumber(blockNumber)
-		if err != nil {
-			return nil, err
-		}
-
-		feeHistory.BaseFeePerGas = append(feeHistory.BaseFeePerGas, fee)
-		feeHistory.GasUsedRatio = append(feeHistory.GasUsedRatio, defaultUsedGasRatio)
-	}
+// isBlockHash reports whether block is a 32-byte block hash rather than a
+// block number or tag.
+func isBlockHash(block string) bool {
+	return len(block) == 66 && strings.HasPrefix(block, "0x")
+}
 
-	// Get the fee for the next block if the newest block is not the latest
-	var nextBaseFeePerGas string
-	var err error
-	if latestBlockInt > newestBlockInt {
-		nextBaseFeePerGas, err = s.getFeeByBlockNumber(newestBlockInt + 1)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		nextBaseFeePerGas = feeHistory.BaseFeePerGas[len(feeHistory.BaseFeePerGas)-1]
-	}
+func (s *ethCore) priorityFee() string {
+	return "0x" + strconv.FormatUint(s.config.MaxPriorityFeePerGas, 16)
+}
 
-	if nextBaseFeePerGas != "" {
-		feeHistory.BaseFeePerGas = append(feeHistory.BaseFeePerGas, nextBaseFeePerGas)
+// blockGasLimit returns the gas limit advertised in blocks.
+func (s *ethCore) blockGasLimit() uint64 {
+	if s.config.BlockGasLimit == 0 {
+		return DefaultBlockGasLimit
 	}
+	return s.config.BlockGasLimit
+}
 
-	// Check if there are any reward percentiles
-	if len(rewardPercentiles) > 0 {
-		rewards := make([][]string, blockCount)
-		for i := range rewards {
-			rewards[i] = make([]string, len(rewardPercentiles))
-			for j := range rewards[i] {
-				rewards[i][j] = "0x0" // Default reward
-			}
-		}
-		feeHistory.Reward = rewards
+// maxCallGas returns the most gas eth_call and eth_estimateGas accept.
+func (s *ethCore) maxCallGas() uint64 {
+	if s.config.MaxCallGas == 0 {
+		return s.blockGasLimit()
 	}
-
-	return feeHistory, nil
+	return s.config.MaxCallGas
 }
 
-func (s *EthService) getFeeByBlockNumber(blockNumber int64) (string, error) {
-	block := s.mClient.GetBlockByHashOrNumber(strconv.FormatInt(blockNumber, 10))
-	if block == nil {
-		return "", fmt.Errorf("failed to get block data")
+// checkCallGas rejects call objects that ask for more gas than maxCallGas.
+// Malformed gas values are left to formatTransactionCallObject.
+func (s *ethCore) checkCallGas(transactionCallObject *domain.TransactionCallObject) *domain.RPCError {
+	if transactionCallObject.Gas == "" || transactionCallObject.Gas == "0x" {
+		return nil
 	}
-
-	fee, err := GetFeeWeibars(s, block.Timestamp.To, "desc") // Hardcode desc to be sure that we get latest
+	gas, err := strconv.ParseUint(strings.TrimPrefix(transactionCallObject.Gas, "0x"), 16, 64)
 	if err != nil {
-		return "", err
-	}
-
-	// Implement dec to hex func
-	return "0x" + strconv.FormatUint(fee.Uint64(), 16), nil
-}
-
-func (s *EthService) getRepeatedFeeHistory(blockCount, oldestBlockInt int64, rewardPercentiles []string, fee string) *domain.FeeHistory {
-	feeHistory := &domain.FeeHistory{
-		BaseFeePerGas: make([]string, blockCount+1),
-		GasUsedRatio:  make([]float64, blockCount),
-		OldestBlock:   fmt.Sprintf("0x%x", oldestBlockInt),
+		return nil
 	}
-
-	for i := int64(0); i < blockCount; i++ {
-		feeHistory.BaseFeePerGas[i] = fee
-		feeHistory.GasUsedRatio[i] = defaultUsedGasRatio
+	if gasCap := s.maxCallGas(); gas > gasCap {
+		return domain.NewGasAllowanceExceededError(gasCap)
 	}
+	return nil
+}
 
-	feeHistory.BaseFeePerGas[blockCount] = fee
-
-	// Check if there are any reward percentiles
-	if len(rewardPercentiles) > 0 {
-		rewards := make([][]string, blockCount)
-		for i := range rewards {
-			rewards[i] = make([]string, len(rewardPercentiles))
-			for j := range rewards[i] {
-				rewards[i][j] = "0x0" // Default reward
-			}
-		}
-		feeHistory.Reward = rewards
+// formatAddress returns address in EIP-55 checksummed form when enabled.
+func (s *ethCore) formatAddress(address string) string {
+	if !s.config.ChecksumAddresses {
+		return address
 	}
-
-	return feeHistory
+	return util.ChecksumAddress(address)
 }
 
-func (s *EthService) resolveEvmAddress(address string) (*string, error) {
+func (s *ethCore) resolveEvmAddress(ctx context.Context, address string) (*string, error) {
 	if address == "" {
-		return &address, fmt.Errorf("address is empty")
+		return &address, ErrEmptyAddress
 	}
 
 	cacheKey := fmt.Sprintf("evm_address_%s", address)
 	var cachedResult string
-	if err := s.cacheService.Get(s.ctx, cacheKey, &cachedResult); err == nil && cachedResult != "" {
+	if err := s.cacheService.Get(ctx, cacheKey, &cachedResult); err == nil && cachedResult != "" {
 		s.logger.Info("EVM Address fetched from cache", zap.String("address", cachedResult))
 		return &cachedResult, nil
 	}
 
 	evmAddress := address
 
-	result, err := s.resolveAddressType(address)
+	result, err := s.resolveAddressType(ctx, address)
 	if err == nil {
 		switch data := result.(type) {
 		case *domain.AccountResponse:
@@ -632,14 +489,54 @@ func (s *EthService) resolveEvmAddress(address string) (*string, error) {
 		}
 	}
 
-	if err := s.cacheService.Set(s.ctx, cacheKey, evmAddress, DefaultExpiration); err != nil {
+	if err := s.cacheService.Set(ctx, cacheKey, evmAddress, s.config.CacheTTLs.Of(cache.ClassAccount)); err != nil {
 		s.logger.Debug("Failed to cache evm address", zap.Error(err))
 	}
 
 	return &evmAddress, nil
 }
 
-func (s *EthService) resolveAddressType(address string) (interface{}, error) {
+// resolveEvmAddresses resolves each distinct address once, running up to
+// addressResolveConcurrency lookups at a time, and maps every address to its
+// EVM address. Addresses that fail to resolve map to themselves.
+func (s *ethCore) resolveEvmAddresses(ctx context.Context, addresses []string) map[string]string {
+	resolved := make(map[string]string, len(addresses))
+	var pending []string
+	for _, address := range addresses {
+		if _, ok := resolved[address]; !ok {
+			resolved[address] = address
+			pending = append(pending, address)
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, addressResolveConcurrency)
+	for _, address := range pending {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			evmAddress, err := s.resolveEvmAddress(ctx, address)
+			if err != nil {
+				s.logger.Error("Failed to resolve address", zap.String("address", address), zap.Error(err))
+			}
+
+			mu.Lock()
+			resolved[address] = *evmAddress
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return resolved
+}
+
+func (s *ethCore) resolveAddressType(ctx context.Context, address string) (interface{}, error) {
 	res := make(chan interface{}, 1)
 
 	var wg sync.WaitGroup
@@ -655,12 +552,12 @@ func (s *EthService) resolveAddressType(address string) (interface{}, error) {
 	}
 
 	wg.Add(2)
-	go tryResolve(func() (interface{}, error) { return s.mClient.GetContractById(address) })
-	go tryResolve(func() (interface{}, error) { return s.mClient.GetAccountById(address) })
+	go tryResolve(func() (interface{}, error) { return s.mClient.GetContractById(ctx, address) })
+	go tryResolve(func() (interface{}, error) { return s.mClient.GetAccountById(ctx, address) })
 
 	if tokenId, err := checkTokenId(address); err == nil && tokenId != nil {
 		wg.Add(1)
-		go tryResolve(func() (interface{}, error) { return s.mClient.GetTokenById(*tokenId) })
+		go tryResolve(func() (interface{}, error) { return s.mClient.GetTokenById(ctx, *tokenId) })
 	}
 
 	go func() {
@@ -673,64 +570,38 @@ func (s *EthService) resolveAddressType(address string) (interface{}, error) {
 		return res, nil
 	}
 
-	return nil, fmt.Errorf("unable to identify address type")
+	return nil, fmt.Errorf("%w: %s", ErrUnknownAddress, address)
 }
 
 func checkTokenId(address string) (*string, error) {
 	if !strings.HasPrefix(address, "0x000000000000") {
-		return nil, fmt.Errorf("not a token address")
+		return nil, ErrNotTokenAddress
 	}
 
 	addressNum, err := HexToDec(address)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse hex value: %s", err.Error())
+		return nil, err
 	}
 
 	str := fmt.Sprintf("0.0.%d", addressNum)
 	return &str, nil
 }
 
-func (s *EthService) getTransactionByBlockAndIndex(queryParamas map[string]interface{}) (interface{}, error) {
-	transaction, err := s.mClient.GetContractResultWithRetry(queryParamas)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get transaction: %s", err.Error())
-	}
-
-	if transaction == nil {
-		return nil, nil
-	}
-
-	evmAddressTo, err := s.resolveEvmAddress(transaction.To)
-	if err != nil {
-		s.logger.Error("Failed to resolve to address", zap.Error(err))
-	}
-
-	evmAddressFrom, err := s.resolveEvmAddress(transaction.From)
-	if err != nil {
-		s.logger.Error("Failed to resolve from address", zap.Error(err))
-	}
-
-	transaction.To = *evmAddressTo
-	transaction.From = *evmAddressFrom
-
-	return ProcessTransaction(*transaction), nil
-}
-
 func ParseTransaction(rawTxHex string) (*util.Tx, error) {
 	if rawTxHex == "" {
-		return nil, errors.New("transaction data is empty")
+		return nil, fmt.Errorf("%w: transaction data is empty", ErrInvalidTransaction)
 	}
 
 	rawTxHex = strings.TrimPrefix(rawTxHex, "0x")
 
 	rawTx, err := hex.DecodeString(rawTxHex)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode hex string: %w", err)
+		return nil, fmt.Errorf("%w: failed to decode hex string: %w", ErrInvalidTransaction, err)
 	}
 
 	tx, err := util.DecodeTx(rawTx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode transaction: %w", err)
+		return nil, fmt.Errorf("%w: failed to decode transaction: %w", ErrInvalidTransaction, err)
 	}
 
 	return tx, nil
@@ -742,71 +613,24 @@ func AddBuffer(weibars *big.Int) *big.Int {
 	return weibars.Add(weibars, buffer)
 }
 
-// ProcessRawTransaction handles the processing of a raw Ethereum transaction for Hedera
-func (s *EthService) SendRawTransactionProcessor(transactionData []byte, tx *util.Tx, gasPrice int64) (*string, error) {
-	// Get the sender address for event tracking
-	fromAddress, err := tx.Sender()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get sender address: %w", err)
-	}
-
-	// Get the recipient address for event tracking
-	var toAddress string
-	if tx.To != "" {
-		toAddress = tx.To
+// estimateTransactionCost returns the expected cost of tx in tinybars: its gas
+// limit priced at the current network gas price. The network gas price is
+// derived from the HBAR/USD exchange rate, so the estimate follows it.
+func estimateTransactionCost(tx *util.Tx, gasPrice int64) int64 {
+	cost := new(big.Int).Mul(new(big.Int).SetUint64(tx.GasLimit), big.NewInt(gasPrice))
+	cost.Div(cost, big.NewInt(domain.WeibarsPerTinybar))
+	if !cost.IsInt64() {
+		return math.MaxInt64
 	}
+	return cost.Int64()
+}
 
-	// Send the raw transaction using the client's implementation
-	response, err := s.hClient.SendRawTransaction(transactionData, gasPrice, fromAddress)
+func (s *ethCore) getCurrentGasPriceForBlock(ctx context.Context, blockHash string) (string, error) {
+	block, err := s.mClient.GetBlockByHashOrNumber(ctx, blockHash)
 	if err != nil {
-		s.logger.Error("Failed to send raw transaction",
-			zap.Error(err),
-			zap.String("from", fromAddress),
-			zap.String("to", toAddress),
-			zap.Int64("gasPrice", gasPrice))
-		return nil, fmt.Errorf("failed to send raw transaction: %w", err)
-	}
-
-	subbmitedTransactionId := response.TransactionID
-
-	transactionIDRegex := regexp.MustCompile(`\d{1}\.\d{1}\.\d{1,10}\@\d{1,10}\.\d{1,9}`)
-	if !transactionIDRegex.MatchString(subbmitedTransactionId) {
-		s.logger.Error("Invalid transaction ID format", zap.String("transactionID", subbmitedTransactionId))
-		return nil, fmt.Errorf("invalid transaction ID format: %s", subbmitedTransactionId)
-	}
-
-	if subbmitedTransactionId != "" {
-		transactionId := ConvertTransactionID(subbmitedTransactionId)
-		contractResult := s.mClient.RepeatGetContractResult(transactionId, 10)
-		if contractResult == nil {
-			s.logger.Error("Failed to get contract result",
-				zap.String("transactionID", transactionId))
-			return nil, fmt.Errorf("no matching transaction record retrieved: %s", transactionId)
-		}
-
-		hash := contractResult.Hash
-
-		if hash == "" {
-			s.logger.Error("Transaction returned a null transaction hash:",
-				zap.String("transactionID", subbmitedTransactionId))
-			return nil, fmt.Errorf("no matching transaction record retrieved: %s", subbmitedTransactionId)
-		}
-
-		s.logger.Info("Transaction sent successfully",
-			zap.String("transactionID", hash),
-			zap.String("from", fromAddress),
-			zap.String("to", toAddress),
-			zap.Int64("gasPrice", gasPrice))
-
-		return &hash, nil
+		return "", err
 	}
-
-	return nil, fmt.Errorf("failed to send transaction: %w", err)
-}
-
-func (s *EthService) getCurrentGasPriceForBlock(blockHash string) (string, error) {
-	block := s.mClient.GetBlockByHashOrNumber(blockHash)
-	gasPriceForTimestamp, err := GetFeeWeibars(s, block.Timestamp.From)
+	gasPriceForTimestamp, err := s.feeWeibars(ctx, block.Timestamp.From)
 	if err != nil {
 		return "", err
 	}
@@ -829,15 +653,15 @@ func truncateString(s string, maxLength int) string {
 	return s
 }
 
-func (s *EthService) isLatestBlockRequest(blockNumberOrTag string, blockNumber int64) bool {
-	if blockNumberOrTag == domain.BlockTagLatest || blockNumberOrTag == domain.BlockTagPending {
+func (s *ethCore) isLatestBlockRequest(ctx context.Context, blockNumberOrTag string, blockNumber int64) bool {
+	if blockTagIsLatestOrPending(&blockNumberOrTag) {
 		return true
 	}
 	if blockNumberOrTag == domain.BlockTagEarliest {
 		return false
 	}
 
-	latestBlockInt, err := s.commonService.GetBlockNumberByNumberOrTag("latest")
+	latestBlockInt, err := s.commonService.GetBlockNumberByNumberOrTag(ctx, "latest")
 	if err != nil {
 		return false
 	}
@@ -845,27 +669,34 @@ func (s *EthService) isLatestBlockRequest(blockNumberOrTag string, blockNumber i
 	return blockNumber+10 > latestBlockInt
 }
 
-func (s *EthService) getContractAddressFromReceipt(receiptResponse domain.ContractResultResponse) string {
-	if len(receiptResponse.FunctionParameters) < 10 {
-		return receiptResponse.Address
+// isContractCreation reports whether the transaction deployed the contract it
+// executed, which the mirror node signals by listing it among the created contracts.
+func isContractCreation(receiptResponse domain.ContractResult) bool {
+	if receiptResponse.To == "" {
+		return true
 	}
-
-	parameters := receiptResponse.FunctionParameters[:10]
-	if _, isHTSCreation := HTSCreateFuncSelectors[parameters]; !isHTSCreation {
-		return receiptResponse.Address
+	if receiptResponse.ContractID == "" {
+		return false
 	}
 
-	if len(receiptResponse.CallResult) < 40 {
-		return receiptResponse.Address
+	for _, created := range receiptResponse.CreatedContractIDs {
+		if created == receiptResponse.ContractID {
+			return true
+		}
 	}
+	return false
+}
 
-	tokenAddress := receiptResponse.CallResult[len(receiptResponse.CallResult)-40:]
+func htsCreatedTokenAddress(receiptResponse domain.ContractResult) (string, bool) {
+	if len(receiptResponse.FunctionParameters) < 10 || len(receiptResponse.CallResult) < 40 {
+		return "", false
+	}
 
-	if !strings.HasPrefix(tokenAddress, "0x") {
-		tokenAddress = fmt.Sprintf("0x%s", tokenAddress)
+	if _, isHTSCreation := HTSCreateFuncSelectors[receiptResponse.FunctionParameters[:10]]; !isHTSCreation {
+		return "", false
 	}
 
-	return tokenAddress
+	return "0x" + receiptResponse.CallResult[len(receiptResponse.CallResult)-40:], true
 }
 
 func isHexString(str string) bool {
@@ -874,3 +705,92 @@ func isHexString(str string) bool {
 	_, err := hex.DecodeString(str)
 	return err == nil
 }
+
+// decodeRevertReason decodes the ABI encoded Error(string) or Panic(uint256)
+// payload returned by a reverted call. Plain text messages are returned as is;
+// any other payload (e.g. custom errors) yields an empty string.
+func decodeRevertReason(data string) string {
+	if !strings.HasPrefix(data, "0x") {
+		return data
+	}
+
+	raw, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	if err != nil || len(raw) < 4+32 {
+		return ""
+	}
+
+	payload := raw[4:]
+	switch hex.EncodeToString(raw[:4]) {
+	case errorStringSelector:
+		return decodeABIString(payload)
+	case panicSelector:
+		code := new(big.Int).SetBytes(payload[:32])
+		if description, ok := panicReasons[code.Uint64()]; ok && code.IsUint64() {
+			return fmt.Sprintf("panic: %s (0x%x)", description, code)
+		}
+		return fmt.Sprintf("panic: unknown panic code (0x%x)", code)
+	default:
+		return ""
+	}
+}
+
+// decodeABIString decodes a single ABI encoded string argument.
+func decodeABIString(payload []byte) string {
+	if len(payload) < 64 {
+		return ""
+	}
+
+	offset := new(big.Int).SetBytes(payload[:32])
+	if !offset.IsInt64() || offset.Int64()+32 > int64(len(payload)) {
+		return ""
+	}
+
+	start := offset.Int64() + 32
+	length := new(big.Int).SetBytes(payload[offset.Int64():start])
+	if !length.IsInt64() || start+length.Int64() > int64(len(payload)) {
+		return ""
+	}
+
+	return string(payload[start : start+length.Int64()])
+}
+
+// hexRevertReason returns a revert reason reported by the mirror node as 0x
+// prefixed hex. Revert payloads are hex already; plain text messages, such as
+// the Hedera status a transaction failed with, are hex encoded.
+func hexRevertReason(message string) string {
+	if strings.HasPrefix(message, "0x") && isHexString(message) {
+		return message
+	}
+	return "0x" + hex.EncodeToString([]byte(message))
+}
+
+// receiptRevertReason returns the revert reason of a failed transaction: the
+// error message the mirror node recorded for it or, failing that, the call
+// result holding the revert payload. It is empty when neither is known.
+func receiptRevertReason(result domain.ContractResult) string {
+	if result.ErrorMessage != nil && *result.ErrorMessage != "" {
+		return hexRevertReason(*result.ErrorMessage)
+	}
+	if result.CallResult != "" && result.CallResult != "0x" {
+		return hexRevertReason(result.CallResult)
+	}
+	return ""
+}
+
+func callErrorToRPCError(err error) *domain.RPCError {
+	var callErr *infrahedera.ContractCallError
+	if errors.As(err, &callErr) && callErr.IsContractRevert() {
+		reason := decodeRevertReason(callErr.Data)
+		if reason == "" {
+			reason = callErr.Detail
+		}
+		// Reverts without a payload carry the mirror node's message instead
+		data := callErr.Data
+		if (data == "" || data == "0x") && callErr.Detail != "" {
+			data = hexRevertReason(callErr.Detail)
+		}
+		return domain.NewContractRevertError(reason, data)
+	}
+
+	return domain.NewRPCError(domain.ServerError, "Failed to post call")
+}
@@ -0,0 +1,888 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	"github.com/LimeChain/Hederium/internal/infrastructure/metrics"
+	"github.com/LimeChain/Hederium/internal/infrastructure/timing"
+	"github.com/LimeChain/Hederium/internal/util"
+	"go.uber.org/zap"
+)
+
+// TransactionService looks up, simulates and submits transactions.
+type TransactionService interface {
+	EstimateGas(ctx context.Context, transaction interface{}, blockParam interface{}) (string, *domain.RPCError)
+	CreateAccessList(ctx context.Context, transaction interface{}, blockParam interface{}) (interface{}, *domain.RPCError)
+	Call(ctx context.Context, transaction interface{}, blockParam interface{}) (interface{}, *domain.RPCError)
+	GetTransactionByHash(ctx context.Context, hash string) (interface{}, *domain.RPCError)
+	GetTransactionReceipt(ctx context.Context, hash string) (interface{}, *domain.RPCError)
+	GetTransactionByBlockHashAndIndex(ctx context.Context, blockHash string, txIndex string) (interface{}, *domain.RPCError)
+	GetTransactionByBlockNumberAndIndex(ctx context.Context, blockNumberOrTag string, txIndex string) (interface{}, *domain.RPCError)
+	SendRawTransaction(ctx context.Context, data string) (interface{}, *domain.RPCError)
+	ProcessTransactionResponse(ctx context.Context, contractResult domain.ContractResult) interface{}
+}
+
+type transactionService struct {
+	*ethCore
+	fees FeeService
+}
+
+func (s *transactionService) EstimateGas(ctx context.Context, transaction interface{}, blockParam interface{}) (string, *domain.RPCError) {
+	s.logger.Info("Estimating gas", zap.Any("transaction", transaction))
+
+	txObj, err := s.parseTransactionCallObject(transaction)
+	if err != nil {
+		s.logger.Error("Failed to parse transaction call object", zap.Error(err))
+		return "0x0", domain.NewRPCError(domain.ServerError, "Failed to parse transaction call object")
+	}
+	if errRpc := s.checkCallGas(txObj); errRpc != nil {
+		return "0x0", errRpc
+	}
+
+	blockParam, errRpc := s.callBlock(ctx, blockParam)
+	if errRpc != nil {
+		return "0x0", errRpc
+	}
+
+	formatResult, err := s.formatTransactionCallObject(txObj, blockParam, true)
+	if err != nil {
+		s.logger.Error("Failed to format transaction call object", zap.Error(err))
+		return "0x0", domain.NewRPCError(domain.ServerError, "Failed to format transaction call object")
+	}
+
+	if gas, ok := s.localDeploymentEstimate(txObj); ok {
+		s.logger.Info("Deployment is too large for the mirror node, returning local estimate", zap.String("gas", gas))
+		return s.capEstimate(gas)
+	}
+
+	callResult, err := s.mClient.PostCall(ctx, formatResult)
+	if err != nil {
+		errRpc := callErrorToRPCError(err)
+		if errRpc.Code == domain.ContractRevert || !s.config.EstimateGasFallback {
+			s.logger.Error("Failed to post call", zap.Error(err))
+			return "0x0", errRpc
+		}
+
+		fallback := s.fallbackGasEstimate(txObj)
+		s.logger.Warn("Mirror node could not estimate gas, returning fallback estimate", zap.Error(err), zap.String("gas", fallback))
+		return fallback, nil
+	}
+	if callResult == nil {
+		s.logger.Error("Failed to post call")
+		return "0x0", domain.NewRPCError(domain.ServerError, "Failed to post call")
+	}
+
+	// Remove leading zeros from the result string
+	result := NormalizeHexString(callResult.(string))
+
+	s.logger.Info("Returning gas", zap.Any("gas", result))
+	return s.capEstimate(result)
+}
+
+// capEstimate fails estimates above the gas cap, as a call needing them would
+// be rejected by eth_call.
+func (s *transactionService) capEstimate(gas string) (string, *domain.RPCError) {
+	estimate, err := strconv.ParseUint(strings.TrimPrefix(gas, "0x"), 16, 64)
+	if gasCap := s.maxCallGas(); err == nil && estimate > gasCap {
+		return "0x0", domain.NewGasAllowanceExceededError(gasCap)
+	}
+	return gas, nil
+}
+
+// CreateAccessList answers eth_createAccessList. The Mirror Node does not
+// report the storage slots a call touches, so the access list is always empty
+// and only gasUsed, taken from a gas estimate of the call, is meaningful.
+func (s *transactionService) CreateAccessList(ctx context.Context, transaction interface{}, blockParam interface{}) (interface{}, *domain.RPCError) {
+	s.logger.Info("Creating access list", zap.Any("transaction", transaction))
+
+	gasUsed, errRpc := s.EstimateGas(ctx, transaction, blockParam)
+	if errRpc != nil {
+		return nil, errRpc
+	}
+
+	return domain.AccessListResult{
+		AccessList: []domain.AccessListEntry{},
+		GasUsed:    gasUsed,
+	}, nil
+}
+
+func (s *transactionService) Call(ctx context.Context, transaction interface{}, blockParam interface{}) (interface{}, *domain.RPCError) {
+	s.logger.Info("Performing eth_call", zap.Any("transaction", transaction))
+
+	txObj, err := s.parseTransactionCallObject(transaction)
+	if err != nil {
+		s.logger.Error("Failed to parse transaction call object", zap.Error(err))
+		return nil, domain.NewRPCError(domain.ServerError, "Failed to parse transaction call object")
+	}
+	if errRpc := s.checkCallGas(txObj); errRpc != nil {
+		return nil, errRpc
+	}
+
+	blockParam, errRpc := s.callBlock(ctx, blockParam)
+	if errRpc != nil {
+		return nil, errRpc
+	}
+
+	result, err := s.formatTransactionCallObject(txObj, blockParam, false)
+	if err != nil {
+		s.logger.Error("Failed to format transaction call object", zap.Error(err))
+		return nil, domain.NewRPCError(domain.ServerError, "Failed to format transaction call object")
+	}
+
+	if answer, errRpc, ok := s.systemContractCall(ctx, txObj, blockParam); ok {
+		return answer, errRpc
+	}
+
+	var cacheKey string
+	callCacheTTL := time.Duration(s.callCacheTTL.Load())
+	if callCacheTTL > 0 {
+		cacheKey = callCacheKey(result)
+		var cachedResult interface{}
+		if err := s.cacheService.Get(ctx, cacheKey, &cachedResult); err == nil && cachedResult != nil {
+			s.logger.Debug("Call result fetched from cache", zap.String("key", cacheKey))
+			return cachedResult, nil
+		}
+	}
+
+	callResult, err := s.mClient.PostCall(ctx, result)
+	if err != nil {
+		s.logger.Error("Failed to post call", zap.Error(err))
+		if errRpc, ok := systemContractCallError(txObj, err); ok {
+			return nil, errRpc
+		}
+		return nil, callErrorToRPCError(err)
+	}
+	if callResult == nil {
+		s.logger.Error("Failed to post call")
+		return "0x0", domain.NewRPCError(domain.ServerError, "Failed to post call")
+	}
+
+	if cacheKey != "" {
+		if err := s.cacheService.Set(ctx, cacheKey, callResult, callCacheTTL); err != nil {
+			s.logger.Debug("Failed to cache call result", zap.Error(err))
+		}
+	}
+
+	s.logger.Info("Returning transaction call result", zap.Any("result", callResult))
+	return callResult, nil
+}
+
+// GetTransactionByHash returns the transaction with the given Ethereum hash or
+// Hedera transaction ID, which the mirror node resolves to the same contract
+// result.
+func (s *transactionService) GetTransactionByHash(ctx context.Context, hash string) (interface{}, *domain.RPCError) {
+	s.logger.Info("Getting transaction by hash", zap.String("hash", hash))
+
+	cacheKey := fmt.Sprintf("%s_%s", GetTransactionByHash, hash)
+
+	// Transactions are cached with their type, so that a cached transaction
+	// has the same fields as a fresh one
+	var cachedTx domain.CachedTransaction
+	if err := s.cacheService.Get(ctx, cacheKey, &cachedTx); err == nil && cachedTx.Transaction != nil {
+		s.logger.Info("Transaction fetched from cache", zap.Any("transaction", cachedTx.Transaction))
+		return cachedTx.Transaction, nil
+	}
+	contractResult, err := s.mClient.GetContractResult(ctx, hash)
+	if isNotFound(err) {
+		// TODO: Here we should handle synthetic transactions
+		return nil, nil
+	}
+	if err != nil {
+		s.logger.Error("Failed to get contract result", zap.Error(err))
+		return nil, mirrorError(err, "Failed to get transaction")
+	}
+	transaction := s.ProcessTransactionResponse(ctx, *contractResult)
+
+	if err := s.cacheService.Set(ctx, cacheKey, domain.CachedTransaction{Transaction: transaction}, s.config.CacheTTLs.Of(cache.ClassTransaction)); err != nil {
+		s.logger.Debug("Failed to cache transaction", zap.Error(err))
+	}
+	if _, isTransactionID := domain.ParseTransactionID(hash); isTransactionID && contractResult.Hash != "" {
+		// Later lookups by the Ethereum hash are served from the cache too
+		hashKey := fmt.Sprintf("%s_%s", GetTransactionByHash, contractResult.Hash)
+		if err := s.cacheService.Set(ctx, hashKey, domain.CachedTransaction{Transaction: transaction}, s.config.CacheTTLs.Of(cache.ClassTransaction)); err != nil {
+			s.logger.Debug("Failed to cache transaction", zap.Error(err))
+		}
+	}
+
+	return transaction, nil
+}
+
+func (s *transactionService) GetTransactionReceipt(ctx context.Context, hash string) (interface{}, *domain.RPCError) {
+	s.logger.Info("Getting transaction receipt", zap.String("hash", hash))
+
+	cacheKey := fmt.Sprintf("%s_%s", GetTransactionReceipt, hash)
+
+	var cachedReceipt interface{}
+	if err := s.cacheService.Get(ctx, cacheKey, &cachedReceipt); err == nil && cachedReceipt != nil {
+		s.logger.Info("Transaction receipt fetched from cache", zap.Any("receipt", cachedReceipt))
+		return cachedReceipt, nil
+	}
+
+	contractResult, err := s.mClient.GetContractResult(ctx, hash)
+	if isNotFound(err) {
+		// TODO: Here we should handle synthetic transactions
+		return nil, nil
+	}
+	if err != nil {
+		s.logger.Error("Failed to get contract result", zap.Error(err))
+		return nil, mirrorError(err, "Failed to get transaction receipt")
+	}
+
+	receipt := s.transactionReceipt(ctx, hash, contractResult)
+
+	if err := s.cacheService.Set(ctx, cacheKey, &receipt, s.config.CacheTTLs.Of(cache.ClassReceipt)); err != nil {
+		s.logger.Debug("Failed to cache transaction receipt", zap.Error(err))
+	}
+
+	s.logger.Info("Returning transaction receipt", zap.Any("receipt", receipt))
+	return receipt, nil
+}
+
+// transactionReceipt builds the receipt of the transaction with the given hash
+// from its contract result.
+func (s *transactionService) transactionReceipt(ctx context.Context, hash string, contractResult *domain.ContractResult) domain.TransactionReceipt {
+	// Logs are numbered within the block, as eth_getLogs numbers them, so the
+	// logs of later transactions follow those of the earlier ones
+	logOffset := 0
+	if len(contractResult.Logs) > 0 && contractResult.TransactionIndex > 0 {
+		offsets, err := blockLogOffsets(ctx, s.mClient, s.cacheService, s.config.CacheTTLs.Of(cache.ClassBlock), contractResult.BlockNumber)
+		if err != nil {
+			s.logger.Warn("Failed to number receipt logs within their block", zap.String("hash", hash), zap.Error(err))
+		} else {
+			logOffset = offsets[contractResult.TransactionIndex]
+		}
+	}
+
+	// Convert logs
+	logs := make([]domain.Log, len(contractResult.Logs))
+	for i, log := range contractResult.Logs {
+		logs[i] = domain.Log{
+			Address:          s.formatAddress(log.Address),
+			BlockHash:        contractResult.BlockHash[:66],
+			BlockNumber:      hexify(contractResult.BlockNumber),
+			Data:             log.Data,
+			LogIndex:         hexify(int64(logOffset + i)),
+			Removed:          false,
+			Topics:           log.Topics,
+			TransactionHash:  hash,
+			TransactionIndex: hexify(int64(contractResult.TransactionIndex)),
+		}
+	}
+
+	// Default values
+	const defaultRootHash = "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"
+
+	evmAddressFrom, err := s.resolveEvmAddress(ctx, contractResult.From)
+	if err != nil {
+		s.logger.Error("Failed to resolve EVM address for from", zap.Any("error", err))
+	}
+
+	// Contract creations have no recipient.
+	var evmAddressTo *string
+	if !isContractCreation(*contractResult) {
+		evmAddressTo, err = s.resolveEvmAddress(ctx, contractResult.To)
+		if err != nil {
+			s.logger.Error("Failed to resolve EVM address for to", zap.Any("error", err))
+		}
+	}
+
+	effectiveGasPrice, err := s.getCurrentGasPriceForBlock(ctx, contractResult.BlockHash[:66])
+	if err != nil {
+		s.logger.Error("Failed to get gas price for block", zap.Any("error", err))
+	}
+
+	// The mirror node leaves the bloom out of some results, so it is computed
+	// from the logs instead
+	logsBloom := contractResult.Bloom
+	if isMissingBloom(logsBloom) {
+		logsBloom = buildLogsBloom(contractResult.Logs)
+	}
+
+	var contractType *string
+	if contractResult.Type != nil {
+		hexType := hexify(int64(*contractResult.Type))
+		contractType = &hexType
+	}
+
+	contractAddress := s.getContractAddressFromReceipt(ctx, *contractResult)
+	if contractAddress != nil {
+		checksummed := s.formatAddress(*contractAddress)
+		contractAddress = &checksummed
+	}
+	from := s.formatAddress(*evmAddressFrom)
+	if evmAddressTo != nil {
+		to := s.formatAddress(*evmAddressTo)
+		evmAddressTo = &to
+	}
+
+	// Create receipt
+	receipt := domain.TransactionReceipt{
+		BlockHash:         contractResult.BlockHash[:66],
+		BlockNumber:       hexify(contractResult.BlockNumber),
+		From:              from,
+		To:                evmAddressTo,
+		CumulativeGasUsed: hexify(contractResult.BlockGasUsed),
+		GasUsed:           hexify(contractResult.GasUsed),
+		ContractAddress:   contractAddress,
+		Logs:              logs,
+		LogsBloom:         logsBloom,
+		TransactionHash:   hash,
+		TransactionIndex:  hexify(int64(contractResult.TransactionIndex)),
+		EffectiveGasPrice: effectiveGasPrice,
+		Root:              defaultRootHash,
+		Status:            contractResult.Status,
+		Type:              contractType,
+	}
+
+	if contractResult.Status == "0x0" {
+		receipt.RevertReason = receiptRevertReason(*contractResult)
+	}
+
+	return receipt
+}
+
+func (s *transactionService) GetTransactionByBlockHashAndIndex(ctx context.Context, blockHash string, txIndex string) (interface{}, *domain.RPCError) {
+	s.logger.Info("Getting transaction by block and index", zap.String("blockHash", blockHash), zap.String("txIndex", txIndex))
+
+	cacheKey := fmt.Sprintf("%s_%s_%s", GetTransactionByBlockHashAndIndex, blockHash, txIndex)
+
+	var cachedTx interface{}
+	if err := s.cacheService.Get(ctx, cacheKey, &cachedTx); err == nil {
+		s.logger.Info("Transaction fetched from cache", zap.Any("transaction", cachedTx))
+		return cachedTx, nil
+	}
+
+	txIndexInt, err := HexToDec(txIndex)
+	if err != nil {
+		s.logger.Error("Failed to parse transaction index", zap.Error(err))
+		return nil, domain.NewRPCError(domain.ServerError, "Failed to parse hex value")
+	}
+
+	queryParamas := map[string]interface{}{
+		"block.hash":        blockHash,
+		"transaction.index": txIndexInt,
+	}
+
+	tx, err := s.getTransactionByBlockAndIndex(ctx, queryParamas)
+	if err != nil {
+		s.logger.Error("Failed to get transaction by block and index", zap.Error(err))
+		return nil, serviceError(err, "Failed to get transaction by block and index")
+	}
+
+	if err := s.cacheService.Set(ctx, cacheKey, tx, s.config.CacheTTLs.Of(cache.ClassTransaction)); err != nil {
+		s.logger.Debug("Failed to cache transaction", zap.Error(err))
+	}
+
+	return tx, nil
+}
+
+func (s *transactionService) GetTransactionByBlockNumberAndIndex(ctx context.Context, blockNumberOrTag string, txIndex string) (interface{}, *domain.RPCError) {
+	s.logger.Info("Getting transaction by block number and index", zap.String("blockNumberOrTag", blockNumberOrTag), zap.String("txIndex", txIndex))
+
+	blockNumberInt, errRpc := s.commonService.GetBlockNumberByNumberOrTag(ctx, blockNumberOrTag)
+	if errRpc != nil {
+		return nil, errRpc
+	}
+
+	cacheKey := fmt.Sprintf("%s_%d_%s", GetTransactionByBlockNumberAndIndex, blockNumberInt, txIndex)
+
+	var cachedTx interface{}
+	if err := s.cacheService.Get(ctx, cacheKey, &cachedTx); err == nil {
+		s.logger.Info("Transaction fetched from cache", zap.Any("transaction", cachedTx))
+		return cachedTx, nil
+	}
+
+	txIndexInt, err := HexToDec(txIndex)
+	if err != nil {
+		s.logger.Error("Failed to parse transaction index", zap.Error(err))
+		return nil, domain.NewRPCError(domain.ServerError, "Failed to parse hex value")
+	}
+
+	queryParamas := map[string]interface{}{
+		"block.number":      blockNumberInt,
+		"transaction.index": txIndexInt,
+	}
+
+	tx, err := s.getTransactionByBlockAndIndex(ctx, queryParamas)
+	if err != nil {
+		s.logger.Error("Failed to get transaction by block and index", zap.Error(err))
+		return nil, serviceError(err, "Failed to get transaction by block and index")
+	}
+
+	if err := s.cacheService.Set(ctx, cacheKey, tx, s.config.CacheTTLs.Of(cache.ClassTransaction)); err != nil {
+		s.logger.Debug("Failed to cache transaction", zap.Error(err))
+	}
+
+	return tx, nil
+}
+
+func (s *transactionService) SendRawTransaction(ctx context.Context, data string) (interface{}, *domain.RPCError) {
+	s.logger.Info("Sending raw transaction", zap.String("data", data))
+
+	parsedTx, err := ParseTransaction(data)
+	if err != nil {
+		s.logger.Error("Failed to parse transaction", zap.Error(err))
+		return nil, domain.NewRPCError(domain.ServerError, "Failed to parse transaction")
+	}
+
+	if err = s.precheck.CheckSize(data); err != nil {
+		return nil, precheckError(err)
+	}
+
+	rawTx, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	if err != nil {
+		s.logger.Error("Failed to decode raw transaction", zap.Error(err))
+		return nil, domain.NewRPCError(domain.ServerError, "Failed to decode raw transaction")
+	}
+
+	// Wallets resubmit transactions whose submission timed out. Like an
+	// Ethereum node that already knows a transaction, the relay does not send
+	// it again and returns the hash it returned the first time
+	cacheKey := fmt.Sprintf("%s_0x%s", SendRawTransaction, hex.EncodeToString(util.Keccak256(rawTx)))
+	var submittedHash string
+	if err := s.cacheService.Get(ctx, cacheKey, &submittedHash); err == nil && submittedHash != "" {
+		s.logger.Info("Transaction already submitted, returning its hash", zap.String("hash", submittedHash))
+		return &submittedHash, nil
+	}
+
+	// Resubmissions arriving while the transaction is being submitted wait
+	// for its outcome
+	result, err, _ := s.flight.Do(cacheKey, func() (interface{}, error) {
+		txHash, rpcErr := s.submitRawTransaction(ctx, parsedTx, rawTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		if err := s.cacheService.Set(ctx, cacheKey, *txHash, s.config.CacheTTLs.Of(cache.ClassTransaction)); err != nil {
+			s.logger.Debug("Failed to cache submitted transaction hash", zap.Error(err))
+		}
+		return txHash, nil
+	})
+	if err != nil {
+		return nil, err.(*domain.RPCError)
+	}
+
+	return result, nil
+}
+
+// submitRawTransaction prechecks, pays for and submits parsedTx, whose raw
+// encoding is rawTx, and returns its hash.
+func (s *transactionService) submitRawTransaction(ctx context.Context, parsedTx *util.Tx, rawTx []byte) (*string, *domain.RPCError) {
+	submitted := false
+	if s.nonces != nil {
+		release, rpcErr := s.awaitNonceTurn(ctx, parsedTx)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		defer func() { release(submitted) }()
+	}
+
+	gasPriceHex, rpcErr := s.fees.GetGasPrice(ctx)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	gasPrice, err := HexToDec(gasPriceHex.(string))
+	if err != nil {
+		s.logger.Error("Failed to parse gas price", zap.Error(err))
+		return nil, domain.NewRPCError(domain.ServerError, "Failed to parse gas price")
+	}
+
+	if err = s.precheck.SendRawTransactionCheck(ctx, parsedTx, gasPrice); err != nil {
+		s.logger.Error("Transaction rejected by precheck", zap.Error(err))
+		return nil, precheckError(err)
+	}
+
+	if rpcErr := s.deductHbarCost(ctx, parsedTx, gasPrice); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	txHash, err := s.sendRawTransactionProcessor(ctx, rawTx, parsedTx, gasPrice)
+	if err != nil {
+		s.logger.Error("Failed to process transaction", zap.Error(err))
+		return nil, serviceError(err, "Failed to process transaction")
+	}
+	submitted = true
+
+	return txHash, nil
+}
+
+// ProcessTransactionResponse resolves the sender and recipient of a contract
+// result to their EVM addresses and converts it with ProcessTransaction.
+func (s *transactionService) ProcessTransactionResponse(ctx context.Context, contractResult domain.ContractResult) interface{} {
+	contractResult.From = s.resolveTransactionAddress(ctx, contractResult.From)
+	contractResult.To = s.resolveTransactionAddress(ctx, contractResult.To)
+	return ProcessTransaction(contractResult)
+}
+
+// resolveTransactionAddress returns the EVM address of the sender or
+// recipient address, or address itself when it cannot be resolved.
+func (s *transactionService) resolveTransactionAddress(ctx context.Context, address string) string {
+	address = truncateString(address, 42)
+	evmAddress, err := s.resolveEvmAddress(ctx, address)
+	if err != nil {
+		return address
+	}
+	return s.formatAddress(*evmAddress)
+}
+
+// awaitNonceTurn waits in the nonce queue until tx may be submitted. The
+// returned function must be called with the outcome of the submission.
+// Transactions whose sender cannot be recovered are not ordered.
+func (s *transactionService) awaitNonceTurn(ctx context.Context, tx *util.Tx) (func(submitted bool), *domain.RPCError) {
+	sender, err := tx.Sender()
+	if err != nil {
+		return func(bool) {}, nil
+	}
+
+	accountNonce := func() (uint64, error) {
+		account, err := s.mClient.GetAccountById(ctx, sender)
+		if err != nil {
+			return 0, err
+		}
+		if account == nil || account.EthereumNonce < 0 {
+			return 0, fmt.Errorf("no nonce for account %s", sender)
+		}
+		return uint64(account.EthereumNonce), nil
+	}
+
+	release, err := s.nonces.Acquire(ctx, sender, tx.Nonce, accountNonce)
+	if err != nil {
+		s.logger.Debug("Gave up waiting for the nonce queue", zap.String("sender", sender), zap.Uint64("nonce", tx.Nonce), zap.Error(err))
+		return nil, domain.NewRequestTimeoutError()
+	}
+	return release, nil
+}
+
+// callBlock resolves a block hash passed to eth_call or eth_estimateGas to the
+// block number the mirror node expects, and the safe and finalized tags, which
+// it does not know, to latest. Other block parameters are returned as they
+// are.
+func (s *transactionService) callBlock(ctx context.Context, blockParam interface{}) (interface{}, *domain.RPCError) {
+	block, ok := blockParam.(string)
+	if ok && (block == domain.BlockTagSafe || block == domain.BlockTagFinalized) {
+		return domain.BlockTagLatest, nil
+	}
+	if !ok || !isBlockHash(block) {
+		return blockParam, nil
+	}
+
+	blockNumber, errRpc := s.commonService.GetBlockNumberByNumberOrTag(ctx, block)
+	if errRpc != nil {
+		return nil, errRpc
+	}
+	return fmt.Sprintf("0x%x", blockNumber), nil
+}
+
+// callCacheKey returns the cache key of an eth_call result: a hash of the call
+// object sent to the mirror node, which holds the sender, target, data, value,
+// gas and block. Map keys are encoded in sorted order, so equal calls hash
+// alike.
+func callCacheKey(callObject map[string]interface{}) string {
+	encoded, _ := json.Marshal(callObject)
+	hash := sha256.Sum256(encoded)
+	return fmt.Sprintf("%s_%s", Call, hex.EncodeToString(hash[:]))
+}
+
+func (s *transactionService) getTransactionByBlockAndIndex(ctx context.Context, queryParamas map[string]interface{}) (interface{}, error) {
+	transaction, err := s.mClient.GetContractResultWithRetry(ctx, queryParamas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	if transaction == nil {
+		return nil, nil
+	}
+
+	return s.ProcessTransactionResponse(ctx, *transaction), nil
+}
+
+// deductHbarCost charges the estimated cost of tx to the HBAR budget of the
+// caller and of the operator.
+func (s *transactionService) deductHbarCost(ctx context.Context, tx *util.Tx, gasPrice int64) *domain.RPCError {
+	if s.tieredLimiter == nil {
+		return nil
+	}
+
+	apiKey, tier := limiter.APIKeyFromContext(ctx)
+	cost := estimateTransactionCost(tx, gasPrice)
+
+	if !s.tieredLimiter.DeductHbarUsage(apiKey, tier, cost) {
+		s.logger.Warn("HBAR budget exhausted", zap.String("tier", tier), zap.Int64("costTinybars", cost))
+		return domain.NewHbarRateLimitExceededError()
+	}
+
+	s.logger.Debug("Deducted transaction cost from HBAR budget", zap.String("tier", tier), zap.Int64("costTinybars", cost), zap.Int64("operatorRemaining", s.tieredLimiter.OperatorHbarRemaining()))
+
+	return nil
+}
+
+// ProcessRawTransaction handles the processing of a raw Ethereum transaction for Hedera
+func (s *transactionService) sendRawTransactionProcessor(ctx context.Context, transactionData []byte, tx *util.Tx, gasPrice int64) (*string, error) {
+	// Get the sender address for event tracking
+	fromAddress, err := tx.Sender()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sender address: %w", err)
+	}
+
+	// Get the recipient address for event tracking
+	var toAddress string
+	if tx.To != "" {
+		toAddress = tx.To
+	}
+
+	// Send the raw transaction using the client's implementation
+	stop := timing.Track(ctx, timing.SDK)
+	response, err := s.hClient.SendRawTransaction(transactionData, gasPrice, fromAddress)
+	stop()
+	if err != nil {
+		s.logger.Error("Failed to send raw transaction",
+			zap.Error(err),
+			zap.String("from", fromAddress),
+			zap.String("to", toAddress),
+			zap.Int64("gasPrice", gasPrice))
+		return nil, fmt.Errorf("failed to send raw transaction: %w", err)
+	}
+
+	subbmitedTransactionId := response.TransactionID
+
+	transactionIDRegex := regexp.MustCompile(`\d{1}\.\d{1}\.\d{1,10}\@\d{1,10}\.\d{1,9}`)
+	if !transactionIDRegex.MatchString(subbmitedTransactionId) {
+		s.logger.Error("Invalid transaction ID format", zap.String("transactionID", subbmitedTransactionId))
+		return nil, fmt.Errorf("invalid transaction ID format: %s", subbmitedTransactionId)
+	}
+
+	if subbmitedTransactionId != "" {
+		transactionId := ConvertTransactionID(subbmitedTransactionId)
+		// The hash of an Ethereum transaction is the Keccak-256 of its raw encoding
+		localHash := "0x" + hex.EncodeToString(util.Keccak256(transactionData))
+
+		if s.config.AsyncSendRawTransaction {
+			hash := localHash
+
+			// The polling outlives the request, it only warms the receipt cache and logs failures
+			go func() {
+				ctx := context.WithoutCancel(ctx)
+				contractResult, err := s.awaitContractResult(ctx, transactionId)
+				if err != nil {
+					return
+				}
+				if !strings.EqualFold(contractResult.Hash, hash) {
+					s.logger.Warn("Recorded transaction hash differs from the one returned",
+						zap.String("transactionID", transactionId),
+						zap.String("returned", hash),
+						zap.String("recorded", contractResult.Hash))
+				}
+				s.warmTransactionCaches(ctx, contractResult)
+			}()
+
+			s.logger.Info("Transaction submitted",
+				zap.String("hash", hash),
+				zap.String("transactionID", transactionId),
+				zap.String("from", fromAddress),
+				zap.String("to", toAddress),
+				zap.Int64("gasPrice", gasPrice))
+
+			return &hash, nil
+		}
+
+		var hash string
+		contractResult, err := s.awaitContractResult(ctx, transactionId)
+		if err != nil {
+			hash, err = s.consensusTransactionHash(subbmitedTransactionId, localHash, err)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			hash = contractResult.Hash
+			// The wallet is told the hash without waiting for the caches to fill
+			go s.warmTransactionCaches(context.WithoutCancel(ctx), contractResult)
+		}
+
+		s.logger.Info("Transaction sent successfully",
+			zap.String("transactionID", hash),
+			zap.String("from", fromAddress),
+			zap.String("to", toAddress),
+			zap.Int64("gasPrice", gasPrice))
+
+		return &hash, nil
+	}
+
+	return nil, fmt.Errorf("failed to send transaction: %w", err)
+}
+
+// awaitContractResult polls the mirror node until it records the submitted
+// transaction and returns its contract result, which carries its Ethereum
+// hash.
+func (s *transactionService) awaitContractResult(ctx context.Context, transactionId string) (*domain.ContractResult, error) {
+	contractResult, err := s.mClient.RepeatGetContractResult(ctx, transactionId, 10)
+	if err != nil {
+		s.logger.Error("Failed to get contract result",
+			zap.String("transactionID", transactionId), zap.Error(err))
+		return nil, fmt.Errorf("%w: %s: %w", ErrTransactionNotRecorded, transactionId, err)
+	}
+
+	if contractResult.Hash == "" {
+		s.logger.Error("Transaction returned a null transaction hash:",
+			zap.String("transactionID", transactionId))
+		return nil, fmt.Errorf("%w: %s", ErrTransactionNotRecorded, transactionId)
+	}
+
+	return contractResult, nil
+}
+
+// warmTransactionCaches caches the receipt and the transaction built from the
+// contract result of a transaction the relay has just sent. Building the
+// receipt caches its block as well, and the mirror client has cached the
+// contract result itself, so the eth_getTransactionReceipt polls wallets send
+// right after eth_sendRawTransaction are answered without asking the mirror
+// node again while it is still indexing.
+func (s *transactionService) warmTransactionCaches(ctx context.Context, contractResult *domain.ContractResult) {
+	if !s.config.WarmReceiptCache {
+		return
+	}
+
+	hash := contractResult.Hash
+	receipt := s.transactionReceipt(ctx, hash, contractResult)
+	if err := s.cacheService.Set(ctx, fmt.Sprintf("%s_%s", GetTransactionReceipt, hash), &receipt, s.config.CacheTTLs.Of(cache.ClassReceipt)); err != nil {
+		s.logger.Debug("Failed to cache transaction receipt", zap.Error(err))
+	}
+
+	transaction := s.ProcessTransactionResponse(ctx, *contractResult)
+	if err := s.cacheService.Set(ctx, fmt.Sprintf("%s_%s", GetTransactionByHash, hash), domain.CachedTransaction{Transaction: transaction}, s.config.CacheTTLs.Of(cache.ClassTransaction)); err != nil {
+		s.logger.Debug("Failed to cache transaction", zap.Error(err))
+	}
+
+	s.logger.Debug("Warmed the caches of a sent transaction", zap.String("hash", hash))
+}
+
+// consensusTransactionHash asks a consensus node for the outcome of a
+// transaction the mirror node has not recorded yet, so that transactions that
+// reached consensus are not reported as failed while the mirror node lags.
+// Failed transactions reached consensus too and return localHash, as their
+// receipts will report the failure. mirrorErr is returned when the consensus
+// node cannot tell either.
+func (s *transactionService) consensusTransactionHash(submittedTransactionId, localHash string, mirrorErr error) (string, error) {
+	if s.hClient == nil {
+		return "", mirrorErr
+	}
+
+	record, err := s.hClient.GetTransactionRecord(submittedTransactionId)
+	if err != nil {
+		s.logger.Error("Failed to get transaction record from consensus node",
+			zap.String("transactionID", submittedTransactionId), zap.Error(err))
+		return "", mirrorErr
+	}
+
+	hash := localHash
+	if record.EthereumHash != "" {
+		hash = record.EthereumHash
+	}
+	metrics.TransactionRecordFallbacks.WithLabelValues(record.Status).Inc()
+	s.logger.Warn("Mirror node has not recorded the transaction, returning its hash from the consensus node record",
+		zap.String("transactionID", submittedTransactionId),
+		zap.String("hash", hash),
+		zap.String("status", record.Status))
+
+	return hash, nil
+}
+
+// getContractAddressFromReceipt returns the address of the contract or token
+// created by the transaction, or nil when it did not create one. HTS create
+// calls return the new token address in their call result.
+func (s *transactionService) getContractAddressFromReceipt(ctx context.Context, receiptResponse domain.ContractResult) *string {
+	if tokenAddress, ok := htsCreatedTokenAddress(receiptResponse); ok {
+		return &tokenAddress
+	}
+
+	if !isContractCreation(receiptResponse) {
+		return nil
+	}
+
+	address := receiptResponse.Address
+	if (address == "" || strings.HasPrefix(address, "0x000000000000")) && receiptResponse.ContractID != "" {
+		contract, err := s.mClient.GetContractById(ctx, receiptResponse.ContractID)
+		if err != nil {
+			s.logger.Debug("Failed to resolve EVM address of created contract", zap.String("contractId", receiptResponse.ContractID), zap.Error(err))
+		} else if contract != nil && contract.EvmAddress != "" {
+			address = contract.EvmAddress
+		}
+	}
+	if address == "" {
+		return nil
+	}
+
+	return &address
+}
+
+// localDeploymentEstimate estimates the gas of a deployment whose init code is
+// larger than the mirror node accepts, as the deploy gas plus a cost per byte
+// of init code, capped at the gas limit of a transaction. It returns false for
+// calls and for deployments the mirror node can estimate.
+func (s *transactionService) localDeploymentEstimate(txObj *domain.TransactionCallObject) (string, bool) {
+	if txObj.To != "" {
+		return "", false
+	}
+	data := txObj.Data
+	if data == "" {
+		data = txObj.Input
+	}
+
+	maxDataSize := s.config.MirrorNodeMaxDataSize
+	if maxDataSize <= 0 {
+		maxDataSize = DefaultMirrorNodeMaxDataSize
+	}
+	size := len(strings.TrimPrefix(data, "0x")) / 2
+	if size <= maxDataSize {
+		return "", false
+	}
+
+	gas := s.config.ContractCreationGas
+	if gas == 0 {
+		gas = DefaultContractCreationGas
+	}
+	perByte := s.config.DeployGasPerByte
+	if perByte == 0 {
+		perByte = DefaultDeployGasPerByte
+	}
+	gas = min(gas+perByte*uint64(size), s.blockGasLimit())
+	return fmt.Sprintf("0x%x", gas), true
+}
+
+// fallbackGasEstimate guesses the gas of a transaction the mirror node could not
+// estimate: the intrinsic cost for plain transfers and the configured defaults
+// for contract calls and deployments.
+func (s *transactionService) fallbackGasEstimate(txObj *domain.TransactionCallObject) string {
+	data := txObj.Data
+	if data == "" {
+		data = txObj.Input
+	}
+	hasData := data != "" && data != "0x"
+
+	switch {
+	case txObj.To == "":
+		gas := s.config.ContractCreationGas
+		if gas == 0 {
+			gas = DefaultContractCreationGas
+		}
+		return fmt.Sprintf("0x%x", gas)
+	case !hasData:
+		return fmt.Sprintf("0x%x", TxBaseCost)
+	default:
+		gas := s.config.ContractCallGas
+		if gas == 0 {
+			gas = DefaultContractCallGas
+		}
+		return fmt.Sprintf("0x%x", gas)
+	}
+}
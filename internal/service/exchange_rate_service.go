@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
+	infrahedera "github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	"go.uber.org/zap"
+)
+
+// ExchangeRateServicer prices HBAR in US dollars at the exchange rate of the
+// network, as published by the mirror node.
+type ExchangeRateServicer interface {
+	GetExchangeRate(ctx context.Context) (*domain.ExchangeRate, *domain.RPCError)
+	// TinybarsToUSD returns the value of tinybars in US dollars at the
+	// current rate.
+	TinybarsToUSD(ctx context.Context, tinybars int64) (float64, error)
+}
+
+type exchangeRateService struct {
+	mClient      infrahedera.MirrorNodeClient
+	cacheService cache.CacheService
+	logger       *zap.Logger
+}
+
+func NewExchangeRateService(mClient infrahedera.MirrorNodeClient, cacheService cache.CacheService, logger *zap.Logger) ExchangeRateServicer {
+	return &exchangeRateService{
+		mClient:      mClient,
+		cacheService: cacheService,
+		logger:       logger,
+	}
+}
+
+// GetExchangeRate returns the current HBAR to USD rate. The rate is cached
+// until it expires, which happens once an hour on Hedera.
+func (s *exchangeRateService) GetExchangeRate(ctx context.Context) (*domain.ExchangeRate, *domain.RPCError) {
+	rate, err := s.currentRate(ctx)
+	if err != nil {
+		return nil, mirrorError(err, "Failed to retrieve the exchange rate")
+	}
+
+	return &domain.ExchangeRate{
+		HbarEquivalent: rate.HbarEquivalent,
+		CentEquivalent: rate.CentEquivalent,
+		ExpirationTime: rate.ExpirationTime,
+		UsdPerHbar:     usdPerHbar(rate),
+	}, nil
+}
+
+func (s *exchangeRateService) TinybarsToUSD(ctx context.Context, tinybars int64) (float64, error) {
+	rate, err := s.currentRate(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return float64(tinybars) / domain.TinybarsPerHbar * usdPerHbar(rate), nil
+}
+
+func (s *exchangeRateService) currentRate(ctx context.Context) (domain.Rate, error) {
+	var cached domain.Rate
+	if err := s.cacheService.Get(ctx, GetExchangeRate, &cached); err == nil && cached.HbarEquivalent > 0 {
+		return cached, nil
+	}
+
+	response, err := s.mClient.GetExchangeRate(ctx)
+	if err != nil {
+		return domain.Rate{}, err
+	}
+	rate := response.CurrentRate
+	if rate.HbarEquivalent <= 0 {
+		return domain.Rate{}, infrahedera.ErrUpstreamUnavailable
+	}
+
+	// A rate the mirror node still serves after it expired is kept briefly,
+	// until the mirror node catches up
+	ttl := time.Until(time.Unix(rate.ExpirationTime, 0))
+	if ttl <= 0 {
+		ttl = time.Minute
+	}
+	if err := s.cacheService.Set(ctx, GetExchangeRate, rate, ttl); err != nil {
+		s.logger.Error("Error caching exchange rate", zap.Error(err))
+	}
+
+	return rate, nil
+}
+
+func usdPerHbar(rate domain.Rate) float64 {
+	return float64(rate.CentEquivalent) / float64(rate.HbarEquivalent) / 100
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	infrahedera "github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	"go.uber.org/zap"
+)
+
+// blockFeed keeps the latest blocks the block poller saw, in order and without
+// gaps, with the hashes of their transactions, so that block and pending
+// transaction filters are answered from memory rather than the mirror node.
+type blockFeed struct {
+	mClient infrahedera.MirrorNodeClient
+	logger  *zap.Logger
+	poller  *BlockPoller
+
+	mu sync.RWMutex
+	// blocks holds consecutive block numbers in ascending order, at most
+	// blockFeedSize of them.
+	blocks []feedBlock
+}
+
+type feedBlock struct {
+	number       int64
+	hash         string
+	transactions []string
+}
+
+// newBlockFeed creates a feed filled with every new block poller sees.
+func newBlockFeed(mClient infrahedera.MirrorNodeClient, logger *zap.Logger, poller *BlockPoller) *blockFeed {
+	f := &blockFeed{mClient: mClient, logger: logger, poller: poller}
+	poller.OnNewBlock(f.add)
+	return f
+}
+
+// add appends block to the feed, together with the blocks the poller skipped
+// since the previous one. The feed starts over from block when they cannot be
+// fetched, so that it never has gaps.
+func (f *blockFeed) add(ctx context.Context, block *domain.BlockResponse) {
+	f.mu.RLock()
+	var last int64 = -1
+	if len(f.blocks) > 0 {
+		last = f.blocks[len(f.blocks)-1].number
+	}
+	f.mu.RUnlock()
+
+	number := int64(block.Number)
+	if number <= last {
+		return
+	}
+
+	var added []feedBlock
+	if last >= 0 && number > last+1 && number-last-1 < blockFeedSize {
+		skipped, err := f.skippedBlocks(ctx, last, number)
+		if err != nil {
+			f.logger.Debug("Failed to fetch the blocks the poller skipped, restarting the block feed", zap.Int64("from", last+1), zap.Int64("to", number-1), zap.Error(err))
+		}
+		added = skipped
+	}
+
+	transactions, err := blockTransactionHashes(ctx, f.mClient, block.Timestamp)
+	if err != nil {
+		f.logger.Debug("Failed to fetch the transactions of a polled block, restarting the block feed", zap.Int64("number", number), zap.Error(err))
+		f.mu.Lock()
+		f.blocks = nil
+		f.mu.Unlock()
+		return
+	}
+	added = append(added, feedBlock{number: number, hash: trimHash(block.Hash), transactions: transactions})
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	// The feed starts over when the blocks added do not follow on from it
+	if len(f.blocks) > 0 && added[0].number != f.blocks[len(f.blocks)-1].number+1 {
+		f.blocks = nil
+	}
+	f.blocks = append(f.blocks, added...)
+	if len(f.blocks) > blockFeedSize {
+		f.blocks = append([]feedBlock(nil), f.blocks[len(f.blocks)-blockFeedSize:]...)
+	}
+}
+
+// skippedBlocks fetches the blocks after last and before number with their
+// transactions. It returns nil unless all of them are found.
+func (f *blockFeed) skippedBlocks(ctx context.Context, last, number int64) ([]feedBlock, error) {
+	var skipped []feedBlock
+	cursor := last
+	for cursor < number-1 {
+		blocks, err := f.mClient.GetBlocks(ctx, strconv.FormatInt(cursor, 10))
+		if err != nil {
+			return nil, err
+		}
+		if len(blocks) == 0 {
+			return nil, fmt.Errorf("no blocks after %d", cursor)
+		}
+		for _, raw := range blocks {
+			block, timestamp, ok := parseFeedBlock(raw)
+			if !ok || block.number != cursor+1 {
+				return nil, fmt.Errorf("unexpected block after %d", cursor)
+			}
+			if block.number >= number {
+				return skipped, nil
+			}
+			if block.transactions, err = blockTransactionHashes(ctx, f.mClient, timestamp); err != nil {
+				return nil, err
+			}
+			skipped = append(skipped, block)
+			cursor = block.number
+		}
+	}
+	return skipped, nil
+}
+
+// since returns the blocks after number. It reports false when the feed
+// cannot tell: the poller is stale or the feed does not reach back to the
+// block after number.
+func (f *blockFeed) since(number int64) ([]feedBlock, bool) {
+	if _, ok := f.poller.Latest(); !ok {
+		return nil, false
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if len(f.blocks) == 0 || f.blocks[0].number > number+1 {
+		return nil, false
+	}
+	start := number + 1 - f.blocks[0].number
+	if start >= int64(len(f.blocks)) {
+		return nil, true
+	}
+	return append([]feedBlock(nil), f.blocks[start:]...), true
+}
+
+// parseFeedBlock reads a block listed by the mirror node.
+func parseFeedBlock(raw map[string]interface{}) (feedBlock, domain.Timestamp, bool) {
+	number, ok := raw["number"].(float64)
+	if !ok {
+		return feedBlock{}, domain.Timestamp{}, false
+	}
+	hash, ok := raw["hash"].(string)
+	if !ok {
+		return feedBlock{}, domain.Timestamp{}, false
+	}
+
+	var timestamp domain.Timestamp
+	if rawTimestamp, ok := raw["timestamp"].(map[string]interface{}); ok {
+		timestamp.From, _ = rawTimestamp["from"].(string)
+		timestamp.To, _ = rawTimestamp["to"].(string)
+	}
+	return feedBlock{number: int64(number), hash: trimHash(hash)}, timestamp, true
+}
+
+// blockTransactionHashes returns the hashes of the transactions of the block
+// spanning timestamp, the transactions eth_getBlockByNumber lists.
+func blockTransactionHashes(ctx context.Context, mClient infrahedera.MirrorNodeClient, timestamp domain.Timestamp) ([]string, error) {
+	results, err := mClient.GetContractResults(ctx, timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, 0, len(results))
+	for _, result := range results {
+		if result.Result == "WRONG_NONCE" || result.Result == "INVALID_ACCOUNT_ID" {
+			continue
+		}
+		hashes = append(hashes, trimHash(result.Hash))
+	}
+	return hashes, nil
+}
+
+// trimHash cuts the 48-byte hashes of the mirror node to the 32 bytes of
+// Ethereum hashes.
+func trimHash(hash string) string {
+	if len(hash) > 66 {
+		return hash[:66]
+	}
+	return hash
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	infrahedera "github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	"go.uber.org/zap"
+)
+
+// HederaServicer answers the hedera_* methods, which expose Hedera-native
+// token data of the mirror node so that wallets need no mirror node client
+// of their own.
+type HederaServicer interface {
+	GetTokenBalances(ctx context.Context, address string) ([]domain.TokenBalance, *domain.RPCError)
+	GetTokenInfo(ctx context.Context, tokenAddress string) (*domain.TokenInfo, *domain.RPCError)
+}
+
+type hederaService struct {
+	mClient infrahedera.MirrorNodeClient
+	logger  *zap.Logger
+}
+
+func NewHederaService(mClient infrahedera.MirrorNodeClient, logger *zap.Logger) HederaServicer {
+	return &hederaService{
+		mClient: mClient,
+		logger:  logger,
+	}
+}
+
+// GetTokenBalances returns the tokens address is associated with and its
+// balance of each. An account the mirror node does not know holds no tokens.
+func (h *hederaService) GetTokenBalances(ctx context.Context, address string) ([]domain.TokenBalance, *domain.RPCError) {
+	h.logger.Info("Getting token balances", zap.String("address", address))
+
+	relationships, err := h.mClient.GetTokenRelationships(ctx, address)
+	if isNotFound(err) {
+		return []domain.TokenBalance{}, nil
+	}
+	if err != nil {
+		return nil, mirrorError(err, fmt.Sprintf("Failed to retrieve token balances of %s", address))
+	}
+
+	balances := make([]domain.TokenBalance, 0, len(relationships))
+	for _, relationship := range relationships {
+		balances = append(balances, domain.TokenBalance{
+			Token:                entityIdToAddress(relationship.TokenId),
+			TokenID:              relationship.TokenId,
+			Balance:              hexify(relationship.Balance),
+			Decimals:             hexify(relationship.Decimals),
+			AutomaticAssociation: relationship.AutomaticAssociation,
+			FreezeStatus:         relationship.FreezeStatus,
+			KycStatus:            relationship.KycStatus,
+		})
+	}
+
+	return balances, nil
+}
+
+// GetTokenInfo returns the metadata of the token at tokenAddress, the long-zero
+// address of its token ID, or nil when there is no such token.
+func (h *hederaService) GetTokenInfo(ctx context.Context, tokenAddress string) (*domain.TokenInfo, *domain.RPCError) {
+	h.logger.Info("Getting token info", zap.String("tokenAddress", tokenAddress))
+
+	tokenId, err := checkTokenId(tokenAddress)
+	if err != nil {
+		return nil, domain.NewInvalidParamsError(fmt.Sprintf("%s is not a token address", tokenAddress))
+	}
+
+	token, err := h.mClient.GetTokenById(ctx, *tokenId)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, mirrorError(err, fmt.Sprintf("Failed to retrieve token %s", *tokenId))
+	}
+
+	return &domain.TokenInfo{
+		Address:           entityIdToAddress(token.TokenId),
+		TokenID:           token.TokenId,
+		Name:              token.Name,
+		Symbol:            token.Symbol,
+		Decimals:          decimalToHex(token.Decimals.String()),
+		TotalSupply:       decimalToHex(token.TotalSupply.String()),
+		MaxSupply:         decimalToHex(token.MaxSupply.String()),
+		Type:              token.Type,
+		SupplyType:        token.SupplyType,
+		TreasuryAccountID: token.TreasuryAccountId,
+		Memo:              token.Memo,
+		PauseStatus:       token.PauseStatus,
+		FreezeDefault:     token.FreezeDefault,
+		Deleted:           token.Deleted,
+	}, nil
+}
+
+// entityIdToAddress returns the long-zero EVM address of a shard.realm.num
+// entity ID, or the ID unchanged when it is not one.
+func entityIdToAddress(entityId string) string {
+	parts := strings.Split(entityId, ".")
+	if len(parts) != 3 {
+		return entityId
+	}
+
+	var ids [3]uint64
+	for i, part := range parts {
+		id, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return entityId
+		}
+		ids[i] = id
+	}
+
+	return fmt.Sprintf("0x%08x%016x%016x", uint32(ids[0]), ids[1], ids[2])
+}
+
+// decimalToHex converts a decimal number sent by the mirror node to a 0x
+// prefixed hex quantity, treating a missing number as zero.
+func decimalToHex(decimal string) string {
+	n, ok := new(big.Int).SetString(decimal, 10)
+	if !ok {
+		return "0x0"
+	}
+	return "0x" + n.Text(16)
+}
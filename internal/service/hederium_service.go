@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	hederiumlogger "github.com/LimeChain/Hederium/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// RelayConfiguration describes a running relay, as returned by
+// hederium_getConfiguration.
+type RelayConfiguration struct {
+	Version    string                    `json:"version"`
+	ChainID    string                    `json:"chainId"`
+	MirrorNode MirrorNodeConfiguration   `json:"mirrorNode"`
+	Features   map[string]bool           `json:"features"`
+	RateLimits map[string]TierRateLimits `json:"rateLimits"`
+	Cache      CacheConfiguration        `json:"cache"`
+}
+
+type MirrorNodeConfiguration struct {
+	URLs    []string `json:"urls"`
+	Active  string   `json:"active"`
+	Version string   `json:"version,omitempty"`
+	// Web3URLs and Web3Active are only set when contract calls go to web3
+	// module URLs of their own.
+	Web3URLs   []string `json:"web3Urls,omitempty"`
+	Web3Active string   `json:"web3Active,omitempty"`
+}
+
+type TierRateLimits struct {
+	RequestsPerMinute      int      `json:"requestsPerMinute"`
+	HbarLimit              int      `json:"hbarLimit"`
+	ReadRequestsPerMinute  int      `json:"readRequestsPerMinute,omitempty"`
+	WriteRequestsPerMinute int      `json:"writeRequestsPerMinute,omitempty"`
+	AllowedMethods         []string `json:"allowedMethods,omitempty"`
+	DeniedMethods          []string `json:"deniedMethods,omitempty"`
+}
+
+type CacheConfiguration struct {
+	Backend string `json:"backend"`
+}
+
+// MirrorNodeURLs is the part of the mirror node client the configuration is
+// read from.
+type MirrorNodeURLs interface {
+	BaseURLs() []string
+	BaseURL() string
+	Web3URLs() []string
+	Web3URL() string
+	Version() string
+}
+
+type HederiumServicer interface {
+	GetConfiguration(ctx context.Context) (interface{}, *domain.RPCError)
+	// Enabled reports whether hederium_getConfiguration answers.
+	Enabled() bool
+	// RegisterFeature adds a runtime feature flag owned outside the service
+	// layer to the reported configuration.
+	RegisterFeature(name string, enabled func() bool)
+}
+
+type hederiumService struct {
+	logger             *zap.Logger
+	applicationVersion string
+	chainId            string
+	mirrorNode         MirrorNodeURLs
+	tieredLimiter      *limiter.TieredLimiter
+	cacheService       cache.CacheService
+	enabled            bool
+
+	mu       sync.Mutex
+	features map[string]func() bool
+}
+
+// NewHederiumService creates the service behind the hederium_* methods, which
+// only answer when enabled is set.
+func NewHederiumService(
+	logger *zap.Logger,
+	applicationVersion string,
+	chainId string,
+	mirrorNode MirrorNodeURLs,
+	tieredLimiter *limiter.TieredLimiter,
+	cacheService cache.CacheService,
+	enabled bool,
+) HederiumServicer {
+	return &hederiumService{
+		logger:             logger,
+		applicationVersion: applicationVersion,
+		chainId:            chainId,
+		mirrorNode:         mirrorNode,
+		tieredLimiter:      tieredLimiter,
+		cacheService:       cacheService,
+		enabled:            enabled,
+		features:           make(map[string]func() bool),
+	}
+}
+
+func (h *hederiumService) Enabled() bool {
+	return h.enabled
+}
+
+func (h *hederiumService) RegisterFeature(name string, enabled func() bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.features[name] = enabled
+}
+
+// GetConfiguration reports the version, network, mirror node, feature flags,
+// rate-limit tiers and cache backend of the relay. Credentials in mirror node
+// URLs are redacted.
+func (h *hederiumService) GetConfiguration(ctx context.Context) (interface{}, *domain.RPCError) {
+	h.logger.Debug("Getting relay configuration")
+
+	if !h.enabled {
+		return nil, domain.NewUnsupportedMethodError("hederium_getConfiguration")
+	}
+
+	configuration := RelayConfiguration{
+		Version:    h.applicationVersion,
+		ChainID:    h.chainId,
+		Features:   make(map[string]bool),
+		RateLimits: make(map[string]TierRateLimits),
+		Cache:      CacheConfiguration{Backend: cacheBackend(h.cacheService)},
+	}
+
+	if h.mirrorNode != nil {
+		configuration.MirrorNode.Active = hederiumlogger.RedactURL(h.mirrorNode.BaseURL())
+		configuration.MirrorNode.Version = h.mirrorNode.Version()
+		for _, u := range h.mirrorNode.BaseURLs() {
+			configuration.MirrorNode.URLs = append(configuration.MirrorNode.URLs, hederiumlogger.RedactURL(u))
+		}
+		if web3URLs := h.mirrorNode.Web3URLs(); len(web3URLs) > 0 {
+			configuration.MirrorNode.Web3Active = hederiumlogger.RedactURL(h.mirrorNode.Web3URL())
+			for _, u := range web3URLs {
+				configuration.MirrorNode.Web3URLs = append(configuration.MirrorNode.Web3URLs, hederiumlogger.RedactURL(u))
+			}
+		}
+	}
+
+	h.mu.Lock()
+	for name, enabled := range h.features {
+		configuration.Features[name] = enabled()
+	}
+	h.mu.Unlock()
+
+	if h.tieredLimiter != nil {
+		for name, tier := range h.tieredLimiter.Tiers() {
+			configuration.RateLimits[name] = TierRateLimits{
+				RequestsPerMinute:      tier.RequestsPerMinute,
+				HbarLimit:              tier.HbarLimit,
+				ReadRequestsPerMinute:  tier.ReadRequestsPerMinute,
+				WriteRequestsPerMinute: tier.WriteRequestsPerMinute,
+				AllowedMethods:         tier.AllowedMethods,
+				DeniedMethods:          tier.DeniedMethods,
+			}
+		}
+	}
+
+	return configuration, nil
+}
+
+func cacheBackend(cacheService cache.CacheService) string {
+	switch cacheService.(type) {
+	case *cache.MemoryCache:
+		return "memory"
+	case *cache.LRUCache:
+		return "lru"
+	case nil:
+		return "none"
+	default:
+		return "custom"
+	}
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
+	infrahedera "github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+)
+
+// blockLogOffsetsKey prefixes the cached log offsets of a block.
+const blockLogOffsetsKey = "block_log_offsets"
+
+// positionedLog is a log with the numbers it is ordered by.
+type positionedLog struct {
+	log              domain.Log
+	blockNumber      int64
+	transactionIndex int
+	index            int
+}
+
+// blockLogOffsets returns, for every transaction of the block that emitted
+// logs, how many logs the earlier transactions of the block emitted. Added to
+// the index of a log within its transaction, which is what the mirror node
+// reports, it gives the index of the log within the block, as Ethereum
+// clients expect. Blocks do not change, so the offsets are cached.
+func blockLogOffsets(ctx context.Context, mClient infrahedera.MirrorNodeClient, cacheService cache.CacheService, ttl time.Duration, blockNumber int64) (map[int]int, error) {
+	cacheKey := fmt.Sprintf("%s_%d", blockLogOffsetsKey, blockNumber)
+	var offsets map[int]int
+	if cacheService != nil {
+		if err := cacheService.Get(ctx, cacheKey, &offsets); err == nil && offsets != nil {
+			return offsets, nil
+		}
+	}
+
+	block, err := mClient.GetBlockByHashOrNumber(ctx, strconv.FormatInt(blockNumber, 10))
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %d: %w", blockNumber, infrahedera.ErrNotFound)
+	}
+	entries, err := mClient.GetContractResultsLogsWithRetry(ctx, map[string]interface{}{
+		"timestamp": fmt.Sprintf("gte:%s&timestamp=lte:%s", block.Timestamp.From, block.Timestamp.To),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int]int)
+	for _, entry := range entries {
+		if entry.TransactionIndex != nil {
+			counts[*entry.TransactionIndex]++
+		}
+	}
+	offsets = logOffsets(counts)
+
+	if cacheService != nil {
+		_ = cacheService.Set(ctx, cacheKey, offsets, ttl)
+	}
+	return offsets, nil
+}
+
+// logOffsets turns the number of logs each transaction of a block emitted
+// into the number of logs emitted before it.
+func logOffsets(counts map[int]int) map[int]int {
+	transactionIndexes := make([]int, 0, len(counts))
+	for transactionIndex := range counts {
+		transactionIndexes = append(transactionIndexes, transactionIndex)
+	}
+	sort.Ints(transactionIndexes)
+
+	offsets := make(map[int]int, len(counts))
+	total := 0
+	for _, transactionIndex := range transactionIndexes {
+		offsets[transactionIndex] = total
+		total += counts[transactionIndex]
+	}
+	return offsets
+}
+
+// indexLogsInBlocks numbers logs within their blocks rather than their
+// transactions and orders them by block number, transaction index and log
+// index. When complete is set logs hold every log of their blocks, so the
+// offsets of the transactions are counted from them; otherwise they are
+// fetched for the blocks with logs of transactions other than the first.
+func (s *commonService) indexLogsInBlocks(ctx context.Context, logs []domain.Log, complete bool) error {
+	positioned := make([]positionedLog, len(logs))
+	counts := make(map[int64]map[int]int)
+	for i, log := range logs {
+		p := positionedLog{log: log}
+		p.blockNumber, _ = HexToDec(log.BlockNumber)
+		transactionIndex, _ := HexToDec(log.TransactionIndex)
+		index, _ := HexToDec(log.LogIndex)
+		p.transactionIndex, p.index = int(transactionIndex), int(index)
+		positioned[i] = p
+
+		if complete || p.transactionIndex > 0 {
+			if counts[p.blockNumber] == nil {
+				counts[p.blockNumber] = make(map[int]int)
+			}
+			counts[p.blockNumber][p.transactionIndex]++
+		}
+	}
+
+	offsets := make(map[int64]map[int]int, len(counts))
+	if complete {
+		for blockNumber, blockCounts := range counts {
+			offsets[blockNumber] = logOffsets(blockCounts)
+		}
+	} else if err := s.fetchBlockLogOffsets(ctx, counts, offsets); err != nil {
+		return err
+	}
+
+	for i := range positioned {
+		p := &positioned[i]
+		p.index += offsets[p.blockNumber][p.transactionIndex]
+		p.log.LogIndex = hexify(int64(p.index))
+	}
+	sort.SliceStable(positioned, func(i, j int) bool {
+		a, b := positioned[i], positioned[j]
+		if a.blockNumber != b.blockNumber {
+			return a.blockNumber < b.blockNumber
+		}
+		if a.transactionIndex != b.transactionIndex {
+			return a.transactionIndex < b.transactionIndex
+		}
+		return a.index < b.index
+	})
+	for i, p := range positioned {
+		logs[i] = p.log
+	}
+	return nil
+}
+
+// fetchBlockLogOffsets fetches the log offsets of the blocks in counts into
+// offsets, up to blockLogOffsetsConcurrency blocks at a time.
+func (s *commonService) fetchBlockLogOffsets(ctx context.Context, counts map[int64]map[int]int, offsets map[int64]map[int]int) error {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var firstErr error
+	slots := make(chan struct{}, blockLogOffsetsConcurrency)
+	for blockNumber := range counts {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			blockOffsets, err := blockLogOffsets(ctx, s.mClient, s.cache, s.cacheTTLs.Of(cache.ClassBlock), blockNumber)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			offsets[blockNumber] = blockOffsets
+		}()
+	}
+	wg.Wait()
+
+	return firstErr
+}
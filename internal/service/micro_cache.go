@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+)
+
+// MicroCache keeps a single value in process for a very short time, in front
+// of the shared cache, for methods polled by every connected wallet. A value
+// older than ttl is still served at once for up to maxStale longer, while a
+// single background refresh replaces it. Only callers arriving after that
+// wait for a fetch, and concurrent ones share it.
+type MicroCache struct {
+	ttl      time.Duration
+	maxStale time.Duration
+
+	mu         sync.Mutex
+	value      interface{}
+	fetchedAt  time.Time
+	refreshing bool
+	// fetching is closed when the running foreground fetch completes.
+	fetching chan struct{}
+}
+
+// NewMicroCache creates a cache whose values are fresh for ttl and may be
+// served stale for maxStale after that.
+func NewMicroCache(ttl, maxStale time.Duration) *MicroCache {
+	return &MicroCache{ttl: ttl, maxStale: maxStale}
+}
+
+// Get returns the cached value, calling fetch when there is none or it is too
+// old. Failed fetches are not cached; a failed background refresh leaves the
+// stale value in place until it expires.
+func (c *MicroCache) Get(ctx context.Context, fetch func(context.Context) (interface{}, *domain.RPCError)) (interface{}, *domain.RPCError) {
+	c.mu.Lock()
+	for {
+		if c.value != nil {
+			age := time.Since(c.fetchedAt)
+			if age < c.ttl {
+				value := c.value
+				c.mu.Unlock()
+				return value, nil
+			}
+			if age < c.ttl+c.maxStale {
+				value := c.value
+				if !c.refreshing {
+					c.refreshing = true
+					go c.refresh(context.WithoutCancel(ctx), fetch)
+				}
+				c.mu.Unlock()
+				return value, nil
+			}
+		}
+
+		if c.fetching == nil {
+			break
+		}
+		// Another caller is already fetching, wait for its outcome
+		fetching := c.fetching
+		c.mu.Unlock()
+		select {
+		case <-fetching:
+		case <-ctx.Done():
+			return nil, domain.NewRequestTimeoutError()
+		}
+		// After a failed fetch, one of the waiters retries
+		c.mu.Lock()
+	}
+
+	fetching := make(chan struct{})
+	c.fetching = fetching
+	c.mu.Unlock()
+
+	value, errRpc := fetch(ctx)
+
+	c.mu.Lock()
+	c.store(value, errRpc)
+	c.fetching = nil
+	close(fetching)
+	c.mu.Unlock()
+
+	return value, errRpc
+}
+
+func (c *MicroCache) refresh(ctx context.Context, fetch func(context.Context) (interface{}, *domain.RPCError)) {
+	value, errRpc := fetch(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.store(value, errRpc)
+	c.refreshing = false
+}
+
+// store keeps a successfully fetched value. Callers must hold c.mu.
+func (c *MicroCache) store(value interface{}, errRpc *domain.RPCError) {
+	if errRpc != nil || value == nil {
+		return
+	}
+	c.value = value
+	c.fetchedAt = time.Now()
+}
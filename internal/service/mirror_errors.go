@@ -0,0 +1,34 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	infrahedera "github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+)
+
+// mirrorError maps an error from the mirror node client to the JSON-RPC error
+// returned to the caller. message describes what failed and is used for
+// errors that have no dedicated code.
+func mirrorError(err error, message string) *domain.RPCError {
+	var pageLimitErr *infrahedera.PageLimitError
+	switch {
+	case errors.As(err, &pageLimitErr):
+		return domain.NewPageLimitExceededError(pageLimitErr.MaxPages)
+	case errors.Is(err, infrahedera.ErrNotFound):
+		return domain.NewResourceNotFoundError(message)
+	case errors.Is(err, infrahedera.ErrRateLimited):
+		return domain.NewMirrorNodeRateLimitedError()
+	case errors.Is(err, infrahedera.ErrTimeout):
+		return domain.NewRequestTimeoutError()
+	case errors.Is(err, infrahedera.ErrUpstreamUnavailable):
+		return domain.NewMirrorNodeUpstreamFailError()
+	}
+	return domain.NewRPCError(domain.ServerError, message)
+}
+
+// isNotFound reports whether the mirror node answered that the requested
+// entity does not exist.
+func isNotFound(err error) bool {
+	return errors.Is(err, infrahedera.ErrNotFound)
+}
@@ -1,10 +1,16 @@
 package service
 
-import "go.uber.org/zap"
+import (
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
 
 type NetServicer interface {
 	Listening() bool
 	Version() string
+	PeerCount() string
 }
 
 type netService struct {
@@ -19,12 +25,24 @@ func NewNetService(log *zap.Logger, chainId string) NetServicer {
 	}
 }
 
-// Listening returns false because the Hedera network does not support listening.
+// Listening returns true, as the relay is always accepting requests while it
+// is able to answer this one.
 func (n *netService) Listening() bool {
-	return false
+	return true
 }
 
-// Version returns the chain ID.
+// Version returns the chain ID as a decimal string, as net_version requires.
+// The configured chain ID is returned unchanged if it is not valid hex.
 func (n *netService) Version() string {
-	return n.chainId
+	chainId, err := strconv.ParseUint(strings.TrimPrefix(n.chainId, "0x"), 16, 64)
+	if err != nil {
+		n.log.Warn("Chain ID is not a hex value, returning it as is", zap.String("chainId", n.chainId))
+		return n.chainId
+	}
+	return strconv.FormatUint(chainId, 10)
+}
+
+// PeerCount returns 0x0, since the relay does not take part in a peer-to-peer network.
+func (n *netService) PeerCount() string {
+	return "0x0"
 }
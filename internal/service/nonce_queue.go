@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// nonceQueueIdleTTL is how long the queue remembers the next nonce of a sender
+// that stopped sending transactions.
+const nonceQueueIdleTTL = 5 * time.Minute
+
+// NonceQueue orders the eth_sendRawTransaction submissions of each sender by
+// nonce. A transaction is submitted once every lower nonce of its sender went
+// through the queue, while a transaction whose nonce leaves a gap is held for
+// up to gapTimeout before it is submitted anyway. Submissions of one sender
+// never overlap, whereas different senders do not wait on each other.
+type NonceQueue struct {
+	mu         sync.Mutex
+	senders    map[string]*senderNonces
+	gapTimeout time.Duration
+	lastPrune  time.Time
+}
+
+type senderNonces struct {
+	// next is the lowest nonce of the sender not submitted yet.
+	next uint64
+	// busy is set while a transaction of the sender is being submitted.
+	busy     bool
+	waiting  int
+	lastUsed time.Time
+	// changed is closed and replaced whenever busy or next changes.
+	changed chan struct{}
+}
+
+// NewNonceQueue creates a queue holding gapped nonces for gapTimeout; a zero
+// timeout uses DefaultNonceGapTimeout.
+func NewNonceQueue(gapTimeout time.Duration) *NonceQueue {
+	if gapTimeout <= 0 {
+		gapTimeout = DefaultNonceGapTimeout
+	}
+	return &NonceQueue{
+		senders:    make(map[string]*senderNonces),
+		gapTimeout: gapTimeout,
+		lastPrune:  time.Now(),
+	}
+}
+
+// Acquire blocks until the transaction with nonce may be submitted for sender.
+// accountNonce is called for senders the queue does not know yet and should
+// return the nonce the network expects next. The caller must call release once
+// the submission is over, telling whether the transaction was accepted. An
+// error is only returned when ctx is done first.
+func (q *NonceQueue) Acquire(ctx context.Context, sender string, nonce uint64, accountNonce func() (uint64, error)) (release func(submitted bool), err error) {
+	sender = strings.ToLower(sender)
+
+	q.mu.Lock()
+	_, known := q.senders[sender]
+	q.mu.Unlock()
+
+	var next uint64
+	if !known {
+		if next, err = accountNonce(); err != nil {
+			// Without a starting point there is nothing to order against
+			next = nonce
+		}
+	}
+
+	deadline := time.Now().Add(q.gapTimeout)
+
+	q.mu.Lock()
+	s := q.sender(sender, next)
+	s.waiting++
+	for s.busy || (nonce > s.next && time.Now().Before(deadline)) {
+		changed, busy := s.changed, s.busy
+		q.mu.Unlock()
+
+		// While another submission is in flight only its end is awaited
+		timer := time.NewTimer(time.Until(deadline))
+		gapExpired := timer.C
+		if busy {
+			gapExpired = nil
+		}
+
+		select {
+		case <-changed:
+		case <-gapExpired:
+		case <-ctx.Done():
+			timer.Stop()
+			q.mu.Lock()
+			s.waiting--
+			q.mu.Unlock()
+			return nil, ctx.Err()
+		}
+		timer.Stop()
+
+		q.mu.Lock()
+	}
+	s.waiting--
+	s.busy = true
+	q.mu.Unlock()
+
+	return func(submitted bool) {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+
+		s.busy = false
+		if submitted && nonce >= s.next {
+			s.next = nonce + 1
+		}
+		s.lastUsed = time.Now()
+		close(s.changed)
+		s.changed = make(chan struct{})
+	}, nil
+}
+
+// sender returns the state of sender, creating it with next as the expected
+// nonce. Callers must hold q.mu.
+func (q *NonceQueue) sender(sender string, next uint64) *senderNonces {
+	now := time.Now()
+	if now.Sub(q.lastPrune) > nonceQueueIdleTTL {
+		for address, s := range q.senders {
+			if !s.busy && s.waiting == 0 && now.Sub(s.lastUsed) > nonceQueueIdleTTL {
+				delete(q.senders, address)
+			}
+		}
+		q.lastPrune = now
+	}
+
+	s, ok := q.senders[sender]
+	if !ok {
+		s = &senderNonces{next: next, changed: make(chan struct{})}
+		q.senders[sender] = s
+	}
+	s.lastUsed = now
+	return s
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
 	"github.com/LimeChain/Hederium/internal/infrastructure/hedera"
 	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
@@ -12,6 +14,10 @@ type ServiceProvider interface {
 	Web3Service() Web3Servicer
 	NetService() NetServicer
 	FilterService() FilterServicer
+	DebugService() DebugServicer
+	HederiumService() HederiumServicer
+	HederaService() HederaServicer
+	ExchangeRateService() ExchangeRateServicer
 }
 
 // For now we use *EthService instead of EthServicer
@@ -20,6 +26,10 @@ type serviceProvider struct {
 	web3Service   Web3Servicer
 	netService    NetServicer
 	filterService FilterServicer
+	debugService  DebugServicer
+	hederium      HederiumServicer
+	hedera        HederaServicer
+	exchangeRate  ExchangeRateServicer
 }
 
 func NewServiceProvider(
@@ -28,17 +38,56 @@ func NewServiceProvider(
 	log *zap.Logger,
 	applicationVersion string,
 	chainId string,
-	apiKeyStore *limiter.APIKeyStore,
+	apiKeyStore limiter.APIKeyStore,
 	tieredLimiter *limiter.TieredLimiter,
 	cacheService cache.CacheService,
+	config Config,
 ) ServiceProvider {
-	commonService := NewCommonService(mClient, log, cacheService)
-	ethService := NewEthService(hClient, mClient, commonService, log, tieredLimiter, chainId, cacheService)
+	var blockPoller *BlockPoller
+	if config.BlockPollerInterval > 0 && mClient != nil {
+		blockPoller = NewBlockPoller(mClient, log, config.BlockPollerInterval)
+	}
+
+	commonService := NewCommonService(mClient, log, cacheService, config.CacheTTLs, config.MaxLogResults, blockPoller)
+	ethService := NewEthService(hClient, mClient, commonService, log, tieredLimiter, chainId, cacheService, config)
+	if blockPoller != nil {
+		ethService.FollowBlockPoller(blockPoller)
+		go blockPoller.Run(context.Background())
+	}
 	web3Service := NewWeb3Service(log, applicationVersion)
 	netService := NewNetService(log, chainId)
-	filterService := NewFilterService(mClient, cacheService, log, commonService)
+	filterService := NewFilterService(mClient, cacheService, log, commonService, config.FilterAPIEnabled, config.FilterTTL)
+	if blockPoller != nil {
+		filterService.FollowBlockPoller(blockPoller)
+	}
+	debugService := NewDebugService(mClient, log, config.DebugAPIEnabled)
+	hederaService := NewHederaService(mClient, log)
+	exchangeRateService := NewExchangeRateService(mClient, cacheService, log)
+
+	var mirrorNode MirrorNodeURLs
+	if mClient != nil {
+		mirrorNode = mClient
+	}
+	hederiumService := NewHederiumService(log, applicationVersion, chainId, mirrorNode, tieredLimiter, cacheService, config.ConfigurationAPIEnabled)
+	hederiumService.RegisterFeature("filters", filterService.Enabled)
+	hederiumService.RegisterFeature("debug", debugService.Enabled)
+	hederiumService.RegisterFeature("callCache", func() bool { return ethService.CallCacheTTL() > 0 })
+	for name, enabled := range map[string]bool{
+		"estimateGasFallback":        config.EstimateGasFallback,
+		"syncingCheck":               config.SyncingCheckEnabled,
+		"asyncSendRawTransaction":    config.AsyncSendRawTransaction,
+		"warmReceiptCache":           config.WarmReceiptCache,
+		"nonceOrdering":              config.NonceOrderingEnabled,
+		"getCodeConsensusFallback":   config.GetCodeConsensusFallback,
+		"microCache":                 config.MicroCacheTTL > 0,
+		"checksumAddresses":          config.ChecksumAddresses,
+		"getStorageAtLatestFallback": config.GetStorageAtLatestFallback,
+		"blockPoller":                blockPoller != nil,
+	} {
+		hederiumService.RegisterFeature(name, func() bool { return enabled })
+	}
 
-	return &serviceProvider{ethService: ethService, web3Service: web3Service, netService: netService, filterService: filterService}
+	return &serviceProvider{ethService: ethService, web3Service: web3Service, netService: netService, filterService: filterService, debugService: debugService, hederium: hederiumService, hedera: hederaService, exchangeRate: exchangeRateService}
 }
 
 func (s *serviceProvider) EthService() *EthService {
@@ -56,3 +105,19 @@ func (s *serviceProvider) NetService() NetServicer {
 func (s *serviceProvider) FilterService() FilterServicer {
 	return s.filterService
 }
+
+func (s *serviceProvider) DebugService() DebugServicer {
+	return s.debugService
+}
+
+func (s *serviceProvider) HederiumService() HederiumServicer {
+	return s.hederium
+}
+
+func (s *serviceProvider) HederaService() HederaServicer {
+	return s.hedera
+}
+
+func (s *serviceProvider) ExchangeRateService() ExchangeRateServicer {
+	return s.exchangeRate
+}
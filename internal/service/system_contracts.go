@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	infrahedera "github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	"github.com/LimeChain/Hederium/internal/util"
+	"go.uber.org/zap"
+)
+
+const (
+	exchangeRateSystemContractAddress = "0x0000000000000000000000000000000000000168"
+	prngSystemContractAddress         = "0x0000000000000000000000000000000000000169"
+
+	// htsSuccess is the SUCCESS response code HTS system contract functions
+	// return ahead of their results.
+	htsSuccess = 22
+	// abiWordSize is the size in bytes of an ABI encoded argument or result.
+	abiWordSize = 32
+)
+
+// systemContracts names the Hedera system contracts eth_call detects, by
+// address.
+var systemContracts = map[string]string{
+	iHTSAddress:                       "HTS",
+	exchangeRateSystemContractAddress: "exchange rate",
+	prngSystemContractAddress:         "PRNG",
+}
+
+// systemContractFunctions lists the selectors of the system contracts whose
+// interfaces are small enough to check calls against, so that calls of other
+// selectors fail with a clear error instead of an opaque one from the mirror
+// node. The HTS system contract has too many functions to list them.
+var systemContractFunctions = map[string]map[string]bool{
+	exchangeRateSystemContractAddress: {
+		selectorTinycentsToTinybars: true,
+		selectorTinybarsToTinycents: true,
+	},
+	prngSystemContractAddress: {
+		selector("getPseudorandomSeed()"): true,
+	},
+}
+
+// Selectors of the system contract functions answered from mirror node data.
+var (
+	selectorIsToken                     = selector("isToken(address)")
+	selectorGetTokenType                = selector("getTokenType(address)")
+	selectorGetTokenDefaultFreezeStatus = selector("getTokenDefaultFreezeStatus(address)")
+	selectorIsFrozen                    = selector("isFrozen(address,address)")
+	selectorIsKyc                       = selector("isKyc(address,address)")
+	selectorAllowance                   = selector("allowance(address,address,address)")
+	selectorTinycentsToTinybars         = selector("tinycentsToTinybars(uint256)")
+	selectorTinybarsToTinycents         = selector("tinybarsToTinycents(uint256)")
+)
+
+// selector returns the function selector of signature, in hex without 0x.
+func selector(signature string) string {
+	return hex.EncodeToString(util.Keccak256([]byte(signature))[:4])
+}
+
+// systemContractRead answers a read of a system contract function from mirror
+// node data, given its ABI encoded arguments. It returns the ABI encoded
+// result, or false to leave the call to the mirror node.
+type systemContractRead func(s *transactionService, ctx context.Context, args []byte) ([]byte, bool, error)
+
+// systemContractReads are the system contract functions answered from mirror
+// node data, by selector.
+var systemContractReads = map[string]systemContractRead{
+	selectorIsToken:                     (*transactionService).htsIsToken,
+	selectorGetTokenType:                (*transactionService).htsGetTokenType,
+	selectorGetTokenDefaultFreezeStatus: (*transactionService).htsGetTokenDefaultFreezeStatus,
+	selectorIsFrozen:                    (*transactionService).htsIsFrozen,
+	selectorIsKyc:                       (*transactionService).htsIsKyc,
+	selectorAllowance:                   (*transactionService).htsAllowance,
+	selectorTinycentsToTinybars:         (*transactionService).tinycentsToTinybars,
+	selectorTinybarsToTinycents:         (*transactionService).tinybarsToTinycents,
+}
+
+// systemContractCall handles an eth_call of a Hedera system contract. Calls
+// without a selector, and calls of selectors a system contract does not have,
+// fail at once. Common reads of the latest state are answered from mirror node
+// data, which the mirror node's EVM simulation often fails to serve. It
+// reports false for calls left to the mirror node.
+func (s *transactionService) systemContractCall(ctx context.Context, txObj *domain.TransactionCallObject, blockParam interface{}) (interface{}, *domain.RPCError, bool) {
+	address := strings.ToLower(txObj.To)
+	name, ok := systemContracts[address]
+	if !ok {
+		return nil, nil, false
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(callData(txObj), "0x"))
+	if err != nil || len(data) < 4 {
+		return nil, domain.NewInvalidParamsError(fmt.Sprintf("Calls to the %s system contract at %s need a function selector", name, address)), true
+	}
+	functionSelector := hex.EncodeToString(data[:4])
+	if functions, ok := systemContractFunctions[address]; ok && !functions[functionSelector] {
+		return nil, domain.NewSystemContractCallError(name, address, functionSelector, "the system contract has no such function"), true
+	}
+
+	read, ok := systemContractReads[functionSelector]
+	if !ok || !latestCallBlock(blockParam) {
+		return nil, nil, false
+	}
+	result, ok, err := read(s, ctx, data[4:])
+	if err != nil {
+		s.logger.Error("Failed to answer system contract call", zap.String("contract", name), zap.String("selector", functionSelector), zap.Error(err))
+		return nil, mirrorError(err, "Failed to post call"), true
+	}
+	if !ok {
+		return nil, nil, false
+	}
+
+	s.logger.Debug("Answered system contract call from mirror node data", zap.String("contract", name), zap.String("selector", functionSelector))
+	return "0x" + hex.EncodeToString(result), nil, true
+}
+
+// systemContractCallError describes a call of a system contract the mirror
+// node could not simulate, naming the function, rather than failing with the
+// generic error of failed calls. Reverts and unavailable mirror nodes are left
+// to callErrorToRPCError.
+func systemContractCallError(txObj *domain.TransactionCallObject, err error) (*domain.RPCError, bool) {
+	address := strings.ToLower(txObj.To)
+	name, ok := systemContracts[address]
+	if !ok {
+		return nil, false
+	}
+
+	var callErr *infrahedera.ContractCallError
+	if !errors.As(err, &callErr) || callErr.IsContractRevert() || (callErr.StatusCode >= http.StatusInternalServerError && callErr.StatusCode != http.StatusNotImplemented) {
+		return nil, false
+	}
+	reason := "the mirror node cannot simulate it"
+	if callErr.Detail != "" {
+		reason += ": " + callErr.Detail
+	} else if callErr.Message != "" {
+		reason += ": " + callErr.Message
+	}
+	functionSelector := strings.TrimPrefix(callData(txObj), "0x")
+	if len(functionSelector) > 8 {
+		functionSelector = functionSelector[:8]
+	}
+	return domain.NewSystemContractCallError(name, address, functionSelector, reason), true
+}
+
+// callData returns the input of a call, which may be sent as input or data.
+func callData(txObj *domain.TransactionCallObject) string {
+	if txObj.Input != "" {
+		return txObj.Input
+	}
+	return txObj.Data
+}
+
+// latestCallBlock reports whether a call is made against the latest state,
+// the only one mirror node data answers system contract reads for.
+func latestCallBlock(blockParam interface{}) bool {
+	if blockParam == nil {
+		return true
+	}
+	block, ok := blockParam.(string)
+	return ok && (block == domain.BlockTagLatest || block == domain.BlockTagPending)
+}
+
+func (s *transactionService) htsIsToken(ctx context.Context, args []byte) ([]byte, bool, error) {
+	tokenId, ok := abiTokenId(args, 0)
+	if !ok {
+		return nil, false, nil
+	}
+	_, err := s.mClient.GetTokenById(ctx, tokenId)
+	if isNotFound(err) {
+		return abiEncode(big.NewInt(htsSuccess), abiBool(false)), true, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return abiEncode(big.NewInt(htsSuccess), abiBool(true)), true, nil
+}
+
+func (s *transactionService) htsGetTokenType(ctx context.Context, args []byte) ([]byte, bool, error) {
+	token, ok, err := s.abiToken(ctx, args, 0)
+	if !ok || err != nil {
+		return nil, false, err
+	}
+	tokenType := int64(0)
+	if token.Type == "NON_FUNGIBLE_UNIQUE" {
+		tokenType = 1
+	}
+	return abiEncode(big.NewInt(htsSuccess), big.NewInt(tokenType)), true, nil
+}
+
+func (s *transactionService) htsGetTokenDefaultFreezeStatus(ctx context.Context, args []byte) ([]byte, bool, error) {
+	token, ok, err := s.abiToken(ctx, args, 0)
+	if !ok || err != nil {
+		return nil, false, err
+	}
+	return abiEncode(big.NewInt(htsSuccess), abiBool(token.FreezeDefault)), true, nil
+}
+
+func (s *transactionService) htsIsFrozen(ctx context.Context, args []byte) ([]byte, bool, error) {
+	relationship, ok, err := s.abiTokenRelationship(ctx, args)
+	if !ok || err != nil {
+		return nil, false, err
+	}
+	return abiEncode(big.NewInt(htsSuccess), abiBool(relationship.FreezeStatus == "FROZEN")), true, nil
+}
+
+func (s *transactionService) htsIsKyc(ctx context.Context, args []byte) ([]byte, bool, error) {
+	relationship, ok, err := s.abiTokenRelationship(ctx, args)
+	if !ok || err != nil {
+		return nil, false, err
+	}
+	return abiEncode(big.NewInt(htsSuccess), abiBool(relationship.KycStatus == "GRANTED")), true, nil
+}
+
+// htsAllowance answers allowance(token, owner, spender) with what is left of
+// the allowance, 0 when none was granted.
+func (s *transactionService) htsAllowance(ctx context.Context, args []byte) ([]byte, bool, error) {
+	tokenId, ok := abiTokenId(args, 0)
+	if !ok {
+		return nil, false, nil
+	}
+	owner, ownerOk := abiAddress(args, 1)
+	spenderAddress, spenderOk := abiAddress(args, 2)
+	if !ownerOk || !spenderOk {
+		return nil, false, nil
+	}
+
+	// The mirror node takes the spender by ID only
+	spender, err := s.mClient.GetAccountById(ctx, spenderAddress)
+	if isNotFound(err) || err == nil && (spender == nil || spender.Account == "") {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	allowance, err := s.mClient.GetTokenAllowance(ctx, owner, spender.Account, tokenId)
+	if isNotFound(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	amount := int64(0)
+	if allowance != nil {
+		amount = allowance.Amount
+	}
+	return abiEncode(big.NewInt(htsSuccess), big.NewInt(amount)), true, nil
+}
+
+// tinycentsToTinybars converts at the current exchange rate, as the exchange
+// rate system contract does.
+func (s *transactionService) tinycentsToTinybars(ctx context.Context, args []byte) ([]byte, bool, error) {
+	return s.convertAtExchangeRate(ctx, args, func(rate domain.Rate) (int64, int64) { return rate.HbarEquivalent, rate.CentEquivalent })
+}
+
+func (s *transactionService) tinybarsToTinycents(ctx context.Context, args []byte) ([]byte, bool, error) {
+	return s.convertAtExchangeRate(ctx, args, func(rate domain.Rate) (int64, int64) { return rate.CentEquivalent, rate.HbarEquivalent })
+}
+
+// convertAtExchangeRate multiplies the amount in args by the ratio terms
+// returns for the current exchange rate, rounding down.
+func (s *transactionService) convertAtExchangeRate(ctx context.Context, args []byte, terms func(rate domain.Rate) (int64, int64)) ([]byte, bool, error) {
+	amount, ok := abiUint(args, 0)
+	if !ok {
+		return nil, false, nil
+	}
+	rate, err := s.mClient.GetExchangeRate(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	numerator, denominator := terms(rate.CurrentRate)
+	if numerator <= 0 || denominator <= 0 {
+		return nil, false, nil
+	}
+
+	converted := new(big.Int).Mul(amount, big.NewInt(numerator))
+	converted.Div(converted, big.NewInt(denominator))
+	return abiEncode(converted), true, nil
+}
+
+// abiToken returns the token whose address is the argument at index, or false
+// when it is no token the mirror node knows.
+func (s *transactionService) abiToken(ctx context.Context, args []byte, index int) (*domain.TokenResponse, bool, error) {
+	tokenId, ok := abiTokenId(args, index)
+	if !ok {
+		return nil, false, nil
+	}
+	token, err := s.mClient.GetTokenById(ctx, tokenId)
+	if isNotFound(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return token, true, nil
+}
+
+// abiTokenRelationship returns the relationship between the token and the
+// account passed as the first two arguments, or false when the account is not
+// associated with the token.
+func (s *transactionService) abiTokenRelationship(ctx context.Context, args []byte) (*domain.TokenRelationship, bool, error) {
+	tokenId, ok := abiTokenId(args, 0)
+	if !ok {
+		return nil, false, nil
+	}
+	account, ok := abiAddress(args, 1)
+	if !ok {
+		return nil, false, nil
+	}
+
+	relationships, err := s.mClient.GetTokenRelationships(ctx, account)
+	if isNotFound(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	for i := range relationships {
+		if relationships[i].TokenId == tokenId {
+			return &relationships[i], true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// abiAddress returns the address argument at index.
+func abiAddress(args []byte, index int) (string, bool) {
+	if len(args) < (index+1)*abiWordSize {
+		return "", false
+	}
+	word := args[index*abiWordSize : (index+1)*abiWordSize]
+	return "0x" + hex.EncodeToString(word[abiWordSize-20:]), true
+}
+
+// abiTokenId returns the ID of the token whose long-zero address is the
+// argument at index.
+func abiTokenId(args []byte, index int) (string, bool) {
+	address, ok := abiAddress(args, index)
+	if !ok {
+		return "", false
+	}
+	tokenId, err := checkTokenId(address)
+	if err != nil {
+		return "", false
+	}
+	return *tokenId, true
+}
+
+// abiUint returns the uint256 argument at index.
+func abiUint(args []byte, index int) (*big.Int, bool) {
+	if len(args) < (index+1)*abiWordSize {
+		return nil, false
+	}
+	return new(big.Int).SetBytes(args[index*abiWordSize : (index+1)*abiWordSize]), true
+}
+
+func abiBool(value bool) *big.Int {
+	if value {
+		return big.NewInt(1)
+	}
+	return big.NewInt(0)
+}
+
+// abiEncode encodes non-negative values as consecutive ABI words.
+func abiEncode(values ...*big.Int) []byte {
+	encoded := make([]byte, len(values)*abiWordSize)
+	for i, value := range values {
+		value.FillBytes(encoded[i*abiWordSize : (i+1)*abiWordSize])
+	}
+	return encoded
+}
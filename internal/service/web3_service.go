@@ -1,10 +1,17 @@
 package service
 
-import "go.uber.org/zap"
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/util"
+	"go.uber.org/zap"
+)
 
-// Web3 interface remains the same.
 type Web3Servicer interface {
 	ClientVersion() string
+	Sha3(data string) (string, *domain.RPCError)
 }
 
 type web3Service struct {
@@ -19,8 +26,8 @@ func NewWeb3Service(log *zap.Logger, applicationVersion string) Web3Servicer {
 	}
 }
 
-// ClientVersion returns "relay/<version>" where version is read from application.version in config.
-// If application.version is not set, returns "relay/unknown".
+// ClientVersion returns "hederium/<version>" where version is read from application.version in config.
+// If application.version is not set, returns "hederium/unknown".
 func (w *web3Service) ClientVersion() string {
 	w.log.Debug("Getting client version")
 
@@ -30,7 +37,18 @@ func (w *web3Service) ClientVersion() string {
 		version = "unknown"
 	}
 
-	clientVersion := "relay/" + version
+	clientVersion := "hederium/" + version
 	w.log.Debug("Returning client version", zap.String("version", clientVersion))
 	return clientVersion
 }
+
+// Sha3 returns the Keccak-256 hash of the given 0x-prefixed hex data.
+func (w *web3Service) Sha3(data string) (string, *domain.RPCError) {
+	decoded, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	if err != nil {
+		w.log.Debug("Invalid hex data for web3_sha3", zap.String("data", data), zap.Error(err))
+		return "", domain.NewInvalidParamsError("data must be a hex string")
+	}
+
+	return "0x" + hex.EncodeToString(util.Keccak256(decoded)), nil
+}
@@ -0,0 +1,414 @@
+// Package grpc_server serves the JSON-RPC methods of the relay over gRPC, for
+// services that prefer protobuf messages to JSON over HTTP. Calls go through
+// the same handler as the JSON-RPC endpoint, so they share its services,
+// caches, limits and timeouts.
+package grpc_server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	hederiumlogger "github.com/LimeChain/Hederium/internal/infrastructure/logger"
+	"github.com/LimeChain/Hederium/internal/transport/rpc"
+	"github.com/LimeChain/Hederium/pkg/relaypb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// APIKeyMetadata carries the API key of a call when API keys are
+	// enforced, as the X-API-KEY header does over HTTP. A bearer token in
+	// AuthorizationMetadata is accepted as well.
+	APIKeyMetadata        = "x-api-key"
+	AuthorizationMetadata = "authorization"
+	// RequestIDMetadata carries the request ID of a call, kept when the
+	// client sends a valid one and echoed in the response header.
+	RequestIDMetadata = "x-request-id"
+	// ErrorCodeMetadata and ErrorDataMetadata carry the JSON-RPC error code
+	// and data of a failed call in the trailer, as gRPC status codes are
+	// coarser.
+	ErrorCodeMetadata = "x-jsonrpc-error-code"
+	ErrorDataMetadata = "x-jsonrpc-error-data"
+)
+
+// Config controls the gRPC listener. It is off unless enabled.
+type Config struct {
+	Enabled bool
+	Address string
+}
+
+// unmarshalOptions skip the fields of results the messages leave out, such
+// as the yParity of transactions.
+var unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+
+type relayServer struct {
+	relaypb.UnimplementedRelayServer
+	handler rpc.RPCHandler
+}
+
+type interceptors struct {
+	logger        *zap.Logger
+	apiKeyStore   limiter.APIKeyStore
+	tieredLimiter *limiter.TieredLimiter
+	admission     *limiter.AdmissionQueue
+}
+
+// NewServer creates a gRPC server answering the Relay service with handler.
+// Calls are admitted through admission, which may be nil, and when
+// enforceAPIKey is set must carry an API key within the rate limit of its
+// tier.
+func NewServer(
+	handler rpc.RPCHandler,
+	logger *zap.Logger,
+	apiKeyStore limiter.APIKeyStore,
+	tieredLimiter *limiter.TieredLimiter,
+	enforceAPIKey bool,
+	admission *limiter.AdmissionQueue,
+	opts ...grpc.ServerOption,
+) *grpc.Server {
+	i := &interceptors{
+		logger:        logger,
+		apiKeyStore:   apiKeyStore,
+		tieredLimiter: tieredLimiter,
+		admission:     admission,
+	}
+	chain := []grpc.UnaryServerInterceptor{i.requestID, i.admit}
+	if enforceAPIKey {
+		chain = append(chain, i.authAndRateLimit)
+	}
+
+	s := grpc.NewServer(append([]grpc.ServerOption{grpc.ChainUnaryInterceptor(chain...)}, opts...)...)
+	relaypb.RegisterRelayServer(s, &relayServer{handler: handler})
+	return s
+}
+
+func (s *relayServer) ChainId(ctx context.Context, _ *relaypb.ChainIdRequest) (*relaypb.QuantityResponse, error) {
+	resp := &relaypb.QuantityResponse{}
+	return resp, s.invoke(ctx, "eth_chainId", []interface{}{}, "value", resp)
+}
+
+func (s *relayServer) BlockNumber(ctx context.Context, _ *relaypb.BlockNumberRequest) (*relaypb.QuantityResponse, error) {
+	resp := &relaypb.QuantityResponse{}
+	return resp, s.invoke(ctx, "eth_blockNumber", []interface{}{}, "value", resp)
+}
+
+func (s *relayServer) GasPrice(ctx context.Context, _ *relaypb.GasPriceRequest) (*relaypb.QuantityResponse, error) {
+	resp := &relaypb.QuantityResponse{}
+	return resp, s.invoke(ctx, "eth_gasPrice", []interface{}{}, "value", resp)
+}
+
+func (s *relayServer) GetBalance(ctx context.Context, req *relaypb.AccountRequest) (*relaypb.QuantityResponse, error) {
+	resp := &relaypb.QuantityResponse{}
+	return resp, s.invoke(ctx, "eth_getBalance", accountParams(req), "value", resp)
+}
+
+func (s *relayServer) GetTransactionCount(ctx context.Context, req *relaypb.AccountRequest) (*relaypb.QuantityResponse, error) {
+	resp := &relaypb.QuantityResponse{}
+	return resp, s.invoke(ctx, "eth_getTransactionCount", accountParams(req), "value", resp)
+}
+
+func (s *relayServer) GetCode(ctx context.Context, req *relaypb.AccountRequest) (*relaypb.DataResponse, error) {
+	resp := &relaypb.DataResponse{}
+	return resp, s.invoke(ctx, "eth_getCode", accountParams(req), "data", resp)
+}
+
+func (s *relayServer) GetBlockByNumber(ctx context.Context, req *relaypb.GetBlockByNumberRequest) (*relaypb.BlockResponse, error) {
+	return s.block(ctx, "eth_getBlockByNumber", []interface{}{orLatest(req.GetBlock()), req.GetFullTransactions()}, req.GetFullTransactions())
+}
+
+func (s *relayServer) GetBlockByHash(ctx context.Context, req *relaypb.GetBlockByHashRequest) (*relaypb.BlockResponse, error) {
+	return s.block(ctx, "eth_getBlockByHash", []interface{}{req.GetHash(), req.GetFullTransactions()}, req.GetFullTransactions())
+}
+
+func (s *relayServer) GetTransactionByHash(ctx context.Context, req *relaypb.TransactionHashRequest) (*relaypb.TransactionResponse, error) {
+	resp := &relaypb.TransactionResponse{}
+	return resp, s.invoke(ctx, "eth_getTransactionByHash", []interface{}{req.GetHash()}, "transaction", resp)
+}
+
+func (s *relayServer) GetTransactionReceipt(ctx context.Context, req *relaypb.TransactionHashRequest) (*relaypb.ReceiptResponse, error) {
+	resp := &relaypb.ReceiptResponse{}
+	return resp, s.invoke(ctx, "eth_getTransactionReceipt", []interface{}{req.GetHash()}, "receipt", resp)
+}
+
+func (s *relayServer) GetLogs(ctx context.Context, req *relaypb.GetLogsRequest) (*relaypb.GetLogsResponse, error) {
+	filter := map[string]interface{}{}
+	setIfPresent(filter, "fromBlock", req.GetFromBlock())
+	setIfPresent(filter, "toBlock", req.GetToBlock())
+	setIfPresent(filter, "blockHash", req.GetBlockHash())
+	if len(req.GetAddresses()) > 0 {
+		filter["address"] = req.GetAddresses()
+	}
+	if len(req.GetTopics()) > 0 {
+		topics := make([]interface{}, len(req.GetTopics()))
+		for i, position := range req.GetTopics() {
+			// A position without values matches any topic
+			if len(position.GetValues()) > 0 {
+				topics[i] = position.GetValues()
+			}
+		}
+		filter["topics"] = topics
+	}
+
+	resp := &relaypb.GetLogsResponse{}
+	return resp, s.invoke(ctx, "eth_getLogs", []interface{}{filter}, "logs", resp)
+}
+
+func (s *relayServer) Call(ctx context.Context, req *relaypb.CallRequest) (*relaypb.DataResponse, error) {
+	resp := &relaypb.DataResponse{}
+	return resp, s.invoke(ctx, "eth_call", []interface{}{callObject(req), orLatest(req.GetBlock())}, "data", resp)
+}
+
+func (s *relayServer) EstimateGas(ctx context.Context, req *relaypb.CallRequest) (*relaypb.QuantityResponse, error) {
+	params := []interface{}{callObject(req)}
+	if req.GetBlock() != "" {
+		params = append(params, req.GetBlock())
+	}
+	resp := &relaypb.QuantityResponse{}
+	return resp, s.invoke(ctx, "eth_estimateGas", params, "value", resp)
+}
+
+func (s *relayServer) SendRawTransaction(ctx context.Context, req *relaypb.SendRawTransactionRequest) (*relaypb.TransactionHashResponse, error) {
+	resp := &relaypb.TransactionHashResponse{}
+	return resp, s.invoke(ctx, "eth_sendRawTransaction", []interface{}{req.GetData()}, "hash", resp)
+}
+
+func (s *relayServer) Invoke(ctx context.Context, req *relaypb.InvokeRequest) (*relaypb.InvokeResponse, error) {
+	params := []interface{}{}
+	if req.GetParamsJson() != "" {
+		if err := json.Unmarshal([]byte(req.GetParamsJson()), &params); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "params_json must be a JSON array: %v", err)
+		}
+	}
+
+	result, err := s.call(ctx, req.GetMethod(), params)
+	if err != nil {
+		return nil, err
+	}
+	return &relaypb.InvokeResponse{ResultJson: string(result)}, nil
+}
+
+// call answers method with the JSON-RPC handler, returning its result as
+// JSON or its error as a gRPC status.
+func (s *relayServer) call(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+	resp := s.handler.HandleRequest(ctx, &rpc.JSONRPCRequest{JSONRPC: "2.0", Method: method, Params: params, ID: 1})
+	if resp.Error != nil {
+		return nil, statusError(ctx, resp.Error)
+	}
+
+	result, err := json.Marshal(resp.Result)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode the result of %s: %v", method, err)
+	}
+	return result, nil
+}
+
+// invoke answers method and decodes its result into field of resp. A null
+// result leaves the field unset.
+func (s *relayServer) invoke(ctx context.Context, method string, params []interface{}, field string, resp proto.Message) error {
+	result, err := s.call(ctx, method, params)
+	if err != nil {
+		return err
+	}
+	return decodeField(method, field, result, resp)
+}
+
+// block answers a block method. The transactions of a block are hashes or
+// whole transactions, which the Block message keeps in separate fields.
+func (s *relayServer) block(ctx context.Context, method string, params []interface{}, fullTransactions bool) (*relaypb.BlockResponse, error) {
+	result, err := s.call(ctx, method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &relaypb.BlockResponse{}
+	var block map[string]json.RawMessage
+	if err := json.Unmarshal(result, &block); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decode the result of %s: %v", method, err)
+	}
+	if block == nil {
+		return resp, nil
+	}
+	if transactions, ok := block["transactions"]; ok && !fullTransactions {
+		block["transactionHashes"] = transactions
+		delete(block, "transactions")
+	}
+
+	result, err = json.Marshal(block)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode the result of %s: %v", method, err)
+	}
+	return resp, decodeField(method, "block", result, resp)
+}
+
+// decodeField decodes the JSON result of method into field of resp.
+func decodeField(method, field string, result json.RawMessage, resp proto.Message) error {
+	wrapped, err := json.Marshal(map[string]json.RawMessage{field: result})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to encode the result of %s: %v", method, err)
+	}
+	if err := unmarshalOptions.Unmarshal(wrapped, resp); err != nil {
+		return status.Errorf(codes.Internal, "failed to decode the result of %s: %v", method, err)
+	}
+	return nil
+}
+
+// statusError turns a JSON-RPC error into a gRPC status, sending its code and
+// data in the trailer.
+func statusError(ctx context.Context, rpcErr *domain.RPCError) error {
+	trailer := metadata.Pairs(ErrorCodeMetadata, strconv.Itoa(rpcErr.Code))
+	if rpcErr.Data != "" {
+		trailer.Append(ErrorDataMetadata, rpcErr.Data)
+	}
+	_ = grpc.SetTrailer(ctx, trailer)
+
+	return status.Error(statusCode(rpcErr.Code), rpcErr.Message)
+}
+
+// statusCode returns the gRPC status code closest to a JSON-RPC error code.
+func statusCode(code int) codes.Code {
+	switch code {
+	case domain.InvalidParams, domain.InvalidRequest, domain.ParseError, domain.InvalidBlockRange, domain.InvalidTimestampRange:
+		return codes.InvalidArgument
+	case domain.MethodNotFound:
+		return codes.Unimplemented
+	case domain.MethodNotAllowed:
+		return codes.PermissionDenied
+	case domain.LimitExceeded, domain.RequestRateLimitExceeded, domain.HbarRateLimitExceeded, domain.MirrorNodeRateLimited:
+		return codes.ResourceExhausted
+	case domain.ContractRevert, domain.ExecutionError:
+		return codes.Aborted
+	case domain.NonceTooLow, domain.GasPriceTooLow, domain.InsufficientFunds, domain.IntrinsicGasTooLow, domain.OversizedData, domain.UnsupportedTransactionType:
+		return codes.FailedPrecondition
+	case domain.RequestTimeout:
+		return codes.DeadlineExceeded
+	case domain.MirrorNodeUpstreamFail:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// requestID assigns every call a request ID, keeping a valid one sent by the
+// client.
+func (i *interceptors) requestID(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	id := firstMetadata(ctx, RequestIDMetadata)
+	if !hederiumlogger.ValidRequestID(id) {
+		id = hederiumlogger.NewRequestID()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadata, id))
+	return handler(hederiumlogger.WithRequestID(ctx, id), req)
+}
+
+// admit holds calls until the admission queue has a worker slot for them,
+// sharing the slots with the JSON-RPC endpoint.
+func (i *interceptors) admit(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	release, err := i.admission.Admit(ctx)
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return nil, status.FromContextError(err).Err()
+		}
+		return nil, statusError(ctx, domain.NewAdmissionRejectedError())
+	}
+	defer release()
+
+	return handler(ctx, req)
+}
+
+// authAndRateLimit requires an API key known to the store and within the
+// rate limit of its tier, reporting the limit in the response header.
+func (i *interceptors) authAndRateLimit(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	apiKey := apiKeyFromMetadata(ctx)
+	if apiKey == "" {
+		return nil, status.Error(codes.Unauthenticated, "API key required")
+	}
+
+	keyHash, tier, exists := i.apiKeyStore.GetTierForKey(apiKey)
+	if !exists {
+		return nil, status.Error(codes.PermissionDenied, "Invalid API key")
+	}
+
+	rateLimit, allowed := i.tieredLimiter.Allow(keyHash, tier)
+	if !rateLimit.Reset.IsZero() {
+		_ = grpc.SetHeader(ctx, metadata.Pairs(
+			"x-ratelimit-limit", strconv.Itoa(rateLimit.Limit),
+			"x-ratelimit-remaining", strconv.Itoa(rateLimit.Remaining),
+			"x-ratelimit-reset", strconv.FormatInt(rateLimit.Reset.Unix(), 10),
+		))
+	}
+	if !allowed {
+		retryAfter := int((time.Until(rateLimit.Reset) + time.Second - 1) / time.Second)
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(retryAfter)))
+		i.logger.Debug("gRPC call rate limited", zap.String("method", info.FullMethod), zap.String("tier", tier))
+		return nil, statusError(ctx, domain.NewRequestRateLimitExceededError(rateLimit.Limit, retryAfter))
+	}
+
+	resp, err := handler(limiter.WithAPIKey(ctx, keyHash, tier), req)
+	if msg, ok := resp.(proto.Message); ok && err == nil {
+		i.tieredLimiter.Usage().RecordBytes(keyHash, tier, int64(proto.Size(msg)))
+	}
+	return resp, err
+}
+
+// accountParams returns the params of the methods reading account state.
+func accountParams(req *relaypb.AccountRequest) []interface{} {
+	return []interface{}{req.GetAddress(), orLatest(req.GetBlock())}
+}
+
+// callObject returns the call object of eth_call and eth_estimateGas,
+// leaving out the fields not set.
+func callObject(req *relaypb.CallRequest) map[string]interface{} {
+	call := map[string]interface{}{}
+	setIfPresent(call, "from", req.GetFrom())
+	setIfPresent(call, "to", req.GetTo())
+	setIfPresent(call, "gas", req.GetGas())
+	setIfPresent(call, "gasPrice", req.GetGasPrice())
+	setIfPresent(call, "value", req.GetValue())
+	setIfPresent(call, "data", req.GetData())
+	return call
+}
+
+func setIfPresent(m map[string]interface{}, key, value string) {
+	if value != "" {
+		m[key] = value
+	}
+}
+
+func orLatest(block string) string {
+	if block == "" {
+		return "latest"
+	}
+	return block
+}
+
+// apiKeyFromMetadata returns the API key of a call, taken from x-api-key or a
+// bearer token in authorization.
+func apiKeyFromMetadata(ctx context.Context) string {
+	if apiKey := firstMetadata(ctx, APIKeyMetadata); apiKey != "" {
+		return apiKey
+	}
+	if scheme, token, ok := strings.Cut(firstMetadata(ctx, AuthorizationMetadata), " "); ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	return ""
+}
+
+func firstMetadata(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
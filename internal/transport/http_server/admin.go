@@ -0,0 +1,253 @@
+package http_server
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	"github.com/LimeChain/Hederium/internal/service"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AdminConfig controls the /admin endpoints. They are only registered when
+// Enabled is set and an APIKey is configured.
+type AdminConfig struct {
+	Enabled bool
+	APIKey  string
+}
+
+// FeatureFlag exposes a feature that can be switched on or off at runtime.
+type FeatureFlag struct {
+	Enabled    func() bool
+	SetEnabled func(enabled bool)
+}
+
+// AdminAPI serves the operator endpoints for inspecting and adjusting the
+// relay without a restart. Every request must carry the admin key in the
+// X-ADMIN-KEY header.
+type AdminAPI struct {
+	apiKey        string
+	logger        *zap.Logger
+	apiKeyStore   limiter.APIKeyStore
+	tieredLimiter *limiter.TieredLimiter
+	cacheService  cache.CacheService
+	exchangeRate  service.ExchangeRateServicer
+	features      map[string]FeatureFlag
+}
+
+type tierLimits struct {
+	RequestsPerMinute      *int     `json:"requestsPerMinute" binding:"required,min=0"`
+	HbarLimit              *int     `json:"hbarLimit" binding:"required,min=0"`
+	ReadRequestsPerMinute  *int     `json:"readRequestsPerMinute,omitempty" binding:"omitempty,min=0"`
+	WriteRequestsPerMinute *int     `json:"writeRequestsPerMinute,omitempty" binding:"omitempty,min=0"`
+	AllowedMethods         []string `json:"allowedMethods,omitempty"`
+	DeniedMethods          []string `json:"deniedMethods,omitempty"`
+}
+
+// limitsOf returns the limits of tc as the admin endpoints report them,
+// leaving out the read and write buckets it does not set.
+func limitsOf(tc limiter.TierConfig) tierLimits {
+	limits := tierLimits{
+		RequestsPerMinute: &tc.RequestsPerMinute,
+		HbarLimit:         &tc.HbarLimit,
+		AllowedMethods:    tc.AllowedMethods,
+		DeniedMethods:     tc.DeniedMethods,
+	}
+	if tc.ReadRequestsPerMinute > 0 {
+		limits.ReadRequestsPerMinute = &tc.ReadRequestsPerMinute
+	}
+	if tc.WriteRequestsPerMinute > 0 {
+		limits.WriteRequestsPerMinute = &tc.WriteRequestsPerMinute
+	}
+	return limits
+}
+
+type apiKeyUsage struct {
+	limiter.APIKey
+	Active             bool  `json:"active"`
+	RequestsThisMinute int   `json:"requestsThisMinute"`
+	HbarSpent          int64 `json:"hbarSpent"`
+}
+
+func NewAdminAPI(
+	apiKey string,
+	logger *zap.Logger,
+	apiKeyStore limiter.APIKeyStore,
+	tieredLimiter *limiter.TieredLimiter,
+	cacheService cache.CacheService,
+	exchangeRate service.ExchangeRateServicer,
+	features map[string]FeatureFlag,
+) *AdminAPI {
+	return &AdminAPI{
+		apiKey:        apiKey,
+		logger:        logger,
+		apiKeyStore:   apiKeyStore,
+		tieredLimiter: tieredLimiter,
+		cacheService:  cacheService,
+		exchangeRate:  exchangeRate,
+		features:      features,
+	}
+}
+
+// RegisterRoutes mounts the admin endpoints under /admin.
+func (a *AdminAPI) RegisterRoutes(router gin.IRouter) {
+	admin := router.Group("/admin", a.authMiddleware())
+	admin.GET("/limits", a.getLimits)
+	admin.PUT("/limits/:tier", a.setLimits)
+	admin.GET("/hbar", a.getHbarBudget)
+	admin.GET("/apikeys", a.getAPIKeys)
+	admin.DELETE("/cache", a.flushCache)
+	admin.GET("/features", a.getFeatures)
+	admin.PUT("/features", a.setFeatures)
+}
+
+func (a *AdminAPI) authMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("X-ADMIN-KEY")
+		if key == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Admin key required"})
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(key), []byte(a.apiKey)) != 1 {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Invalid admin key"})
+			return
+		}
+		c.Next()
+	}
+}
+
+func (a *AdminAPI) getLimits(c *gin.Context) {
+	tiers := make(map[string]tierLimits)
+	for name, tc := range a.tieredLimiter.Tiers() {
+		tiers[name] = limitsOf(tc)
+	}
+	c.JSON(http.StatusOK, tiers)
+}
+
+func (a *AdminAPI) setLimits(c *gin.Context) {
+	var limits tierLimits
+	if err := c.ShouldBindJSON(&limits); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tier := c.Param("tier")
+
+	// Read and write limits and method lists left out of the request are
+	// kept, so that adjusting the rate limits cannot lift a restriction by
+	// accident
+	tc := a.tieredLimiter.Tiers()[tier]
+	tc.RequestsPerMinute = *limits.RequestsPerMinute
+	tc.HbarLimit = *limits.HbarLimit
+	if limits.ReadRequestsPerMinute != nil {
+		tc.ReadRequestsPerMinute = *limits.ReadRequestsPerMinute
+	}
+	if limits.WriteRequestsPerMinute != nil {
+		tc.WriteRequestsPerMinute = *limits.WriteRequestsPerMinute
+	}
+	if limits.AllowedMethods != nil {
+		tc.AllowedMethods = limits.AllowedMethods
+	}
+	if limits.DeniedMethods != nil {
+		tc.DeniedMethods = limits.DeniedMethods
+	}
+	a.tieredLimiter.SetTier(tier, tc)
+	a.logger.Info("Updated rate limit tier", zap.String("tier", tier), zap.Int("requestsPerMinute", *limits.RequestsPerMinute), zap.Int("hbarLimit", *limits.HbarLimit))
+
+	c.JSON(http.StatusOK, limitsOf(tc))
+}
+
+func (a *AdminAPI) getHbarBudget(c *gin.Context) {
+	budget := a.tieredLimiter.OperatorHbarBudget()
+	remaining := a.tieredLimiter.OperatorHbarRemaining()
+	response := gin.H{
+		"budget":    budget,
+		"remaining": remaining,
+		"spent":     budget - remaining,
+	}
+
+	// The USD estimates are left out while the exchange rate is unavailable
+	if a.exchangeRate != nil {
+		if spentUsd, err := a.exchangeRate.TinybarsToUSD(c.Request.Context(), budget-remaining); err == nil {
+			remainingUsd, _ := a.exchangeRate.TinybarsToUSD(c.Request.Context(), remaining)
+			response["spentUsd"] = spentUsd
+			response["remainingUsd"] = remainingUsd
+		} else {
+			a.logger.Warn("Failed to price the HBAR budget in USD", zap.Error(err))
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (a *AdminAPI) getAPIKeys(c *gin.Context) {
+	now := time.Now()
+	keys := a.apiKeyStore.Keys()
+	usage := make([]apiKeyUsage, 0, len(keys))
+	for _, key := range keys {
+		usage = append(usage, apiKeyUsage{
+			APIKey:             key,
+			Active:             key.Active(now),
+			RequestsThisMinute: a.tieredLimiter.RequestCount(key.KeyHash),
+			HbarSpent:          a.tieredLimiter.UserHbarUsage(key.KeyHash),
+		})
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+func (a *AdminAPI) flushCache(c *gin.Context) {
+	keys := c.QueryArray("key")
+	if len(keys) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "at least one key query parameter is required"})
+		return
+	}
+
+	for _, key := range keys {
+		if err := a.cacheService.Delete(c.Request.Context(), key); err != nil {
+			a.logger.Error("Failed to flush cache key", zap.String("key", key), zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to flush %s", key)})
+			return
+		}
+	}
+	a.logger.Info("Flushed cache keys", zap.Strings("keys", keys))
+
+	c.JSON(http.StatusOK, gin.H{"flushed": keys})
+}
+
+func (a *AdminAPI) getFeatures(c *gin.Context) {
+	c.JSON(http.StatusOK, a.featureStates())
+}
+
+func (a *AdminAPI) setFeatures(c *gin.Context) {
+	var updates map[string]bool
+	if err := c.ShouldBindJSON(&updates); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	for name := range updates {
+		if _, ok := a.features[name]; !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown feature: %s", name)})
+			return
+		}
+	}
+	for name, enabled := range updates {
+		a.features[name].SetEnabled(enabled)
+		a.logger.Info("Toggled feature", zap.String("feature", name), zap.Bool("enabled", enabled))
+	}
+
+	c.JSON(http.StatusOK, a.featureStates())
+}
+
+func (a *AdminAPI) featureStates() map[string]bool {
+	states := make(map[string]bool, len(a.features))
+	for name, flag := range a.features {
+		states[name] = flag.Enabled()
+	}
+	return states
+}
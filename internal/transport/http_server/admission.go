@@ -0,0 +1,31 @@
+package http_server
+
+import (
+	"net/http"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	"github.com/LimeChain/Hederium/internal/transport/rpc"
+	"github.com/gin-gonic/gin"
+)
+
+// AdmissionMiddleware holds JSON-RPC requests in queue until a worker slot is
+// free. Requests the queue turns away are answered with 503 Service
+// Unavailable and a -32005 error, and asked to retry after a second. A batch
+// takes a single slot.
+func AdmissionMiddleware(queue *limiter.AdmissionQueue) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		release, err := queue.Admit(c.Request.Context())
+		if err != nil {
+			c.Header("Retry-After", "1")
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, rpc.JSONRPCResponse{
+				JSONRPC: "2.0",
+				Error:   domain.NewAdmissionRejectedError(),
+			})
+			return
+		}
+		defer release()
+
+		c.Next()
+	}
+}
@@ -0,0 +1,115 @@
+package http_server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/timing"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// DevModeConfig controls the verbose request tracing meant for local
+// debugging. It must not be enabled in production, as full request and
+// response payloads end up in the logs.
+type DevModeConfig struct {
+	Enabled bool
+}
+
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// DevModeMiddleware logs every JSON-RPC request and response pretty-printed
+// to logger, together with the time spent in the cache, the mirror node and
+// the Hedera SDK. Signed transactions are replaced by their size, so that the
+// logs can be shared without leaking them.
+func DevModeMiddleware(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			logger.Debug("Failed to read request body", zap.Error(err))
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx, recorder := timing.NewContext(c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+
+		writer := &bodyRecorder{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		logger.Debug("JSON-RPC request\n" + prettyPayload(body))
+
+		c.Next()
+
+		durations := recorder.Durations()
+		logger.Debug("JSON-RPC response\n"+prettyPayload(writer.body.Bytes()),
+			zap.Int("status", c.Writer.Status()),
+			zap.Duration("total", time.Since(start)),
+			zap.Duration(timing.Cache, durations[timing.Cache]),
+			zap.Duration(timing.Mirror, durations[timing.Mirror]),
+			zap.Duration(timing.SDK, durations[timing.SDK]),
+		)
+	}
+}
+
+// prettyPayload indents a JSON body with raw transactions redacted. Bodies that
+// are not valid JSON are returned as they are.
+func prettyPayload(body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var payload interface{}
+	if err := decoder.Decode(&payload); err != nil {
+		return string(body)
+	}
+
+	switch p := payload.(type) {
+	case []interface{}:
+		for _, request := range p {
+			redactRawTransaction(request)
+		}
+	default:
+		redactRawTransaction(p)
+	}
+
+	var pretty bytes.Buffer
+	encoder := json.NewEncoder(&pretty)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(payload); err != nil {
+		return string(body)
+	}
+	return strings.TrimSuffix(pretty.String(), "\n")
+}
+
+func redactRawTransaction(request interface{}) {
+	r, ok := request.(map[string]interface{})
+	if !ok || r["method"] != "eth_sendRawTransaction" {
+		return
+	}
+	params, ok := r["params"].([]interface{})
+	if !ok || len(params) == 0 {
+		return
+	}
+	if raw, ok := params[0].(string); ok {
+		params[0] = fmt.Sprintf("<redacted %d bytes>", (len(raw)-2)/2)
+	}
+}
@@ -0,0 +1,56 @@
+package http_server
+
+import (
+	"net/http"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	"github.com/gin-gonic/gin"
+)
+
+// Health statuses reported by /health.
+const (
+	healthOK          = "ok"
+	healthUnavailable = "unavailable"
+)
+
+type healthResponse struct {
+	Status string `json:"status"`
+	// ConsensusNodes is left out while the consensus nodes are not monitored.
+	ConsensusNodes *hedera.ConsensusHealthStatus `json:"consensusNodes,omitempty"`
+	Networks       map[string]healthResponse     `json:"networks,omitempty"`
+}
+
+// handleHealth reports the state of the consensus nodes of every network. It
+// answers 503 when no consensus node of the default network answered its
+// latest check, so that load balancers route around the instance.
+func (s *server) handleHealth(ctx *gin.Context) {
+	response := consensusHealth(s.hClient)
+	if len(s.networkClients) > 0 {
+		response.Networks = make(map[string]healthResponse, len(s.networkClients))
+		for name, hClient := range s.networkClients {
+			response.Networks[name] = consensusHealth(hClient)
+		}
+	}
+
+	status := http.StatusOK
+	if response.Status != healthOK {
+		status = http.StatusServiceUnavailable
+	}
+	ctx.JSON(status, response)
+}
+
+func consensusHealth(hClient *hedera.HederaClient) healthResponse {
+	if hClient == nil {
+		return healthResponse{Status: healthOK}
+	}
+	nodes, monitored := hClient.ConsensusHealth()
+	if !monitored {
+		return healthResponse{Status: healthOK}
+	}
+
+	response := healthResponse{Status: healthOK, ConsensusNodes: &nodes}
+	if !nodes.LastCheck.IsZero() && len(nodes.Healthy) == 0 {
+		response.Status = healthUnavailable
+	}
+	return response
+}
@@ -0,0 +1,120 @@
+package http_server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/transport/rpc"
+	"github.com/gin-gonic/gin"
+)
+
+// HTTPCacheConfig controls the HTTP caching headers of single JSON-RPC
+// responses. Responses holding data that can no longer change are sent with
+// an ETag and a Cache-Control header allowing caches to keep them for MaxAge;
+// all other responses are marked as not to be stored.
+type HTTPCacheConfig struct {
+	Enabled bool
+	MaxAge  time.Duration
+}
+
+// immutableMethods maps the methods whose found results never change to the
+// index of their block parameter, which must name a block by number rather
+// than by tag for the result to be fixed, or -1 when they take none. Hedera
+// blocks are final once the mirror node lists them.
+var immutableMethods = map[string]int{
+	"eth_getBlockByHash":                      -1,
+	"eth_getBlockByNumber":                    0,
+	"eth_getBlockTransactionCountByHash":      -1,
+	"eth_getBlockTransactionCountByNumber":    0,
+	"eth_getTransactionByHash":                -1,
+	"eth_getTransactionByBlockHashAndIndex":   -1,
+	"eth_getTransactionByBlockNumberAndIndex": 0,
+	"eth_getTransactionReceipt":               -1,
+}
+
+// immutableResponse reports whether resp, the answer to req, holds data that
+// can no longer change. Errors and empty results are excluded, as the data
+// may not have reached the mirror node yet.
+func immutableResponse(req *rpc.JSONRPCRequest, resp *rpc.JSONRPCResponse) bool {
+	blockParam, ok := immutableMethods[req.Method]
+	if !ok || resp.Error != nil || isNilResult(resp.Result) {
+		return false
+	}
+	if blockParam < 0 {
+		return true
+	}
+
+	params, ok := req.Params.([]interface{})
+	if !ok || len(params) <= blockParam {
+		return false
+	}
+	block, ok := params[blockParam].(string)
+	return ok && strings.HasPrefix(block, "0x")
+}
+
+func isNilResult(result interface{}) bool {
+	if result == nil {
+		return true
+	}
+	value := reflect.ValueOf(result)
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return value.IsNil()
+	}
+	return false
+}
+
+// writeSingleResponse sends the response to a single, non-batch request. With
+// caching enabled, immutable responses carry an ETag and are answered with
+// 304 Not Modified when the client already holds them.
+func (s *server) writeSingleResponse(ctx *gin.Context, req *rpc.JSONRPCRequest, resp *rpc.JSONRPCResponse) {
+	status := http.StatusOK
+	if resp.Error != nil {
+		status = http.StatusBadRequest
+	}
+	if !s.httpCache.Enabled {
+		ctx.JSON(status, resp)
+		return
+	}
+
+	if !immutableResponse(req, resp) {
+		ctx.Header("Cache-Control", "no-store")
+		ctx.JSON(status, resp)
+		return
+	}
+
+	body, err := json.Marshal(resp)
+	if err != nil {
+		ctx.Header("Cache-Control", "no-store")
+		ctx.JSON(status, resp)
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	ctx.Header("ETag", etag)
+	ctx.Header("Cache-Control", fmt.Sprintf("public, max-age=%d, immutable", int(s.httpCache.MaxAge.Seconds())))
+	if etagMatches(ctx.GetHeader("If-None-Match"), etag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+	ctx.Data(status, "application/json; charset=utf-8", body)
+}
+
+// etagMatches reports whether the If-None-Match header value lists etag,
+// comparing weakly as RFC 9110 requires for this header.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
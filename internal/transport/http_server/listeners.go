@@ -0,0 +1,107 @@
+package http_server
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strings"
+)
+
+// ListenerConfig is one address the server accepts connections on. Network is
+// "tcp" (IPv4 and IPv6), "tcp4", "tcp6" or "unix"; for unix the address is the
+// socket path. Connections are served over TLS when CertFile and KeyFile are
+// set.
+type ListenerConfig struct {
+	Network  string
+	Address  string
+	CertFile string
+	KeyFile  string
+}
+
+// TLS reports whether the listener serves HTTPS.
+func (l ListenerConfig) TLS() bool {
+	return l.CertFile != ""
+}
+
+func (l ListenerConfig) String() string {
+	return l.Network + ":" + l.Address
+}
+
+// ParseListeners reads the server.listeners list of the config file, each
+// entry an object with network, address and optional certFile and keyFile.
+// Networks default to tcp.
+func ParseListeners(raw interface{}) ([]ListenerConfig, error) {
+	entries, ok := raw.([]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	listeners := make([]ListenerConfig, 0, len(entries))
+	for i, entry := range entries {
+		fields := map[string]string{}
+		switch m := entry.(type) {
+		case map[string]interface{}:
+			for key, value := range m {
+				if value != nil {
+					fields[strings.ToLower(key)] = fmt.Sprint(value)
+				}
+			}
+		case map[interface{}]interface{}:
+			for key, value := range m {
+				if value != nil {
+					fields[strings.ToLower(fmt.Sprint(key))] = fmt.Sprint(value)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("listener %d: expected an object with network and address", i)
+		}
+
+		listener := ListenerConfig{
+			Network:  fields["network"],
+			Address:  fields["address"],
+			CertFile: fields["certfile"],
+			KeyFile:  fields["keyfile"],
+		}
+		if listener.Network == "" {
+			listener.Network = "tcp"
+		}
+		if err := listener.validate(); err != nil {
+			return nil, fmt.Errorf("listener %d: %w", i, err)
+		}
+		listeners = append(listeners, listener)
+	}
+	return listeners, nil
+}
+
+func (l ListenerConfig) validate() error {
+	switch l.Network {
+	case "tcp", "tcp4", "tcp6", "unix":
+	default:
+		return fmt.Errorf("unsupported network %q", l.Network)
+	}
+	if l.Address == "" {
+		return errors.New("address is required")
+	}
+	if (l.CertFile == "") != (l.KeyFile == "") {
+		return errors.New("certFile and keyFile must be set together")
+	}
+	return nil
+}
+
+// listen opens the listener. A socket file left behind by a previous run is
+// removed first; any other file at the socket path is an error.
+func (l ListenerConfig) listen() (net.Listener, error) {
+	if l.Network == "unix" {
+		if info, err := os.Lstat(l.Address); err == nil {
+			if info.Mode().Type() != fs.ModeSocket {
+				return nil, fmt.Errorf("%s exists and is not a socket", l.Address)
+			}
+			if err := os.Remove(l.Address); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return net.Listen(l.Network, l.Address)
+}
@@ -0,0 +1,76 @@
+package http_server
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"go.uber.org/zap"
+)
+
+// handleLogExport streams the logs matching an eth_getLogs filter object as
+// newline-delimited JSON, one log per line. Mirror node pages are written and
+// flushed as they arrive, so exports are not bound by the eth_getLogs result
+// limit. A failure after the first page is reported as a final {"error": ...}
+// line, since the status code has already been sent.
+func (s *server) handleLogExport(c *gin.Context) {
+	var filter map[string]interface{}
+	if err := c.ShouldBindJSON(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": domain.NewInvalidRequestError("Expected an eth_getLogs filter object")})
+		return
+	}
+
+	var params domain.EthGetLogsParams
+	if err := params.FromPositionalParams([]interface{}{filter}); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": domain.NewInvalidParamsError(err.Error())})
+		return
+	}
+	if err := binding.Validator.ValidateStruct(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": domain.NewInvalidParamsError(domain.NewValidationParamError(&params, err).Error())})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if _, tier := limiter.APIKeyFromContext(ctx); tier != "" && !s.tieredLimiter.MethodAllowed(tier, "eth_getLogs") {
+		c.JSON(http.StatusForbidden, gin.H{"error": domain.NewMethodNotAllowedError("eth_getLogs", tier)})
+		return
+	}
+
+	// Large exports outlast the server write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		s.logger.Debug("Failed to lift the write deadline of the log export", zap.Error(err))
+	}
+
+	encoder := json.NewEncoder(c.Writer)
+	started := false
+	errRpc := s.serviceProvider.EthService().StreamLogs(ctx, params.ToLogParams(), func(logs []domain.Log) error {
+		if !started {
+			c.Header("Content-Type", "application/x-ndjson")
+			c.Status(http.StatusOK)
+			started = true
+		}
+		for _, log := range logs {
+			if err := encoder.Encode(log); err != nil {
+				return err
+			}
+		}
+		c.Writer.Flush()
+		return nil
+	})
+
+	switch {
+	case errRpc != nil && !started:
+		c.JSON(http.StatusBadRequest, gin.H{"error": errRpc})
+	case errRpc != nil:
+		if err := encoder.Encode(gin.H{"error": errRpc}); err != nil {
+			s.logger.Debug("Failed to write log export error", zap.Error(err))
+		}
+	case !started:
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Status(http.StatusOK)
+	}
+}
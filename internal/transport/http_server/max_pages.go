@@ -0,0 +1,24 @@
+package http_server
+
+import (
+	"strconv"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	"github.com/gin-gonic/gin"
+)
+
+// MaxPagesHeader lets a client lower the number of mirror node pages its
+// queries may follow, to fail fast instead of waiting on a large range. It
+// cannot raise the budget set by mirrorNode.maxPages.
+const MaxPagesHeader = "X-Mirror-Node-Max-Pages"
+
+// MaxPagesMiddleware applies the page budget sent in MaxPagesHeader to the
+// request context. Values that are not positive integers are ignored.
+func MaxPagesMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if pages, err := strconv.Atoi(c.GetHeader(MaxPagesHeader)); err == nil && pages > 0 {
+			c.Request = c.Request.WithContext(hedera.WithMaxPages(c.Request.Context(), pages))
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,102 @@
+package http_server
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	"github.com/LimeChain/Hederium/internal/service"
+	"github.com/LimeChain/Hederium/internal/transport/rpc"
+	"github.com/gin-gonic/gin"
+)
+
+// Network is a further Hedera network served next to the default one, with
+// its own clients and chain ID. Its requests are posted to /<Name>, or to /
+// with a Host header listed in Hosts.
+type Network struct {
+	Name         string
+	Hosts        []string
+	ChainID      string
+	HederaClient *hedera.HederaClient
+	MirrorClient *hedera.MirrorClient
+
+	// BlockGasLimit and MaxCallGas override the gas limits of the default
+	// network when set.
+	BlockGasLimit uint64
+	MaxCallGas    uint64
+}
+
+// serviceConfig returns the service configuration of the network: that of the
+// default network with the gas limits of the network, where set.
+func (n Network) serviceConfig(defaults service.Config) service.Config {
+	if n.BlockGasLimit != 0 {
+		defaults.BlockGasLimit = n.BlockGasLimit
+	}
+	if n.MaxCallGas != 0 {
+		defaults.MaxCallGas = n.MaxCallGas
+	}
+	return defaults
+}
+
+var networkNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// reservedPaths are the top-level paths the server routes itself, which
+// networks cannot be named after.
+var reservedPaths = map[string]bool{"metrics": true, "health": true, "logs": true, "admin": true}
+
+// ValidateNetworks checks that the networks have distinct names usable as a
+// path segment, a chain ID and clients, and that no host is claimed twice.
+func ValidateNetworks(networks []Network) error {
+	names := make(map[string]bool)
+	hosts := make(map[string]string)
+	for i, network := range networks {
+		switch {
+		case !networkNamePattern.MatchString(network.Name):
+			return fmt.Errorf("network %d: name %q must be lowercase letters, digits and dashes", i, network.Name)
+		case reservedPaths[network.Name]:
+			return fmt.Errorf("network %d: name %q is reserved", i, network.Name)
+		case names[network.Name]:
+			return fmt.Errorf("network %d: duplicate name %q", i, network.Name)
+		case network.ChainID == "":
+			return fmt.Errorf("network %s: chainId is required", network.Name)
+		case network.HederaClient == nil || network.MirrorClient == nil:
+			return fmt.Errorf("network %s: hedera and mirror node clients are required", network.Name)
+		}
+		names[network.Name] = true
+
+		for _, host := range network.Hosts {
+			host = strings.ToLower(host)
+			if other, ok := hosts[host]; ok {
+				return fmt.Errorf("network %s: host %s is already served by network %s", network.Name, host, other)
+			}
+			hosts[host] = network.Name
+		}
+	}
+	return nil
+}
+
+// rpcHandlerForHost returns the handler of the network serving host, or the
+// default handler when no network lists it.
+func (s *server) rpcHandlerForHost(host string) rpc.RPCHandler {
+	if name, ok := s.networkForHost(host); ok {
+		return s.networkHandlers[name]
+	}
+	return s.rpcHandler
+}
+
+// networkForHost returns the further network claiming host, if any.
+func (s *server) networkForHost(host string) (string, bool) {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	name, ok := s.hostNetworks[strings.ToLower(host)]
+	return name, ok
+}
+
+func (s *server) handleNetworkRPCRequest(name string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		s.serveRPC(ctx, s.networkHandlers[name])
+	}
+}
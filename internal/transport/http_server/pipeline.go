@@ -0,0 +1,120 @@
+package http_server
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Names of the built-in stages of the JSON-RPC pipeline, in the order they
+// run unless PipelineConfig.Order says otherwise.
+const (
+	MiddlewareRequestLog = "requestLog"
+	MiddlewareDevMode    = "devMode"
+	MiddlewareAuth       = "auth"
+	MiddlewareMaxPages   = "maxPages"
+	MiddlewareShadow     = "shadow"
+)
+
+// Middleware is a named stage of the pipeline every JSON-RPC request passes
+// through before it is served. Deployers embedding the relay implement it to
+// run their own stages, such as audit hooks, next to the built-in ones.
+type Middleware interface {
+	Name() string
+	Handler() gin.HandlerFunc
+}
+
+type middleware struct {
+	name    string
+	handler gin.HandlerFunc
+}
+
+// NewMiddleware names handler as a pipeline stage.
+func NewMiddleware(name string, handler gin.HandlerFunc) Middleware {
+	return middleware{name: name, handler: handler}
+}
+
+func (m middleware) Name() string {
+	return m.name
+}
+
+func (m middleware) Handler() gin.HandlerFunc {
+	return m.handler
+}
+
+// PipelineConfig sets up the JSON-RPC pipeline. Middlewares are added after
+// the built-in stages, and the stages named in Order run first, in that
+// order, followed by the others in their default order. Listing a stage does
+// not enable it: the built-in ones still depend on their own settings, and the
+// recovery, access log, request ID and CORS handling applied to every route,
+// as well as the admission queue, always run ahead of the pipeline.
+type PipelineConfig struct {
+	Order       []string
+	Middlewares []Middleware
+}
+
+// orderMiddlewares puts the stages named in order first and the others after
+// them, keeping their order in stages. Names matching no stage and stages
+// reusing the name of an earlier one are logged and left out.
+func orderMiddlewares(stages []Middleware, order []string, logger *zap.Logger) []Middleware {
+	byName := make(map[string]Middleware, len(stages))
+	var unique []Middleware
+	for _, stage := range stages {
+		if _, ok := byName[stage.Name()]; ok {
+			logger.Warn("Middleware name is already taken, skipping it", zap.String("middleware", stage.Name()))
+			continue
+		}
+		byName[stage.Name()] = stage
+		unique = append(unique, stage)
+	}
+
+	ordered := make([]Middleware, 0, len(unique))
+	placed := make(map[string]bool, len(unique))
+	for _, name := range order {
+		stage, ok := byName[name]
+		if !ok {
+			if !isBuiltinMiddleware(name) {
+				logger.Warn("Unknown middleware in middleware.order, ignoring it", zap.String("middleware", name))
+			}
+			continue
+		}
+		if !placed[name] {
+			ordered = append(ordered, stage)
+			placed[name] = true
+		}
+	}
+	for _, stage := range unique {
+		if !placed[stage.Name()] {
+			ordered = append(ordered, stage)
+		}
+	}
+
+	return ordered
+}
+
+// isBuiltinMiddleware reports whether name is a built-in stage, which is left
+// out of the pipeline rather than unknown while its settings disable it.
+func isBuiltinMiddleware(name string) bool {
+	switch name {
+	case MiddlewareRequestLog, MiddlewareDevMode, MiddlewareAuth, MiddlewareMaxPages, MiddlewareShadow:
+		return true
+	}
+	return false
+}
+
+// middlewareHandlers returns the handlers of stages, without those named in
+// except.
+func middlewareHandlers(stages []Middleware, except ...string) []gin.HandlerFunc {
+	handlers := make([]gin.HandlerFunc, 0, len(stages))
+	for _, stage := range stages {
+		skip := false
+		for _, name := range except {
+			if stage.Name() == name {
+				skip = true
+			}
+		}
+		if !skip {
+			handlers = append(handlers, stage.Handler())
+		}
+	}
+	return handlers
+}
@@ -0,0 +1,51 @@
+package http_server
+
+import (
+	"net/http"
+	"runtime/debug"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	hederiumlogger "github.com/LimeChain/Hederium/internal/infrastructure/logger"
+	"github.com/LimeChain/Hederium/internal/infrastructure/metrics"
+	"github.com/LimeChain/Hederium/internal/transport/rpc"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RecoveryMiddleware answers requests whose handling panicked with a -32603
+// JSON-RPC internal error, logging the panic with its stack trace and request
+// ID and counting it in the hederium_panics_total metric by route. Panics in
+// JSON-RPC methods are recovered by the RPC handler, per call, and never
+// reach it.
+func RecoveryMiddleware(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			// The client is gone, there is no one to answer
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			route := c.Request.Method + " " + c.FullPath()
+			metrics.Panics.WithLabelValues(route).Inc()
+			logger.Error("Recovered from panic while serving request",
+				zap.String("route", route),
+				zap.String("requestId", hederiumlogger.RequestIDFromContext(c.Request.Context())),
+				zap.Any("panic", recovered),
+				zap.ByteString("stack", debug.Stack()))
+
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, rpc.JSONRPCResponse{
+				JSONRPC: "2.0",
+				Error:   domain.NewInternalError("Internal error"),
+			})
+		}()
+		c.Next()
+	}
+}
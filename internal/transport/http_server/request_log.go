@@ -0,0 +1,155 @@
+package http_server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	hederiumlogger "github.com/LimeChain/Hederium/internal/infrastructure/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// RequestIDHeader carries the request ID in responses. A valid ID sent by
+	// the client in the same header is kept, so that callers can correlate
+	// their own logs.
+	RequestIDHeader = "X-Request-ID"
+	// maxLoggedParams caps the size of the parameters written to the request
+	// log, as eth_call data and log filters can be large.
+	maxLoggedParams = 1024
+)
+
+// RequestLogConfig controls the one-line summary logged for every JSON-RPC
+// request.
+type RequestLogConfig struct {
+	Enabled bool
+}
+
+// RequestIDMiddleware assigns every request an ID, available to handlers
+// through the request context and echoed in the X-Request-ID header.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !hederiumlogger.ValidRequestID(id) {
+			id = hederiumlogger.NewRequestID()
+		}
+
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(hederiumlogger.WithRequestID(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// RequestLogMiddleware logs the request ID, methods, redacted parameters,
+// duration, response size and JSON-RPC error codes of every request it
+// wraps.
+func RequestLogMiddleware(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			logger.Debug("Failed to read request body", zap.Error(err))
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		writer := &bodyRecorder{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		fields := []zap.Field{
+			zap.String("requestId", hederiumlogger.RequestIDFromContext(c.Request.Context())),
+			zap.Int("status", c.Writer.Status()),
+			zap.Duration("duration", time.Since(start)),
+			zap.Int("responseSize", writer.body.Len()),
+		}
+		fields = append(fields, requestFields(body)...)
+		fields = append(fields, errorCodeFields(writer.body.Bytes())...)
+		logger.Info("JSON-RPC request served", fields...)
+	}
+}
+
+type loggedRequest struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// requestFields describes a single or batch request. Bodies that are not
+// valid JSON-RPC are described by their size alone.
+func requestFields(body []byte) []zap.Field {
+	var batch []loggedRequest
+	if err := json.Unmarshal(body, &batch); err == nil {
+		methods := make([]string, len(batch))
+		for i, request := range batch {
+			methods[i] = request.Method
+		}
+		return []zap.Field{zap.Strings("methods", methods)}
+	}
+
+	var request loggedRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return []zap.Field{zap.Int("requestSize", len(body))}
+	}
+	return []zap.Field{
+		zap.String("method", request.Method),
+		zap.String("params", redactedParams(request.Method, request.Params)),
+	}
+}
+
+// redactedParams renders params compactly, with raw transactions replaced by
+// their size and the result truncated to maxLoggedParams.
+func redactedParams(method string, params json.RawMessage) string {
+	if len(params) == 0 {
+		return ""
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(params, &decoded); err != nil {
+		return ""
+	}
+	redactRawTransaction(map[string]interface{}{"method": method, "params": decoded})
+
+	var encoded bytes.Buffer
+	encoder := json.NewEncoder(&encoded)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(decoded); err != nil {
+		return ""
+	}
+	rendered := strings.TrimSuffix(encoded.String(), "\n")
+	if len(rendered) > maxLoggedParams {
+		return rendered[:maxLoggedParams] + "..."
+	}
+	return rendered
+}
+
+type loggedResponse struct {
+	Error *struct {
+		Code int `json:"code"`
+	} `json:"error"`
+}
+
+func errorCodeFields(body []byte) []zap.Field {
+	var batch []loggedResponse
+	if err := json.Unmarshal(body, &batch); err == nil {
+		var codes []int
+		for _, response := range batch {
+			if response.Error != nil {
+				codes = append(codes, response.Error.Code)
+			}
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return []zap.Field{zap.Ints("errorCodes", codes)}
+	}
+
+	var response loggedResponse
+	if err := json.Unmarshal(body, &response); err != nil || response.Error == nil {
+		return nil
+	}
+	return []zap.Field{zap.Int("errorCode", response.Error.Code)}
+}
@@ -0,0 +1,60 @@
+package http_server
+
+import (
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	hederiumlogger "github.com/LimeChain/Hederium/internal/infrastructure/logger"
+	"github.com/LimeChain/Hederium/internal/service"
+	"go.uber.org/zap"
+)
+
+// RuntimeConfig holds the settings that can be changed while the relay
+// serves, as re-read from the config file on SIGHUP or when the file changes.
+type RuntimeConfig struct {
+	LogLevel string
+	// Tiers and APIKeys are in the format of the limiter and apiKeys sections
+	// of the config. APIKeys only apply to the config API key store backend;
+	// the other backends reload keys from their own source.
+	Tiers   map[string]interface{}
+	APIKeys interface{}
+	// FiltersEnabled, DebugEnabled and BatchRequestsEnabled toggle the same
+	// features as the admin API.
+	FiltersEnabled       bool
+	DebugEnabled         bool
+	BatchRequestsEnabled bool
+	CallCacheTTL         time.Duration
+}
+
+// ApplyRuntimeConfig applies cfg to every network. Each setting is swapped
+// in at once, so requests see either its old or its new value, and requests
+// in flight are not interrupted. Invalid settings are logged and left as they
+// were.
+func (s *server) ApplyRuntimeConfig(cfg RuntimeConfig) {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	if err := hederiumlogger.SetLevel(cfg.LogLevel); err != nil {
+		s.logger.Error("Invalid log level, keeping the previous one", zap.String("level", cfg.LogLevel), zap.Error(err))
+	}
+
+	s.tieredLimiter.ReplaceTiers(cfg.Tiers)
+	if _, err := limiter.ReloadAPIKeys(s.apiKeyStore, cfg.APIKeys); err != nil {
+		s.logger.Error("Invalid API keys, keeping the previous ones", zap.Error(err))
+	}
+
+	s.enableBatchRequests.Store(cfg.BatchRequestsEnabled)
+	for _, services := range append([]service.ServiceProvider{s.serviceProvider}, s.networkServices...) {
+		services.FilterService().SetEnabled(cfg.FiltersEnabled)
+		services.DebugService().SetEnabled(cfg.DebugEnabled)
+		services.EthService().SetCallCacheTTL(cfg.CallCacheTTL)
+	}
+
+	s.logger.Info("Applied runtime configuration",
+		zap.String("logLevel", cfg.LogLevel),
+		zap.Int("tiers", len(cfg.Tiers)),
+		zap.Bool("filters", cfg.FiltersEnabled),
+		zap.Bool("debug", cfg.DebugEnabled),
+		zap.Bool("batchRequests", cfg.BatchRequestsEnabled),
+		zap.Duration("callCacheTTL", cfg.CallCacheTTL))
+}
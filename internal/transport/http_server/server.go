@@ -2,38 +2,92 @@ package http_server
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/LimeChain/Hederium/internal/domain"
 	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
 	"github.com/LimeChain/Hederium/internal/infrastructure/hedera"
 	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	hederiumlogger "github.com/LimeChain/Hederium/internal/infrastructure/logger"
+	"github.com/LimeChain/Hederium/internal/infrastructure/startup"
 	"github.com/LimeChain/Hederium/internal/service"
 	"github.com/LimeChain/Hederium/internal/transport/rpc"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 )
 
 type Server interface {
 	Start() error
+	// Handler returns the router serving the RPC and admin endpoints, for
+	// embedding the relay without binding a port.
+	Handler() http.Handler
+	// ApplyRuntimeConfig swaps in the settings that may change while the
+	// relay serves, without dropping connections.
+	ApplyRuntimeConfig(cfg RuntimeConfig)
+	// RPCHandler returns the handler answering JSON-RPC calls for the default
+	// network, for serving them over other transports.
+	RPCHandler() rpc.RPCHandler
 }
 
 type server struct {
 	router              *gin.Engine
 	logger              *zap.Logger
-	port                string
+	listeners           []ListenerConfig
+	tlsConfig           TLSConfig
 	serviceProvider     service.ServiceProvider
-	apiKeyStore         *limiter.APIKeyStore
+	apiKeyStore         limiter.APIKeyStore
 	tieredLimiter       *limiter.TieredLimiter
 	enforceAPIKey       bool
-	enableBatchRequests bool
+	enableBatchRequests atomic.Bool
 	rpcHandler          rpc.RPCHandler
+	// networkHandlers serve the networks configured next to the default one,
+	// by name, and hostNetworks maps the hosts they are reached at to names.
+	networkHandlers map[string]rpc.RPCHandler
+	hostNetworks    map[string]string
+	// networkServices serve the further networks, for applying runtime
+	// settings to them as well.
+	networkServices []service.ServiceProvider
+	// reloadMu keeps runtime config changes from interleaving.
+	reloadMu sync.Mutex
+	// hClient and networkClients are the consensus node clients whose health
+	// /health reports.
+	hClient        *hedera.HederaClient
+	networkClients map[string]*hedera.HederaClient
+	httpCache      HTTPCacheConfig
+}
+
+// ServerOptions holds the optional parts of a Server. Each left at its zero
+// value is turned off or uses its defaults.
+type ServerOptions struct {
+	CORS          CORSConfig
+	Admin         AdminConfig
+	DevMode       DevModeConfig
+	RequestLog    RequestLogConfig
+	Listeners     []ListenerConfig
+	TLS           TLSConfig
+	Networks      []Network
+	Concurrency   limiter.ConcurrencyConfig
+	HTTPCache     HTTPCacheConfig
+	Overload      *limiter.OverloadController
+	Shadow        ShadowConfig
+	StartupReport *startup.Report
+	Pipeline      PipelineConfig
+	Admission     *limiter.AdmissionQueue
+	Timeouts      rpc.TimeoutConfig
 }
 
 func NewServer(
@@ -42,16 +96,18 @@ func NewServer(
 	logger *zap.Logger,
 	applicationVersion string,
 	chainId string,
-	apiKeyStore *limiter.APIKeyStore,
+	apiKeyStore limiter.APIKeyStore,
 	tieredLimiter *limiter.TieredLimiter,
 	enforceAPIKey bool,
 	enableBatchRequests bool,
 	cacheService cache.CacheService,
-	port string,
+	serviceConfig service.Config,
+	opts ServerOptions,
 ) Server {
-	serviceProvider := service.NewServiceProvider(hClient, mClient, logger, applicationVersion, chainId, apiKeyStore, tieredLimiter, cacheService)
+	serviceProvider := service.NewServiceProvider(hClient, mClient, logger, applicationVersion, chainId, apiKeyStore, tieredLimiter, cacheService, serviceConfig)
 
-	router := gin.Default()
+	router := gin.New()
+	router.Use(gin.Logger(), RecoveryMiddleware(logger))
 
 	// Register custom validators used by request structs
 	if err := rpc.RegisterCustomValidators(); err != nil {
@@ -61,84 +117,311 @@ func NewServer(
 	rpcHandler := rpc.NewHandler(
 		logger,
 		serviceProvider,
+		tieredLimiter,
+		limiter.NewMethodConcurrency(opts.Concurrency),
+		opts.Overload,
+		opts.Timeouts,
 	)
 
 	s := &server{
-		router:              router,
-		logger:              logger,
-		port:                port,
-		serviceProvider:     serviceProvider,
-		apiKeyStore:         apiKeyStore,
-		tieredLimiter:       tieredLimiter,
-		enforceAPIKey:       enforceAPIKey,
-		enableBatchRequests: enableBatchRequests,
-		rpcHandler:          rpcHandler,
+		router:          router,
+		logger:          logger,
+		listeners:       opts.Listeners,
+		tlsConfig:       opts.TLS,
+		serviceProvider: serviceProvider,
+		apiKeyStore:     apiKeyStore,
+		tieredLimiter:   tieredLimiter,
+		enforceAPIKey:   enforceAPIKey,
+		rpcHandler:      rpcHandler,
+		networkHandlers: make(map[string]rpc.RPCHandler),
+		hostNetworks:    make(map[string]string),
+		hClient:         hClient,
+		networkClients:  make(map[string]*hedera.HederaClient),
+		httpCache:       opts.HTTPCache,
 	}
 
+	// Further networks share the cache, under their own keys, and the rate
+	// limits and load shedding of the default one. Concurrency is bounded per
+	// network, as each has its own mirror node
+	for _, network := range opts.Networks {
+		networkLogger := logger.With(zap.String("network", network.Name))
+		networkServices := service.NewServiceProvider(network.HederaClient, network.MirrorClient, networkLogger, applicationVersion, network.ChainID, apiKeyStore, tieredLimiter, cache.NewPrefixedCache(cacheService, network.Name), network.serviceConfig(serviceConfig))
+		s.networkHandlers[network.Name] = rpc.NewHandler(networkLogger, networkServices, tieredLimiter, limiter.NewMethodConcurrency(opts.Concurrency), opts.Overload, opts.Timeouts)
+		s.networkServices = append(s.networkServices, networkServices)
+		s.networkClients[network.Name] = network.HederaClient
+		for _, host := range network.Hosts {
+			s.hostNetworks[strings.ToLower(host)] = network.Name
+		}
+	}
+	s.enableBatchRequests.Store(enableBatchRequests)
+	serviceProvider.HederiumService().RegisterFeature("batchRequests", s.enableBatchRequests.Load)
+	serviceProvider.HederiumService().RegisterFeature("enforceApiKey", func() bool { return enforceAPIKey })
+
+	router.Use(RequestIDMiddleware())
+	// Preflight requests carry no API key, so CORS runs ahead of authentication
+	router.Use(CORSMiddleware(opts.CORS))
+	router.OPTIONS("/", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	router.GET("/health", s.handleHealth)
+	if opts.StartupReport != nil {
+		router.GET("/debug/startup", gin.WrapH(opts.StartupReport.Handler()))
+	}
+
+	var stages []Middleware
+	if opts.RequestLog.Enabled {
+		stages = append(stages, NewMiddleware(MiddlewareRequestLog, RequestLogMiddleware(logger)))
+	}
+	if opts.DevMode.Enabled {
+		logger.Warn("Dev mode is enabled, full request and response payloads will be logged")
+		stages = append(stages, NewMiddleware(MiddlewareDevMode, DevModeMiddleware(hederiumlogger.NewDevLogger())))
+	}
 	if enforceAPIKey {
-		router.POST("/", s.authAndRateLimitMiddleware(), s.handleRPCRequest)
-	} else {
-		router.POST("/", s.handleRPCRequest)
+		stages = append(stages, NewMiddleware(MiddlewareAuth, s.authAndRateLimitMiddleware()))
+	}
+	stages = append(stages, NewMiddleware(MiddlewareMaxPages, MaxPagesMiddleware()))
+	if opts.Shadow.Enabled {
+		if opts.Shadow.URL == "" {
+			logger.Warn("Shadow mode is enabled but no shadow relay URL is configured, not mirroring requests")
+		} else {
+			logger.Info("Shadow mode is enabled", zap.String("url", opts.Shadow.URL), zap.Float64("percentage", opts.Shadow.Percentage))
+			shadowing := ShadowMiddleware(opts.Shadow, logger)
+			stages = append(stages, NewMiddleware(MiddlewareShadow, func(c *gin.Context) {
+				// Requests the Host header routes to a further network are
+				// not mirrored, as the shadow relay serves the default one
+				if _, ok := s.networkForHost(c.Request.Host); ok {
+					c.Next()
+					return
+				}
+				shadowing(c)
+			}))
+		}
+	}
+	stages = orderMiddlewares(append(stages, opts.Pipeline.Middlewares...), opts.Pipeline.Order, logger)
+
+	// Requests are admitted ahead of the pipeline, so that no work is done for
+	// those turned away. The networks share the worker slots
+	admit := AdmissionMiddleware(opts.Admission)
+	router.POST("/", append(append([]gin.HandlerFunc{admit}, middlewareHandlers(stages)...), s.handleRPCRequest)...)
+	for _, network := range opts.Networks {
+		router.POST("/"+network.Name, append(append([]gin.HandlerFunc{admit}, middlewareHandlers(stages, MiddlewareShadow)...), s.handleNetworkRPCRequest(network.Name))...)
+	}
+
+	// Exports are streamed, so they skip the dev mode payload logging
+	var exportHandlers []gin.HandlerFunc
+	if enforceAPIKey {
+		exportHandlers = append(exportHandlers, s.authAndRateLimitMiddleware())
+	}
+	router.POST("/logs/export", append(exportHandlers, s.handleLogExport)...)
+
+	if opts.Admin.Enabled {
+		if opts.Admin.APIKey == "" {
+			logger.Warn("Admin API is enabled but no admin API key is configured, not exposing /admin")
+		} else {
+			features := map[string]FeatureFlag{
+				"filters": {
+					Enabled:    serviceProvider.FilterService().Enabled,
+					SetEnabled: serviceProvider.FilterService().SetEnabled,
+				},
+				"debug": {
+					Enabled:    serviceProvider.DebugService().Enabled,
+					SetEnabled: serviceProvider.DebugService().SetEnabled,
+				},
+				"batchRequests": {
+					Enabled:    s.enableBatchRequests.Load,
+					SetEnabled: s.enableBatchRequests.Store,
+				},
+			}
+			NewAdminAPI(opts.Admin.APIKey, logger, apiKeyStore, tieredLimiter, cacheService, serviceProvider.ExchangeRateService(), features).RegisterRoutes(router)
+		}
 	}
 
 	return s
 }
 
+func (s *server) Handler() http.Handler {
+	return s.router
+}
+
+func (s *server) RPCHandler() rpc.RPCHandler {
+	return s.rpcHandler
+}
+
+// Start serves the router on every configured listener until the process is
+// interrupted or one of the listeners fails. TLS certificates are reloaded on
+// SIGHUP and, when a reload interval is configured, whenever their files
+// change.
 func (s *server) Start() error {
+	if len(s.listeners) == 0 {
+		return errors.New("no listeners configured")
+	}
+
 	srv := &http.Server{
 		Handler:      s.router,
-		Addr:         fmt.Sprintf(":%s", s.port),
 		WriteTimeout: 15 * time.Second,
 		ReadTimeout:  15 * time.Second,
 	}
 
-	errChan := make(chan error, 1)
+	reloaders := make(map[string]*CertReloader)
+	opened := make([]net.Listener, 0, len(s.listeners))
+	closeOpened := func() {
+		for _, l := range opened {
+			_ = l.Close()
+		}
+	}
+	for _, config := range s.listeners {
+		var tlsConfig *tls.Config
+		if config.TLS() {
+			// Listeners sharing a certificate share its reloader
+			key := config.CertFile + "\x00" + config.KeyFile
+			reloader, ok := reloaders[key]
+			if !ok {
+				var err error
+				if reloader, err = NewCertReloader(config.CertFile, config.KeyFile); err != nil {
+					closeOpened()
+					return fmt.Errorf("failed to load TLS certificate for %s: %w", config, err)
+				}
+				reloaders[key] = reloader
+			}
+			tlsConfig = NewTLSConfig(reloader)
+		}
 
-	go func() {
-		s.logger.Info("Starting server on port", zap.String("port", s.port))
-		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			errChan <- err
+		listener, err := config.listen()
+		if err != nil {
+			closeOpened()
+			return fmt.Errorf("failed to listen on %s: %w", config, err)
 		}
-	}()
+		if tlsConfig != nil {
+			listener = tls.NewListener(listener, tlsConfig)
+		}
+		opened = append(opened, listener)
+	}
+
+	errChan := make(chan error, len(opened))
+
+	for i, listener := range opened {
+		config := s.listeners[i]
+		go func() {
+			s.logger.Info("Starting server", zap.Stringer("listener", config), zap.Bool("tls", config.TLS()))
+			if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errChan <- fmt.Errorf("%s: %w", config, err)
+			}
+		}()
+	}
+
+	var reload <-chan time.Time
+	if len(reloaders) > 0 && s.tlsConfig.ReloadInterval > 0 {
+		ticker := time.NewTicker(s.tlsConfig.ReloadInterval)
+		defer ticker.Stop()
+		reload = ticker.C
+	}
 
 	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt)
-
-	select {
-	case <-c:
-		s.logger.Info("Shutting down the server...")
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-		defer cancel()
-		return srv.Shutdown(ctx)
-	case err := <-errChan:
-		return err
+	signal.Notify(c, os.Interrupt, syscall.SIGHUP)
+	defer signal.Stop(c)
+
+	for {
+		select {
+		case sig := <-c:
+			if sig == syscall.SIGHUP {
+				s.reloadCertificates(reloaders, true)
+				continue
+			}
+			s.logger.Info("Shutting down the server...")
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+			return srv.Shutdown(ctx)
+		case <-reload:
+			s.reloadCertificates(reloaders, false)
+		case err := <-errChan:
+			_ = srv.Close()
+			return err
+		}
+	}
+}
+
+// reloadCertificates reloads every TLS certificate, or only those whose files
+// changed unless force is set. Failures are logged and the previous
+// certificate stays in use.
+func (s *server) reloadCertificates(reloaders map[string]*CertReloader, force bool) {
+	for _, reloader := range reloaders {
+		var reloaded bool
+		var err error
+		if force {
+			reloaded, err = true, reloader.Reload()
+		} else {
+			reloaded, err = reloader.ReloadIfChanged()
+		}
+		if err != nil {
+			s.logger.Error("Failed to reload TLS certificate", zap.String("certFile", reloader.certFile), zap.Error(err))
+		} else if reloaded {
+			s.logger.Info("Reloaded TLS certificate", zap.String("certFile", reloader.certFile))
+		}
 	}
 }
 
+// authAndRateLimitMiddleware requires an API key known to the store and
+// within the rate limit of its tier. From there on the caller is identified by
+// the hash of its key, so that rate limits and usage never hold the key.
 func (s *server) authAndRateLimitMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		apiKey := c.GetHeader("X-API-KEY")
+		apiKey := apiKeyFromRequest(c)
 		if apiKey == "" {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "API key required"})
 			return
 		}
 
-		tier, exists := s.apiKeyStore.GetTierForKey(apiKey)
+		keyHash, tier, exists := s.apiKeyStore.GetTierForKey(apiKey)
 		if !exists {
 			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Invalid API key"})
 			return
 		}
 
-		if !s.tieredLimiter.CheckLimits(apiKey, tier) {
-			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Rate limit exceeded"})
+		status, allowed := s.tieredLimiter.Allow(keyHash, tier)
+		setRateLimitHeaders(c, status)
+		if !allowed {
+			retryAfter := status.RetryAfter()
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, rpc.JSONRPCResponse{
+				JSONRPC: "2.0",
+				Error:   domain.NewRequestRateLimitExceededError(status.Limit, retryAfter),
+			})
 			return
 		}
 
-		c.Set("apiKey", apiKey)
+		c.Set("apiKey", keyHash)
 		c.Set("tier", tier)
+		c.Request = c.Request.WithContext(limiter.WithAPIKey(c.Request.Context(), keyHash, tier))
 
 		c.Next()
+
+		s.tieredLimiter.Usage().RecordBytes(keyHash, tier, int64(c.Writer.Size()))
+	}
+}
+
+// apiKeyFromRequest returns the API key of a request, taken from the
+// X-API-KEY header, a bearer token in the Authorization header or the apikey
+// query parameter, in that order.
+func apiKeyFromRequest(c *gin.Context) string {
+	if apiKey := c.GetHeader("X-API-KEY"); apiKey != "" {
+		return apiKey
 	}
+	if scheme, token, ok := strings.Cut(c.GetHeader("Authorization"), " "); ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	return c.Query("apikey")
+}
+
+// setRateLimitHeaders tells the client the request limit of its API key, how
+// many requests are left and when, in Unix seconds, the window starts over.
+// Requests of unknown tiers have no window to report.
+func setRateLimitHeaders(c *gin.Context, status limiter.RateLimitStatus) {
+	if status.Reset.IsZero() {
+		return
+	}
+	c.Header("X-RateLimit-Limit", strconv.Itoa(status.Limit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(status.Remaining))
+	c.Header("X-RateLimit-Reset", strconv.FormatInt(status.Reset.Unix(), 10))
 }
 
 type batchResponse struct {
@@ -146,7 +429,7 @@ type batchResponse struct {
 	response rpc.JSONRPCResponse
 }
 
-func (s *server) handleBatchRequest(ctx *gin.Context, requests []rpc.JSONRPCRequest) {
+func (s *server) handleBatchRequest(ctx *gin.Context, rpcHandler rpc.RPCHandler, requests []rpc.JSONRPCRequest) {
 	// Create a context with timeout for the entire batch
 	batchCtx, cancel := context.WithTimeout(ctx.Request.Context(), 30*time.Second)
 	defer cancel()
@@ -173,7 +456,7 @@ func (s *server) handleBatchRequest(ctx *gin.Context, requests []rpc.JSONRPCRequ
 				default:
 					// Process the request
 					req := requests[index]
-					resp := s.rpcHandler.HandleRequest(batchCtx, &req)
+					resp := rpcHandler.HandleRequest(batchCtx, &req)
 					resultsChan <- batchResponse{
 						index:    index,
 						response: *resp,
@@ -225,6 +508,11 @@ func (s *server) handleBatchRequest(ctx *gin.Context, requests []rpc.JSONRPCRequ
 }
 
 func (s *server) handleRPCRequest(ctx *gin.Context) {
+	s.serveRPC(ctx, s.rpcHandlerForHost(ctx.Request.Host))
+}
+
+// serveRPC answers a single or batch JSON-RPC request with rpcHandler.
+func (s *server) serveRPC(ctx *gin.Context, rpcHandler rpc.RPCHandler) {
 	// Read the request body once
 	body, err := ctx.GetRawData()
 	if err != nil {
@@ -239,7 +527,7 @@ func (s *server) handleRPCRequest(ctx *gin.Context) {
 	var batchReq []rpc.JSONRPCRequest
 	if err := json.Unmarshal(body, &batchReq); err == nil {
 		// It's a batch request
-		if len(batchReq) > 1 && !s.enableBatchRequests {
+		if len(batchReq) > 1 && !s.enableBatchRequests.Load() {
 			ctx.JSON(http.StatusBadRequest, rpc.JSONRPCResponse{
 				JSONRPC: "2.0",
 				Error:   domain.NewRPCError(domain.InvalidRequest, "Batch requests are disabled"),
@@ -249,7 +537,7 @@ func (s *server) handleRPCRequest(ctx *gin.Context) {
 
 		// Handle single request in batch format
 		if len(batchReq) == 1 {
-			resp := s.rpcHandler.HandleRequest(ctx.Request.Context(), &batchReq[0])
+			resp := rpcHandler.HandleRequest(ctx.Request.Context(), &batchReq[0])
 			if resp.Error != nil {
 				ctx.JSON(http.StatusBadRequest, resp)
 			} else {
@@ -259,7 +547,7 @@ func (s *server) handleRPCRequest(ctx *gin.Context) {
 		}
 
 		// Handle multiple requests in parallel
-		s.handleBatchRequest(ctx, batchReq)
+		s.handleBatchRequest(ctx, rpcHandler, batchReq)
 		return
 	}
 
@@ -273,10 +561,6 @@ func (s *server) handleRPCRequest(ctx *gin.Context) {
 		return
 	}
 
-	resp := s.rpcHandler.HandleRequest(ctx.Request.Context(), &singleReq)
-	if resp.Error != nil {
-		ctx.JSON(http.StatusBadRequest, resp)
-	} else {
-		ctx.JSON(http.StatusOK, resp)
-	}
+	resp := rpcHandler.HandleRequest(ctx.Request.Context(), &singleReq)
+	s.writeSingleResponse(ctx, &singleReq, resp)
 }
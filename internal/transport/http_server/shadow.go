@@ -0,0 +1,317 @@
+package http_server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/metrics"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultShadowTimeout bounds a shadow request when no timeout is
+	// configured.
+	DefaultShadowTimeout = 5 * time.Second
+	// DefaultShadowMaxInFlight is the number of shadow requests that may be
+	// pending at once when no limit is configured.
+	DefaultShadowMaxInFlight = 64
+	// maxShadowDiffs caps the differing paths reported per request.
+	maxShadowDiffs = 10
+	// maxLoggedShadowResponse caps the size of each response logged with a
+	// mismatch.
+	maxLoggedShadowResponse = 2048
+)
+
+// Outcomes of a shadow request, the outcome label of the
+// hederium_shadow_requests_total metric.
+const (
+	shadowMatch    = "match"
+	shadowMismatch = "mismatch"
+	shadowFailed   = "error"
+	shadowDropped  = "dropped"
+)
+
+// shadowUnsafeMethods are never mirrored: they submit transactions, or create
+// and read state held by one relay, such as filters, whose IDs differ between
+// relays.
+var shadowUnsafeMethods = map[string]bool{
+	"eth_sendRawTransaction":          true,
+	"eth_sendTransaction":             true,
+	"eth_newFilter":                   true,
+	"eth_newBlockFilter":              true,
+	"eth_newPendingTransactionFilter": true,
+	"eth_uninstallFilter":             true,
+	"eth_getFilterChanges":            true,
+	"eth_getFilterLogs":               true,
+	"eth_subscribe":                   true,
+	"eth_unsubscribe":                 true,
+}
+
+// ShadowConfig sets up shadow mode: Percentage percent of the read-only
+// single requests to the default network are sent again to the relay at URL,
+// with Headers, and its responses are compared with those of the relay. The
+// client is always answered by this relay, without waiting for the shadow.
+type ShadowConfig struct {
+	Enabled     bool
+	URL         string
+	Percentage  float64
+	Headers     map[string]string
+	Timeout     time.Duration
+	MaxInFlight int
+}
+
+type shadow struct {
+	config   ShadowConfig
+	logger   *zap.Logger
+	client   *http.Client
+	inFlight chan struct{}
+}
+
+// ShadowMiddleware mirrors a share of the requests it wraps to the shadow
+// relay of config and logs every response that differs, counting the outcome
+// of each in the hederium_shadow_requests_total metric. Mirrored requests
+// beyond MaxInFlight are dropped rather than queued.
+func ShadowMiddleware(config ShadowConfig, logger *zap.Logger) gin.HandlerFunc {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultShadowTimeout
+	}
+	if config.MaxInFlight <= 0 {
+		config.MaxInFlight = DefaultShadowMaxInFlight
+	}
+
+	s := &shadow{
+		config:   config,
+		logger:   logger,
+		client:   &http.Client{Timeout: config.Timeout},
+		inFlight: make(chan struct{}, config.MaxInFlight),
+	}
+	return s.handle
+}
+
+func (s *shadow) handle(c *gin.Context) {
+	if rand.Float64()*100 >= s.config.Percentage {
+		c.Next()
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		s.logger.Debug("Failed to read request body", zap.Error(err))
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	method, ok := shadowMethod(body)
+	if !ok {
+		c.Next()
+		return
+	}
+
+	writer := &bodyRecorder{ResponseWriter: c.Writer}
+	c.Writer = writer
+
+	c.Next()
+
+	// Requests turned away before they were served, e.g. for a missing API
+	// key, say nothing about parity, and unknown methods are not counted so
+	// that clients cannot add metric labels at will
+	if c.Writer.Status() != http.StatusOK && c.Writer.Status() != http.StatusBadRequest {
+		return
+	}
+	var response loggedResponse
+	if err := json.Unmarshal(writer.body.Bytes(), &response); err != nil {
+		return
+	}
+	if response.Error != nil && response.Error.Code == domain.MethodNotFound {
+		return
+	}
+
+	select {
+	case s.inFlight <- struct{}{}:
+	default:
+		metrics.ShadowRequests.WithLabelValues(method, shadowDropped).Inc()
+		return
+	}
+	primary := writer.body.Bytes()
+	go func() {
+		defer func() { <-s.inFlight }()
+		s.compare(method, body, primary)
+	}()
+}
+
+// shadowMethod returns the method of a single read-only request. Batches and
+// bodies that are not JSON-RPC are not mirrored.
+func shadowMethod(body []byte) (string, bool) {
+	var request loggedRequest
+	if err := json.Unmarshal(body, &request); err != nil || request.Method == "" {
+		return "", false
+	}
+	return request.Method, !shadowUnsafeMethods[request.Method]
+}
+
+// compare sends body to the shadow relay and compares its response with the
+// primary one.
+func (s *shadow) compare(method string, body, primary []byte) {
+	shadowed, err := s.send(body)
+	if err != nil {
+		metrics.ShadowRequests.WithLabelValues(method, shadowFailed).Inc()
+		s.logger.Debug("Shadow request failed", zap.String("method", method), zap.Error(err))
+		return
+	}
+
+	diffs, err := responseDiffs(primary, shadowed)
+	if err != nil {
+		metrics.ShadowRequests.WithLabelValues(method, shadowFailed).Inc()
+		s.logger.Debug("Shadow response is not JSON-RPC", zap.String("method", method), zap.Error(err))
+		return
+	}
+	if len(diffs) == 0 {
+		metrics.ShadowRequests.WithLabelValues(method, shadowMatch).Inc()
+		return
+	}
+
+	metrics.ShadowRequests.WithLabelValues(method, shadowMismatch).Inc()
+	var request loggedRequest
+	_ = json.Unmarshal(body, &request)
+	s.logger.Warn("Shadow relay response differs",
+		zap.String("method", method),
+		zap.String("params", redactedParams(method, request.Params)),
+		zap.Strings("diffs", diffs),
+		zap.String("response", truncate(primary, maxLoggedShadowResponse)),
+		zap.String("shadowResponse", truncate(shadowed, maxLoggedShadowResponse)),
+	)
+}
+
+func (s *shadow) send(body []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range s.config.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return io.ReadAll(resp.Body)
+}
+
+type shadowResponse struct {
+	Result interface{} `json:"result"`
+	Error  *struct {
+		Code int `json:"code"`
+	} `json:"error"`
+}
+
+// responseDiffs lists where two JSON-RPC responses differ: in their error
+// code, or at the paths of their results holding different values. Hex
+// strings are compared case-insensitively, as relays may or may not checksum
+// addresses.
+func responseDiffs(primary, shadowed []byte) ([]string, error) {
+	var a, b shadowResponse
+	if err := json.Unmarshal(primary, &a); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(shadowed, &b); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case a.Error != nil && b.Error != nil:
+		if a.Error.Code != b.Error.Code {
+			return []string{fmt.Sprintf("error code: %d != %d", a.Error.Code, b.Error.Code)}, nil
+		}
+		return nil, nil
+	case a.Error != nil:
+		return []string{fmt.Sprintf("error: %d != result", a.Error.Code)}, nil
+	case b.Error != nil:
+		return []string{fmt.Sprintf("error: result != %d", b.Error.Code)}, nil
+	}
+
+	var diffs []string
+	diffValues("result", a.Result, b.Result, &diffs)
+	return diffs, nil
+}
+
+func diffValues(path string, a, b interface{}, diffs *[]string) {
+	if len(*diffs) >= maxShadowDiffs {
+		return
+	}
+
+	switch a := a.(type) {
+	case map[string]interface{}:
+		if b, ok := b.(map[string]interface{}); ok {
+			keys := make(map[string]bool, len(a)+len(b))
+			for key := range a {
+				keys[key] = true
+			}
+			for key := range b {
+				keys[key] = true
+			}
+			sorted := make([]string, 0, len(keys))
+			for key := range keys {
+				sorted = append(sorted, key)
+			}
+			sort.Strings(sorted)
+			for _, key := range sorted {
+				diffValues(path+"."+key, a[key], b[key], diffs)
+			}
+			return
+		}
+	case []interface{}:
+		if b, ok := b.([]interface{}); ok {
+			if len(a) != len(b) {
+				*diffs = append(*diffs, fmt.Sprintf("%s: %d != %d elements", path, len(a), len(b)))
+				return
+			}
+			for i := range a {
+				diffValues(fmt.Sprintf("%s[%d]", path, i), a[i], b[i], diffs)
+			}
+			return
+		}
+	case string:
+		if b, ok := b.(string); ok && strings.HasPrefix(a, "0x") && strings.EqualFold(a, b) {
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*diffs = append(*diffs, fmt.Sprintf("%s: %s != %s", path, renderValue(a), renderValue(b)))
+	}
+}
+
+func renderValue(value interface{}) string {
+	if value == nil {
+		return "missing"
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return truncate(encoded, 100)
+}
+
+func truncate(data []byte, limit int) string {
+	if len(data) > limit {
+		return string(data[:limit]) + "..."
+	}
+	return string(data)
+}
@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"context"
+	"math"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/service"
+)
+
+// shed reports whether a call is turned away because the relay is overloaded:
+// calls of low-priority methods and eth_getLogs queries spanning more blocks
+// than the overload controller allows.
+func (h *rpcHandler) shed(ctx context.Context, methodName string, params interface{}) bool {
+	if !h.overload.Overloaded() {
+		return false
+	}
+	if h.overload.LowPriority(methodName) {
+		return true
+	}
+
+	logParams, ok := params.(*domain.EthGetLogsParams)
+	maxRange := h.overload.MaxLogsBlockRange()
+	return ok && maxRange > 0 && h.logsBlockSpan(ctx, logParams) > maxRange
+}
+
+// logsBlockSpan returns how many blocks after the first an eth_getLogs query
+// covers. Tags are resolved against the latest block; spans that cannot be
+// worked out count as unbounded.
+func (h *rpcHandler) logsBlockSpan(ctx context.Context, params *domain.EthGetLogsParams) int64 {
+	if params.BlockHash != "" {
+		return 0
+	}
+
+	var latest *int64
+	resolve := func(block string) (int64, bool) {
+		switch block {
+		case domain.BlockTagEarliest:
+			return 0, true
+		case domain.BlockTagLatest, domain.BlockTagPending, domain.BlockTagSafe, domain.BlockTagFinalized:
+			if latest == nil {
+				result, rpcErr := h.services.EthService().GetBlockNumber(ctx)
+				hexNumber, ok := result.(string)
+				if rpcErr != nil || !ok {
+					return 0, false
+				}
+				number, err := service.HexToDec(hexNumber)
+				if err != nil {
+					return 0, false
+				}
+				latest = &number
+			}
+			return *latest, true
+		}
+		number, err := service.HexToDec(block)
+		return number, err == nil
+	}
+
+	from, fromOK := resolve(params.FromBlock)
+	to, toOK := resolve(params.ToBlock)
+	if !fromOK || !toOK {
+		return math.MaxInt64
+	}
+	return to - from
+}
@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"context"
+	"sort"
 
 	"github.com/LimeChain/Hederium/internal/domain"
 	"github.com/LimeChain/Hederium/internal/service"
@@ -13,8 +14,18 @@ type MethodInfo struct {
 	Name         string
 	ParamCreator func() domain.RPCParams
 	Handler      HandlerFunc
+	// Enabled reports whether the method is turned on in the relay
+	// configuration; nil means it always is.
+	Enabled func(services service.ServiceProvider) bool
+	// Unsupported marks methods that are registered only to explain why the
+	// relay does not implement them.
+	Unsupported bool
 }
 
+// accountMethods need accounts or private keys held by the node, which the
+// relay deliberately does not have.
+var accountMethods = []string{"eth_sendTransaction", "eth_sign", "eth_signTransaction"}
+
 type Methods struct {
 	methods map[string]MethodInfo
 }
@@ -27,6 +38,11 @@ func NewMethods() *Methods {
 	m.registerWeb3Methods()
 	m.registerNetMethods()
 	m.registerFilterMethods()
+	m.registerDebugMethods()
+	m.registerHederiumMethods()
+	m.registerHederaMethods()
+	m.registerDiscoverMethods()
+	m.registerUnsupportedMethods()
 
 	return m
 }
@@ -40,6 +56,27 @@ func (m *Methods) registerMethod(info MethodInfo) {
 	m.methods[info.Name] = info
 }
 
+// SupportedMethods lists the methods the relay answers with its current
+// configuration, and those it recognizes but does not implement.
+func (m *Methods) SupportedMethods(services service.ServiceProvider) domain.SupportedMethods {
+	result := domain.SupportedMethods{Supported: []string{}, Unsupported: []string{}}
+	for name, info := range m.methods {
+		switch {
+		case info.Unsupported:
+			result.Unsupported = append(result.Unsupported, name)
+		case info.Enabled == nil || info.Enabled(services):
+			result.Supported = append(result.Supported, name)
+		}
+	}
+	sort.Strings(result.Supported)
+	sort.Strings(result.Unsupported)
+	return result
+}
+
+func filtersEnabled(services service.ServiceProvider) bool {
+	return services.FilterService().Enabled()
+}
+
 func (m *Methods) registerEthMethods() {
 	m.registerMethod(MethodInfo{
 		Name: "eth_blockNumber",
@@ -47,7 +84,7 @@ func (m *Methods) registerEthMethods() {
 			return &domain.NoParameters{}
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
-			return services.EthService().GetBlockNumber()
+			return services.EthService().GetBlockNumber(ctx)
 		},
 	})
 
@@ -58,7 +95,7 @@ func (m *Methods) registerEthMethods() {
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
 			p := params.(*domain.EthGetBlockByHashParams)
-			return services.EthService().GetBlockByHash(p.BlockHash, p.ShowDetails)
+			return services.EthService().GetBlockByHash(ctx, p.BlockHash, p.ShowDetails)
 		},
 	})
 
@@ -69,7 +106,7 @@ func (m *Methods) registerEthMethods() {
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
 			p := params.(*domain.EthGetBlockByNumberParams)
-			return services.EthService().GetBlockByNumber(p.BlockNumber, p.ShowDetails)
+			return services.EthService().GetBlockByNumber(ctx, p.BlockNumber, p.ShowDetails)
 		},
 	})
 
@@ -80,7 +117,7 @@ func (m *Methods) registerEthMethods() {
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
 			p := params.(*domain.EthGetBalanceParams)
-			return services.EthService().GetBalance(p.Address, p.BlockNumber), nil
+			return services.EthService().GetBalance(ctx, p.Address, p.BlockNumber)
 		},
 	})
 
@@ -91,7 +128,7 @@ func (m *Methods) registerEthMethods() {
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
 			p := params.(*domain.EthGetTransactionCountParams)
-			return services.EthService().GetTransactionCount(p.Address, p.BlockNumber), nil
+			return services.EthService().GetTransactionCount(ctx, p.Address, p.BlockNumber)
 		},
 	})
 
@@ -102,7 +139,7 @@ func (m *Methods) registerEthMethods() {
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
 			p := params.(*domain.EthGetCodeParams)
-			return services.EthService().GetCode(p.Address, p.BlockNumber)
+			return services.EthService().GetCode(ctx, p.Address, p.BlockNumber)
 		},
 	})
 
@@ -113,7 +150,18 @@ func (m *Methods) registerEthMethods() {
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
 			p := params.(*domain.EthGetStorageAtParams)
-			return services.EthService().GetStorageAt(p.Address, p.StoragePosition, p.BlockNumber)
+			return services.EthService().GetStorageAt(ctx, p.Address, p.StoragePosition, p.BlockNumber)
+		},
+	})
+
+	m.registerMethod(MethodInfo{
+		Name: "eth_getProof",
+		ParamCreator: func() domain.RPCParams {
+			return &domain.EthGetProofParams{}
+		},
+		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
+			p := params.(*domain.EthGetProofParams)
+			return services.EthService().GetProof(ctx, p.Address, p.StorageKeys, p.BlockNumber)
 		},
 	})
 
@@ -124,7 +172,7 @@ func (m *Methods) registerEthMethods() {
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
 			p := params.(*domain.EthSendRawTransactionParams)
-			return services.EthService().SendRawTransaction(p.SignedTransaction)
+			return services.EthService().SendRawTransaction(ctx, p.SignedTransaction)
 		},
 	})
 
@@ -135,7 +183,7 @@ func (m *Methods) registerEthMethods() {
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
 			p := params.(*domain.EthGetTransactionByHashParams)
-			return services.EthService().GetTransactionByHash(p.TransactionHash)
+			return services.EthService().GetTransactionByHash(ctx, p.TransactionHash)
 		},
 	})
 
@@ -146,7 +194,7 @@ func (m *Methods) registerEthMethods() {
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
 			p := params.(*domain.EthGetTransactionReceiptParams)
-			return services.EthService().GetTransactionReceipt(p.TransactionHash)
+			return services.EthService().GetTransactionReceipt(ctx, p.TransactionHash)
 		},
 	})
 
@@ -157,7 +205,7 @@ func (m *Methods) registerEthMethods() {
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
 			p := params.(*domain.EthGetBlockTransactionCountByHashParams)
-			return services.EthService().GetBlockTransactionCountByHash(p.BlockHash)
+			return services.EthService().GetBlockTransactionCountByHash(ctx, p.BlockHash)
 		},
 	})
 
@@ -168,7 +216,7 @@ func (m *Methods) registerEthMethods() {
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
 			p := params.(*domain.EthGetBlockTransactionCountByNumberParams)
-			return services.EthService().GetBlockTransactionCountByNumber(p.BlockNumber)
+			return services.EthService().GetBlockTransactionCountByNumber(ctx, p.BlockNumber)
 		},
 	})
 
@@ -179,7 +227,7 @@ func (m *Methods) registerEthMethods() {
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
 			p := params.(*domain.EthGetTransactionByBlockHashAndIndexParams)
-			return services.EthService().GetTransactionByBlockHashAndIndex(p.BlockHash, p.TransactionIndex)
+			return services.EthService().GetTransactionByBlockHashAndIndex(ctx, p.BlockHash, p.TransactionIndex)
 		},
 	})
 
@@ -190,7 +238,7 @@ func (m *Methods) registerEthMethods() {
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
 			p := params.(*domain.EthGetTransactionByBlockNumberAndIndexParams)
-			return services.EthService().GetTransactionByBlockNumberAndIndex(p.BlockNumber, p.TransactionIndex)
+			return services.EthService().GetTransactionByBlockNumberAndIndex(ctx, p.BlockNumber, p.TransactionIndex)
 		},
 	})
 
@@ -201,7 +249,7 @@ func (m *Methods) registerEthMethods() {
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
 			p := params.(*domain.EthCallParams)
-			return services.EthService().Call(p.CallObject, p.Block)
+			return services.EthService().Call(ctx, p.CallObject, p.Block)
 		},
 	})
 
@@ -212,7 +260,18 @@ func (m *Methods) registerEthMethods() {
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
 			p := params.(*domain.EthEstimateGasParams)
-			return services.EthService().EstimateGas(p.CallObject, p.BlockParameter)
+			return services.EthService().EstimateGas(ctx, p.CallObject, p.BlockParameter)
+		},
+	})
+
+	m.registerMethod(MethodInfo{
+		Name: "eth_createAccessList",
+		ParamCreator: func() domain.RPCParams {
+			return &domain.EthCreateAccessListParams{}
+		},
+		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
+			p := params.(*domain.EthCreateAccessListParams)
+			return services.EthService().CreateAccessList(ctx, p.CallObject, p.BlockParameter)
 		},
 	})
 
@@ -222,7 +281,7 @@ func (m *Methods) registerEthMethods() {
 			return &domain.NoParameters{}
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
-			return services.EthService().GetGasPrice()
+			return services.EthService().GetGasPrice(ctx)
 		},
 	})
 
@@ -244,7 +303,7 @@ func (m *Methods) registerEthMethods() {
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
 			p := params.(*domain.EthGetLogsParams)
 			logParams := p.ToLogParams()
-			return services.EthService().GetLogs(logParams)
+			return services.EthService().GetLogs(ctx, logParams)
 		},
 	})
 
@@ -255,7 +314,7 @@ func (m *Methods) registerEthMethods() {
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
 			p := params.(*domain.EthFeeHistoryParams)
-			return services.EthService().FeeHistory(p.BlockCount, p.NewestBlock, p.RewardPercentiles)
+			return services.EthService().FeeHistory(ctx, p.BlockCount, p.NewestBlock, p.RewardPercentiles)
 		},
 	})
 
@@ -319,7 +378,7 @@ func (m *Methods) registerEthMethods() {
 			return &domain.NoParameters{}
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
-			return services.EthService().Syncing()
+			return services.EthService().Syncing(ctx)
 		},
 	})
 
@@ -365,6 +424,17 @@ func (m *Methods) registerWeb3Methods() {
 			return services.Web3Service().ClientVersion(), nil
 		},
 	})
+
+	m.registerMethod(MethodInfo{
+		Name: "web3_sha3",
+		ParamCreator: func() domain.RPCParams {
+			return &domain.Web3Sha3Params{}
+		},
+		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
+			p := params.(*domain.Web3Sha3Params)
+			return services.Web3Service().Sha3(p.Data)
+		},
+	})
 }
 
 // registerNetMethods registers all Net API methods
@@ -388,71 +458,199 @@ func (m *Methods) registerNetMethods() {
 			return services.NetService().Version(), nil
 		},
 	})
+
+	m.registerMethod(MethodInfo{
+		Name: "net_peerCount",
+		ParamCreator: func() domain.RPCParams {
+			return &domain.NoParameters{}
+		},
+		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
+			return services.NetService().PeerCount(), nil
+		},
+	})
 }
 
 // registerFilterMethods registers all Filter API methods
 func (m *Methods) registerFilterMethods() {
 	m.registerMethod(MethodInfo{
-		Name: "eth_newFilter",
+		Name:    "eth_newFilter",
+		Enabled: filtersEnabled,
 		ParamCreator: func() domain.RPCParams {
 			return &domain.EthNewFilterParams{}
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
 			p := params.(*domain.EthNewFilterParams)
-			return services.FilterService().NewFilter(p.FromBlock, p.ToBlock, p.Address, p.Topics)
+			return services.FilterService().NewFilter(ctx, p.FromBlock, p.ToBlock, p.Address, p.Topics)
 		},
 	})
 
 	m.registerMethod(MethodInfo{
-		Name: "eth_newBlockFilter",
+		Name:    "eth_newBlockFilter",
+		Enabled: filtersEnabled,
 		ParamCreator: func() domain.RPCParams {
 			return &domain.NoParameters{}
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
-			return services.FilterService().NewBlockFilter()
+			return services.FilterService().NewBlockFilter(ctx)
 		},
 	})
 
 	m.registerMethod(MethodInfo{
-		Name: "eth_uninstallFilter",
+		Name:    "eth_uninstallFilter",
+		Enabled: filtersEnabled,
 		ParamCreator: func() domain.RPCParams {
 			return &domain.EthUninstallFilterParams{}
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
 			p := params.(*domain.EthUninstallFilterParams)
-			return services.FilterService().UninstallFilter(p.FilterID)
+			return services.FilterService().UninstallFilter(ctx, p.FilterID)
 		},
 	})
 
 	m.registerMethod(MethodInfo{
-		Name: "eth_newPendingTransactionFilter",
+		Name:    "eth_newPendingTransactionFilter",
+		Enabled: filtersEnabled,
 		ParamCreator: func() domain.RPCParams {
 			return &domain.NoParameters{}
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
-			return services.FilterService().NewPendingTransactionFilter()
+			return services.FilterService().NewPendingTransactionFilter(ctx)
 		},
 	})
 
 	m.registerMethod(MethodInfo{
-		Name: "eth_getFilterLogs",
+		Name:    "eth_getFilterLogs",
+		Enabled: filtersEnabled,
 		ParamCreator: func() domain.RPCParams {
 			return &domain.EthGetFilterLogsParams{}
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
 			p := params.(*domain.EthGetFilterLogsParams)
-			return services.FilterService().GetFilterLogs(p.FilterID)
+			return services.FilterService().GetFilterLogs(ctx, p.FilterID)
 		},
 	})
 
 	m.registerMethod(MethodInfo{
-		Name: "eth_getFilterChanges",
+		Name:    "eth_getFilterChanges",
+		Enabled: filtersEnabled,
 		ParamCreator: func() domain.RPCParams {
 			return &domain.EthGetFilterChangesParams{}
 		},
 		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
 			p := params.(*domain.EthGetFilterChangesParams)
-			return services.FilterService().GetFilterChanges(p.FilterID)
+			return services.FilterService().GetFilterChanges(ctx, p.FilterID)
 		},
 	})
 }
+
+// registerDebugMethods registers all Debug API methods
+func (m *Methods) registerDebugMethods() {
+	m.registerMethod(MethodInfo{
+		Name: "debug_traceTransaction",
+		Enabled: func(services service.ServiceProvider) bool {
+			return services.DebugService().Enabled()
+		},
+		ParamCreator: func() domain.RPCParams {
+			return &domain.DebugTraceTransactionParams{}
+		},
+		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
+			p := params.(*domain.DebugTraceTransactionParams)
+			return services.DebugService().TraceTransaction(ctx, p.TransactionIdOrHash, p.Tracer, p.TracerConfig)
+		},
+	})
+}
+
+// registerHederiumMethods registers the non-standard hederium_* methods
+func (m *Methods) registerHederiumMethods() {
+	m.registerMethod(MethodInfo{
+		Name: "hederium_getConfiguration",
+		Enabled: func(services service.ServiceProvider) bool {
+			return services.HederiumService().Enabled()
+		},
+		ParamCreator: func() domain.RPCParams {
+			return &domain.NoParameters{}
+		},
+		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
+			return services.HederiumService().GetConfiguration(ctx)
+		},
+	})
+
+	m.registerMethod(MethodInfo{
+		Name: "hederium_getExchangeRate",
+		ParamCreator: func() domain.RPCParams {
+			return &domain.NoParameters{}
+		},
+		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
+			return services.ExchangeRateService().GetExchangeRate(ctx)
+		},
+	})
+
+	m.registerMethod(MethodInfo{
+		Name: "hederium_supportedMethods",
+		ParamCreator: func() domain.RPCParams {
+			return &domain.NoParameters{}
+		},
+		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
+			return m.SupportedMethods(services), nil
+		},
+	})
+}
+
+// registerHederaMethods registers the hedera_* methods, which serve token
+// data of the mirror node
+func (m *Methods) registerHederaMethods() {
+	m.registerMethod(MethodInfo{
+		Name: "hedera_getTokenBalances",
+		ParamCreator: func() domain.RPCParams {
+			return &domain.HederaGetTokenBalancesParams{}
+		},
+		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
+			p := params.(*domain.HederaGetTokenBalancesParams)
+			return services.HederaService().GetTokenBalances(ctx, p.Address)
+		},
+	})
+
+	m.registerMethod(MethodInfo{
+		Name: "hedera_getTokenInfo",
+		ParamCreator: func() domain.RPCParams {
+			return &domain.HederaGetTokenInfoParams{}
+		},
+		Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
+			p := params.(*domain.HederaGetTokenInfoParams)
+			return services.HederaService().GetTokenInfo(ctx, p.TokenAddress)
+		},
+	})
+}
+
+// registerDiscoverMethods registers rpc.discover, the OpenRPC service
+// discovery method, also under the rpc_discover name used by some clients.
+func (m *Methods) registerDiscoverMethods() {
+	for _, name := range []string{"rpc.discover", "rpc_discover"} {
+		m.registerMethod(MethodInfo{
+			Name: name,
+			ParamCreator: func() domain.RPCParams {
+				return &domain.NoParameters{}
+			},
+			Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
+				return m.Discover(services), nil
+			},
+		})
+	}
+}
+
+// registerUnsupportedMethods registers the account methods, so that callers
+// learn how to work without them instead of getting a generic routing error.
+func (m *Methods) registerUnsupportedMethods() {
+	for _, name := range accountMethods {
+		m.registerMethod(MethodInfo{
+			Name: name,
+			ParamCreator: func() domain.RPCParams {
+				return &domain.NoParameters{}
+			},
+			Handler: func(ctx context.Context, params domain.RPCParams, services service.ServiceProvider) (interface{}, *domain.RPCError) {
+				return nil, domain.NewAccountMethodUnsupportedError(name)
+			},
+			Unsupported: true,
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package rpc
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/service"
+)
+
+// OpenRPCVersion is the version of the OpenRPC specification rpc.discover
+// documents follow.
+const OpenRPCVersion = "1.2.6"
+
+// OpenRPCDocument describes the methods of the relay, as returned by
+// rpc.discover. See https://spec.open-rpc.org.
+type OpenRPCDocument struct {
+	OpenRPC string          `json:"openrpc"`
+	Info    OpenRPCInfo     `json:"info"`
+	Methods []OpenRPCMethod `json:"methods"`
+	// UnsupportedMethods lists the methods the relay recognizes but does not
+	// implement, such as those needing accounts held by the node.
+	UnsupportedMethods []string `json:"x-unsupportedMethods"`
+}
+
+type OpenRPCInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type OpenRPCMethod struct {
+	Name string `json:"name"`
+	// Description notes where the method departs from the Ethereum JSON-RPC
+	// specification.
+	Description    string                     `json:"description,omitempty"`
+	ParamStructure string                     `json:"paramStructure"`
+	Params         []OpenRPCContentDescriptor `json:"params"`
+	Result         OpenRPCContentDescriptor   `json:"result"`
+}
+
+type OpenRPCContentDescriptor struct {
+	Name     string                 `json:"name"`
+	Required bool                   `json:"required,omitempty"`
+	Schema   map[string]interface{} `json:"schema"`
+}
+
+// methodDeviations describes how methods differ from their Ethereum
+// counterparts on Hedera.
+var methodDeviations = map[string]string{
+	"eth_accounts":                      "Returns an empty list, as the relay holds no accounts, unless a list of addresses is configured.",
+	"eth_mining":                        "Always returns false, as Hedera has no mining.",
+	"eth_hashrate":                      "Always returns 0x0, as Hedera has no mining.",
+	"eth_syncing":                       "Returns false, or a sync status when the syncing check is enabled and the Mirror Node lags behind consensus.",
+	"eth_getUncleCountByBlockHash":      "Always returns 0x0, as Hedera has no uncle blocks.",
+	"eth_getUncleCountByBlockNumber":    "Always returns 0x0, as Hedera has no uncle blocks.",
+	"eth_getUncleByBlockHashAndIndex":   "Always returns null, as Hedera has no uncle blocks.",
+	"eth_getUncleByBlockNumberAndIndex": "Always returns null, as Hedera has no uncle blocks.",
+	"eth_createAccessList":              "Returns an empty access list with the gas estimate of the Mirror Node.",
+	"eth_getProof":                      "Returns empty account and storage proofs, as Hedera cannot produce Merkle proofs.",
+	"eth_getLogs":                       "Fails with -32005 when more logs match than the configured maximum or the query spans more Mirror Node pages than allowed.",
+	"eth_estimateGas":                   "Falls back to a heuristic estimate when the Mirror Node fails for reasons other than a revert.",
+	"eth_sendRawTransaction":            "Returns once the Mirror Node records the transaction, or once a Consensus Node accepts it when asynchronous submission is enabled.",
+	"eth_getTransactionByHash":          "Also accepts Hedera transaction IDs, as 0.0.1001@1700000002.000000005 or 0.0.1001-1700000002-000000005.",
+	"eth_getStorageAt":                  "Also accepts storage slots shorter than 32 bytes and decimal slots.",
+	"net_version":                       "Returns the chain ID in decimal.",
+	"net_peerCount":                     "Always returns 0x0.",
+	"debug_traceTransaction":            "Supports the callTracer and opcodeLogger tracers only, backed by the Mirror Node.",
+	"hederium_getConfiguration":         "Hedera-specific: returns the configuration of the running relay.",
+	"hederium_supportedMethods":         "Hedera-specific: lists the supported and unsupported methods.",
+	"hederium_getExchangeRate":          "Hedera-specific: returns the current HBAR to USD exchange rate of the network.",
+	"hedera_getTokenBalances":           "Hedera-specific: lists the HTS tokens an account is associated with and its balance of each.",
+	"hedera_getTokenInfo":               "Hedera-specific: returns the metadata of the HTS token at a long-zero token address.",
+}
+
+// paramSchemaOverrides replaces the schema derived from the validation tags of
+// parameters that are normalized before they are validated.
+var paramSchemaOverrides = map[string]map[string]interface{}{
+	"eth_getStorageAt.storagePosition": {
+		"anyOf": []interface{}{
+			map[string]interface{}{"type": "string", "pattern": "^0x[0-9a-fA-F]{1,64}$"},
+			map[string]interface{}{"type": "string", "pattern": "^[0-9]+$"},
+			map[string]interface{}{"type": "integer", "minimum": 0},
+		},
+	},
+}
+
+var (
+	hexSchema         = map[string]interface{}{"type": "string", "pattern": "^0x[0-9a-fA-F]+$"}
+	hashSchema        = map[string]interface{}{"type": "string", "pattern": "^0x[0-9a-fA-F]{64}$"}
+	addressSchema     = map[string]interface{}{"type": "string", "pattern": "^0x[0-9a-fA-F]{40}$"}
+	blockTagSchema    = map[string]interface{}{"type": "string", "enum": []string{"latest", "earliest", "pending", "safe", "finalized"}}
+	blockObjectSchema = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"blockHash":        hashSchema,
+			"blockNumber":      hexSchema,
+			"requireCanonical": map[string]interface{}{"type": "boolean"},
+		},
+	}
+	transactionIDSchema = map[string]interface{}{"type": "string", "pattern": `^\d+\.\d+\.\d+(@\d+\.\d+|-\d+-\d+)$`}
+	topicsSchema        = map[string]interface{}{
+		"type":     "array",
+		"maxItems": 4,
+		"items": map[string]interface{}{
+			"anyOf": []interface{}{
+				map[string]interface{}{"type": "null"},
+				hashSchema,
+				map[string]interface{}{"type": "array", "items": hashSchema},
+			},
+		},
+	}
+)
+
+// Discover builds the OpenRPC document of the methods the relay answers with
+// its current configuration. Parameter schemas are derived from the params
+// structs as described on domain.RPCParams.
+func (m *Methods) Discover(services service.ServiceProvider) OpenRPCDocument {
+	supported := m.SupportedMethods(services)
+
+	document := OpenRPCDocument{
+		OpenRPC: OpenRPCVersion,
+		Info: OpenRPCInfo{
+			Title:   "Hederium JSON-RPC relay",
+			Version: strings.TrimPrefix(services.Web3Service().ClientVersion(), "hederium/"),
+		},
+		Methods:            make([]OpenRPCMethod, 0, len(supported.Supported)),
+		UnsupportedMethods: supported.Unsupported,
+	}
+	for _, name := range supported.Supported {
+		document.Methods = append(document.Methods, OpenRPCMethod{
+			Name:           name,
+			Description:    methodDeviations[name],
+			ParamStructure: "by-position",
+			Params:         paramDescriptors(name, m.methods[name].ParamCreator()),
+			Result:         OpenRPCContentDescriptor{Name: "result", Schema: map[string]interface{}{}},
+		})
+	}
+	return document
+}
+
+func paramDescriptors(method string, params domain.RPCParams) []OpenRPCContentDescriptor {
+	descriptors := []OpenRPCContentDescriptor{}
+	objects := make(map[string]int)
+
+	structType := reflect.TypeOf(params).Elem()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		rules := field.Tag.Get("binding")
+		if rules == "" {
+			rules = field.Tag.Get("validate")
+		}
+		schema, ok := paramSchemaOverrides[method+"."+name]
+		if !ok {
+			schema = fieldSchema(field.Type, strings.Split(rules, ","))
+		}
+		required := hasRule(strings.Split(rules, ","), "required")
+
+		object, options, _ := strings.Cut(field.Tag.Get("rpcparam"), ",")
+		if object == "" {
+			descriptors = append(descriptors, OpenRPCContentDescriptor{Name: name, Required: required, Schema: schema})
+			continue
+		}
+
+		index, ok := objects[object]
+		if !ok {
+			index = len(descriptors)
+			objects[object] = index
+			descriptors = append(descriptors, OpenRPCContentDescriptor{
+				Name:   object,
+				Schema: map[string]interface{}{"type": "object", "properties": map[string]interface{}{}},
+			})
+		}
+		descriptors[index].Schema["properties"].(map[string]interface{})[name] = schema
+		if required || options == "required" {
+			descriptors[index].Required = true
+		}
+	}
+	return descriptors
+}
+
+// fieldSchema returns the JSON schema of a field of the given type validated
+// by rules, the comma-separated binding tag split into its parts.
+func fieldSchema(fieldType reflect.Type, rules []string) map[string]interface{} {
+	// Rules after dive apply to the elements of a slice
+	elementRules := rules
+	for i, rule := range rules {
+		if rule == "dive" {
+			rules, elementRules = rules[:i], rules[i+1:]
+			break
+		}
+	}
+
+	if fieldType == reflect.TypeOf(domain.Address{}) {
+		return map[string]interface{}{
+			"anyOf": []interface{}{addressSchema, map[string]interface{}{"type": "array", "items": addressSchema}},
+		}
+	}
+
+	if fieldType == reflect.TypeOf(domain.TopicFilter{}) {
+		return topicsSchema
+	}
+
+	switch fieldType.Kind() {
+	case reflect.String:
+		return stringSchema(rules)
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": fieldSchema(fieldType.Elem(), elementRules)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object"}
+	case reflect.Struct:
+		properties := make(map[string]interface{})
+		for i := 0; i < fieldType.NumField(); i++ {
+			field := fieldType.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if !field.IsExported() || name == "-" || name == "" {
+				continue
+			}
+			properties[name] = fieldSchema(field.Type, strings.Split(field.Tag.Get("binding"), ","))
+		}
+		return map[string]interface{}{"type": "object", "properties": properties}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+func stringSchema(rules []string) map[string]interface{} {
+	switch {
+	case hasRule(rules, "eth_address"):
+		return addressSchema
+	case hasRule(rules, "block_number_or_tag"):
+		return map[string]interface{}{"anyOf": []interface{}{hexSchema, blockTagSchema}}
+	case hasRule(rules, "block_number_tag_or_hash"):
+		return map[string]interface{}{"anyOf": []interface{}{hexSchema, blockTagSchema, blockObjectSchema}}
+	case hasRule(rules, "transaction_hash_or_id"):
+		return map[string]interface{}{"anyOf": []interface{}{hashSchema, transactionIDSchema}}
+	case hasRule(rules, "hexadecimal") && hasRule(rules, "len=66"):
+		return hashSchema
+	case hasRule(rules, "hexadecimal"):
+		return hexSchema
+	case hasRule(rules, "startswith=0x"):
+		return map[string]interface{}{"type": "string", "pattern": "^0x"}
+	}
+	for _, rule := range rules {
+		if values, ok := strings.CutPrefix(rule, "oneof="); ok {
+			enum := strings.Fields(values)
+			sort.Strings(enum)
+			return map[string]interface{}{"type": "string", "enum": enum}
+		}
+	}
+	return map[string]interface{}{"type": "string"}
+}
+
+func hasRule(rules []string, rule string) bool {
+	for _, r := range rules {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
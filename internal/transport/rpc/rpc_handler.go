@@ -2,9 +2,15 @@ package rpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"runtime/debug"
+	"time"
 
 	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	hederiumlogger "github.com/LimeChain/Hederium/internal/infrastructure/logger"
+	"github.com/LimeChain/Hederium/internal/infrastructure/metrics"
 	"github.com/LimeChain/Hederium/internal/service"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
@@ -30,25 +36,42 @@ type RPCHandler interface {
 }
 
 type rpcHandler struct {
-	logger   *zap.Logger
-	registry *Methods
-	services service.ServiceProvider
+	logger            *zap.Logger
+	registry          *Methods
+	services          service.ServiceProvider
+	tieredLimiter     *limiter.TieredLimiter
+	methodConcurrency *limiter.MethodConcurrency
+	overload          *limiter.OverloadController
+	timeouts          TimeoutConfig
 }
 
+// NewHandler creates the handler dispatching JSON-RPC calls to services.
+// methodConcurrency may be nil to leave every method unbounded, overload nil
+// to never shed load, and timeouts zero to give calls no budget.
 func NewHandler(
 	logger *zap.Logger,
 	services service.ServiceProvider,
+	tieredLimiter *limiter.TieredLimiter,
+	methodConcurrency *limiter.MethodConcurrency,
+	overload *limiter.OverloadController,
+	timeouts TimeoutConfig,
 ) RPCHandler {
 	return &rpcHandler{
-		logger:   logger,
-		registry: NewMethods(),
-		services: services,
+		logger:            logger,
+		registry:          NewMethods(),
+		services:          services,
+		tieredLimiter:     tieredLimiter,
+		methodConcurrency: methodConcurrency,
+		overload:          overload,
+		timeouts:          timeouts,
 	}
 }
 
 func (h *rpcHandler) HandleRequest(ctx context.Context, req *JSONRPCRequest) *JSONRPCResponse {
 	methodName := req.Method
-	h.logger.Info("JSON-RPC method called", zap.String("method", methodName))
+	h.logger.Info("JSON-RPC method called",
+		zap.String("method", methodName),
+		zap.String("requestId", hederiumlogger.RequestIDFromContext(ctx)))
 
 	result, rpcErr := h.dispatchMethod(ctx, methodName, req.Params)
 	resp := &JSONRPCResponse{JSONRPC: "2.0", ID: req.ID}
@@ -57,15 +80,42 @@ func (h *rpcHandler) HandleRequest(ctx context.Context, req *JSONRPCRequest) *JS
 	} else {
 		resp.Result = result
 	}
+
+	apiKey, tier := limiter.APIKeyFromContext(ctx)
+	h.tieredLimiter.Usage().RecordRequest(apiKey, tier, methodName, rpcErr != nil)
 	return resp
 }
 
-func (h *rpcHandler) dispatchMethod(ctx context.Context, methodName string, params interface{}) (interface{}, *domain.RPCError) {
+func (h *rpcHandler) dispatchMethod(ctx context.Context, methodName string, params interface{}) (result interface{}, rpcErr *domain.RPCError) {
 	methodInfo, ok := h.registry.GetMethod(methodName)
 	if !ok {
 		return nil, domain.NewRPCError(domain.MethodNotFound, fmt.Sprintf("Unsupported JSON-RPC method: %s", methodName))
 	}
 
+	// A panicking call fails on its own, without taking down the other calls
+	// of its batch or the relay
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			metrics.Panics.WithLabelValues(methodName).Inc()
+			h.logger.Error("Recovered from panic in JSON-RPC method",
+				zap.String("method", methodName),
+				zap.String("requestId", hederiumlogger.RequestIDFromContext(ctx)),
+				zap.Any("panic", recovered),
+				zap.ByteString("stack", debug.Stack()))
+			result, rpcErr = nil, domain.NewInternalError("Internal error")
+		}
+	}()
+
+	// The tier is only set when API keys are enforced
+	if apiKey, tier := limiter.APIKeyFromContext(ctx); tier != "" {
+		if !h.tieredLimiter.MethodAllowed(tier, methodName) {
+			return nil, domain.NewMethodNotAllowedError(methodName, tier)
+		}
+		if status, allowed := h.tieredLimiter.AllowMethod(apiKey, tier, methodName); !allowed {
+			return nil, domain.NewRequestRateLimitExceededError(status.Limit, status.RetryAfter())
+		}
+	}
+
 	h.logger.Debug("Received params", zap.Any("params", params))
 
 	rpcParams := methodInfo.ParamCreator()
@@ -84,9 +134,42 @@ func (h *rpcHandler) dispatchMethod(ctx context.Context, methodName string, para
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		if err := v.Struct(rpcParams); err != nil {
 			h.logger.Debug("Validation failed", zap.Error(err))
-			return nil, domain.NewRPCError(domain.InvalidParams, err.Error())
+			return nil, domain.NewRPCError(domain.InvalidParams, domain.NewValidationParamError(rpcParams, err).Error())
 		}
 	}
 
-	return methodInfo.Handler(ctx, rpcParams, h.services)
+	if h.shed(ctx, methodName, rpcParams) {
+		metrics.LoadShedRequests.WithLabelValues(methodName).Inc()
+		return nil, domain.NewServerOverloadedError(methodName)
+	}
+
+	// Slots are only taken by valid calls, so malformed ones cannot queue
+	// ahead of them
+	release, ok := h.methodConcurrency.Acquire(ctx, methodName)
+	if !ok {
+		metrics.MethodConcurrencyRejections.WithLabelValues(methodName).Inc()
+		return nil, domain.NewConcurrencyLimitExceededError(methodName)
+	}
+	defer release()
+
+	// The budget starts once the call holds its slot, and reaches every
+	// mirror node request made for it through the context
+	callCtx, cancel := h.timeouts.withTimeout(ctx, methodName)
+	defer cancel()
+	start := time.Now()
+	result, rpcErr = methodInfo.Handler(callCtx, rpcParams, h.services)
+
+	// A call that ran out of budget fails as such even when its handler
+	// returned, as what it returned was built from cancelled requests
+	if errors.Is(callCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		elapsed := time.Since(start)
+		metrics.MethodTimeouts.WithLabelValues(methodName).Inc()
+		h.logger.Warn("JSON-RPC method timed out",
+			zap.String("method", methodName),
+			zap.String("requestId", hederiumlogger.RequestIDFromContext(ctx)),
+			zap.Duration("timeout", h.timeouts.For(methodName)),
+			zap.Duration("elapsed", elapsed))
+		return nil, domain.NewMethodTimeoutError(methodName, elapsed)
+	}
+	return result, rpcErr
 }
@@ -0,0 +1,37 @@
+package rpc
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// TimeoutConfig sets how long a call of each JSON-RPC method in Methods may
+// run before it fails, and Default for the methods not listed. Zero leaves a
+// method without a budget of its own, bounded only by the mirror node timeout
+// of each request it makes.
+type TimeoutConfig struct {
+	Default time.Duration
+	Methods map[string]time.Duration
+}
+
+// For returns the budget of a call of method. Method names match in any
+// letter case, as viper lower-cases map keys.
+func (c TimeoutConfig) For(method string) time.Duration {
+	for name, timeout := range c.Methods {
+		if strings.EqualFold(name, method) {
+			return timeout
+		}
+	}
+	return c.Default
+}
+
+// withTimeout derives the context a call of method runs with, which is done
+// once its budget is spent.
+func (c TimeoutConfig) withTimeout(ctx context.Context, method string) (context.Context, context.CancelFunc) {
+	timeout := c.For(method)
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
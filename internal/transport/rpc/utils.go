@@ -2,14 +2,26 @@ package rpc
 
 import (
 	"regexp"
+
+	"github.com/LimeChain/Hederium/internal/domain"
 )
 
 func IsValidAddress(address string) bool {
 	return regexp.MustCompile("^0x[a-fA-F0-9]{40}$").MatchString(address)
 }
 
+// IsValidBlockTag reports whether tag is one of the named blocks. Hedera
+// blocks are final once created, so safe and finalized name the latest block.
+func IsValidBlockTag(tag string) bool {
+	switch tag {
+	case domain.BlockTagLatest, domain.BlockTagEarliest, domain.BlockTagPending, domain.BlockTagSafe, domain.BlockTagFinalized:
+		return true
+	}
+	return false
+}
+
 func IsValidBlockNumberOrTag(blockNumber string) bool {
-	return blockNumber == "latest" || blockNumber == "earliest" || blockNumber == "pending" || IsValidHexNumber(blockNumber)
+	return IsValidBlockTag(blockNumber) || IsValidHexNumber(blockNumber)
 }
 
 func IsValidHexNumber(hexNumber string) bool {
@@ -17,7 +29,7 @@ func IsValidHexNumber(hexNumber string) bool {
 }
 
 func IsValidBlockHashOrTag(blockHash string) bool {
-	return regexp.MustCompile("^0x[a-fA-F0-9]{64}$").MatchString(blockHash) || blockHash == "latest" || blockHash == "earliest" || blockHash == "pending"
+	return regexp.MustCompile("^0x[a-fA-F0-9]{64}$").MatchString(blockHash) || IsValidBlockTag(blockHash)
 }
 
 func IsValidHexHash(hexHash string) bool {
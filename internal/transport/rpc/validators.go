@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/LimeChain/Hederium/internal/domain"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 )
@@ -20,10 +21,18 @@ func RegisterCustomValidators() error {
 			return err
 		}
 
+		if err := v.RegisterValidation("block_number_tag_or_hash", blockNumberTagOrHashValidator); err != nil {
+			return err
+		}
+
 		if err := v.RegisterValidation("hexadecimal", hexadecimalValidator); err != nil {
 			return err
 		}
 
+		if err := v.RegisterValidation("transaction_hash_or_id", transactionHashOrIDValidator); err != nil {
+			return err
+		}
+
 		if err := v.RegisterValidation("eth_address_or_array", ethAddressOrArrayValidator); err != nil {
 			return err
 		}
@@ -49,12 +58,25 @@ func blockNumberOrTagValidator(fl validator.FieldLevel) bool {
 	return IsValidBlockNumberOrTag(value)
 }
 
+// blockNumberTagOrHashValidator validates block numbers, special tags or block hashes
+func blockNumberTagOrHashValidator(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	return IsValidBlock(value)
+}
+
 // hexadecimalValidator validates hexadecimal strings with 0x prefix
 func hexadecimalValidator(fl validator.FieldLevel) bool {
 	value := fl.Field().String()
 	return IsValidHexNumber(value)
 }
 
+// transactionHashOrIDValidator validates Ethereum transaction hashes or Hedera transaction IDs
+func transactionHashOrIDValidator(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	_, isTransactionID := domain.ParseTransactionID(value)
+	return IsValidHexHash(value) || isTransactionID
+}
+
 // ethAddressOrArrayValidator validates either a single Ethereum address or an array of addresses
 func ethAddressOrArrayValidator(fl validator.FieldLevel) bool {
 	field := fl.Field()
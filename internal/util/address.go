@@ -0,0 +1,44 @@
+package util
+
+import (
+	"encoding/hex"
+	"strings"
+)
+
+// IsAddress reports whether s is a 0x-prefixed, 20-byte hex address, in any
+// letter case.
+func IsAddress(s string) bool {
+	if len(s) != 42 || !strings.HasPrefix(s, "0x") {
+		return false
+	}
+	_, err := hex.DecodeString(s[2:])
+	return err == nil
+}
+
+// ChecksumAddress returns the EIP-55 mixed-case form of address. Values that
+// are not 20-byte hex addresses, such as empty strings or Hedera entity IDs,
+// are returned unchanged.
+func ChecksumAddress(address string) string {
+	if !IsAddress(address) {
+		return address
+	}
+
+	lower := strings.ToLower(address[2:])
+	hash := Keccak256([]byte(lower))
+
+	checksummed := []byte(lower)
+	for i, c := range checksummed {
+		if c < 'a' {
+			continue
+		}
+		// Each letter is uppercased when the matching nibble of the hash is 8 or more
+		nibble := hash[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if nibble&0x0f >= 8 {
+			checksummed[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(checksummed)
+}
@@ -0,0 +1,10 @@
+package util
+
+import "golang.org/x/crypto/sha3"
+
+// Keccak256 returns the legacy Keccak-256 digest of data, as used by the EVM.
+func Keccak256(data []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	return h.Sum(nil)
+}
@@ -0,0 +1,228 @@
+// Package client is a typed Go client for the JSON-RPC API of the Hederium
+// relay.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// HTTPError is returned when the relay answers with an HTTP error that carries
+// no JSON-RPC response, e.g. when a proxy in front of it fails.
+type HTTPError struct {
+	StatusCode int
+	Body       string
+}
+
+// Error implements the error interface
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("relay answered with HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+// BatchElem is one call of a batch: the method and parameters to send, where
+// to decode the result and the error of the call once the batch returned.
+type BatchElem struct {
+	Method string
+	Params []interface{}
+	// Result is decoded into unless it is nil or the call failed.
+	Result interface{}
+	// Error is set when the call failed, to a *RPCError when the relay
+	// answered it with an error.
+	Error error
+}
+
+// Option configures an EthClient.
+type Option func(*EthClient)
+
+// WithHTTPClient sends requests through httpClient instead of
+// http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *EthClient) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithHeader adds a header to every request, e.g. X-API-Key.
+func WithHeader(key, value string) Option {
+	return func(c *EthClient) {
+		c.headers.Add(key, value)
+	}
+}
+
+// WithTimeout bounds every call that is made with a context without a
+// deadline.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *EthClient) {
+		c.timeout = timeout
+	}
+}
+
+// EthClient calls the JSON-RPC API of a relay. It is safe for concurrent use.
+type EthClient struct {
+	url        string
+	httpClient *http.Client
+	headers    http.Header
+	timeout    time.Duration
+	nextID     atomic.Int64
+}
+
+type request struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int64         `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type response struct {
+	ID     *int64          `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+// NewEthClient creates a client for the relay JSON-RPC endpoint at url, e.g.
+// http://localhost:7546 or http://localhost:7546/<network> for a further
+// network of the relay.
+func NewEthClient(url string, opts ...Option) *EthClient {
+	c := &EthClient{
+		url:        url,
+		httpClient: http.DefaultClient,
+		headers:    make(http.Header),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// CallContext calls method with params and decodes its result into result,
+// unless result is nil. An error response of the relay is returned as a
+// *RPCError.
+func (c *EthClient) CallContext(ctx context.Context, result interface{}, method string, params ...interface{}) error {
+	body, err := c.post(ctx, c.newRequest(method, params))
+	if err != nil {
+		return err
+	}
+
+	var resp response
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("decoding %s response: %w", method, err)
+	}
+	return decodeResult(resp, result)
+}
+
+// BatchCall sends every call of batch in a single request and sets the Result
+// or Error of each. The error returned is that of the batch as a whole, e.g.
+// when the relay has batch requests disabled.
+func (c *EthClient) BatchCall(ctx context.Context, batch []BatchElem) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	requests := make([]request, len(batch))
+	byID := make(map[int64]*BatchElem, len(batch))
+	for i := range batch {
+		requests[i] = c.newRequest(batch[i].Method, batch[i].Params)
+		byID[requests[i].ID] = &batch[i]
+	}
+
+	body, err := c.post(ctx, requests)
+	if err != nil {
+		return err
+	}
+
+	// A batch of one call is answered like a single request, and a rejected
+	// batch with a single error
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '{' {
+		var resp response
+		if err := json.Unmarshal(trimmed, &resp); err != nil {
+			return fmt.Errorf("decoding batch response: %w", err)
+		}
+		if resp.ID == nil || byID[*resp.ID] == nil {
+			if resp.Error != nil {
+				return resp.Error
+			}
+			return fmt.Errorf("batch response without a known id")
+		}
+		body = append(append([]byte{'['}, trimmed...), ']')
+	}
+
+	var responses []response
+	if err := json.Unmarshal(body, &responses); err != nil {
+		return fmt.Errorf("decoding batch response: %w", err)
+	}
+	for _, resp := range responses {
+		if resp.ID == nil {
+			continue
+		}
+		if elem, ok := byID[*resp.ID]; ok {
+			elem.Error = decodeResult(resp, elem.Result)
+			delete(byID, *resp.ID)
+		}
+	}
+	for _, elem := range byID {
+		elem.Error = fmt.Errorf("no response to %s in batch", elem.Method)
+	}
+	return nil
+}
+
+func (c *EthClient) newRequest(method string, params []interface{}) request {
+	if params == nil {
+		params = []interface{}{}
+	}
+	return request{JSONRPC: "2.0", ID: c.nextID.Add(1), Method: method, Params: params}
+}
+
+// post sends payload and returns the response body. Bodies of HTTP errors are
+// returned as well when they hold a JSON-RPC response, as the relay answers
+// failed calls with 400.
+func (c *EthClient) post(ctx context.Context, payload interface{}) ([]byte, error) {
+	if _, ok := ctx.Deadline(); !ok && c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range c.headers {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK && !json.Valid(body) {
+		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+	return body, nil
+}
+
+func decodeResult(resp response, result interface{}) error {
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(resp.Result, result)
+}
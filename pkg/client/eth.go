@@ -0,0 +1,149 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Block tags accepted wherever a block is named, next to 0x prefixed hex
+// block numbers as returned by BlockNumberArg.
+const (
+	Latest    = "latest"
+	Earliest  = "earliest"
+	Pending   = "pending"
+	Safe      = "safe"
+	Finalized = "finalized"
+)
+
+// BlockNumberArg names block number n as a block parameter.
+func BlockNumberArg(n uint64) string {
+	return "0x" + strconv.FormatUint(n, 16)
+}
+
+// ChainID returns the chain ID of the network the relay serves.
+func (c *EthClient) ChainID(ctx context.Context) (uint64, error) {
+	return c.callUint64(ctx, "eth_chainId")
+}
+
+// BlockNumber returns the number of the latest block.
+func (c *EthClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return c.callUint64(ctx, "eth_blockNumber")
+}
+
+// GasPrice returns the current gas price in weibars.
+func (c *EthClient) GasPrice(ctx context.Context) (*big.Int, error) {
+	return c.callBig(ctx, "eth_gasPrice")
+}
+
+// BalanceAt returns the balance of address in weibars at block.
+func (c *EthClient) BalanceAt(ctx context.Context, address, block string) (*big.Int, error) {
+	return c.callBig(ctx, "eth_getBalance", address, block)
+}
+
+// NonceAt returns the transaction count of address at block.
+func (c *EthClient) NonceAt(ctx context.Context, address, block string) (uint64, error) {
+	return c.callUint64(ctx, "eth_getTransactionCount", address, block)
+}
+
+// CodeAt returns the runtime bytecode of the contract at address, as hex.
+func (c *EthClient) CodeAt(ctx context.Context, address, block string) (string, error) {
+	var code string
+	err := c.CallContext(ctx, &code, "eth_getCode", address, block)
+	return code, err
+}
+
+// StorageAt returns the value of storage slot of the contract at address, as
+// a 32 byte hex word.
+func (c *EthClient) StorageAt(ctx context.Context, address, slot, block string) (string, error) {
+	var value string
+	err := c.CallContext(ctx, &value, "eth_getStorageAt", address, slot, block)
+	return value, err
+}
+
+// BlockByNumber returns the block named by a number or tag, with full
+// transaction objects when fullTx is set and their hashes otherwise. It
+// returns nil when the block does not exist.
+func (c *EthClient) BlockByNumber(ctx context.Context, block string, fullTx bool) (*Block, error) {
+	var result *Block
+	err := c.CallContext(ctx, &result, "eth_getBlockByNumber", block, fullTx)
+	return result, err
+}
+
+// BlockByHash returns the block with hash, or nil when it does not exist.
+func (c *EthClient) BlockByHash(ctx context.Context, hash string, fullTx bool) (*Block, error) {
+	var result *Block
+	err := c.CallContext(ctx, &result, "eth_getBlockByHash", hash, fullTx)
+	return result, err
+}
+
+// TransactionByHash returns the transaction with hash, or nil when it does not
+// exist.
+func (c *EthClient) TransactionByHash(ctx context.Context, hash string) (*Transaction, error) {
+	var result *Transaction
+	err := c.CallContext(ctx, &result, "eth_getTransactionByHash", hash)
+	return result, err
+}
+
+// TransactionReceipt returns the receipt of the transaction with hash, or nil
+// while it is not known.
+func (c *EthClient) TransactionReceipt(ctx context.Context, hash string) (*TransactionReceipt, error) {
+	var result *TransactionReceipt
+	err := c.CallContext(ctx, &result, "eth_getTransactionReceipt", hash)
+	return result, err
+}
+
+// GetLogs returns the logs matching filter.
+func (c *EthClient) GetLogs(ctx context.Context, filter LogFilter) ([]Log, error) {
+	var logs []Log
+	err := c.CallContext(ctx, &logs, "eth_getLogs", filter)
+	return logs, err
+}
+
+// CallContract executes msg against the state at block without creating a
+// transaction and returns its output as hex. A reverted call fails with a
+// *RPCError with code CodeContractRevert and the revert payload as Data.
+func (c *EthClient) CallContract(ctx context.Context, msg CallMsg, block string) (string, error) {
+	var output string
+	err := c.CallContext(ctx, &output, "eth_call", msg, block)
+	return output, err
+}
+
+// EstimateGas returns the gas msg needs to succeed.
+func (c *EthClient) EstimateGas(ctx context.Context, msg CallMsg) (uint64, error) {
+	return c.callUint64(ctx, "eth_estimateGas", msg)
+}
+
+// SendRawTransaction submits a signed, RLP encoded transaction given as hex
+// and returns its hash.
+func (c *EthClient) SendRawTransaction(ctx context.Context, rawTx string) (string, error) {
+	var hash string
+	err := c.CallContext(ctx, &hash, "eth_sendRawTransaction", rawTx)
+	return hash, err
+}
+
+func (c *EthClient) callUint64(ctx context.Context, method string, params ...interface{}) (uint64, error) {
+	var hex string
+	if err := c.CallContext(ctx, &hex, method, params...); err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseUint(strings.TrimPrefix(hex, "0x"), 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s returned %q: %w", method, hex, err)
+	}
+	return n, nil
+}
+
+func (c *EthClient) callBig(ctx context.Context, method string, params ...interface{}) (*big.Int, error) {
+	var hex string
+	if err := c.CallContext(ctx, &hex, method, params...); err != nil {
+		return nil, err
+	}
+	n, ok := new(big.Int).SetString(strings.TrimPrefix(hex, "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("%s returned %q, not a hex number", method, hex)
+	}
+	return n, nil
+}
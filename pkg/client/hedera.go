@@ -0,0 +1,19 @@
+package client
+
+import "context"
+
+// TokenBalances returns the HTS tokens the account at address is associated
+// with and its balance of each, through hedera_getTokenBalances.
+func (c *EthClient) TokenBalances(ctx context.Context, address string) ([]TokenBalance, error) {
+	var balances []TokenBalance
+	err := c.CallContext(ctx, &balances, "hedera_getTokenBalances", address)
+	return balances, err
+}
+
+// TokenInfo returns the metadata of the HTS token at tokenAddress, or nil when
+// there is no such token, through hedera_getTokenInfo.
+func (c *EthClient) TokenInfo(ctx context.Context, tokenAddress string) (*TokenInfo, error) {
+	var result *TokenInfo
+	err := c.CallContext(ctx, &result, "hedera_getTokenInfo", tokenAddress)
+	return result, err
+}
@@ -0,0 +1,46 @@
+package client
+
+import "github.com/LimeChain/Hederium/internal/domain"
+
+// The results of the relay, shared with its server side so that the client
+// always decodes what the relay sends.
+type (
+	Block              = domain.Block
+	Transaction        = domain.Transaction
+	TransactionReceipt = domain.TransactionReceipt
+	Log                = domain.Log
+	TokenBalance       = domain.TokenBalance
+	TokenInfo          = domain.TokenInfo
+	// RPCError is a JSON-RPC error returned by the relay. Data holds the
+	// revert payload of reverted calls.
+	RPCError = domain.RPCError
+)
+
+// JSON-RPC error codes of the relay that callers commonly act on.
+const (
+	CodeContractRevert = domain.ContractRevert
+	CodeMethodNotFound = domain.MethodNotFound
+	CodeInvalidParams  = domain.InvalidParams
+	CodeLimitExceeded  = domain.LimitExceeded
+)
+
+// CallMsg is the transaction object of eth_call and eth_estimateGas. Numbers
+// are 0x prefixed hex strings; empty fields are left out.
+type CallMsg struct {
+	From     string `json:"from,omitempty"`
+	To       string `json:"to,omitempty"`
+	Gas      string `json:"gas,omitempty"`
+	GasPrice string `json:"gasPrice,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Data     string `json:"data,omitempty"`
+}
+
+// LogFilter is the filter object of eth_getLogs. A filter names either
+// BlockHash or a FromBlock and ToBlock range, which default to latest.
+type LogFilter struct {
+	Address   []string   `json:"address,omitempty"`
+	Topics    [][]string `json:"topics,omitempty"`
+	BlockHash string     `json:"blockHash,omitempty"`
+	FromBlock string     `json:"fromBlock,omitempty"`
+	ToBlock   string     `json:"toBlock,omitempty"`
+}
@@ -0,0 +1,468 @@
+package e2e
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+)
+
+// DefaultGasPrice is the EthereumTransaction gas price, in tinybars, served by
+// /api/v1/network/fees until SetGasPrice is called.
+const DefaultGasPrice int64 = 71
+
+// MirrorNodeVersion is the mirror node version stated by /api/v1/docs/openapi.yml.
+const MirrorNodeVersion = "0.120.0"
+
+// MirrorNode is a fake mirror node REST API backed by fixtures registered by
+// the test. Requests for anything that was not registered get the same 404 the
+// real mirror node returns, so the relay takes its not-found paths.
+type MirrorNode struct {
+	*httptest.Server
+
+	mu              sync.Mutex
+	blocks          []domain.BlockResponse
+	contractResults []domain.ContractResult
+	logs            []domain.LogEntry
+	balances        map[string]int64
+	accounts        map[string]domain.AccountResponse
+	contracts       map[string]domain.ContractResponse
+	gasPrice        int64
+	handlers        map[string]http.HandlerFunc
+	requests        []string
+}
+
+// NewMirrorNode starts a fake mirror node that is shut down when the test ends.
+func NewMirrorNode(t *testing.T) *MirrorNode {
+	t.Helper()
+
+	m := &MirrorNode{
+		balances:  make(map[string]int64),
+		accounts:  make(map[string]domain.AccountResponse),
+		contracts: make(map[string]domain.ContractResponse),
+		gasPrice:  DefaultGasPrice,
+		handlers:  make(map[string]http.HandlerFunc),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/docs/openapi.yml", m.getOpenAPI)
+	mux.HandleFunc("GET /api/v1/blocks", m.listBlocks)
+	mux.HandleFunc("GET /api/v1/blocks/{id}", m.getBlock)
+	mux.HandleFunc("GET /api/v1/network/fees", m.getNetworkFees)
+	mux.HandleFunc("GET /api/v1/balances", m.getBalances)
+	mux.HandleFunc("GET /api/v1/accounts/{id}", m.getAccount)
+	mux.HandleFunc("GET /api/v1/contracts/{id}", m.getContract)
+	mux.HandleFunc("GET /api/v1/contracts/results", m.listContractResults)
+	mux.HandleFunc("GET /api/v1/contracts/results/{id}", m.getContractResult)
+	mux.HandleFunc("GET /api/v1/contracts/results/logs", m.listLogs)
+	mux.HandleFunc("GET /api/v1/contracts/{id}/results/logs", m.listLogs)
+
+	m.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		m.requests = append(m.requests, r.URL.RequestURI())
+		handler, overridden := m.handlers[r.URL.Path]
+		m.mu.Unlock()
+
+		if overridden {
+			handler(w, r)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(m.Close)
+
+	return m
+}
+
+// AddBlock registers blocks. Blocks are listed in the order of their numbers
+// regardless of the order they were added in.
+func (m *MirrorNode) AddBlock(blocks ...domain.BlockResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.blocks = append(m.blocks, blocks...)
+	sort.Slice(m.blocks, func(i, j int) bool { return m.blocks[i].Number < m.blocks[j].Number })
+}
+
+// AddContractResult registers the results of Ethereum transactions. Their logs
+// are served by the logs endpoints as well.
+func (m *MirrorNode) AddContractResult(results ...domain.ContractResult) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, result := range results {
+		m.contractResults = append(m.contractResults, result)
+
+		blockNumber := result.BlockNumber
+		transactionIndex := result.TransactionIndex
+		for _, log := range result.Logs {
+			index := log.Index
+			m.logs = append(m.logs, domain.LogEntry{
+				Address:          log.Address,
+				Bloom:            log.Bloom,
+				ContractID:       log.ContractID,
+				Data:             log.Data,
+				Index:            &index,
+				Topics:           log.Topics,
+				BlockHash:        result.BlockHash,
+				BlockNumber:      &blockNumber,
+				RootContractID:   result.ContractID,
+				Timestamp:        result.Timestamp,
+				TransactionHash:  result.Hash,
+				TransactionIndex: &transactionIndex,
+			})
+		}
+	}
+}
+
+// AddLogs registers logs that do not belong to a registered contract result.
+func (m *MirrorNode) AddLogs(logs ...domain.LogEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.logs = append(m.logs, logs...)
+}
+
+// SetBalance sets the balance, in tinybars, returned for account, which may be
+// an account ID or an EVM address.
+func (m *MirrorNode) SetBalance(account string, tinybars int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.balances[strings.ToLower(account)] = tinybars
+}
+
+// AddAccount registers an account under its ID and EVM address.
+func (m *MirrorNode) AddAccount(account domain.AccountResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range []string{account.Account, account.EvmAddress} {
+		if key != "" {
+			m.accounts[strings.ToLower(key)] = account
+		}
+	}
+}
+
+// AddContract registers a contract under its ID and EVM address.
+func (m *MirrorNode) AddContract(contract domain.ContractResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range []string{contract.ContractID, contract.EvmAddress} {
+		if key != "" {
+			m.contracts[strings.ToLower(key)] = contract
+		}
+	}
+}
+
+// SetGasPrice sets the EthereumTransaction gas price, in tinybars.
+func (m *MirrorNode) SetGasPrice(tinybars int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.gasPrice = tinybars
+}
+
+// Handle serves every request for path with handler instead of the fixtures,
+// e.g. to simulate mirror node failures.
+func (m *MirrorNode) Handle(path string, handler http.HandlerFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.handlers[path] = handler
+}
+
+// Requests returns the path and query of every request received so far.
+func (m *MirrorNode) Requests() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]string(nil), m.requests...)
+}
+
+func (m *MirrorNode) listBlocks(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	query := r.URL.Query()
+	blocks := make([]domain.BlockResponse, 0, len(m.blocks))
+	for _, block := range m.blocks {
+		if matchesNumber(query["block.number"], int64(block.Number)) && matchesTimestamp(query["timestamp"], block.Timestamp.From) {
+			blocks = append(blocks, block)
+		}
+	}
+	if query.Get("order") == "desc" {
+		for i, j := 0, len(blocks)-1; i < j; i, j = i+1, j-1 {
+			blocks[i], blocks[j] = blocks[j], blocks[i]
+		}
+	}
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit < len(blocks) {
+		blocks = blocks[:limit]
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"blocks": blocks,
+		"links":  map[string]interface{}{"next": nil},
+	})
+}
+
+func (m *MirrorNode) getBlock(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := r.PathValue("id")
+	for _, block := range m.blocks {
+		if matchesHashOrNumber(id, block.Hash, int64(block.Number)) {
+			writeJSON(w, block)
+			return
+		}
+	}
+	writeNotFound(w)
+}
+
+func (m *MirrorNode) getOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write([]byte("openapi: 3.0.0\ninfo:\n  title: Hedera Mirror Node REST API\n  version: " + MirrorNodeVersion + "\n"))
+}
+
+func (m *MirrorNode) getNetworkFees(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	writeJSON(w, domain.FeeResponse{
+		Fees: []domain.Fee{
+			{Gas: m.gasPrice, TransactionType: "ContractCall"},
+			{Gas: m.gasPrice, TransactionType: "ContractCreate"},
+			{Gas: m.gasPrice, TransactionType: "EthereumTransaction"},
+		},
+	})
+}
+
+func (m *MirrorNode) getBalances(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	account := r.URL.Query().Get("account.id")
+	balances := []map[string]interface{}{}
+	if balance, ok := m.balanceOf(account); ok {
+		balances = append(balances, map[string]interface{}{
+			"account": account,
+			"balance": balance,
+			"tokens":  []interface{}{},
+		})
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"balances": balances,
+		"links":    map[string]interface{}{"next": nil},
+	})
+}
+
+// balanceOf returns the balance set for account, or for the ID or EVM address
+// of the account it names. Like the mirror node, it does not find contracts
+// by their EVM address.
+func (m *MirrorNode) balanceOf(account string) (int64, bool) {
+	keys := []string{account}
+	if registered, ok := m.accounts[strings.ToLower(account)]; ok {
+		keys = append(keys, registered.Account, registered.EvmAddress)
+	}
+	for _, key := range keys {
+		if balance, ok := m.balances[strings.ToLower(key)]; ok {
+			return balance, true
+		}
+	}
+	return 0, false
+}
+
+func (m *MirrorNode) getAccount(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if account, ok := m.accounts[strings.ToLower(r.PathValue("id"))]; ok {
+		writeJSON(w, account)
+		return
+	}
+	writeNotFound(w)
+}
+
+func (m *MirrorNode) getContract(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if contract, ok := m.contracts[strings.ToLower(r.PathValue("id"))]; ok {
+		writeJSON(w, contract)
+		return
+	}
+	writeNotFound(w)
+}
+
+func (m *MirrorNode) listContractResults(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	query := r.URL.Query()
+	results := make([]domain.ContractResult, 0, len(m.contractResults))
+	for _, result := range m.contractResults {
+		if !matchesTimestamp(query["timestamp"], result.Timestamp) ||
+			!matchesNumber(query["block.number"], result.BlockNumber) ||
+			!matchesNumber(query["transaction.index"], int64(result.TransactionIndex)) {
+			continue
+		}
+		if from := query.Get("from"); from != "" && !strings.EqualFold(from, result.From) {
+			continue
+		}
+		if blockHash := query.Get("block.hash"); blockHash != "" && !strings.HasPrefix(result.BlockHash, blockHash) {
+			continue
+		}
+		results = append(results, result)
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"results": results,
+		"links":   map[string]interface{}{"next": nil},
+	})
+}
+
+func (m *MirrorNode) getContractResult(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := r.PathValue("id")
+	for _, result := range m.contractResults {
+		if strings.HasPrefix(result.Hash, id) {
+			writeJSON(w, result)
+			return
+		}
+	}
+	writeNotFound(w)
+}
+
+func (m *MirrorNode) listLogs(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	query := r.URL.Query()
+	address := r.PathValue("id")
+	logs := make([]domain.LogEntry, 0, len(m.logs))
+	for _, log := range m.logs {
+		if address != "" && !strings.EqualFold(address, log.Address) && address != log.ContractID {
+			continue
+		}
+		if !matchesTimestamp(query["timestamp"], log.Timestamp) || !matchesTopics(query, log.Topics) {
+			continue
+		}
+		logs = append(logs, log)
+	}
+
+	writeJSON(w, domain.ContractResultsLogResponse{Logs: logs})
+}
+
+// matchesNumber applies mirror node filters such as "gt:5" or "5" to value.
+func matchesNumber(filters []string, value int64) bool {
+	for _, filter := range filters {
+		operator, operand := splitFilter(filter)
+		n, err := strconv.ParseInt(operand, 10, 64)
+		if err != nil || !compare(operator, value, n) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesTimestamp applies mirror node filters such as "lte:1700000000.000000000"
+// to the seconds.nanoseconds timestamp value.
+func matchesTimestamp(filters []string, value string) bool {
+	for _, filter := range filters {
+		operator, operand := splitFilter(filter)
+		if !compare(operator, timestampNanos(value), timestampNanos(operand)) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesTopics(query map[string][]string, topics []string) bool {
+	for i := 0; i < 4; i++ {
+		wanted := query["topic"+strconv.Itoa(i)]
+		if len(wanted) == 0 {
+			continue
+		}
+		if i >= len(topics) {
+			return false
+		}
+
+		found := false
+		for _, topic := range wanted {
+			if strings.EqualFold(topic, topics[i]) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesHashOrNumber(id, hash string, number int64) bool {
+	if strings.HasPrefix(id, "0x") {
+		return strings.HasPrefix(hash, id)
+	}
+	n, err := strconv.ParseInt(id, 10, 64)
+	return err == nil && n == number
+}
+
+func splitFilter(filter string) (string, string) {
+	if operator, operand, ok := strings.Cut(filter, ":"); ok {
+		return operator, operand
+	}
+	return "eq", filter
+}
+
+func compare(operator string, a, b int64) bool {
+	switch operator {
+	case "gt":
+		return a > b
+	case "gte":
+		return a >= b
+	case "lt":
+		return a < b
+	case "lte":
+		return a <= b
+	case "ne":
+		return a != b
+	default:
+		return a == b
+	}
+}
+
+// timestampNanos converts a seconds.nanoseconds timestamp to nanoseconds.
+func timestampNanos(timestamp string) int64 {
+	seconds, nanos, _ := strings.Cut(timestamp, ".")
+	s, _ := strconv.ParseInt(seconds, 10, 64)
+	n, _ := strconv.ParseInt((nanos + "000000000")[:9], 10, 64)
+	return s*1_000_000_000 + n
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"_status": map[string]interface{}{
+			"messages": []map[string]string{{"message": "Not found"}},
+		},
+	})
+}
@@ -0,0 +1,206 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
+	"github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	"github.com/LimeChain/Hederium/internal/service"
+	"github.com/LimeChain/Hederium/internal/transport/http_server"
+	"github.com/LimeChain/Hederium/internal/transport/rpc"
+	"github.com/LimeChain/Hederium/pkg/client"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// DefaultChainID is the chain ID the relay reports unless RelayConfig sets one.
+const DefaultChainID = "0x12a"
+
+// RelayConfig tunes the relay booted by NewRelay. The zero value runs it with
+// the default service settings, without API keys and without batch requests.
+type RelayConfig struct {
+	ChainID             string
+	Service             service.Config
+	EnableBatchRequests bool
+	Admin               http_server.AdminConfig
+	// Networks are served next to the default network, each from its own
+	// mirror node.
+	Networks    []RelayNetwork
+	Concurrency limiter.ConcurrencyConfig
+	HTTPCache   http_server.HTTPCacheConfig
+	// Overload, when set, sheds load as it tells.
+	Overload *limiter.OverloadController
+	Timeouts rpc.TimeoutConfig
+}
+
+// RelayNetwork is a further network of a relay, reached at /<Name> or with
+// one of Hosts as the Host header.
+type RelayNetwork struct {
+	Name    string
+	Hosts   []string
+	ChainID string
+	Mirror  *MirrorNode
+	// BlockGasLimit and MaxCallGas override those of the default network
+	// when set.
+	BlockGasLimit uint64
+	MaxCallGas    uint64
+}
+
+// Relay is a Hederium HTTP server with the real services, reading from a fake
+// MirrorNode. It has no Hedera consensus node client, so methods that submit
+// transactions cannot be exercised through it.
+type Relay struct {
+	*httptest.Server
+	// Eth is a typed client of the relay.
+	Eth *client.EthClient
+
+	t      *testing.T
+	nextID atomic.Int64
+}
+
+// Response is a JSON-RPC response together with its HTTP status code.
+type Response struct {
+	StatusCode int              `json:"-"`
+	Result     json.RawMessage  `json:"result"`
+	Error      *domain.RPCError `json:"error"`
+}
+
+// NewRelay starts a relay backed by mirror that is shut down when the test ends.
+func NewRelay(t *testing.T, mirror *MirrorNode, config RelayConfig) *Relay {
+	t.Helper()
+
+	if config.ChainID == "" {
+		config.ChainID = DefaultChainID
+	}
+
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+	cacheService := cache.NewMemoryCache(time.Minute, time.Minute)
+	mClient := hedera.NewMirrorClient([]string{mirror.URL}, 5, logger, cacheService)
+	mClient.DetectVersion(context.Background())
+
+	networks := make([]http_server.Network, 0, len(config.Networks))
+	for _, network := range config.Networks {
+		networks = append(networks, http_server.Network{
+			Name:         network.Name,
+			Hosts:        network.Hosts,
+			ChainID:      network.ChainID,
+			MirrorClient: hedera.NewMirrorClient([]string{network.Mirror.URL}, 5, logger, cache.NewPrefixedCache(cacheService, network.Name)),
+
+			BlockGasLimit: network.BlockGasLimit,
+			MaxCallGas:    network.MaxCallGas,
+		})
+	}
+
+	server := http_server.NewServer(
+		nil,
+		mClient,
+		logger,
+		"e2e",
+		config.ChainID,
+		limiter.NewAPIKeyStore(nil),
+		limiter.NewTieredLimiter(nil, 0, 0),
+		false,
+		config.EnableBatchRequests,
+		cacheService,
+		config.Service,
+		http_server.ServerOptions{
+			Admin:       config.Admin,
+			RequestLog:  http_server.RequestLogConfig{Enabled: true},
+			Networks:    networks,
+			Concurrency: config.Concurrency,
+			HTTPCache:   config.HTTPCache,
+			Overload:    config.Overload,
+			Timeouts:    config.Timeouts,
+		},
+	)
+
+	r := &Relay{Server: httptest.NewServer(server.Handler()), t: t}
+	r.Eth = client.NewEthClient(r.URL, client.WithTimeout(10*time.Second))
+	t.Cleanup(r.Close)
+
+	return r
+}
+
+// Call sends a single JSON-RPC request and returns the decoded response.
+func (r *Relay) Call(method string, params ...interface{}) *Response {
+	r.t.Helper()
+
+	if params == nil {
+		params = []interface{}{}
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      r.nextID.Add(1),
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		r.t.Fatalf("encoding %s request: %v", method, err)
+	}
+
+	return r.Post(body)
+}
+
+// Post sends a raw request body to the RPC endpoint and returns the decoded
+// response.
+func (r *Relay) Post(body []byte) *Response {
+	r.t.Helper()
+	return r.PostTo("/", "", body)
+}
+
+// PostTo sends a raw request body to path, with host as the Host header unless
+// it is empty, and returns the decoded response.
+func (r *Relay) PostTo(path, host string, body []byte) *Response {
+	r.t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, r.URL+path, bytes.NewReader(body))
+	if err != nil {
+		r.t.Fatalf("creating request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if host != "" {
+		req.Host = host
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		r.t.Fatalf("sending request: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		r.t.Fatalf("reading response: %v", err)
+	}
+
+	response := &Response{StatusCode: resp.StatusCode}
+	if err := json.Unmarshal(data, response); err != nil {
+		r.t.Fatalf("decoding response %q: %v", data, err)
+	}
+	return response
+}
+
+// CallResult sends a JSON-RPC request, fails the test on an error response and
+// decodes the result into out.
+func (r *Relay) CallResult(out interface{}, method string, params ...interface{}) {
+	r.t.Helper()
+
+	response := r.Call(method, params...)
+	if response.Error != nil {
+		r.t.Fatalf("%s returned error %d: %s", method, response.Error.Code, response.Error.Message)
+	}
+	if err := json.Unmarshal(response.Result, out); err != nil {
+		r.t.Fatalf("decoding %s result %s: %v", method, response.Result, err)
+	}
+}
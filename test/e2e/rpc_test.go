@@ -0,0 +1,898 @@
+package e2e_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	"github.com/LimeChain/Hederium/internal/service"
+	"github.com/LimeChain/Hederium/internal/transport/http_server"
+	"github.com/LimeChain/Hederium/internal/transport/rpc"
+	"github.com/LimeChain/Hederium/internal/util"
+	"github.com/LimeChain/Hederium/pkg/client"
+	"github.com/LimeChain/Hederium/test/e2e"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	blockHash  = "0x3c08bbbee74d287b1dcd3f0ca6d1d2cb92c90883c4acf9747de9f3f3162ad25b999fc7e86699f60f2a3fb3ed9a646c6b"
+	txHash     = "0x4a563af33c4871b51a8b108aa2fe1dd5280a30dfb7236170ae5e5e7957eb6392"
+	sender     = "0x05fba803be258049a27b820088bab1cad2058871"
+	contract   = "0x1a6cd6a2b3e6e8fdd6bb0eb6b3c6e13b0d1a2b3c"
+	transferID = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+)
+
+func newMirrorNode(t *testing.T) *e2e.MirrorNode {
+	mirror := e2e.NewMirrorNode(t)
+
+	mirror.AddBlock(
+		domain.BlockResponse{
+			Count:     0,
+			Hash:      "0x" + strings.Repeat("1", 96),
+			Number:    99,
+			Timestamp: domain.Timestamp{From: "1700000000.000000000", To: "1700000001.999999999"},
+		},
+		domain.BlockResponse{
+			Count:     1,
+			Hash:      blockHash,
+			Number:    100,
+			GasUsed:   50000,
+			Timestamp: domain.Timestamp{From: "1700000002.000000000", To: "1700000003.999999999"},
+		},
+	)
+
+	mirror.AddAccount(domain.AccountResponse{Account: "0.0.1001", EvmAddress: sender})
+	mirror.AddContract(domain.ContractResponse{ContractID: "0.0.1002", EvmAddress: contract})
+
+	mirror.AddContractResult(domain.ContractResult{
+		Address:          contract,
+		ContractID:       "0.0.1002",
+		From:             sender,
+		To:               contract,
+		Hash:             txHash,
+		BlockHash:        blockHash,
+		BlockNumber:      100,
+		BlockGasUsed:     50000,
+		GasUsed:          50000,
+		GasLimit:         100000,
+		Timestamp:        "1700000002.500000000",
+		TransactionIndex: 0,
+		Status:           "0x1",
+		Result:           "SUCCESS",
+		Bloom:            "0x",
+		Logs: []domain.MirroNodeLogs{{
+			Address:    contract,
+			ContractID: "0.0.1002",
+			Data:       "0x0000000000000000000000000000000000000000000000000000000000000064",
+			Index:      0,
+			Topics:     []string{transferID},
+		}},
+	})
+
+	return mirror
+}
+
+func TestChainIDAndBlockNumber(t *testing.T) {
+	relay := e2e.NewRelay(t, newMirrorNode(t), e2e.RelayConfig{})
+	ctx := context.Background()
+
+	chainID, err := relay.Eth.ChainID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0x12a), chainID) // e2e.DefaultChainID
+
+	blockNumber, err := relay.Eth.BlockNumber(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), blockNumber)
+}
+
+func TestSafeAndFinalizedTags(t *testing.T) {
+	mirror := newMirrorNode(t)
+	mirror.SetBalance(sender, 5)
+	relay := e2e.NewRelay(t, mirror, e2e.RelayConfig{})
+	ctx := context.Background()
+
+	// Hedera blocks are final once created, so both tags name the latest block
+	for _, tag := range []string{client.Safe, client.Finalized} {
+		block, err := relay.Eth.BlockByNumber(ctx, tag, false)
+		require.NoError(t, err, tag)
+		require.NotNil(t, block, tag)
+		assert.Equal(t, "0x64", *block.Number, tag)
+
+		balance, err := relay.Eth.BalanceAt(ctx, sender, tag)
+		require.NoError(t, err, tag)
+		assert.Equal(t, big.NewInt(50_000_000_000), balance, tag)
+
+		logs, err := relay.Eth.GetLogs(ctx, client.LogFilter{FromBlock: tag, ToBlock: tag, Address: []string{contract}})
+		require.NoError(t, err, tag)
+		assert.Len(t, logs, 1, tag)
+	}
+}
+
+func TestGetBalance(t *testing.T) {
+	mirror := newMirrorNode(t)
+	mirror.SetBalance(sender, 5)
+	relay := e2e.NewRelay(t, mirror, e2e.RelayConfig{})
+	ctx := context.Background()
+
+	balance, err := relay.Eth.BalanceAt(ctx, sender, client.Latest)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(50_000_000_000), balance) // 5 tinybars in weibars
+
+	balance, err = relay.Eth.BalanceAt(ctx, contract, client.Latest)
+	require.NoError(t, err)
+	assert.Zero(t, balance.Sign())
+}
+
+func TestGetBalance_ContractAndLongZeroAddresses(t *testing.T) {
+	mirror := newMirrorNode(t)
+	// The mirror node only finds the balance of the contract by its ID
+	mirror.SetBalance("0.0.1002", 7)
+	relay := e2e.NewRelay(t, mirror, e2e.RelayConfig{})
+	ctx := context.Background()
+
+	for _, address := range []string{contract, "0x00000000000000000000000000000000000003ea"} {
+		balance, err := relay.Eth.BalanceAt(ctx, address, client.Latest)
+		require.NoError(t, err, address)
+		assert.Equal(t, big.NewInt(70_000_000_000), balance, address)
+	}
+}
+
+func TestHederaTokenMethods(t *testing.T) {
+	mirror := newMirrorNode(t)
+	mirror.Handle("/api/v1/accounts/"+sender+"/tokens", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"tokens": [{"token_id": "0.0.1009", "balance": 2500, "decimals": 2, "automatic_association": false,
+			"freeze_status": "UNFROZEN", "kyc_status": "NOT_APPLICABLE", "created_timestamp": "1700000000.000000001"}], "links": {"next": null}}`)
+	})
+	// The mirror node sends supplies and decimals of tokens as strings
+	mirror.Handle("/api/v1/tokens/0.0.1009", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"token_id": "0.0.1009", "name": "Test Token", "symbol": "TST", "decimals": "2", "total_supply": "100000",
+			"max_supply": "0", "initial_supply": "100000", "type": "FUNGIBLE_COMMON", "supply_type": "INFINITE", "treasury_account_id": "0.0.1002"}`)
+	})
+	relay := e2e.NewRelay(t, mirror, e2e.RelayConfig{})
+	ctx := context.Background()
+	token := "0x00000000000000000000000000000000000003f1"
+
+	balances, err := relay.Eth.TokenBalances(ctx, sender)
+	require.NoError(t, err)
+	require.Len(t, balances, 1)
+	assert.Equal(t, token, balances[0].Token)
+	assert.Equal(t, "0x9c4", balances[0].Balance)
+	assert.Equal(t, "0x2", balances[0].Decimals)
+
+	// Accounts unknown to the mirror node hold no tokens
+	balances, err = relay.Eth.TokenBalances(ctx, contract)
+	require.NoError(t, err)
+	assert.Empty(t, balances)
+
+	info, err := relay.Eth.TokenInfo(ctx, token)
+	require.NoError(t, err)
+	require.NotNil(t, info)
+	assert.Equal(t, "TST", info.Symbol)
+	assert.Equal(t, "0x186a0", info.TotalSupply)
+	assert.Equal(t, "0.0.1002", info.TreasuryAccountID)
+
+	info, err = relay.Eth.TokenInfo(ctx, "0x00000000000000000000000000000000000003f2")
+	require.NoError(t, err)
+	assert.Nil(t, info)
+
+	_, err = relay.Eth.TokenInfo(ctx, sender)
+	var rpcErr *client.RPCError
+	if assert.ErrorAs(t, err, &rpcErr) {
+		assert.Equal(t, client.CodeInvalidParams, rpcErr.Code)
+	}
+}
+
+func TestGetBalance_BlockParameterObject(t *testing.T) {
+	mirror := newMirrorNode(t)
+	mirror.SetBalance(sender, 5)
+	relay := e2e.NewRelay(t, mirror, e2e.RelayConfig{})
+
+	var balance string
+	relay.CallResult(&balance, "eth_getBalance", sender, map[string]interface{}{"blockHash": blockHash[:66], "requireCanonical": true})
+	assert.Equal(t, "0xba43b7400", balance)
+
+	relay.CallResult(&balance, "eth_getBalance", sender, map[string]interface{}{"blockNumber": "0x64"})
+	assert.Equal(t, "0xba43b7400", balance)
+
+	response := relay.Call("eth_getBalance", sender, map[string]interface{}{"blockHash": blockHash[:66], "blockNumber": "0x64"})
+	if assert.NotNil(t, response.Error) {
+		assert.Equal(t, domain.InvalidParams, response.Error.Code)
+	}
+}
+
+func TestGetStorageAt_SlotFormatsAndLatestFallback(t *testing.T) {
+	mirror := newMirrorNode(t)
+	mirror.AddBlock(domain.BlockResponse{
+		Hash:      "0x" + strings.Repeat("2", 96),
+		Number:    1,
+		Timestamp: domain.Timestamp{From: "1600000000.000000000", To: "1600000001.999999999"},
+	})
+
+	value := "0x" + strings.Repeat("0", 62) + "2a"
+	var slots []string
+	// The mirror node has pruned the history and only knows the latest state
+	mirror.Handle("/api/v1/contracts/"+contract+"/state", func(w http.ResponseWriter, r *http.Request) {
+		slots = append(slots, r.URL.Query().Get("slot"))
+		state := []domain.ContractState{}
+		if r.URL.Query().Get("timestamp") == "" {
+			state = append(state, domain.ContractState{Value: value})
+		}
+		_ = json.NewEncoder(w).Encode(domain.ContractStateResponse{State: state})
+	})
+
+	relay := e2e.NewRelay(t, mirror, e2e.RelayConfig{Service: service.Config{GetStorageAtLatestFallback: true}})
+
+	for _, slot := range []interface{}{"0x1", "1", 1, "0x" + strings.Repeat("0", 63) + "1"} {
+		var result string
+		relay.CallResult(&result, "eth_getStorageAt", contract, slot, "0x1")
+		assert.Equal(t, value, result)
+	}
+	// Each call asks for the block, then falls back to the latest state
+	assert.Len(t, slots, 8)
+	for _, slot := range slots {
+		assert.Equal(t, "0x"+strings.Repeat("0", 63)+"1", slot)
+	}
+
+	response := relay.Call("eth_getStorageAt", contract, "0x"+strings.Repeat("1", 65), "latest")
+	if assert.NotNil(t, response.Error) {
+		assert.Equal(t, domain.InvalidParams, response.Error.Code)
+	}
+
+	relay = e2e.NewRelay(t, mirror, e2e.RelayConfig{})
+	var result string
+	relay.CallResult(&result, "eth_getStorageAt", contract, "0x1", "0x1")
+	assert.Equal(t, "0x"+strings.Repeat("0", 64), result)
+}
+
+func TestGetTransactionByHash_HederaTransactionID(t *testing.T) {
+	mirror := newMirrorNode(t)
+	mirror.Handle("/api/v1/contracts/results/0.0.1001-1700000002-000000005", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(domain.ContractResult{
+			From:        sender,
+			To:          contract,
+			Hash:        txHash,
+			BlockHash:   blockHash,
+			BlockNumber: 100,
+			Timestamp:   "1700000002.500000000",
+		})
+	})
+	relay := e2e.NewRelay(t, mirror, e2e.RelayConfig{})
+
+	for _, id := range []string{"0.0.1001@1700000002.5", "0.0.1001-1700000002-000000005"} {
+		var transaction map[string]interface{}
+		relay.CallResult(&transaction, "eth_getTransactionByHash", id)
+		assert.Equal(t, txHash, transaction["hash"], id)
+		assert.Equal(t, "0x64", transaction["blockNumber"], id)
+	}
+
+	for _, invalid := range []string{"0.0.1001@1700000002", "0.0.1001@1700000002.1000000000", "1001-1700000002-5"} {
+		response := relay.Call("eth_getTransactionByHash", invalid)
+		if assert.NotNil(t, response.Error, invalid) {
+			assert.Equal(t, domain.InvalidParams, response.Error.Code)
+		}
+	}
+}
+
+func TestGetTransactionReceipt(t *testing.T) {
+	relay := e2e.NewRelay(t, newMirrorNode(t), e2e.RelayConfig{})
+
+	receipt, err := relay.Eth.TransactionReceipt(context.Background(), txHash)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+
+	assert.Equal(t, blockHash[:66], receipt.BlockHash)
+	assert.Equal(t, "0x64", receipt.BlockNumber)
+	assert.Equal(t, sender, receipt.From)
+	if assert.NotNil(t, receipt.To) {
+		assert.Equal(t, contract, *receipt.To)
+	}
+	assert.Nil(t, receipt.ContractAddress)
+	assert.Equal(t, "0xc350", receipt.GasUsed)
+	assert.Equal(t, "0x1", receipt.Status)
+	require.Len(t, receipt.Logs, 1)
+	assert.Equal(t, []string{transferID}, receipt.Logs[0].Topics)
+}
+
+func TestLogsBloom(t *testing.T) {
+	mirror := newMirrorNode(t)
+	relay := e2e.NewRelay(t, mirror, e2e.RelayConfig{})
+
+	// The mirror node has no bloom for the transaction, so it comes from the logs
+	var receipt domain.TransactionReceipt
+	relay.CallResult(&receipt, "eth_getTransactionReceipt", txHash)
+	assert.True(t, bloomContains(t, receipt.LogsBloom, contract))
+	assert.True(t, bloomContains(t, receipt.LogsBloom, transferID))
+	assert.False(t, bloomContains(t, receipt.LogsBloom, sender))
+
+	// The block bloom is the union of the blooms of its transactions
+	mirror.AddContractResult(domain.ContractResult{
+		From:        sender,
+		To:          sender,
+		Hash:        "0x" + strings.Repeat("5", 64),
+		BlockHash:   blockHash,
+		BlockNumber: 100,
+		Timestamp:   "1700000003.000000000",
+		Result:      "SUCCESS",
+		Bloom:       receipt.LogsBloom,
+	})
+	var block domain.Block
+	relay.CallResult(&block, "eth_getBlockByNumber", "0x64", false)
+	assert.Equal(t, receipt.LogsBloom, block.LogsBloom)
+
+	relay.CallResult(&block, "eth_getBlockByNumber", "0x63", false)
+	assert.Equal(t, "0x"+strings.Repeat("0", 512), block.LogsBloom)
+}
+
+// bloomContains checks the three bits of value, given in hex, in bloom.
+func bloomContains(t *testing.T, bloom, value string) bool {
+	t.Helper()
+
+	bloomBytes, err := hex.DecodeString(strings.TrimPrefix(bloom, "0x"))
+	require.NoError(t, err)
+	require.Len(t, bloomBytes, 256)
+	valueBytes, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+	require.NoError(t, err)
+
+	hash := util.Keccak256(valueBytes)
+	for i := 0; i < 6; i += 2 {
+		bit := (int(hash[i])<<8 | int(hash[i+1])) & 2047
+		if bloomBytes[255-bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestGetTransactionReceipt_ChecksumAddresses(t *testing.T) {
+	relay := e2e.NewRelay(t, newMirrorNode(t), e2e.RelayConfig{Service: service.Config{ChecksumAddresses: true}})
+
+	var receipt domain.TransactionReceipt
+	relay.CallResult(&receipt, "eth_getTransactionReceipt", txHash)
+
+	assert.Equal(t, "0x05FbA803Be258049A27B820088bab1cAD2058871", receipt.From)
+	if assert.NotNil(t, receipt.To) {
+		assert.Equal(t, "0x1a6CD6A2B3E6e8FDD6bb0Eb6b3c6e13b0D1a2B3C", *receipt.To)
+	}
+	require.Len(t, receipt.Logs, 1)
+	assert.Equal(t, *receipt.To, receipt.Logs[0].Address)
+}
+
+func TestGetTransactionReceipt_NotFound(t *testing.T) {
+	relay := e2e.NewRelay(t, newMirrorNode(t), e2e.RelayConfig{})
+
+	receipt, err := relay.Eth.TransactionReceipt(context.Background(), "0x"+strings.Repeat("a", 64))
+	assert.NoError(t, err)
+	assert.Nil(t, receipt)
+}
+
+func TestGetLogs(t *testing.T) {
+	relay := e2e.NewRelay(t, newMirrorNode(t), e2e.RelayConfig{})
+	ctx := context.Background()
+
+	logs, err := relay.Eth.GetLogs(ctx, client.LogFilter{
+		FromBlock: client.BlockNumberArg(99),
+		ToBlock:   client.BlockNumberArg(100),
+		Address:   []string{contract},
+		Topics:    [][]string{{transferID}},
+	})
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, contract, logs[0].Address)
+	assert.Equal(t, txHash, logs[0].TransactionHash)
+	assert.Equal(t, "0x64", logs[0].BlockNumber)
+
+	logs, err = relay.Eth.GetLogs(ctx, client.LogFilter{FromBlock: "0x63", ToBlock: "0x63"})
+	require.NoError(t, err)
+	assert.Empty(t, logs)
+}
+
+func TestClient_BatchCall(t *testing.T) {
+	relay := e2e.NewRelay(t, newMirrorNode(t), e2e.RelayConfig{EnableBatchRequests: true})
+
+	var blockNumber string
+	var block client.Block
+	batch := []client.BatchElem{
+		{Method: "eth_blockNumber", Result: &blockNumber},
+		{Method: "eth_getBlockByNumber", Params: []interface{}{"0x64", false}, Result: &block},
+		{Method: "eth_getBalance", Params: []interface{}{"not an address", client.Latest}},
+	}
+	require.NoError(t, relay.Eth.BatchCall(context.Background(), batch))
+
+	assert.NoError(t, batch[0].Error)
+	assert.Equal(t, "0x64", blockNumber)
+	assert.NoError(t, batch[1].Error)
+	assert.Equal(t, blockHash[:66], *block.Hash)
+	var rpcErr *client.RPCError
+	if assert.ErrorAs(t, batch[2].Error, &rpcErr) {
+		assert.Equal(t, client.CodeInvalidParams, rpcErr.Code)
+	}
+
+	// Relays with batch requests disabled reject the batch as a whole
+	relay = e2e.NewRelay(t, newMirrorNode(t), e2e.RelayConfig{})
+	err := relay.Eth.BatchCall(context.Background(), batch[:2])
+	if assert.ErrorAs(t, err, &rpcErr) {
+		assert.Contains(t, rpcErr.Message, "Batch requests are disabled")
+	}
+}
+
+func TestGetLogs_TopicAlternatives(t *testing.T) {
+	relay := e2e.NewRelay(t, newMirrorNode(t), e2e.RelayConfig{})
+	otherTopic := "0x" + strings.Repeat("1", 64)
+
+	getLogs := func(topics interface{}) []domain.Log {
+		t.Helper()
+		var logs []domain.Log
+		relay.CallResult(&logs, "eth_getLogs", map[string]interface{}{
+			"fromBlock": "0x63",
+			"toBlock":   "0x64",
+			"topics":    topics,
+		})
+		return logs
+	}
+
+	assert.Len(t, getLogs([]interface{}{[]string{otherTopic, transferID}}), 1)
+	assert.Len(t, getLogs([]interface{}{nil}), 1)
+	assert.Empty(t, getLogs([]interface{}{[]string{otherTopic}}))
+	// The log has no second topic
+	assert.Empty(t, getLogs([]interface{}{transferID, []string{otherTopic, transferID}}))
+
+	response := relay.Call("eth_getLogs", map[string]interface{}{
+		"fromBlock": "0x63",
+		"topics":    []interface{}{[]string{"0x1234"}},
+	})
+	if assert.NotNil(t, response.Error) {
+		assert.Equal(t, domain.InvalidParams, response.Error.Code)
+		assert.Contains(t, response.Error.Message, "for topics")
+	}
+
+	response = relay.Call("eth_getLogs", map[string]interface{}{
+		"fromBlock": "0x63",
+		"topics":    []interface{}{nil, nil, nil, nil, transferID},
+	})
+	if assert.NotNil(t, response.Error) {
+		assert.Equal(t, domain.InvalidParams, response.Error.Code)
+		assert.Contains(t, response.Error.Message, "Expected at most 4 entries for topics")
+	}
+}
+
+func TestMirrorNodeUnavailable(t *testing.T) {
+	mirror := newMirrorNode(t)
+	mirror.Handle("/api/v1/contracts/results/logs", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	relay := e2e.NewRelay(t, mirror, e2e.RelayConfig{})
+
+	response := relay.Call("eth_getLogs", map[string]interface{}{
+		"fromBlock": "0x63",
+		"toBlock":   "0x64",
+	})
+	if assert.NotNil(t, response.Error) {
+		assert.Equal(t, domain.MirrorNodeUpstreamFail, response.Error.Code)
+	}
+}
+
+func TestGetLogs_PageLimit(t *testing.T) {
+	mirror := newMirrorNode(t)
+	// The mirror node always has another page
+	mirror.Handle("/api/v1/contracts/results/logs", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"logs": []map[string]interface{}{{
+				"address": contract, "block_hash": blockHash, "block_number": 100, "index": 0,
+				"transaction_hash": txHash, "transaction_index": 0, "timestamp": "1700000002.500000000",
+			}},
+			"links": map[string]interface{}{"next": "/api/v1/contracts/results/logs?timestamp=lt:1700000002.500000000"},
+		})
+	})
+	relay := e2e.NewRelay(t, mirror, e2e.RelayConfig{})
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"eth_getLogs","params":[{"fromBlock":"0x63","toBlock":"0x64"}]}`
+	req, err := http.NewRequest(http.MethodPost, relay.URL, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(http_server.MaxPagesHeader, "3")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	var response e2e.Response
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	if assert.NotNil(t, response.Error) {
+		assert.Equal(t, domain.LimitExceeded, response.Error.Code)
+		assert.Contains(t, response.Error.Message, "more than 3 mirror node pages")
+	}
+
+	var logRequests int
+	for _, request := range mirror.Requests() {
+		if strings.HasPrefix(request, "/api/v1/contracts/results/logs") {
+			logRequests++
+		}
+	}
+	assert.Equal(t, 3, logRequests)
+}
+
+func TestMethodConcurrency(t *testing.T) {
+	mirror := newMirrorNode(t)
+	started := make(chan struct{}, 1)
+	unblock := make(chan struct{})
+	mirror.Handle("/api/v1/contracts/results/logs", func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-unblock
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"logs": []interface{}{}, "links": map[string]interface{}{"next": nil}})
+	})
+	relay := e2e.NewRelay(t, mirror, e2e.RelayConfig{
+		Concurrency: limiter.ConcurrencyConfig{Limits: map[string]int{"eth_getlogs": 1}},
+	})
+	filter := map[string]interface{}{"fromBlock": "0x63", "toBlock": "0x64"}
+
+	done := make(chan *e2e.Response)
+	go func() { done <- relay.Call("eth_getLogs", filter) }()
+	<-started
+
+	// The slot is taken, so further log queries are rejected while cheap
+	// methods are still served
+	response := relay.Call("eth_getLogs", filter)
+	if assert.NotNil(t, response.Error) {
+		assert.Equal(t, domain.LimitExceeded, response.Error.Code)
+		assert.Contains(t, response.Error.Message, "Too many concurrent eth_getLogs requests")
+	}
+	var blockNumber string
+	relay.CallResult(&blockNumber, "eth_blockNumber")
+	assert.Equal(t, "0x64", blockNumber)
+
+	close(unblock)
+	assert.Nil(t, (<-done).Error)
+	assert.Nil(t, relay.Call("eth_getLogs", filter).Error)
+}
+
+func TestLoadShedding(t *testing.T) {
+	mirror := newMirrorNode(t)
+	overload := limiter.NewOverloadController(limiter.OverloadConfig{
+		CheckInterval:      5 * time.Millisecond,
+		MaxGoroutines:      1,
+		LowPriorityMethods: []string{"debug_*"},
+		MaxLogsBlockRange:  10,
+	}, zap.NewNop())
+	t.Cleanup(overload.Stop)
+	relay := e2e.NewRelay(t, mirror, e2e.RelayConfig{
+		Service:  service.Config{DebugAPIEnabled: true},
+		Overload: overload,
+	})
+	require.Eventually(t, overload.Overloaded, time.Second, time.Millisecond)
+
+	shed := []struct {
+		method string
+		params []interface{}
+	}{
+		{"debug_traceTransaction", []interface{}{txHash}},
+		{"eth_getLogs", []interface{}{map[string]interface{}{"fromBlock": "0x1", "toBlock": "0x64"}}},
+		{"eth_getLogs", []interface{}{map[string]interface{}{"fromBlock": "earliest"}}},
+	}
+	for _, call := range shed {
+		response := relay.Call(call.method, call.params...)
+		if assert.NotNil(t, response.Error, call.method) {
+			assert.Equal(t, domain.LimitExceeded, response.Error.Code)
+			assert.Contains(t, response.Error.Message, "Relay overloaded")
+		}
+	}
+
+	// Narrow log queries, receipts and everything else are still served
+	assert.Nil(t, relay.Call("eth_getLogs", map[string]interface{}{"fromBlock": "0x60", "toBlock": "latest"}).Error)
+	assert.Nil(t, relay.Call("eth_getLogs", map[string]interface{}{"blockHash": blockHash[:66]}).Error)
+	var receipt map[string]interface{}
+	relay.CallResult(&receipt, "eth_getTransactionReceipt", txHash)
+	assert.Equal(t, txHash, receipt["transactionHash"])
+}
+
+func TestGetConfiguration(t *testing.T) {
+	mirror := newMirrorNode(t)
+	relay := e2e.NewRelay(t, mirror, e2e.RelayConfig{
+		EnableBatchRequests: true,
+		Service:             service.Config{ConfigurationAPIEnabled: true, DebugAPIEnabled: true},
+	})
+
+	var configuration service.RelayConfiguration
+	relay.CallResult(&configuration, "hederium_getConfiguration")
+
+	assert.Equal(t, "e2e", configuration.Version)
+	assert.Equal(t, e2e.DefaultChainID, configuration.ChainID)
+	assert.Equal(t, []string{mirror.URL}, configuration.MirrorNode.URLs)
+	assert.Equal(t, mirror.URL, configuration.MirrorNode.Active)
+	assert.Equal(t, e2e.MirrorNodeVersion, configuration.MirrorNode.Version)
+	assert.Equal(t, "memory", configuration.Cache.Backend)
+	assert.True(t, configuration.Features["batchRequests"])
+	assert.True(t, configuration.Features["debug"])
+	assert.False(t, configuration.Features["filters"])
+	assert.False(t, configuration.Features["enforceApiKey"])
+	assert.NotNil(t, configuration.RateLimits)
+}
+
+func TestGetConfiguration_Disabled(t *testing.T) {
+	relay := e2e.NewRelay(t, newMirrorNode(t), e2e.RelayConfig{})
+
+	response := relay.Call("hederium_getConfiguration")
+	if assert.NotNil(t, response.Error) {
+		assert.Equal(t, domain.MethodNotFound, response.Error.Code)
+	}
+}
+
+func TestGetExchangeRate(t *testing.T) {
+	mirror := newMirrorNode(t)
+	expiration := time.Now().Add(time.Hour).Unix()
+	mirror.Handle("/api/v1/network/exchangerate", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(domain.ExchangeRateResponse{
+			CurrentRate: domain.Rate{HbarEquivalent: 30000, CentEquivalent: 240000, ExpirationTime: expiration},
+			Timestamp:   "1700000000.000000000",
+		})
+	})
+	relay := e2e.NewRelay(t, mirror, e2e.RelayConfig{})
+
+	for i := 0; i < 2; i++ {
+		var rate domain.ExchangeRate
+		relay.CallResult(&rate, "hederium_getExchangeRate")
+		assert.Equal(t, domain.ExchangeRate{HbarEquivalent: 30000, CentEquivalent: 240000, ExpirationTime: expiration, UsdPerHbar: 0.08}, rate)
+	}
+
+	// The rate is cached until it expires
+	var requests int
+	for _, request := range mirror.Requests() {
+		if request == "/api/v1/network/exchangerate" {
+			requests++
+		}
+	}
+	assert.Equal(t, 1, requests)
+}
+
+func TestSupportedMethods(t *testing.T) {
+	relay := e2e.NewRelay(t, newMirrorNode(t), e2e.RelayConfig{Service: service.Config{FilterAPIEnabled: true}})
+
+	var methods domain.SupportedMethods
+	relay.CallResult(&methods, "hederium_supportedMethods")
+
+	assert.Contains(t, methods.Supported, "eth_blockNumber")
+	assert.Contains(t, methods.Supported, "eth_newFilter")
+	assert.Contains(t, methods.Supported, "eth_newPendingTransactionFilter")
+	assert.Contains(t, methods.Supported, "hederium_supportedMethods")
+	assert.NotContains(t, methods.Supported, "debug_traceTransaction")
+	assert.NotContains(t, methods.Supported, "hederium_getConfiguration")
+	assert.Equal(t, []string{"eth_sendTransaction", "eth_sign", "eth_signTransaction"}, methods.Unsupported)
+}
+
+func TestDiscover(t *testing.T) {
+	relay := e2e.NewRelay(t, newMirrorNode(t), e2e.RelayConfig{})
+
+	var document rpc.OpenRPCDocument
+	relay.CallResult(&document, "rpc.discover")
+
+	assert.Equal(t, rpc.OpenRPCVersion, document.OpenRPC)
+	assert.Contains(t, document.UnsupportedMethods, "eth_sendTransaction")
+
+	methods := make(map[string]rpc.OpenRPCMethod)
+	for _, method := range document.Methods {
+		methods[method.Name] = method
+	}
+	assert.NotContains(t, methods, "debug_traceTransaction")
+	assert.NotContains(t, methods, "eth_newFilter")
+	assert.Contains(t, methods, "rpc_discover")
+
+	getBalance := methods["eth_getBalance"]
+	require.Len(t, getBalance.Params, 2)
+	assert.Equal(t, "address", getBalance.Params[0].Name)
+	assert.True(t, getBalance.Params[0].Required)
+	assert.Equal(t, "^0x[0-9a-fA-F]{40}$", getBalance.Params[0].Schema["pattern"])
+	assert.False(t, getBalance.Params[1].Required)
+
+	// The filter fields are the properties of a single object parameter
+	getLogs := methods["eth_getLogs"]
+	require.Len(t, getLogs.Params, 1)
+	assert.Equal(t, "filter", getLogs.Params[0].Name)
+	assert.True(t, getLogs.Params[0].Required)
+	assert.Contains(t, getLogs.Params[0].Schema["properties"], "fromBlock")
+	assert.Contains(t, getLogs.Params[0].Schema["properties"], "topics")
+
+	assert.Empty(t, methods["eth_chainId"].Params)
+	assert.Contains(t, methods["eth_getTransactionByHash"].Description, "Hedera transaction IDs")
+}
+
+func TestAccountMethodsUnsupported(t *testing.T) {
+	relay := e2e.NewRelay(t, newMirrorNode(t), e2e.RelayConfig{})
+
+	for _, method := range []string{"eth_sendTransaction", "eth_sign", "eth_signTransaction"} {
+		response := relay.Call(method, sender, "0xdeadbeef")
+		if assert.NotNil(t, response.Error, method) {
+			assert.Equal(t, domain.MethodNotFound, response.Error.Code)
+			assert.Contains(t, response.Error.Message, "Method not supported: "+method)
+			assert.Contains(t, response.Error.Message, "eth_sendRawTransaction")
+		}
+	}
+}
+
+func TestInvalidParamErrors(t *testing.T) {
+	relay := e2e.NewRelay(t, newMirrorNode(t), e2e.RelayConfig{})
+
+	testCases := []struct {
+		method   string
+		params   []interface{}
+		expected string
+	}{
+		{"eth_getBlockByHash", []interface{}{123, false}, "Invalid parameter 0: Expected 0x prefixed string representing the hash (32 bytes) of a block, value: 123"},
+		{"eth_getBlockByNumber", []interface{}{"latest", "yes"}, "Invalid parameter 1: Expected boolean type, value: yes"},
+		{"eth_getBalance", []interface{}{"0x1234", "latest"}, "Invalid parameter 0: Expected 0x prefixed string representing the address (20 bytes), value: 0x1234"},
+		{"eth_getBalance", []interface{}{sender, 5}, "Invalid parameter 1: blockNumber must be a string or an object, value: 5"},
+		{"eth_getLogs", []interface{}{map[string]interface{}{"fromBlock": "first"}}, `Invalid parameter 0: Expected 0x prefixed hexadecimal block number, or the string "latest", "earliest", "pending", "safe" or "finalized" for fromBlock, value: first`},
+		{"eth_sendRawTransaction", []interface{}{"0x" + strings.Repeat("zz", 100)}, "Invalid parameter 0: Expected 0x prefixed hexadecimal string, value: 0x" + strings.Repeat("zz", 31) + "..."},
+	}
+
+	for _, tc := range testCases {
+		response := relay.Call(tc.method, tc.params...)
+		if assert.NotNil(t, response.Error, tc.method) {
+			assert.Equal(t, domain.InvalidParams, response.Error.Code)
+			assert.Equal(t, tc.expected, response.Error.Message)
+		}
+	}
+}
+
+func TestHealth(t *testing.T) {
+	relay := e2e.NewRelay(t, newMirrorNode(t), e2e.RelayConfig{})
+
+	resp, err := http.Get(relay.URL + "/health")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	// Without a Hedera client the consensus nodes are not monitored
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, map[string]interface{}{"status": "ok"}, body)
+}
+
+func TestLogExport(t *testing.T) {
+	relay := e2e.NewRelay(t, newMirrorNode(t), e2e.RelayConfig{})
+
+	resp, err := http.Post(relay.URL+"/logs/export", "application/json", strings.NewReader(`{"fromBlock": "0x63", "toBlock": "0x64", "address": "`+contract+`"}`))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+	decoder := json.NewDecoder(resp.Body)
+	var logs []domain.Log
+	for decoder.More() {
+		var log domain.Log
+		require.NoError(t, decoder.Decode(&log))
+		logs = append(logs, log)
+	}
+	require.Len(t, logs, 1)
+	assert.Equal(t, txHash, logs[0].TransactionHash)
+	assert.Equal(t, "0x64", logs[0].BlockNumber)
+}
+
+func TestLogExport_InvalidFilter(t *testing.T) {
+	relay := e2e.NewRelay(t, newMirrorNode(t), e2e.RelayConfig{})
+
+	resp, err := http.Post(relay.URL+"/logs/export", "application/json", strings.NewReader(`{"fromBlock": "0x63", "limit": 10}`))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	var body struct {
+		Error *domain.RPCError `json:"error"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	if assert.NotNil(t, body.Error) {
+		assert.Equal(t, domain.InvalidParams, body.Error.Code)
+	}
+}
+
+func TestNetworks(t *testing.T) {
+	testnet := e2e.NewMirrorNode(t)
+	testnet.AddBlock(domain.BlockResponse{
+		Hash:      "0x" + strings.Repeat("2", 96),
+		Number:    7,
+		Timestamp: domain.Timestamp{From: "1700000000.000000000", To: "1700000001.999999999"},
+	})
+	relay := e2e.NewRelay(t, newMirrorNode(t), e2e.RelayConfig{
+		Networks: []e2e.RelayNetwork{{Name: "testnet", Hosts: []string{"testnet.relay.local"}, ChainID: "0x128", Mirror: testnet}},
+	})
+
+	chainID := `{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`
+	blockNumber := `{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`
+
+	assert.Equal(t, json.RawMessage(`"0x128"`), relay.PostTo("/testnet", "", []byte(chainID)).Result)
+	assert.Equal(t, json.RawMessage(`"0x7"`), relay.PostTo("/testnet", "", []byte(blockNumber)).Result)
+
+	// The Host header picks the network on the root path, ignoring the port
+	assert.Equal(t, json.RawMessage(`"0x128"`), relay.PostTo("/", "testnet.relay.local:7546", []byte(chainID)).Result)
+	assert.Equal(t, json.RawMessage(`"0x7"`), relay.PostTo("/", "TESTNET.relay.local", []byte(blockNumber)).Result)
+
+	// Any other request is answered by the default network
+	assert.Equal(t, json.RawMessage(`"`+e2e.DefaultChainID+`"`), relay.PostTo("/", "mainnet.relay.local", []byte(chainID)).Result)
+	assert.Equal(t, json.RawMessage(`"0x64"`), relay.Post([]byte(blockNumber)).Result)
+}
+
+func TestNetworks_GasLimits(t *testing.T) {
+	testnet := e2e.NewMirrorNode(t)
+	testnet.AddBlock(domain.BlockResponse{
+		Hash:      "0x" + strings.Repeat("2", 96),
+		Number:    7,
+		Timestamp: domain.Timestamp{From: "1700000000.000000000", To: "1700000001.999999999"},
+	})
+	relay := e2e.NewRelay(t, newMirrorNode(t), e2e.RelayConfig{
+		Service:  service.Config{MaxCallGas: 1000000},
+		Networks: []e2e.RelayNetwork{{Name: "testnet", ChainID: "0x128", Mirror: testnet, BlockGasLimit: 30000000, MaxCallGas: 2000000}},
+	})
+
+	var block struct {
+		GasLimit string `json:"gasLimit"`
+	}
+	require.NoError(t, json.Unmarshal(relay.Post([]byte(`{"jsonrpc":"2.0","id":1,"method":"eth_getBlockByNumber","params":["0x64",false]}`)).Result, &block))
+	assert.Equal(t, "0xe4e1c0", block.GasLimit)
+	require.NoError(t, json.Unmarshal(relay.PostTo("/testnet", "", []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_getBlockByNumber","params":["0x7",false]}`)).Result, &block))
+	assert.Equal(t, "0x1c9c380", block.GasLimit)
+
+	// 1500000 gas is above the cap of the default network only
+	call := []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_estimateGas","params":[{"to":"0x742d35cc6634c0532925a3b844bc454e4438f44e","gas":"0x16e360"}]}`)
+	resp := relay.Post(call)
+	if assert.NotNil(t, resp.Error) {
+		assert.Equal(t, "gas required exceeds allowance (1000000)", resp.Error.Message)
+	}
+	resp = relay.PostTo("/testnet", "", call)
+	if resp.Error != nil {
+		assert.NotContains(t, resp.Error.Message, "exceeds allowance")
+	}
+}
+
+func TestHTTPCache_ImmutableResponses(t *testing.T) {
+	relay := e2e.NewRelay(t, newMirrorNode(t), e2e.RelayConfig{
+		HTTPCache: http_server.HTTPCacheConfig{Enabled: true, MaxAge: time.Hour},
+	})
+
+	post := func(body, ifNoneMatch string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, relay.URL, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		return resp
+	}
+
+	blockByNumber := `{"jsonrpc":"2.0","id":1,"method":"eth_getBlockByNumber","params":["0x64",false]}`
+	resp := post(blockByNumber, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "public, max-age=3600, immutable", resp.Header.Get("Cache-Control"))
+	etag := resp.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+
+	resp = post(blockByNumber, `"other", `+etag)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	assert.Equal(t, etag, resp.Header.Get("ETag"))
+
+	// Receipts of found transactions are immutable too
+	resp = post(`{"jsonrpc":"2.0","id":1,"method":"eth_getTransactionReceipt","params":["`+txHash+`"]}`, "")
+	assert.NotEmpty(t, resp.Header.Get("ETag"))
+
+	// Blocks named by tag, missing data and mutable methods must not be stored
+	for _, body := range []string{
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getBlockByNumber","params":["latest",false]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_getBlockByNumber","params":["0x1000",false]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`,
+	} {
+		resp = post(body, "")
+		assert.Empty(t, resp.Header.Get("ETag"), body)
+		assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"), body)
+	}
+}
@@ -0,0 +1,139 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type rpcRequest struct {
+	ID     int64         `json:"id"`
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+func newRelay(t *testing.T, handler http.HandlerFunc) *client.EthClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return client.NewEthClient(server.URL, client.WithHeader("X-API-Key", "key"))
+}
+
+func TestEthClient_TypedResults(t *testing.T) {
+	ethClient := newRelay(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "key", r.Header.Get("X-API-Key"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var req rpcRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		results := map[string]interface{}{
+			"eth_blockNumber":           "0x64",
+			"eth_getBalance":            "0xde0b6b3a7640000",
+			"eth_getTransactionReceipt": nil,
+			"eth_getBlockByNumber":      map[string]interface{}{"number": "0x64", "hash": "0xabc", "transactions": []string{}},
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": results[req.Method]})
+	})
+	ctx := context.Background()
+
+	blockNumber, err := ethClient.BlockNumber(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), blockNumber)
+
+	balance, err := ethClient.BalanceAt(ctx, "0x05fba803be258049a27b820088bab1cad2058871", client.Latest)
+	require.NoError(t, err)
+	assert.Equal(t, "1000000000000000000", balance.String())
+
+	receipt, err := ethClient.TransactionReceipt(ctx, "0x01")
+	require.NoError(t, err)
+	assert.Nil(t, receipt)
+
+	block, err := ethClient.BlockByNumber(ctx, client.BlockNumberArg(100), false)
+	require.NoError(t, err)
+	require.NotNil(t, block)
+	assert.Equal(t, "0x64", *block.Number)
+}
+
+func TestEthClient_Errors(t *testing.T) {
+	ethClient := newRelay(t, func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch req.Method {
+		case "eth_call":
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID,
+				"error": map[string]interface{}{"code": 3, "message": "execution reverted", "data": "0x08c379a0"}})
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = io.WriteString(w, "bad gateway")
+		}
+	})
+	ctx := context.Background()
+
+	_, err := ethClient.CallContract(ctx, client.CallMsg{To: "0x1a6cd6a2b3e6e8fdd6bb0eb6b3c6e13b0d1a2b3c"}, client.Latest)
+	var rpcErr *client.RPCError
+	if assert.ErrorAs(t, err, &rpcErr) {
+		assert.Equal(t, client.CodeContractRevert, rpcErr.Code)
+		assert.Equal(t, "0x08c379a0", rpcErr.Data)
+	}
+
+	_, err = ethClient.ChainID(ctx)
+	var httpErr *client.HTTPError
+	if assert.ErrorAs(t, err, &httpErr) {
+		assert.Equal(t, http.StatusBadGateway, httpErr.StatusCode)
+		assert.Equal(t, "bad gateway", httpErr.Body)
+	}
+}
+
+func TestEthClient_Timeout(t *testing.T) {
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(unblock) })
+	ethClient := client.NewEthClient(server.URL, client.WithTimeout(20*time.Millisecond))
+
+	start := time.Now()
+	_, err := ethClient.BlockNumber(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestEthClient_BatchCall(t *testing.T) {
+	ethClient := newRelay(t, func(w http.ResponseWriter, r *http.Request) {
+		var reqs []rpcRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&reqs))
+		require.Len(t, reqs, 3)
+
+		// Responses arrive in any order, and one is missing
+		_ = json.NewEncoder(w).Encode([]interface{}{
+			map[string]interface{}{"jsonrpc": "2.0", "id": reqs[1].ID, "error": map[string]interface{}{"code": -32602, "message": "invalid"}},
+			map[string]interface{}{"jsonrpc": "2.0", "id": reqs[0].ID, "result": "0x12a"},
+		})
+	})
+
+	var chainID string
+	batch := []client.BatchElem{
+		{Method: "eth_chainId", Result: &chainID},
+		{Method: "eth_getBalance", Params: []interface{}{"0x1", client.Latest}},
+		{Method: "eth_blockNumber"},
+	}
+	require.NoError(t, ethClient.BatchCall(context.Background(), batch))
+
+	assert.NoError(t, batch[0].Error)
+	assert.Equal(t, "0x12a", chainID)
+	var rpcErr *client.RPCError
+	if assert.ErrorAs(t, batch[1].Error, &rpcErr) {
+		assert.Equal(t, client.CodeInvalidParams, rpcErr.Code)
+	}
+	assert.ErrorContains(t, batch[2].Error, "no response to eth_blockNumber")
+}
@@ -0,0 +1,85 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthFeeHistoryParams_RewardPercentiles(t *testing.T) {
+	testCases := []struct {
+		name        string
+		percentiles interface{}
+		expected    []string
+		expectedErr string
+	}{
+		{
+			name:        "numbers",
+			percentiles: []interface{}{float64(25), float64(75)},
+			expected:    []string{"25", "75"},
+		},
+		{
+			name:        "fractional numbers",
+			percentiles: []interface{}{float64(0), 12.5, float64(100)},
+			expected:    []string{"0", "12.5", "100"},
+		},
+		{
+			name:        "decimal and hex strings",
+			percentiles: []interface{}{"10", "0x32"},
+			expected:    []string{"10", "50"},
+		},
+		{
+			name:        "empty",
+			percentiles: []interface{}{},
+			expected:    []string{},
+		},
+		{
+			name:        "not an array",
+			percentiles: float64(25),
+			expectedErr: "Invalid parameter 2: Expected an array of reward percentiles, value: 25",
+		},
+		{
+			name:        "not a number",
+			percentiles: []interface{}{"median"},
+			expectedErr: `Invalid parameter 2: Expected an array of reward percentiles, value: ["median"]`,
+		},
+		{
+			name:        "above 100",
+			percentiles: []interface{}{float64(50), float64(101)},
+			expectedErr: "Invalid parameter 2: Expected reward percentiles between 0 and 100, value: [50,101]",
+		},
+		{
+			name:        "negative",
+			percentiles: []interface{}{float64(-1)},
+			expectedErr: "Invalid parameter 2: Expected reward percentiles between 0 and 100, value: [-1]",
+		},
+		{
+			name:        "decreasing",
+			percentiles: []interface{}{float64(75), float64(25)},
+			expectedErr: "Invalid parameter 2: Expected reward percentiles in increasing order, value: [75,25]",
+		},
+		{
+			name:        "repeated",
+			percentiles: []interface{}{float64(50), "50"},
+			expectedErr: `Invalid parameter 2: Expected reward percentiles in increasing order, value: [50,"50"]`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var params domain.EthFeeHistoryParams
+			err := params.FromPositionalParams([]interface{}{"0x2", "latest", tc.percentiles})
+
+			if tc.expectedErr != "" {
+				var paramErr *domain.ParamError
+				require.ErrorAs(t, err, &paramErr)
+				assert.Equal(t, tc.expectedErr, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, params.RewardPercentiles)
+		})
+	}
+}
@@ -129,3 +129,25 @@ func TestMemoryCache_ConcurrentAccess(t *testing.T) {
 		<-done
 	}
 }
+
+func TestPrefixedCache(t *testing.T) {
+	memCache := cache.NewMemoryCache(time.Minute*5, time.Minute)
+	testnet := cache.NewPrefixedCache(memCache, "testnet")
+	ctx := context.Background()
+
+	assert.NoError(t, memCache.Set(ctx, "eth_blockNumber", "0x64", time.Minute))
+	assert.NoError(t, testnet.Set(ctx, "eth_blockNumber", "0x7", time.Minute))
+
+	var value string
+	assert.NoError(t, testnet.Get(ctx, "eth_blockNumber", &value))
+	assert.Equal(t, "0x7", value)
+	assert.NoError(t, memCache.Get(ctx, "eth_blockNumber", &value))
+	assert.Equal(t, "0x64", value)
+	assert.NoError(t, memCache.Get(ctx, "testnet:eth_blockNumber", &value))
+	assert.Equal(t, "0x7", value)
+
+	assert.NoError(t, testnet.Delete(ctx, "eth_blockNumber"))
+	assert.Error(t, testnet.Get(ctx, "eth_blockNumber", &value))
+	assert.NoError(t, memCache.Get(ctx, "eth_blockNumber", &value))
+	assert.Equal(t, "0x64", value)
+}
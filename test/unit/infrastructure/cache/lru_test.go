@@ -0,0 +1,95 @@
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUCache_SetAndGet(t *testing.T) {
+	lru := cache.NewLRUCache(time.Minute, 0, 10, 0)
+	ctx := context.Background()
+
+	require.NoError(t, lru.Set(ctx, "test_key", "test_value", time.Minute))
+
+	var value string
+	assert.NoError(t, lru.Get(ctx, "test_key", &value))
+	assert.Equal(t, "test_value", value)
+
+	assert.Error(t, lru.Get(ctx, "missing_key", &value))
+}
+
+func TestLRUCache_EvictsLeastRecentlyUsedEntries(t *testing.T) {
+	lru := cache.NewLRUCache(time.Minute, 0, 3, 0)
+	ctx := context.Background()
+
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, lru.Set(ctx, fmt.Sprintf("key%d", i), i, 0))
+	}
+
+	// Reading key1 makes key2 the least recently used entry
+	var value int
+	require.NoError(t, lru.Get(ctx, "key1", &value))
+	require.NoError(t, lru.Set(ctx, "key4", 4, 0))
+
+	assert.Error(t, lru.Get(ctx, "key2", &value))
+	for _, key := range []string{"key1", "key3", "key4"} {
+		assert.NoError(t, lru.Get(ctx, key, &value), key)
+	}
+	assert.Equal(t, 3, lru.(*cache.LRUCache).Len())
+}
+
+func TestLRUCache_EvictsBeyondMemoryBudget(t *testing.T) {
+	// Each entry takes 4 bytes of key and 12 bytes of JSON value
+	lru := cache.NewLRUCache(time.Minute, 0, 0, 40)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, lru.Set(ctx, fmt.Sprintf("key%d", i), "0123456789", 0))
+	}
+
+	assert.Equal(t, 2, lru.(*cache.LRUCache).Len())
+	assert.Equal(t, int64(32), lru.(*cache.LRUCache).Bytes())
+
+	var value string
+	assert.Error(t, lru.Get(ctx, "key2", &value))
+	assert.NoError(t, lru.Get(ctx, "key4", &value))
+
+	// Values larger than the whole budget are not stored
+	assert.Error(t, lru.Set(ctx, "large", string(make([]byte, 64)), 0))
+}
+
+func TestLRUCache_Expiration(t *testing.T) {
+	lru := cache.NewLRUCache(time.Minute, 10*time.Millisecond, 10, 0)
+	ctx := context.Background()
+
+	require.NoError(t, lru.Set(ctx, "short", "value", 20*time.Millisecond))
+	require.NoError(t, lru.Set(ctx, "default", "value", 0))
+	require.NoError(t, lru.Set(ctx, "forever", "value", -1))
+
+	require.Eventually(t, func() bool {
+		return lru.(*cache.LRUCache).Len() == 2
+	}, time.Second, 10*time.Millisecond)
+
+	var value string
+	assert.Error(t, lru.Get(ctx, "short", &value))
+	assert.NoError(t, lru.Get(ctx, "default", &value))
+	assert.NoError(t, lru.Get(ctx, "forever", &value))
+}
+
+func TestLRUCache_Delete(t *testing.T) {
+	lru := cache.NewLRUCache(time.Minute, 0, 10, 0)
+	ctx := context.Background()
+
+	require.NoError(t, lru.Set(ctx, "test_key", "test_value", 0))
+	require.NoError(t, lru.Delete(ctx, "test_key"))
+
+	var value string
+	assert.Error(t, lru.Get(ctx, "test_key", &value))
+	assert.Equal(t, int64(0), lru.(*cache.LRUCache).Bytes())
+}
@@ -0,0 +1,23 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTTLs_Of(t *testing.T) {
+	ttls := cache.TTLs{
+		cache.ClassBlock:    6 * time.Hour,
+		cache.ClassGasPrice: 10 * time.Second,
+		cache.ClassToken:    0,
+	}
+
+	assert.Equal(t, 6*time.Hour, ttls.Of(cache.ClassBlock))
+	assert.Equal(t, 10*time.Second, ttls.Of(cache.ClassGasPrice))
+	assert.Equal(t, cache.DefaultTTL, ttls.Of(cache.ClassToken))
+	assert.Equal(t, cache.DefaultTTL, ttls.Of(cache.ClassReceipt))
+	assert.Equal(t, cache.DefaultTTL, cache.TTLs(nil).Of(cache.ClassAccount))
+}
@@ -0,0 +1,223 @@
+package config_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const operatorKey = "302e020100300506032b6570042204206bb5ca5c9a33b8a12e2d962fe14587fa40e92306e9c39bcd2bb62951100d7248"
+
+func fetchSecret(t *testing.T, source config.SecretSource) (string, error) {
+	t.Helper()
+
+	provider, err := config.NewSecretProvider(source)
+	require.NoError(t, err)
+	require.NotNil(t, provider)
+	return provider.Fetch(context.Background())
+}
+
+func TestSecretProvider_Env(t *testing.T) {
+	t.Setenv("HEDERIUM_TEST_OPERATOR_KEY", operatorKey+"\n")
+
+	secret, err := fetchSecret(t, config.SecretSource{Provider: config.SecretProviderEnv, Env: "HEDERIUM_TEST_OPERATOR_KEY"})
+	require.NoError(t, err)
+	assert.Equal(t, operatorKey, secret)
+
+	_, err = fetchSecret(t, config.SecretSource{Provider: config.SecretProviderEnv, Env: "HEDERIUM_TEST_UNSET"})
+	assert.Error(t, err)
+}
+
+func TestSecretProvider_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "operator-key")
+	require.NoError(t, os.WriteFile(path, []byte(operatorKey+"\n"), 0o600))
+
+	secret, err := fetchSecret(t, config.SecretSource{Provider: config.SecretProviderFile, File: path})
+	require.NoError(t, err)
+	assert.Equal(t, operatorKey, secret)
+}
+
+func TestSecretProvider_AWS(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY")
+	t.Setenv("AWS_SESSION_TOKEN", "session-token")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "secretsmanager.GetSecretValue", r.Header.Get("X-Amz-Target"))
+		assert.Equal(t, "session-token", r.Header.Get("X-Amz-Security-Token"))
+		authorization := r.Header.Get("Authorization")
+		assert.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"+time.Now().UTC().Format("20060102")+"/eu-west-1/secretsmanager/aws4_request, "))
+		assert.Contains(t, authorization, "SignedHeaders=content-type;host;x-amz-date;x-amz-security-token;x-amz-target, Signature=")
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "hederium/operator", body["SecretId"])
+
+		secret, _ := json.Marshal(map[string]string{"operatorKey": operatorKey})
+		_ = json.NewEncoder(w).Encode(map[string]string{"SecretString": string(secret)})
+	}))
+	defer server.Close()
+
+	secret, err := fetchSecret(t, config.SecretSource{
+		Provider: config.SecretProviderAWS,
+		SecretID: "hederium/operator",
+		Field:    "operatorKey",
+		Region:   "eu-west-1",
+		Endpoint: server.URL,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, operatorKey, secret)
+}
+
+func TestSecretProvider_GCP(t *testing.T) {
+	t.Setenv("GOOGLE_OAUTH_ACCESS_TOKEN", "gcp-token")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/projects/hederium/secrets/operator-key/versions/latest:access", r.URL.Path)
+		assert.Equal(t, "Bearer gcp-token", r.Header.Get("Authorization"))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"payload": map[string]string{"data": base64.StdEncoding.EncodeToString([]byte(operatorKey))},
+		})
+	}))
+	defer server.Close()
+
+	secret, err := fetchSecret(t, config.SecretSource{
+		Provider: config.SecretProviderGCP,
+		SecretID: "projects/hederium/secrets/operator-key/versions/latest",
+		Endpoint: server.URL,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, operatorKey, secret)
+}
+
+func TestSecretProvider_Vault(t *testing.T) {
+	t.Setenv("VAULT_TOKEN", "vault-token")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/secret/data/hederium", r.URL.Path)
+		assert.Equal(t, "vault-token", r.Header.Get("X-Vault-Token"))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"data":     map[string]string{"operatorKey": operatorKey, "apiKey": "other"},
+				"metadata": map[string]interface{}{"version": 3},
+			},
+		})
+	}))
+	defer server.Close()
+
+	source := config.SecretSource{Provider: config.SecretProviderVault, SecretID: "secret/data/hederium", Field: "operatorKey", VaultAddress: server.URL}
+	secret, err := fetchSecret(t, source)
+	require.NoError(t, err)
+	assert.Equal(t, operatorKey, secret)
+
+	// A field must be named when the secret holds several
+	source.Field = ""
+	_, err = fetchSecret(t, source)
+	assert.Error(t, err)
+}
+
+func TestNewSecretProvider_Invalid(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	t.Setenv("VAULT_ADDR", "")
+
+	for _, source := range []config.SecretSource{
+		{Provider: "keychain"},
+		{Provider: config.SecretProviderEnv},
+		{Provider: config.SecretProviderFile},
+		{Provider: config.SecretProviderAWS, SecretID: "hederium/operator"},
+		{Provider: config.SecretProviderGCP},
+		{Provider: config.SecretProviderVault, SecretID: "secret/data/hederium"},
+	} {
+		_, err := config.NewSecretProvider(source)
+		assert.Error(t, err, source.Provider)
+	}
+
+	provider, err := config.NewSecretProvider(config.SecretSource{Provider: config.SecretProviderConfig})
+	assert.NoError(t, err)
+	assert.Nil(t, provider)
+}
+
+func TestResolveOperatorKey(t *testing.T) {
+	t.Setenv("HEDERIUM_TEST_OPERATOR_KEY", operatorKey)
+
+	v := loadConfig(t, `
+hedera:
+  operatorKey: ""
+  operatorKeySource:
+    provider: "env"
+    env: "HEDERIUM_TEST_OPERATOR_KEY"
+`)
+	provider, err := config.ResolveOperatorKey(v)
+	require.NoError(t, err)
+	assert.NotNil(t, provider)
+	assert.Equal(t, operatorKey, v.GetString("hedera.operatorKey"))
+
+	// Keys kept in the config file are left alone
+	v = loadConfig(t, "hedera:\n  operatorKey: \"in-config\"\n")
+	provider, err = config.ResolveOperatorKey(v)
+	require.NoError(t, err)
+	assert.Nil(t, provider)
+	assert.Equal(t, "in-config", v.GetString("hedera.operatorKey"))
+
+	v = loadConfig(t, "hedera:\n  operatorKeySource:\n    provider: \"env\"\n    env: \"HEDERIUM_TEST_UNSET\"\n")
+	_, err = config.ResolveOperatorKey(v)
+	assert.Error(t, err)
+}
+
+type secretFunc func(ctx context.Context) (string, error)
+
+func (f secretFunc) Fetch(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+func TestWatchSecret(t *testing.T) {
+	secrets := make(chan string, 4)
+	secrets <- "current"
+	secrets <- "rotated"
+	secrets <- "rotated"
+	provider := secretFunc(func(context.Context) (string, error) {
+		select {
+		case secret := <-secrets:
+			return secret, nil
+		default:
+			return "", errors.New("unreachable")
+		}
+	})
+
+	applied := make(chan string, 4)
+	rejected := false
+	config.WatchSecret(provider, 5*time.Millisecond, "current", func(secret string) error {
+		// The first attempt fails and is retried on the next tick
+		if !rejected {
+			rejected = true
+			return errors.New("invalid key")
+		}
+		applied <- secret
+		return nil
+	}, zap.NewNop())
+
+	select {
+	case secret := <-applied:
+		assert.Equal(t, "rotated", secret)
+	case <-time.After(time.Second):
+		t.Fatal("rotated secret was not applied")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, applied)
+}
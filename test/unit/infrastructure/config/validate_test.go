@@ -0,0 +1,158 @@
+package config_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/config"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validConfig = `
+hedera:
+  network: "testnet"
+  operatorId: "0.0.1466"
+  operatorKey: "302e020100300506032b6570042204206bb5ca5c9a33b8a12e2d962fe14587fa40e92306e9c39bcd2bb62951100d7248"
+  chainId: "0x128"
+mirrorNode:
+  baseUrl: "MIRROR_URL"
+  checkOnStartup: true
+limiter:
+  free:
+    requestsPerMinute: 100
+    hbarLimit: 10
+apiKeys:
+  - key: "FREE-KEY"
+    tier: "free"
+features:
+  enforceApiKey: true
+`
+
+func loadConfig(t *testing.T, content string) *viper.Viper {
+	t.Helper()
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	require.NoError(t, v.ReadConfig(strings.NewReader(content)))
+	return v
+}
+
+func newMirror(t *testing.T, status int) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/network/nodes", r.URL.Path)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"nodes":[{"node_id":0}],"links":{"next":null}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestValidate_Valid(t *testing.T) {
+	v := loadConfig(t, strings.Replace(validConfig, "MIRROR_URL", newMirror(t, http.StatusOK), 1))
+	assert.NoError(t, config.Validate(v))
+}
+
+func TestValidate_ReportsAllProblems(t *testing.T) {
+	v := loadConfig(t, `
+hedera:
+  network: "devnet"
+  operatorId: "1466"
+  operatorKey: "not-a-key"
+  chainId: 296
+  operatorSelection: "random"
+mirrorNode:
+  baseUrl: "MIRROR_URL"
+  checkOnStartup: true
+limiter:
+  free:
+    requestsPerMinute: 0
+    hbarLimit: 10
+apiKeys:
+  - key: "PREMIUM-KEY"
+    tier: "premium"
+features:
+  enforceApiKey: true
+server:
+  tls:
+    certFile: "cert.pem"
+`)
+	v.Set("mirrorNode.baseUrl", newMirror(t, http.StatusServiceUnavailable))
+
+	err := config.Validate(v)
+	var validationErr *config.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+
+	keys := make([]string, 0, len(validationErr.Problems))
+	for _, problem := range validationErr.Problems {
+		keys = append(keys, problem.Key)
+		assert.NotEmpty(t, problem.Message)
+	}
+	assert.ElementsMatch(t, []string{
+		"hedera.network",
+		"hedera.operatorId",
+		"hedera.operatorKey",
+		"hedera.operatorSelection",
+		"hedera.chainId",
+		"mirrorNode.baseUrl",
+		"limiter.free.requestsPerMinute",
+		"apiKeys[0].tier",
+		"server.tls",
+	}, keys)
+
+	assert.Contains(t, err.Error(), "9 configuration problem(s) found")
+	assert.Contains(t, err.Error(), "hint: quote the hex value")
+}
+
+func TestValidate_MirrorNodeCheck(t *testing.T) {
+	v := loadConfig(t, strings.Replace(validConfig, "MIRROR_URL", "ftp://mirror", 1))
+	err := config.Validate(v)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not an http or https URL")
+
+	// Unreachable mirror nodes only fail the check when it is enabled
+	v = loadConfig(t, strings.Replace(validConfig, "MIRROR_URL", "http://127.0.0.1:1", 1))
+	assert.Error(t, config.Validate(v))
+	v.Set("mirrorNode.checkOnStartup", false)
+	assert.NoError(t, config.Validate(v))
+}
+
+func TestValidate_SQLAPIKeyStore(t *testing.T) {
+	v := loadConfig(t, strings.Replace(validConfig, "MIRROR_URL", newMirror(t, http.StatusOK), 1))
+	v.Set("apiKeyStore.backend", "sql")
+	v.Set("apiKeyStore.driver", "mysql")
+
+	err := config.Validate(v)
+	var validationErr *config.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	keys := make([]string, 0, len(validationErr.Problems))
+	for _, problem := range validationErr.Problems {
+		keys = append(keys, problem.Key)
+	}
+	assert.ElementsMatch(t, []string{"apiKeyStore.driver", "apiKeyStore.dsn"}, keys)
+
+	v.Set("apiKeyStore.driver", "postgres")
+	v.Set("apiKeyStore.dsn", "postgres://relay@db/hederium")
+	assert.NoError(t, config.Validate(v))
+}
+
+func TestValidate_SQLUsageSink(t *testing.T) {
+	v := loadConfig(t, strings.Replace(validConfig, "MIRROR_URL", newMirror(t, http.StatusOK), 1))
+	v.Set("apiKeyUsage.enabled", true)
+	v.Set("apiKeyUsage.sink", "sql")
+	v.Set("apiKeyUsage.driver", "mysql")
+	v.Set("apiKeyUsage.dsn", "relay@/hederium")
+
+	err := config.Validate(v)
+	var validationErr *config.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	require.Len(t, validationErr.Problems, 1)
+	assert.Equal(t, "apiKeyUsage.driver", validationErr.Problems[0].Key)
+
+	v.Set("apiKeyUsage.driver", "postgres")
+	assert.NoError(t, config.Validate(v))
+}
@@ -0,0 +1,60 @@
+package hedera_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	sdk "github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsensusNodeHealth(t *testing.T) {
+	health := hedera.NewConsensusNodeHealth("testnet", time.Hour)
+	nodes := []sdk.AccountID{{Account: 3}, {Account: 4}, {Account: 5}, {Account: 6}}
+
+	// Unchecked nodes are all available
+	assert.Nil(t, health.Available(nodes))
+
+	health.Record("0.0.3", nil)
+	health.Record("0.0.4", sdk.ErrHederaPreCheckStatus{Status: sdk.StatusBusy})
+	health.Record("0.0.5", errors.New("rpc error: code = Unavailable"))
+	assert.Equal(t, 0, health.EndRound())
+
+	// Busy and unreachable nodes are skipped, unchecked ones are kept
+	assert.Equal(t, []sdk.AccountID{{Account: 3}, {Account: 6}}, health.Available(nodes))
+
+	status := health.Status()
+	assert.Equal(t, []string{"0.0.3"}, status.Healthy)
+	assert.Equal(t, []string{"0.0.4"}, status.Busy)
+	assert.Equal(t, []string{"0.0.5"}, status.Unreachable)
+	assert.False(t, status.LastCheck.IsZero())
+
+	// Once no node is available the SDK picks among all of them
+	health.Record("0.0.3", errors.New("connection refused"))
+	health.Record("0.0.6", sdk.ErrHederaPreCheckStatus{Status: sdk.StatusPlatformNotActive})
+	assert.Nil(t, health.Available(nodes))
+	assert.Equal(t, 1, health.EndRound())
+	assert.Equal(t, 2, health.EndRound())
+
+	health.Reconnected()
+	assert.Equal(t, 1, health.Status().Reconnects)
+	assert.Equal(t, 1, health.EndRound())
+
+	health.Record("0.0.3", nil)
+	assert.Equal(t, 0, health.EndRound())
+}
+
+func TestConsensusNodeHealth_BusyCooldown(t *testing.T) {
+	health := hedera.NewConsensusNodeHealth("testnet", 10*time.Millisecond)
+	nodes := []sdk.AccountID{{Account: 3}, {Account: 4}}
+
+	health.Record("0.0.3", nil)
+	health.Record("0.0.4", sdk.ErrHederaPreCheckStatus{Status: sdk.StatusBusy})
+	assert.Equal(t, []sdk.AccountID{{Account: 3}}, health.Available(nodes))
+
+	// A busy node is tried again once the cooldown ends
+	time.Sleep(20 * time.Millisecond)
+	assert.Nil(t, health.Available(nodes))
+}
@@ -1,15 +1,22 @@
 package hedera_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
 	"github.com/LimeChain/Hederium/internal/infrastructure/hedera"
 	"github.com/LimeChain/Hederium/test/unit/mocks"
 	"github.com/golang/mock/gomock"
@@ -43,9 +50,9 @@ func TestNewMirrorClient(t *testing.T) {
 	baseURL := "http://test.com"
 	timeoutSeconds := 30
 
-	client := hedera.NewMirrorClient(baseURL, timeoutSeconds, setup.logger, setup.cacheService)
+	client := hedera.NewMirrorClient([]string{baseURL}, timeoutSeconds, setup.logger, setup.cacheService)
 
-	assert.Equal(t, baseURL, client.BaseURL)
+	assert.Equal(t, baseURL, client.BaseURL())
 	assert.Equal(t, time.Duration(timeoutSeconds)*time.Second, client.Timeout)
 }
 
@@ -73,8 +80,8 @@ func TestGetLatestBlock_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := hedera.NewMirrorClient(server.URL, 30, setup.logger, setup.cacheService)
-	block, err := client.GetLatestBlock()
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+	block, err := client.GetLatestBlock(context.Background())
 
 	assert.NoError(t, err)
 	assert.Equal(t, float64(123), block["number"])
@@ -95,14 +102,349 @@ func TestGetLatestBlock_EmptyResponse(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := hedera.NewMirrorClient(server.URL, 30, setup.logger, setup.cacheService)
-	block, err := client.GetLatestBlock()
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+	block, err := client.GetLatestBlock(context.Background())
 
 	assert.Error(t, err)
 	assert.Nil(t, block)
 	assert.Contains(t, err.Error(), "no blocks returned")
 }
 
+func TestGetLatestBlock_ContextCanceled(t *testing.T) {
+	setup := setupTest(t)
+	defer setup.ctrl.Finish()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+
+	start := time.Now()
+	block, err := client.GetLatestBlock(ctx)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, block)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestGetLatestBlock_ErrorKinds(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   int
+		delay    time.Duration
+		expected error
+	}{
+		{name: "not found", status: http.StatusNotFound, expected: hedera.ErrNotFound},
+		{name: "rate limited", status: http.StatusTooManyRequests, expected: hedera.ErrRateLimited},
+		{name: "server error", status: http.StatusBadGateway, expected: hedera.ErrUpstreamUnavailable},
+		{name: "timeout", status: http.StatusOK, delay: 1500 * time.Millisecond, expected: hedera.ErrTimeout},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			setup := setupTest(t)
+			defer setup.ctrl.Finish()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(tc.delay)
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			client := hedera.NewMirrorClient([]string{server.URL}, 1, setup.logger, setup.cacheService)
+			block, err := client.GetLatestBlock(context.Background())
+
+			assert.Nil(t, block)
+			assert.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
+
+func TestMirrorClient_Failover(t *testing.T) {
+	setup := setupTest(t)
+	defer setup.ctrl.Finish()
+
+	primaryCalls := 0
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryCalls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"blocks": []map[string]interface{}{{"number": float64(7)}},
+		})
+	}))
+	defer secondary.Close()
+
+	client := hedera.NewMirrorClient([]string{primary.URL, secondary.URL + "/"}, 30, setup.logger, setup.cacheService)
+	assert.Equal(t, primary.URL, client.BaseURL())
+
+	for i := 0; i < 3; i++ {
+		_, err := client.GetLatestBlock(context.Background())
+		assert.Error(t, err)
+	}
+	assert.Equal(t, secondary.URL, client.BaseURL())
+
+	// Selection is sticky: later requests stay on the secondary
+	for i := 0; i < 2; i++ {
+		block, err := client.GetLatestBlock(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, float64(7), block["number"])
+	}
+	assert.Equal(t, 3, primaryCalls)
+	assert.Equal(t, secondary.URL, client.BaseURL())
+}
+
+func TestMirrorClient_Web3Failover(t *testing.T) {
+	setup := setupTest(t)
+	defer setup.ctrl.Finish()
+
+	rest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer rest.Close()
+	restBackup := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer restBackup.Close()
+
+	var web3Paths []string
+	web3 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		web3Paths = append(web3Paths, r.URL.Path)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer web3.Close()
+	web3Backup := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"result":"0x01"}`))
+	}))
+	defer web3Backup.Close()
+
+	client := hedera.NewMirrorClient([]string{rest.URL, restBackup.URL}, 30, setup.logger, setup.cacheService)
+	assert.Equal(t, client.BaseURL(), client.Web3URL(), "contract calls use the REST API by default")
+	assert.Empty(t, client.Web3URLs())
+
+	client.UseWeb3URLs([]string{web3.URL + "/", web3Backup.URL})
+	assert.Equal(t, []string{web3.URL, web3Backup.URL}, client.Web3URLs())
+
+	// REST API failures move REST requests only
+	for i := 0; i < 3; i++ {
+		_, err := client.GetLatestBlock(context.Background())
+		assert.Error(t, err)
+	}
+	assert.Equal(t, restBackup.URL, client.BaseURL())
+	assert.Equal(t, web3.URL, client.Web3URL())
+
+	// and web3 failures move contract calls only
+	for i := 0; i < 3; i++ {
+		_, err := client.PostCall(context.Background(), map[string]interface{}{"to": "0x1"})
+		assert.Error(t, err)
+	}
+	assert.Equal(t, []string{"/api/v1/contracts/call", "/api/v1/contracts/call", "/api/v1/contracts/call"}, web3Paths)
+	assert.Equal(t, web3Backup.URL, client.Web3URL())
+	assert.Equal(t, restBackup.URL, client.BaseURL())
+
+	result, err := client.PostCall(context.Background(), map[string]interface{}{"to": "0x1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "0x01", result)
+}
+
+func TestMirrorClient_Web3HealthCheck(t *testing.T) {
+	setup := setupTest(t)
+	defer setup.ctrl.Finish()
+
+	var restProbes atomic.Int32
+	rest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		restProbes.Add(1)
+	}))
+	defer rest.Close()
+
+	// The web3 module rejecting the probe call is up; failing it is down
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer down.Close()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/contracts/call", r.URL.Path)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer up.Close()
+
+	client := hedera.NewMirrorClient([]string{rest.URL}, 30, setup.logger, setup.cacheService)
+	client.UseWeb3URLs([]string{down.URL, up.URL})
+	client.MonitorEndpoints(10 * time.Millisecond)
+
+	assert.Eventually(t, func() bool { return client.Web3URL() == up.URL }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, rest.URL, client.BaseURL())
+	assert.Equal(t, int32(0), restProbes.Load(), "a single REST base URL is not health-checked")
+}
+
+func TestMirrorClient_NoFailoverOnClientErrors(t *testing.T) {
+	setup := setupTest(t)
+	defer setup.ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := hedera.NewMirrorClient([]string{server.URL, "http://secondary.invalid"}, 30, setup.logger, setup.cacheService)
+	for i := 0; i < 5; i++ {
+		_, err := client.GetLatestBlock(context.Background())
+		assert.Error(t, err)
+	}
+	assert.Equal(t, server.URL, client.BaseURL())
+}
+
+func TestGetContractResultsBySender_Pagination(t *testing.T) {
+	setup := setupTest(t)
+	defer setup.ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/contracts/results", r.URL.Path)
+		response := map[string]interface{}{
+			"results": []map[string]interface{}{{"nonce": 0}, {"nonce": 1}},
+			"links":   map[string]interface{}{"next": "/api/v1/contracts/results?from=0x123&page=2"},
+		}
+		if r.URL.Query().Get("page") == "2" {
+			response = map[string]interface{}{
+				"results": []map[string]interface{}{{"nonce": 2}},
+				"links":   map[string]interface{}{"next": nil},
+			}
+		} else {
+			assert.Equal(t, "0x123", r.URL.Query().Get("from"))
+			assert.Equal(t, "lte:1234567890.000000000", r.URL.Query().Get("timestamp"))
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+	results, err := client.GetContractResultsBySender(context.Background(), "0x123", "1234567890.000000000")
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, int64(2), results[2].Nonce)
+}
+
+func TestGetTokenRelationships_Pagination(t *testing.T) {
+	setup := setupTest(t)
+	defer setup.ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/accounts/0x123/tokens", r.URL.Path)
+		response := map[string]interface{}{
+			"tokens": []map[string]interface{}{{"token_id": "0.0.1001", "balance": 5, "decimals": 2}},
+			"links":  map[string]interface{}{"next": "/api/v1/accounts/0x123/tokens?limit=100&token.id=gt:0.0.1001"},
+		}
+		if r.URL.Query().Get("token.id") != "" {
+			response = map[string]interface{}{
+				"tokens": []map[string]interface{}{{"token_id": "0.0.1002", "balance": 1, "decimals": 0, "freeze_status": "UNFROZEN"}},
+				"links":  map[string]interface{}{"next": nil},
+			}
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+	tokens, err := client.GetTokenRelationships(context.Background(), "0x123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.TokenRelationship{
+		{TokenId: "0.0.1001", Balance: 5, Decimals: 2},
+		{TokenId: "0.0.1002", Balance: 1, FreezeStatus: "UNFROZEN"},
+	}, tokens)
+}
+
+func TestGetTokenAllowance(t *testing.T) {
+	setup := setupTest(t)
+	defer setup.ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/accounts/0x123/allowances/tokens", r.URL.Path)
+		assert.Equal(t, "0.0.1002", r.URL.Query().Get("spender.id"))
+		response := map[string]interface{}{"allowances": []map[string]interface{}{}}
+		if r.URL.Query().Get("token.id") == "0.0.1001" {
+			response["allowances"] = []map[string]interface{}{{"amount": 75, "amount_granted": 100, "owner": "0.0.1000", "spender": "0.0.1002", "token_id": "0.0.1001"}}
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+	allowance, err := client.GetTokenAllowance(context.Background(), "0x123", "0.0.1002", "0.0.1001")
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.TokenAllowance{Amount: 75, AmountGranted: 100, Owner: "0.0.1000", Spender: "0.0.1002", TokenId: "0.0.1001"}, allowance)
+
+	allowance, err = client.GetTokenAllowance(context.Background(), "0x123", "0.0.1002", "0.0.1003")
+	assert.NoError(t, err)
+	assert.Nil(t, allowance)
+}
+
+func TestStreamContractResultsLogs_Pagination(t *testing.T) {
+	setup := setupTest(t)
+	defer setup.ctrl.Finish()
+
+	log := func(index int) map[string]interface{} {
+		return map[string]interface{}{"index": index, "block_number": 10, "transaction_index": 0, "block_hash": "0xabc"}
+	}
+
+	// More pages than MaxPages, which caps the non-streaming log queries
+	pages := hedera.MaxPages + 2
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/contracts/0x123/results/logs", r.URL.Path)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		response := map[string]interface{}{
+			"logs":  []map[string]interface{}{log(2 * page), log(2*page + 1)},
+			"links": map[string]interface{}{"next": fmt.Sprintf("/api/v1/contracts/0x123/results/logs?page=%d", page+1)},
+		}
+		if page == pages-1 {
+			response["links"] = map[string]interface{}{"next": nil}
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+
+	var pageSizes []int
+	var indexes []int
+	err := client.StreamContractResultsLogs(context.Background(), "0x123", map[string]interface{}{"topic0": "0xddf2"}, func(logs []domain.LogEntry) error {
+		pageSizes = append(pageSizes, len(logs))
+		for _, l := range logs {
+			indexes = append(indexes, *l.Index)
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, pageSizes, pages)
+	assert.Len(t, indexes, 2*pages)
+	assert.Equal(t, 2*pages-1, indexes[len(indexes)-1])
+
+	stop := errors.New("stop")
+	calls := 0
+	err = client.StreamContractResultsLogs(context.Background(), "0x123", nil, func(logs []domain.LogEntry) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
 func TestGetBlockByHashOrNumber_Success(t *testing.T) {
 	setup := setupTest(t)
 	defer setup.ctrl.Finish()
@@ -132,9 +474,10 @@ func TestGetBlockByHashOrNumber_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := hedera.NewMirrorClient(server.URL, 30, setup.logger, setup.cacheService)
-	block := client.GetBlockByHashOrNumber("123")
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+	block, err := client.GetBlockByHashOrNumber(context.Background(), "123")
 
+	assert.NoError(t, err)
 	assert.NotNil(t, block)
 	assert.Equal(t, expectedBlock.Number, block.Number)
 	assert.Equal(t, expectedBlock.Hash, block.Hash)
@@ -155,10 +498,65 @@ func TestGetBlockByHashOrNumber_ErrorResponse(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := hedera.NewMirrorClient(server.URL, 30, setup.logger, setup.cacheService)
-	block := client.GetBlockByHashOrNumber("123")
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+	block, err := client.GetBlockByHashOrNumber(context.Background(), "123")
 
 	assert.Nil(t, block)
+	assert.ErrorIs(t, err, hedera.ErrUpstreamUnavailable)
+}
+
+func TestGetBlockByHashOrNumber_ConcurrentMissesShareRequest(t *testing.T) {
+	setup := setupTest(t)
+	defer setup.ctrl.Finish()
+
+	const callers = 20
+	var arrived sync.WaitGroup
+	arrived.Add(callers)
+
+	// Every caller misses the cache before any of them fetches the block
+	setup.cacheService.EXPECT().
+		Get(gomock.Any(), "getBlockByHashOrNumber_123", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, value interface{}) error {
+			arrived.Done()
+			arrived.Wait()
+			return ErrCacheMiss
+		}).
+		Times(callers)
+	setup.cacheService.EXPECT().
+		Set(gomock.Any(), "getBlockByHashOrNumber_123", gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(1)
+
+	var requests atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		_ = json.NewEncoder(w).Encode(domain.BlockResponse{Number: 123, Hash: "0xabc"})
+	}))
+	defer server.Close()
+
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+
+	blocks := make(chan *domain.BlockResponse, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			block, _ := client.GetBlockByHashOrNumber(context.Background(), "123")
+			blocks <- block
+		}()
+	}
+
+	arrived.Wait()
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+
+	for i := 0; i < callers; i++ {
+		block := <-blocks
+		if assert.NotNil(t, block) {
+			assert.Equal(t, "0xabc", block.Hash)
+		}
+	}
+	assert.Equal(t, int32(1), requests.Load())
 }
 
 func TestGetNetworkFees_Success(t *testing.T) {
@@ -186,8 +584,8 @@ func TestGetNetworkFees_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := hedera.NewMirrorClient(server.URL, 30, setup.logger, setup.cacheService)
-	fees, err := client.GetNetworkFees("", "")
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+	fees, err := client.GetNetworkFees(context.Background(), "", "")
 	assert.NoError(t, err)
 
 	assert.Equal(t, int64(100000), fees) // Should return the EthereumTransaction fee
@@ -202,7 +600,7 @@ func TestGetContractResults_Success(t *testing.T) {
 		To:   "1640995300",
 	}
 
-	expectedResults := []domain.ContractResults{
+	expectedResults := []domain.ContractResult{
 		{
 			Hash:   "0xtx1",
 			Result: "SUCCESS",
@@ -215,7 +613,7 @@ func TestGetContractResults_Success(t *testing.T) {
 
 	// First page response
 	firstPage := struct {
-		Results []domain.ContractResults `json:"results"`
+		Results []domain.ContractResult `json:"results"`
 		Links   struct {
 			Next *string `json:"next"`
 		} `json:"links"`
@@ -227,7 +625,7 @@ func TestGetContractResults_Success(t *testing.T) {
 
 	// Second page response
 	secondPage := struct {
-		Results []domain.ContractResults `json:"results"`
+		Results []domain.ContractResult `json:"results"`
 		Links   struct {
 			Next *string `json:"next"`
 		} `json:"links"`
@@ -251,14 +649,115 @@ func TestGetContractResults_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := hedera.NewMirrorClient(server.URL, 30, setup.logger, setup.cacheService)
-	results := client.GetContractResults(timestamp)
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+	results, err := client.GetContractResults(context.Background(), timestamp)
 
+	assert.NoError(t, err)
 	assert.Equal(t, 2, len(results))
 	assert.Equal(t, expectedResults[0].Hash, results[0].Hash)
 	assert.Equal(t, expectedResults[1].Hash, results[1].Hash)
 }
 
+func TestGetContractResult_Normalized(t *testing.T) {
+	setup := setupTest(t)
+	defer setup.ctrl.Finish()
+
+	hash := "0x" + strings.Repeat("a", 64)
+	blockHash := "0x" + strings.Repeat("b", 96)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/contracts/results/"+hash, r.URL.Path)
+		_, _ = fmt.Fprintf(w, `{"hash":%q,"block_hash":%q,"block_number":7,"type":null,"logs":[{"address":"0x1","index":0}]}`, hash, blockHash)
+	}))
+	defer server.Close()
+
+	setup.cacheService.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("not found"))
+	setup.cacheService.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+	result, err := client.GetContractResult(context.Background(), hash)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, hash, result.Hash)
+		assert.Equal(t, blockHash[:66], result.BlockHash)
+		assert.Nil(t, result.Type)
+		assert.Equal(t, 0, result.TxType())
+		assert.Len(t, result.Logs, 1)
+	}
+}
+
+func TestGetContractResult_NotFoundCached(t *testing.T) {
+	hash := "0x" + strings.Repeat("a", 64)
+	var requests atomic.Int32
+	var recorded atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if !recorded.Load() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"hash":%q,"block_hash":"0x%s","block_number":7}`, hash, strings.Repeat("b", 64))
+	}))
+	defer server.Close()
+
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, zap.NewNop(), cache.NewMemoryCache(time.Minute, time.Minute))
+	client.NotFoundTTL = time.Minute
+
+	for i := 0; i < 3; i++ {
+		result, err := client.GetContractResult(context.Background(), hash)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, hedera.ErrNotFound)
+	}
+	assert.Equal(t, int32(1), requests.Load(), "not found answers are served from the cache")
+
+	// Waiting for a transaction just sent asks the mirror node regardless
+	recorded.Store(true)
+	result, err := client.RepeatGetContractResult(context.Background(), hash, 1)
+	if assert.NoError(t, err) {
+		assert.Equal(t, hash, result.Hash)
+	}
+	assert.Equal(t, int32(2), requests.Load())
+
+	// and the result found replaces the not found answer
+	result, err = client.GetContractResult(context.Background(), hash)
+	if assert.NoError(t, err) {
+		assert.Equal(t, hash, result.Hash)
+	}
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestGetBlockByHashOrNumber_NotFoundCached(t *testing.T) {
+	testCases := []struct {
+		name             string
+		notFoundTTL      time.Duration
+		expectedRequests int32
+	}{
+		{name: "cached", notFoundTTL: time.Minute, expectedRequests: 1},
+		{name: "disabled", notFoundTTL: 0, expectedRequests: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				w.WriteHeader(http.StatusNotFound)
+			}))
+			defer server.Close()
+
+			client := hedera.NewMirrorClient([]string{server.URL}, 30, zap.NewNop(), cache.NewMemoryCache(time.Minute, time.Minute))
+			client.NotFoundTTL = tc.notFoundTTL
+
+			for i := 0; i < 2; i++ {
+				block, err := client.GetBlockByHashOrNumber(context.Background(), "123")
+				assert.Nil(t, block)
+				assert.ErrorIs(t, err, hedera.ErrNotFound)
+			}
+			assert.Equal(t, tc.expectedRequests, requests.Load())
+		})
+	}
+}
+
 func TestGetContractResults_ErrorResponse(t *testing.T) {
 	setup := setupTest(t)
 	defer setup.ctrl.Finish()
@@ -268,10 +767,11 @@ func TestGetContractResults_ErrorResponse(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := hedera.NewMirrorClient(server.URL, 30, setup.logger, setup.cacheService)
-	results := client.GetContractResults(domain.Timestamp{})
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+	results, err := client.GetContractResults(context.Background(), domain.Timestamp{})
 
 	assert.Empty(t, results)
+	assert.ErrorIs(t, err, hedera.ErrUpstreamUnavailable)
 }
 
 func TestGetNetworkFees_NoEthereumFee(t *testing.T) {
@@ -292,8 +792,8 @@ func TestGetNetworkFees_NoEthereumFee(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := hedera.NewMirrorClient(server.URL, 30, setup.logger, setup.cacheService)
-	fees, err := client.GetNetworkFees("", "") //  Should be handled better
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+	fees, err := client.GetNetworkFees(context.Background(), "", "") //  Should be handled better
 
 	assert.NoError(t, err)
 	assert.Equal(t, int64(0), fees) // Should return 0 when no EthereumTransaction fee is found
@@ -312,8 +812,8 @@ func TestGetNetworkFees_EmptyResponse(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := hedera.NewMirrorClient(server.URL, 30, setup.logger, setup.cacheService)
-	_, err := client.GetNetworkFees("", "") // Should be handled better
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+	_, err := client.GetNetworkFees(context.Background(), "", "") // Should be handled better
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "no fees returned")
@@ -329,6 +829,7 @@ func TestGetBalance(t *testing.T) {
 		timestampTo    string
 		mockResponse   interface{}
 		expectedResult string
+		expectedErr    error
 		statusCode     int
 	}{
 		{
@@ -360,16 +861,24 @@ func TestGetBalance(t *testing.T) {
 			address:        "0x1234567890123456789012345678901234567890",
 			timestampTo:    "2023-12-09T12:00:00.000Z",
 			mockResponse:   "invalid json",
-			expectedResult: "0x0",
+			expectedResult: "",
 			statusCode:     http.StatusOK,
 		},
 		{
-			name:           "Server error",
-			address:        "0x1234567890123456789012345678901234567890",
-			timestampTo:    "2023-12-09T12:00:00.000Z",
-			mockResponse:   nil,
-			expectedResult: "0x0",
-			statusCode:     http.StatusInternalServerError,
+			name:         "Server error",
+			address:      "0x1234567890123456789012345678901234567890",
+			timestampTo:  "2023-12-09T12:00:00.000Z",
+			mockResponse: nil,
+			expectedErr:  hedera.ErrUpstreamUnavailable,
+			statusCode:   http.StatusInternalServerError,
+		},
+		{
+			name:         "Rate limited",
+			address:      "0x1234567890123456789012345678901234567890",
+			timestampTo:  "2023-12-09T12:00:00.000Z",
+			mockResponse: nil,
+			expectedErr:  hedera.ErrRateLimited,
+			statusCode:   http.StatusTooManyRequests,
 		},
 	}
 
@@ -387,9 +896,17 @@ func TestGetBalance(t *testing.T) {
 			}))
 			defer server.Close()
 
-			client := hedera.NewMirrorClient(server.URL, 5, setup.logger, setup.cacheService)
-			result := client.GetBalance(tc.address, tc.timestampTo)
+			client := hedera.NewMirrorClient([]string{server.URL}, 5, setup.logger, setup.cacheService)
+			result, err := client.GetBalance(context.Background(), tc.address, tc.timestampTo)
 			assert.Equal(t, tc.expectedResult, result)
+			switch {
+			case tc.expectedErr != nil:
+				assert.ErrorIs(t, err, tc.expectedErr)
+			case tc.expectedResult == "":
+				assert.Error(t, err)
+			default:
+				assert.NoError(t, err)
+			}
 		})
 	}
 }
@@ -429,14 +946,120 @@ func TestGetBalance_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := hedera.NewMirrorClient(server.URL, 30, setup.logger, setup.cacheService)
-	result := client.GetBalance("0.0.123", "1234567890.000000000")
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+	result, err := client.GetBalance(context.Background(), "0.0.123", "1234567890.000000000")
+	assert.NoError(t, err)
 
 	// 1 million tinybars * 10000000000 (conversion to weibars) = 10000000000000000 weibars
 	expectedHex := "0x" + new(big.Int).Mul(big.NewInt(1000000), big.NewInt(10000000000)).Text(16)
 	assert.Equal(t, expectedHex, result)
 }
 
+func TestGetBalance_AppliesTransfersSinceSnapshot(t *testing.T) {
+	setup := setupTest(t)
+	defer setup.ctrl.Finish()
+
+	var transactionPages int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/balances":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"timestamp": "1700000000.000000000",
+				"balances":  []map[string]interface{}{{"account": "0.0.123", "balance": 1000}},
+			})
+		case "/api/v1/transactions":
+			transactionPages++
+			if transactionPages == 1 {
+				assert.Equal(t, "0.0.123", r.URL.Query().Get("account.id"))
+				assert.Equal(t, []string{"gt:1700000000.000000000", "lte:1700000600.500000000"}, r.URL.Query()["timestamp"])
+				next := "/api/v1/transactions?account.id=0.0.123&page=2"
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"transactions": []map[string]interface{}{
+						{"consensus_timestamp": "1700000100.000000000", "transfers": []map[string]interface{}{
+							{"account": "0.0.123", "amount": 500},
+							{"account": "0.0.456", "amount": -500},
+						}},
+					},
+					"links": map[string]interface{}{"next": next},
+				})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"transactions": []map[string]interface{}{
+					{"consensus_timestamp": "1700000200.000000000", "transfers": []map[string]interface{}{
+						{"account": "0.0.123", "amount": -200},
+						{"account": "0.0.98", "amount": 200},
+					}},
+					// As in a real record, the staking reward paid to the
+					// account is listed both in transfers, from 0.0.800, and
+					// in staking_reward_transfers
+					{"consensus_timestamp": "1700000300.000000000", "transfers": []map[string]interface{}{
+						{"account": "0.0.800", "amount": -70},
+						{"account": "0.0.123", "amount": 70},
+					}, "staking_reward_transfers": []map[string]interface{}{
+						{"account": "0.0.123", "amount": 70},
+					}},
+				},
+				"links": map[string]interface{}{"next": nil},
+			})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := hedera.NewMirrorClient([]string{server.URL}, 5, setup.logger, setup.cacheService)
+	result, err := client.GetBalance(context.Background(), "0.0.123", "1700000600.500000000")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, transactionPages)
+
+	// (1000 + 500 - 200 + 70 staking reward, counted once) tinybars in weibars
+	expectedHex := "0x" + new(big.Int).Mul(big.NewInt(1370), big.NewInt(10000000000)).Text(16)
+	assert.Equal(t, expectedHex, result)
+}
+
+func TestGetBalance_LatestSkipsTransfers(t *testing.T) {
+	setup := setupTest(t)
+	defer setup.ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/balances", r.URL.Path)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"timestamp": "1700000000.000000000",
+			"balances":  []map[string]interface{}{{"account": "0.0.123", "balance": 1000}},
+		})
+	}))
+	defer server.Close()
+
+	client := hedera.NewMirrorClient([]string{server.URL}, 5, setup.logger, setup.cacheService)
+	result, err := client.GetBalance(context.Background(), "0.0.123", "0")
+	assert.NoError(t, err)
+	expectedHex := "0x" + new(big.Int).Mul(big.NewInt(1000), big.NewInt(10000000000)).Text(16)
+	assert.Equal(t, expectedHex, result)
+}
+
+func TestGetBalance_TransfersError(t *testing.T) {
+	setup := setupTest(t)
+	defer setup.ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/transactions" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"timestamp": "1700000000.000000000",
+			"balances":  []map[string]interface{}{{"account": "0.0.123", "balance": 1000}},
+		})
+	}))
+	defer server.Close()
+
+	client := hedera.NewMirrorClient([]string{server.URL}, 5, setup.logger, setup.cacheService)
+	result, err := client.GetBalance(context.Background(), "0.0.123", "1700000600.000000000")
+	assert.ErrorIs(t, err, hedera.ErrUpstreamUnavailable)
+	assert.Empty(t, result)
+}
+
 func TestGetBalance_Error(t *testing.T) {
 	setup := setupTest(t)
 	defer setup.ctrl.Finish()
@@ -447,10 +1070,11 @@ func TestGetBalance_Error(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := hedera.NewMirrorClient(server.URL, 30, setup.logger, setup.cacheService)
-	result := client.GetBalance("0.0.123", "1234567890.000000000")
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+	result, err := client.GetBalance(context.Background(), "0.0.123", "1234567890.000000000")
 
-	assert.Equal(t, "0x0", result)
+	assert.Empty(t, result)
+	assert.ErrorIs(t, err, hedera.ErrUpstreamUnavailable)
 }
 
 func TestGetAccount_Success(t *testing.T) {
@@ -481,9 +1105,10 @@ func TestGetAccount_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := hedera.NewMirrorClient(server.URL, 30, setup.logger, setup.cacheService)
-	result := client.GetAccount("0.0.123", "1234567890.000000000")
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+	result, err := client.GetAccount(context.Background(), "0.0.123", "1234567890.000000000")
 
+	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	accountResponse := result.(domain.AccountResponse)
 	assert.Equal(t, "0.0.123", accountResponse.Account)
@@ -501,10 +1126,11 @@ func TestGetAccount_Error(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := hedera.NewMirrorClient(server.URL, 30, setup.logger, setup.cacheService)
-	result := client.GetAccount("0.0.123", "1234567890.000000000")
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+	result, err := client.GetAccount(context.Background(), "0.0.123", "1234567890.000000000")
 
 	assert.Nil(t, result)
+	assert.ErrorIs(t, err, hedera.ErrUpstreamUnavailable)
 }
 
 func TestPostCall(t *testing.T) {
@@ -571,12 +1197,14 @@ func TestPostCall(t *testing.T) {
 			}))
 			defer server.Close()
 
-			client := hedera.NewMirrorClient(server.URL, 5, setup.logger, setup.cacheService)
-			result := client.PostCall(tc.callObject)
+			client := hedera.NewMirrorClient([]string{server.URL}, 5, setup.logger, setup.cacheService)
+			result, err := client.PostCall(context.Background(), tc.callObject)
 
 			if tc.expectedResult == "" {
+				assert.Error(t, err)
 				assert.Nil(t, result)
 			} else {
+				assert.NoError(t, err)
 				assert.Equal(t, tc.expectedResult, result)
 			}
 		})
@@ -626,7 +1254,7 @@ func TestGetContractStateByAddressAndSlot(t *testing.T) {
 			timestampTo:    "2023-12-09T12:00:00.000Z",
 			mockResponse:   nil,
 			expectedResult: nil,
-			expectedError:  false,
+			expectedError:  true,
 			statusCode:     http.StatusNotFound,
 		},
 		{
@@ -636,7 +1264,7 @@ func TestGetContractStateByAddressAndSlot(t *testing.T) {
 			timestampTo:    "2023-12-09T12:00:00.000Z",
 			mockResponse:   nil,
 			expectedResult: nil,
-			expectedError:  false,
+			expectedError:  true,
 			statusCode:     http.StatusInternalServerError,
 		},
 		{
@@ -669,8 +1297,8 @@ func TestGetContractStateByAddressAndSlot(t *testing.T) {
 			}))
 			defer server.Close()
 
-			client := hedera.NewMirrorClient(server.URL, 5, setup.logger, setup.cacheService)
-			result, err := client.GetContractStateByAddressAndSlot(tc.address, tc.slot, tc.timestampTo)
+			client := hedera.NewMirrorClient([]string{server.URL}, 5, setup.logger, setup.cacheService)
+			result, err := client.GetContractStateByAddressAndSlot(context.Background(), tc.address, tc.slot, tc.timestampTo)
 
 			if tc.expectedError {
 				assert.Error(t, err)
@@ -753,8 +1381,8 @@ func TestGetContractResultsLogsByAddress(t *testing.T) {
 			}))
 			defer server.Close()
 
-			client := hedera.NewMirrorClient(server.URL, 5, setup.logger, setup.cacheService)
-			results, err := client.GetContractResultsLogsByAddress(tc.address, tc.queryParams)
+			client := hedera.NewMirrorClient([]string{server.URL}, 5, setup.logger, setup.cacheService)
+			results, err := client.GetContractResultsLogsByAddress(context.Background(), tc.address, tc.queryParams)
 
 			if tc.expectError {
 				assert.Error(t, err)
@@ -767,6 +1395,54 @@ func TestGetContractResultsLogsByAddress(t *testing.T) {
 	}
 }
 
+func TestGetContractResultsLogsByAddress_PageLimit(t *testing.T) {
+	setup := setupTest(t)
+	defer setup.ctrl.Finish()
+
+	// Every page links to another one
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := requests.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"logs":  []map[string]interface{}{{"address": "0x123", "index": page}},
+			"links": map[string]interface{}{"next": fmt.Sprintf("/api/v1/contracts/0x123/results/logs?page=%d", page+1)},
+		})
+	}))
+	defer server.Close()
+
+	client := hedera.NewMirrorClient([]string{server.URL}, 5, setup.logger, setup.cacheService)
+	client.MaxPages = 3
+
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		maxPages int
+	}{
+		{"deployment budget", context.Background(), 3},
+		{"lower request budget", hedera.WithMaxPages(context.Background(), 2), 2},
+		{"higher request budget is ignored", hedera.WithMaxPages(context.Background(), 50), 3},
+		{"invalid request budget is ignored", hedera.WithMaxPages(context.Background(), -1), 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests.Store(0)
+			logs, err := client.GetContractResultsLogsByAddress(tc.ctx, "0x123", nil)
+
+			assert.Nil(t, logs)
+			assert.ErrorIs(t, err, hedera.ErrPageLimitExceeded)
+			var pageLimitErr *hedera.PageLimitError
+			if assert.ErrorAs(t, err, &pageLimitErr) {
+				assert.Equal(t, tc.maxPages, pageLimitErr.MaxPages)
+			}
+			assert.Equal(t, int32(tc.maxPages), requests.Load())
+		})
+	}
+
+	_, err := client.GetContractResultsBySender(hedera.WithMaxPages(context.Background(), 1), "0x123", "1234567890.000000000")
+	assert.ErrorIs(t, err, hedera.ErrPageLimitExceeded)
+}
+
 func TestGetContractResultsLogsWithRetry(t *testing.T) {
 	setup := setupTest(t)
 	defer setup.ctrl.Finish()
@@ -826,6 +1502,17 @@ func TestGetContractResultsLogsWithRetry(t *testing.T) {
 			expectedResult: nil,
 			expectError:    true,
 			statusCode:     http.StatusInternalServerError,
+			expectedCalls:  2,
+		},
+		{
+			name: "Bad request is not retried",
+			queryParams: map[string]interface{}{
+				"timestamp.gte": "1640995200.000000000",
+			},
+			mockResponses:  []interface{}{map[string]interface{}{"error": "Invalid parameter"}},
+			expectedResult: nil,
+			expectError:    true,
+			statusCode:     http.StatusBadRequest,
 			expectedCalls:  1,
 		},
 	}
@@ -845,8 +1532,9 @@ func TestGetContractResultsLogsWithRetry(t *testing.T) {
 			}))
 			defer server.Close()
 
-			client := hedera.NewMirrorClient(server.URL, 5, setup.logger, setup.cacheService)
-			result, err := client.GetContractResultsLogsWithRetry(tc.queryParams)
+			client := hedera.NewMirrorClient([]string{server.URL}, 5, setup.logger, setup.cacheService)
+			client.Retry = hedera.RetryPolicy{BaseDelay: time.Millisecond}
+			result, err := client.GetContractResultsLogsWithRetry(context.Background(), tc.queryParams)
 
 			if tc.expectError {
 				assert.Error(t, err)
@@ -945,8 +1633,8 @@ func TestGetAccountById(t *testing.T) {
 			}))
 			defer server.Close()
 
-			client := hedera.NewMirrorClient(server.URL, 5, setup.logger, setup.cacheService)
-			result, err := client.GetAccountById(tc.accountId)
+			client := hedera.NewMirrorClient([]string{server.URL}, 5, setup.logger, setup.cacheService)
+			result, err := client.GetAccountById(context.Background(), tc.accountId)
 
 			if tc.expectError {
 				assert.Error(t, err)
@@ -1034,8 +1722,8 @@ func TestGetContractById(t *testing.T) {
 			}))
 			defer server.Close()
 
-			client := hedera.NewMirrorClient(server.URL, 5, setup.logger, setup.cacheService)
-			result, err := client.GetContractById(tc.contractId)
+			client := hedera.NewMirrorClient([]string{server.URL}, 5, setup.logger, setup.cacheService)
+			result, err := client.GetContractById(context.Background(), tc.contractId)
 
 			if tc.expectError {
 				assert.Error(t, err)
@@ -1053,6 +1741,146 @@ func TestGetContractById(t *testing.T) {
 	}
 }
 
+func TestGetContractByIdWithRetry(t *testing.T) {
+	testCases := []struct {
+		name             string
+		statusCodes      []int
+		expectedRequests int32
+		expectedErr      error
+	}{
+		{
+			name:             "Retries after upstream failure",
+			statusCodes:      []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedRequests: 2,
+		},
+		{
+			name:             "Gives up after the last attempt",
+			statusCodes:      []int{http.StatusTooManyRequests, http.StatusTooManyRequests},
+			expectedRequests: 2,
+			expectedErr:      hedera.ErrRateLimited,
+		},
+		{
+			name:             "Does not retry missing contracts",
+			statusCodes:      []int{http.StatusNotFound},
+			expectedRequests: 1,
+			expectedErr:      hedera.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			setup := setupTest(t)
+			defer setup.ctrl.Finish()
+
+			setup.cacheService.EXPECT().
+				Get(gomock.Any(), "getContractById_0.0.123", gomock.Any()).
+				Return(ErrCacheMiss).
+				Times(int(tc.expectedRequests))
+			if tc.expectedErr == nil {
+				setup.cacheService.EXPECT().
+					Set(gomock.Any(), "getContractById_0.0.123", gomock.Any(), gomock.Any()).
+					Return(nil)
+			}
+
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				statusCode := tc.statusCodes[requests.Add(1)-1]
+				w.WriteHeader(statusCode)
+				if statusCode == http.StatusOK {
+					json.NewEncoder(w).Encode(domain.ContractResponse{ContractID: "0.0.123"})
+				}
+			}))
+			defer server.Close()
+
+			client := hedera.NewMirrorClient([]string{server.URL}, 5, setup.logger, setup.cacheService)
+			client.Retry = hedera.RetryPolicy{BaseDelay: time.Millisecond}
+			result, err := client.GetContractByIdWithRetry(context.Background(), "0.0.123")
+
+			assert.Equal(t, tc.expectedRequests, requests.Load())
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "0.0.123", result.ContractID)
+			}
+		})
+	}
+}
+
+func TestGetContractByIdWithRetry_Policy(t *testing.T) {
+	setup := setupTest(t)
+	defer setup.ctrl.Finish()
+	setup.cacheService.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(ErrCacheMiss).AnyTimes()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := hedera.NewMirrorClient([]string{server.URL}, 5, setup.logger, setup.cacheService)
+
+	// Every configured attempt is made while there is time
+	client.Retry = hedera.RetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+	_, err := client.GetContractByIdWithRetry(context.Background(), "0.0.123")
+	assert.ErrorIs(t, err, hedera.ErrUpstreamUnavailable)
+	assert.Equal(t, int32(4), requests.Load())
+
+	// A backoff that would outlast the deadline of the request is not waited for
+	requests.Store(0)
+	client.Retry = hedera.RetryPolicy{MaxAttempts: 4, BaseDelay: time.Second}
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = client.GetContractByIdWithRetry(ctx, "0.0.123")
+	assert.ErrorIs(t, err, hedera.ErrUpstreamUnavailable)
+	assert.Equal(t, int32(1), requests.Load())
+	assert.Less(t, time.Since(start), 300*time.Millisecond)
+}
+
+func TestGetContractByIdWithRetry_RetryAfter(t *testing.T) {
+	setup := setupTest(t)
+	defer setup.ctrl.Finish()
+	setup.cacheService.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(ErrCacheMiss).AnyTimes()
+	setup.cacheService.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"contract_id": "0.0.123", "evm_address": "0x123"})
+	}))
+	defer server.Close()
+
+	client := hedera.NewMirrorClient([]string{server.URL}, 5, setup.logger, setup.cacheService)
+	client.Retry = hedera.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}
+	var retryAfter []time.Duration
+	client.OnRateLimited = func(wait time.Duration) { retryAfter = append(retryAfter, wait) }
+
+	// The retry waits as long as the mirror node asked instead of the backoff
+	start := time.Now()
+	contract, err := client.GetContractByIdWithRetry(context.Background(), "0.0.123")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "0x123", contract.EvmAddress)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+	assert.Equal(t, []time.Duration{time.Second}, retryAfter)
+
+	// A wait that would outlast the deadline of the request is not attempted
+	requests.Store(0)
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	_, err = client.GetContractByIdWithRetry(ctx, "0.0.123")
+	assert.ErrorIs(t, err, hedera.ErrRateLimited)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
 func TestGetContractResultWithRetry(t *testing.T) {
 	setup := setupTest(t)
 	defer setup.ctrl.Finish()
@@ -1061,7 +1889,7 @@ func TestGetContractResultWithRetry(t *testing.T) {
 		name           string
 		queryParams    map[string]interface{}
 		mockResponses  []interface{}
-		expectedResult *domain.ContractResults
+		expectedResult *domain.ContractResult
 		expectError    bool
 		statusCode     int
 		expectedCalls  int
@@ -1073,12 +1901,12 @@ func TestGetContractResultWithRetry(t *testing.T) {
 			},
 			mockResponses: []interface{}{
 				struct {
-					Results []domain.ContractResults `json:"results"`
+					Results []domain.ContractResult `json:"results"`
 					Links   struct {
 						Next *string `json:"next"`
 					} `json:"links"`
 				}{
-					Results: []domain.ContractResults{
+					Results: []domain.ContractResult{
 						{
 							Address:          "0x1234567890123456789012345678901234567890",
 							Hash:             "0xtx1",
@@ -1090,7 +1918,7 @@ func TestGetContractResultWithRetry(t *testing.T) {
 					},
 				},
 			},
-			expectedResult: &domain.ContractResults{
+			expectedResult: &domain.ContractResult{
 				Address:          "0x1234567890123456789012345678901234567890",
 				Hash:             "0xtx1",
 				Result:           "SUCCESS",
@@ -1109,12 +1937,12 @@ func TestGetContractResultWithRetry(t *testing.T) {
 			},
 			mockResponses: []interface{}{
 				struct {
-					Results []domain.ContractResults `json:"results"`
+					Results []domain.ContractResult `json:"results"`
 					Links   struct {
 						Next *string `json:"next"`
 					} `json:"links"`
 				}{
-					Results: []domain.ContractResults{
+					Results: []domain.ContractResult{
 						{
 							Hash:             "0xtx1",
 							Result:           "SUCCESS",
@@ -1125,12 +1953,12 @@ func TestGetContractResultWithRetry(t *testing.T) {
 					},
 				},
 				struct {
-					Results []domain.ContractResults `json:"results"`
+					Results []domain.ContractResult `json:"results"`
 					Links   struct {
 						Next *string `json:"next"`
 					} `json:"links"`
 				}{
-					Results: []domain.ContractResults{
+					Results: []domain.ContractResult{
 						{
 							Hash:             "0xtx1",
 							Result:           "SUCCESS",
@@ -1155,7 +1983,7 @@ func TestGetContractResultWithRetry(t *testing.T) {
 			expectedResult: nil,
 			expectError:    true,
 			statusCode:     http.StatusInternalServerError,
-			expectedCalls:  1,
+			expectedCalls:  2,
 		},
 	}
 
@@ -1174,8 +2002,9 @@ func TestGetContractResultWithRetry(t *testing.T) {
 			}))
 			defer server.Close()
 
-			client := hedera.NewMirrorClient(server.URL, 5, setup.logger, setup.cacheService)
-			result, err := client.GetContractResultWithRetry(tc.queryParams)
+			client := hedera.NewMirrorClient([]string{server.URL}, 5, setup.logger, setup.cacheService)
+			client.Retry = hedera.RetryPolicy{BaseDelay: time.Millisecond}
+			result, err := client.GetContractResultWithRetry(context.Background(), tc.queryParams)
 
 			if tc.expectError {
 				assert.Error(t, err)
@@ -1214,19 +2043,21 @@ func TestGetTokenById(t *testing.T) {
 			name:    "Successful token fetch",
 			tokenId: "0.0.123",
 			mockResponse: &domain.TokenResponse{
-				TokenId:     "0.0.123",
-				Name:        "Test Token",
-				Symbol:      "TST",
-				Decimals:    18,
-				TotalSupply: 1000000,
-				Type:        "FUNGIBLE_COMMON",
+				TokenId:       "0.0.123",
+				Name:          "Test Token",
+				Symbol:        "TST",
+				Decimals:      "18",
+				InitialSupply: "0",
+				MaxSupply:     "0",
+				TotalSupply:   "1000000",
+				Type:          "FUNGIBLE_COMMON",
 			},
 			expectedResult: &domain.TokenResponse{
 				TokenId:     "0.0.123",
 				Name:        "Test Token",
 				Symbol:      "TST",
-				Decimals:    18,
-				TotalSupply: 1000000,
+				Decimals:    "18",
+				TotalSupply: "1000000",
 				Type:        "FUNGIBLE_COMMON",
 			},
 			expectError: false,
@@ -1266,8 +2097,8 @@ func TestGetTokenById(t *testing.T) {
 			}))
 			defer server.Close()
 
-			client := hedera.NewMirrorClient(server.URL, 5, setup.logger, setup.cacheService)
-			result, err := client.GetTokenById(tc.tokenId)
+			client := hedera.NewMirrorClient([]string{server.URL}, 5, setup.logger, setup.cacheService)
+			result, err := client.GetTokenById(context.Background(), tc.tokenId)
 
 			if tc.expectError {
 				assert.Error(t, err)
@@ -1285,3 +2116,92 @@ func TestGetTokenById(t *testing.T) {
 		})
 	}
 }
+
+func TestGetContractsResultsActions(t *testing.T) {
+	setup := setupTest(t)
+	defer setup.ctrl.Finish()
+
+	txHash := "0x2c4c1e7cbbcd6aa8fc5c5e1b3e4bc7b8b5c6e7e2a9e2b8b2d7c9e3a0b1c2d3e4"
+
+	setup.cacheService.EXPECT().
+		Get(gomock.Any(), "getContractsResultsActions_"+txHash, gomock.Any()).
+		Return(ErrCacheMiss)
+
+	setup.cacheService.EXPECT().
+		Set(gomock.Any(), "getContractsResultsActions_"+txHash, gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/contracts/results/"+txHash+"/actions", r.URL.String())
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"actions": []map[string]interface{}{
+				{"call_depth": 0, "call_operation_type": "CALL", "call_type": "CALL", "gas": 400000, "gas_used": 5000},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+	result, err := client.GetContractsResultsActions(context.Background(), txHash)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Actions, 1)
+	assert.Equal(t, "CALL", result.Actions[0].CallOperationType)
+	assert.Equal(t, int64(5000), result.Actions[0].GasUsed)
+}
+
+func TestGetContractsResultsOpcodes(t *testing.T) {
+	setup := setupTest(t)
+	defer setup.ctrl.Finish()
+
+	txHash := "0x2c4c1e7cbbcd6aa8fc5c5e1b3e4bc7b8b5c6e7e2a9e2b8b2d7c9e3a0b1c2d3e4"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/contracts/results/"+txHash+"/opcodes?stack=true&memory=false&storage=true", r.URL.String())
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"gas":          21000,
+			"failed":       false,
+			"return_value": "0x",
+			"opcodes": []map[string]interface{}{
+				{"pc": 0, "op": "PUSH1", "gas": 21000, "gas_cost": 3, "depth": 1, "stack": []string{}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := hedera.NewMirrorClient([]string{server.URL}, 30, setup.logger, setup.cacheService)
+	result, err := client.GetContractsResultsOpcodes(context.Background(), txHash, true, false, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(21000), result.Gas)
+	assert.Len(t, result.Opcodes, 1)
+	assert.Equal(t, "PUSH1", result.Opcodes[0].Op)
+}
+
+func TestPostCall_ContractRevert(t *testing.T) {
+	setup := setupTest(t)
+	defer setup.ctrl.Finish()
+
+	revertData := "0x08c379a000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000004626f6f6d00000000000000000000000000000000000000000000000000000000"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"_status":{"messages":[{"message":"CONTRACT_REVERT_EXECUTED","detail":"boom","data":"` + revertData + `"}]}}`))
+	}))
+	defer server.Close()
+
+	client := hedera.NewMirrorClient([]string{server.URL}, 5, setup.logger, setup.cacheService)
+	result, err := client.PostCall(context.Background(), map[string]interface{}{"data": "0x123456"})
+
+	assert.Nil(t, result)
+
+	var callErr *hedera.ContractCallError
+	assert.True(t, errors.As(err, &callErr))
+	assert.True(t, callErr.IsContractRevert())
+	assert.Equal(t, http.StatusBadRequest, callErr.StatusCode)
+	assert.Equal(t, "boom", callErr.Detail)
+	assert.Equal(t, revertData, callErr.Data)
+}
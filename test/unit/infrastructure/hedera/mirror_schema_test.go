@@ -0,0 +1,101 @@
+package hedera_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
+	"github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// newerAccount is an account as a newer mirror node might serve it: with
+// ethereum_nonce renamed to nonce, without alias and with fields the relay
+// does not know.
+const newerAccount = `{
+	"account": "0.0.123",
+	"evm_address": "0x1234567890123456789012345678901234567890",
+	"nonce": 5,
+	"balance": {"balance": 9007199254740993, "timestamp": "1234567890.000000000", "tokens": [], "staked": true},
+	"hooks": []
+}`
+
+func newSchemaMirror(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/docs/openapi.yml":
+			_, _ = w.Write([]byte("openapi: 3.0.0\ninfo:\n  title: Hedera Mirror Node REST API\n  version: 0.120.0\n"))
+		default:
+			_, _ = w.Write([]byte(newerAccount))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMirrorClient_ToleratesSchemaDrift(t *testing.T) {
+	server := newSchemaMirror(t)
+	core, logs := observer.New(zap.DebugLevel)
+	client := hedera.NewMirrorClient([]string{server.URL}, 5, zap.New(core), cache.NewMemoryCache(time.Minute, time.Minute))
+	client.SchemaAliases = hedera.SchemaAliases{"ethereum_nonce": {"nonce"}}
+	client.DetectVersion(context.Background())
+
+	for _, id := range []string{"0.0.123", "0.0.124"} {
+		account, err := client.GetAccountById(context.Background(), id)
+		require.NoError(t, err)
+		assert.Equal(t, "0.0.123", account.Account)
+		assert.Equal(t, int64(5), account.EthereumNonce)
+		// Numbers survive the response being re-encoded with the renamed field
+		assert.Equal(t, int64(9007199254740993), account.Balance.Balance)
+	}
+
+	// Every difference is logged once, however many responses show it
+	renamed := logs.FilterMessage("Mirror node response field renamed, reading it under its new name").All()
+	require.Len(t, renamed, 1)
+	assert.Equal(t, zapcore.WarnLevel, renamed[0].Level)
+	assert.Equal(t, "domain.AccountResponse", renamed[0].ContextMap()["schema"])
+	assert.Equal(t, "ethereum_nonce", renamed[0].ContextMap()["field"])
+	assert.Equal(t, "nonce", renamed[0].ContextMap()["renamedTo"])
+	assert.Equal(t, "0.120.0", renamed[0].ContextMap()["mirrorNodeVersion"])
+
+	unknown := logs.FilterMessage("Mirror node response has a field the relay does not know, ignoring it")
+	assert.Equal(t, 1, unknown.FilterField(zap.String("field", "hooks")).Len())
+	assert.Equal(t, 1, unknown.FilterField(zap.String("field", "balance.staked")).Len())
+	assert.Zero(t, unknown.FilterField(zap.String("field", "nonce")).Len())
+	for _, entry := range unknown.All() {
+		assert.Equal(t, zapcore.DebugLevel, entry.Level)
+	}
+
+	missing := logs.FilterMessage("Mirror node response lacks a field the relay reads, it may have been renamed")
+	assert.Equal(t, 1, missing.FilterField(zap.String("field", "alias")).Len())
+	assert.Zero(t, missing.FilterField(zap.String("field", "ethereum_nonce")).Len())
+}
+
+func TestMirrorClient_DetectVersion(t *testing.T) {
+	server := newSchemaMirror(t)
+	client := hedera.NewMirrorClient([]string{server.URL}, 5, zap.NewNop(), cache.NewMemoryCache(time.Minute, time.Minute))
+
+	assert.Empty(t, client.Version())
+	assert.Equal(t, "0.120.0", client.DetectVersion(context.Background()))
+	assert.Equal(t, "0.120.0", client.Version())
+}
+
+func TestMirrorClient_DetectVersion_Unavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	core, logs := observer.New(zap.DebugLevel)
+	client := hedera.NewMirrorClient([]string{server.URL}, 5, zap.New(core), cache.NewMemoryCache(time.Minute, time.Minute))
+
+	assert.Empty(t, client.DetectVersion(context.Background()))
+	assert.Empty(t, client.Version())
+	assert.Equal(t, 1, logs.FilterMessage("Could not detect the mirror node version").Len())
+}
@@ -0,0 +1,89 @@
+package hedera_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hederasdk "github.com/hashgraph/hedera-sdk-go/v2"
+)
+
+func TestParsePrivateKey(t *testing.T) {
+	ed25519Key, err := hederasdk.PrivateKeyGenerateEd25519()
+	require.NoError(t, err)
+	ecdsaKey, err := hederasdk.PrivateKeyGenerateEcdsa()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		key      string
+		keyType  string
+		expected hederasdk.PrivateKey
+		detected string
+	}{
+		{"DER ED25519", ed25519Key.StringDer(), hedera.KeyTypeAuto, ed25519Key, hedera.KeyTypeED25519},
+		{"DER ECDSA", ecdsaKey.StringDer(), "", ecdsaKey, hedera.KeyTypeECDSA},
+		{"DER ECDSA with its type", ecdsaKey.StringDer(), hedera.KeyTypeECDSA, ecdsaKey, hedera.KeyTypeECDSA},
+		{"raw ED25519", hex.EncodeToString(ed25519Key.BytesRaw()), hedera.KeyTypeAuto, ed25519Key, hedera.KeyTypeED25519},
+		{"raw ECDSA with 0x", "0x" + hex.EncodeToString(ecdsaKey.BytesRaw()), hedera.KeyTypeAuto, ecdsaKey, hedera.KeyTypeECDSA},
+		{"raw ECDSA with its type", hex.EncodeToString(ecdsaKey.BytesRaw()), hedera.KeyTypeECDSA, ecdsaKey, hedera.KeyTypeECDSA},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := hedera.ParsePrivateKey(tt.key, tt.keyType)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected.PublicKey().String(), key.PublicKey().String())
+			assert.Equal(t, tt.detected, hedera.KeyTypeOf(key))
+		})
+	}
+}
+
+func TestParsePrivateKey_Invalid(t *testing.T) {
+	ed25519Key, err := hederasdk.PrivateKeyGenerateEd25519()
+	require.NoError(t, err)
+	ecdsaKey, err := hederasdk.PrivateKeyGenerateEcdsa()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		key     string
+		keyType string
+		message string
+	}{
+		{"not hex", "not-a-key", hedera.KeyTypeAuto, "not a hex encoded private key"},
+		{"too short", "0x1234", hedera.KeyTypeAuto, "not a hex encoded private key"},
+		{"ED25519 as ECDSA", ed25519Key.StringDer(), hedera.KeyTypeECDSA, "the key is DER encoded as an ED25519 key, not an ECDSA(secp256k1) one"},
+		{"ECDSA as ED25519", ecdsaKey.StringDer(), hedera.KeyTypeED25519, "the key is DER encoded as an ECDSA(secp256k1) key, not an ED25519 one"},
+		{"unknown type", ed25519Key.StringDer(), "rsa", `unknown key type "rsa"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := hedera.ParsePrivateKey(tt.key, tt.keyType)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
+
+func TestOperator_EvmAddress(t *testing.T) {
+	ecdsaKey, err := hederasdk.PrivateKeyGenerateEcdsa()
+	require.NoError(t, err)
+	operator, err := hedera.NewOperator("0.0.1001", ecdsaKey.StringDer(), "")
+	require.NoError(t, err)
+	assert.Equal(t, "0x"+ecdsaKey.PublicKey().ToEvmAddress(), operator.EvmAddress())
+
+	ed25519Key, err := hederasdk.PrivateKeyGenerateEd25519()
+	require.NoError(t, err)
+	operator, err = hedera.NewOperator("0.0.1002", ed25519Key.StringDer(), "")
+	require.NoError(t, err)
+	assert.Empty(t, operator.EvmAddress())
+
+	_, err = hedera.NewOperator("0.0.1003", "not-a-key", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid key for operator 0.0.1003")
+}
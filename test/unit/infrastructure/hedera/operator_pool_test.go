@@ -0,0 +1,125 @@
+package hedera_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hederasdk "github.com/hashgraph/hedera-sdk-go/v2"
+)
+
+func newTestOperators(t *testing.T, ids ...string) []hedera.Operator {
+	operators := make([]hedera.Operator, 0, len(ids))
+	for _, id := range ids {
+		key, err := hederasdk.PrivateKeyGenerateEd25519()
+		require.NoError(t, err)
+		operator, err := hedera.NewOperator(id, key.String(), "")
+		require.NoError(t, err)
+		operators = append(operators, operator)
+	}
+	return operators
+}
+
+func TestOperatorPool_RoundRobin(t *testing.T) {
+	pool, err := hedera.NewOperatorPool(newTestOperators(t, "0.0.1001", "0.0.1002", "0.0.1003"), hedera.RoundRobin, 0)
+	require.NoError(t, err)
+
+	var selected []string
+	for i := 0; i < 4; i++ {
+		operator, err := pool.Next()
+		require.NoError(t, err)
+		selected = append(selected, operator.AccountID.String())
+	}
+
+	assert.Equal(t, []string{"0.0.1001", "0.0.1002", "0.0.1003", "0.0.1001"}, selected)
+	assert.Equal(t, "0.0.1001", pool.Primary().AccountID.String())
+}
+
+func TestOperatorPool_LeastRecentlyUsed(t *testing.T) {
+	pool, err := hedera.NewOperatorPool(newTestOperators(t, "0.0.1001", "0.0.1002"), hedera.LeastRecentlyUsed, 0)
+	require.NoError(t, err)
+
+	first, err := pool.Next()
+	require.NoError(t, err)
+	second, err := pool.Next()
+	require.NoError(t, err)
+	third, err := pool.Next()
+	require.NoError(t, err)
+
+	assert.Equal(t, "0.0.1001", first.AccountID.String())
+	assert.Equal(t, "0.0.1002", second.AccountID.String())
+	assert.Equal(t, "0.0.1001", third.AccountID.String())
+}
+
+func TestOperatorPool_SkipsLowBalance(t *testing.T) {
+	operators := newTestOperators(t, "0.0.1001", "0.0.1002")
+	pool, err := hedera.NewOperatorPool(operators, hedera.RoundRobin, 1000)
+	require.NoError(t, err)
+
+	pool.UpdateBalance(operators[0].AccountID, 999)
+	pool.UpdateBalance(operators[1].AccountID, 5000)
+
+	for i := 0; i < 3; i++ {
+		operator, err := pool.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "0.0.1002", operator.AccountID.String())
+	}
+	assert.Equal(t, map[string]int64{"0.0.1001": 999, "0.0.1002": 5000}, pool.Balances())
+
+	pool.UpdateBalance(operators[1].AccountID, 10)
+	_, err = pool.Next()
+	assert.Error(t, err)
+}
+
+func TestOperatorPool_SetPrimaryKey(t *testing.T) {
+	pool, err := hedera.NewOperatorPool(newTestOperators(t, "0.0.1001", "0.0.1002"), hedera.RoundRobin, 0)
+	require.NoError(t, err)
+
+	rotated, err := hederasdk.PrivateKeyGenerateEcdsa()
+	require.NoError(t, err)
+	pool.SetPrimaryKey(rotated)
+
+	assert.Equal(t, rotated.String(), pool.Primary().PrivateKey.String())
+	assert.Equal(t, hedera.KeyTypeECDSA, pool.Primary().KeyType)
+	operator, err := pool.Next()
+	require.NoError(t, err)
+	assert.Equal(t, rotated.String(), operator.PrivateKey.String())
+	// Other operators keep their keys
+	assert.NotEqual(t, rotated.String(), pool.Operators()[1].PrivateKey.String())
+}
+
+func TestOperatorPool_Invalid(t *testing.T) {
+	_, err := hedera.NewOperatorPool(nil, hedera.RoundRobin, 0)
+	assert.Error(t, err)
+
+	_, err = hedera.NewOperatorPool(newTestOperators(t, "0.0.1001"), "random", 0)
+	assert.Error(t, err)
+}
+
+func TestParseOperators(t *testing.T) {
+	key, err := hederasdk.PrivateKeyGenerateEd25519()
+	require.NoError(t, err)
+	ecdsaKey, err := hederasdk.PrivateKeyGenerateEcdsa()
+	require.NoError(t, err)
+
+	operators, err := hedera.ParseOperators([]interface{}{
+		map[string]interface{}{"id": "0.0.1001", "key": key.String()},
+		map[interface{}]interface{}{"id": "0.0.1002", "key": key.String()},
+		map[string]interface{}{"id": "0.0.1003", "key": hex.EncodeToString(ecdsaKey.BytesRaw()), "keytype": "ecdsa"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, operators, 3)
+	assert.Equal(t, "0.0.1002", operators[1].AccountID.String())
+	assert.Equal(t, hedera.KeyTypeED25519, operators[1].KeyType)
+	assert.Equal(t, hedera.KeyTypeECDSA, operators[2].KeyType)
+
+	_, err = hedera.ParseOperators([]interface{}{map[string]interface{}{"id": "0.0.1001", "key": "not-a-key"}})
+	assert.Error(t, err)
+
+	operators, err = hedera.ParseOperators(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, operators)
+}
@@ -0,0 +1,83 @@
+package limiter_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmissionQueue_QueueFull(t *testing.T) {
+	queue := limiter.NewAdmissionQueue(limiter.AdmissionConfig{MaxConcurrent: 1, QueueDepth: 1, QueueTimeout: time.Second})
+	ctx := context.Background()
+
+	release, err := queue.Admit(ctx)
+	require.NoError(t, err)
+
+	admitted := make(chan error, 1)
+	go func() {
+		release, err := queue.Admit(ctx)
+		if err == nil {
+			release()
+		}
+		admitted <- err
+	}()
+	require.Eventually(t, func() bool { return queue.Waiting() == 1 }, time.Second, time.Millisecond)
+
+	// The queue holds a single waiting request
+	_, err = queue.Admit(ctx)
+	assert.ErrorIs(t, err, limiter.ErrAdmissionQueueFull)
+
+	release()
+	assert.NoError(t, <-admitted)
+	assert.Zero(t, queue.Waiting())
+}
+
+func TestAdmissionQueue_Timeout(t *testing.T) {
+	queue := limiter.NewAdmissionQueue(limiter.AdmissionConfig{MaxConcurrent: 1, QueueDepth: 10, QueueTimeout: 20 * time.Millisecond})
+	ctx := context.Background()
+
+	release, err := queue.Admit(ctx)
+	require.NoError(t, err)
+	defer release()
+
+	start := time.Now()
+	_, err = queue.Admit(ctx)
+	assert.ErrorIs(t, err, limiter.ErrAdmissionTimeout)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	// Gives up when the request is cancelled
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = queue.Admit(cancelled)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, queue.Waiting())
+}
+
+func TestAdmissionQueue_NoQueue(t *testing.T) {
+	queue := limiter.NewAdmissionQueue(limiter.AdmissionConfig{MaxConcurrent: 1, QueueDepth: 10})
+	ctx := context.Background()
+
+	release, err := queue.Admit(ctx)
+	require.NoError(t, err)
+	// Without a queue timeout requests fail at once while every slot is busy
+	_, err = queue.Admit(ctx)
+	assert.ErrorIs(t, err, limiter.ErrAdmissionQueueFull)
+
+	release()
+	release, err = queue.Admit(ctx)
+	require.NoError(t, err)
+	release()
+}
+
+func TestAdmissionQueue_Nil(t *testing.T) {
+	var queue *limiter.AdmissionQueue
+	for i := 0; i < 3; i++ {
+		_, err := queue.Admit(context.Background())
+		assert.NoError(t, err)
+	}
+	assert.Zero(t, queue.Waiting())
+}
@@ -0,0 +1,328 @@
+package limiter_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestAPIKeyStore_Config(t *testing.T) {
+	store := limiter.NewAPIKeyStore([]interface{}{
+		map[interface{}]interface{}{"key": "FREE-KEY", "tier": "free"},
+		map[string]interface{}{"key": "OLD-KEY", "tier": "premium", "revokedAt": "2020-01-01T00:00:00Z"},
+		map[string]interface{}{"key": "FUTURE-KEY", "tier": "premium", "createdAt": time.Now().Add(time.Hour).Format(time.RFC3339)},
+	})
+
+	_, tier, ok := store.GetTierForKey("FREE-KEY")
+	assert.True(t, ok)
+	assert.Equal(t, "free", tier)
+
+	_, _, ok = store.GetTierForKey("OLD-KEY")
+	assert.False(t, ok, "revoked keys are rejected")
+	_, _, ok = store.GetTierForKey("FUTURE-KEY")
+	assert.False(t, ok, "keys are rejected before their creation time")
+	_, _, ok = store.GetTierForKey("UNKNOWN")
+	assert.False(t, ok)
+
+	// Keys are kept and sorted by hash only
+	keys := store.Keys()
+	require.Len(t, keys, 3)
+	assert.Equal(t, limiter.HashAPIKey("FREE-KEY"), keys[2].KeyHash)
+	assert.Empty(t, keys[2].Key)
+	assert.NotNil(t, keys[1].RevokedAt)
+}
+
+func TestAPIKeyStore_HashedAndExpiringKeys(t *testing.T) {
+	store := limiter.NewAPIKeyStore([]interface{}{
+		map[string]interface{}{"keyHash": strings.ToUpper(limiter.HashAPIKey("HASHED-KEY")), "tier": "free"},
+		map[string]interface{}{"key": "EXPIRED-KEY", "tier": "free", "expiresAt": "2020-01-01T00:00:00Z"},
+		map[string]interface{}{"key": "EXPIRING-KEY", "tier": "premium", "expiresAt": time.Now().Add(time.Hour).Format(time.RFC3339)},
+	})
+
+	keyHash, tier, ok := store.GetTierForKey("HASHED-KEY")
+	assert.True(t, ok)
+	assert.Equal(t, "free", tier)
+	assert.Equal(t, limiter.HashAPIKey("HASHED-KEY"), keyHash)
+
+	_, _, ok = store.GetTierForKey("EXPIRED-KEY")
+	assert.False(t, ok, "keys are rejected from their expiry date")
+	_, tier, ok = store.GetTierForKey("EXPIRING-KEY")
+	assert.True(t, ok)
+	assert.Equal(t, "premium", tier)
+
+	// The hash itself is not a key
+	_, _, ok = store.GetTierForKey(limiter.HashAPIKey("HASHED-KEY"))
+	assert.False(t, ok)
+}
+
+func TestParseAPIKeys_InvalidKeyHash(t *testing.T) {
+	_, err := limiter.ParseAPIKeys([]interface{}{
+		map[string]interface{}{"keyHash": "not-a-hash", "tier": "free"},
+	})
+	assert.Error(t, err)
+}
+
+func TestParseAPIKeys_InvalidTimestamp(t *testing.T) {
+	_, err := limiter.ParseAPIKeys([]interface{}{
+		map[string]interface{}{"key": "KEY", "tier": "free", "revokedAt": "yesterday"},
+	})
+	assert.Error(t, err)
+}
+
+func TestAPIKeyStore_FileReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`apiKeys:
+  - key: "FREE-KEY"
+    tier: "free"
+`), 0o600))
+
+	store, err := limiter.NewFileAPIKeyStore(path, 10*time.Millisecond, zap.NewNop())
+	require.NoError(t, err)
+
+	_, tier, ok := store.GetTierForKey("FREE-KEY")
+	assert.True(t, ok)
+	assert.Equal(t, "free", tier)
+
+	require.NoError(t, os.WriteFile(path, []byte(`apiKeys:
+  - key: "FREE-KEY"
+    tier: "free"
+    revokedAt: 2020-01-01T00:00:00Z
+  - key: "NEW-KEY"
+    tier: "premium"
+`), 0o600))
+	// Make sure the modification time moves even on coarse-grained filesystems
+	future := time.Now().Add(time.Second)
+	require.NoError(t, os.Chtimes(path, future, future))
+
+	assert.Eventually(t, func() bool {
+		_, tier, ok := store.GetTierForKey("NEW-KEY")
+		return ok && tier == "premium"
+	}, time.Second, 10*time.Millisecond)
+
+	_, _, ok = store.GetTierForKey("FREE-KEY")
+	assert.False(t, ok)
+}
+
+func TestAPIKeyStore_FileMissing(t *testing.T) {
+	_, err := limiter.NewFileAPIKeyStore(filepath.Join(t.TempDir(), "missing.yaml"), time.Minute, zap.NewNop())
+	assert.Error(t, err)
+}
+
+// closedPostgresDSN returns the DSN of a PostgreSQL server on a port nothing
+// listens on.
+func closedPostgresDSN(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+	return "postgres://relay:secret@" + addr + "/hederium?sslmode=disable&connect_timeout=2"
+}
+
+func TestOpenAPIKeyStore_SQLReachesTheDatabase(t *testing.T) {
+	// The driver is linked in, so building the store from config gets as far
+	// as connecting to the database
+	_, err := limiter.OpenAPIKeyStore(limiter.APIKeyStoreConfig{
+		Backend: "sql",
+		Driver:  limiter.PostgresDriver,
+		DSN:     closedPostgresDSN(t),
+		Table:   "api_keys",
+	}, zap.NewNop())
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "unknown driver")
+	var opErr *net.OpError
+	assert.ErrorAs(t, err, &opErr)
+}
+
+func TestOpenAPIKeyStore_UnsupportedDriver(t *testing.T) {
+	_, err := limiter.OpenAPIKeyStore(limiter.APIKeyStoreConfig{Backend: "sql", Driver: "mysql", DSN: "relay@/hederium", Table: "api_keys"}, zap.NewNop())
+	assert.ErrorContains(t, err, `unsupported sql driver "mysql"`)
+
+	_, err = limiter.OpenAPIKeyStore(limiter.APIKeyStoreConfig{Backend: "sql", Table: "api_keys"}, zap.NewNop())
+	assert.ErrorContains(t, err, "missing sql data source name")
+}
+
+func TestOpenAPIKeyStore_Config(t *testing.T) {
+	store, err := limiter.OpenAPIKeyStore(limiter.APIKeyStoreConfig{
+		Keys: []interface{}{map[string]interface{}{"key": "FREE-KEY", "tier": "free"}},
+	}, zap.NewNop())
+	require.NoError(t, err)
+	_, tier, ok := store.GetTierForKey("FREE-KEY")
+	assert.True(t, ok)
+	assert.Equal(t, "free", tier)
+
+	_, err = limiter.OpenAPIKeyStore(limiter.APIKeyStoreConfig{Backend: "redis"}, zap.NewNop())
+	assert.Error(t, err)
+}
+
+func TestReloadAPIKeys(t *testing.T) {
+	store := limiter.NewAPIKeyStore([]interface{}{
+		map[string]interface{}{"key": "OLD-KEY", "tier": "free"},
+	})
+
+	replaced, err := limiter.ReloadAPIKeys(store, []interface{}{
+		map[string]interface{}{"key": "NEW-KEY", "tier": "premium"},
+	})
+	require.NoError(t, err)
+	assert.True(t, replaced)
+
+	_, _, exists := store.GetTierForKey("OLD-KEY")
+	assert.False(t, exists)
+	_, tier, exists := store.GetTierForKey("NEW-KEY")
+	assert.True(t, exists)
+	assert.Equal(t, "premium", tier)
+
+	// Invalid lists leave the keys as they were
+	_, err = limiter.ReloadAPIKeys(store, []interface{}{
+		map[string]interface{}{"key": "BAD-KEY", "tier": "free", "createdAt": "yesterday"},
+	})
+	assert.Error(t, err)
+	_, _, exists = store.GetTierForKey("NEW-KEY")
+	assert.True(t, exists)
+}
+
+// keyTable is a database/sql connector answering the queries of the SQL API
+// key store with fixed results.
+type keyTable map[string]keyTableResult
+
+type keyTableResult struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (t keyTable) Connect(context.Context) (driver.Conn, error) { return keyTableConn(t), nil }
+func (t keyTable) Driver() driver.Driver                        { return nil }
+
+type keyTableConn keyTable
+
+func (c keyTableConn) Prepare(query string) (driver.Stmt, error) {
+	result, ok := c[query]
+	if !ok {
+		return nil, fmt.Errorf("unexpected query %q", query)
+	}
+	return keyTableStmt(result), nil
+}
+func (c keyTableConn) Close() error { return nil }
+func (c keyTableConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions not supported")
+}
+
+type keyTableStmt keyTableResult
+
+func (s keyTableStmt) Close() error  { return nil }
+func (s keyTableStmt) NumInput() int { return 0 }
+func (s keyTableStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec not supported")
+}
+func (s keyTableStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &keyTableRows{result: keyTableResult(s)}, nil
+}
+
+type keyTableRows struct {
+	result keyTableResult
+	next   int
+}
+
+func (r *keyTableRows) Columns() []string { return r.result.columns }
+func (r *keyTableRows) Close() error      { return nil }
+func (r *keyTableRows) Next(dest []driver.Value) error {
+	if r.next == len(r.result.rows) {
+		return io.EOF
+	}
+	copy(dest, r.result.rows[r.next])
+	r.next++
+	return nil
+}
+
+func TestSQLAPIKeyStore(t *testing.T) {
+	revoked := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name    string
+		columns []string
+		query   string
+		rows    [][]driver.Value
+	}{
+		{
+			name:    "Hashed keys",
+			columns: []string{"key_hash", "tier", "created_at", "revoked_at", "expires_at"},
+			query:   "SELECT key_hash, NULL, tier, created_at, revoked_at, expires_at FROM api_keys",
+			rows: [][]driver.Value{
+				{limiter.HashAPIKey("HASHED-KEY"), nil, "premium", nil, nil, nil},
+				{limiter.HashAPIKey("OLD-KEY"), nil, "free", nil, revoked, nil},
+			},
+		},
+		{
+			name:    "Table being migrated to hashed keys",
+			columns: []string{"api_key", "key_hash", "tier", "created_at", "revoked_at", "expires_at"},
+			query:   "SELECT key_hash, api_key, tier, created_at, revoked_at, expires_at FROM api_keys",
+			rows: [][]driver.Value{
+				{strings.ToUpper(limiter.HashAPIKey("HASHED-KEY")), nil, "premium", nil, nil, nil},
+				{nil, "OLD-KEY", "free", nil, revoked, nil},
+				{"", "PLAIN-KEY", "free", nil, nil, nil},
+			},
+		},
+		{
+			name:    "Plain text keys",
+			columns: []string{"api_key", "tier", "created_at", "revoked_at", "expires_at"},
+			query:   "SELECT NULL, api_key, tier, created_at, revoked_at, expires_at FROM api_keys",
+			rows: [][]driver.Value{
+				{nil, "HASHED-KEY", "premium", nil, nil, nil},
+				{nil, "PLAIN-KEY", "free", nil, nil, nil},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := sql.OpenDB(keyTable{
+				"SELECT * FROM api_keys WHERE 1 = 0": {columns: tc.columns},
+				tc.query:                             {columns: []string{"key_hash", "api_key", "tier", "created_at", "revoked_at", "expires_at"}, rows: tc.rows},
+			})
+			defer func() { _ = db.Close() }()
+
+			store, err := limiter.NewSQLAPIKeyStore(db, "api_keys", time.Hour, zap.NewNop())
+			require.NoError(t, err)
+
+			_, tier, ok := store.GetTierForKey("HASHED-KEY")
+			assert.True(t, ok)
+			assert.Equal(t, "premium", tier)
+
+			_, _, ok = store.GetTierForKey("OLD-KEY")
+			assert.False(t, ok)
+
+			_, tier, ok = store.GetTierForKey("PLAIN-KEY")
+			assert.Equal(t, tc.name != "Hashed keys", ok)
+			if ok {
+				assert.Equal(t, "free", tier)
+			}
+
+			// Only hashes are kept
+			for _, key := range store.Keys() {
+				assert.Empty(t, key.Key)
+			}
+		})
+	}
+}
+
+func TestSQLAPIKeyStore_NoKeyColumn(t *testing.T) {
+	db := sql.OpenDB(keyTable{
+		"SELECT * FROM api_keys WHERE 1 = 0": {columns: []string{"key", "tier"}},
+	})
+	defer func() { _ = db.Close() }()
+
+	_, err := limiter.NewSQLAPIKeyStore(db, "api_keys", time.Hour, zap.NewNop())
+	assert.ErrorContains(t, err, "neither a key_hash nor an api_key column")
+}
@@ -0,0 +1,73 @@
+package limiter_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodConcurrency_Reject(t *testing.T) {
+	// viper lower-cases the method names read from the config
+	concurrency := limiter.NewMethodConcurrency(limiter.ConcurrencyConfig{
+		Limits: map[string]int{"eth_getlogs": 2, "eth_call": 0},
+	})
+	ctx := context.Background()
+
+	release1, ok := concurrency.Acquire(ctx, "eth_getLogs")
+	require.True(t, ok)
+	release2, ok := concurrency.Acquire(ctx, "eth_getLogs")
+	require.True(t, ok)
+	_, ok = concurrency.Acquire(ctx, "eth_getLogs")
+	assert.False(t, ok)
+
+	// Other methods are not affected, and a zero limit means no limit
+	for i := 0; i < 5; i++ {
+		_, ok = concurrency.Acquire(ctx, "eth_call")
+		assert.True(t, ok)
+		_, ok = concurrency.Acquire(ctx, "eth_blockNumber")
+		assert.True(t, ok)
+	}
+
+	release1()
+	release3, ok := concurrency.Acquire(ctx, "eth_getLogs")
+	assert.True(t, ok)
+	release2()
+	release3()
+}
+
+func TestMethodConcurrency_Queue(t *testing.T) {
+	concurrency := limiter.NewMethodConcurrency(limiter.ConcurrencyConfig{
+		Limits:       map[string]int{"eth_getLogs": 1},
+		QueueTimeout: time.Second,
+	})
+	ctx := context.Background()
+
+	release, ok := concurrency.Acquire(ctx, "eth_getLogs")
+	require.True(t, ok)
+	time.AfterFunc(20*time.Millisecond, release)
+
+	// Waits for the slot to be released
+	start := time.Now()
+	release, ok = concurrency.Acquire(ctx, "eth_getLogs")
+	require.True(t, ok)
+	assert.Less(t, time.Since(start), time.Second)
+
+	// Gives up when the request is cancelled
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, ok = concurrency.Acquire(cancelled, "eth_getLogs")
+	assert.False(t, ok)
+	release()
+}
+
+func TestMethodConcurrency_Nil(t *testing.T) {
+	var concurrency *limiter.MethodConcurrency
+
+	release, ok := concurrency.Acquire(context.Background(), "eth_getLogs")
+	assert.True(t, ok)
+	release()
+}
@@ -0,0 +1,200 @@
+package limiter_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLimiter(operatorBudget int, resetWindow time.Duration) *limiter.TieredLimiter {
+	cfg := map[string]interface{}{
+		"free": map[interface{}]interface{}{
+			"requestsPerMinute": 2,
+			"hbarLimit":         1,
+		},
+	}
+	return limiter.NewTieredLimiter(cfg, operatorBudget, resetWindow)
+}
+
+func TestCheckLimits(t *testing.T) {
+	l := newTestLimiter(10, 0)
+
+	assert.True(t, l.CheckLimits("key", "free"))
+	assert.True(t, l.CheckLimits("key", "free"))
+	assert.False(t, l.CheckLimits("key", "free"))
+	assert.False(t, l.CheckLimits("key", "unknown"))
+}
+
+func TestAllow_ReportsWindow(t *testing.T) {
+	l := newTestLimiter(10, 0)
+
+	status, ok := l.Allow("key", "free")
+	assert.True(t, ok)
+	assert.Equal(t, 2, status.Limit)
+	assert.Equal(t, 1, status.Remaining)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), status.Reset, time.Second)
+
+	status, ok = l.Allow("key", "free")
+	assert.True(t, ok)
+	assert.Equal(t, 0, status.Remaining)
+
+	rejected, ok := l.Allow("key", "free")
+	assert.False(t, ok)
+	assert.Equal(t, 0, rejected.Remaining)
+	assert.Equal(t, status.Reset, rejected.Reset, "the window does not move while requests are refused")
+}
+
+func TestAllowMethod_ReadAndWriteBuckets(t *testing.T) {
+	l := limiter.NewTieredLimiter(map[string]interface{}{
+		"free": map[string]interface{}{
+			"requestsperminute":      100,
+			"hbarlimit":              1,
+			"readrequestsperminute":  2,
+			"writerequestsperminute": 1,
+		},
+		"premium": map[string]interface{}{"requestsperminute": 100, "hbarlimit": 1},
+	}, 10, 0)
+
+	_, ok := l.AllowMethod("key", "free", "eth_call")
+	assert.True(t, ok)
+	status, ok := l.AllowMethod("key", "free", "eth_getBalance")
+	assert.True(t, ok)
+	assert.Equal(t, 2, status.Limit)
+	assert.Equal(t, 0, status.Remaining)
+	_, ok = l.AllowMethod("key", "free", "eth_call")
+	assert.False(t, ok, "reads are limited once the read bucket is used up")
+
+	_, ok = l.AllowMethod("key", "free", "eth_sendRawTransaction")
+	assert.True(t, ok, "writes have a bucket of their own")
+	rejected, ok := l.AllowMethod("key", "free", "eth_sendRawTransaction")
+	assert.False(t, ok)
+	assert.Equal(t, 1, rejected.Limit)
+	assert.True(t, rejected.RetryAfter() >= 1 && rejected.RetryAfter() <= 60)
+
+	_, ok = l.AllowMethod("other-key", "free", "eth_sendRawTransaction")
+	assert.True(t, ok, "buckets are kept per key")
+	assert.Equal(t, 0, l.RequestCount("key"), "buckets do not count against requestsPerMinute")
+
+	for range 5 {
+		_, ok = l.AllowMethod("key", "premium", "eth_call")
+		assert.True(t, ok, "tiers without buckets only limit requests")
+	}
+	_, ok = l.AllowMethod("key", "unknown", "eth_call")
+	assert.True(t, ok)
+}
+
+func TestDeductHbarUsage_PerKeyLimit(t *testing.T) {
+	l := newTestLimiter(10, 0)
+
+	assert.True(t, l.DeductHbarUsage("key", "free", limiter.TinybarsPerHbar/2))
+	assert.True(t, l.DeductHbarUsage("key", "free", limiter.TinybarsPerHbar/2))
+	assert.False(t, l.DeductHbarUsage("key", "free", 1), "free tier limit of 1 HBAR is spent")
+	assert.Equal(t, int64(limiter.TinybarsPerHbar), l.UserHbarUsage("key"))
+
+	// Other keys and the operator budget are unaffected
+	assert.True(t, l.DeductHbarUsage("other-key", "free", limiter.TinybarsPerHbar))
+	assert.Equal(t, int64(8*limiter.TinybarsPerHbar), l.OperatorHbarRemaining())
+}
+
+func TestDeductHbarUsage_OperatorBudget(t *testing.T) {
+	l := newTestLimiter(1, 0)
+
+	// Without an API key only the operator budget applies
+	assert.True(t, l.DeductHbarUsage("", "", limiter.TinybarsPerHbar))
+	assert.False(t, l.DeductHbarUsage("", "", 1))
+	assert.Equal(t, int64(0), l.OperatorHbarRemaining())
+}
+
+func TestDeductHbarUsage_UnknownTier(t *testing.T) {
+	l := newTestLimiter(10, 0)
+
+	assert.False(t, l.DeductHbarUsage("key", "unknown", 1))
+	assert.Equal(t, int64(10*limiter.TinybarsPerHbar), l.OperatorHbarRemaining())
+}
+
+func TestDeductHbarUsage_ResetWindow(t *testing.T) {
+	l := newTestLimiter(1, 50*time.Millisecond)
+
+	assert.True(t, l.DeductHbarUsage("key", "free", limiter.TinybarsPerHbar))
+	assert.False(t, l.DeductHbarUsage("key", "free", 1))
+
+	time.Sleep(60 * time.Millisecond)
+
+	assert.Equal(t, int64(0), l.UserHbarUsage("key"))
+	assert.Equal(t, int64(limiter.TinybarsPerHbar), l.OperatorHbarRemaining())
+	assert.True(t, l.DeductHbarUsage("key", "free", limiter.TinybarsPerHbar))
+}
+
+func TestAPIKeyFromContext(t *testing.T) {
+	apiKey, tier := limiter.APIKeyFromContext(context.Background())
+	assert.Empty(t, apiKey)
+	assert.Empty(t, tier)
+
+	ctx := limiter.WithAPIKey(context.Background(), "key", "premium")
+	apiKey, tier = limiter.APIKeyFromContext(ctx)
+	assert.Equal(t, "key", apiKey)
+	assert.Equal(t, "premium", tier)
+}
+
+func TestSetTier(t *testing.T) {
+	l := newTestLimiter(10, 0)
+
+	assert.True(t, l.CheckLimits("key", "free"))
+	assert.Equal(t, 1, l.RequestCount("key"))
+
+	l.SetTier("free", limiter.TierConfig{RequestsPerMinute: 1, HbarLimit: 1})
+	assert.False(t, l.CheckLimits("key", "free"), "lowered limit applies to the current window")
+
+	l.SetTier("premium", limiter.TierConfig{RequestsPerMinute: 5, HbarLimit: 2})
+	assert.True(t, l.CheckLimits("other-key", "premium"))
+	assert.Equal(t, map[string]limiter.TierConfig{
+		"free":    {RequestsPerMinute: 1, HbarLimit: 1},
+		"premium": {RequestsPerMinute: 5, HbarLimit: 2},
+	}, l.Tiers())
+}
+
+func TestMethodAllowed(t *testing.T) {
+	// viper lower-cases nested keys and decodes them into string-keyed maps
+	l := limiter.NewTieredLimiter(map[string]interface{}{
+		"free": map[string]interface{}{
+			"requestsperminute": 100,
+			"hbarlimit":         1,
+			"deniedmethods":     []interface{}{"debug_*", "eth_sendRawTransaction"},
+		},
+		"readonly": map[interface{}]interface{}{
+			"requestsPerMinute": 100,
+			"hbarLimit":         0,
+			"allowedMethods":    []interface{}{"eth_*", "net_version"},
+			"deniedMethods":     []interface{}{"eth_sendRawTransaction"},
+		},
+	}, 10, 0)
+
+	assert.Equal(t, 100, l.Tiers()["free"].RequestsPerMinute)
+
+	assert.True(t, l.MethodAllowed("free", "eth_call"))
+	assert.False(t, l.MethodAllowed("free", "debug_traceTransaction"))
+	assert.False(t, l.MethodAllowed("free", "eth_sendRawTransaction"))
+
+	assert.True(t, l.MethodAllowed("readonly", "eth_getBalance"))
+	assert.True(t, l.MethodAllowed("readonly", "net_version"))
+	assert.False(t, l.MethodAllowed("readonly", "net_listening"))
+	assert.False(t, l.MethodAllowed("readonly", "eth_sendRawTransaction"))
+
+	assert.True(t, l.MethodAllowed("unknown", "debug_traceTransaction"))
+}
+
+func TestReplaceTiers(t *testing.T) {
+	l := newTestLimiter(10, 0)
+	l.ReplaceTiers(map[string]interface{}{
+		"premium": map[string]interface{}{"requestsPerMinute": 5, "hbarLimit": 2},
+	})
+
+	tiers := l.Tiers()
+	assert.NotContains(t, tiers, "free")
+	assert.Equal(t, 5, tiers["premium"].RequestsPerMinute)
+	assert.False(t, l.CheckLimits("key", "free"), "removed tiers refuse their keys")
+	assert.True(t, l.CheckLimits("key", "premium"))
+}
@@ -0,0 +1,90 @@
+package limiter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestOverloadController_Goroutines(t *testing.T) {
+	controller := limiter.NewOverloadController(limiter.OverloadConfig{
+		CheckInterval:      5 * time.Millisecond,
+		MaxGoroutines:      1,
+		LowPriorityMethods: []string{"debug_*", "eth_getLogs"},
+		MaxLogsBlockRange:  100,
+	}, zap.NewNop())
+	defer controller.Stop()
+
+	assert.Eventually(t, controller.Overloaded, time.Second, time.Millisecond)
+	assert.True(t, controller.LowPriority("debug_traceTransaction"))
+	assert.True(t, controller.LowPriority("eth_getLogs"))
+	assert.False(t, controller.LowPriority("eth_sendRawTransaction"))
+	assert.Equal(t, int64(100), controller.MaxLogsBlockRange())
+}
+
+func TestOverloadController_MirrorErrorRate(t *testing.T) {
+	controller := limiter.NewOverloadController(limiter.OverloadConfig{
+		CheckInterval:      20 * time.Millisecond,
+		MaxMirrorErrorRate: 0.5,
+		MinMirrorRequests:  4,
+	}, zap.NewNop())
+	defer controller.Stop()
+
+	// Too few requests for the rate to count
+	controller.RecordMirrorResult(true)
+	controller.RecordMirrorResult(true)
+	time.Sleep(50 * time.Millisecond)
+	assert.False(t, controller.Overloaded())
+
+	assert.Eventually(t, func() bool {
+		for i := 0; i < 4; i++ {
+			controller.RecordMirrorResult(i > 0)
+		}
+		return controller.Overloaded()
+	}, time.Second, 5*time.Millisecond)
+
+	// Once requests succeed again the relay recovers
+	assert.Eventually(t, func() bool {
+		for i := 0; i < 4; i++ {
+			controller.RecordMirrorResult(false)
+		}
+		return !controller.Overloaded()
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestOverloadController_MirrorRateLimit(t *testing.T) {
+	controller := limiter.NewOverloadController(limiter.OverloadConfig{CheckInterval: time.Hour}, zap.NewNop())
+	defer controller.Stop()
+
+	// Shedding starts at once, without waiting for the next check
+	controller.RecordMirrorRateLimit(50 * time.Millisecond)
+	assert.True(t, controller.Overloaded())
+	// A shorter backoff does not end a longer one early
+	controller.RecordMirrorRateLimit(time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	assert.True(t, controller.Overloaded())
+
+	assert.Eventually(t, func() bool { return !controller.Overloaded() }, time.Second, 5*time.Millisecond)
+}
+
+func TestOverloadController_NoLimits(t *testing.T) {
+	controller := limiter.NewOverloadController(limiter.OverloadConfig{CheckInterval: time.Millisecond}, zap.NewNop())
+	defer controller.Stop()
+
+	time.Sleep(20 * time.Millisecond)
+	assert.False(t, controller.Overloaded())
+}
+
+func TestOverloadController_Nil(t *testing.T) {
+	var controller *limiter.OverloadController
+	controller.RecordMirrorResult(true)
+	controller.RecordMirrorRateLimit(time.Second)
+	controller.Stop()
+
+	assert.False(t, controller.Overloaded())
+	assert.False(t, controller.LowPriority("debug_traceTransaction"))
+	assert.Zero(t, controller.MaxLogsBlockRange())
+}
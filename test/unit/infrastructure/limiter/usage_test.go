@@ -0,0 +1,189 @@
+package limiter_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type recordingSink struct {
+	mu      sync.Mutex
+	flushes [][]limiter.KeyUsage
+}
+
+func (s *recordingSink) Write(_ context.Context, usage []limiter.KeyUsage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.flushes = append(s.flushes, usage)
+	return nil
+}
+
+func TestUsageTracker_Aggregates(t *testing.T) {
+	sink := &recordingSink{}
+	tracker := limiter.NewUsageTracker(sink, time.Hour, zap.NewNop())
+
+	l := newTestLimiter(10, 0)
+	l.TrackUsage(tracker)
+
+	tracker.RecordRequest("key", "free", "eth_call", false)
+	tracker.RecordRequest("key", "free", "eth_call", true)
+	tracker.RecordRequest("key", "free", "eth_chainId", false)
+	tracker.RecordRequest("", "", "eth_chainId", false)
+	tracker.RecordBytes("key", "free", 120)
+	tracker.RecordBytes("key", "free", 30)
+	require.True(t, l.DeductHbarUsage("key", "free", 500))
+	require.True(t, l.DeductHbarUsage("", "", 700), "operator spending is not attributed to a key")
+	tracker.RecordRequest("other-key", "premium", "eth_blockNumber", false)
+
+	tracker.Stop()
+
+	require.Len(t, sink.flushes, 1)
+	usage := make(map[string]limiter.KeyUsage)
+	for _, keyUsage := range sink.flushes[0] {
+		usage[keyUsage.APIKey] = keyUsage
+	}
+	require.Len(t, usage, 2)
+
+	keyUsage := usage["key"]
+	assert.Equal(t, "free", keyUsage.Tier)
+	assert.Equal(t, limiter.UsageKeyID("key"), keyUsage.KeyID)
+	assert.Equal(t, int64(3), keyUsage.Requests)
+	assert.Equal(t, int64(1), keyUsage.Errors)
+	assert.Equal(t, int64(150), keyUsage.BytesServed)
+	assert.Equal(t, int64(500), keyUsage.HbarTinybars)
+	assert.Equal(t, map[string]limiter.MethodUsage{
+		"eth_call":    {Requests: 2, Errors: 1},
+		"eth_chainId": {Requests: 1},
+	}, keyUsage.Methods)
+	assert.False(t, keyUsage.From.After(keyUsage.To))
+
+	assert.Equal(t, int64(1), usage["other-key"].Requests)
+}
+
+func TestUsageTracker_PeriodicFlush(t *testing.T) {
+	sink := &recordingSink{}
+	tracker := limiter.NewUsageTracker(sink, 10*time.Millisecond, zap.NewNop())
+	defer tracker.Stop()
+
+	tracker.RecordRequest("key", "free", "eth_call", false)
+
+	assert.Eventually(t, func() bool {
+		sink.mu.Lock()
+		defer sink.mu.Unlock()
+		return len(sink.flushes) == 1
+	}, time.Second, 5*time.Millisecond)
+
+	// Intervals without requests are not written
+	time.Sleep(30 * time.Millisecond)
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	assert.Len(t, sink.flushes, 1)
+}
+
+func TestUsageTracker_Nil(t *testing.T) {
+	var tracker *limiter.UsageTracker
+	tracker.RecordRequest("key", "free", "eth_call", false)
+	tracker.RecordBytes("key", "free", 10)
+	tracker.RecordHbar("key", "free", 10)
+	tracker.Stop()
+
+	assert.Nil(t, newTestLimiter(10, 0).Usage())
+}
+
+func TestFileUsageSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usage.jsonl")
+	sink := limiter.NewFileUsageSink(path)
+
+	require.NoError(t, sink.Write(context.Background(), []limiter.KeyUsage{{APIKey: "a", Requests: 1}}))
+	require.NoError(t, sink.Write(context.Background(), []limiter.KeyUsage{{APIKey: "b", Requests: 2}}))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var keys []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var keyUsage limiter.KeyUsage
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &keyUsage))
+		keys = append(keys, keyUsage.APIKey)
+	}
+	assert.Equal(t, []string{"a", "b"}, keys)
+}
+
+func TestSQLUsageSink_InvalidTable(t *testing.T) {
+	_, err := limiter.NewSQLUsageSink(nil, "usage; DROP TABLE api_keys")
+	assert.Error(t, err)
+}
+
+func TestOpenUsageSink_SQLReachesTheDatabase(t *testing.T) {
+	sink, err := limiter.OpenUsageSink(limiter.UsageSinkConfig{
+		Sink:   "sql",
+		Driver: limiter.PostgresDriver,
+		DSN:    closedPostgresDSN(t),
+		Table:  "api_key_usage",
+	})
+	require.NoError(t, err)
+
+	// The driver is linked in, so writing gets as far as connecting
+	err = sink.Write(context.Background(), []limiter.KeyUsage{{APIKey: "a", Requests: 1}})
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "unknown driver")
+	var opErr *net.OpError
+	assert.ErrorAs(t, err, &opErr)
+}
+
+func TestOpenUsageSink_UnsupportedDriver(t *testing.T) {
+	// The inserts use PostgreSQL placeholders, which other drivers reject
+	_, err := limiter.OpenUsageSink(limiter.UsageSinkConfig{Sink: "sql", Driver: "sqlite3", DSN: "usage.db", Table: "api_key_usage"})
+	assert.ErrorContains(t, err, `unsupported sql driver "sqlite3"`)
+
+	_, err = limiter.OpenUsageSink(limiter.UsageSinkConfig{Sink: "kafka"})
+	assert.Error(t, err)
+}
+
+func TestStatsDUsageSink(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	sink, err := limiter.NewStatsDUsageSink(conn.LocalAddr().String(), "relay.")
+	require.NoError(t, err)
+	require.NoError(t, sink.Write(context.Background(), []limiter.KeyUsage{{
+		APIKey:       "secret",
+		KeyID:        limiter.UsageKeyID("secret"),
+		Requests:     3,
+		Errors:       1,
+		BytesServed:  42,
+		HbarTinybars: 7,
+		Methods:      map[string]limiter.MethodUsage{"eth_call": {Requests: 3, Errors: 1}},
+	}}))
+
+	buf := make([]byte, 2048)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+
+	prefix := "relay." + limiter.UsageKeyID("secret")
+	assert.Equal(t, []string{
+		prefix + ".requests:3|c",
+		prefix + ".errors:1|c",
+		prefix + ".bytes_served:42|c",
+		prefix + ".hbar_tinybars:7|c",
+		prefix + ".methods.eth_call.requests:3|c",
+		prefix + ".methods.eth_call.errors:1|c",
+	}, strings.Split(string(buf[:n]), "\n"))
+	assert.NotContains(t, string(buf[:n]), "secret")
+}
@@ -0,0 +1,91 @@
+package startup_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/startup"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newMirrorNode(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/network/nodes" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("node.id") == "" {
+			_, _ = w.Write([]byte(`{"nodes":[{"node_id":0},{"node_id":1}],"links":{"next":"/api/v1/network/nodes?node.id=gt:1"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"nodes":[{"node_id":2}],"links":{"next":null}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNewReport(t *testing.T) {
+	mirrorNode := newMirrorNode(t)
+
+	v := viper.New()
+	v.Set("application.version", "1.2.3")
+	v.Set("mirrorNode.baseUrl", []string{mirrorNode.URL, "http://127.0.0.1:0"})
+	v.Set("mirrorNode.timeoutSeconds", 1)
+	v.Set("hedera.operatorId", "0.0.2")
+	v.Set("hedera.operatorKey", "302e020100300506032b6570")
+	v.Set("apiKeys", []interface{}{map[string]interface{}{"key": "FREE-KEY", "tier": "free"}})
+	v.Set("apiKeyStore.dsn", "postgres://relay:secret@db/relay")
+	v.Set("logging.sink.url", "https://logs.example.com/ingest?token=abc")
+	v.Set("features.enforceApiKey", true)
+	v.Set("filters.enabled", false)
+	v.Set("shadow.enabled", true)
+
+	report := startup.NewReport(v, map[string]int64{"0.0.2": 100})
+
+	assert.Equal(t, "1.2.3", report.Version)
+	assert.NotEmpty(t, report.Runtime.GoVersion)
+	assert.Equal(t, map[string]int64{"0.0.2": 100}, report.OperatorBalances)
+	assert.Equal(t, map[string]bool{"enforceapikey": true, "filters": false, "shadow": true}, report.Features)
+
+	require.Len(t, report.MirrorNodes, 2)
+	assert.True(t, report.MirrorNodes[0].Reachable)
+	assert.Equal(t, 3, report.MirrorNodes[0].ConsensusNodes)
+	assert.False(t, report.MirrorNodes[1].Reachable)
+	assert.NotEmpty(t, report.MirrorNodes[1].Error)
+
+	encoded, err := json.Marshal(report.Config)
+	require.NoError(t, err)
+	for _, secret := range []string{"302e020100300506032b6570", "FREE-KEY", "relay:secret", "token=abc"} {
+		assert.NotContains(t, string(encoded), secret)
+	}
+	assert.Contains(t, string(encoded), `"operatorid":"0.0.2"`)
+	assert.Contains(t, string(encoded), `"tier":"free"`)
+	assert.Contains(t, string(encoded), `"url":"https://logs.example.com/ingest"`)
+}
+
+func TestReport_LogAndHandler(t *testing.T) {
+	v := viper.New()
+	v.Set("application.version", "1.2.3")
+	v.Set("mirrorNode.baseUrl", "http://127.0.0.1:0")
+	v.Set("mirrorNode.timeoutSeconds", 1)
+	report := startup.NewReport(v, nil)
+
+	core, logs := observer.New(zap.InfoLevel)
+	report.Log(zap.New(core))
+	assert.Equal(t, 1, logs.FilterMessage("Startup report").Len())
+	assert.Equal(t, 1, logs.FilterMessage("Mirror node unreachable at startup").Len())
+
+	w := httptest.NewRecorder()
+	report.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/startup", nil))
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var served map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &served))
+	assert.Equal(t, "1.2.3", served["version"])
+}
@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/eth_account_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/LimeChain/Hederium/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAccountService is a mock of AccountService interface.
+type MockAccountService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountServiceMockRecorder
+}
+
+// MockAccountServiceMockRecorder is the mock recorder for MockAccountService.
+type MockAccountServiceMockRecorder struct {
+	mock *MockAccountService
+}
+
+// NewMockAccountService creates a new mock instance.
+func NewMockAccountService(ctrl *gomock.Controller) *MockAccountService {
+	mock := &MockAccountService{ctrl: ctrl}
+	mock.recorder = &MockAccountServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountService) EXPECT() *MockAccountServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAccounts mocks base method.
+func (m *MockAccountService) GetAccounts() (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccounts")
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// GetAccounts indicates an expected call of GetAccounts.
+func (mr *MockAccountServiceMockRecorder) GetAccounts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccounts", reflect.TypeOf((*MockAccountService)(nil).GetAccounts))
+}
+
+// GetBalance mocks base method.
+func (m *MockAccountService) GetBalance(ctx context.Context, address, blockNumberTagOrHash string) (string, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalance", ctx, address, blockNumberTagOrHash)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// GetBalance indicates an expected call of GetBalance.
+func (mr *MockAccountServiceMockRecorder) GetBalance(ctx, address, blockNumberTagOrHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockAccountService)(nil).GetBalance), ctx, address, blockNumberTagOrHash)
+}
+
+// GetCode mocks base method.
+func (m *MockAccountService) GetCode(ctx context.Context, address, blockNumberOrTag string) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCode", ctx, address, blockNumberOrTag)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// GetCode indicates an expected call of GetCode.
+func (mr *MockAccountServiceMockRecorder) GetCode(ctx, address, blockNumberOrTag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCode", reflect.TypeOf((*MockAccountService)(nil).GetCode), ctx, address, blockNumberOrTag)
+}
+
+// GetProof mocks base method.
+func (m *MockAccountService) GetProof(ctx context.Context, address string, storageKeys []string, blockNumberOrTag string) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProof", ctx, address, storageKeys, blockNumberOrTag)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// GetProof indicates an expected call of GetProof.
+func (mr *MockAccountServiceMockRecorder) GetProof(ctx, address, storageKeys, blockNumberOrTag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProof", reflect.TypeOf((*MockAccountService)(nil).GetProof), ctx, address, storageKeys, blockNumberOrTag)
+}
+
+// GetStorageAt mocks base method.
+func (m *MockAccountService) GetStorageAt(ctx context.Context, address, slot, blockNumberOrHash string) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStorageAt", ctx, address, slot, blockNumberOrHash)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// GetStorageAt indicates an expected call of GetStorageAt.
+func (mr *MockAccountServiceMockRecorder) GetStorageAt(ctx, address, slot, blockNumberOrHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageAt", reflect.TypeOf((*MockAccountService)(nil).GetStorageAt), ctx, address, slot, blockNumberOrHash)
+}
+
+// GetTransactionCount mocks base method.
+func (m *MockAccountService) GetTransactionCount(ctx context.Context, address, blockNumberOrTag string) (string, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionCount", ctx, address, blockNumberOrTag)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// GetTransactionCount indicates an expected call of GetTransactionCount.
+func (mr *MockAccountServiceMockRecorder) GetTransactionCount(ctx, address, blockNumberOrTag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionCount", reflect.TypeOf((*MockAccountService)(nil).GetTransactionCount), ctx, address, blockNumberOrTag)
+}
@@ -0,0 +1,215 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/eth_block_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/LimeChain/Hederium/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBlockService is a mock of BlockService interface.
+type MockBlockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockServiceMockRecorder
+}
+
+// MockBlockServiceMockRecorder is the mock recorder for MockBlockService.
+type MockBlockServiceMockRecorder struct {
+	mock *MockBlockService
+}
+
+// NewMockBlockService creates a new mock instance.
+func NewMockBlockService(ctrl *gomock.Controller) *MockBlockService {
+	mock := &MockBlockService{ctrl: ctrl}
+	mock.recorder = &MockBlockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockService) EXPECT() *MockBlockServiceMockRecorder {
+	return m.recorder
+}
+
+// GetBlockByHash mocks base method.
+func (m *MockBlockService) GetBlockByHash(ctx context.Context, hash string, showDetails bool) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockByHash", ctx, hash, showDetails)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// GetBlockByHash indicates an expected call of GetBlockByHash.
+func (mr *MockBlockServiceMockRecorder) GetBlockByHash(ctx, hash, showDetails interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockByHash", reflect.TypeOf((*MockBlockService)(nil).GetBlockByHash), ctx, hash, showDetails)
+}
+
+// GetBlockByNumber mocks base method.
+func (m *MockBlockService) GetBlockByNumber(ctx context.Context, numberOrTag string, showDetails bool) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockByNumber", ctx, numberOrTag, showDetails)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// GetBlockByNumber indicates an expected call of GetBlockByNumber.
+func (mr *MockBlockServiceMockRecorder) GetBlockByNumber(ctx, numberOrTag, showDetails interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockByNumber", reflect.TypeOf((*MockBlockService)(nil).GetBlockByNumber), ctx, numberOrTag, showDetails)
+}
+
+// GetBlockNumber mocks base method.
+func (m *MockBlockService) GetBlockNumber(ctx context.Context) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockNumber", ctx)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// GetBlockNumber indicates an expected call of GetBlockNumber.
+func (mr *MockBlockServiceMockRecorder) GetBlockNumber(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockNumber", reflect.TypeOf((*MockBlockService)(nil).GetBlockNumber), ctx)
+}
+
+// GetBlockTransactionCountByHash mocks base method.
+func (m *MockBlockService) GetBlockTransactionCountByHash(ctx context.Context, blockHash string) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockTransactionCountByHash", ctx, blockHash)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// GetBlockTransactionCountByHash indicates an expected call of GetBlockTransactionCountByHash.
+func (mr *MockBlockServiceMockRecorder) GetBlockTransactionCountByHash(ctx, blockHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockTransactionCountByHash", reflect.TypeOf((*MockBlockService)(nil).GetBlockTransactionCountByHash), ctx, blockHash)
+}
+
+// GetBlockTransactionCountByNumber mocks base method.
+func (m *MockBlockService) GetBlockTransactionCountByNumber(ctx context.Context, blockNumberOrTag string) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockTransactionCountByNumber", ctx, blockNumberOrTag)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// GetBlockTransactionCountByNumber indicates an expected call of GetBlockTransactionCountByNumber.
+func (mr *MockBlockServiceMockRecorder) GetBlockTransactionCountByNumber(ctx, blockNumberOrTag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockTransactionCountByNumber", reflect.TypeOf((*MockBlockService)(nil).GetBlockTransactionCountByNumber), ctx, blockNumberOrTag)
+}
+
+// GetLogs mocks base method.
+func (m *MockBlockService) GetLogs(ctx context.Context, logParams domain.LogParams) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogs", ctx, logParams)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// GetLogs indicates an expected call of GetLogs.
+func (mr *MockBlockServiceMockRecorder) GetLogs(ctx, logParams interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogs", reflect.TypeOf((*MockBlockService)(nil).GetLogs), ctx, logParams)
+}
+
+// GetUncleByBlockHashAndIndex mocks base method.
+func (m *MockBlockService) GetUncleByBlockHashAndIndex(blockHash, index string) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUncleByBlockHashAndIndex", blockHash, index)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// GetUncleByBlockHashAndIndex indicates an expected call of GetUncleByBlockHashAndIndex.
+func (mr *MockBlockServiceMockRecorder) GetUncleByBlockHashAndIndex(blockHash, index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUncleByBlockHashAndIndex", reflect.TypeOf((*MockBlockService)(nil).GetUncleByBlockHashAndIndex), blockHash, index)
+}
+
+// GetUncleByBlockNumberAndIndex mocks base method.
+func (m *MockBlockService) GetUncleByBlockNumberAndIndex(blockNumber, index string) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUncleByBlockNumberAndIndex", blockNumber, index)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// GetUncleByBlockNumberAndIndex indicates an expected call of GetUncleByBlockNumberAndIndex.
+func (mr *MockBlockServiceMockRecorder) GetUncleByBlockNumberAndIndex(blockNumber, index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUncleByBlockNumberAndIndex", reflect.TypeOf((*MockBlockService)(nil).GetUncleByBlockNumberAndIndex), blockNumber, index)
+}
+
+// GetUncleCountByBlockHash mocks base method.
+func (m *MockBlockService) GetUncleCountByBlockHash(blockHash string) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUncleCountByBlockHash", blockHash)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// GetUncleCountByBlockHash indicates an expected call of GetUncleCountByBlockHash.
+func (mr *MockBlockServiceMockRecorder) GetUncleCountByBlockHash(blockHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUncleCountByBlockHash", reflect.TypeOf((*MockBlockService)(nil).GetUncleCountByBlockHash), blockHash)
+}
+
+// GetUncleCountByBlockNumber mocks base method.
+func (m *MockBlockService) GetUncleCountByBlockNumber(blockNumber string) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUncleCountByBlockNumber", blockNumber)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// GetUncleCountByBlockNumber indicates an expected call of GetUncleCountByBlockNumber.
+func (mr *MockBlockServiceMockRecorder) GetUncleCountByBlockNumber(blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUncleCountByBlockNumber", reflect.TypeOf((*MockBlockService)(nil).GetUncleCountByBlockNumber), blockNumber)
+}
+
+// StreamLogs mocks base method.
+func (m *MockBlockService) StreamLogs(ctx context.Context, logParams domain.LogParams, emit func([]domain.Log) error) *domain.RPCError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamLogs", ctx, logParams, emit)
+	ret0, _ := ret[0].(*domain.RPCError)
+	return ret0
+}
+
+// StreamLogs indicates an expected call of StreamLogs.
+func (mr *MockBlockServiceMockRecorder) StreamLogs(ctx, logParams, emit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamLogs", reflect.TypeOf((*MockBlockService)(nil).StreamLogs), ctx, logParams, emit)
+}
+
+// Syncing mocks base method.
+func (m *MockBlockService) Syncing(ctx context.Context) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Syncing", ctx)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// Syncing indicates an expected call of Syncing.
+func (mr *MockBlockServiceMockRecorder) Syncing(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Syncing", reflect.TypeOf((*MockBlockService)(nil).Syncing), ctx)
+}
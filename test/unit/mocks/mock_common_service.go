@@ -1,12 +1,14 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: internal/service/eth_common.go
 
+// Package mocks is a generated GoMock package.
 package mocks
 
 import (
-	"reflect"
+	context "context"
+	reflect "reflect"
 
-	"github.com/LimeChain/Hederium/internal/domain"
+	domain "github.com/LimeChain/Hederium/internal/domain"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -33,105 +35,119 @@ func (m *MockCommonService) EXPECT() *MockCommonServiceMockRecorder {
 	return m.recorder
 }
 
-// GetLogs mocks base method.
-func (m *MockCommonService) GetLogs(logParams domain.LogParams) ([]domain.Log, *domain.RPCError) {
+// GetBlockNumber mocks base method.
+func (m *MockCommonService) GetBlockNumber(ctx context.Context) (interface{}, *domain.RPCError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLogs", logParams)
-	ret0, _ := ret[0].([]domain.Log)
+	ret := m.ctrl.Call(m, "GetBlockNumber", ctx)
+	ret0, _ := ret[0].(interface{})
 	ret1, _ := ret[1].(*domain.RPCError)
 	return ret0, ret1
 }
 
-// GetLogs indicates an expected call of GetLogs.
-func (mr *MockCommonServiceMockRecorder) GetLogs(logParams interface{}) *gomock.Call {
+// GetBlockNumber indicates an expected call of GetBlockNumber.
+func (mr *MockCommonServiceMockRecorder) GetBlockNumber(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogs", reflect.TypeOf((*MockCommonService)(nil).GetLogs), logParams)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockNumber", reflect.TypeOf((*MockCommonService)(nil).GetBlockNumber), ctx)
 }
 
-// ValidateBlockHashAndAddTimestampToParams mocks base method.
-func (m *MockCommonService) ValidateBlockHashAndAddTimestampToParams(params map[string]interface{}, blockHash string) error {
+// GetBlockNumberByNumberOrTag mocks base method.
+func (m *MockCommonService) GetBlockNumberByNumberOrTag(ctx context.Context, blockNumberOrTag string) (int64, *domain.RPCError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ValidateBlockHashAndAddTimestampToParams", params, blockHash)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetBlockNumberByNumberOrTag", ctx, blockNumberOrTag)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
 }
 
-// ValidateBlockHashAndAddTimestampToParams indicates an expected call of ValidateBlockHashAndAddTimestampToParams.
-func (mr *MockCommonServiceMockRecorder) ValidateBlockHashAndAddTimestampToParams(params, blockHash interface{}) *gomock.Call {
+// GetBlockNumberByNumberOrTag indicates an expected call of GetBlockNumberByNumberOrTag.
+func (mr *MockCommonServiceMockRecorder) GetBlockNumberByNumberOrTag(ctx, blockNumberOrTag interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBlockHashAndAddTimestampToParams", reflect.TypeOf((*MockCommonService)(nil).ValidateBlockHashAndAddTimestampToParams), params, blockHash)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockNumberByNumberOrTag", reflect.TypeOf((*MockCommonService)(nil).GetBlockNumberByNumberOrTag), ctx, blockNumberOrTag)
 }
 
-// ValidateBlockRangeAndAddTimestampToParams mocks base method.
-func (m *MockCommonService) ValidateBlockRangeAndAddTimestampToParams(params map[string]interface{}, fromBlock, toBlock string, address []string) (bool, *domain.RPCError) {
+// GetLogs mocks base method.
+func (m *MockCommonService) GetLogs(ctx context.Context, logParams domain.LogParams) ([]domain.Log, *domain.RPCError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ValidateBlockRangeAndAddTimestampToParams", params, fromBlock, toBlock, address)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "GetLogs", ctx, logParams)
+	ret0, _ := ret[0].([]domain.Log)
 	ret1, _ := ret[1].(*domain.RPCError)
 	return ret0, ret1
 }
 
-// ValidateBlockRangeAndAddTimestampToParams indicates an expected call of ValidateBlockRangeAndAddTimestampToParams.
-func (mr *MockCommonServiceMockRecorder) ValidateBlockRangeAndAddTimestampToParams(params, fromBlock, toBlock, address interface{}) *gomock.Call {
+// GetLogs indicates an expected call of GetLogs.
+func (mr *MockCommonServiceMockRecorder) GetLogs(ctx, logParams interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBlockRangeAndAddTimestampToParams", reflect.TypeOf((*MockCommonService)(nil).ValidateBlockRangeAndAddTimestampToParams), params, fromBlock, toBlock, address)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogs", reflect.TypeOf((*MockCommonService)(nil).GetLogs), ctx, logParams)
 }
 
 // GetLogsWithParams mocks base method.
-func (m *MockCommonService) GetLogsWithParams(address []string, params map[string]interface{}) ([]domain.Log, error) {
+func (m *MockCommonService) GetLogsWithParams(ctx context.Context, address []string, params map[string]interface{}) ([]domain.Log, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLogsWithParams", address, params)
+	ret := m.ctrl.Call(m, "GetLogsWithParams", ctx, address, params)
 	ret0, _ := ret[0].([]domain.Log)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetLogsWithParams indicates an expected call of GetLogsWithParams.
-func (mr *MockCommonServiceMockRecorder) GetLogsWithParams(address, params interface{}) *gomock.Call {
+func (mr *MockCommonServiceMockRecorder) GetLogsWithParams(ctx, address, params interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogsWithParams", reflect.TypeOf((*MockCommonService)(nil).GetLogsWithParams), address, params)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogsWithParams", reflect.TypeOf((*MockCommonService)(nil).GetLogsWithParams), ctx, address, params)
 }
 
-// GetBlockNumberByNumberOrTag mocks base method.
-func (m *MockCommonService) GetBlockNumberByNumberOrTag(blockNumberOrTag string) (int64, *domain.RPCError) {
+// StreamLogs mocks base method.
+func (m *MockCommonService) StreamLogs(ctx context.Context, logParams domain.LogParams, emit func([]domain.Log) error) *domain.RPCError {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBlockNumberByNumberOrTag", blockNumberOrTag)
-	ret0, _ := ret[0].(int64)
-	ret1, _ := ret[1].(*domain.RPCError)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "StreamLogs", ctx, logParams, emit)
+	ret0, _ := ret[0].(*domain.RPCError)
+	return ret0
 }
 
-// GetBlockNumberByNumberOrTag indicates an expected call of GetBlockNumberByNumberOrTag.
-func (mr *MockCommonServiceMockRecorder) GetBlockNumberByNumberOrTag(blockNumberOrTag interface{}) *gomock.Call {
+// StreamLogs indicates an expected call of StreamLogs.
+func (mr *MockCommonServiceMockRecorder) StreamLogs(ctx, logParams, emit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamLogs", reflect.TypeOf((*MockCommonService)(nil).StreamLogs), ctx, logParams, emit)
+}
+
+// ValidateBlockHashAndAddTimestampToParams mocks base method.
+func (m *MockCommonService) ValidateBlockHashAndAddTimestampToParams(ctx context.Context, params map[string]interface{}, blockHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateBlockHashAndAddTimestampToParams", ctx, params, blockHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateBlockHashAndAddTimestampToParams indicates an expected call of ValidateBlockHashAndAddTimestampToParams.
+func (mr *MockCommonServiceMockRecorder) ValidateBlockHashAndAddTimestampToParams(ctx, params, blockHash interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockNumberByNumberOrTag", reflect.TypeOf((*MockCommonService)(nil).GetBlockNumberByNumberOrTag), blockNumberOrTag)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBlockHashAndAddTimestampToParams", reflect.TypeOf((*MockCommonService)(nil).ValidateBlockHashAndAddTimestampToParams), ctx, params, blockHash)
 }
 
 // ValidateBlockRange mocks base method.
-func (m *MockCommonService) ValidateBlockRange(fromBlock, toBlock string) *domain.RPCError {
+func (m *MockCommonService) ValidateBlockRange(ctx context.Context, fromBlock, toBlock string) *domain.RPCError {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ValidateBlockRange", fromBlock, toBlock)
+	ret := m.ctrl.Call(m, "ValidateBlockRange", ctx, fromBlock, toBlock)
 	ret0, _ := ret[0].(*domain.RPCError)
 	return ret0
 }
 
 // ValidateBlockRange indicates an expected call of ValidateBlockRange.
-func (mr *MockCommonServiceMockRecorder) ValidateBlockRange(fromBlock, toBlock interface{}) *gomock.Call {
+func (mr *MockCommonServiceMockRecorder) ValidateBlockRange(ctx, fromBlock, toBlock interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBlockRange", reflect.TypeOf((*MockCommonService)(nil).ValidateBlockRange), fromBlock, toBlock)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBlockRange", reflect.TypeOf((*MockCommonService)(nil).ValidateBlockRange), ctx, fromBlock, toBlock)
 }
 
-// GetBlockNumber mocks base method.
-func (m *MockCommonService) GetBlockNumber() (interface{}, *domain.RPCError) {
+// ValidateBlockRangeAndAddTimestampToParams mocks base method.
+func (m *MockCommonService) ValidateBlockRangeAndAddTimestampToParams(ctx context.Context, params map[string]interface{}, fromBlock, toBlock string, address []string) (bool, *domain.RPCError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBlockNumber")
-	ret0, _ := ret[0].(interface{})
+	ret := m.ctrl.Call(m, "ValidateBlockRangeAndAddTimestampToParams", ctx, params, fromBlock, toBlock, address)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(*domain.RPCError)
 	return ret0, ret1
 }
 
-// GetBlockNumber indicates an expected call of GetBlockNumber.
-func (mr *MockCommonServiceMockRecorder) GetBlockNumber() *gomock.Call {
+// ValidateBlockRangeAndAddTimestampToParams indicates an expected call of ValidateBlockRangeAndAddTimestampToParams.
+func (mr *MockCommonServiceMockRecorder) ValidateBlockRangeAndAddTimestampToParams(ctx, params, fromBlock, toBlock, address interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockNumber", reflect.TypeOf((*MockCommonService)(nil).GetBlockNumber))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBlockRangeAndAddTimestampToParams", reflect.TypeOf((*MockCommonService)(nil).ValidateBlockRangeAndAddTimestampToParams), ctx, params, fromBlock, toBlock, address)
 }
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/eth_fee_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/LimeChain/Hederium/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockFeeService is a mock of FeeService interface.
+type MockFeeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeeServiceMockRecorder
+}
+
+// MockFeeServiceMockRecorder is the mock recorder for MockFeeService.
+type MockFeeServiceMockRecorder struct {
+	mock *MockFeeService
+}
+
+// NewMockFeeService creates a new mock instance.
+func NewMockFeeService(ctrl *gomock.Controller) *MockFeeService {
+	mock := &MockFeeService{ctrl: ctrl}
+	mock.recorder = &MockFeeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeeService) EXPECT() *MockFeeServiceMockRecorder {
+	return m.recorder
+}
+
+// FeeHistory mocks base method.
+func (m *MockFeeService) FeeHistory(ctx context.Context, blockCount, newestBlock string, rewardPercentiles []string) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FeeHistory", ctx, blockCount, newestBlock, rewardPercentiles)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// FeeHistory indicates an expected call of FeeHistory.
+func (mr *MockFeeServiceMockRecorder) FeeHistory(ctx, blockCount, newestBlock, rewardPercentiles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FeeHistory", reflect.TypeOf((*MockFeeService)(nil).FeeHistory), ctx, blockCount, newestBlock, rewardPercentiles)
+}
+
+// GetGasPrice mocks base method.
+func (m *MockFeeService) GetGasPrice(ctx context.Context) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGasPrice", ctx)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// GetGasPrice indicates an expected call of GetGasPrice.
+func (mr *MockFeeServiceMockRecorder) GetGasPrice(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGasPrice", reflect.TypeOf((*MockFeeService)(nil).GetGasPrice), ctx)
+}
+
+// MaxPriorityFeePerGas mocks base method.
+func (m *MockFeeService) MaxPriorityFeePerGas() (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxPriorityFeePerGas")
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// MaxPriorityFeePerGas indicates an expected call of MaxPriorityFeePerGas.
+func (mr *MockFeeServiceMockRecorder) MaxPriorityFeePerGas() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxPriorityFeePerGas", reflect.TypeOf((*MockFeeService)(nil).MaxPriorityFeePerGas))
+}
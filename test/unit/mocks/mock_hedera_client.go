@@ -78,6 +78,21 @@ func (mr *MockHederaNodeClientMockRecorder) GetOperatorPublicKey() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperatorPublicKey", reflect.TypeOf((*MockHederaNodeClient)(nil).GetOperatorPublicKey))
 }
 
+// GetTransactionRecord mocks base method.
+func (m *MockHederaNodeClient) GetTransactionRecord(transactionId string) (*hedera.TransactionRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionRecord", transactionId)
+	ret0, _ := ret[0].(*hedera.TransactionRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionRecord indicates an expected call of GetTransactionRecord.
+func (mr *MockHederaNodeClientMockRecorder) GetTransactionRecord(transactionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionRecord", reflect.TypeOf((*MockHederaNodeClient)(nil).GetTransactionRecord), transactionId)
+}
+
 // SendRawTransaction mocks base method.
 func (m *MockHederaNodeClient) SendRawTransaction(transactionData []byte, networkGasPriceInWeiBars int64, callerId string) (*hedera.TransactionResponse, error) {
 	m.ctrl.T.Helper()
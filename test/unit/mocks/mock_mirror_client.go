@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	domain "github.com/LimeChain/Hederium/internal/domain"
@@ -35,249 +36,375 @@ func (m *MockMirrorClient) EXPECT() *MockMirrorClientMockRecorder {
 }
 
 // GetAccount mocks base method.
-func (m *MockMirrorClient) GetAccount(address, timestampTo string) interface{} {
+func (m *MockMirrorClient) GetAccount(ctx context.Context, address, timestampTo string) (interface{}, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccount", address, timestampTo)
+	ret := m.ctrl.Call(m, "GetAccount", ctx, address, timestampTo)
 	ret0, _ := ret[0].(interface{})
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GetAccount indicates an expected call of GetAccount.
-func (mr *MockMirrorClientMockRecorder) GetAccount(address, timestampTo interface{}) *gomock.Call {
+func (mr *MockMirrorClientMockRecorder) GetAccount(ctx, address, timestampTo interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockMirrorClient)(nil).GetAccount), address, timestampTo)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockMirrorClient)(nil).GetAccount), ctx, address, timestampTo)
 }
 
 // GetAccountById mocks base method.
-func (m *MockMirrorClient) GetAccountById(idOrAliasOrEvmAddress string) (*domain.AccountResponse, error) {
+func (m *MockMirrorClient) GetAccountById(ctx context.Context, idOrAliasOrEvmAddress string) (*domain.AccountResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountById", idOrAliasOrEvmAddress)
+	ret := m.ctrl.Call(m, "GetAccountById", ctx, idOrAliasOrEvmAddress)
 	ret0, _ := ret[0].(*domain.AccountResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAccountById indicates an expected call of GetAccountById.
-func (mr *MockMirrorClientMockRecorder) GetAccountById(idOrAliasOrEvmAddress interface{}) *gomock.Call {
+func (mr *MockMirrorClientMockRecorder) GetAccountById(ctx, idOrAliasOrEvmAddress interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountById", reflect.TypeOf((*MockMirrorClient)(nil).GetAccountById), idOrAliasOrEvmAddress)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountById", reflect.TypeOf((*MockMirrorClient)(nil).GetAccountById), ctx, idOrAliasOrEvmAddress)
 }
 
 // GetBalance mocks base method.
-func (m *MockMirrorClient) GetBalance(address, timestampTo string) string {
+func (m *MockMirrorClient) GetBalance(ctx context.Context, address, timestampTo string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBalance", address, timestampTo)
+	ret := m.ctrl.Call(m, "GetBalance", ctx, address, timestampTo)
 	ret0, _ := ret[0].(string)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GetBalance indicates an expected call of GetBalance.
-func (mr *MockMirrorClientMockRecorder) GetBalance(address, timestampTo interface{}) *gomock.Call {
+func (mr *MockMirrorClientMockRecorder) GetBalance(ctx, address, timestampTo interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockMirrorClient)(nil).GetBalance), address, timestampTo)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockMirrorClient)(nil).GetBalance), ctx, address, timestampTo)
 }
 
 // GetBlockByHashOrNumber mocks base method.
-func (m *MockMirrorClient) GetBlockByHashOrNumber(hashOrNumber string) *domain.BlockResponse {
+func (m *MockMirrorClient) GetBlockByHashOrNumber(ctx context.Context, hashOrNumber string) (*domain.BlockResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBlockByHashOrNumber", hashOrNumber)
+	ret := m.ctrl.Call(m, "GetBlockByHashOrNumber", ctx, hashOrNumber)
 	ret0, _ := ret[0].(*domain.BlockResponse)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GetBlockByHashOrNumber indicates an expected call of GetBlockByHashOrNumber.
-func (mr *MockMirrorClientMockRecorder) GetBlockByHashOrNumber(hashOrNumber interface{}) *gomock.Call {
+func (mr *MockMirrorClientMockRecorder) GetBlockByHashOrNumber(ctx, hashOrNumber interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockByHashOrNumber", reflect.TypeOf((*MockMirrorClient)(nil).GetBlockByHashOrNumber), hashOrNumber)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockByHashOrNumber", reflect.TypeOf((*MockMirrorClient)(nil).GetBlockByHashOrNumber), ctx, hashOrNumber)
 }
 
 // GetBlocks mocks base method.
-func (m *MockMirrorClient) GetBlocks(blockNumber string) ([]map[string]interface{}, error) {
+func (m *MockMirrorClient) GetBlocks(ctx context.Context, blockNumber string) ([]map[string]interface{}, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBlocks", blockNumber)
+	ret := m.ctrl.Call(m, "GetBlocks", ctx, blockNumber)
 	ret0, _ := ret[0].([]map[string]interface{})
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetBlocks indicates an expected call of GetBlocks.
-func (mr *MockMirrorClientMockRecorder) GetBlocks(blockNumber interface{}) *gomock.Call {
+func (mr *MockMirrorClientMockRecorder) GetBlocks(ctx, blockNumber interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocks", reflect.TypeOf((*MockMirrorClient)(nil).GetBlocks), blockNumber)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocks", reflect.TypeOf((*MockMirrorClient)(nil).GetBlocks), ctx, blockNumber)
 }
 
 // GetContractById mocks base method.
-func (m *MockMirrorClient) GetContractById(contractIdOrAddress string) (*domain.ContractResponse, error) {
+func (m *MockMirrorClient) GetContractById(ctx context.Context, contractIdOrAddress string) (*domain.ContractResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetContractById", contractIdOrAddress)
+	ret := m.ctrl.Call(m, "GetContractById", ctx, contractIdOrAddress)
 	ret0, _ := ret[0].(*domain.ContractResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetContractById indicates an expected call of GetContractById.
-func (mr *MockMirrorClientMockRecorder) GetContractById(contractIdOrAddress interface{}) *gomock.Call {
+func (mr *MockMirrorClientMockRecorder) GetContractById(ctx, contractIdOrAddress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractById", reflect.TypeOf((*MockMirrorClient)(nil).GetContractById), ctx, contractIdOrAddress)
+}
+
+// GetContractByIdWithRetry mocks base method.
+func (m *MockMirrorClient) GetContractByIdWithRetry(ctx context.Context, contractIdOrAddress string) (*domain.ContractResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContractByIdWithRetry", ctx, contractIdOrAddress)
+	ret0, _ := ret[0].(*domain.ContractResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContractByIdWithRetry indicates an expected call of GetContractByIdWithRetry.
+func (mr *MockMirrorClientMockRecorder) GetContractByIdWithRetry(ctx, contractIdOrAddress interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractById", reflect.TypeOf((*MockMirrorClient)(nil).GetContractById), contractIdOrAddress)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractByIdWithRetry", reflect.TypeOf((*MockMirrorClient)(nil).GetContractByIdWithRetry), ctx, contractIdOrAddress)
 }
 
 // GetContractResult mocks base method.
-func (m *MockMirrorClient) GetContractResult(transactionId string) interface{} {
+func (m *MockMirrorClient) GetContractResult(ctx context.Context, transactionId string) (*domain.ContractResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetContractResult", transactionId)
-	ret0, _ := ret[0].(interface{})
-	return ret0
+	ret := m.ctrl.Call(m, "GetContractResult", ctx, transactionId)
+	ret0, _ := ret[0].(*domain.ContractResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GetContractResult indicates an expected call of GetContractResult.
-func (mr *MockMirrorClientMockRecorder) GetContractResult(transactionId interface{}) *gomock.Call {
+func (mr *MockMirrorClientMockRecorder) GetContractResult(ctx, transactionId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractResult", reflect.TypeOf((*MockMirrorClient)(nil).GetContractResult), transactionId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractResult", reflect.TypeOf((*MockMirrorClient)(nil).GetContractResult), ctx, transactionId)
 }
 
 // GetContractResultWithRetry mocks base method.
-func (m *MockMirrorClient) GetContractResultWithRetry(queryParams map[string]interface{}) (*domain.ContractResults, error) {
+func (m *MockMirrorClient) GetContractResultWithRetry(ctx context.Context, queryParams map[string]interface{}) (*domain.ContractResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetContractResultWithRetry", queryParams)
-	ret0, _ := ret[0].(*domain.ContractResults)
+	ret := m.ctrl.Call(m, "GetContractResultWithRetry", ctx, queryParams)
+	ret0, _ := ret[0].(*domain.ContractResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetContractResultWithRetry indicates an expected call of GetContractResultWithRetry.
-func (mr *MockMirrorClientMockRecorder) GetContractResultWithRetry(queryParams interface{}) *gomock.Call {
+func (mr *MockMirrorClientMockRecorder) GetContractResultWithRetry(ctx, queryParams interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractResultWithRetry", reflect.TypeOf((*MockMirrorClient)(nil).GetContractResultWithRetry), queryParams)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractResultWithRetry", reflect.TypeOf((*MockMirrorClient)(nil).GetContractResultWithRetry), ctx, queryParams)
 }
 
 // GetContractResults mocks base method.
-func (m *MockMirrorClient) GetContractResults(timestamp domain.Timestamp) []domain.ContractResults {
+func (m *MockMirrorClient) GetContractResults(ctx context.Context, timestamp domain.Timestamp) ([]domain.ContractResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetContractResults", timestamp)
-	ret0, _ := ret[0].([]domain.ContractResults)
-	return ret0
+	ret := m.ctrl.Call(m, "GetContractResults", ctx, timestamp)
+	ret0, _ := ret[0].([]domain.ContractResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GetContractResults indicates an expected call of GetContractResults.
-func (mr *MockMirrorClientMockRecorder) GetContractResults(timestamp interface{}) *gomock.Call {
+func (mr *MockMirrorClientMockRecorder) GetContractResults(ctx, timestamp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractResults", reflect.TypeOf((*MockMirrorClient)(nil).GetContractResults), ctx, timestamp)
+}
+
+// GetContractResultsBySender mocks base method.
+func (m *MockMirrorClient) GetContractResultsBySender(ctx context.Context, address, timestampTo string) ([]domain.ContractResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContractResultsBySender", ctx, address, timestampTo)
+	ret0, _ := ret[0].([]domain.ContractResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContractResultsBySender indicates an expected call of GetContractResultsBySender.
+func (mr *MockMirrorClientMockRecorder) GetContractResultsBySender(ctx, address, timestampTo interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractResults", reflect.TypeOf((*MockMirrorClient)(nil).GetContractResults), timestamp)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractResultsBySender", reflect.TypeOf((*MockMirrorClient)(nil).GetContractResultsBySender), ctx, address, timestampTo)
 }
 
 // GetContractResultsLogsByAddress mocks base method.
-func (m *MockMirrorClient) GetContractResultsLogsByAddress(address string, queryParams map[string]interface{}) ([]domain.LogEntry, error) {
+func (m *MockMirrorClient) GetContractResultsLogsByAddress(ctx context.Context, address string, queryParams map[string]interface{}) ([]domain.LogEntry, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetContractResultsLogsByAddress", address, queryParams)
+	ret := m.ctrl.Call(m, "GetContractResultsLogsByAddress", ctx, address, queryParams)
 	ret0, _ := ret[0].([]domain.LogEntry)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetContractResultsLogsByAddress indicates an expected call of GetContractResultsLogsByAddress.
-func (mr *MockMirrorClientMockRecorder) GetContractResultsLogsByAddress(address, queryParams interface{}) *gomock.Call {
+func (mr *MockMirrorClientMockRecorder) GetContractResultsLogsByAddress(ctx, address, queryParams interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractResultsLogsByAddress", reflect.TypeOf((*MockMirrorClient)(nil).GetContractResultsLogsByAddress), address, queryParams)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractResultsLogsByAddress", reflect.TypeOf((*MockMirrorClient)(nil).GetContractResultsLogsByAddress), ctx, address, queryParams)
 }
 
 // GetContractResultsLogsWithRetry mocks base method.
-func (m *MockMirrorClient) GetContractResultsLogsWithRetry(queryParams map[string]interface{}) ([]domain.LogEntry, error) {
+func (m *MockMirrorClient) GetContractResultsLogsWithRetry(ctx context.Context, queryParams map[string]interface{}) ([]domain.LogEntry, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetContractResultsLogsWithRetry", queryParams)
+	ret := m.ctrl.Call(m, "GetContractResultsLogsWithRetry", ctx, queryParams)
 	ret0, _ := ret[0].([]domain.LogEntry)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetContractResultsLogsWithRetry indicates an expected call of GetContractResultsLogsWithRetry.
-func (mr *MockMirrorClientMockRecorder) GetContractResultsLogsWithRetry(queryParams interface{}) *gomock.Call {
+func (mr *MockMirrorClientMockRecorder) GetContractResultsLogsWithRetry(ctx, queryParams interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractResultsLogsWithRetry", reflect.TypeOf((*MockMirrorClient)(nil).GetContractResultsLogsWithRetry), queryParams)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractResultsLogsWithRetry", reflect.TypeOf((*MockMirrorClient)(nil).GetContractResultsLogsWithRetry), ctx, queryParams)
 }
 
 // GetContractStateByAddressAndSlot mocks base method.
-func (m *MockMirrorClient) GetContractStateByAddressAndSlot(address, slot, timestampTo string) (*domain.ContractStateResponse, error) {
+func (m *MockMirrorClient) GetContractStateByAddressAndSlot(ctx context.Context, address, slot, timestampTo string) (*domain.ContractStateResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetContractStateByAddressAndSlot", address, slot, timestampTo)
+	ret := m.ctrl.Call(m, "GetContractStateByAddressAndSlot", ctx, address, slot, timestampTo)
 	ret0, _ := ret[0].(*domain.ContractStateResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetContractStateByAddressAndSlot indicates an expected call of GetContractStateByAddressAndSlot.
-func (mr *MockMirrorClientMockRecorder) GetContractStateByAddressAndSlot(address, slot, timestampTo interface{}) *gomock.Call {
+func (mr *MockMirrorClientMockRecorder) GetContractStateByAddressAndSlot(ctx, address, slot, timestampTo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractStateByAddressAndSlot", reflect.TypeOf((*MockMirrorClient)(nil).GetContractStateByAddressAndSlot), ctx, address, slot, timestampTo)
+}
+
+// GetContractsResultsActions mocks base method.
+func (m *MockMirrorClient) GetContractsResultsActions(ctx context.Context, transactionIdOrHash string) (*domain.ContractActionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContractsResultsActions", ctx, transactionIdOrHash)
+	ret0, _ := ret[0].(*domain.ContractActionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContractsResultsActions indicates an expected call of GetContractsResultsActions.
+func (mr *MockMirrorClientMockRecorder) GetContractsResultsActions(ctx, transactionIdOrHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractsResultsActions", reflect.TypeOf((*MockMirrorClient)(nil).GetContractsResultsActions), ctx, transactionIdOrHash)
+}
+
+// GetContractsResultsOpcodes mocks base method.
+func (m *MockMirrorClient) GetContractsResultsOpcodes(ctx context.Context, transactionIdOrHash string, stack, memory, storage bool) (*domain.OpcodesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContractsResultsOpcodes", ctx, transactionIdOrHash, stack, memory, storage)
+	ret0, _ := ret[0].(*domain.OpcodesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContractsResultsOpcodes indicates an expected call of GetContractsResultsOpcodes.
+func (mr *MockMirrorClientMockRecorder) GetContractsResultsOpcodes(ctx, transactionIdOrHash, stack, memory, storage interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractsResultsOpcodes", reflect.TypeOf((*MockMirrorClient)(nil).GetContractsResultsOpcodes), ctx, transactionIdOrHash, stack, memory, storage)
+}
+
+// GetExchangeRate mocks base method.
+func (m *MockMirrorClient) GetExchangeRate(ctx context.Context) (*domain.ExchangeRateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRate", ctx)
+	ret0, _ := ret[0].(*domain.ExchangeRateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRate indicates an expected call of GetExchangeRate.
+func (mr *MockMirrorClientMockRecorder) GetExchangeRate(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractStateByAddressAndSlot", reflect.TypeOf((*MockMirrorClient)(nil).GetContractStateByAddressAndSlot), address, slot, timestampTo)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRate", reflect.TypeOf((*MockMirrorClient)(nil).GetExchangeRate), ctx)
 }
 
 // GetLatestBlock mocks base method.
-func (m *MockMirrorClient) GetLatestBlock() (map[string]interface{}, error) {
+func (m *MockMirrorClient) GetLatestBlock(ctx context.Context) (map[string]interface{}, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLatestBlock")
+	ret := m.ctrl.Call(m, "GetLatestBlock", ctx)
 	ret0, _ := ret[0].(map[string]interface{})
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetLatestBlock indicates an expected call of GetLatestBlock.
-func (mr *MockMirrorClientMockRecorder) GetLatestBlock() *gomock.Call {
+func (mr *MockMirrorClientMockRecorder) GetLatestBlock(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestBlock", reflect.TypeOf((*MockMirrorClient)(nil).GetLatestBlock))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestBlock", reflect.TypeOf((*MockMirrorClient)(nil).GetLatestBlock), ctx)
 }
 
 // GetNetworkFees mocks base method.
-func (m *MockMirrorClient) GetNetworkFees(timestampTo, order string) (int64, error) {
+func (m *MockMirrorClient) GetNetworkFees(ctx context.Context, timestampTo, order string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNetworkFees", timestampTo, order)
+	ret := m.ctrl.Call(m, "GetNetworkFees", ctx, timestampTo, order)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetNetworkFees indicates an expected call of GetNetworkFees.
-func (mr *MockMirrorClientMockRecorder) GetNetworkFees(timestampTo, order interface{}) *gomock.Call {
+func (mr *MockMirrorClientMockRecorder) GetNetworkFees(ctx, timestampTo, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkFees", reflect.TypeOf((*MockMirrorClient)(nil).GetNetworkFees), ctx, timestampTo, order)
+}
+
+// GetTokenAllowance mocks base method.
+func (m *MockMirrorClient) GetTokenAllowance(ctx context.Context, owner, spender, tokenId string) (*domain.TokenAllowance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokenAllowance", ctx, owner, spender, tokenId)
+	ret0, _ := ret[0].(*domain.TokenAllowance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokenAllowance indicates an expected call of GetTokenAllowance.
+func (mr *MockMirrorClientMockRecorder) GetTokenAllowance(ctx, owner, spender, tokenId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkFees", reflect.TypeOf((*MockMirrorClient)(nil).GetNetworkFees), timestampTo, order)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokenAllowance", reflect.TypeOf((*MockMirrorClient)(nil).GetTokenAllowance), ctx, owner, spender, tokenId)
 }
 
 // GetTokenById mocks base method.
-func (m *MockMirrorClient) GetTokenById(tokenId string) (*domain.TokenResponse, error) {
+func (m *MockMirrorClient) GetTokenById(ctx context.Context, tokenId string) (*domain.TokenResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTokenById", tokenId)
+	ret := m.ctrl.Call(m, "GetTokenById", ctx, tokenId)
 	ret0, _ := ret[0].(*domain.TokenResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTokenById indicates an expected call of GetTokenById.
-func (mr *MockMirrorClientMockRecorder) GetTokenById(tokenId interface{}) *gomock.Call {
+func (mr *MockMirrorClientMockRecorder) GetTokenById(ctx, tokenId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokenById", reflect.TypeOf((*MockMirrorClient)(nil).GetTokenById), tokenId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokenById", reflect.TypeOf((*MockMirrorClient)(nil).GetTokenById), ctx, tokenId)
+}
+
+// GetTokenRelationships mocks base method.
+func (m *MockMirrorClient) GetTokenRelationships(ctx context.Context, idOrAliasOrEvmAddress string) ([]domain.TokenRelationship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokenRelationships", ctx, idOrAliasOrEvmAddress)
+	ret0, _ := ret[0].([]domain.TokenRelationship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokenRelationships indicates an expected call of GetTokenRelationships.
+func (mr *MockMirrorClientMockRecorder) GetTokenRelationships(ctx, idOrAliasOrEvmAddress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokenRelationships", reflect.TypeOf((*MockMirrorClient)(nil).GetTokenRelationships), ctx, idOrAliasOrEvmAddress)
 }
 
 // PostCall mocks base method.
-func (m *MockMirrorClient) PostCall(callObject map[string]interface{}) interface{} {
+func (m *MockMirrorClient) PostCall(ctx context.Context, callObject map[string]interface{}) (interface{}, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PostCall", callObject)
+	ret := m.ctrl.Call(m, "PostCall", ctx, callObject)
 	ret0, _ := ret[0].(interface{})
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // PostCall indicates an expected call of PostCall.
-func (mr *MockMirrorClientMockRecorder) PostCall(callObject interface{}) *gomock.Call {
+func (mr *MockMirrorClientMockRecorder) PostCall(ctx, callObject interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostCall", reflect.TypeOf((*MockMirrorClient)(nil).PostCall), callObject)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostCall", reflect.TypeOf((*MockMirrorClient)(nil).PostCall), ctx, callObject)
 }
 
 // RepeatGetContractResult mocks base method.
-func (m *MockMirrorClient) RepeatGetContractResult(transactionIdOrHash string, retries int) *domain.ContractResultResponse {
+func (m *MockMirrorClient) RepeatGetContractResult(ctx context.Context, transactionIdOrHash string, retries int) (*domain.ContractResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RepeatGetContractResult", transactionIdOrHash, retries)
-	ret0, _ := ret[0].(*domain.ContractResultResponse)
-	return ret0
+	ret := m.ctrl.Call(m, "RepeatGetContractResult", ctx, transactionIdOrHash, retries)
+	ret0, _ := ret[0].(*domain.ContractResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // RepeatGetContractResult indicates an expected call of RepeatGetContractResult.
-func (mr *MockMirrorClientMockRecorder) RepeatGetContractResult(transactionIdOrHash, retries interface{}) *gomock.Call {
+func (mr *MockMirrorClientMockRecorder) RepeatGetContractResult(ctx, transactionIdOrHash, retries interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepeatGetContractResult", reflect.TypeOf((*MockMirrorClient)(nil).RepeatGetContractResult), ctx, transactionIdOrHash, retries)
+}
+
+// StreamContractResultsLogs mocks base method.
+func (m *MockMirrorClient) StreamContractResultsLogs(ctx context.Context, address string, queryParams map[string]interface{}, handle func([]domain.LogEntry) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamContractResultsLogs", ctx, address, queryParams, handle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamContractResultsLogs indicates an expected call of StreamContractResultsLogs.
+func (mr *MockMirrorClientMockRecorder) StreamContractResultsLogs(ctx, address, queryParams, handle interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepeatGetContractResult", reflect.TypeOf((*MockMirrorClient)(nil).RepeatGetContractResult), transactionIdOrHash, retries)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamContractResultsLogs", reflect.TypeOf((*MockMirrorClient)(nil).StreamContractResultsLogs), ctx, address, queryParams, handle)
 }
@@ -0,0 +1,170 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/eth_transaction_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/LimeChain/Hederium/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTransactionService is a mock of TransactionService interface.
+type MockTransactionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionServiceMockRecorder
+}
+
+// MockTransactionServiceMockRecorder is the mock recorder for MockTransactionService.
+type MockTransactionServiceMockRecorder struct {
+	mock *MockTransactionService
+}
+
+// NewMockTransactionService creates a new mock instance.
+func NewMockTransactionService(ctrl *gomock.Controller) *MockTransactionService {
+	mock := &MockTransactionService{ctrl: ctrl}
+	mock.recorder = &MockTransactionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionService) EXPECT() *MockTransactionServiceMockRecorder {
+	return m.recorder
+}
+
+// Call mocks base method.
+func (m *MockTransactionService) Call(ctx context.Context, transaction, blockParam interface{}) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Call", ctx, transaction, blockParam)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// Call indicates an expected call of Call.
+func (mr *MockTransactionServiceMockRecorder) Call(ctx, transaction, blockParam interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Call", reflect.TypeOf((*MockTransactionService)(nil).Call), ctx, transaction, blockParam)
+}
+
+// CreateAccessList mocks base method.
+func (m *MockTransactionService) CreateAccessList(ctx context.Context, transaction, blockParam interface{}) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccessList", ctx, transaction, blockParam)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// CreateAccessList indicates an expected call of CreateAccessList.
+func (mr *MockTransactionServiceMockRecorder) CreateAccessList(ctx, transaction, blockParam interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccessList", reflect.TypeOf((*MockTransactionService)(nil).CreateAccessList), ctx, transaction, blockParam)
+}
+
+// EstimateGas mocks base method.
+func (m *MockTransactionService) EstimateGas(ctx context.Context, transaction, blockParam interface{}) (string, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateGas", ctx, transaction, blockParam)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// EstimateGas indicates an expected call of EstimateGas.
+func (mr *MockTransactionServiceMockRecorder) EstimateGas(ctx, transaction, blockParam interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateGas", reflect.TypeOf((*MockTransactionService)(nil).EstimateGas), ctx, transaction, blockParam)
+}
+
+// GetTransactionByBlockHashAndIndex mocks base method.
+func (m *MockTransactionService) GetTransactionByBlockHashAndIndex(ctx context.Context, blockHash, txIndex string) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionByBlockHashAndIndex", ctx, blockHash, txIndex)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// GetTransactionByBlockHashAndIndex indicates an expected call of GetTransactionByBlockHashAndIndex.
+func (mr *MockTransactionServiceMockRecorder) GetTransactionByBlockHashAndIndex(ctx, blockHash, txIndex interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByBlockHashAndIndex", reflect.TypeOf((*MockTransactionService)(nil).GetTransactionByBlockHashAndIndex), ctx, blockHash, txIndex)
+}
+
+// GetTransactionByBlockNumberAndIndex mocks base method.
+func (m *MockTransactionService) GetTransactionByBlockNumberAndIndex(ctx context.Context, blockNumberOrTag, txIndex string) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionByBlockNumberAndIndex", ctx, blockNumberOrTag, txIndex)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// GetTransactionByBlockNumberAndIndex indicates an expected call of GetTransactionByBlockNumberAndIndex.
+func (mr *MockTransactionServiceMockRecorder) GetTransactionByBlockNumberAndIndex(ctx, blockNumberOrTag, txIndex interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByBlockNumberAndIndex", reflect.TypeOf((*MockTransactionService)(nil).GetTransactionByBlockNumberAndIndex), ctx, blockNumberOrTag, txIndex)
+}
+
+// GetTransactionByHash mocks base method.
+func (m *MockTransactionService) GetTransactionByHash(ctx context.Context, hash string) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionByHash", ctx, hash)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// GetTransactionByHash indicates an expected call of GetTransactionByHash.
+func (mr *MockTransactionServiceMockRecorder) GetTransactionByHash(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByHash", reflect.TypeOf((*MockTransactionService)(nil).GetTransactionByHash), ctx, hash)
+}
+
+// GetTransactionReceipt mocks base method.
+func (m *MockTransactionService) GetTransactionReceipt(ctx context.Context, hash string) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionReceipt", ctx, hash)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// GetTransactionReceipt indicates an expected call of GetTransactionReceipt.
+func (mr *MockTransactionServiceMockRecorder) GetTransactionReceipt(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionReceipt", reflect.TypeOf((*MockTransactionService)(nil).GetTransactionReceipt), ctx, hash)
+}
+
+// ProcessTransactionResponse mocks base method.
+func (m *MockTransactionService) ProcessTransactionResponse(ctx context.Context, contractResult domain.ContractResult) interface{} {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessTransactionResponse", ctx, contractResult)
+	ret0, _ := ret[0].(interface{})
+	return ret0
+}
+
+// ProcessTransactionResponse indicates an expected call of ProcessTransactionResponse.
+func (mr *MockTransactionServiceMockRecorder) ProcessTransactionResponse(ctx, contractResult interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessTransactionResponse", reflect.TypeOf((*MockTransactionService)(nil).ProcessTransactionResponse), ctx, contractResult)
+}
+
+// SendRawTransaction mocks base method.
+func (m *MockTransactionService) SendRawTransaction(ctx context.Context, data string) (interface{}, *domain.RPCError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendRawTransaction", ctx, data)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(*domain.RPCError)
+	return ret0, ret1
+}
+
+// SendRawTransaction indicates an expected call of SendRawTransaction.
+func (mr *MockTransactionServiceMockRecorder) SendRawTransaction(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendRawTransaction", reflect.TypeOf((*MockTransactionService)(nil).SendRawTransaction), ctx, data)
+}
@@ -0,0 +1,108 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
+	"github.com/LimeChain/Hederium/internal/service"
+	"github.com/LimeChain/Hederium/test/unit/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBlockPoller_ServesLatestBlockFromMemory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	logger := zap.NewNop()
+	cacheService := cache.NewMemoryCache(time.Minute, time.Minute)
+
+	block := &domain.BlockResponse{
+		Count:     2,
+		Hash:      "0x" + "ab12" + "0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		Number:    100,
+		Timestamp: domain.Timestamp{From: "1700000000.000000000", To: "1700000001.999999999"},
+	}
+	mockClient.EXPECT().GetLatestBlock(gomock.Any()).Return(map[string]interface{}{"number": float64(100)}, nil).MinTimes(1)
+	// The block is fetched once, by the poller; requests are answered from the cache
+	mockClient.EXPECT().GetBlockByHashOrNumber(gomock.Any(), "100").Return(block, nil).Times(1)
+	mockClient.EXPECT().GetContractResults(gomock.Any(), block.Timestamp).Return([]domain.ContractResult{}, nil).AnyTimes()
+	mockClient.EXPECT().GetNetworkFees(gomock.Any(), "", "").Return(int64(71), nil).Times(1)
+
+	poller := service.NewBlockPoller(mockClient, logger, 10*time.Millisecond)
+	commonService := service.NewCommonService(mockClient, logger, cacheService, nil, 0, poller)
+	ethService := service.NewEthService(nil, mockClient, commonService, logger, nil, defaultChainId, cacheService, service.Config{})
+	ethService.FollowBlockPoller(poller)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go poller.Run(ctx)
+
+	require.Eventually(t, func() bool {
+		var gasPrice string
+		return cacheService.Get(ctx, service.GetGasPrice, &gasPrice) == nil
+	}, time.Second, 5*time.Millisecond)
+
+	blockNumber, errRpc := ethService.GetBlockNumber(ctx)
+	assert.Nil(t, errRpc)
+	assert.Equal(t, "0x64", blockNumber)
+
+	latest, errRpc := ethService.GetBlockByNumber(ctx, "latest", false)
+	require.Nil(t, errRpc)
+	assert.Equal(t, "0x64", *latest.(*domain.Block).Number)
+
+	byHash, errRpc := ethService.GetBlockByHash(ctx, block.Hash[:66], true)
+	require.Nil(t, errRpc)
+	assert.Equal(t, block.Hash[:66], *byHash.(domain.Block).Hash)
+
+	count, errRpc := ethService.GetBlockTransactionCountByNumber(ctx, "latest")
+	assert.Nil(t, errRpc)
+	assert.Equal(t, "0x2", count)
+
+	gasPrice, errRpc := ethService.GetGasPrice(ctx)
+	assert.Nil(t, errRpc)
+	assert.Equal(t, "0xa54f4c3c00", gasPrice)
+}
+
+func TestBlockPoller_StaleWhenPollsFail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	var failing atomic.Bool
+	mockClient.EXPECT().GetLatestBlock(gomock.Any()).DoAndReturn(func(context.Context) (map[string]interface{}, error) {
+		if failing.Load() {
+			return nil, errors.New("mirror node unavailable")
+		}
+		return map[string]interface{}{"number": float64(7)}, nil
+	}).AnyTimes()
+	mockClient.EXPECT().GetBlockByHashOrNumber(gomock.Any(), "7").Return(&domain.BlockResponse{Number: 7}, nil).Times(1)
+
+	poller := service.NewBlockPoller(mockClient, zap.NewNop(), 5*time.Millisecond)
+	_, ok := poller.Latest()
+	assert.False(t, ok)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go poller.Run(ctx)
+
+	require.Eventually(t, func() bool {
+		latest, ok := poller.Latest()
+		return ok && latest.Number == 7
+	}, time.Second, time.Millisecond)
+
+	// Requests go back to the mirror node rather than serve an outdated head
+	failing.Store(true)
+	require.Eventually(t, func() bool {
+		_, ok := poller.Latest()
+		return !ok
+	}, time.Second, time.Millisecond)
+}
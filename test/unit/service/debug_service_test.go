@@ -0,0 +1,177 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	"github.com/LimeChain/Hederium/internal/service"
+	"github.com/LimeChain/Hederium/test/unit/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+const traceTxHash = "0x2c4c1e7cbbcd6aa8fc5c5e1b3e4bc7b8b5c6e7e2a9e2b8b2d7c9e3a0b1c2d3e4"
+
+func setupDebugTest(t *testing.T, enabled bool) (*gomock.Controller, *mocks.MockMirrorClient, service.DebugServicer) {
+	ctrl := gomock.NewController(t)
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	debugService := service.NewDebugService(mockClient, zap.NewNop(), enabled)
+
+	return ctrl, mockClient, debugService
+}
+
+func TestTraceTransaction_Disabled(t *testing.T) {
+	ctrl, _, debugService := setupDebugTest(t, false)
+	defer ctrl.Finish()
+
+	result, errRpc := debugService.TraceTransaction(context.Background(), traceTxHash, domain.CallTracer, domain.TracerConfig{})
+
+	assert.Nil(t, result)
+	assert.NotNil(t, errRpc)
+	assert.Equal(t, domain.MethodNotFound, errRpc.Code)
+}
+
+func TestTraceTransaction_CallTracer(t *testing.T) {
+	ctrl, mockClient, debugService := setupDebugTest(t, true)
+	defer ctrl.Finish()
+
+	// Error(string) encoding of "Not enough"
+	revertData := "0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"000000000000000000000000000000000000000000000000000000000000000a" +
+		"4e6f7420656e6f75676800000000000000000000000000000000000000000000"
+
+	from := "0xc37f417fa09933335240fca72dd257bfbde9c275"
+	contract := "0x637a6a8e5a69c087c24983b05261f63f64ed7e9b"
+	nested := "0x91b1c451777122afc9b83f9b96160d7e59847ad7"
+
+	mockClient.EXPECT().GetContractsResultsActions(gomock.Any(), traceTxHash).Return(&domain.ContractActionsResponse{
+		Actions: []domain.ContractAction{
+			{CallDepth: 0, CallType: "CALL", CallOperationType: "CALL", From: from, To: contract, Gas: 400000, GasUsed: 5000, Input: "0x1234", ResultData: "0x", ResultDataType: "OUTPUT"},
+			{CallDepth: 1, CallType: "CALL", CallOperationType: "STATICCALL", From: contract, To: nested, Gas: 300000, GasUsed: 1000, Input: "0xabcd", ResultData: "0x01", ResultDataType: "OUTPUT"},
+			{CallDepth: 2, CallType: "CALL", CallOperationType: "CALL", From: nested, To: contract, Gas: 200000, GasUsed: 500, Input: "0x", ResultData: revertData, ResultDataType: "REVERT_REASON", Value: 10},
+			{CallDepth: 1, CallType: "CALL", CallOperationType: "DELEGATECALL", From: contract, To: nested, Gas: 100000, GasUsed: 200, Input: "0x", ResultData: "0x", ResultDataType: "OUTPUT"},
+		},
+	}, nil)
+
+	mockClient.EXPECT().GetContractResult(gomock.Any(), traceTxHash).Return(&domain.ContractResult{
+		From:               from,
+		To:                 contract,
+		Amount:             0,
+		GasLimit:           400000,
+		GasUsed:            5000,
+		FunctionParameters: "0x1234",
+		CallResult:         "0x",
+		Result:             "SUCCESS",
+	}, nil)
+
+	result, errRpc := debugService.TraceTransaction(context.Background(), traceTxHash, domain.CallTracer, domain.TracerConfig{})
+
+	assert.Nil(t, errRpc)
+	trace, ok := result.(*domain.CallTrace)
+	assert.True(t, ok)
+	assert.Equal(t, "CALL", trace.Type)
+	assert.Equal(t, from, trace.From)
+	assert.Equal(t, contract, trace.To)
+	assert.Equal(t, "0x0", trace.Value)
+	assert.Equal(t, "0x61a80", trace.Gas)
+	assert.Equal(t, "0x1388", trace.GasUsed)
+	assert.Empty(t, trace.Error)
+
+	// Depth 1 calls hang off the top-level call, depth 2 off the preceding depth 1 call
+	assert.Len(t, trace.Calls, 2)
+	assert.Equal(t, "STATICCALL", trace.Calls[0].Type)
+	assert.Equal(t, "DELEGATECALL", trace.Calls[1].Type)
+	assert.Len(t, trace.Calls[0].Calls, 1)
+
+	reverted := trace.Calls[0].Calls[0]
+	assert.Equal(t, "0xa", reverted.Value)
+	assert.Equal(t, "REVERT_REASON", reverted.Error)
+	assert.Equal(t, "Not enough", reverted.RevertReason)
+}
+
+func TestTraceTransaction_CallTracerOnlyTopCall(t *testing.T) {
+	ctrl, mockClient, debugService := setupDebugTest(t, true)
+	defer ctrl.Finish()
+
+	mockClient.EXPECT().GetContractsResultsActions(gomock.Any(), traceTxHash).Return(&domain.ContractActionsResponse{
+		Actions: []domain.ContractAction{
+			{CallDepth: 0, CallType: "CREATE", CallOperationType: "CREATE"},
+			{CallDepth: 1, CallType: "CALL", CallOperationType: "CALL"},
+		},
+	}, nil)
+
+	errorMessage := "0x"
+	mockClient.EXPECT().GetContractResult(gomock.Any(), traceTxHash).Return(&domain.ContractResult{
+		Result:       "CONTRACT_REVERT_EXECUTED",
+		ErrorMessage: &errorMessage,
+	}, nil)
+
+	result, errRpc := debugService.TraceTransaction(context.Background(), traceTxHash, domain.CallTracer, domain.TracerConfig{OnlyTopCall: true})
+
+	assert.Nil(t, errRpc)
+	trace := result.(*domain.CallTrace)
+	assert.Equal(t, "CREATE", trace.Type)
+	assert.Equal(t, "CONTRACT_REVERT_EXECUTED", trace.Error)
+	assert.Equal(t, errorMessage, trace.Output)
+	assert.Nil(t, trace.Calls)
+}
+
+func TestTraceTransaction_CallTracerNotFound(t *testing.T) {
+	ctrl, mockClient, debugService := setupDebugTest(t, true)
+	defer ctrl.Finish()
+
+	mockClient.EXPECT().GetContractsResultsActions(gomock.Any(), traceTxHash).Return(nil, hedera.ErrNotFound)
+
+	result, errRpc := debugService.TraceTransaction(context.Background(), traceTxHash, domain.CallTracer, domain.TracerConfig{})
+
+	assert.Nil(t, result)
+	assert.NotNil(t, errRpc)
+	assert.Equal(t, -32002, errRpc.Code)
+	assert.NotEqual(t, domain.FilterNotFound, errRpc.Code, "a missing trace target is told apart from a missing filter")
+}
+
+func TestTraceTransaction_OpcodeLogger(t *testing.T) {
+	ctrl, mockClient, debugService := setupDebugTest(t, true)
+	defer ctrl.Finish()
+
+	reason := "0x4e6f7420656e6f756768"
+	mockClient.EXPECT().GetContractsResultsOpcodes(gomock.Any(), traceTxHash, false, true, true).Return(&domain.OpcodesResponse{
+		Gas:         21000,
+		Failed:      true,
+		ReturnValue: "0x0001",
+		Opcodes: []domain.Opcode{
+			{
+				Pc:      1,
+				Op:      "PUSH1",
+				Gas:     20000,
+				GasCost: 3,
+				Depth:   1,
+				Stack:   []string{"0x01"},
+				Memory:  []string{"0x0000000000000000000000000000000000000000000000000000000000000080"},
+				Storage: map[string]string{"0x00": "0x01"},
+				Reason:  &reason,
+			},
+		},
+	}, nil)
+
+	result, errRpc := debugService.TraceTransaction(context.Background(), traceTxHash, domain.OpcodeLogger, domain.TracerConfig{EnableMemory: true, DisableStack: true})
+
+	assert.Nil(t, errRpc)
+	trace := result.(*domain.OpcodeTrace)
+	assert.Equal(t, int64(21000), trace.Gas)
+	assert.True(t, trace.Failed)
+	assert.Equal(t, "0001", trace.ReturnValue)
+	assert.Len(t, trace.StructLogs, 1)
+
+	structLog := trace.StructLogs[0]
+	assert.Equal(t, "PUSH1", structLog.Op)
+	assert.Equal(t, int64(3), structLog.GasCost)
+	assert.Nil(t, structLog.Stack)
+	assert.Equal(t, []string{"0000000000000000000000000000000000000000000000000000000000000080"}, structLog.Memory)
+	assert.Equal(t, map[string]string{"00": "01"}, structLog.Storage)
+	assert.Equal(t, "4e6f7420656e6f756768", *structLog.Reason)
+}
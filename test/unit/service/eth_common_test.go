@@ -1,14 +1,21 @@
 package service_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
+	"github.com/LimeChain/Hederium/internal/infrastructure/hedera"
 	"github.com/LimeChain/Hederium/internal/service"
 	"github.com/LimeChain/Hederium/test/unit/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -17,7 +24,7 @@ func setupCommonTest(t *testing.T) (*gomock.Controller, *mocks.MockMirrorClient,
 	logger, _ := zap.NewDevelopment()
 	mockClient := mocks.NewMockMirrorClient(ctrl)
 	mockCache := mocks.NewMockCacheService(ctrl)
-	commonService := service.NewCommonService(mockClient, logger, mockCache)
+	commonService := service.NewCommonService(mockClient, logger, mockCache, nil, 0, nil)
 
 	return ctrl, mockClient, mockCache, commonService
 }
@@ -38,7 +45,7 @@ func TestGetBlockNumberByNumberOrTag(t *testing.T) {
 			input: "latest",
 			mockSetup: func() {
 				mockClient.EXPECT().
-					GetLatestBlock().
+					GetLatestBlock(gomock.Any()).
 					Return(map[string]interface{}{"number": float64(123)}, nil)
 			},
 			expectedResult: 123,
@@ -49,7 +56,29 @@ func TestGetBlockNumberByNumberOrTag(t *testing.T) {
 			input: "pending",
 			mockSetup: func() {
 				mockClient.EXPECT().
-					GetLatestBlock().
+					GetLatestBlock(gomock.Any()).
+					Return(map[string]interface{}{"number": float64(123)}, nil)
+			},
+			expectedResult: 123,
+			expectError:    false,
+		},
+		{
+			name:  "Safe tag",
+			input: "safe",
+			mockSetup: func() {
+				mockClient.EXPECT().
+					GetLatestBlock(gomock.Any()).
+					Return(map[string]interface{}{"number": float64(123)}, nil)
+			},
+			expectedResult: 123,
+			expectError:    false,
+		},
+		{
+			name:  "Finalized tag",
+			input: "finalized",
+			mockSetup: func() {
+				mockClient.EXPECT().
+					GetLatestBlock(gomock.Any()).
 					Return(map[string]interface{}{"number": float64(123)}, nil)
 			},
 			expectedResult: 123,
@@ -76,13 +105,35 @@ func TestGetBlockNumberByNumberOrTag(t *testing.T) {
 			expectedResult: 0,
 			expectError:    true,
 		},
+		{
+			name:  "Block hash",
+			input: "0x" + strings.Repeat("ab", 32),
+			mockSetup: func() {
+				mockClient.EXPECT().
+					GetBlockByHashOrNumber(gomock.Any(), "0x"+strings.Repeat("ab", 32)).
+					Return(&domain.BlockResponse{Number: 456}, nil)
+			},
+			expectedResult: 456,
+			expectError:    false,
+		},
+		{
+			name:  "Unknown block hash",
+			input: "0x" + strings.Repeat("cd", 32),
+			mockSetup: func() {
+				mockClient.EXPECT().
+					GetBlockByHashOrNumber(gomock.Any(), "0x"+strings.Repeat("cd", 32)).
+					Return(nil, hedera.ErrNotFound)
+			},
+			expectedResult: 0,
+			expectError:    true,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockSetup()
 
-			result, errRpc := commonService.GetBlockNumberByNumberOrTag(tc.input)
+			result, errRpc := commonService.GetBlockNumberByNumberOrTag(context.Background(), tc.input)
 
 			if tc.expectError {
 				assert.NotNil(t, errRpc)
@@ -111,7 +162,7 @@ func TestValidateBlockRange(t *testing.T) {
 			toBlock:   "0x2",
 			mockSetup: func() {
 				mockClient.EXPECT().
-					GetLatestBlock().
+					GetLatestBlock(gomock.Any()).
 					Return(map[string]interface{}{"number": float64(100)}, nil)
 			},
 			expectError: false,
@@ -122,7 +173,7 @@ func TestValidateBlockRange(t *testing.T) {
 			toBlock:   "0x1",
 			mockSetup: func() {
 				mockClient.EXPECT().
-					GetLatestBlock().
+					GetLatestBlock(gomock.Any()).
 					Return(map[string]interface{}{"number": float64(100)}, nil)
 			},
 			expectError: true,
@@ -133,7 +184,7 @@ func TestValidateBlockRange(t *testing.T) {
 			toBlock:   "0x5",
 			mockSetup: func() {
 				mockClient.EXPECT().
-					GetLatestBlock().
+					GetLatestBlock(gomock.Any()).
 					Return(map[string]interface{}{"number": float64(100)}, nil)
 			},
 			expectError: true,
@@ -144,7 +195,7 @@ func TestValidateBlockRange(t *testing.T) {
 			toBlock:   "latest",
 			mockSetup: func() {
 				mockClient.EXPECT().
-					GetLatestBlock().
+					GetLatestBlock(gomock.Any()).
 					Return(map[string]interface{}{"number": float64(100)}, nil)
 			},
 			expectError: false,
@@ -155,7 +206,7 @@ func TestValidateBlockRange(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockSetup()
 
-			errRpc := commonService.ValidateBlockRange(tc.fromBlock, tc.toBlock)
+			errRpc := commonService.ValidateBlockRange(context.Background(), tc.fromBlock, tc.toBlock)
 
 			if tc.expectError {
 				assert.NotNil(t, errRpc)
@@ -186,7 +237,7 @@ func TestGetLogsWithParams(t *testing.T) {
 			},
 			mockSetup: func() {
 				mockClient.EXPECT().
-					GetContractResultsLogsWithRetry(map[string]interface{}{
+					GetContractResultsLogsWithRetry(gomock.Any(), map[string]interface{}{
 						"timestamp": "gte:1672531200&timestamp=lte:1672531202",
 					}).
 					Return([]domain.LogEntry{
@@ -225,7 +276,7 @@ func TestGetLogsWithParams(t *testing.T) {
 			},
 			mockSetup: func() {
 				mockClient.EXPECT().
-					GetContractResultsLogsByAddress("0xaddress", map[string]interface{}{
+					GetContractResultsLogsByAddress(gomock.Any(), "0xaddress", map[string]interface{}{
 						"timestamp": "gte:1672531200&timestamp=lte:1672531202",
 					}).
 					Return([]domain.LogEntry{
@@ -264,7 +315,7 @@ func TestGetLogsWithParams(t *testing.T) {
 			},
 			mockSetup: func() {
 				mockClient.EXPECT().
-					GetContractResultsLogsByAddress("0xaddress", map[string]interface{}{
+					GetContractResultsLogsByAddress(gomock.Any(), "0xaddress", map[string]interface{}{
 						"timestamp": "gte:1672531200&timestamp=lte:1672531202",
 					}).
 					Return(nil, fmt.Errorf("failed to fetch logs"))
@@ -278,7 +329,7 @@ func TestGetLogsWithParams(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockSetup()
 
-			result, err := commonService.GetLogsWithParams(tc.addresses, tc.params)
+			result, err := commonService.GetLogsWithParams(context.Background(), tc.addresses, tc.params)
 
 			if tc.expectError {
 				assert.Error(t, err)
@@ -309,13 +360,13 @@ func TestValidateBlockHashAndAddTimestampToParams(t *testing.T) {
 			params:    make(map[string]interface{}),
 			mockSetup: func() {
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("0x123abc").
+					GetBlockByHashOrNumber(gomock.Any(), "0x123abc").
 					Return(&domain.BlockResponse{
 						Timestamp: domain.Timestamp{
 							From: "2023-01-01T00:00:00.000Z",
 							To:   "2023-01-01T00:00:01.000Z",
 						},
-					})
+					}, nil)
 			},
 			expectError: false,
 			expectedParams: map[string]interface{}{
@@ -328,10 +379,10 @@ func TestValidateBlockHashAndAddTimestampToParams(t *testing.T) {
 			params:    make(map[string]interface{}),
 			mockSetup: func() {
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("0xinvalid").
-					Return(nil)
+					GetBlockByHashOrNumber(gomock.Any(), "0xinvalid").
+					Return(nil, hedera.ErrNotFound)
 			},
-			expectError:    false,
+			expectError:    true,
 			expectedParams: map[string]interface{}{},
 		},
 	}
@@ -340,10 +391,11 @@ func TestValidateBlockHashAndAddTimestampToParams(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockSetup()
 
-			err := commonService.ValidateBlockHashAndAddTimestampToParams(tc.params, tc.blockHash)
+			err := commonService.ValidateBlockHashAndAddTimestampToParams(context.Background(), tc.params, tc.blockHash)
 
 			if tc.expectError {
-				assert.Error(t, err)
+				assert.ErrorIs(t, err, hedera.ErrNotFound)
+				assert.Equal(t, tc.expectedParams, tc.params)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tc.expectedParams, tc.params)
@@ -375,28 +427,28 @@ func TestValidateBlockRangeAndAddTimestampToParams(t *testing.T) {
 			params:    make(map[string]interface{}),
 			mockSetup: func() {
 				mockClient.EXPECT().
-					GetLatestBlock().
+					GetLatestBlock(gomock.Any()).
 					Return(map[string]interface{}{"number": float64(100)}, nil)
 
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("1").
+					GetBlockByHashOrNumber(gomock.Any(), "1").
 					Return(&domain.BlockResponse{
 						Number: 1,
 						Timestamp: domain.Timestamp{
 							From: "1672531200",
 							To:   "1672531201",
 						},
-					})
+					}, nil)
 
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("2").
+					GetBlockByHashOrNumber(gomock.Any(), "2").
 					Return(&domain.BlockResponse{
 						Number: 2,
 						Timestamp: domain.Timestamp{
 							From: "1672531201",
 							To:   "1672531202",
 						},
-					})
+					}, nil)
 			},
 			expectOk:    true,
 			expectError: false,
@@ -405,35 +457,103 @@ func TestValidateBlockRangeAndAddTimestampToParams(t *testing.T) {
 			},
 		},
 		{
-			name:      "Block range too large",
+			name:      "Block range longer than 7 days is split",
 			fromBlock: "0x1",
 			toBlock:   "0x64", // 100 in hex
 			address:   []string{"0xaddress"},
 			params:    make(map[string]interface{}),
 			mockSetup: func() {
 				mockClient.EXPECT().
-					GetLatestBlock().
+					GetLatestBlock(gomock.Any()).
 					Return(map[string]interface{}{"number": float64(100)}, nil)
 
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("1").
+					GetBlockByHashOrNumber(gomock.Any(), "1").
 					Return(&domain.BlockResponse{
 						Number: 1,
 						Timestamp: domain.Timestamp{
 							From: "1672531200",
 							To:   "1672531201",
 						},
-					})
+					}, nil)
 
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("100").
+					GetBlockByHashOrNumber(gomock.Any(), "100").
 					Return(&domain.BlockResponse{
 						Number: 100,
 						Timestamp: domain.Timestamp{
 							From: "1673222400", // 8 days later
 							To:   "1673222401",
 						},
-					})
+					}, nil)
+			},
+			expectOk:    true,
+			expectError: false,
+			expectedParams: map[string]interface{}{
+				"timestamp": []string{
+					"gte:1672531200.000000000&timestamp=lte:1672876800.500000000",
+					"gte:1672876800.500000001&timestamp=lte:1673222401.000000000",
+				},
+			},
+		},
+		{
+			name:      "Multiple addresses over block range limit",
+			fromBlock: "0x1",
+			toBlock:   "0x7d0", // 2000 in hex
+			address:   []string{"0xaddress1", "0xaddress2"},
+			params:    make(map[string]interface{}),
+			mockSetup: func() {
+				mockClient.EXPECT().
+					GetLatestBlock(gomock.Any()).
+					Return(map[string]interface{}{"number": float64(3000)}, nil)
+
+				mockClient.EXPECT().
+					GetBlockByHashOrNumber(gomock.Any(), "1").
+					Return(&domain.BlockResponse{
+						Number:    1,
+						Timestamp: domain.Timestamp{From: "1672531200.000000000", To: "1672531201.999999999"},
+					}, nil)
+
+				mockClient.EXPECT().
+					GetBlockByHashOrNumber(gomock.Any(), "2000").
+					Return(&domain.BlockResponse{
+						Number:    2000,
+						Timestamp: domain.Timestamp{From: "1672535198.000000000", To: "1672535199.999999999"},
+					}, nil)
+			},
+			expectOk:    true,
+			expectError: false,
+			expectedParams: map[string]interface{}{
+				"timestamp": []string{
+					"gte:1672531200.000000000&timestamp=lte:1672533199.999999999",
+					"gte:1672533200.000000000&timestamp=lte:1672535199.999999999",
+				},
+			},
+		},
+		{
+			name:      "From block after to block",
+			fromBlock: "0x2",
+			toBlock:   "0x1",
+			address:   []string{"0xaddress"},
+			params:    make(map[string]interface{}),
+			mockSetup: func() {
+				mockClient.EXPECT().
+					GetLatestBlock(gomock.Any()).
+					Return(map[string]interface{}{"number": float64(100)}, nil)
+
+				mockClient.EXPECT().
+					GetBlockByHashOrNumber(gomock.Any(), "2").
+					Return(&domain.BlockResponse{
+						Number:    2,
+						Timestamp: domain.Timestamp{From: "1672531201", To: "1672531202"},
+					}, nil)
+
+				mockClient.EXPECT().
+					GetBlockByHashOrNumber(gomock.Any(), "1").
+					Return(&domain.BlockResponse{
+						Number:    1,
+						Timestamp: domain.Timestamp{From: "1672531200", To: "1672531201"},
+					}, nil)
 			},
 			expectOk:       false,
 			expectError:    true,
@@ -445,7 +565,7 @@ func TestValidateBlockRangeAndAddTimestampToParams(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockSetup()
 
-			ok, errRpc := commonService.ValidateBlockRangeAndAddTimestampToParams(tc.params, tc.fromBlock, tc.toBlock, tc.address)
+			ok, errRpc := commonService.ValidateBlockRangeAndAddTimestampToParams(context.Background(), tc.params, tc.fromBlock, tc.toBlock, tc.address)
 
 			assert.Equal(t, tc.expectOk, ok)
 			if tc.expectError {
@@ -473,20 +593,20 @@ func TestCommonGetLogs(t *testing.T) {
 			name: "Success with block hash",
 			logParams: domain.LogParams{
 				BlockHash: "0x123abc",
-				Topics:    []string{"0xtopic1", "0xtopic2"},
+				Topics:    domain.TopicFilter{{"0xtopic1"}, {"0xtopic2"}},
 			},
 			mockSetup: func() {
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("0x123abc").
+					GetBlockByHashOrNumber(gomock.Any(), "0x123abc").
 					Return(&domain.BlockResponse{
 						Timestamp: domain.Timestamp{
 							From: "1672531200",
 							To:   "1672531201",
 						},
-					})
+					}, nil)
 
 				mockClient.EXPECT().
-					GetContractResultsLogsWithRetry(map[string]interface{}{
+					GetContractResultsLogsWithRetry(gomock.Any(), map[string]interface{}{
 						"timestamp": "gte:1672531200&timestamp=lte:1672531201",
 						"topic0":    "0xtopic1",
 						"topic1":    "0xtopic2",
@@ -529,34 +649,34 @@ func TestCommonGetLogs(t *testing.T) {
 			mockSetup: func() {
 				// Mock GetLatestBlock for block range validation
 				mockClient.EXPECT().
-					GetLatestBlock().
+					GetLatestBlock(gomock.Any()).
 					Return(map[string]interface{}{"number": float64(100)}, nil)
 
 				// Mock getting from block
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("1").
+					GetBlockByHashOrNumber(gomock.Any(), "1").
 					Return(&domain.BlockResponse{
 						Number: 1,
 						Timestamp: domain.Timestamp{
 							From: "1672531200",
 							To:   "1672531201",
 						},
-					})
+					}, nil)
 
 				// Mock getting to block
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("2").
+					GetBlockByHashOrNumber(gomock.Any(), "2").
 					Return(&domain.BlockResponse{
 						Number: 2,
 						Timestamp: domain.Timestamp{
 							From: "1672531201",
 							To:   "1672531202",
 						},
-					})
+					}, nil)
 
 				// Mock getting logs
 				mockClient.EXPECT().
-					GetContractResultsLogsByAddress("0xaddress1", map[string]interface{}{
+					GetContractResultsLogsByAddress(gomock.Any(), "0xaddress1", map[string]interface{}{
 						"timestamp": "gte:1672531200&timestamp=lte:1672531202",
 					}).
 					Return([]domain.LogEntry{
@@ -594,13 +714,8 @@ func TestCommonGetLogs(t *testing.T) {
 			},
 			mockSetup: func() {
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("0xnonexistent").
-					Return(nil)
-
-				// When block is not found, GetContractResultsLogsWithRetry is called with empty params
-				mockClient.EXPECT().
-					GetContractResultsLogsWithRetry(map[string]interface{}{}).
-					Return([]domain.LogEntry{}, nil)
+					GetBlockByHashOrNumber(gomock.Any(), "0xnonexistent").
+					Return(nil, hedera.ErrNotFound)
 			},
 			expectedResult: []domain.Log{},
 			expectError:    false,
@@ -612,16 +727,16 @@ func TestCommonGetLogs(t *testing.T) {
 			},
 			mockSetup: func() {
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("0x123abc").
+					GetBlockByHashOrNumber(gomock.Any(), "0x123abc").
 					Return(&domain.BlockResponse{
 						Timestamp: domain.Timestamp{
 							From: "1672531200",
 							To:   "1672531201",
 						},
-					})
+					}, nil)
 
 				mockClient.EXPECT().
-					GetContractResultsLogsWithRetry(map[string]interface{}{
+					GetContractResultsLogsWithRetry(gomock.Any(), map[string]interface{}{
 						"timestamp": "gte:1672531200&timestamp=lte:1672531201",
 					}).
 					Return(nil, fmt.Errorf("failed to fetch logs"))
@@ -635,7 +750,7 @@ func TestCommonGetLogs(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockSetup()
 
-			result, errRpc := commonService.GetLogs(tc.logParams)
+			result, errRpc := commonService.GetLogs(context.Background(), tc.logParams)
 
 			if tc.expectError {
 				assert.NotNil(t, errRpc)
@@ -661,7 +776,7 @@ func TestCommonGetBlockNumber(t *testing.T) {
 			name: "Success",
 			mockSetup: func() {
 				mockClient.EXPECT().
-					GetLatestBlock().
+					GetLatestBlock(gomock.Any()).
 					Return(map[string]interface{}{
 						"number": float64(42),
 					}, nil)
@@ -673,7 +788,7 @@ func TestCommonGetBlockNumber(t *testing.T) {
 			name: "Error getting latest block",
 			mockSetup: func() {
 				mockClient.EXPECT().
-					GetLatestBlock().
+					GetLatestBlock(gomock.Any()).
 					Return(nil, fmt.Errorf("failed to fetch block"))
 			},
 			expectedResult: nil,
@@ -683,7 +798,7 @@ func TestCommonGetBlockNumber(t *testing.T) {
 			name: "Invalid block number type",
 			mockSetup: func() {
 				mockClient.EXPECT().
-					GetLatestBlock().
+					GetLatestBlock(gomock.Any()).
 					Return(map[string]interface{}{
 						"number": "not a number",
 					}, nil)
@@ -697,7 +812,7 @@ func TestCommonGetBlockNumber(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockSetup()
 
-			result, errRpc := commonService.GetBlockNumber()
+			result, errRpc := commonService.GetBlockNumber(context.Background())
 
 			if tc.expectError {
 				assert.NotNil(t, errRpc)
@@ -709,3 +824,330 @@ func TestCommonGetBlockNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestGetLogsWithParams_TimestampWindows(t *testing.T) {
+	ctrl, mockClient, _, commonService := setupCommonTest(t)
+	defer ctrl.Finish()
+
+	entry := func(block int64) domain.LogEntry {
+		return domain.LogEntry{
+			Address:          "0xaddress",
+			BlockHash:        "0xblockhash",
+			BlockNumber:      ptr(block),
+			TransactionIndex: ptr(0),
+			Index:            ptr(0),
+		}
+	}
+
+	gomock.InOrder(
+		mockClient.EXPECT().
+			GetContractResultsLogsByAddress(gomock.Any(), "0xaddress", map[string]interface{}{
+				"timestamp": "gte:1.000000000&timestamp=lte:1.999999999",
+				"topic0":    "0xtopic",
+			}).
+			Return([]domain.LogEntry{entry(1)}, nil),
+		mockClient.EXPECT().
+			GetContractResultsLogsByAddress(gomock.Any(), "0xaddress", map[string]interface{}{
+				"timestamp": "gte:2.000000000&timestamp=lte:2.999999999",
+				"topic0":    "0xtopic",
+			}).
+			Return([]domain.LogEntry{entry(2)}, nil),
+	)
+
+	logs, err := commonService.GetLogsWithParams(context.Background(), []string{"0xaddress"}, map[string]interface{}{
+		"timestamp": []string{
+			"gte:1.000000000&timestamp=lte:1.999999999",
+			"gte:2.000000000&timestamp=lte:2.999999999",
+		},
+		"topic0": "0xtopic",
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, logs, 2)
+	assert.Equal(t, "0x1", logs[0].BlockNumber)
+	assert.Equal(t, "0x2", logs[1].BlockNumber)
+}
+
+func TestGetLogsWithParams_MergesAddresses(t *testing.T) {
+	ctrl, mockClient, _, commonService := setupCommonTest(t)
+	defer ctrl.Finish()
+
+	entry := func(address string, block int64, index int) domain.LogEntry {
+		return domain.LogEntry{
+			Address:          address,
+			BlockHash:        "0xblockhash",
+			BlockNumber:      ptr(block),
+			TransactionIndex: ptr(0),
+			Index:            ptr(index),
+		}
+	}
+	params := map[string]interface{}{"timestamp": "gte:1.000000000&timestamp=lte:9.999999999"}
+
+	// 0xADDRESS1 repeats 0xaddress1 and is not queried again; 0xaddress3
+	// names the contract of 0xaddress2 by another address
+	mockClient.EXPECT().
+		GetContractResultsLogsByAddress(gomock.Any(), "0xaddress1", params).
+		Return([]domain.LogEntry{entry("0xaddress1", 3, 0), entry("0xaddress1", 1, 1)}, nil)
+	mockClient.EXPECT().
+		GetContractResultsLogsByAddress(gomock.Any(), "0xaddress2", params).
+		Return([]domain.LogEntry{entry("0xaddress2", 1, 0), entry("0xaddress2", 2, 4)}, nil)
+	mockClient.EXPECT().
+		GetContractResultsLogsByAddress(gomock.Any(), "0xaddress3", params).
+		Return([]domain.LogEntry{entry("0xaddress2", 2, 4)}, nil)
+
+	logs, err := commonService.GetLogsWithParams(context.Background(), []string{"0xaddress1", "0xaddress2", "0xADDRESS1", "0xaddress3"}, params)
+
+	require.NoError(t, err)
+	var order []string
+	for _, log := range logs {
+		order = append(order, log.BlockNumber+"/"+log.LogIndex)
+	}
+	assert.Equal(t, []string{"0x1/0x0", "0x1/0x1", "0x2/0x4", "0x3/0x0"}, order)
+}
+
+func TestGetLogsWithParams_CombinedLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	commonService := service.NewCommonService(mockClient, zap.NewNop(), nil, nil, 2, nil)
+
+	entry := func(index int) domain.LogEntry {
+		return domain.LogEntry{BlockNumber: ptr(int64(1)), TransactionIndex: ptr(0), Index: ptr(index)}
+	}
+	mockClient.EXPECT().
+		GetContractResultsLogsByAddress(gomock.Any(), "0xaddress1", gomock.Any()).
+		Return([]domain.LogEntry{entry(0), entry(1)}, nil)
+	mockClient.EXPECT().
+		GetContractResultsLogsByAddress(gomock.Any(), "0xaddress2", gomock.Any()).
+		Return([]domain.LogEntry{entry(2)}, nil)
+
+	// Neither address alone has more logs than the limit, both together do
+	_, err := commonService.GetLogsWithParams(context.Background(), []string{"0xaddress1", "0xaddress2"}, map[string]interface{}{})
+	assert.ErrorIs(t, err, service.ErrLogLimitExceeded)
+}
+
+func TestCommonGetLogs_TopicAlternatives(t *testing.T) {
+	ctrl, mockClient, _, commonService := setupCommonTest(t)
+	defer ctrl.Finish()
+
+	mockClient.EXPECT().
+		GetBlockByHashOrNumber(gomock.Any(), "0x123abc").
+		Return(&domain.BlockResponse{Timestamp: domain.Timestamp{From: "1672531200", To: "1672531201"}}, nil)
+
+	// Alternatives are sent as a repeated param, positions without any are left out
+	mockClient.EXPECT().
+		GetContractResultsLogsWithRetry(gomock.Any(), map[string]interface{}{
+			"timestamp": "gte:1672531200&timestamp=lte:1672531201",
+			"topic0":    "0xtopica&topic0=0xtopicb",
+			"topic2":    "0xtopicc",
+		}).
+		Return([]domain.LogEntry{
+			{BlockNumber: ptr(int64(1)), TransactionIndex: ptr(0), Index: ptr(0), Topics: []string{"0xTOPICB", "0xany", "0xtopicc"}},
+			{BlockNumber: ptr(int64(1)), TransactionIndex: ptr(0), Index: ptr(1), Topics: []string{"0xtopicd", "0xany", "0xtopicc"}},
+		}, nil)
+
+	logs, errRpc := commonService.GetLogs(context.Background(), domain.LogParams{
+		BlockHash: "0x123abc",
+		Topics:    domain.TopicFilter{{"0xtopica", "0xtopicb"}, nil, {"0xtopicc"}},
+	})
+
+	assert.Nil(t, errRpc)
+	require.Len(t, logs, 1)
+	assert.Equal(t, "0x0", logs[0].LogIndex)
+}
+
+func TestCommonGetLogs_MaxResultsExceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	commonService := service.NewCommonService(mockClient, zap.NewNop(), mocks.NewMockCacheService(ctrl), nil, 1, nil)
+
+	mockClient.EXPECT().
+		GetBlockByHashOrNumber(gomock.Any(), "0x123abc").
+		Return(&domain.BlockResponse{Timestamp: domain.Timestamp{From: "1672531200", To: "1672531201"}}, nil)
+
+	mockClient.EXPECT().
+		GetContractResultsLogsWithRetry(gomock.Any(), gomock.Any()).
+		Return([]domain.LogEntry{
+			{BlockNumber: ptr(int64(1)), TransactionIndex: ptr(0), Index: ptr(0)},
+			{BlockNumber: ptr(int64(1)), TransactionIndex: ptr(0), Index: ptr(1)},
+		}, nil)
+
+	logs, errRpc := commonService.GetLogs(context.Background(), domain.LogParams{BlockHash: "0x123abc"})
+
+	assert.Nil(t, logs)
+	assert.NotNil(t, errRpc)
+	assert.Equal(t, domain.LimitExceeded, errRpc.Code)
+	assert.Equal(t, "query returned more than 1 results", errRpc.Message)
+}
+
+func TestGetLogsWithParams_SameIndexInDifferentTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	commonService := service.NewCommonService(mockClient, zap.NewNop(), nil, nil, 0, nil)
+
+	mockClient.EXPECT().
+		GetContractResultsLogsByAddress(gomock.Any(), "0xaddress1", gomock.Any()).
+		Return([]domain.LogEntry{{BlockNumber: ptr(int64(1)), TransactionIndex: ptr(1), Index: ptr(0)}}, nil)
+	mockClient.EXPECT().
+		GetContractResultsLogsByAddress(gomock.Any(), "0xaddress2", gomock.Any()).
+		Return([]domain.LogEntry{{BlockNumber: ptr(int64(1)), TransactionIndex: ptr(0), Index: ptr(0)}}, nil)
+
+	// Both logs are the first of their transactions, neither is a duplicate
+	logs, err := commonService.GetLogsWithParams(context.Background(), []string{"0xaddress1", "0xaddress2"}, map[string]interface{}{})
+
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	assert.Equal(t, "0x0", logs[0].TransactionIndex)
+	assert.Equal(t, "0x1", logs[1].TransactionIndex)
+}
+
+func TestGetLogsWithParams_IncompleteLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	commonService := service.NewCommonService(mockClient, zap.NewNop(), nil, nil, 0, nil)
+
+	mockClient.EXPECT().
+		GetContractResultsLogsByAddress(gomock.Any(), "0xaddress1", gomock.Any()).
+		Return([]domain.LogEntry{{BlockNumber: ptr(int64(1)), TransactionIndex: ptr(0), Index: ptr(0)}}, nil)
+	mockClient.EXPECT().
+		GetContractResultsLogsByAddress(gomock.Any(), "0xaddress2", gomock.Any()).
+		Return([]domain.LogEntry{{BlockNumber: ptr(int64(1)), Index: ptr(0)}}, nil)
+
+	// The log without a transaction index cannot be ordered or returned
+	logs, err := commonService.GetLogsWithParams(context.Background(), []string{"0xaddress1", "0xaddress2"}, map[string]interface{}{})
+
+	assert.Nil(t, logs)
+	assert.ErrorIs(t, err, service.ErrIncompleteLog)
+}
+
+func TestCommonGetLogs_BlockLogIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	commonService := service.NewCommonService(mockClient, zap.NewNop(), cache.NewMemoryCache(time.Minute, time.Minute), nil, 0, nil)
+
+	mockClient.EXPECT().
+		GetBlockByHashOrNumber(gomock.Any(), "0x123abc").
+		Return(&domain.BlockResponse{Timestamp: domain.Timestamp{From: "1672531200", To: "1672531201"}}, nil).
+		Times(2)
+	mockClient.EXPECT().
+		GetContractResultsLogsByAddress(gomock.Any(), "0xcontract", gomock.Any()).
+		Return([]domain.LogEntry{
+			{BlockNumber: ptr(int64(5)), TransactionIndex: ptr(2), Index: ptr(0)},
+			{BlockNumber: ptr(int64(5)), TransactionIndex: ptr(0), Index: ptr(1)},
+		}, nil).
+		Times(2)
+
+	// The offsets of the block are fetched once, from all its logs
+	mockClient.EXPECT().
+		GetBlockByHashOrNumber(gomock.Any(), "5").
+		Return(&domain.BlockResponse{Number: 5, Timestamp: domain.Timestamp{From: "1672531200", To: "1672531201"}}, nil)
+	mockClient.EXPECT().
+		GetContractResultsLogsWithRetry(gomock.Any(), map[string]interface{}{"timestamp": "gte:1672531200&timestamp=lte:1672531201"}).
+		Return([]domain.LogEntry{
+			{BlockNumber: ptr(int64(5)), TransactionIndex: ptr(0), Index: ptr(0)},
+			{BlockNumber: ptr(int64(5)), TransactionIndex: ptr(0), Index: ptr(1)},
+			{BlockNumber: ptr(int64(5)), TransactionIndex: ptr(1), Index: ptr(0)},
+			{BlockNumber: ptr(int64(5)), TransactionIndex: ptr(1), Index: ptr(1)},
+			{BlockNumber: ptr(int64(5)), TransactionIndex: ptr(1), Index: ptr(2)},
+			{BlockNumber: ptr(int64(5)), TransactionIndex: ptr(2), Index: ptr(0)},
+		}, nil)
+
+	for i := 0; i < 2; i++ {
+		logs, errRpc := commonService.GetLogs(context.Background(), domain.LogParams{BlockHash: "0x123abc", Address: domain.Address{"0xcontract"}})
+
+		require.Nil(t, errRpc)
+		var order []string
+		for _, log := range logs {
+			order = append(order, log.TransactionIndex+"/"+log.LogIndex)
+		}
+		assert.Equal(t, []string{"0x0/0x1", "0x2/0x5"}, order)
+	}
+}
+
+func TestCommonGetLogs_BlockLogOffsetsCachedForBlockTTL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	mockCache := mocks.NewMockCacheService(ctrl)
+	ttls := cache.TTLs{cache.ClassBlock: 42 * time.Second}
+	commonService := service.NewCommonService(mockClient, zap.NewNop(), mockCache, ttls, 0, nil)
+
+	mockClient.EXPECT().
+		GetBlockByHashOrNumber(gomock.Any(), "0x123abc").
+		Return(&domain.BlockResponse{Timestamp: domain.Timestamp{From: "1672531200", To: "1672531201"}}, nil)
+	mockClient.EXPECT().
+		GetContractResultsLogsByAddress(gomock.Any(), "0xcontract", gomock.Any()).
+		Return([]domain.LogEntry{{BlockNumber: ptr(int64(5)), TransactionIndex: ptr(1), Index: ptr(0)}}, nil)
+	mockClient.EXPECT().
+		GetBlockByHashOrNumber(gomock.Any(), "5").
+		Return(&domain.BlockResponse{Number: 5, Timestamp: domain.Timestamp{From: "1672531200", To: "1672531201"}}, nil)
+	mockClient.EXPECT().
+		GetContractResultsLogsWithRetry(gomock.Any(), gomock.Any()).
+		Return([]domain.LogEntry{
+			{BlockNumber: ptr(int64(5)), TransactionIndex: ptr(0), Index: ptr(0)},
+			{BlockNumber: ptr(int64(5)), TransactionIndex: ptr(1), Index: ptr(0)},
+		}, nil)
+
+	mockCache.EXPECT().Get(gomock.Any(), "block_log_offsets_5", gomock.Any()).Return(errors.New("not found"))
+	mockCache.EXPECT().Set(gomock.Any(), "block_log_offsets_5", gomock.Any(), 42*time.Second).Return(nil)
+
+	logs, errRpc := commonService.GetLogs(context.Background(), domain.LogParams{BlockHash: "0x123abc", Address: domain.Address{"0xcontract"}})
+
+	require.Nil(t, errRpc)
+	require.Len(t, logs, 1)
+	assert.Equal(t, "0x1", logs[0].LogIndex)
+}
+
+func TestCommonGetLogs_BlockLogOffsetsMissingBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	commonService := service.NewCommonService(mockClient, zap.NewNop(), nil, nil, 0, nil)
+
+	mockClient.EXPECT().
+		GetBlockByHashOrNumber(gomock.Any(), "0x123abc").
+		Return(&domain.BlockResponse{Timestamp: domain.Timestamp{From: "1672531200", To: "1672531201"}}, nil)
+	mockClient.EXPECT().
+		GetContractResultsLogsByAddress(gomock.Any(), "0xcontract", gomock.Any()).
+		Return([]domain.LogEntry{{BlockNumber: ptr(int64(5)), TransactionIndex: ptr(1), Index: ptr(0)}}, nil)
+	mockClient.EXPECT().
+		GetBlockByHashOrNumber(gomock.Any(), "5").
+		Return(nil, nil)
+
+	logs, errRpc := commonService.GetLogs(context.Background(), domain.LogParams{BlockHash: "0x123abc", Address: domain.Address{"0xcontract"}})
+
+	assert.Nil(t, logs)
+	require.NotNil(t, errRpc)
+	assert.Equal(t, domain.ResourceNotFound, errRpc.Code)
+}
+
+func TestCommonGetLogs_BlockLogIndexFromCompleteBlock(t *testing.T) {
+	ctrl, mockClient, _, commonService := setupCommonTest(t)
+	defer ctrl.Finish()
+
+	mockClient.EXPECT().
+		GetBlockByHashOrNumber(gomock.Any(), "0x123abc").
+		Return(&domain.BlockResponse{Timestamp: domain.Timestamp{From: "1672531200", To: "1672531201"}}, nil)
+	mockClient.EXPECT().
+		GetContractResultsLogsWithRetry(gomock.Any(), gomock.Any()).
+		Return([]domain.LogEntry{
+			{BlockNumber: ptr(int64(5)), TransactionIndex: ptr(1), Index: ptr(0)},
+			{BlockNumber: ptr(int64(5)), TransactionIndex: ptr(0), Index: ptr(1)},
+			{BlockNumber: ptr(int64(5)), TransactionIndex: ptr(0), Index: ptr(0)},
+		}, nil)
+
+	// Without an address or topics every log of the block is there to count
+	logs, errRpc := commonService.GetLogs(context.Background(), domain.LogParams{BlockHash: "0x123abc"})
+
+	require.Nil(t, errRpc)
+	var order []string
+	for _, log := range logs {
+		order = append(order, log.TransactionIndex+"/"+log.LogIndex)
+	}
+	assert.Equal(t, []string{"0x0/0x0", "0x0/0x1", "0x1/0x2"}, order)
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -18,7 +19,7 @@ func setupFilterTest(t *testing.T) (*gomock.Controller, *mocks.MockMirrorClient,
 	mockClient := mocks.NewMockMirrorClient(ctrl)
 	mockCache := mocks.NewMockCacheService(ctrl)
 	mockCommon := mocks.NewMockCommonService(ctrl)
-	filterService := service.NewFilterService(mockClient, mockCache, logger, mockCommon)
+	filterService := service.NewFilterService(mockClient, mockCache, logger, mockCommon, true, 0)
 
 	return ctrl, mockClient, mockCache, mockCommon, filterService
 }
@@ -29,15 +30,15 @@ func TestNewFilter(t *testing.T) {
 
 	t.Run("Success_with_valid_block_range", func(t *testing.T) {
 		// Mock ValidateBlockRange
-		mockCommon.EXPECT().ValidateBlockRange("latest", "latest").Return(nil)
+		mockCommon.EXPECT().ValidateBlockRange(gomock.Any(), "latest", "latest").Return(nil)
 
 		// Mock GetBlockNumberByNumberOrTag for "latest" in NewFilter
-		mockCommon.EXPECT().GetBlockNumberByNumberOrTag("latest").Return(int64(100), nil)
+		mockCommon.EXPECT().GetBlockNumberByNumberOrTag(gomock.Any(), "latest").Return(int64(100), nil)
 
 		// Mock cache Set
 		mockCache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
-		filter, err := service.NewFilter("latest", "latest", []string{"0xaddress"}, []string{"0xtopic1"})
+		filter, err := service.NewFilter(context.Background(), "latest", "latest", []string{"0xaddress"}, domain.TopicFilter{{"0xtopic1"}})
 
 		assert.Nil(t, err)
 		assert.NotNil(t, filter)
@@ -45,9 +46,9 @@ func TestNewFilter(t *testing.T) {
 
 	t.Run("Error_with_invalid_block_range", func(t *testing.T) {
 		// Mock ValidateBlockRange to return error
-		mockCommon.EXPECT().ValidateBlockRange("0x2", "0x1").Return(domain.NewInvalidBlockRangeError())
+		mockCommon.EXPECT().ValidateBlockRange(gomock.Any(), "0x2", "0x1").Return(domain.NewInvalidBlockRangeError())
 
-		filter, err := service.NewFilter("0x2", "0x1", []string{"0xaddress"}, []string{"0xtopic1"})
+		filter, err := service.NewFilter(context.Background(), "0x2", "0x1", []string{"0xaddress"}, domain.TopicFilter{{"0xtopic1"}})
 
 		assert.NotNil(t, err)
 		assert.Nil(t, filter)
@@ -69,7 +70,7 @@ func TestNewBlockFilter(t *testing.T) {
 			name: "Success",
 			mockSetup: func() {
 				mockCommon.EXPECT().
-					GetBlockNumberByNumberOrTag("latest").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "latest").
 					Return(int64(100), nil)
 
 				mockCache.EXPECT().
@@ -83,7 +84,7 @@ func TestNewBlockFilter(t *testing.T) {
 			name: "Error getting block number",
 			mockSetup: func() {
 				mockCommon.EXPECT().
-					GetBlockNumberByNumberOrTag("latest").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "latest").
 					Return(int64(0), domain.NewRPCError(domain.ServerError, "failed to get block"))
 			},
 			expectError:    true,
@@ -95,7 +96,7 @@ func TestNewBlockFilter(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockSetup()
 
-			result, errRpc := filterService.NewBlockFilter()
+			result, errRpc := filterService.NewBlockFilter(context.Background())
 
 			if tc.expectError {
 				assert.NotNil(t, errRpc)
@@ -167,7 +168,7 @@ func TestUninstallFilter(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockSetup()
 
-			result, errRpc := filterService.UninstallFilter(tc.filterID)
+			result, errRpc := filterService.UninstallFilter(context.Background(), tc.filterID)
 
 			if tc.expectError {
 				assert.NotNil(t, errRpc)
@@ -180,13 +181,36 @@ func TestUninstallFilter(t *testing.T) {
 }
 
 func TestNewPendingTransactionFilter(t *testing.T) {
-	ctrl, _, _, _, filterService := setupFilterTest(t)
+	ctrl, _, mockCache, mockCommon, filterService := setupFilterTest(t)
 	defer ctrl.Finish()
 
-	result, errRpc := filterService.NewPendingTransactionFilter()
-	assert.Nil(t, result)
-	assert.NotNil(t, errRpc)
-	assert.Equal(t, -32601, errRpc.Code)
+	t.Run("Success", func(t *testing.T) {
+		mockCommon.EXPECT().GetBlockNumberByNumberOrTag(gomock.Any(), "latest").Return(int64(100), nil)
+		mockCache.EXPECT().
+			Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx interface{}, key string, value interface{}, ttl interface{}) error {
+				filter := value.(*domain.Filter)
+				assert.Equal(t, "new_pending_transaction", filter.Type)
+				assert.Equal(t, "0x64", filter.BlockAtCreation)
+				return nil
+			})
+
+		result, errRpc := filterService.NewPendingTransactionFilter(context.Background())
+		assert.Nil(t, errRpc)
+		if assert.NotNil(t, result) {
+			assert.Equal(t, 34, len(*result))
+		}
+	})
+
+	t.Run("Error getting block number", func(t *testing.T) {
+		mockCommon.EXPECT().
+			GetBlockNumberByNumberOrTag(gomock.Any(), "latest").
+			Return(int64(0), domain.NewRPCError(domain.ServerError, "failed to get block"))
+
+		result, errRpc := filterService.NewPendingTransactionFilter(context.Background())
+		assert.NotNil(t, errRpc)
+		assert.Nil(t, result)
+	})
 }
 
 func TestGetFilterLogs(t *testing.T) {
@@ -210,7 +234,7 @@ func TestGetFilterLogs(t *testing.T) {
 					FromBlock: "0x1",
 					ToBlock:   "0x2",
 					Address:   []string{"0xaddress1"},
-					Topics:    []string{"0xtopic1"},
+					Topics:    domain.TopicFilter{{"0xtopic1"}},
 				}
 
 				mockCache.EXPECT().
@@ -235,7 +259,7 @@ func TestGetFilterLogs(t *testing.T) {
 				}
 
 				mockCommon.EXPECT().
-					GetLogs(gomock.Any()).
+					GetLogs(gomock.Any(), gomock.Any()).
 					Return(expectedLogs, nil)
 
 				mockCache.EXPECT().
@@ -293,7 +317,7 @@ func TestGetFilterLogs(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockSetup()
 
-			result, errRpc := filterService.GetFilterLogs(tc.filterID)
+			result, errRpc := filterService.GetFilterLogs(context.Background(), tc.filterID)
 
 			if tc.expectError {
 				assert.NotNil(t, errRpc)
@@ -327,7 +351,7 @@ func TestGetFilterChanges(t *testing.T) {
 					FromBlock: "0x1",
 					ToBlock:   "0x2",
 					Address:   []string{"0xaddress1"},
-					Topics:    []string{"0xtopic1"},
+					Topics:    domain.TopicFilter{{"0xtopic1"}},
 				}
 
 				mockCache.EXPECT().
@@ -352,12 +376,28 @@ func TestGetFilterChanges(t *testing.T) {
 				}
 
 				mockCommon.EXPECT().
-					GetLogs(gomock.Any()).
+					GetBlockNumberByNumberOrTag(gomock.Any(), "latest").
+					Return(int64(7), nil)
+				mockCommon.EXPECT().
+					GetBlockNumberByNumberOrTag(gomock.Any(), "0x2").
+					Return(int64(2), nil)
+				mockCommon.EXPECT().
+					GetLogs(gomock.Any(), domain.LogParams{
+						FromBlock: "0x1",
+						ToBlock:   "0x2",
+						Address:   []string{"0xaddress1"},
+						Topics:    domain.TopicFilter{{"0xtopic1"}},
+					}).
 					Return(expectedLogs, nil)
 
+				// The poll covered blocks up to the toBlock of the filter,
+				// not only up to the block of its last log
 				mockCache.EXPECT().
 					Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil)
+					DoAndReturn(func(ctx interface{}, key string, value interface{}, ttl interface{}) error {
+						assert.Equal(t, "0x3", value.(*domain.Filter).LastQueried)
+						return nil
+					})
 			},
 			expectError: false,
 			expectedResult: []domain.Log{
@@ -392,7 +432,7 @@ func TestGetFilterChanges(t *testing.T) {
 					})
 
 				mockClient.EXPECT().
-					GetBlocks("0x1").
+					GetBlocks(gomock.Any(), "0x1").
 					Return([]map[string]interface{}{
 						{"hash": "0xblockhash1", "number": float64(1)},
 						{"hash": "0xblockhash2", "number": float64(2)},
@@ -405,6 +445,122 @@ func TestGetFilterChanges(t *testing.T) {
 			expectError:    false,
 			expectedResult: []string{"0xblockhash1", "0xblockhash2"},
 		},
+		{
+			name:     "Success with pending transaction filter",
+			filterID: "0x123abc",
+			mockSetup: func() {
+				filter := domain.Filter{
+					ID:              "0x123abc",
+					Type:            "new_pending_transaction",
+					BlockAtCreation: "0x1",
+				}
+
+				mockCache.EXPECT().
+					Get(gomock.Any(), fmt.Sprintf("filterId_%s", "0x123abc"), gomock.Any()).
+					DoAndReturn(func(ctx interface{}, key string, value interface{}) error {
+						f := value.(*domain.Filter)
+						*f = filter
+						return nil
+					})
+
+				timestamp := domain.Timestamp{From: "1700000000.000000000", To: "1700000001.999999999"}
+				mockClient.EXPECT().
+					GetBlocks(gomock.Any(), "0x1").
+					Return([]map[string]interface{}{
+						{"hash": "0xblockhash2", "number": float64(2), "timestamp": map[string]interface{}{"from": timestamp.From, "to": timestamp.To}},
+					}, nil)
+				mockClient.EXPECT().
+					GetContractResults(gomock.Any(), timestamp).
+					Return([]domain.ContractResult{
+						{Hash: "0xtxhash1", Result: "SUCCESS"},
+						{Hash: "0xtxhash2", Result: "WRONG_NONCE"},
+						{Hash: "0xtxhash3", Result: "CONTRACT_REVERT_EXECUTED"},
+					}, nil)
+
+				mockCache.EXPECT().
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx interface{}, key string, value interface{}, ttl interface{}) error {
+						assert.Equal(t, "0x2", value.(*domain.Filter).LastQueried)
+						return nil
+					})
+			},
+			expectError:    false,
+			expectedResult: []string{"0xtxhash1", "0xtxhash3"},
+		},
+		{
+			name:     "Log filter resumes from last queried block",
+			filterID: "0x123abc",
+			mockSetup: func() {
+				filter := domain.Filter{
+					ID:          "0x123abc",
+					Type:        "log",
+					FromBlock:   "0x1",
+					ToBlock:     "latest",
+					LastQueried: "0x5",
+				}
+
+				mockCache.EXPECT().
+					Get(gomock.Any(), fmt.Sprintf("filterId_%s", "0x123abc"), gomock.Any()).
+					DoAndReturn(func(ctx interface{}, key string, value interface{}) error {
+						f := value.(*domain.Filter)
+						*f = filter
+						return nil
+					})
+
+				// The latest block is fixed before the logs are fetched, so
+				// blocks created meanwhile are left to the next poll
+				mockCommon.EXPECT().
+					GetBlockNumberByNumberOrTag(gomock.Any(), "latest").
+					Return(int64(7), nil)
+
+				mockCommon.EXPECT().
+					GetLogs(gomock.Any(), domain.LogParams{FromBlock: "0x5", ToBlock: "0x7"}).
+					Return([]domain.Log{}, nil)
+
+				mockCache.EXPECT().
+					Set(gomock.Any(), fmt.Sprintf("filterId_%s", "0x123abc"), gomock.Any(), service.DefaultFilterTTL).
+					DoAndReturn(func(ctx interface{}, key string, value interface{}, ttl interface{}) error {
+						assert.Equal(t, "0x8", value.(*domain.Filter).LastQueried)
+						return nil
+					})
+			},
+			expectError:    false,
+			expectedResult: []domain.Log{},
+		},
+		{
+			name:     "Log filter past its toBlock returns no logs",
+			filterID: "0x123abc",
+			mockSetup: func() {
+				filter := domain.Filter{
+					ID:          "0x123abc",
+					Type:        "log",
+					FromBlock:   "0x1",
+					ToBlock:     "0x2",
+					LastQueried: "0x3",
+				}
+
+				mockCache.EXPECT().
+					Get(gomock.Any(), fmt.Sprintf("filterId_%s", "0x123abc"), gomock.Any()).
+					DoAndReturn(func(ctx interface{}, key string, value interface{}) error {
+						f := value.(*domain.Filter)
+						*f = filter
+						return nil
+					})
+
+				mockCommon.EXPECT().
+					GetBlockNumberByNumberOrTag(gomock.Any(), "latest").
+					Return(int64(7), nil)
+				mockCommon.EXPECT().
+					GetBlockNumberByNumberOrTag(gomock.Any(), "0x2").
+					Return(int64(2), nil)
+
+				mockCache.EXPECT().
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectError:    false,
+			expectedResult: []domain.Log{},
+		},
 		{
 			name:     "Filter not found",
 			filterID: "0xnonexistent",
@@ -422,7 +578,7 @@ func TestGetFilterChanges(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockSetup()
 
-			result, errRpc := filterService.GetFilterChanges(tc.filterID)
+			result, errRpc := filterService.GetFilterChanges(context.Background(), tc.filterID)
 
 			if tc.expectError {
 				assert.NotNil(t, errRpc)
@@ -434,3 +590,27 @@ func TestGetFilterChanges(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterAPIDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger, _ := zap.NewDevelopment()
+	filterService := service.NewFilterService(mocks.NewMockMirrorClient(ctrl), mocks.NewMockCacheService(ctrl), logger, mocks.NewMockCommonService(ctrl), false, 0)
+
+	_, errRpc := filterService.NewFilter(context.Background(), "latest", "latest", nil, nil)
+	assert.NotNil(t, errRpc)
+	assert.Equal(t, domain.MethodNotFound, errRpc.Code)
+
+	_, errRpc = filterService.NewBlockFilter(context.Background())
+	assert.NotNil(t, errRpc)
+
+	_, errRpc = filterService.NewPendingTransactionFilter(context.Background())
+	assert.NotNil(t, errRpc)
+
+	_, errRpc = filterService.GetFilterChanges(context.Background(), "0x123abc")
+	assert.NotNil(t, errRpc)
+
+	_, errRpc = filterService.GetFilterLogs(context.Background(), "0x123abc")
+	assert.NotNil(t, errRpc)
+}
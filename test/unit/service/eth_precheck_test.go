@@ -0,0 +1,169 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/service"
+	"github.com/LimeChain/Hederium/internal/util"
+	"github.com/LimeChain/Hederium/test/unit/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func newLegacyTx() *util.Tx {
+	return &util.Tx{
+		Nonce:    5,
+		GasPrice: big.NewInt(710000000000),
+		GasLimit: 50000,
+		To:       "0x0a56fd9e0c4f67df549e7f375a9451c0086482ec",
+		Value:    big.NewInt(0),
+		ChainID:  big.NewInt(296),
+		V:        big.NewInt(627),
+	}
+}
+
+func TestPrecheck_ValidTransaction(t *testing.T) {
+	p := service.NewPrecheck(nil, zap.NewNop(), "0x128")
+	tx := newLegacyTx()
+
+	account := &domain.AccountResponse{}
+	account.Balance.Balance = 1000000000
+
+	assert.NoError(t, p.ChainID(tx))
+	assert.NoError(t, p.GasLimit(tx))
+	assert.NoError(t, p.Nonce(tx, 5))
+	assert.NoError(t, p.GasPrice(tx, 710000000000))
+	assert.NoError(t, p.Balance(tx, account))
+	assert.NoError(t, p.Value(tx))
+	assert.NoError(t, p.TransactionType(tx))
+}
+
+func TestPrecheck_Errors(t *testing.T) {
+	p := service.NewPrecheck(nil, zap.NewNop(), "0x128")
+
+	testCases := []struct {
+		name     string
+		check    func(tx *util.Tx) error
+		modify   func(tx *util.Tx)
+		expected *domain.RPCError
+	}{
+		{
+			name:     "chain id mismatch",
+			check:    p.ChainID,
+			modify:   func(tx *util.Tx) { tx.ChainID = big.NewInt(295) },
+			expected: domain.NewUnsupportedChainIDError("0x127", "0x128"),
+		},
+		{
+			name:     "intrinsic gas too low",
+			check:    p.GasLimit,
+			modify:   func(tx *util.Tx) { tx.GasLimit = 20000 },
+			expected: domain.NewIntrinsicGasTooLowError(20000, 21000),
+		},
+		{
+			name:     "gas limit above network max",
+			check:    p.GasLimit,
+			modify:   func(tx *util.Tx) { tx.GasLimit = 15000001 },
+			expected: domain.NewGasLimitTooHighError(15000001, 15000000),
+		},
+		{
+			name:     "nonce too low",
+			check:    func(tx *util.Tx) error { return p.Nonce(tx, 7) },
+			modify:   func(tx *util.Tx) {},
+			expected: domain.NewNonceTooLowError(5, 7),
+		},
+		{
+			name:     "gas price below network price",
+			check:    func(tx *util.Tx) error { return p.GasPrice(tx, 720000000000) },
+			modify:   func(tx *util.Tx) {},
+			expected: domain.NewGasPriceTooLowError("710000000000", "720000000000"),
+		},
+		{
+			name:  "max fee below network price",
+			check: func(tx *util.Tx) error { return p.GasPrice(tx, 720000000000) },
+			modify: func(tx *util.Tx) {
+				tx.Type = 2
+				tx.GasPrice = nil
+				tx.GasFeeCap = big.NewInt(700000000000)
+				tx.GasTipCap = big.NewInt(0)
+			},
+			expected: domain.NewGasPriceTooLowError("700000000000", "720000000000"),
+		},
+		{
+			name: "insufficient payer balance",
+			check: func(tx *util.Tx) error {
+				account := &domain.AccountResponse{}
+				account.Balance.Balance = 100
+				return p.Balance(tx, account)
+			},
+			modify:   func(tx *util.Tx) {},
+			expected: domain.NewInsufficientFundsError(),
+		},
+		{
+			name:     "value below one tinybar",
+			check:    p.Value,
+			modify:   func(tx *util.Tx) { tx.Value = big.NewInt(1) },
+			expected: domain.NewValueTooLowError(),
+		},
+		{
+			name:     "blob transaction",
+			check:    p.TransactionType,
+			modify:   func(tx *util.Tx) { tx.Type = 3 },
+			expected: domain.NewUnsupportedTransactionTypeError(3),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := newLegacyTx()
+			tc.modify(tx)
+			err := tc.check(tx)
+
+			var rpcErr *domain.RPCError
+			if assert.True(t, errors.As(err, &rpcErr)) {
+				assert.Equal(t, tc.expected, rpcErr)
+			}
+		})
+	}
+}
+
+func TestPrecheck_CheckSize(t *testing.T) {
+	p := service.NewPrecheck(nil, zap.NewNop(), "0x128")
+
+	assert.NoError(t, p.CheckSize("0x"+strings.Repeat("00", 128*1024)))
+	assert.Equal(t, domain.NewOversizedDataError(128*1024+1, 128*1024), p.CheckSize("0x"+strings.Repeat("00", 128*1024+1)))
+}
+
+func TestSendRawTransaction_PrecheckErrorCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMirrorClient := mocks.NewMockMirrorClient(ctrl)
+	mockCacheService := mocks.NewMockCacheService(ctrl)
+
+	// The transaction below is signed for chain 0x128
+	ethService := service.NewEthService(nil, mockMirrorClient, nil, zap.NewNop(), nil, "0x12a", mockCacheService, service.Config{})
+
+	mockCacheService.EXPECT().
+		Get(gomock.Any(), "eth_gasPrice", gomock.Any()).
+		SetArg(2, "0x4f29944800").
+		Return(nil)
+	mockMirrorClient.EXPECT().
+		GetAccountById(gomock.Any(), gomock.Any()).
+		Return(&domain.AccountResponse{EthereumNonce: 30}, nil)
+
+	rawTxHex := "0xf8cc1e854f29944800832dc6c0940a56fd9e0c4f67df549e7f375a9451c0086482ec80b864a41368620000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000b757064617465645f6d7367000000000000000000000000000000000000000000820274a0cd6095ae91ea5d609b32923a9f73572e2d031fde0b7e38de44d3eda187474140a03028ecf5eb61070cba8e927ad5e11eac116da441307f2d54dae8be90f4476c59"
+
+	mockCacheService.EXPECT().
+		Get(gomock.Any(), sendRawTransactionKey(rawTxHex), gomock.Any()).
+		Return(errors.New("not found"))
+
+	result, errRpc := ethService.SendRawTransaction(context.Background(), rawTxHex)
+	assert.Nil(t, result)
+	assert.Equal(t, domain.NewUnsupportedChainIDError("0x128", "0x12a"), errRpc)
+}
@@ -1,17 +1,20 @@
 package service_test
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"strings"
 	"testing"
 
 	"github.com/LimeChain/Hederium/internal/domain"
+	infrahedera "github.com/LimeChain/Hederium/internal/infrastructure/hedera"
 	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
 	"github.com/LimeChain/Hederium/internal/service"
 	"github.com/LimeChain/Hederium/test/unit/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -33,7 +36,7 @@ func TestGetFeeWeibars_Success(t *testing.T) {
 
 	expectedGasTinybars := int64(100000) // For now I am making it like this, but it should be checked!
 	mockClient.EXPECT().
-		GetNetworkFees("", "").
+		GetNetworkFees(gomock.Any(), "", "").
 		Return(expectedGasTinybars, nil)
 
 	s := service.NewEthService(
@@ -44,9 +47,10 @@ func TestGetFeeWeibars_Success(t *testing.T) {
 		nil,
 		defaultChainId,
 		cacheService,
+		service.Config{},
 	)
 
-	result, err := service.GetFeeWeibars(s, "", "")
+	result, err := service.GetFeeWeibars(context.Background(), s, "", "")
 	assert.Nil(t, err)
 
 	// Expected weibars = tinybars * 10^10
@@ -60,8 +64,8 @@ func TestGetFeeWeibars_Error(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockClient.EXPECT().
-		GetNetworkFees("", "").
-		Return(int64(0), fmt.Errorf("network error"))
+		GetNetworkFees(gomock.Any(), "", "").
+		Return(int64(0), fmt.Errorf("network error: %w", infrahedera.ErrTimeout))
 
 	s := service.NewEthService(
 		nil,
@@ -71,11 +75,12 @@ func TestGetFeeWeibars_Error(t *testing.T) {
 		nil,
 		defaultChainId,
 		cacheService,
+		service.Config{},
 	)
 
-	result, err := service.GetFeeWeibars(s, "", "")
+	result, err := service.GetFeeWeibars(context.Background(), s, "", "")
 	assert.Nil(t, result)
-	assert.NotNil(t, err)
+	assert.ErrorIs(t, err, infrahedera.ErrTimeout)
 }
 
 func TestProcessBlock_Success(t *testing.T) {
@@ -94,7 +99,7 @@ func TestProcessBlock_Success(t *testing.T) {
 		},
 	}
 
-	contractResults := []domain.ContractResults{
+	contractResults := []domain.ContractResult{
 		{
 			Hash:   "0xtx1",
 			Result: "SUCCESS",
@@ -116,8 +121,8 @@ func TestProcessBlock_Success(t *testing.T) {
 	}
 
 	mockClient.EXPECT().
-		GetContractResults(block.Timestamp).
-		Return(contractResults)
+		GetContractResults(gomock.Any(), block.Timestamp).
+		Return(contractResults, nil)
 
 	// Mock address resolution for first transaction
 	fromCacheKey1 := fmt.Sprintf("evm_address_%s", contractResults[0].From)
@@ -126,11 +131,11 @@ func TestProcessBlock_Success(t *testing.T) {
 		Return(fmt.Errorf("not found"))
 
 	mockClient.EXPECT().
-		GetContractById(contractResults[0].From).
+		GetContractById(gomock.Any(), contractResults[0].From).
 		Return(nil, fmt.Errorf("not found"))
 
 	mockClient.EXPECT().
-		GetAccountById(contractResults[0].From).
+		GetAccountById(gomock.Any(), contractResults[0].From).
 		Return(&domain.AccountResponse{
 			EvmAddress: contractResults[0].From,
 		}, nil)
@@ -145,11 +150,11 @@ func TestProcessBlock_Success(t *testing.T) {
 		Return(fmt.Errorf("not found"))
 
 	mockClient.EXPECT().
-		GetContractById(contractResults[0].To).
+		GetContractById(gomock.Any(), contractResults[0].To).
 		Return(nil, fmt.Errorf("not found"))
 
 	mockClient.EXPECT().
-		GetAccountById(contractResults[0].To).
+		GetAccountById(gomock.Any(), contractResults[0].To).
 		Return(&domain.AccountResponse{
 			EvmAddress: contractResults[0].To,
 		}, nil)
@@ -165,11 +170,11 @@ func TestProcessBlock_Success(t *testing.T) {
 		Return(fmt.Errorf("not found"))
 
 	mockClient.EXPECT().
-		GetContractById(contractResults[2].From).
+		GetContractById(gomock.Any(), contractResults[2].From).
 		Return(nil, fmt.Errorf("not found"))
 
 	mockClient.EXPECT().
-		GetAccountById(contractResults[2].From).
+		GetAccountById(gomock.Any(), contractResults[2].From).
 		Return(&domain.AccountResponse{
 			EvmAddress: contractResults[2].From,
 		}, nil)
@@ -184,11 +189,11 @@ func TestProcessBlock_Success(t *testing.T) {
 		Return(fmt.Errorf("not found"))
 
 	mockClient.EXPECT().
-		GetContractById(contractResults[2].To).
+		GetContractById(gomock.Any(), contractResults[2].To).
 		Return(nil, fmt.Errorf("not found"))
 
 	mockClient.EXPECT().
-		GetAccountById(contractResults[2].To).
+		GetAccountById(gomock.Any(), contractResults[2].To).
 		Return(&domain.AccountResponse{
 			EvmAddress: contractResults[2].To,
 		}, nil)
@@ -205,9 +210,10 @@ func TestProcessBlock_Success(t *testing.T) {
 		nil,
 		defaultChainId,
 		mockCacheService,
+		service.Config{},
 	)
 
-	result, errMap := service.ProcessBlock(s, block, false)
+	result, errMap := service.ProcessBlock(context.Background(), s, block, false)
 	assert.Nil(t, errMap)
 
 	ethBlock := result
@@ -242,8 +248,8 @@ func TestProcessBlock_WithLongHashes(t *testing.T) {
 	}
 
 	mockClient.EXPECT().
-		GetContractResults(block.Timestamp).
-		Return([]domain.ContractResults{})
+		GetContractResults(gomock.Any(), block.Timestamp).
+		Return([]domain.ContractResult{}, nil)
 
 	s := service.NewEthService(
 		nil,
@@ -253,9 +259,10 @@ func TestProcessBlock_WithLongHashes(t *testing.T) {
 		nil,
 		defaultChainId,
 		cacheService,
+		service.Config{},
 	)
 
-	result, errMap := service.ProcessBlock(s, block, false)
+	result, errMap := service.ProcessBlock(context.Background(), s, block, false)
 	assert.Nil(t, errMap)
 
 	ethBlock := result
@@ -263,11 +270,71 @@ func TestProcessBlock_WithLongHashes(t *testing.T) {
 	assert.Equal(t, 66, len(ethBlock.ParentHash))
 }
 
+func TestProcessBlock_ResolvesEachAddressOnce(t *testing.T) {
+	ctrl, mockClient, logger, mockCacheService, _ := setupTest(t)
+	defer ctrl.Finish()
+
+	block := &domain.BlockResponse{
+		Number: 123,
+		Hash:   "0x123abc",
+		Timestamp: domain.Timestamp{
+			From: "1640995200",
+		},
+	}
+
+	sender := "0x" + strings.Repeat("1", 40)
+	contractResults := []domain.ContractResult{}
+	for i := 0; i < 20; i++ {
+		contractResults = append(contractResults, domain.ContractResult{
+			Hash:   fmt.Sprintf("0xtx%d", i),
+			From:   sender,
+			To:     fmt.Sprintf("0x2%039x", i%4),
+			Result: "SUCCESS",
+		})
+	}
+
+	mockClient.EXPECT().
+		GetContractResults(gomock.Any(), block.Timestamp).
+		Return(contractResults, nil)
+
+	// One sender and four recipients
+	mockCacheService.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("not found")).Times(5)
+	mockCacheService.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), service.DefaultExpiration).Return(nil).Times(5)
+	mockClient.EXPECT().GetContractById(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("not found")).Times(5)
+	mockClient.EXPECT().
+		GetAccountById(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, address string) (*domain.AccountResponse, error) {
+			return &domain.AccountResponse{EvmAddress: address}, nil
+		}).
+		Times(5)
+
+	s := service.NewEthService(
+		nil,
+		mockClient,
+		nil,
+		logger,
+		nil,
+		defaultChainId,
+		mockCacheService,
+		service.Config{},
+	)
+
+	result, err := service.ProcessBlock(context.Background(), s, block, true)
+	assert.NoError(t, err)
+	require.Len(t, result.Transactions, 20)
+
+	for i, tx := range result.Transactions {
+		transaction := tx.(domain.Transaction)
+		assert.Equal(t, sender, transaction.From)
+		assert.Equal(t, fmt.Sprintf("0x2%039x", i%4), *transaction.To)
+	}
+}
+
 func TestProcessTransaction_LegacyTransaction(t *testing.T) {
 	// Create a properly formatted Ethereum address by padding with zeros
 	toAddress := "0xto123" + strings.Repeat("0", 35) // 0x + 40 hex chars = 42 total length
 
-	contractResult := domain.ContractResults{
+	contractResult := domain.ContractResult{
 		BlockNumber:        123,
 		BlockHash:          "0xblockHash123",
 		Hash:               "0xtxHash123" + strings.Repeat("0", 100),
@@ -280,7 +347,7 @@ func TestProcessTransaction_LegacyTransaction(t *testing.T) {
 		R:                  "0xr123" + strings.Repeat("0", 100),
 		S:                  "0xs123" + strings.Repeat("0", 100),
 		Nonce:              10,
-		Type:               0, // Legacy transaction
+		Type:               intPtr(0), // Legacy transaction
 		GasPrice:           "0x100",
 		FunctionParameters: "0xabcd",
 		ChainID:            "0x1",
@@ -291,7 +358,7 @@ func TestProcessTransaction_LegacyTransaction(t *testing.T) {
 	assert.True(t, ok)
 
 	// Test empty/missing values default to "0x0"
-	emptyResult := domain.ContractResults{
+	emptyResult := domain.ContractResult{
 		BlockNumber: 123,
 		GasUsed:     1000,
 	}
@@ -305,7 +372,7 @@ func TestProcessTransaction_LegacyTransaction(t *testing.T) {
 	assert.Equal(t, "0x0", emptyTx.GasPrice)
 
 	// Test gas price conversion
-	gasPriceResult := domain.ContractResults{
+	gasPriceResult := domain.ContractResult{
 		GasPrice: "0x64", // 100 in hex
 	}
 	gasPriceTx := service.ProcessTransaction(gasPriceResult).(domain.Transaction)
@@ -334,12 +401,12 @@ func TestProcessTransaction_LegacyTransaction(t *testing.T) {
 func TestProcessTransaction_EIP2930(t *testing.T) {
 	toAddress := "0xto123" + strings.Repeat("0", 35) // Properly formatted Ethereum address
 
-	contractResult := domain.ContractResults{
+	contractResult := domain.ContractResult{
 		BlockNumber: 123,
 		Hash:        "0xtxHash123" + strings.Repeat("0", 100),
 		From:        "0xfrom123" + strings.Repeat("0", 100),
 		To:          toAddress,
-		Type:        1, // EIP-2930
+		Type:        intPtr(1), // EIP-2930
 		GasPrice:    "0x100",
 		R:           "0xr123" + strings.Repeat("0", 100),
 		S:           "0xs123" + strings.Repeat("0", 100),
@@ -356,12 +423,12 @@ func TestProcessTransaction_EIP2930(t *testing.T) {
 func TestProcessTransaction_EIP1559(t *testing.T) {
 	toAddress := "0xto123" + strings.Repeat("0", 35) // Properly formatted Ethereum address
 
-	contractResult := domain.ContractResults{
+	contractResult := domain.ContractResult{
 		BlockNumber:          123,
 		Hash:                 "0xtxHash123" + strings.Repeat("0", 100),
 		From:                 "0xfrom123" + strings.Repeat("0", 100),
 		To:                   toAddress,
-		Type:                 2, // EIP-1559
+		Type:                 intPtr(2), // EIP-1559
 		MaxPriorityFeePerGas: "0x100",
 		MaxFeePerGas:         "0x200",
 		R:                    "0xr123" + strings.Repeat("0", 100),
@@ -381,12 +448,12 @@ func TestProcessTransaction_EIP1559(t *testing.T) {
 func TestProcessTransaction_UnknownType(t *testing.T) {
 	toAddress := "0xto123" + strings.Repeat("0", 35) // Properly formatted Ethereum address
 
-	contractResult := domain.ContractResults{
+	contractResult := domain.ContractResult{
 		BlockNumber: 123,
 		Hash:        "0xtxHash123" + strings.Repeat("0", 100),
 		From:        "0xfrom123" + strings.Repeat("0", 100),
 		To:          toAddress,
-		Type:        99, // Unknown type
+		Type:        intPtr(99), // Unknown type
 		R:           "0xr123" + strings.Repeat("0", 100),
 		S:           "0xs123" + strings.Repeat("0", 100),
 	}
@@ -410,6 +477,7 @@ func TestFormatTransactionCallObject(t *testing.T) {
 		nil,
 		defaultChainId,
 		cacheService,
+		service.Config{},
 	)
 
 	testCases := []struct {
@@ -418,7 +486,7 @@ func TestFormatTransactionCallObject(t *testing.T) {
 		blockParam  interface{}
 		estimate    bool
 		expected    map[string]interface{}
-		expectError bool
+		expectError error
 	}{
 		{
 			name: "Basic transaction with value",
@@ -435,7 +503,6 @@ func TestFormatTransactionCallObject(t *testing.T) {
 				"value":    "0", // 100 weibars is less than 1 tinybar, so it rounds to 0
 				"estimate": false,
 			},
-			expectError: false,
 		},
 		{
 			name: "Transaction with gas price",
@@ -448,7 +515,6 @@ func TestFormatTransactionCallObject(t *testing.T) {
 				"gasPrice": "100",
 				"estimate": true,
 			},
-			expectError: false,
 		},
 		{
 			name: "Transaction with gas",
@@ -462,7 +528,6 @@ func TestFormatTransactionCallObject(t *testing.T) {
 				"block":    "latest",
 				"estimate": false,
 			},
-			expectError: false,
 		},
 		{
 			name: "Transaction with input and data",
@@ -476,7 +541,6 @@ func TestFormatTransactionCallObject(t *testing.T) {
 				"data":     "0x123",
 				"estimate": false,
 			},
-			expectError: false,
 		},
 		{
 			name: "Error: Conflicting input and data",
@@ -487,15 +551,15 @@ func TestFormatTransactionCallObject(t *testing.T) {
 			blockParam:  nil,
 			estimate:    false,
 			expected:    nil,
-			expectError: true,
+			expectError: service.ErrConflictingCallData,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			result, err := service.FormatTransactionCallObject(s, tc.input, tc.blockParam, tc.estimate)
-			if tc.expectError {
-				assert.Error(t, err)
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError)
 				assert.Nil(t, result)
 			} else {
 				assert.NoError(t, err)
@@ -507,11 +571,10 @@ func TestFormatTransactionCallObject(t *testing.T) {
 
 func TestWeibarHexToTinyBarInt(t *testing.T) {
 	testCases := []struct {
-		name          string
-		input         string
-		expected      int64
-		expectError   bool
-		errorContains string
+		name        string
+		input       string
+		expected    int64
+		expectError error
 	}{
 		{
 			name:     "Zero value",
@@ -539,10 +602,19 @@ func TestWeibarHexToTinyBarInt(t *testing.T) {
 			expected: 1,
 		},
 		{
-			name:          "Invalid hex string",
-			input:         "0xNOTHEX",
-			expectError:   true,
-			errorContains: "failed to parse hex value",
+			name:        "Invalid hex string",
+			input:       "0xNOTHEX",
+			expectError: service.ErrInvalidHex,
+		},
+		{
+			name:        "Invalid decimal string",
+			input:       "12ab",
+			expectError: service.ErrInvalidNumber,
+		},
+		{
+			name:        "Value beyond int64 tinybars",
+			input:       "0x" + strings.Repeat("f", 40),
+			expectError: service.ErrValueOutOfRange,
 		},
 		{
 			name:     "Empty hex string",
@@ -554,11 +626,8 @@ func TestWeibarHexToTinyBarInt(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			result, err := service.WeibarHexToTinyBarInt(tc.input)
-			if tc.expectError {
-				assert.Error(t, err)
-				if tc.errorContains != "" {
-					assert.Contains(t, err.Error(), tc.errorContains)
-				}
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tc.expected, result)
@@ -619,18 +688,14 @@ func TestProcessTransactionResponse(t *testing.T) {
 		return "0x" + strings.Repeat(char, 64)
 	}
 
-	intPtr := func(i int) *int {
-		return &i
-	}
-
 	testCases := []struct {
 		name     string
-		input    domain.ContractResultResponse
+		input    domain.ContractResult
 		expected interface{}
 	}{
 		{
 			name: "Legacy transaction",
-			input: domain.ContractResultResponse{
+			input: domain.ContractResult{
 				BlockNumber:      123,
 				BlockHash:        makeHexString("1"),
 				Hash:             makeHexString("2"),
@@ -652,8 +717,8 @@ func TestProcessTransactionResponse(t *testing.T) {
 				BlockNumber:      stringPtr("0x7b"), // 123 in hex
 				From:             "0x" + strings.Repeat("3", 40),
 				To:               stringPtr("0x" + strings.Repeat("4", 40)),
-				Gas:              "0x5208", // 21000 in hex
-				GasPrice:         "0x5678",
+				Gas:              "0x5208",         // 21000 in hex
+				GasPrice:         "0xc953642ae000", // 0x5678 tinybars in weibars
 				Hash:             makeHexString("2"),
 				Nonce:            "0x5",
 				TransactionIndex: stringPtr("0x1"),
@@ -667,7 +732,7 @@ func TestProcessTransactionResponse(t *testing.T) {
 		},
 		{
 			name: "EIP-2930 transaction",
-			input: domain.ContractResultResponse{
+			input: domain.ContractResult{
 				BlockNumber:      456,
 				BlockHash:        makeHexString("5"),
 				Hash:             makeHexString("6"),
@@ -690,8 +755,8 @@ func TestProcessTransactionResponse(t *testing.T) {
 					BlockNumber:      stringPtr("0x1c8"), // 456 in hex
 					From:             "0x" + strings.Repeat("7", 40),
 					To:               stringPtr("0x" + strings.Repeat("8", 40)),
-					Gas:              "0x5208", // 21000 in hex
-					GasPrice:         "0x5678",
+					Gas:              "0x5208",         // 21000 in hex
+					GasPrice:         "0xc953642ae000", // 0x5678 tinybars in weibars
 					Hash:             makeHexString("6"),
 					Nonce:            "0x6",
 					TransactionIndex: stringPtr("0x2"),
@@ -707,7 +772,7 @@ func TestProcessTransactionResponse(t *testing.T) {
 		},
 		{
 			name: "EIP-1559 transaction",
-			input: domain.ContractResultResponse{
+			input: domain.ContractResult{
 				BlockNumber:          789,
 				BlockHash:            makeHexString("9"),
 				Hash:                 makeHexString("f"),
@@ -732,8 +797,8 @@ func TestProcessTransactionResponse(t *testing.T) {
 					BlockNumber:      stringPtr("0x315"), // 789 in hex
 					From:             "0x" + strings.Repeat("a", 40),
 					To:               stringPtr("0x" + strings.Repeat("b", 40)),
-					Gas:              "0x5208", // 21000 in hex
-					GasPrice:         "0x5678",
+					Gas:              "0x5208",         // 21000 in hex
+					GasPrice:         "0xc953642ae000", // 0x5678 tinybars in weibars
 					Hash:             makeHexString("f"),
 					Nonce:            "0x7",
 					TransactionIndex: stringPtr("0x3"),
@@ -765,7 +830,7 @@ func TestProcessTransactionResponse(t *testing.T) {
 					},
 				},
 			}
-			tieredLimiter := limiter.NewTieredLimiter(cfg, 1000)
+			tieredLimiter := limiter.NewTieredLimiter(cfg, 1000, 0)
 
 			// Set up cache expectations for both 'from' and 'to' addresses
 			fromAddress := tc.input.From
@@ -789,9 +854,9 @@ func TestProcessTransactionResponse(t *testing.T) {
 					return nil
 				}).AnyTimes()
 
-			s := service.NewEthService(mockHederaClient, mockMirrorClient, nil, logger, tieredLimiter, defaultChainId, mockCacheService)
+			s := service.NewEthService(mockHederaClient, mockMirrorClient, nil, logger, tieredLimiter, defaultChainId, mockCacheService, service.Config{})
 
-			result := s.ProcessTransactionResponse(tc.input)
+			result := s.ProcessTransactionResponse(context.Background(), tc.input)
 
 			// Type-specific assertions
 			switch expected := tc.expected.(type) {
@@ -818,3 +883,14 @@ func TestProcessTransactionResponse(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestParseTransaction_Invalid(t *testing.T) {
+	for _, rawTx := range []string{"", "0xnothex", "0x1234"} {
+		_, err := service.ParseTransaction(rawTx)
+		assert.ErrorIs(t, err, service.ErrInvalidTransaction, rawTx)
+	}
+}
@@ -2,18 +2,25 @@ package service_test
 
 import (
 	"context"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
 	"github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
 	"github.com/LimeChain/Hederium/internal/service"
+	"github.com/LimeChain/Hederium/internal/util"
 	"github.com/LimeChain/Hederium/test/unit/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -57,7 +64,7 @@ func TestGetBlockNumber(t *testing.T) {
 		Times(1)
 
 	commonService.EXPECT().
-		GetBlockNumber().
+		GetBlockNumber(gomock.Any()).
 		Return("0x2a", nil).
 		Times(1)
 
@@ -74,13 +81,52 @@ func TestGetBlockNumber(t *testing.T) {
 		nil,
 		defaultChainId,
 		cacheService,
+		service.Config{},
 	)
 
-	result, errMap := s.GetBlockNumber()
+	result, errMap := s.GetBlockNumber(context.Background())
 	assert.Nil(t, errMap)
 	assert.Equal(t, "0x2a", result)
 }
 
+func TestGetBlockNumber_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cacheService := mocks.NewMockCacheService(ctrl)
+	commonService := mocks.NewMockCommonService(ctrl)
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+
+	cacheService.EXPECT().
+		Get(gomock.Any(), GetBlockNumber, gomock.Any()).
+		Return(errors.New("not found")).
+		Times(1)
+
+	commonService.EXPECT().
+		GetBlockNumber(gomock.Any()).
+		Return(nil, domain.NewRPCError(domain.ServerError, "Failed to fetch latest block")).
+		Times(1)
+
+	// Nothing is cached, so the next call asks the mirror node again
+	cacheService.EXPECT().Set(gomock.Any(), GetBlockNumber, gomock.Any(), gomock.Any()).Times(0)
+
+	s := service.NewEthService(
+		nil,
+		mockClient,
+		commonService,
+		zap.NewNop(),
+		nil,
+		defaultChainId,
+		cacheService,
+		service.Config{},
+	)
+
+	result, errRpc := s.GetBlockNumber(context.Background())
+	assert.Nil(t, result)
+	require.NotNil(t, errRpc)
+	assert.Equal(t, domain.ServerError, errRpc.Code)
+}
+
 func TestGetAccounts(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -104,6 +150,7 @@ func TestGetAccounts(t *testing.T) {
 		nil, // tieredLimiter not needed for this test
 		defaultChainId,
 		cacheService,
+		service.Config{},
 	)
 
 	result, errMap := s.GetAccounts()
@@ -114,6 +161,29 @@ func TestGetAccounts(t *testing.T) {
 	assert.Empty(t, accounts, "Accounts array should be empty")
 }
 
+func TestGetAccounts_Configured(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	addresses := []string{"0x67d8d32e9bf1a9968a5ff53b87d777aa8ebbee69"}
+	s := service.NewEthService(nil, mocks.NewMockMirrorClient(ctrl), nil, zap.NewNop(), nil, defaultChainId, mocks.NewMockCacheService(ctrl), service.Config{
+		AccountsEnabled: true,
+		Accounts:        addresses,
+	})
+
+	result, errRpc := s.GetAccounts()
+	assert.Nil(t, errRpc)
+	assert.Equal(t, addresses, result)
+
+	// The list is reported only while enabled
+	s = service.NewEthService(nil, mocks.NewMockMirrorClient(ctrl), nil, zap.NewNop(), nil, defaultChainId, mocks.NewMockCacheService(ctrl), service.Config{
+		Accounts: addresses,
+	})
+	result, errRpc = s.GetAccounts()
+	assert.Nil(t, errRpc)
+	assert.Equal(t, []string{}, result)
+}
+
 func TestSyncing(t *testing.T) {
 	// Setup
 	ctrl := gomock.NewController(t)
@@ -123,14 +193,64 @@ func TestSyncing(t *testing.T) {
 	mockClient := mocks.NewMockMirrorClient(ctrl)
 	cacheService := mocks.NewMockCacheService(ctrl)
 
-	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService)
+	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService, service.Config{})
 
 	// Test
-	result, errMap := s.Syncing()
+	result, errMap := s.Syncing(context.Background())
 	assert.Nil(t, errMap)
 	assert.Equal(t, false, result)
 }
 
+func TestSyncing_LagCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	cacheService := mocks.NewMockCacheService(ctrl)
+
+	s := service.NewEthService(nil, mockClient, nil, zap.NewNop(), nil, defaultChainId, cacheService, service.Config{
+		SyncingCheckEnabled: true,
+		SyncingLagThreshold: time.Minute,
+	})
+
+	latestBlock := func(age time.Duration) map[string]interface{} {
+		blockTime := time.Now().Add(-age)
+		return map[string]interface{}{
+			"number":    float64(100),
+			"timestamp": map[string]interface{}{"to": fmt.Sprintf("%d.%09d", blockTime.Unix(), blockTime.Nanosecond())},
+		}
+	}
+
+	t.Run("Up to date", func(t *testing.T) {
+		mockClient.EXPECT().GetLatestBlock(gomock.Any()).Return(latestBlock(5*time.Second), nil)
+
+		result, errRpc := s.Syncing(context.Background())
+		assert.Nil(t, errRpc)
+		assert.Equal(t, false, result)
+	})
+
+	t.Run("Lagging", func(t *testing.T) {
+		mockClient.EXPECT().GetLatestBlock(gomock.Any()).Return(latestBlock(10*time.Minute), nil)
+
+		result, errRpc := s.Syncing(context.Background())
+		assert.Nil(t, errRpc)
+		status, ok := result.(domain.SyncingStatus)
+		if assert.True(t, ok) {
+			assert.Equal(t, "0x64", status.StartingBlock)
+			assert.Equal(t, "0x64", status.CurrentBlock)
+			assert.Equal(t, "0x190", status.HighestBlock) // 100 + 600s / 2s
+		}
+	})
+
+	t.Run("Mirror node failure", func(t *testing.T) {
+		mockClient.EXPECT().GetLatestBlock(gomock.Any()).Return(nil, errors.New("unavailable"))
+
+		result, errRpc := s.Syncing(context.Background())
+		assert.Nil(t, result)
+		assert.NotNil(t, errRpc)
+	})
+}
+
 func TestMining(t *testing.T) {
 	// Setup
 	ctrl := gomock.NewController(t)
@@ -140,7 +260,7 @@ func TestMining(t *testing.T) {
 	mockClient := mocks.NewMockMirrorClient(ctrl)
 	cacheService := mocks.NewMockCacheService(ctrl)
 
-	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService)
+	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService, service.Config{})
 
 	// Test
 	result, errMap := s.Mining()
@@ -157,7 +277,7 @@ func TestMaxPriorityFeePerGas(t *testing.T) {
 	mockClient := mocks.NewMockMirrorClient(ctrl)
 	cacheService := mocks.NewMockCacheService(ctrl)
 
-	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService)
+	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService, service.Config{})
 
 	// Test
 	result, errMap := s.MaxPriorityFeePerGas()
@@ -165,6 +285,16 @@ func TestMaxPriorityFeePerGas(t *testing.T) {
 	assert.Equal(t, "0x0", result)
 }
 
+func TestMaxPriorityFeePerGas_Configured(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	s := service.NewEthService(nil, nil, nil, logger, nil, defaultChainId, nil, service.Config{MaxPriorityFeePerGas: 1000000000})
+
+	result, errMap := s.MaxPriorityFeePerGas()
+	assert.Nil(t, errMap)
+	assert.Equal(t, "0x3b9aca00", result)
+}
+
 func TestHashrate(t *testing.T) {
 	// Setup
 	ctrl := gomock.NewController(t)
@@ -174,7 +304,7 @@ func TestHashrate(t *testing.T) {
 	mockClient := mocks.NewMockMirrorClient(ctrl)
 	cacheService := mocks.NewMockCacheService(ctrl)
 
-	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService)
+	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService, service.Config{})
 
 	// Test
 	result, errMap := s.Hashrate()
@@ -191,7 +321,7 @@ func TestUncleRelatedMethods(t *testing.T) {
 	mockClient := mocks.NewMockMirrorClient(ctrl)
 	cacheService := mocks.NewMockCacheService(ctrl)
 
-	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService)
+	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService, service.Config{})
 
 	// Test all uncle-related methods
 	t.Run("GetUncleCountByBlockNumber", func(t *testing.T) {
@@ -227,7 +357,7 @@ func TestGetBlockTransactionCountByHash(t *testing.T) {
 	mockClient := mocks.NewMockMirrorClient(ctrl)
 	cacheService := mocks.NewMockCacheService(ctrl)
 
-	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService)
+	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService, service.Config{})
 
 	testCases := []struct {
 		name           string
@@ -279,9 +409,13 @@ func TestGetBlockTransactionCountByHash(t *testing.T) {
 				Get(gomock.Any(), fmt.Sprintf("eth_getBlockTransactionCountByHash_%s", tc.blockHash), gomock.Any()).
 				Return(fmt.Errorf("not found"))
 
+			var mockErr error
+			if tc.mockResponse == nil {
+				mockErr = hedera.ErrNotFound
+			}
 			mockClient.EXPECT().
-				GetBlockByHashOrNumber(tc.blockHash).
-				Return(tc.mockResponse)
+				GetBlockByHashOrNumber(gomock.Any(), tc.blockHash).
+				Return(tc.mockResponse, mockErr)
 
 			if tc.mockResponse != nil {
 				cacheService.EXPECT().
@@ -289,7 +423,7 @@ func TestGetBlockTransactionCountByHash(t *testing.T) {
 					Return(nil)
 			}
 
-			result, errRpc := s.GetBlockTransactionCountByHash(tc.blockHash)
+			result, errRpc := s.GetBlockTransactionCountByHash(context.Background(), tc.blockHash)
 
 			assert.Equal(t, tc.expectedResult, result)
 			assert.Equal(t, tc.expectedError, errRpc)
@@ -320,6 +454,7 @@ func TestGetGasPrice(t *testing.T) {
 		nil, // tieredLimiter not needed for this test
 		defaultChainId,
 		cacheService,
+		service.Config{},
 	)
 
 	// Set up cache expectations
@@ -328,7 +463,7 @@ func TestGetGasPrice(t *testing.T) {
 		Return(fmt.Errorf("not found"))
 
 	mockClient.EXPECT().
-		GetNetworkFees("", "").
+		GetNetworkFees(gomock.Any(), "", "").
 		Return(int64(100), nil) // Return 100 tinybars
 
 	expectedResult := "0xe8d4a51000"
@@ -336,7 +471,7 @@ func TestGetGasPrice(t *testing.T) {
 		Set(gomock.Any(), "eth_gasPrice", expectedResult, service.DefaultExpiration).
 		Return(nil)
 
-	result, errMap := s.GetGasPrice()
+	result, errMap := s.GetGasPrice(context.Background())
 	assert.Nil(t, errMap)
 
 	// Expected calculation:
@@ -345,6 +480,79 @@ func TestGetGasPrice(t *testing.T) {
 	assert.Equal(t, expectedResult, result)
 }
 
+func TestGetGasPrice_CachedForGasPriceTTL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cacheService := mocks.NewMockCacheService(ctrl)
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	s := service.NewEthService(nil, mockClient, nil, zap.NewNop(), nil, defaultChainId, cacheService, service.Config{
+		CacheTTLs: cache.TTLs{cache.ClassGasPrice: 10 * time.Second, cache.ClassBlock: 6 * time.Hour},
+	})
+
+	cacheService.EXPECT().Get(gomock.Any(), "eth_gasPrice", gomock.Any()).Return(fmt.Errorf("not found"))
+	mockClient.EXPECT().GetNetworkFees(gomock.Any(), "", "").Return(int64(100), nil)
+	cacheService.EXPECT().Set(gomock.Any(), "eth_gasPrice", "0xe8d4a51000", 10*time.Second).Return(nil)
+
+	result, errRpc := s.GetGasPrice(context.Background())
+	assert.Nil(t, errRpc)
+	assert.Equal(t, "0xe8d4a51000", result)
+}
+
+func TestGetGasPrice_ConcurrentMissesShareRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger, _ := zap.NewDevelopment()
+	cacheService := mocks.NewMockCacheService(ctrl)
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+
+	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService, service.Config{})
+
+	const callers = 20
+	var arrived sync.WaitGroup
+	arrived.Add(callers)
+
+	cacheService.EXPECT().
+		Get(gomock.Any(), "eth_gasPrice", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, value interface{}) error {
+			arrived.Done()
+			arrived.Wait()
+			return fmt.Errorf("not found")
+		}).
+		Times(callers)
+
+	release := make(chan struct{})
+	mockClient.EXPECT().
+		GetNetworkFees(gomock.Any(), "", "").
+		DoAndReturn(func(ctx context.Context, timestampTo, order string) (int64, error) {
+			<-release
+			return int64(100), nil
+		}).
+		Times(1)
+
+	cacheService.EXPECT().
+		Set(gomock.Any(), "eth_gasPrice", "0xe8d4a51000", service.DefaultExpiration).
+		Return(nil).
+		Times(1)
+
+	results := make(chan interface{}, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			result, _ := s.GetGasPrice(context.Background())
+			results <- result
+		}()
+	}
+
+	arrived.Wait()
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+
+	for i := 0; i < callers; i++ {
+		assert.Equal(t, "0xe8d4a51000", <-results)
+	}
+}
+
 func TestGetGasPrice_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -353,7 +561,7 @@ func TestGetGasPrice_Error(t *testing.T) {
 	mockClient := mocks.NewMockMirrorClient(ctrl)
 	cacheService := mocks.NewMockCacheService(ctrl)
 
-	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService)
+	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService, service.Config{})
 
 	// Set up cache expectations
 	cacheService.EXPECT().
@@ -362,14 +570,33 @@ func TestGetGasPrice_Error(t *testing.T) {
 
 	// Set up mirror client expectations to return error
 	mockClient.EXPECT().
-		GetNetworkFees("", "").
+		GetNetworkFees(gomock.Any(), "", "").
 		Return(int64(0), fmt.Errorf("failed to fetch network fees"))
 
-	result, errRpc := s.GetGasPrice()
+	result, errRpc := s.GetGasPrice(context.Background())
 	assert.Nil(t, result)
 	assert.Equal(t, domain.NewRPCError(domain.ServerError, "Failed to fetch gas price"), errRpc)
 }
 
+func TestGetGasPrice_MirrorNodeRateLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	cacheService := mocks.NewMockCacheService(ctrl)
+	s := service.NewEthService(nil, mockClient, nil, zap.NewNop(), nil, defaultChainId, cacheService, service.Config{})
+
+	cacheService.EXPECT().Get(gomock.Any(), "eth_gasPrice", gomock.Any()).Return(fmt.Errorf("not found"))
+	mockClient.EXPECT().
+		GetNetworkFees(gomock.Any(), "", "").
+		Return(int64(0), fmt.Errorf("network fees: %w", hedera.ErrRateLimited))
+
+	// The mirror node error survives the helpers wrapping it
+	result, errRpc := s.GetGasPrice(context.Background())
+	assert.Nil(t, result)
+	assert.Equal(t, domain.NewMirrorNodeRateLimitedError(), errRpc)
+}
+
 func TestGetChainId(t *testing.T) {
 	// Create a logger for testing
 	cfg := zap.NewDevelopmentConfig()
@@ -416,6 +643,7 @@ func TestGetChainId(t *testing.T) {
 				nil, // tieredLimiter not needed for this test
 				tc.chainId,
 				cacheService,
+				service.Config{},
 			)
 
 			result, errMap := s.GetChainId()
@@ -452,7 +680,7 @@ func TestGetBlockByHash(t *testing.T) {
 		hash         string
 		showDetails  bool
 		mockResponse *domain.BlockResponse
-		mockResults  []domain.ContractResults
+		mockResults  []domain.ContractResult
 		expectNil    bool
 	}{
 		{
@@ -460,7 +688,7 @@ func TestGetBlockByHash(t *testing.T) {
 			hash:         testHash,
 			showDetails:  false,
 			mockResponse: expectedBlock,
-			mockResults: []domain.ContractResults{
+			mockResults: []domain.ContractResult{
 				{
 					Hash:   "0xtx1",
 					Result: "SUCCESS",
@@ -494,14 +722,18 @@ func TestGetBlockByHash(t *testing.T) {
 				Get(gomock.Any(), cacheKey, gomock.Any()).
 				Return(fmt.Errorf("not found"))
 
+			var mockErr error
+			if tc.mockResponse == nil {
+				mockErr = hedera.ErrNotFound
+			}
 			mockClient.EXPECT().
-				GetBlockByHashOrNumber(tc.hash).
-				Return(tc.mockResponse)
+				GetBlockByHashOrNumber(gomock.Any(), tc.hash).
+				Return(tc.mockResponse, mockErr)
 
 			if tc.mockResponse != nil {
 				mockClient.EXPECT().
-					GetContractResults(tc.mockResponse.Timestamp).
-					Return(tc.mockResults)
+					GetContractResults(gomock.Any(), tc.mockResponse.Timestamp).
+					Return(tc.mockResults, nil)
 
 				// For each transaction in mockResults, set up cache expectations for resolving addresses
 				for _, tx := range tc.mockResults {
@@ -530,8 +762,8 @@ func TestGetBlockByHash(t *testing.T) {
 					Return(nil)
 			}
 
-			s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, mockCacheService)
-			result, errMap := s.GetBlockByHash(tc.hash, tc.showDetails)
+			s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, mockCacheService, service.Config{})
+			result, errMap := s.GetBlockByHash(context.Background(), tc.hash, tc.showDetails)
 
 			if tc.expectNil {
 				assert.Nil(t, result)
@@ -586,7 +818,7 @@ func TestGetBlockByNumber(t *testing.T) {
 		numberOrTag  string
 		showDetails  bool
 		mockResponse *domain.BlockResponse
-		mockResults  []domain.ContractResults
+		mockResults  []domain.ContractResult
 		expectNil    bool
 		setupMocks   func()
 	}{
@@ -595,7 +827,7 @@ func TestGetBlockByNumber(t *testing.T) {
 			numberOrTag:  "0x7b",
 			showDetails:  false,
 			mockResponse: expectedBlock,
-			mockResults: []domain.ContractResults{{
+			mockResults: []domain.ContractResult{{
 				Hash:   "0xtx1",
 				Result: "SUCCESS",
 				From:   "0x" + strings.Repeat("2", 40),
@@ -605,7 +837,7 @@ func TestGetBlockByNumber(t *testing.T) {
 			setupMocks: func() {
 				// Mock GetBlockNumberByNumberOrTag for hex block
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("0x7b").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "0x7b").
 					Return(int64(123), nil)
 
 				// Mock cache miss for block
@@ -616,18 +848,18 @@ func TestGetBlockByNumber(t *testing.T) {
 
 				// Mock getting block data
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("123").
-					Return(expectedBlock)
+					GetBlockByHashOrNumber(gomock.Any(), "123").
+					Return(expectedBlock, nil)
 
 				// Mock getting contract results
 				mockClient.EXPECT().
-					GetContractResults(expectedBlock.Timestamp).
-					Return([]domain.ContractResults{{
+					GetContractResults(gomock.Any(), expectedBlock.Timestamp).
+					Return([]domain.ContractResult{{
 						Hash:   "0xtx1",
 						Result: "SUCCESS",
 						From:   "0x" + strings.Repeat("2", 40),
 						To:     "0x" + strings.Repeat("3", 40),
-					}})
+					}}, nil)
 
 				// Mock address resolution for 'from' address
 				fromAddr := "0x" + strings.Repeat("2", 40)
@@ -637,11 +869,11 @@ func TestGetBlockByNumber(t *testing.T) {
 					Return(errors.New("not found"))
 
 				mockClient.EXPECT().
-					GetContractById(fromAddr).
+					GetContractById(gomock.Any(), fromAddr).
 					Return(nil, errors.New("not found"))
 
 				mockClient.EXPECT().
-					GetAccountById(fromAddr).
+					GetAccountById(gomock.Any(), fromAddr).
 					Return(&domain.AccountResponse{
 						EvmAddress: fromAddr,
 					}, nil)
@@ -658,11 +890,11 @@ func TestGetBlockByNumber(t *testing.T) {
 					Return(errors.New("not found"))
 
 				mockClient.EXPECT().
-					GetContractById(toAddr).
+					GetContractById(gomock.Any(), toAddr).
 					Return(nil, errors.New("not found"))
 
 				mockClient.EXPECT().
-					GetAccountById(toAddr).
+					GetAccountById(gomock.Any(), toAddr).
 					Return(&domain.AccountResponse{
 						EvmAddress: toAddr,
 					}, nil)
@@ -682,7 +914,7 @@ func TestGetBlockByNumber(t *testing.T) {
 			numberOrTag:  "latest",
 			showDetails:  false,
 			mockResponse: expectedBlock,
-			mockResults: []domain.ContractResults{{
+			mockResults: []domain.ContractResult{{
 				Hash:   "0xtx1",
 				Result: "SUCCESS",
 				From:   "0x" + strings.Repeat("2", 40),
@@ -690,9 +922,15 @@ func TestGetBlockByNumber(t *testing.T) {
 			}},
 			expectNil: false,
 			setupMocks: func() {
+				// Mock cache miss for the latest block
+				latestKey := fmt.Sprintf("eth_getBlockByNumber_latest_%t", false)
+				cacheService.EXPECT().
+					Get(gomock.Any(), latestKey, gomock.Any()).
+					Return(errors.New("not found"))
+
 				// Mock GetBlockNumberByNumberOrTag for latest block
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("latest").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "latest").
 					Return(int64(100), nil)
 
 				// Mock cache miss for block
@@ -703,18 +941,18 @@ func TestGetBlockByNumber(t *testing.T) {
 
 				// Mock getting block data
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("100").
-					Return(expectedBlock)
+					GetBlockByHashOrNumber(gomock.Any(), "100").
+					Return(expectedBlock, nil)
 
 				// Mock getting contract results
 				mockClient.EXPECT().
-					GetContractResults(expectedBlock.Timestamp).
-					Return([]domain.ContractResults{{
+					GetContractResults(gomock.Any(), expectedBlock.Timestamp).
+					Return([]domain.ContractResult{{
 						Hash:   "0xtx1",
 						Result: "SUCCESS",
 						From:   "0x" + strings.Repeat("2", 40),
 						To:     "0x" + strings.Repeat("3", 40),
-					}})
+					}}, nil)
 
 				// Mock address resolution for 'from' address
 				fromAddr := "0x" + strings.Repeat("2", 40)
@@ -724,11 +962,11 @@ func TestGetBlockByNumber(t *testing.T) {
 					Return(errors.New("not found"))
 
 				mockClient.EXPECT().
-					GetContractById(fromAddr).
+					GetContractById(gomock.Any(), fromAddr).
 					Return(nil, errors.New("not found"))
 
 				mockClient.EXPECT().
-					GetAccountById(fromAddr).
+					GetAccountById(gomock.Any(), fromAddr).
 					Return(&domain.AccountResponse{
 						EvmAddress: fromAddr,
 					}, nil)
@@ -745,11 +983,11 @@ func TestGetBlockByNumber(t *testing.T) {
 					Return(errors.New("not found"))
 
 				mockClient.EXPECT().
-					GetContractById(toAddr).
+					GetContractById(gomock.Any(), toAddr).
 					Return(nil, errors.New("not found"))
 
 				mockClient.EXPECT().
-					GetAccountById(toAddr).
+					GetAccountById(gomock.Any(), toAddr).
 					Return(&domain.AccountResponse{
 						EvmAddress: toAddr,
 					}, nil)
@@ -758,18 +996,35 @@ func TestGetBlockByNumber(t *testing.T) {
 					Set(gomock.Any(), toCacheKey, toAddr, service.DefaultExpiration).
 					Return(nil)
 
-				// Mock cache set for block
+				// The latest block is cached under the tag, briefly
 				cacheService.EXPECT().
-					Set(gomock.Any(), cacheKey, gomock.Any(), service.DefaultExpiration).
+					Set(gomock.Any(), latestKey, gomock.Any(), service.ShortExpiration).
 					Return(nil)
 			},
 		},
+		{
+			name:        "Pending served as the cached latest block",
+			numberOrTag: "pending",
+			showDetails: false,
+			expectNil:   false,
+			setupMocks: func() {
+				latestKey := fmt.Sprintf("eth_getBlockByNumber_latest_%t", false)
+				cacheService.EXPECT().
+					Get(gomock.Any(), latestKey, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, value interface{}) error {
+						hash := "0x123abc"
+						number := "0x7b"
+						*value.(*domain.Block) = domain.Block{Hash: &hash, Number: &number}
+						return nil
+					})
+			},
+		},
 		{
 			name:         "Success with earliest tag",
 			numberOrTag:  "earliest",
 			showDetails:  false,
 			mockResponse: expectedBlock,
-			mockResults: []domain.ContractResults{{
+			mockResults: []domain.ContractResult{{
 				Hash:   "0xtx1",
 				Result: "SUCCESS",
 				From:   "0x" + strings.Repeat("2", 40),
@@ -779,7 +1034,7 @@ func TestGetBlockByNumber(t *testing.T) {
 			setupMocks: func() {
 				// Mock GetBlockNumberByNumberOrTag for earliest block
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("earliest").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "earliest").
 					Return(int64(0), nil)
 
 				// Mock cache miss for block
@@ -790,18 +1045,18 @@ func TestGetBlockByNumber(t *testing.T) {
 
 				// Mock getting block data
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("0").
-					Return(expectedBlock)
+					GetBlockByHashOrNumber(gomock.Any(), "0").
+					Return(expectedBlock, nil)
 
 				// Mock getting contract results
 				mockClient.EXPECT().
-					GetContractResults(expectedBlock.Timestamp).
-					Return([]domain.ContractResults{{
+					GetContractResults(gomock.Any(), expectedBlock.Timestamp).
+					Return([]domain.ContractResult{{
 						Hash:   "0xtx1",
 						Result: "SUCCESS",
 						From:   "0x" + strings.Repeat("2", 40),
 						To:     "0x" + strings.Repeat("3", 40),
-					}})
+					}}, nil)
 
 				// Mock address resolution for 'from' address
 				fromAddr := "0x" + strings.Repeat("2", 40)
@@ -811,11 +1066,11 @@ func TestGetBlockByNumber(t *testing.T) {
 					Return(errors.New("not found"))
 
 				mockClient.EXPECT().
-					GetContractById(fromAddr).
+					GetContractById(gomock.Any(), fromAddr).
 					Return(nil, errors.New("not found"))
 
 				mockClient.EXPECT().
-					GetAccountById(fromAddr).
+					GetAccountById(gomock.Any(), fromAddr).
 					Return(&domain.AccountResponse{
 						EvmAddress: fromAddr,
 					}, nil)
@@ -832,11 +1087,11 @@ func TestGetBlockByNumber(t *testing.T) {
 					Return(errors.New("not found"))
 
 				mockClient.EXPECT().
-					GetContractById(toAddr).
+					GetContractById(gomock.Any(), toAddr).
 					Return(nil, errors.New("not found"))
 
 				mockClient.EXPECT().
-					GetAccountById(toAddr).
+					GetAccountById(gomock.Any(), toAddr).
 					Return(&domain.AccountResponse{
 						EvmAddress: toAddr,
 					}, nil)
@@ -860,7 +1115,7 @@ func TestGetBlockByNumber(t *testing.T) {
 			setupMocks: func() {
 				// Mock GetBlockNumberByNumberOrTag for non-existent block
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("0x999").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "0x999").
 					Return(int64(2457), nil)
 
 				// Mock cache miss for block
@@ -871,8 +1126,8 @@ func TestGetBlockByNumber(t *testing.T) {
 
 				// Mock getting block data returns nil for non-existent block
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("2457").
-					Return(nil)
+					GetBlockByHashOrNumber(gomock.Any(), "2457").
+					Return(nil, hedera.ErrNotFound)
 			},
 		},
 		{
@@ -883,7 +1138,7 @@ func TestGetBlockByNumber(t *testing.T) {
 			setupMocks: func() {
 				// Mock GetBlockNumberByNumberOrTag to return error for invalid hex
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("0xinvalid").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "0xinvalid").
 					Return(int64(0), domain.NewRPCError(domain.ServerError, "Invalid block number"))
 			},
 		},
@@ -896,7 +1151,7 @@ func TestGetBlockByNumber(t *testing.T) {
 			setupMocks: func() {
 				// Mock GetBlockNumberByNumberOrTag for hex block
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("0x7b").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "0x7b").
 					Return(int64(123), nil)
 
 				cacheKey := fmt.Sprintf("eth_getBlockByNumber_%d_%t", 123, false)
@@ -933,7 +1188,7 @@ func TestGetBlockByNumber(t *testing.T) {
 			numberOrTag:  "0x7b",
 			showDetails:  true,
 			mockResponse: expectedBlock,
-			mockResults: []domain.ContractResults{{
+			mockResults: []domain.ContractResult{{
 				Hash:             "0xtx1",
 				Result:           "SUCCESS",
 				BlockHash:        expectedBlock.Hash,
@@ -946,7 +1201,7 @@ func TestGetBlockByNumber(t *testing.T) {
 			setupMocks: func() {
 				// Mock GetBlockNumberByNumberOrTag for hex block
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("0x7b").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "0x7b").
 					Return(int64(123), nil)
 
 				// Mock cache miss for transaction
@@ -956,12 +1211,12 @@ func TestGetBlockByNumber(t *testing.T) {
 					Return(errors.New("not found"))
 
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("123").
-					Return(expectedBlock)
+					GetBlockByHashOrNumber(gomock.Any(), "123").
+					Return(expectedBlock, nil)
 
 				mockClient.EXPECT().
-					GetContractResults(expectedBlock.Timestamp).
-					Return([]domain.ContractResults{{
+					GetContractResults(gomock.Any(), expectedBlock.Timestamp).
+					Return([]domain.ContractResult{{
 						Hash:             "0xtx1",
 						Result:           "SUCCESS",
 						BlockHash:        expectedBlock.Hash,
@@ -969,7 +1224,7 @@ func TestGetBlockByNumber(t *testing.T) {
 						TransactionIndex: 0,
 						From:             "0x" + strings.Repeat("2", 40),
 						To:               "0x" + strings.Repeat("3", 40),
-					}})
+					}}, nil)
 
 				// Mock resolveEvmAddress for 'from' address
 				fromCacheKey := fmt.Sprintf("evm_address_%s", "0x"+strings.Repeat("2", 40))
@@ -978,11 +1233,11 @@ func TestGetBlockByNumber(t *testing.T) {
 					Return(errors.New("not found"))
 
 				mockClient.EXPECT().
-					GetContractById("0x"+strings.Repeat("2", 40)).
+					GetContractById(gomock.Any(), "0x"+strings.Repeat("2", 40)).
 					Return(nil, errors.New("not found"))
 
 				mockClient.EXPECT().
-					GetAccountById("0x"+strings.Repeat("2", 40)).
+					GetAccountById(gomock.Any(), "0x"+strings.Repeat("2", 40)).
 					Return(&domain.AccountResponse{
 						EvmAddress: "0x" + strings.Repeat("2", 40),
 					}, nil)
@@ -998,11 +1253,11 @@ func TestGetBlockByNumber(t *testing.T) {
 					Return(errors.New("not found"))
 
 				mockClient.EXPECT().
-					GetContractById("0x"+strings.Repeat("3", 40)).
+					GetContractById(gomock.Any(), "0x"+strings.Repeat("3", 40)).
 					Return(nil, errors.New("not found"))
 
 				mockClient.EXPECT().
-					GetAccountById("0x"+strings.Repeat("3", 40)).
+					GetAccountById(gomock.Any(), "0x"+strings.Repeat("3", 40)).
 					Return(&domain.AccountResponse{
 						EvmAddress: "0x" + strings.Repeat("3", 40),
 					}, nil)
@@ -1022,8 +1277,8 @@ func TestGetBlockByNumber(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setupMocks()
 
-			s := service.NewEthService(nil, mockClient, commonService, logger, nil, defaultChainId, cacheService)
-			result, errRpc := s.GetBlockByNumber(tc.numberOrTag, tc.showDetails)
+			s := service.NewEthService(nil, mockClient, commonService, logger, nil, defaultChainId, cacheService, service.Config{})
+			result, errRpc := s.GetBlockByNumber(context.Background(), tc.numberOrTag, tc.showDetails)
 
 			if tc.name == "Invalid hex number" {
 				assert.NotNil(t, errRpc)
@@ -1088,6 +1343,7 @@ func TestGetBalance(t *testing.T) {
 		nil,
 		defaultChainId,
 		cacheService,
+		service.Config{},
 	)
 
 	testCases := []struct {
@@ -1102,9 +1358,25 @@ func TestGetBalance(t *testing.T) {
 			address:    "0x1234567890123456789012345678901234567890",
 			blockParam: "latest",
 			setupMock: func() {
+				// The address is resolved to the account once and then cached.
+				// The lookups run concurrently, so the contract lookup may not
+				// have been made when the account is found
+				cacheService.EXPECT().
+					Get(gomock.Any(), "balance_account_0x1234567890123456789012345678901234567890", gomock.Any()).
+					Return(errors.New("not found"))
+				mockClient.EXPECT().
+					GetContractById(gomock.Any(), "0x1234567890123456789012345678901234567890").
+					Return(nil, hedera.ErrNotFound).
+					MaxTimes(1)
+				mockClient.EXPECT().
+					GetAccountById(gomock.Any(), "0x1234567890123456789012345678901234567890").
+					Return(&domain.AccountResponse{Account: "0.0.1001"}, nil)
+				cacheService.EXPECT().
+					Set(gomock.Any(), "balance_account_0x1234567890123456789012345678901234567890", "0.0.1001", gomock.Any()).
+					Return(nil)
 				mockClient.EXPECT().
-					GetBalance("0x1234567890123456789012345678901234567890", "0").
-					Return("0x64")
+					GetBalance(gomock.Any(), "0.0.1001", "0").
+					Return("0x64", nil)
 			},
 			expectedResult: "0x64",
 		},
@@ -1114,20 +1386,24 @@ func TestGetBalance(t *testing.T) {
 			blockParam: "earliest",
 			setupMock: func() {
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("0").
+					GetBlockByHashOrNumber(gomock.Any(), "0").
 					Return(&domain.BlockResponse{
 						Timestamp: domain.Timestamp{
 							To: "2023-01-01T00:00:00.000Z",
 						},
-					})
+					}, nil)
 				mockClient.EXPECT().
-					GetLatestBlock().
+					GetLatestBlock(gomock.Any()).
 					Return(map[string]interface{}{
 						"number": float64(100),
 					}, nil)
+				cacheService.EXPECT().
+					Get(gomock.Any(), "balance_account_0x1234567890123456789012345678901234567890", gomock.Any()).
+					SetArg(2, "0.0.1001").
+					Return(nil)
 				mockClient.EXPECT().
-					GetBalance("0x1234567890123456789012345678901234567890", "2023-01-01T00:00:00.000Z").
-					Return("0x32")
+					GetBalance(gomock.Any(), "0.0.1001", "2023-01-01T00:00:00.000Z").
+					Return("0x32", nil)
 			},
 			expectedResult: "0x32",
 		},
@@ -1137,20 +1413,24 @@ func TestGetBalance(t *testing.T) {
 			blockParam: "0x50",
 			setupMock: func() {
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("80").
+					GetBlockByHashOrNumber(gomock.Any(), "80").
 					Return(&domain.BlockResponse{
 						Timestamp: domain.Timestamp{
 							To: "2023-06-01T00:00:00.000Z",
 						},
-					})
+					}, nil)
 				mockClient.EXPECT().
-					GetLatestBlock().
+					GetLatestBlock(gomock.Any()).
 					Return(map[string]interface{}{
 						"number": float64(100),
 					}, nil)
+				cacheService.EXPECT().
+					Get(gomock.Any(), "balance_account_0x1234567890123456789012345678901234567890", gomock.Any()).
+					SetArg(2, "0.0.1001").
+					Return(nil)
 				mockClient.EXPECT().
-					GetBalance("0x1234567890123456789012345678901234567890", "2023-06-01T00:00:00.000Z").
-					Return("0x96")
+					GetBalance(gomock.Any(), "0.0.1001", "2023-06-01T00:00:00.000Z").
+					Return("0x96", nil)
 			},
 			expectedResult: "0x96",
 		},
@@ -1160,8 +1440,8 @@ func TestGetBalance(t *testing.T) {
 			blockParam: "0x999",
 			setupMock: func() {
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("2457").
-					Return(nil)
+					GetBlockByHashOrNumber(gomock.Any(), "2457").
+					Return(nil, hedera.ErrNotFound)
 			},
 			expectedResult: "0x0",
 		},
@@ -1170,7 +1450,8 @@ func TestGetBalance(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setupMock()
-			result := s.GetBalance(tc.address, tc.blockParam)
+			result, errRpc := s.GetBalance(context.Background(), tc.address, tc.blockParam)
+			assert.Nil(t, errRpc)
 			assert.Equal(t, tc.expectedResult, result)
 		})
 	}
@@ -1192,8 +1473,8 @@ func TestGetBalance_Latest(t *testing.T) {
 
 	// Setup expectations for getting balance with "0" timestamp
 	mockClient.EXPECT().
-		GetBalance("0x123", "0").
-		Return("0x2a")
+		GetBalance(gomock.Any(), "0x123", "0").
+		Return("0x2a", nil)
 
 	s := service.NewEthService(
 		nil,
@@ -1203,9 +1484,11 @@ func TestGetBalance_Latest(t *testing.T) {
 		nil,
 		defaultChainId,
 		cacheService,
+		service.Config{},
 	)
 
-	result := s.GetBalance("0x123", "latest")
+	result, errRpc := s.GetBalance(context.Background(), "0x123", "latest")
+	assert.Nil(t, errRpc)
 	assert.Equal(t, "0x2a", result)
 }
 
@@ -1223,24 +1506,24 @@ func TestGetBalance_Earliest(t *testing.T) {
 
 	// Setup expectations for getting block zero
 	mockClient.EXPECT().
-		GetBlockByHashOrNumber("0").
+		GetBlockByHashOrNumber(gomock.Any(), "0").
 		Return(&domain.BlockResponse{
 			Timestamp: domain.Timestamp{
 				To: "2023-01-01T00:00:00.000Z",
 			},
-		})
+		}, nil)
 
 	// Setup expectations for getting latest block
 	mockClient.EXPECT().
-		GetLatestBlock().
+		GetLatestBlock(gomock.Any()).
 		Return(map[string]interface{}{
 			"number": float64(100),
 		}, nil)
 
 	// Setup expectations for getting balance
 	mockClient.EXPECT().
-		GetBalance("0x123", "2023-01-01T00:00:00.000Z").
-		Return("0x0")
+		GetBalance(gomock.Any(), "0x123", "2023-01-01T00:00:00.000Z").
+		Return("0x0", nil)
 
 	s := service.NewEthService(
 		nil,
@@ -1250,9 +1533,11 @@ func TestGetBalance_Earliest(t *testing.T) {
 		nil,
 		defaultChainId,
 		cacheService,
+		service.Config{},
 	)
 
-	result := s.GetBalance("0x123", "earliest")
+	result, errRpc := s.GetBalance(context.Background(), "0x123", "earliest")
+	assert.Nil(t, errRpc)
 	assert.Equal(t, "0x0", result)
 }
 
@@ -1271,32 +1556,33 @@ func TestGetBalance_SpecificBlock(t *testing.T) {
 	cacheService := mocks.NewMockCacheService(ctrl)
 
 	// Create service
-	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService)
+	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService, service.Config{})
 
 	// Setup expectations for getting specific block
 	mockClient.EXPECT().
-		GetBlockByHashOrNumber("100").
+		GetBlockByHashOrNumber(gomock.Any(), "100").
 		Return(&domain.BlockResponse{
 			Number: 100,
 			Timestamp: domain.Timestamp{
 				To: "1234567890.000000000",
 			},
-		})
+		}, nil)
 
 	// Setup expectations for getting latest block - set it far from block 100
 	mockClient.EXPECT().
-		GetLatestBlock().
+		GetLatestBlock(gomock.Any()).
 		Return(map[string]interface{}{
 			"number": float64(200), // Set this much higher than 100 to ensure we use block timestamp
 		}, nil)
 
 	// Setup expectations for getting balance
 	mockClient.EXPECT().
-		GetBalance("0x123", "1234567890.000000000").
-		Return("0x64")
+		GetBalance(gomock.Any(), "0x123", "1234567890.000000000").
+		Return("0x64", nil)
 
 	// Call the method
-	result := s.GetBalance("0x123", "100")
+	result, errRpc := s.GetBalance(context.Background(), "0x123", "100")
+	assert.Nil(t, errRpc)
 
 	// Assert the result
 	assert.Equal(t, "0x64", result)
@@ -1316,8 +1602,8 @@ func TestGetBalance_BlockNotFound(t *testing.T) {
 
 	// Setup expectations for getting block that doesn't exist
 	mockClient.EXPECT().
-		GetBlockByHashOrNumber("999999").
-		Return(nil)
+		GetBlockByHashOrNumber(gomock.Any(), "999999").
+		Return(nil, hedera.ErrNotFound)
 
 	s := service.NewEthService(
 		nil,
@@ -1327,12 +1613,118 @@ func TestGetBalance_BlockNotFound(t *testing.T) {
 		nil,
 		defaultChainId,
 		cacheService,
+		service.Config{},
 	)
 
-	result := s.GetBalance("0x123", "999999")
+	result, errRpc := s.GetBalance(context.Background(), "0x123", "999999")
+	assert.Nil(t, errRpc)
 	assert.Equal(t, "0x0", result)
 }
 
+func TestGetBalance_ResolvesBalanceAccount(t *testing.T) {
+	contractAddress := "0xa3cf3bd2e2b5b5b2c10a4c4d5e2c5b8a1e5f6a7b"
+
+	testCases := []struct {
+		name      string
+		address   string
+		setupMock func(mockClient *mocks.MockMirrorClient, cacheService *mocks.MockCacheService)
+		accountId string
+	}{
+		{
+			name:    "Contract EVM address",
+			address: contractAddress,
+			setupMock: func(mockClient *mocks.MockMirrorClient, cacheService *mocks.MockCacheService) {
+				cacheService.EXPECT().
+					Get(gomock.Any(), "balance_account_"+contractAddress, gomock.Any()).
+					Return(errors.New("not found"))
+				mockClient.EXPECT().
+					GetContractById(gomock.Any(), contractAddress).
+					Return(&domain.ContractResponse{ContractID: "0.0.2002"}, nil)
+				mockClient.EXPECT().
+					GetAccountById(gomock.Any(), contractAddress).
+					Return(nil, hedera.ErrNotFound).
+					MaxTimes(1)
+				cacheService.EXPECT().
+					Set(gomock.Any(), "balance_account_"+contractAddress, "0.0.2002", gomock.Any()).
+					Return(nil)
+			},
+			accountId: "0.0.2002",
+		},
+		{
+			name:      "Long-zero address",
+			address:   "0x00000000000000000000000000000000000007d2",
+			setupMock: func(*mocks.MockMirrorClient, *mocks.MockCacheService) {},
+			accountId: "0.0.2002",
+		},
+		{
+			name:    "Unknown address",
+			address: contractAddress,
+			setupMock: func(mockClient *mocks.MockMirrorClient, cacheService *mocks.MockCacheService) {
+				cacheService.EXPECT().
+					Get(gomock.Any(), "balance_account_"+contractAddress, gomock.Any()).
+					Return(errors.New("not found"))
+				mockClient.EXPECT().
+					GetContractById(gomock.Any(), contractAddress).
+					Return(nil, hedera.ErrNotFound)
+				mockClient.EXPECT().
+					GetAccountById(gomock.Any(), contractAddress).
+					Return(nil, hedera.ErrNotFound)
+			},
+			accountId: contractAddress,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mocks.NewMockMirrorClient(ctrl)
+			cacheService := mocks.NewMockCacheService(ctrl)
+			s := service.NewEthService(nil, mockClient, nil, zap.NewNop(), nil, defaultChainId, cacheService, service.Config{})
+
+			tc.setupMock(mockClient, cacheService)
+			mockClient.EXPECT().
+				GetBalance(gomock.Any(), tc.accountId, "0").
+				Return("0x2540be400", nil)
+
+			result, errRpc := s.GetBalance(context.Background(), tc.address, "latest")
+			assert.Nil(t, errRpc)
+			assert.Equal(t, "0x2540be400", result)
+		})
+	}
+}
+
+func TestGetTransactionCount_Historical(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	mockCommon := mocks.NewMockCommonService(ctrl)
+	cacheService := mocks.NewMockCacheService(ctrl)
+
+	s := service.NewEthService(nil, mockClient, mockCommon, zap.NewNop(), nil, defaultChainId, cacheService, service.Config{})
+
+	mockCommon.EXPECT().GetBlockNumberByNumberOrTag(gomock.Any(), "0x64").Return(int64(100), nil)
+	mockCommon.EXPECT().GetBlockNumberByNumberOrTag(gomock.Any(), "latest").Return(int64(200), nil)
+	mockClient.EXPECT().
+		GetBlockByHashOrNumber(gomock.Any(), "100").
+		Return(&domain.BlockResponse{Number: 100, Timestamp: domain.Timestamp{To: "1234567890.000000000"}}, nil)
+	mockClient.EXPECT().
+		GetContractResultsBySender(gomock.Any(), "0x123", "1234567890.000000000").
+		Return([]domain.ContractResult{
+			{Result: "SUCCESS", Nonce: 0},
+			{Result: "CONTRACT_REVERT_EXECUTED", Nonce: 1},
+			{Result: "WRONG_NONCE", Nonce: 1},
+			{Result: "SUCCESS", Nonce: 2},
+		}, nil)
+
+	result, errRpc := s.GetTransactionCount(context.Background(), "0x123", "0x64")
+	assert.Nil(t, errRpc)
+
+	assert.Equal(t, "0x3", result)
+}
+
 func TestCall(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1341,7 +1733,7 @@ func TestCall(t *testing.T) {
 	mockClient := mocks.NewMockMirrorClient(ctrl)
 	cacheService := mocks.NewMockCacheService(ctrl)
 
-	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService)
+	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService, service.Config{})
 
 	testCases := []struct {
 		name           string
@@ -1394,12 +1786,12 @@ func TestCall(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			if tc.setupMock {
 				mockClient.EXPECT().
-					PostCall(gomock.Any()).
-					Return(tc.mockResponse).
+					PostCall(gomock.Any(), gomock.Any()).
+					Return(tc.mockResponse, nil).
 					Times(1)
 			}
 
-			result, errRpc := s.Call(tc.transaction, tc.blockParam)
+			result, errRpc := s.Call(context.Background(), tc.transaction, tc.blockParam)
 
 			if tc.expectError {
 				assert.NotNil(t, errRpc)
@@ -1420,7 +1812,7 @@ func TestEstimateGas(t *testing.T) {
 	mockClient := mocks.NewMockMirrorClient(ctrl)
 	cacheService := mocks.NewMockCacheService(ctrl)
 
-	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService)
+	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService, service.Config{})
 
 	testCases := []struct {
 		name           string
@@ -1484,12 +1876,12 @@ func TestEstimateGas(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			if tc.setupMock {
 				mockClient.EXPECT().
-					PostCall(gomock.Any()).
-					Return(tc.mockResponse).
+					PostCall(gomock.Any(), gomock.Any()).
+					Return(tc.mockResponse, nil).
 					Times(1)
 			}
 
-			result, errRpc := s.EstimateGas(tc.transaction, tc.blockParam)
+			result, errRpc := s.EstimateGas(context.Background(), tc.transaction, tc.blockParam)
 
 			if tc.expectError {
 				assert.NotNil(t, errRpc)
@@ -1501,7 +1893,8 @@ func TestEstimateGas(t *testing.T) {
 		})
 	}
 }
-func TestGetTransactionByHash(t *testing.T) {
+
+func TestEstimateGas_Fallback(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -1509,76 +1902,282 @@ func TestGetTransactionByHash(t *testing.T) {
 	mockClient := mocks.NewMockMirrorClient(ctrl)
 	cacheService := mocks.NewMockCacheService(ctrl)
 
-	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService)
+	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService, service.Config{
+		EstimateGasFallback: true,
+		ContractCallGas:     300000,
+	})
 
-	// Common test data
-	testHash := "0x5d019848d6dad96bc3a9e947350975cd16cf1c51efd4d5b9a273803446fbbb43"
-	toAddress := "0x" + strings.Repeat("3", 40)
-	fromAddress := "0x" + strings.Repeat("2", 40)
-	baseContractResult := domain.ContractResultResponse{
-		BlockNumber:        123,
-		BlockHash:          "0x" + strings.Repeat("1", 64),
-		Hash:               testHash,
-		From:               fromAddress,
-		To:                 toAddress,
-		GasUsed:            21000,
-		GasPrice:           "0x5678",
-		TransactionIndex:   1,
-		Amount:             1000000,
-		V:                  27,
-		R:                  "0x" + strings.Repeat("4", 64),
-		S:                  "0x" + strings.Repeat("5", 64),
-		Nonce:              5,
-		FunctionParameters: "0x",
-		ChainID:            defaultChainId,
-		Type:               new(int),
-	}
+	unavailable := &hedera.ContractCallError{StatusCode: 503, Message: "Service Unavailable"}
 
 	testCases := []struct {
 		name           string
-		hash           string
-		mockResult     interface{}
-		expectedResult bool
-		checkFields    func(t *testing.T, result interface{})
+		transaction    interface{}
+		callErr        error
+		expectedResult string
+		expectedCode   int
 	}{
 		{
-			name: "Legacy transaction (type 0)",
-			hash: testHash,
-			mockResult: func() domain.ContractResultResponse {
-				result := baseContractResult
-				typeVal := 0
-				result.Type = &typeVal
-				return result
-			}(),
-			expectedResult: true,
-			checkFields: func(t *testing.T, result interface{}) {
-				tx, ok := result.(domain.Transaction)
-				assert.True(t, ok)
-				assert.Equal(t, "0x0", tx.Type)
-				assert.Equal(t, testHash, tx.Hash)
-				assert.Equal(t, "0x7b", *tx.BlockNumber) // 123 in hex
-				assert.Equal(t, defaultChainId, *tx.ChainId)
-				assert.Equal(t, toAddress, *tx.To)
-				assert.Equal(t, fromAddress, tx.From)
+			name: "Transfer uses intrinsic gas",
+			transaction: map[string]interface{}{
+				"from":  "0xb1d6b01b94d854f521665696ea17fcf87c160d97",
+				"to":    "0x742d35Cc6634C0532925a3b844Bc454e4438f44e",
+				"value": "0x1",
 			},
+			callErr:        unavailable,
+			expectedResult: "0x5208",
 		},
 		{
-			name: "EIP-2930 transaction (type 1)",
-			hash: testHash,
-			mockResult: func() domain.ContractResultResponse {
-				result := baseContractResult
-				typeVal := 1
-				result.Type = &typeVal
-				return result
-			}(),
-			expectedResult: true,
-			checkFields: func(t *testing.T, result interface{}) {
-				tx, ok := result.(domain.Transaction2930)
-				assert.True(t, ok)
-				assert.Equal(t, "0x1", tx.Type)
-				assert.Empty(t, tx.AccessList)
-				assert.Equal(t, testHash, tx.Hash)
-				assert.Equal(t, "0x7b", *tx.BlockNumber) // 123 in hex
+			name: "Contract call uses configured gas",
+			transaction: map[string]interface{}{
+				"to":   "0x742d35Cc6634C0532925a3b844Bc454e4438f44e",
+				"data": "0x70a08231",
+			},
+			callErr:        errors.New("connection refused"),
+			expectedResult: "0x493e0",
+		},
+		{
+			name: "Contract creation uses default gas",
+			transaction: map[string]interface{}{
+				"data": "0x6080604052",
+			},
+			callErr:        unavailable,
+			expectedResult: "0xc3500",
+		},
+		{
+			name: "Revert is still returned",
+			transaction: map[string]interface{}{
+				"to":   "0x742d35Cc6634C0532925a3b844Bc454e4438f44e",
+				"data": "0x70a08231",
+			},
+			callErr:      &hedera.ContractCallError{StatusCode: 400, Message: "CONTRACT_REVERT_EXECUTED", Data: "0x"},
+			expectedCode: domain.ContractRevert,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient.EXPECT().
+				PostCall(gomock.Any(), gomock.Any()).
+				Return(nil, tc.callErr).
+				Times(1)
+
+			result, errRpc := s.EstimateGas(context.Background(), tc.transaction, "latest")
+
+			if tc.expectedCode != 0 {
+				if assert.NotNil(t, errRpc) {
+					assert.Equal(t, tc.expectedCode, errRpc.Code)
+				}
+				return
+			}
+			assert.Nil(t, errRpc)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestEstimateGas_LargeDeployment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	s := service.NewEthService(nil, mockClient, nil, zap.NewNop(), nil, defaultChainId, mocks.NewMockCacheService(ctrl), service.Config{
+		MirrorNodeMaxDataSize: 1000,
+	})
+
+	initCode := func(size int) map[string]interface{} {
+		return map[string]interface{}{"data": "0x" + strings.Repeat("60", size)}
+	}
+
+	// Deployments within the limit are estimated by the mirror node
+	mockClient.EXPECT().PostCall(gomock.Any(), gomock.Any()).Return("0x000000000000000000000000000000000000000000000000000000000001e240", nil).Times(1)
+	result, errRpc := s.EstimateGas(context.Background(), initCode(1000), "latest")
+	assert.Nil(t, errRpc)
+	assert.Equal(t, "0x1e240", result)
+
+	// Larger ones are estimated locally: 800000 + 200 gas per byte
+	result, errRpc = s.EstimateGas(context.Background(), initCode(1001), "latest")
+	assert.Nil(t, errRpc)
+	assert.Equal(t, "0xf4308", result)
+
+	// and capped at the gas limit of a transaction
+	result, errRpc = s.EstimateGas(context.Background(), initCode(100000), "latest")
+	assert.Nil(t, errRpc)
+	assert.Equal(t, "0xe4e1c0", result)
+}
+
+func TestCallAndEstimateGas_GasCap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	s := service.NewEthService(nil, mockClient, nil, zap.NewNop(), nil, defaultChainId, mocks.NewMockCacheService(ctrl), service.Config{
+		BlockGasLimit:         30000000,
+		MaxCallGas:            1000000,
+		MirrorNodeMaxDataSize: 1000,
+	})
+
+	call := func(gas string) map[string]interface{} {
+		return map[string]interface{}{
+			"to":   "0x742d35Cc6634C0532925a3b844Bc454e4438f44e",
+			"data": "0x70a08231",
+			"gas":  gas,
+		}
+	}
+
+	// Calls above the cap are rejected without asking the mirror node
+	_, errRpc := s.Call(context.Background(), call("0xf4241"), "latest")
+	if assert.NotNil(t, errRpc) {
+		assert.Equal(t, domain.ServerError, errRpc.Code)
+		assert.Equal(t, "gas required exceeds allowance (1000000)", errRpc.Message)
+	}
+	_, errRpc = s.EstimateGas(context.Background(), call("0xf4241"), "latest")
+	if assert.NotNil(t, errRpc) {
+		assert.Equal(t, "gas required exceeds allowance (1000000)", errRpc.Message)
+	}
+
+	// as are estimates above it
+	mockClient.EXPECT().PostCall(gomock.Any(), gomock.Any()).Return("0x00000000000000000000000000000000000000000000000000000000000f4241", nil)
+	_, errRpc = s.EstimateGas(context.Background(), call(""), "latest")
+	if assert.NotNil(t, errRpc) {
+		assert.Equal(t, "gas required exceeds allowance (1000000)", errRpc.Message)
+	}
+
+	// while calls at the cap go through
+	mockClient.EXPECT().PostCall(gomock.Any(), gomock.Any()).Return("0x01", nil)
+	result, errRpc := s.Call(context.Background(), call("0xf4240"), "latest")
+	assert.Nil(t, errRpc)
+	assert.Equal(t, "0x01", result)
+
+	// Local deployment estimates are capped at the block gas limit, and then
+	// by the call cap
+	_, errRpc = s.EstimateGas(context.Background(), map[string]interface{}{"data": "0x" + strings.Repeat("60", 100000)}, "latest")
+	if assert.NotNil(t, errRpc) {
+		assert.Equal(t, "gas required exceeds allowance (1000000)", errRpc.Message)
+	}
+}
+
+func TestCreateAccessList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger, _ := zap.NewDevelopment()
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	cacheService := mocks.NewMockCacheService(ctrl)
+
+	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService, service.Config{})
+
+	transaction := map[string]interface{}{
+		"to":   "0x742d35Cc6634C0532925a3b844Bc454e4438f44e",
+		"data": "0x70a08231000000000000000000000000b1d6b01b94d854f521665696ea17fcf87c160d97",
+	}
+
+	t.Run("Returns empty access list with gas used", func(t *testing.T) {
+		mockClient.EXPECT().
+			PostCall(gomock.Any(), gomock.Any()).
+			Return("0x0000000000000000000000000000000000000000000000000000000000005208", nil).
+			Times(1)
+
+		result, errRpc := s.CreateAccessList(context.Background(), transaction, "latest")
+
+		assert.Nil(t, errRpc)
+		assert.Equal(t, domain.AccessListResult{
+			AccessList: []domain.AccessListEntry{},
+			GasUsed:    "0x5208",
+		}, result)
+	})
+
+	t.Run("Mirror node failure", func(t *testing.T) {
+		mockClient.EXPECT().
+			PostCall(gomock.Any(), gomock.Any()).
+			Return(nil, nil).
+			Times(1)
+
+		result, errRpc := s.CreateAccessList(context.Background(), transaction, "latest")
+
+		assert.Nil(t, result)
+		assert.NotNil(t, errRpc)
+		assert.Equal(t, -32000, errRpc.Code)
+	})
+}
+
+func TestGetTransactionByHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger, _ := zap.NewDevelopment()
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	cacheService := mocks.NewMockCacheService(ctrl)
+
+	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService, service.Config{})
+
+	// Common test data
+	testHash := "0x5d019848d6dad96bc3a9e947350975cd16cf1c51efd4d5b9a273803446fbbb43"
+	toAddress := "0x" + strings.Repeat("3", 40)
+	fromAddress := "0x" + strings.Repeat("2", 40)
+	baseContractResult := domain.ContractResult{
+		BlockNumber:        123,
+		BlockHash:          "0x" + strings.Repeat("1", 64),
+		Hash:               testHash,
+		From:               fromAddress,
+		To:                 toAddress,
+		GasUsed:            21000,
+		GasPrice:           "0x5678",
+		TransactionIndex:   1,
+		Amount:             1000000,
+		V:                  27,
+		R:                  "0x" + strings.Repeat("4", 64),
+		S:                  "0x" + strings.Repeat("5", 64),
+		Nonce:              5,
+		FunctionParameters: "0x",
+		ChainID:            defaultChainId,
+		Type:               new(int),
+	}
+
+	testCases := []struct {
+		name           string
+		hash           string
+		mockResult     *domain.ContractResult
+		expectedResult bool
+		checkFields    func(t *testing.T, result interface{})
+	}{
+		{
+			name: "Legacy transaction (type 0)",
+			hash: testHash,
+			mockResult: func() *domain.ContractResult {
+				result := baseContractResult
+				typeVal := 0
+				result.Type = &typeVal
+				return &result
+			}(),
+			expectedResult: true,
+			checkFields: func(t *testing.T, result interface{}) {
+				tx, ok := result.(domain.Transaction)
+				assert.True(t, ok)
+				assert.Equal(t, "0x0", tx.Type)
+				assert.Equal(t, testHash, tx.Hash)
+				assert.Equal(t, "0x7b", *tx.BlockNumber) // 123 in hex
+				assert.Equal(t, defaultChainId, *tx.ChainId)
+				assert.Equal(t, toAddress, *tx.To)
+				assert.Equal(t, fromAddress, tx.From)
+			},
+		},
+		{
+			name: "EIP-2930 transaction (type 1)",
+			hash: testHash,
+			mockResult: func() *domain.ContractResult {
+				result := baseContractResult
+				typeVal := 1
+				result.Type = &typeVal
+				return &result
+			}(),
+			expectedResult: true,
+			checkFields: func(t *testing.T, result interface{}) {
+				tx, ok := result.(domain.Transaction2930)
+				assert.True(t, ok)
+				assert.Equal(t, "0x1", tx.Type)
+				assert.Empty(t, tx.AccessList)
+				assert.Equal(t, testHash, tx.Hash)
+				assert.Equal(t, "0x7b", *tx.BlockNumber) // 123 in hex
 				assert.Equal(t, defaultChainId, *tx.ChainId)
 				assert.Equal(t, toAddress, *tx.To)
 				assert.Equal(t, fromAddress, tx.From)
@@ -1587,13 +2186,13 @@ func TestGetTransactionByHash(t *testing.T) {
 		{
 			name: "EIP-1559 transaction (type 2)",
 			hash: testHash,
-			mockResult: func() domain.ContractResultResponse {
+			mockResult: func() *domain.ContractResult {
 				result := baseContractResult
 				typeVal := 2
 				result.Type = &typeVal
 				result.MaxPriorityFeePerGas = "0x1234"
 				result.MaxFeePerGas = "0x5678"
-				return result
+				return &result
 			}(),
 			expectedResult: true,
 			checkFields: func(t *testing.T, result interface{}) {
@@ -1620,19 +2219,23 @@ func TestGetTransactionByHash(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			// Set up cache expectations for transaction lookup
-			var cachedTx interface{}
+			var cachedTx domain.CachedTransaction
 			cacheService.EXPECT().
 				Get(gomock.Any(), fmt.Sprintf("eth_getTransactionByHash_%s", tc.hash), &cachedTx).
 				Return(errors.New("not found")).
 				Times(1)
 
+			var mockErr error
+			if tc.mockResult == nil {
+				mockErr = hedera.ErrNotFound
+			}
 			mockClient.EXPECT().
-				GetContractResult(tc.hash).
-				Return(tc.mockResult).
+				GetContractResult(gomock.Any(), tc.hash).
+				Return(tc.mockResult, mockErr).
 				Times(1)
 
 			if tc.mockResult != nil {
-				result := tc.mockResult.(domain.ContractResultResponse)
+				result := *tc.mockResult
 
 				// Set up cache expectations for 'to' address resolution
 				var cachedToAddr string
@@ -1661,7 +2264,7 @@ func TestGetTransactionByHash(t *testing.T) {
 					Times(1)
 			}
 
-			result, errMap := s.GetTransactionByHash(tc.hash)
+			result, errMap := s.GetTransactionByHash(context.Background(), tc.hash)
 			assert.Nil(t, errMap)
 			if tc.checkFields != nil {
 				tc.checkFields(t, result)
@@ -1669,6 +2272,141 @@ func TestGetTransactionByHash(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTransactionByHash_CachedTransactionKeepsType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	cacheService := cache.NewMemoryCache(time.Minute, time.Minute)
+	s := service.NewEthService(nil, mockClient, nil, zap.NewNop(), nil, defaultChainId, cacheService, service.Config{})
+
+	fromAddress := "0x" + strings.Repeat("2", 40)
+	toAddress := "0x" + strings.Repeat("3", 40)
+	require.NoError(t, cacheService.Set(context.Background(), "evm_address_"+fromAddress, fromAddress, time.Minute))
+	require.NoError(t, cacheService.Set(context.Background(), "evm_address_"+toAddress, toAddress, time.Minute))
+
+	for _, tc := range []struct {
+		name     string
+		txType   int
+		expected interface{}
+	}{
+		{name: "legacy", txType: 0, expected: domain.Transaction{}},
+		{name: "eip2930", txType: 1, expected: domain.Transaction2930{}},
+		{name: "eip1559", txType: 2, expected: domain.Transaction1559{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			hash := fmt.Sprintf("0x%064x", tc.txType+1)
+			txType := tc.txType
+			mockClient.EXPECT().
+				GetContractResult(gomock.Any(), hash).
+				Return(&domain.ContractResult{
+					BlockNumber:          123,
+					BlockHash:            "0x" + strings.Repeat("1", 64),
+					Hash:                 hash,
+					From:                 fromAddress,
+					To:                   toAddress,
+					GasPrice:             "0x5678",
+					ChainID:              defaultChainId,
+					Type:                 &txType,
+					MaxFeePerGas:         "0x59",
+					MaxPriorityFeePerGas: "0x33",
+				}, nil).
+				Times(1)
+
+			fresh, errRpc := s.GetTransactionByHash(context.Background(), hash)
+			require.Nil(t, errRpc)
+			cached, errRpc := s.GetTransactionByHash(context.Background(), hash)
+			require.Nil(t, errRpc)
+
+			assert.IsType(t, tc.expected, cached)
+			freshJSON, err := json.Marshal(fresh)
+			require.NoError(t, err)
+			cachedJSON, err := json.Marshal(cached)
+			require.NoError(t, err)
+			assert.Equal(t, string(freshJSON), string(cachedJSON))
+		})
+	}
+}
+
+func TestGetTransactionByHash_UntypedCacheEntryIsRefetched(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	cacheService := cache.NewMemoryCache(time.Minute, time.Minute)
+	s := service.NewEthService(nil, mockClient, nil, zap.NewNop(), nil, defaultChainId, cacheService, service.Config{})
+
+	address := "0x" + strings.Repeat("2", 40)
+	hash := "0x" + strings.Repeat("a", 64)
+	require.NoError(t, cacheService.Set(context.Background(), "evm_address_"+address, address, time.Minute))
+	// An entry written before transactions were cached with their type
+	require.NoError(t, cacheService.Set(context.Background(), "eth_getTransactionByHash_"+hash, map[string]interface{}{"hash": hash}, time.Minute))
+
+	mockClient.EXPECT().
+		GetContractResult(gomock.Any(), hash).
+		Return(&domain.ContractResult{BlockHash: "0x" + strings.Repeat("1", 64), Hash: hash, From: address, To: address, ChainID: defaultChainId}, nil).
+		Times(1)
+
+	result, errRpc := s.GetTransactionByHash(context.Background(), hash)
+	require.Nil(t, errRpc)
+	assert.IsType(t, domain.Transaction{}, result)
+}
+
+func TestGetTransactionReceipt_BlockLogIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	cacheService := cache.NewMemoryCache(time.Minute, time.Minute)
+	s := service.NewEthService(nil, mockClient, nil, zap.NewNop(), nil, defaultChainId, cacheService, service.Config{})
+
+	hash := "0x" + strings.Repeat("a", 64)
+	blockHash := "0x" + strings.Repeat("1", 64)
+	address := "0x" + strings.Repeat("2", 40)
+	require.NoError(t, cacheService.Set(context.Background(), "evm_address_"+address, address, time.Minute))
+
+	mockClient.EXPECT().
+		GetContractResult(gomock.Any(), hash).
+		Return(&domain.ContractResult{
+			BlockHash:        blockHash,
+			BlockNumber:      42,
+			Hash:             hash,
+			From:             address,
+			To:               address,
+			TransactionIndex: 2,
+			Logs:             []domain.MirroNodeLogs{{Address: address, Index: 0}, {Address: address, Index: 1}},
+		}, nil)
+	mockClient.EXPECT().
+		GetBlockByHashOrNumber(gomock.Any(), blockHash).
+		Return(&domain.BlockResponse{Hash: blockHash, Number: 42}, nil)
+	mockClient.EXPECT().
+		GetNetworkFees(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(int64(71), nil)
+
+	// The two earlier transactions of the block emitted three logs
+	mockClient.EXPECT().
+		GetBlockByHashOrNumber(gomock.Any(), "42").
+		Return(&domain.BlockResponse{Number: 42, Timestamp: domain.Timestamp{From: "1672531200", To: "1672531201"}}, nil)
+	mockClient.EXPECT().
+		GetContractResultsLogsWithRetry(gomock.Any(), gomock.Any()).
+		Return([]domain.LogEntry{
+			{BlockNumber: ptr(int64(42)), TransactionIndex: ptr(0), Index: ptr(0)},
+			{BlockNumber: ptr(int64(42)), TransactionIndex: ptr(1), Index: ptr(0)},
+			{BlockNumber: ptr(int64(42)), TransactionIndex: ptr(1), Index: ptr(1)},
+			{BlockNumber: ptr(int64(42)), TransactionIndex: ptr(2), Index: ptr(0)},
+			{BlockNumber: ptr(int64(42)), TransactionIndex: ptr(2), Index: ptr(1)},
+		}, nil)
+
+	result, errRpc := s.GetTransactionReceipt(context.Background(), hash)
+
+	require.Nil(t, errRpc)
+	receipt := result.(domain.TransactionReceipt)
+	require.Len(t, receipt.Logs, 2)
+	assert.Equal(t, "0x3", receipt.Logs[0].LogIndex)
+	assert.Equal(t, "0x4", receipt.Logs[1].LogIndex)
+}
+
 func TestGetTransactionReceipt(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1677,7 +2415,7 @@ func TestGetTransactionReceipt(t *testing.T) {
 	mockClient := mocks.NewMockMirrorClient(ctrl)
 	cacheService := mocks.NewMockCacheService(ctrl)
 
-	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService)
+	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService, service.Config{})
 
 	txHash := "0x123"
 	blockHash := "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
@@ -1685,7 +2423,7 @@ func TestGetTransactionReceipt(t *testing.T) {
 	testCases := []struct {
 		name        string
 		hash        string
-		mockResult  domain.ContractResultResponse
+		mockResult  domain.ContractResult
 		mockBlock   *domain.BlockResponse
 		mockFee     int64
 		expectError bool
@@ -1693,7 +2431,7 @@ func TestGetTransactionReceipt(t *testing.T) {
 		{
 			name: "successful_transaction_receipt",
 			hash: txHash,
-			mockResult: domain.ContractResultResponse{
+			mockResult: domain.ContractResult{
 				BlockHash:          blockHash,
 				BlockNumber:        123,
 				BlockGasUsed:       150000,
@@ -1722,7 +2460,7 @@ func TestGetTransactionReceipt(t *testing.T) {
 		{
 			name:        "transaction_not_found",
 			hash:        "0xnonexistent",
-			mockResult:  domain.ContractResultResponse{},
+			mockResult:  domain.ContractResult{},
 			mockBlock:   nil,
 			expectError: false,
 		},
@@ -1739,13 +2477,13 @@ func TestGetTransactionReceipt(t *testing.T) {
 			// Set up mock expectations for GetContractResult
 			if tc.hash == "0xnonexistent" {
 				mockClient.EXPECT().
-					GetContractResult(tc.hash).
-					Return(nil).
+					GetContractResult(gomock.Any(), tc.hash).
+					Return(nil, hedera.ErrNotFound).
 					Times(1)
 			} else {
 				mockClient.EXPECT().
-					GetContractResult(tc.hash).
-					Return(tc.mockResult).
+					GetContractResult(gomock.Any(), tc.hash).
+					Return(&tc.mockResult, nil).
 					Times(1)
 
 				// Mock address resolution for 'from' address
@@ -1756,14 +2494,14 @@ func TestGetTransactionReceipt(t *testing.T) {
 
 				// Mock concurrent address resolution calls for 'from' address
 				mockClient.EXPECT().
-					GetContractById(tc.mockResult.From).
+					GetContractById(gomock.Any(), tc.mockResult.From).
 					Return(&domain.ContractResponse{
 						EvmAddress: tc.mockResult.From,
 					}, nil).
 					AnyTimes()
 
 				mockClient.EXPECT().
-					GetAccountById(tc.mockResult.From).
+					GetAccountById(gomock.Any(), tc.mockResult.From).
 					Return(&domain.AccountResponse{
 						EvmAddress: tc.mockResult.From,
 					}, nil).
@@ -1772,7 +2510,7 @@ func TestGetTransactionReceipt(t *testing.T) {
 				// Mock token check for 'from' address
 				if strings.HasPrefix(tc.mockResult.From, "0x000000000000") {
 					mockClient.EXPECT().
-						GetTokenById(gomock.Any()).
+						GetTokenById(gomock.Any(), gomock.Any()).
 						Return(&domain.TokenResponse{}, nil).
 						AnyTimes()
 				}
@@ -1790,14 +2528,14 @@ func TestGetTransactionReceipt(t *testing.T) {
 
 				// Mock concurrent address resolution calls for 'to' address
 				mockClient.EXPECT().
-					GetContractById(tc.mockResult.To).
+					GetContractById(gomock.Any(), tc.mockResult.To).
 					Return(&domain.ContractResponse{
 						EvmAddress: tc.mockResult.To,
 					}, nil).
 					AnyTimes()
 
 				mockClient.EXPECT().
-					GetAccountById(tc.mockResult.To).
+					GetAccountById(gomock.Any(), tc.mockResult.To).
 					Return(&domain.AccountResponse{
 						EvmAddress: tc.mockResult.To,
 					}, nil).
@@ -1806,7 +2544,7 @@ func TestGetTransactionReceipt(t *testing.T) {
 				// Mock token check for 'to' address
 				if strings.HasPrefix(tc.mockResult.To, "0x000000000000") {
 					mockClient.EXPECT().
-						GetTokenById(gomock.Any()).
+						GetTokenById(gomock.Any(), gomock.Any()).
 						Return(&domain.TokenResponse{}, nil).
 						AnyTimes()
 				}
@@ -1818,13 +2556,13 @@ func TestGetTransactionReceipt(t *testing.T) {
 
 				// Mock GetBlockByHashOrNumber for gas price
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber(tc.mockResult.BlockHash[:66]).
-					Return(tc.mockBlock).
+					GetBlockByHashOrNumber(gomock.Any(), tc.mockResult.BlockHash[:66]).
+					Return(tc.mockBlock, nil).
 					Times(1)
 
 				// Mock GetNetworkFees
 				mockClient.EXPECT().
-					GetNetworkFees(tc.mockBlock.Timestamp.From, "").
+					GetNetworkFees(gomock.Any(), tc.mockBlock.Timestamp.From, "").
 					Return(tc.mockFee, nil).
 					Times(1)
 
@@ -1835,7 +2573,7 @@ func TestGetTransactionReceipt(t *testing.T) {
 					Times(1)
 			}
 
-			result, errMap := s.GetTransactionReceipt(tc.hash)
+			result, errMap := s.GetTransactionReceipt(context.Background(), tc.hash)
 			if tc.expectError {
 				assert.NotNil(t, errMap)
 			} else {
@@ -1848,7 +2586,10 @@ func TestGetTransactionReceipt(t *testing.T) {
 					assert.Equal(t, tc.mockResult.BlockHash[:66], receipt.BlockHash)
 					assert.Equal(t, "0x7b", receipt.BlockNumber) // 123 in hex
 					assert.Equal(t, tc.mockResult.From, receipt.From)
-					assert.Equal(t, tc.mockResult.To, receipt.To)
+					if assert.NotNil(t, receipt.To) {
+						assert.Equal(t, tc.mockResult.To, *receipt.To)
+					}
+					assert.Nil(t, receipt.ContractAddress)
 					assert.Equal(t, "0x249f0", receipt.CumulativeGasUsed) // 150000 in hex
 					assert.Equal(t, "0x186a0", receipt.GasUsed)           // 100000 in hex
 					assert.Equal(t, "0x1", receipt.Status)
@@ -1898,7 +2639,7 @@ func TestFeeHistory(t *testing.T) {
 
 				// Mock GetBlockNumber
 				commonService.EXPECT().
-					GetBlockNumber().
+					GetBlockNumber(gomock.Any()).
 					Return(interface{}("0x64"), nil).
 					Times(1)
 
@@ -1910,7 +2651,7 @@ func TestFeeHistory(t *testing.T) {
 
 				// Mock GetBlockNumberByNumberOrTag
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("latest").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "latest").
 					Return(int64(100), nil).
 					Times(1)
 
@@ -1921,7 +2662,7 @@ func TestFeeHistory(t *testing.T) {
 					Times(1)
 
 				mockClient.EXPECT().
-					GetNetworkFees("", "").
+					GetNetworkFees(gomock.Any(), "", "").
 					Return(int64(10000000000), nil).
 					Times(1)
 
@@ -1958,7 +2699,7 @@ func TestFeeHistory(t *testing.T) {
 
 				// Mock GetBlockNumber from commonService
 				commonService.EXPECT().
-					GetBlockNumber().
+					GetBlockNumber(gomock.Any()).
 					Return("0x64", nil). // 100 in hex
 					Times(1)
 
@@ -1970,7 +2711,7 @@ func TestFeeHistory(t *testing.T) {
 
 				// Mock GetBlockNumberByNumberOrTag
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("latest").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "latest").
 					Return(int64(100), nil).
 					Times(1)
 
@@ -2008,7 +2749,7 @@ func TestFeeHistory(t *testing.T) {
 
 				// Mock GetBlockNumber from commonService
 				commonService.EXPECT().
-					GetBlockNumber().
+					GetBlockNumber(gomock.Any()).
 					Return("0x64", nil). // 100 in hex
 					Times(1)
 
@@ -2020,7 +2761,7 @@ func TestFeeHistory(t *testing.T) {
 
 				// Mock GetBlockNumberByNumberOrTag
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("latest").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "latest").
 					Return(int64(100), nil).
 					Times(1)
 			},
@@ -2044,7 +2785,7 @@ func TestFeeHistory(t *testing.T) {
 
 				// Mock GetBlockNumber from commonService
 				commonService.EXPECT().
-					GetBlockNumber().
+					GetBlockNumber(gomock.Any()).
 					Return("0x64", nil). // 100 in hex
 					Times(1)
 
@@ -2056,7 +2797,7 @@ func TestFeeHistory(t *testing.T) {
 
 				// Mock GetBlockNumberByNumberOrTag to fail with proper RPCError
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("0xinvalid").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "0xinvalid").
 					Return(int64(0), domain.NewRPCError(domain.ServerError, "Invalid block number")).
 					Times(1)
 			},
@@ -2077,7 +2818,7 @@ func TestFeeHistory(t *testing.T) {
 
 				// Mock GetBlockNumber from commonService
 				commonService.EXPECT().
-					GetBlockNumber().
+					GetBlockNumber(gomock.Any()).
 					Return(nil, nil). // 100 in hex
 					Times(1)
 
@@ -2092,11 +2833,11 @@ func TestFeeHistory(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			s := service.NewEthService(nil, mockClient, commonService, logger, nil, "0x12a", cacheService)
+			s := service.NewEthService(nil, mockClient, commonService, logger, nil, "0x12a", cacheService, service.Config{})
 
 			tc.setupMocks()
 
-			result, errMap := s.FeeHistory(tc.blockCount, tc.newestBlock, tc.rewardPercentiles)
+			result, errMap := s.FeeHistory(context.Background(), tc.blockCount, tc.newestBlock, tc.rewardPercentiles)
 
 			if tc.expectError {
 				assert.NotNil(t, errMap)
@@ -2121,7 +2862,7 @@ func TestGetStorageAt(t *testing.T) {
 	cacheService := mocks.NewMockCacheService(ctrl)
 	commonService := mocks.NewMockCommonService(ctrl)
 
-	s := service.NewEthService(nil, mockClient, commonService, logger, nil, defaultChainId, cacheService)
+	s := service.NewEthService(nil, mockClient, commonService, logger, nil, defaultChainId, cacheService, service.Config{})
 
 	testCases := []struct {
 		name           string
@@ -2132,6 +2873,7 @@ func TestGetStorageAt(t *testing.T) {
 		mockState      *domain.ContractStateResponse
 		expectedResult interface{}
 		expectError    bool
+		expectedCode   int
 		setupMock      func()
 	}{
 		{
@@ -2155,19 +2897,19 @@ func TestGetStorageAt(t *testing.T) {
 			expectError:    false,
 			setupMock: func() {
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("latest").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "latest").
 					Return(int64(100), nil)
 
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("100").
+					GetBlockByHashOrNumber(gomock.Any(), "100").
 					Return(&domain.BlockResponse{
 						Timestamp: domain.Timestamp{
 							To: "2023-12-09T12:00:00.000Z",
 						},
-					})
+					}, nil)
 
 				mockClient.EXPECT().
-					GetContractStateByAddressAndSlot(
+					GetContractStateByAddressAndSlot(gomock.Any(),
 						"0x742d35Cc6634C0532925a3b844Bc454e4438f44e",
 						"0x0",
 						"2023-12-09T12:00:00.000Z",
@@ -2202,19 +2944,19 @@ func TestGetStorageAt(t *testing.T) {
 			expectError:    false,
 			setupMock: func() {
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("earliest").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "earliest").
 					Return(int64(0), nil)
 
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("0").
+					GetBlockByHashOrNumber(gomock.Any(), "0").
 					Return(&domain.BlockResponse{
 						Timestamp: domain.Timestamp{
 							To: "2023-01-01T00:00:00.000Z",
 						},
-					})
+					}, nil)
 
 				mockClient.EXPECT().
-					GetContractStateByAddressAndSlot(
+					GetContractStateByAddressAndSlot(gomock.Any(),
 						"0x742d35Cc6634C0532925a3b844Bc454e4438f44e",
 						"0x1",
 						"2023-01-01T00:00:00.000Z",
@@ -2249,19 +2991,19 @@ func TestGetStorageAt(t *testing.T) {
 			expectError:    false,
 			setupMock: func() {
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("0x50").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "0x50").
 					Return(int64(80), nil)
 
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("80").
+					GetBlockByHashOrNumber(gomock.Any(), "80").
 					Return(&domain.BlockResponse{
 						Timestamp: domain.Timestamp{
 							To: "2023-06-01T00:00:00.000Z",
 						},
-					})
+					}, nil)
 
 				mockClient.EXPECT().
-					GetContractStateByAddressAndSlot(
+					GetContractStateByAddressAndSlot(gomock.Any(),
 						"0x742d35Cc6634C0532925a3b844Bc454e4438f44e",
 						"0x2",
 						"2023-06-01T00:00:00.000Z",
@@ -2276,21 +3018,22 @@ func TestGetStorageAt(t *testing.T) {
 			},
 		},
 		{
-			name:        "Block not found",
-			address:     "0x742d35Cc6634C0532925a3b844Bc454e4438f44e",
-			slot:        "0x0",
-			blockParam:  "0x999",
-			mockBlock:   nil,
-			mockState:   nil,
-			expectError: true,
+			name:         "Block not found",
+			address:      "0x742d35Cc6634C0532925a3b844Bc454e4438f44e",
+			slot:         "0x0",
+			blockParam:   "0x999",
+			mockBlock:    nil,
+			mockState:    nil,
+			expectError:  true,
+			expectedCode: domain.ResourceNotFound,
 			setupMock: func() {
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("0x999").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "0x999").
 					Return(int64(2457), nil)
 
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("2457").
-					Return(nil)
+					GetBlockByHashOrNumber(gomock.Any(), "2457").
+					Return(nil, hedera.ErrNotFound)
 			},
 		},
 		{
@@ -2308,19 +3051,19 @@ func TestGetStorageAt(t *testing.T) {
 			expectError:    false,
 			setupMock: func() {
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("latest").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "latest").
 					Return(int64(100), nil)
 
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("100").
+					GetBlockByHashOrNumber(gomock.Any(), "100").
 					Return(&domain.BlockResponse{
 						Timestamp: domain.Timestamp{
 							To: "2023-12-09T12:00:00.000Z",
 						},
-					})
+					}, nil)
 
 				mockClient.EXPECT().
-					GetContractStateByAddressAndSlot(
+					GetContractStateByAddressAndSlot(gomock.Any(),
 						"0x742d35Cc6634C0532925a3b844Bc454e4438f44e",
 						"0x3",
 						"2023-12-09T12:00:00.000Z",
@@ -2338,23 +3081,24 @@ func TestGetStorageAt(t *testing.T) {
 					To: "2023-12-09T12:00:00.000Z",
 				},
 			},
-			mockState:   nil,
-			expectError: true,
+			mockState:    nil,
+			expectError:  true,
+			expectedCode: domain.ServerError,
 			setupMock: func() {
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("latest").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "latest").
 					Return(int64(100), nil)
 
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("100").
+					GetBlockByHashOrNumber(gomock.Any(), "100").
 					Return(&domain.BlockResponse{
 						Timestamp: domain.Timestamp{
 							To: "2023-12-09T12:00:00.000Z",
 						},
-					})
+					}, nil)
 
 				mockClient.EXPECT().
-					GetContractStateByAddressAndSlot(
+					GetContractStateByAddressAndSlot(gomock.Any(),
 						"0x742d35Cc6634C0532925a3b844Bc454e4438f44e",
 						"0x4",
 						"2023-12-09T12:00:00.000Z",
@@ -2368,11 +3112,11 @@ func TestGetStorageAt(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setupMock()
 
-			result, errRpc := s.GetStorageAt(tc.address, tc.slot, tc.blockParam)
+			result, errRpc := s.GetStorageAt(context.Background(), tc.address, tc.slot, tc.blockParam)
 
 			if tc.expectError {
 				assert.NotNil(t, errRpc)
-				assert.Equal(t, -32000, errRpc.Code)
+				assert.Equal(t, tc.expectedCode, errRpc.Code)
 			} else {
 				assert.Nil(t, errRpc)
 				assert.Equal(t, tc.expectedResult, result)
@@ -2390,7 +3134,7 @@ func TestGetLogs(t *testing.T) {
 	cacheService := mocks.NewMockCacheService(ctrl)
 	commonService := mocks.NewMockCommonService(ctrl)
 
-	s := service.NewEthService(nil, mockClient, commonService, logger, nil, defaultChainId, cacheService)
+	s := service.NewEthService(nil, mockClient, commonService, logger, nil, defaultChainId, cacheService, service.Config{})
 
 	testCases := []struct {
 		name           string
@@ -2405,14 +3149,14 @@ func TestGetLogs(t *testing.T) {
 			logParams: domain.LogParams{
 				BlockHash: "0x123abc",
 				Address:   []string{"0x742d35Cc6634C0532925a3b844Bc454e4438f44e"},
-				Topics:    []string{"0xtopic1", "0xtopic2"},
+				Topics:    domain.TopicFilter{{"0xtopic1"}, {"0xtopic2"}},
 			},
 			setupMocks: func() {
 				commonService.EXPECT().
-					GetLogs(domain.LogParams{
+					GetLogs(gomock.Any(), domain.LogParams{
 						BlockHash: "0x123abc",
 						Address:   []string{"0x742d35Cc6634C0532925a3b844Bc454e4438f44e"},
-						Topics:    []string{"0xtopic1", "0xtopic2"},
+						Topics:    domain.TopicFilter{{"0xtopic1"}, {"0xtopic2"}},
 					}).
 					Return([]domain.Log{
 						{
@@ -2451,7 +3195,7 @@ func TestGetLogs(t *testing.T) {
 			},
 			setupMocks: func() {
 				commonService.EXPECT().
-					GetLogs(domain.LogParams{
+					GetLogs(gomock.Any(), domain.LogParams{
 						BlockHash: "0xinvalid",
 					}).
 					Return([]domain.Log{}, nil)
@@ -2468,7 +3212,7 @@ func TestGetLogs(t *testing.T) {
 			},
 			setupMocks: func() {
 				commonService.EXPECT().
-					GetLogs(domain.LogParams{
+					GetLogs(gomock.Any(), domain.LogParams{
 						FromBlock: "0x1",
 						ToBlock:   "latest",
 					}).
@@ -2486,7 +3230,7 @@ func TestGetLogs(t *testing.T) {
 			},
 			setupMocks: func() {
 				commonService.EXPECT().
-					GetLogs(domain.LogParams{
+					GetLogs(gomock.Any(), domain.LogParams{
 						FromBlock: "0x10",
 						ToBlock:   "0x5",
 					}).
@@ -2502,7 +3246,7 @@ func TestGetLogs(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setupMocks()
 
-			result, errRpc := s.GetLogs(tc.logParams)
+			result, errRpc := s.GetLogs(context.Background(), tc.logParams)
 
 			if tc.expectError {
 				assert.NotNil(t, errRpc)
@@ -2526,7 +3270,7 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 	cacheService := mocks.NewMockCacheService(ctrl)
 	commonService := mocks.NewMockCommonService(ctrl)
 
-	s := service.NewEthService(nil, mockClient, commonService, logger, nil, defaultChainId, cacheService)
+	s := service.NewEthService(nil, mockClient, commonService, logger, nil, defaultChainId, cacheService, service.Config{})
 
 	testCases := []struct {
 		name            string
@@ -2548,7 +3292,7 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 			setupMock: func() {
 				// Mock GetBlockNumberByNumberOrTag
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("0x7b").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "0x7b").
 					Return(int64(123), nil)
 
 				// Mock cache get attempt
@@ -2558,8 +3302,8 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 
 				// Mock getting block data
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("123").
-					Return(&domain.BlockResponse{Count: 5})
+					GetBlockByHashOrNumber(gomock.Any(), "123").
+					Return(&domain.BlockResponse{Count: 5}, nil)
 
 				// Mock cache set with the result
 				cacheService.EXPECT().
@@ -2581,7 +3325,7 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 			setupMock: func() {
 				// Mock GetBlockNumberByNumberOrTag
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("latest").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "latest").
 					Return(int64(100), nil)
 
 				// Mock cache get attempt
@@ -2590,8 +3334,8 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 					Return(fmt.Errorf("not found"))
 
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("100").
-					Return(&domain.BlockResponse{Count: 10})
+					GetBlockByHashOrNumber(gomock.Any(), "100").
+					Return(&domain.BlockResponse{Count: 10}, nil)
 
 				// Mock cache set with the result
 				cacheService.EXPECT().
@@ -2610,7 +3354,7 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 			setupMock: func() {
 				// Mock GetBlockNumberByNumberOrTag
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("earliest").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "earliest").
 					Return(int64(0), nil)
 
 				// Mock cache get attempt
@@ -2619,8 +3363,8 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 					Return(fmt.Errorf("not found"))
 
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("0").
-					Return(&domain.BlockResponse{Count: 1})
+					GetBlockByHashOrNumber(gomock.Any(), "0").
+					Return(&domain.BlockResponse{Count: 1}, nil)
 
 				// Mock cache set with the result
 				cacheService.EXPECT().
@@ -2637,7 +3381,7 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 			setupMock: func() {
 				// Mock GetBlockNumberByNumberOrTag
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("0x999").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "0x999").
 					Return(int64(2457), nil)
 
 				// Mock cache get attempt
@@ -2646,8 +3390,8 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 					Return(fmt.Errorf("not found"))
 
 				mockClient.EXPECT().
-					GetBlockByHashOrNumber("2457"). // 0x999 in decimal
-					Return(nil)
+					GetBlockByHashOrNumber(gomock.Any(), "2457"). // 0x999 in decimal
+					Return(nil, hedera.ErrNotFound)
 			},
 		},
 		{
@@ -2658,7 +3402,7 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 			setupMock: func() {
 				// Mock GetBlockNumberByNumberOrTag to return error
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("0xinvalid").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "0xinvalid").
 					Return(int64(0), domain.NewRPCError(domain.ServerError, "Invalid block number"))
 			},
 		},
@@ -2668,7 +3412,7 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setupMock()
 
-			result, errMap := s.GetBlockTransactionCountByNumber(tc.blockParam)
+			result, errMap := s.GetBlockTransactionCountByNumber(context.Background(), tc.blockParam)
 
 			assert.Equal(t, tc.expectedResult, result)
 			assert.Equal(t, tc.expectedError, errMap)
@@ -2683,9 +3427,9 @@ func TestGetTransactionByBlockHashAndIndex(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
 	mockClient := mocks.NewMockMirrorClient(ctrl)
 	cacheService := mocks.NewMockCacheService(ctrl)
-	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService)
+	s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService, service.Config{})
 
-	baseContractResult := domain.ContractResults{
+	baseContractResult := domain.ContractResult{
 		BlockNumber:      123,
 		BlockHash:        "0x" + strings.Repeat("1", 64),
 		Hash:             "0x" + strings.Repeat("a", 64),
@@ -2699,7 +3443,7 @@ func TestGetTransactionByBlockHashAndIndex(t *testing.T) {
 		R:                "0x" + strings.Repeat("4", 64),
 		S:                "0x" + strings.Repeat("5", 64),
 		Nonce:            5,
-		Type:             0,
+		Type:             intPtr(0),
 		ChainID:          "0x1",
 	}
 
@@ -2709,7 +3453,7 @@ func TestGetTransactionByBlockHashAndIndex(t *testing.T) {
 		name           string
 		blockHash      string
 		index          string
-		mockResult     *domain.ContractResults
+		mockResult     *domain.ContractResult
 		expectedResult interface{}
 		expectedError  *domain.RPCError
 		setupMocks     func()
@@ -2728,7 +3472,7 @@ func TestGetTransactionByBlockHashAndIndex(t *testing.T) {
 
 				// Mock getting contract result
 				mockClient.EXPECT().
-					GetContractResultWithRetry(gomock.Any()).
+					GetContractResultWithRetry(gomock.Any(), gomock.Any()).
 					Return(&baseContractResult, nil)
 
 				// Mock cache service for from address
@@ -2799,7 +3543,7 @@ func TestGetTransactionByBlockHashAndIndex(t *testing.T) {
 
 				// Mock getting contract result
 				mockClient.EXPECT().
-					GetContractResultWithRetry(gomock.Any()).
+					GetContractResultWithRetry(gomock.Any(), gomock.Any()).
 					Return(nil, nil)
 
 				// Mock cache set for nil result
@@ -2817,7 +3561,7 @@ func TestGetTransactionByBlockHashAndIndex(t *testing.T) {
 				tc.setupMocks()
 			}
 
-			result, errMap := s.GetTransactionByBlockHashAndIndex(tc.blockHash, tc.index)
+			result, errMap := s.GetTransactionByBlockHashAndIndex(context.Background(), tc.blockHash, tc.index)
 
 			if tc.expectedError != nil {
 				assert.Equal(t, tc.expectedError, errMap)
@@ -2841,9 +3585,9 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 	mockClient := mocks.NewMockMirrorClient(ctrl)
 	cacheService := mocks.NewMockCacheService(ctrl)
 	commonService := mocks.NewMockCommonService(ctrl)
-	s := service.NewEthService(nil, mockClient, commonService, logger, nil, defaultChainId, cacheService)
+	s := service.NewEthService(nil, mockClient, commonService, logger, nil, defaultChainId, cacheService, service.Config{})
 
-	baseContractResult := domain.ContractResults{
+	baseContractResult := domain.ContractResult{
 		BlockNumber:      123,
 		BlockHash:        "0x" + strings.Repeat("1", 64),
 		Hash:             "0x" + strings.Repeat("a", 64),
@@ -2857,7 +3601,7 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 		R:                "0x" + strings.Repeat("4", 64),
 		S:                "0x" + strings.Repeat("5", 64),
 		Nonce:            5,
-		Type:             0,
+		Type:             intPtr(0),
 		ChainID:          "0x1",
 	}
 
@@ -2865,7 +3609,7 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 		name           string
 		blockNumber    string
 		index          string
-		mockResult     *domain.ContractResults
+		mockResult     *domain.ContractResult
 		expectedResult interface{}
 		expectedError  *domain.RPCError
 		setupMocks     func()
@@ -2878,7 +3622,7 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 			setupMocks: func() {
 				// Mock GetBlockNumberByNumberOrTag for latest block
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("latest").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "latest").
 					Return(int64(123), nil)
 
 				// Mock cache miss for transaction
@@ -2889,7 +3633,7 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 
 				// Mock getting contract result
 				mockClient.EXPECT().
-					GetContractResultWithRetry(gomock.Any()).
+					GetContractResultWithRetry(gomock.Any(), gomock.Any()).
 					Return(&baseContractResult, nil)
 
 				// Mock cache service for from address
@@ -2900,14 +3644,14 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 
 				// Mock concurrent resolution calls for from address
 				mockClient.EXPECT().
-					GetContractById(baseContractResult.From).
+					GetContractById(gomock.Any(), baseContractResult.From).
 					Return(&domain.ContractResponse{
 						EvmAddress: baseContractResult.From,
 					}, nil).
 					AnyTimes()
 
 				mockClient.EXPECT().
-					GetAccountById(baseContractResult.From).
+					GetAccountById(gomock.Any(), baseContractResult.From).
 					Return(&domain.AccountResponse{
 						EvmAddress: baseContractResult.From,
 					}, nil).
@@ -2926,14 +3670,14 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 
 				// Mock concurrent resolution calls for to address
 				mockClient.EXPECT().
-					GetContractById(baseContractResult.To).
+					GetContractById(gomock.Any(), baseContractResult.To).
 					Return(&domain.ContractResponse{
 						EvmAddress: baseContractResult.To,
 					}, nil).
 					AnyTimes()
 
 				mockClient.EXPECT().
-					GetAccountById(baseContractResult.To).
+					GetAccountById(gomock.Any(), baseContractResult.To).
 					Return(&domain.AccountResponse{
 						EvmAddress: baseContractResult.To,
 					}, nil).
@@ -2975,7 +3719,7 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 			setupMocks: func() {
 				// Mock GetBlockNumberByNumberOrTag for hex block
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("0x7b").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "0x7b").
 					Return(int64(123), nil)
 
 				// Mock cache miss for transaction
@@ -2986,7 +3730,7 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 
 				// Mock getting contract result
 				mockClient.EXPECT().
-					GetContractResultWithRetry(gomock.Any()).
+					GetContractResultWithRetry(gomock.Any(), gomock.Any()).
 					Return(&baseContractResult, nil)
 
 				// Mock cache service for from address
@@ -2997,14 +3741,14 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 
 				// Mock concurrent resolution calls for from address
 				mockClient.EXPECT().
-					GetContractById(baseContractResult.From).
+					GetContractById(gomock.Any(), baseContractResult.From).
 					Return(&domain.ContractResponse{
 						EvmAddress: baseContractResult.From,
 					}, nil).
 					AnyTimes()
 
 				mockClient.EXPECT().
-					GetAccountById(baseContractResult.From).
+					GetAccountById(gomock.Any(), baseContractResult.From).
 					Return(&domain.AccountResponse{
 						EvmAddress: baseContractResult.From,
 					}, nil).
@@ -3023,14 +3767,14 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 
 				// Mock concurrent resolution calls for to address
 				mockClient.EXPECT().
-					GetContractById(baseContractResult.To).
+					GetContractById(gomock.Any(), baseContractResult.To).
 					Return(&domain.ContractResponse{
 						EvmAddress: baseContractResult.To,
 					}, nil).
 					AnyTimes()
 
 				mockClient.EXPECT().
-					GetAccountById(baseContractResult.To).
+					GetAccountById(gomock.Any(), baseContractResult.To).
 					Return(&domain.AccountResponse{
 						EvmAddress: baseContractResult.To,
 					}, nil).
@@ -3072,7 +3816,7 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 			setupMocks: func() {
 				// Mock GetBlockNumberByNumberOrTag to return error for invalid block
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("invalid").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "invalid").
 					Return(int64(0), domain.NewRPCError(domain.ServerError, "Invalid block number"))
 			},
 			expectedError: domain.NewRPCError(domain.ServerError, "Invalid block number"),
@@ -3084,7 +3828,7 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 			setupMocks: func() {
 				// Mock GetBlockNumberByNumberOrTag for hex block
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("0x7b").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "0x7b").
 					Return(int64(123), nil)
 
 				// Mock cache miss for transaction
@@ -3102,7 +3846,7 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 			setupMocks: func() {
 				// Mock GetBlockNumberByNumberOrTag for hex block
 				commonService.EXPECT().
-					GetBlockNumberByNumberOrTag("0x7b").
+					GetBlockNumberByNumberOrTag(gomock.Any(), "0x7b").
 					Return(int64(123), nil)
 
 				// Mock cache miss for transaction
@@ -3113,7 +3857,7 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 
 				// Mock getting contract result
 				mockClient.EXPECT().
-					GetContractResultWithRetry(gomock.Any()).
+					GetContractResultWithRetry(gomock.Any(), gomock.Any()).
 					Return(nil, nil)
 
 				// Mock cache set for nil result
@@ -3131,7 +3875,7 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 				tc.setupMocks()
 			}
 
-			result, errRpc := s.GetTransactionByBlockNumberAndIndex(tc.blockNumber, tc.index)
+			result, errRpc := s.GetTransactionByBlockNumberAndIndex(context.Background(), tc.blockNumber, tc.index)
 
 			if tc.expectedError != nil {
 				assert.Equal(t, tc.expectedError, errRpc)
@@ -3171,13 +3915,14 @@ func TestGetCode(t *testing.T) {
 		nil,
 		defaultChainId,
 		cacheService,
+		service.Config{GetCodeConsensusFallback: true},
 	)
 
 	t.Run("iHTS precompile address", func(t *testing.T) {
 		address := "0x0000000000000000000000000000000000000167"
 		blockNumber := "latest"
 
-		result, errMap := s.GetCode(address, blockNumber)
+		result, errMap := s.GetCode(context.Background(), address, blockNumber)
 
 		assert.Equal(t, "0xfe", result)
 		assert.Nil(t, errMap)
@@ -3195,17 +3940,17 @@ func TestGetCode(t *testing.T) {
 
 		// Set up concurrent resolution expectations
 		mockClient.EXPECT().
-			GetContractById(address).
+			GetContractById(gomock.Any(), address).
 			Return(nil, errors.New("not found")).
 			AnyTimes()
 
 		mockClient.EXPECT().
-			GetAccountById(address).
+			GetAccountById(gomock.Any(), address).
 			Return(nil, errors.New("not found")).
 			AnyTimes()
 
 		mockClient.EXPECT().
-			GetTokenById(gomock.Any()).
+			GetTokenById(gomock.Any(), gomock.Any()).
 			Return(nil, errors.New("not a token")).
 			AnyTimes()
 
@@ -3219,7 +3964,7 @@ func TestGetCode(t *testing.T) {
 			Set(gomock.Any(), cacheKey, runtimeBytecode, DefaultExpiration).
 			Return(nil)
 
-		result, errMap := s.GetCode(address, blockNumber)
+		result, errMap := s.GetCode(context.Background(), address, blockNumber)
 
 		assert.Equal(t, runtimeBytecode, result)
 		assert.Nil(t, errMap)
@@ -3240,17 +3985,17 @@ func TestGetCode(t *testing.T) {
 
 		// Then expect concurrent resolution attempts
 		mockClient.EXPECT().
-			GetContractById(address).
+			GetContractById(gomock.Any(), address).
 			Return(nil, fmt.Errorf("not found")).
 			AnyTimes()
 
 		mockClient.EXPECT().
-			GetAccountById(address).
+			GetAccountById(gomock.Any(), address).
 			Return(nil, fmt.Errorf("not found")).
 			AnyTimes()
 
 		mockClient.EXPECT().
-			GetTokenById(gomock.Any()).
+			GetTokenById(gomock.Any(), gomock.Any()).
 			Return(nil, fmt.Errorf("not a token")).
 			AnyTimes()
 
@@ -3266,7 +4011,7 @@ func TestGetCode(t *testing.T) {
 			Return(nil).
 			Times(1)
 
-		result, errMap := s.GetCode(address, blockNumber)
+		result, errMap := s.GetCode(context.Background(), address, blockNumber)
 
 		assert.Equal(t, expectedResponse, result)
 		assert.Nil(t, errMap)
@@ -3283,7 +4028,7 @@ func TestGetCode(t *testing.T) {
 			SetArg(2, cachedBytecode).
 			Return(nil)
 
-		result, errMap := s.GetCode(address, blockNumber)
+		result, errMap := s.GetCode(context.Background(), address, blockNumber)
 
 		assert.Equal(t, cachedBytecode, result)
 		assert.Nil(t, errMap)
@@ -3301,18 +4046,18 @@ func TestGetCode(t *testing.T) {
 
 		// Then expect concurrent resolution attempts
 		mockClient.EXPECT().
-			GetContractById(address).
+			GetContractById(gomock.Any(), address).
 			Return(nil, fmt.Errorf("not found")).
 			AnyTimes()
 
 		mockClient.EXPECT().
-			GetAccountById(address).
+			GetAccountById(gomock.Any(), address).
 			Return(nil, fmt.Errorf("not found")).
 			AnyTimes()
 
 		// Add token resolution expectation
 		mockClient.EXPECT().
-			GetTokenById(gomock.Any()).
+			GetTokenById(gomock.Any(), gomock.Any()).
 			Return(nil, fmt.Errorf("not a token")).
 			AnyTimes()
 
@@ -3321,48 +4066,145 @@ func TestGetCode(t *testing.T) {
 			GetContractByteCode(int64(0), int64(0), address).
 			Return(nil, fmt.Errorf("hedera client error"))
 
-		result, errMap := s.GetCode(address, blockNumber)
+		result, errMap := s.GetCode(context.Background(), address, blockNumber)
 
 		assert.Equal(t, "0x", result)
 		assert.Nil(t, errMap)
 	})
 }
 
-func TestSendRawTransactionEndpoint(t *testing.T) {
-	// Setup
+func TestGetCode_WithoutConsensusFallback(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockMirrorClient := mocks.NewMockMirrorClient(ctrl)
+	cacheService := mocks.NewMockCacheService(ctrl)
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	// No GetContractByteCode calls are expected on the consensus node client
 	mockHederaClient := mocks.NewMockHederaNodeClient(ctrl)
-	mockCacheService := mocks.NewMockCacheService(ctrl)
 
-	logger := zap.NewNop()
-	ethService := service.NewEthService(mockHederaClient, mockMirrorClient, nil, logger, nil, "0x128", mockCacheService)
+	s := service.NewEthService(mockHederaClient, mockClient, nil, zap.NewNop(), nil, defaultChainId, cacheService, service.Config{})
 
-	// Test case 1: Successful transaction
-	t.Run("Successful transaction", func(t *testing.T) {
-		// Mock cache service for gas price
-		mockCacheService.EXPECT().
-			Get(gomock.Any(), "eth_gasPrice", gomock.Any()).
-			SetArg(2, "0x4f29944800").
-			Return(nil)
+	expectUnresolved := func(address string) {
+		cacheService.EXPECT().
+			Get(gomock.Any(), fmt.Sprintf("%s_%s_latest", GetCode, address), gomock.Any()).
+			Return(errors.New("not found"))
+		mockClient.EXPECT().
+			GetContractById(gomock.Any(), address).
+			Return(nil, hedera.ErrUpstreamUnavailable)
+		mockClient.EXPECT().
+			GetAccountById(gomock.Any(), address).
+			Return(nil, hedera.ErrUpstreamUnavailable)
+	}
 
-		// Mock GetAccount for contract address
-		mockMirrorClient.EXPECT().
-			GetAccount(gomock.Any(), gomock.Any()).
+	t.Run("Contract fetched with retry", func(t *testing.T) {
+		address := "0x0a56fd9e0c4f67df549e7f375a9451c0086482ec"
+		runtimeBytecode := "0x6080604052"
+
+		expectUnresolved(address)
+		mockClient.EXPECT().
+			GetContractByIdWithRetry(gomock.Any(), address).
+			Return(&domain.ContractResponse{RuntimeBytecode: &runtimeBytecode}, nil)
+		cacheService.EXPECT().
+			Set(gomock.Any(), fmt.Sprintf("%s_%s_latest", GetCode, address), runtimeBytecode, service.DefaultExpiration).
 			Return(nil)
 
-		// Mock GetAccountById for sender address
-		mockMirrorClient.EXPECT().
-			GetAccountById(gomock.Any()).
-			Return(&domain.AccountResponse{
-				EvmAddress: "0x96216849c49358B10257cb55b28eA603c874b05E",
-				Balance: struct {
-					Balance   int64         `json:"balance"`
-					Timestamp string        `json:"timestamp"`
-					Tokens    []interface{} `json:"tokens"`
-				}{
+		result, errRpc := s.GetCode(context.Background(), address, "latest")
+
+		assert.Nil(t, errRpc)
+		assert.Equal(t, runtimeBytecode, result)
+	})
+
+	t.Run("Unknown address", func(t *testing.T) {
+		address := "0x1b56fd9e0c4f67df549e7f375a9451c0086482ec"
+
+		expectUnresolved(address)
+		mockClient.EXPECT().
+			GetContractByIdWithRetry(gomock.Any(), address).
+			Return(nil, hedera.ErrNotFound)
+
+		result, errRpc := s.GetCode(context.Background(), address, "latest")
+
+		assert.Nil(t, errRpc)
+		assert.Equal(t, "0x", result)
+	})
+
+	t.Run("Account without code", func(t *testing.T) {
+		address := "0x2c56fd9e0c4f67df549e7f375a9451c0086482ec"
+
+		cacheService.EXPECT().
+			Get(gomock.Any(), fmt.Sprintf("%s_%s_latest", GetCode, address), gomock.Any()).
+			Return(errors.New("not found"))
+		mockClient.EXPECT().
+			GetContractById(gomock.Any(), address).
+			Return(nil, hedera.ErrNotFound)
+		mockClient.EXPECT().
+			GetAccountById(gomock.Any(), address).
+			Return(&domain.AccountResponse{EvmAddress: address}, nil)
+
+		result, errRpc := s.GetCode(context.Background(), address, "latest")
+
+		assert.Nil(t, errRpc)
+		assert.Equal(t, "0x", result)
+	})
+
+	t.Run("Mirror node unavailable", func(t *testing.T) {
+		address := "0x3d56fd9e0c4f67df549e7f375a9451c0086482ec"
+
+		expectUnresolved(address)
+		mockClient.EXPECT().
+			GetContractByIdWithRetry(gomock.Any(), address).
+			Return(nil, hedera.ErrUpstreamUnavailable)
+
+		result, errRpc := s.GetCode(context.Background(), address, "latest")
+
+		assert.Nil(t, result)
+		if assert.NotNil(t, errRpc) {
+			assert.Equal(t, domain.MirrorNodeUpstreamFail, errRpc.Code)
+		}
+	})
+}
+
+// sendRawTransactionKey is the cache key of the hash returned for rawTxHex.
+func sendRawTransactionKey(rawTxHex string) string {
+	rawTx, _ := hex.DecodeString(strings.TrimPrefix(rawTxHex, "0x"))
+	return "eth_sendRawTransaction_0x" + hex.EncodeToString(util.Keccak256(rawTx))
+}
+
+func TestSendRawTransactionEndpoint(t *testing.T) {
+	// Setup
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMirrorClient := mocks.NewMockMirrorClient(ctrl)
+	mockHederaClient := mocks.NewMockHederaNodeClient(ctrl)
+	mockCacheService := mocks.NewMockCacheService(ctrl)
+
+	logger := zap.NewNop()
+	ethService := service.NewEthService(mockHederaClient, mockMirrorClient, nil, logger, nil, "0x128", mockCacheService, service.Config{})
+
+	// Test case 1: Successful transaction
+	t.Run("Successful transaction", func(t *testing.T) {
+		// Mock cache service for gas price
+		mockCacheService.EXPECT().
+			Get(gomock.Any(), "eth_gasPrice", gomock.Any()).
+			SetArg(2, "0x4f29944800").
+			Return(nil)
+
+		// Mock GetAccount for contract address
+		mockMirrorClient.EXPECT().
+			GetAccount(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, hedera.ErrNotFound)
+
+		// Mock GetAccountById for sender address
+		mockMirrorClient.EXPECT().
+			GetAccountById(gomock.Any(), gomock.Any()).
+			Return(&domain.AccountResponse{
+				EvmAddress: "0x96216849c49358B10257cb55b28eA603c874b05E",
+				Balance: struct {
+					Balance   int64         `json:"balance"`
+					Timestamp string        `json:"timestamp"`
+					Tokens    []interface{} `json:"tokens"`
+				}{
 					Balance:   1000000000,
 					Timestamp: "2021-01-01T00:00:00Z",
 					Tokens:    []interface{}{},
@@ -3373,6 +4215,13 @@ func TestSendRawTransactionEndpoint(t *testing.T) {
 
 		expectedHash := "0x123456789abcdef"
 
+		mockCacheService.EXPECT().
+			Get(gomock.Any(), sendRawTransactionKey(rawTxHex), gomock.Any()).
+			Return(errors.New("not found"))
+		mockCacheService.EXPECT().
+			Set(gomock.Any(), sendRawTransactionKey(rawTxHex), expectedHash, gomock.Any()).
+			Return(nil)
+
 		// Mock successful transaction
 		mockHederaClient.EXPECT().
 			SendRawTransaction(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -3381,12 +4230,12 @@ func TestSendRawTransactionEndpoint(t *testing.T) {
 			}, nil)
 
 		mockMirrorClient.EXPECT().
-			RepeatGetContractResult(gomock.Any(), gomock.Any()).
-			Return(&domain.ContractResultResponse{
+			RepeatGetContractResult(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&domain.ContractResult{
 				Hash: expectedHash,
-			})
+			}, nil)
 
-		result, errMap := ethService.SendRawTransaction(rawTxHex)
+		result, errMap := ethService.SendRawTransaction(context.Background(), rawTxHex)
 
 		assert.Nil(t, errMap)
 		resultStr, ok := result.(*string)
@@ -3396,10 +4245,799 @@ func TestSendRawTransactionEndpoint(t *testing.T) {
 
 	// Test case 2: Invalid transaction data
 	t.Run("Invalid transaction data", func(t *testing.T) {
-		result, errRpc := ethService.SendRawTransaction("")
+		result, errRpc := ethService.SendRawTransaction(context.Background(), "")
 
 		assert.NotNil(t, errRpc)
 		assert.Nil(t, result)
 		assert.Equal(t, domain.NewRPCError(domain.ServerError, "Failed to parse transaction"), errRpc)
 	})
+
+	// Test case 3: HBAR budget exhausted
+	t.Run("HBAR budget exhausted", func(t *testing.T) {
+		// 3,000,000 gas at 34 tinybars costs 1.02 HBAR, above the 1 HBAR budget
+		tieredLimiter := limiter.NewTieredLimiter(nil, 1, 0)
+		limitedService := service.NewEthService(mockHederaClient, mockMirrorClient, nil, logger, tieredLimiter, "0x128", mockCacheService, service.Config{})
+
+		mockCacheService.EXPECT().
+			Get(gomock.Any(), "eth_gasPrice", gomock.Any()).
+			SetArg(2, "0x4f29944800").
+			Return(nil)
+
+		mockMirrorClient.EXPECT().
+			GetAccount(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, hedera.ErrNotFound)
+
+		mockMirrorClient.EXPECT().
+			GetAccountById(gomock.Any(), gomock.Any()).
+			Return(&domain.AccountResponse{
+				EvmAddress: "0x96216849c49358B10257cb55b28eA603c874b05E",
+				Balance: struct {
+					Balance   int64         `json:"balance"`
+					Timestamp string        `json:"timestamp"`
+					Tokens    []interface{} `json:"tokens"`
+				}{
+					Balance:   1000000000,
+					Timestamp: "2021-01-01T00:00:00Z",
+					Tokens:    []interface{}{},
+				},
+			}, nil)
+
+		rawTxHex := "0xf8cc1e854f29944800832dc6c0940a56fd9e0c4f67df549e7f375a9451c0086482ec80b864a41368620000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000b757064617465645f6d7367000000000000000000000000000000000000000000820274a0cd6095ae91ea5d609b32923a9f73572e2d031fde0b7e38de44d3eda187474140a03028ecf5eb61070cba8e927ad5e11eac116da441307f2d54dae8be90f4476c59"
+
+		mockCacheService.EXPECT().
+			Get(gomock.Any(), sendRawTransactionKey(rawTxHex), gomock.Any()).
+			Return(errors.New("not found"))
+
+		result, errRpc := limitedService.SendRawTransaction(context.Background(), rawTxHex)
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.NewHbarRateLimitExceededError(), errRpc)
+		assert.Equal(t, int64(limiter.TinybarsPerHbar), tieredLimiter.OperatorHbarRemaining())
+	})
+}
+
+func TestSendRawTransaction_Async(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMirrorClient := mocks.NewMockMirrorClient(ctrl)
+	mockHederaClient := mocks.NewMockHederaNodeClient(ctrl)
+	mockCacheService := mocks.NewMockCacheService(ctrl)
+
+	ethService := service.NewEthService(mockHederaClient, mockMirrorClient, nil, zap.NewNop(), nil, "0x128", mockCacheService, service.Config{AsyncSendRawTransaction: true})
+
+	rawTxHex := "0xf8cc1e854f29944800832dc6c0940a56fd9e0c4f67df549e7f375a9451c0086482ec80b864a41368620000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000b757064617465645f6d7367000000000000000000000000000000000000000000820274a0cd6095ae91ea5d609b32923a9f73572e2d031fde0b7e38de44d3eda187474140a03028ecf5eb61070cba8e927ad5e11eac116da441307f2d54dae8be90f4476c59"
+	rawTx, _ := hex.DecodeString(strings.TrimPrefix(rawTxHex, "0x"))
+	expectedHash := "0x" + hex.EncodeToString(util.Keccak256(rawTx))
+
+	mockCacheService.EXPECT().
+		Get(gomock.Any(), sendRawTransactionKey(rawTxHex), gomock.Any()).
+		Return(errors.New("not found"))
+	mockCacheService.EXPECT().
+		Set(gomock.Any(), sendRawTransactionKey(rawTxHex), expectedHash, gomock.Any()).
+		Return(nil)
+	mockCacheService.EXPECT().
+		Get(gomock.Any(), "eth_gasPrice", gomock.Any()).
+		SetArg(2, "0x4f29944800").
+		Return(nil)
+	mockMirrorClient.EXPECT().
+		GetAccount(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, hedera.ErrNotFound)
+	mockMirrorClient.EXPECT().
+		GetAccountById(gomock.Any(), gomock.Any()).
+		Return(&domain.AccountResponse{
+			EvmAddress: "0x96216849c49358B10257cb55b28eA603c874b05E",
+			Balance: struct {
+				Balance   int64         `json:"balance"`
+				Timestamp string        `json:"timestamp"`
+				Tokens    []interface{} `json:"tokens"`
+			}{Balance: 1000000000},
+		}, nil)
+	mockHederaClient.EXPECT().
+		SendRawTransaction(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&hedera.TransactionResponse{TransactionID: "0.0.1234@1234567890.123456789"}, nil)
+
+	// The mirror node is only polled after the hash has been returned
+	polled := make(chan struct{})
+	release := make(chan struct{})
+	mockMirrorClient.EXPECT().
+		RepeatGetContractResult(gomock.Any(), "0.0.1234-1234567890-123456789", 10).
+		DoAndReturn(func(ctx context.Context, transactionId string, retries int) (*domain.ContractResult, error) {
+			<-release
+			defer close(polled)
+			return &domain.ContractResult{Hash: expectedHash}, nil
+		})
+
+	result, errRpc := ethService.SendRawTransaction(context.Background(), rawTxHex)
+	assert.Nil(t, errRpc)
+	if hash, ok := result.(*string); assert.True(t, ok) {
+		assert.Equal(t, expectedHash, *hash)
+	}
+
+	close(release)
+	select {
+	case <-polled:
+	case <-time.After(time.Second):
+		t.Fatal("transaction record was not polled in the background")
+	}
+}
+
+func TestSendRawTransaction_ConsensusRecordFallback(t *testing.T) {
+	rawTxHex := "0xf8cc1e854f29944800832dc6c0940a56fd9e0c4f67df549e7f375a9451c0086482ec80b864a41368620000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000b757064617465645f6d7367000000000000000000000000000000000000000000820274a0cd6095ae91ea5d609b32923a9f73572e2d031fde0b7e38de44d3eda187474140a03028ecf5eb61070cba8e927ad5e11eac116da441307f2d54dae8be90f4476c59"
+	rawTx, _ := hex.DecodeString(strings.TrimPrefix(rawTxHex, "0x"))
+	localHash := "0x" + hex.EncodeToString(util.Keccak256(rawTx))
+
+	testCases := []struct {
+		name         string
+		record       *hedera.TransactionRecord
+		recordErr    error
+		expectedHash string
+		expectError  bool
+	}{
+		{
+			name:         "Successful transaction",
+			record:       &hedera.TransactionRecord{Status: "SUCCESS", EthereumHash: "0xabc"},
+			expectedHash: "0xabc",
+		},
+		{
+			name:         "Reverted transaction",
+			record:       &hedera.TransactionRecord{Status: "CONTRACT_REVERT_EXECUTED"},
+			expectedHash: localHash,
+		},
+		{
+			name:        "Consensus node unavailable",
+			recordErr:   errors.New("connection refused"),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockMirrorClient := mocks.NewMockMirrorClient(ctrl)
+			mockHederaClient := mocks.NewMockHederaNodeClient(ctrl)
+			mockCacheService := mocks.NewMockCacheService(ctrl)
+			ethService := service.NewEthService(mockHederaClient, mockMirrorClient, nil, zap.NewNop(), nil, "0x128", mockCacheService, service.Config{})
+
+			mockCacheService.EXPECT().
+				Get(gomock.Any(), sendRawTransactionKey(rawTxHex), gomock.Any()).
+				Return(errors.New("not found"))
+			if !tc.expectError {
+				mockCacheService.EXPECT().
+					Set(gomock.Any(), sendRawTransactionKey(rawTxHex), tc.expectedHash, gomock.Any()).
+					Return(nil)
+			}
+			mockCacheService.EXPECT().
+				Get(gomock.Any(), "eth_gasPrice", gomock.Any()).
+				SetArg(2, "0x4f29944800").
+				Return(nil)
+			mockMirrorClient.EXPECT().
+				GetAccount(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, hedera.ErrNotFound)
+			mockMirrorClient.EXPECT().
+				GetAccountById(gomock.Any(), gomock.Any()).
+				Return(&domain.AccountResponse{
+					EvmAddress: "0x96216849c49358B10257cb55b28eA603c874b05E",
+					Balance: struct {
+						Balance   int64         `json:"balance"`
+						Timestamp string        `json:"timestamp"`
+						Tokens    []interface{} `json:"tokens"`
+					}{Balance: 1000000000},
+				}, nil)
+			mockHederaClient.EXPECT().
+				SendRawTransaction(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(&hedera.TransactionResponse{TransactionID: "0.0.1234@1234567890.123456789"}, nil)
+			mockMirrorClient.EXPECT().
+				RepeatGetContractResult(gomock.Any(), "0.0.1234-1234567890-123456789", 10).
+				Return(nil, hedera.ErrNotFound)
+			mockHederaClient.EXPECT().
+				GetTransactionRecord("0.0.1234@1234567890.123456789").
+				Return(tc.record, tc.recordErr)
+
+			result, errRpc := ethService.SendRawTransaction(context.Background(), rawTxHex)
+
+			if tc.expectError {
+				assert.NotNil(t, errRpc)
+				assert.Nil(t, result)
+				return
+			}
+			assert.Nil(t, errRpc)
+			if hash, ok := result.(*string); assert.True(t, ok) {
+				assert.Equal(t, tc.expectedHash, *hash)
+			}
+		})
+	}
+}
+
+func TestSendRawTransaction_WarmReceiptCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMirrorClient := mocks.NewMockMirrorClient(ctrl)
+	mockHederaClient := mocks.NewMockHederaNodeClient(ctrl)
+	mockCacheService := mocks.NewMockCacheService(ctrl)
+
+	ethService := service.NewEthService(mockHederaClient, mockMirrorClient, nil, zap.NewNop(), nil, "0x128", mockCacheService, service.Config{WarmReceiptCache: true})
+
+	rawTxHex := "0xf8cc1e854f29944800832dc6c0940a56fd9e0c4f67df549e7f375a9451c0086482ec80b864a41368620000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000b757064617465645f6d7367000000000000000000000000000000000000000000820274a0cd6095ae91ea5d609b32923a9f73572e2d031fde0b7e38de44d3eda187474140a03028ecf5eb61070cba8e927ad5e11eac116da441307f2d54dae8be90f4476c59"
+	rawTx, _ := hex.DecodeString(strings.TrimPrefix(rawTxHex, "0x"))
+	hash := "0x" + hex.EncodeToString(util.Keccak256(rawTx))
+	from := "0x96216849c49358b10257cb55b28ea603c874b05e"
+	to := "0x0a56fd9e0c4f67df549e7f375a9451c0086482ec"
+	blockHash := "0x" + strings.Repeat("ab", 32)
+
+	mockCacheService.EXPECT().
+		Get(gomock.Any(), sendRawTransactionKey(rawTxHex), gomock.Any()).
+		Return(errors.New("not found"))
+	mockCacheService.EXPECT().
+		Set(gomock.Any(), sendRawTransactionKey(rawTxHex), hash, gomock.Any()).
+		Return(nil)
+	mockCacheService.EXPECT().
+		Get(gomock.Any(), "eth_gasPrice", gomock.Any()).
+		SetArg(2, "0x4f29944800").
+		Return(nil)
+	mockMirrorClient.EXPECT().
+		GetAccount(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, hedera.ErrNotFound)
+	mockMirrorClient.EXPECT().
+		GetAccountById(gomock.Any(), gomock.Any()).
+		Return(&domain.AccountResponse{
+			EvmAddress: from,
+			Balance: struct {
+				Balance   int64         `json:"balance"`
+				Timestamp string        `json:"timestamp"`
+				Tokens    []interface{} `json:"tokens"`
+			}{Balance: 1000000000},
+		}, nil)
+	mockHederaClient.EXPECT().
+		SendRawTransaction(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&hedera.TransactionResponse{TransactionID: "0.0.1234@1234567890.123456789"}, nil)
+	mockMirrorClient.EXPECT().
+		RepeatGetContractResult(gomock.Any(), "0.0.1234-1234567890-123456789", 10).
+		Return(&domain.ContractResult{Hash: hash, BlockHash: blockHash, BlockNumber: 42, From: from, To: to, Status: "0x1"}, nil)
+
+	// The receipt and transaction are built and cached in the background
+	for _, address := range []string{from, to} {
+		mockCacheService.EXPECT().
+			Get(gomock.Any(), "evm_address_"+address, gomock.Any()).
+			SetArg(2, address).
+			Return(nil).
+			AnyTimes()
+	}
+	mockMirrorClient.EXPECT().
+		GetBlockByHashOrNumber(gomock.Any(), blockHash).
+		Return(&domain.BlockResponse{Hash: blockHash, Number: 42}, nil)
+	mockMirrorClient.EXPECT().
+		GetNetworkFees(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(int64(71), nil)
+
+	warmed := make(chan struct{})
+	var receipt *domain.TransactionReceipt
+	mockCacheService.EXPECT().
+		Set(gomock.Any(), "eth_getTransactionReceipt_"+hash, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+			receipt = value.(*domain.TransactionReceipt)
+			return nil
+		})
+	mockCacheService.EXPECT().
+		Set(gomock.Any(), "eth_getTransactionByHash_"+hash, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+			close(warmed)
+			return nil
+		})
+
+	result, errRpc := ethService.SendRawTransaction(context.Background(), rawTxHex)
+	assert.Nil(t, errRpc)
+	if returned, ok := result.(*string); assert.True(t, ok) {
+		assert.Equal(t, hash, *returned)
+	}
+
+	select {
+	case <-warmed:
+	case <-time.After(time.Second):
+		t.Fatal("caches of the sent transaction were not warmed")
+	}
+	require.NotNil(t, receipt)
+	assert.Equal(t, hash, receipt.TransactionHash)
+	assert.Equal(t, blockHash, receipt.BlockHash)
+	assert.Equal(t, "0x2a", receipt.BlockNumber)
+	assert.Equal(t, "0x1", receipt.Status)
+}
+
+func TestSendRawTransaction_Resubmission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMirrorClient := mocks.NewMockMirrorClient(ctrl)
+	mockHederaClient := mocks.NewMockHederaNodeClient(ctrl)
+	cacheService := cache.NewMemoryCache(time.Minute, time.Minute)
+	require.NoError(t, cacheService.Set(context.Background(), "eth_gasPrice", "0x4f29944800", time.Minute))
+
+	ethService := service.NewEthService(mockHederaClient, mockMirrorClient, nil, zap.NewNop(), nil, "0x128", cacheService, service.Config{})
+
+	rawTxHex := "0xf8cc1e854f29944800832dc6c0940a56fd9e0c4f67df549e7f375a9451c0086482ec80b864a41368620000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000b757064617465645f6d7367000000000000000000000000000000000000000000820274a0cd6095ae91ea5d609b32923a9f73572e2d031fde0b7e38de44d3eda187474140a03028ecf5eb61070cba8e927ad5e11eac116da441307f2d54dae8be90f4476c59"
+	expectedHash := "0x123456789abcdef"
+
+	// The transaction is submitted once, however often it is sent
+	mockMirrorClient.EXPECT().
+		GetAccount(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, hedera.ErrNotFound)
+	mockMirrorClient.EXPECT().
+		GetAccountById(gomock.Any(), gomock.Any()).
+		Return(&domain.AccountResponse{
+			EvmAddress: "0x96216849c49358B10257cb55b28eA603c874b05E",
+			Balance: struct {
+				Balance   int64         `json:"balance"`
+				Timestamp string        `json:"timestamp"`
+				Tokens    []interface{} `json:"tokens"`
+			}{Balance: 1000000000},
+		}, nil)
+	release := make(chan struct{})
+	mockHederaClient.EXPECT().
+		SendRawTransaction(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(transactionData []byte, gasPrice int64, callerId string) (*hedera.TransactionResponse, error) {
+			<-release
+			return &hedera.TransactionResponse{TransactionID: "0.0.1234@1234567890.123456789"}, nil
+		})
+	mockMirrorClient.EXPECT().
+		RepeatGetContractResult(gomock.Any(), "0.0.1234-1234567890-123456789", 10).
+		Return(&domain.ContractResult{Hash: expectedHash}, nil)
+
+	// A resubmission arriving while the first submission is pending waits for it
+	results := make(chan interface{}, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			result, errRpc := ethService.SendRawTransaction(context.Background(), rawTxHex)
+			assert.Nil(t, errRpc)
+			results <- result
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	for i := 0; i < 2; i++ {
+		if hash, ok := (<-results).(*string); assert.True(t, ok) {
+			assert.Equal(t, expectedHash, *hash)
+		}
+	}
+
+	// A later resubmission returns the hash without submitting again
+	result, errRpc := ethService.SendRawTransaction(context.Background(), rawTxHex)
+	assert.Nil(t, errRpc)
+	if hash, ok := result.(*string); assert.True(t, ok) {
+		assert.Equal(t, expectedHash, *hash)
+	}
+}
+
+func TestGetProof(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := zap.NewNop()
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	mockHederaClient := mocks.NewMockHederaNodeClient(ctrl)
+	cacheService := mocks.NewMockCacheService(ctrl)
+	commonService := mocks.NewMockCommonService(ctrl)
+
+	s := service.NewEthService(mockHederaClient, mockClient, commonService, logger, nil, defaultChainId, cacheService, service.Config{})
+
+	address := "0x742d35cc6634c0532925a3b844bc454e4438f44e"
+	slot := "0x0"
+	runtimeBytecode := "0x6080"
+	timestampTo := "1702123200.000000000"
+
+	cacheService.EXPECT().Get(gomock.Any(), "balance_account_"+address, gomock.Any()).SetArg(2, "0.0.1001").Return(nil)
+	mockClient.EXPECT().GetBalance(gomock.Any(), "0.0.1001", "0").Return("0x64", nil)
+
+	commonService.EXPECT().GetBlockNumberByNumberOrTag(gomock.Any(), "latest").Return(int64(100), nil).Times(2)
+	mockClient.EXPECT().
+		GetBlockByHashOrNumber(gomock.Any(), "100").
+		Return(&domain.BlockResponse{Timestamp: domain.Timestamp{To: timestampTo}}, nil).
+		Times(2)
+	mockClient.EXPECT().
+		GetAccount(gomock.Any(), address, timestampTo).
+		Return(domain.AccountResponse{EthereumNonce: 5}, nil)
+
+	cacheService.EXPECT().Get(gomock.Any(), fmt.Sprintf("%s_%s_%s", GetCode, address, "latest"), gomock.Any()).Return(errors.New("not found"))
+	cacheService.EXPECT().Set(gomock.Any(), gomock.Any(), runtimeBytecode, gomock.Any()).Return(nil)
+	mockClient.EXPECT().
+		GetContractById(gomock.Any(), address).
+		Return(&domain.ContractResponse{EvmAddress: address, RuntimeBytecode: &runtimeBytecode}, nil).
+		AnyTimes()
+	mockClient.EXPECT().GetAccountById(gomock.Any(), address).Return(nil, errors.New("not found")).AnyTimes()
+
+	mockClient.EXPECT().
+		GetContractStateByAddressAndSlot(gomock.Any(), address, slot, timestampTo).
+		Return(&domain.ContractStateResponse{
+			State: []domain.ContractState{
+				{Value: "0x0000000000000000000000000000000000000000000000000000000000000064"},
+			},
+		}, nil)
+
+	result, errRpc := s.GetProof(context.Background(), address, []string{slot}, "latest")
+
+	assert.Nil(t, errRpc)
+	proof, ok := result.(domain.AccountProof)
+	assert.True(t, ok)
+	assert.Equal(t, address, proof.Address)
+	assert.Equal(t, "0x64", proof.Balance)
+	assert.Equal(t, "0x5", proof.Nonce)
+	assert.Equal(t, "0x1a578b7a4b0b5755db6d121b4118d4bc68fe170dca840c59bc922f14175a76b0", proof.CodeHash) // keccak256(0x6080)
+	assert.Equal(t, "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421", proof.StorageHash)
+	assert.Empty(t, proof.AccountProof)
+	assert.Equal(t, []domain.StorageProof{{Key: slot, Value: "0x64", Proof: []string{}}}, proof.StorageProof)
+}
+
+func TestCall_CachesResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	cacheService := cache.NewMemoryCache(time.Minute, time.Minute)
+	s := service.NewEthService(nil, mockClient, nil, zap.NewNop(), nil, defaultChainId, cacheService, service.Config{CallCacheTTL: time.Minute})
+
+	balanceOf := map[string]interface{}{
+		"to":   "0x742d35Cc6634C0532925a3b844Bc454e4438f44e",
+		"data": "0x70a08231000000000000000000000000b1d6b01b94d854f521665696ea17fcf87c160d97",
+	}
+	totalSupply := map[string]interface{}{
+		"to":   "0x742d35Cc6634C0532925a3b844Bc454e4438f44e",
+		"data": "0x18160ddd",
+	}
+	revert := map[string]interface{}{
+		"to":   "0x742d35Cc6634C0532925a3b844Bc454e4438f44e",
+		"data": "0xdeadbeef",
+	}
+
+	mockClient.EXPECT().PostCall(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, callObject map[string]interface{}) (interface{}, error) {
+		if callObject["data"] == "0xdeadbeef" {
+			return nil, &hedera.ContractCallError{StatusCode: 400, Message: "CONTRACT_REVERT_EXECUTED"}
+		}
+		return fmt.Sprintf("%v_%v", callObject["data"], callObject["block"]), nil
+	}).Times(5)
+
+	// The repeated call is answered from the cache
+	for i := 0; i < 2; i++ {
+		result, errRpc := s.Call(context.Background(), balanceOf, "latest")
+		require.Nil(t, errRpc)
+		assert.Equal(t, "0x70a08231000000000000000000000000b1d6b01b94d854f521665696ea17fcf87c160d97_latest", result)
+	}
+
+	// Other data or another block is a different call
+	_, errRpc := s.Call(context.Background(), totalSupply, "latest")
+	require.Nil(t, errRpc)
+	result, errRpc := s.Call(context.Background(), balanceOf, "0x64")
+	require.Nil(t, errRpc)
+	assert.Equal(t, "0x70a08231000000000000000000000000b1d6b01b94d854f521665696ea17fcf87c160d97_0x64", result)
+
+	// Reverts are not cached
+	for i := 0; i < 2; i++ {
+		_, errRpc = s.Call(context.Background(), revert, "latest")
+		require.NotNil(t, errRpc)
+	}
+}
+
+func TestCallAndEstimateGas_ContractRevert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	s := service.NewEthService(nil, mockClient, nil, zap.NewNop(), nil, defaultChainId, mocks.NewMockCacheService(ctrl), service.Config{})
+
+	transaction := map[string]interface{}{
+		"to":   "0x742d35Cc6634C0532925a3b844Bc454e4438f44e",
+		"data": "0x70a08231",
+	}
+
+	errorStringData := "0x08c379a000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000004626f6f6d00000000000000000000000000000000000000000000000000000000"
+	panicData := "0x4e487b710000000000000000000000000000000000000000000000000000000000000011"
+	customErrorData := "0xcf4791810000000000000000000000000000000000000000000000000000000000000001"
+
+	testCases := []struct {
+		name            string
+		callErr         error
+		expectedCode    int
+		expectedMessage string
+		expectedData    string
+	}{
+		{
+			name:            "Error(string) revert",
+			callErr:         &hedera.ContractCallError{StatusCode: 400, Message: "CONTRACT_REVERT_EXECUTED", Data: errorStringData},
+			expectedCode:    domain.ContractRevert,
+			expectedMessage: "execution reverted: boom",
+			expectedData:    errorStringData,
+		},
+		{
+			name:            "Panic(uint256) revert",
+			callErr:         &hedera.ContractCallError{StatusCode: 400, Message: "CONTRACT_REVERT_EXECUTED", Data: panicData},
+			expectedCode:    domain.ContractRevert,
+			expectedMessage: "execution reverted: panic: arithmetic underflow or overflow (0x11)",
+			expectedData:    panicData,
+		},
+		{
+			name:            "Custom error revert",
+			callErr:         &hedera.ContractCallError{StatusCode: 400, Message: "CONTRACT_REVERT_EXECUTED", Data: customErrorData},
+			expectedCode:    domain.ContractRevert,
+			expectedMessage: "execution reverted",
+			expectedData:    customErrorData,
+		},
+		{
+			name:            "Revert without payload",
+			callErr:         &hedera.ContractCallError{StatusCode: 400, Message: "CONTRACT_REVERT_EXECUTED", Detail: "Not enough", Data: "0x"},
+			expectedCode:    domain.ContractRevert,
+			expectedMessage: "execution reverted: Not enough",
+			expectedData:    "0x4e6f7420656e6f756768",
+		},
+		{
+			name:            "Non revert failure",
+			callErr:         &hedera.ContractCallError{StatusCode: 400, Message: "INVALID_TRANSACTION"},
+			expectedCode:    domain.ServerError,
+			expectedMessage: "Failed to post call",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient.EXPECT().PostCall(gomock.Any(), gomock.Any()).Return(nil, tc.callErr).Times(2)
+
+			_, errRpc := s.Call(context.Background(), transaction, "latest")
+			assert.Equal(t, tc.expectedCode, errRpc.Code)
+			assert.Equal(t, tc.expectedMessage, errRpc.Message)
+			assert.Equal(t, tc.expectedData, errRpc.Data)
+
+			_, errRpc = s.EstimateGas(context.Background(), transaction, "latest")
+			assert.Equal(t, tc.expectedCode, errRpc.Code)
+			assert.Equal(t, tc.expectedMessage, errRpc.Message)
+			assert.Equal(t, tc.expectedData, errRpc.Data)
+		})
+	}
+}
+
+func TestGetTransactionReceipt_ContractAddress(t *testing.T) {
+	blockHash := "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+	from := "0x00000000000000000000000000000000000004d2"
+	deployedAddress := "0x7f9a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c"
+	tokenAddress := "0x00000000000000000000000000000000000007d1"
+
+	testCases := []struct {
+		name                    string
+		result                  domain.ContractResult
+		resolveContract         bool
+		expectedTo              *string
+		expectedContractAddress *string
+	}{
+		{
+			name: "contract creation resolves long-zero address",
+			result: domain.ContractResult{
+				ContractID:         "0.0.1001",
+				CreatedContractIDs: []string{"0.0.1001"},
+				Address:            "0x00000000000000000000000000000000000003e9",
+				To:                 "0x00000000000000000000000000000000000003e9",
+			},
+			resolveContract:         true,
+			expectedContractAddress: &deployedAddress,
+		},
+		{
+			name: "contract creation with evm address",
+			result: domain.ContractResult{
+				ContractID:         "0.0.1001",
+				CreatedContractIDs: []string{"0.0.1001"},
+				Address:            deployedAddress,
+				To:                 deployedAddress,
+			},
+			expectedContractAddress: &deployedAddress,
+		},
+		{
+			name: "hts token creation",
+			result: domain.ContractResult{
+				ContractID:         "0.0.359",
+				Address:            "0x0000000000000000000000000000000000000167",
+				To:                 "0x0000000000000000000000000000000000000167",
+				FunctionParameters: service.CreateFungibleTokenV1 + "0000000000000000000000000000000000000000000000000000000000000000",
+				CallResult:         "0x0000000000000000000000000000000000000000000000000000000000000016" + strings.Repeat("0", 24) + tokenAddress[2:],
+			},
+			expectedTo:              stringPtr("0x0000000000000000000000000000000000000167"),
+			expectedContractAddress: &tokenAddress,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logger, _ := zap.NewDevelopment()
+			mockClient := mocks.NewMockMirrorClient(ctrl)
+			cacheService := mocks.NewMockCacheService(ctrl)
+			s := service.NewEthService(nil, mockClient, nil, logger, nil, defaultChainId, cacheService, service.Config{})
+
+			result := tc.result
+			result.BlockHash = blockHash
+			result.BlockNumber = 123
+			result.From = from
+			result.Status = "0x1"
+			result.Bloom = "0x"
+
+			cacheService.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("not found")).AnyTimes()
+			cacheService.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			mockClient.EXPECT().GetContractResult(gomock.Any(), "0xabc").Return(&result, nil)
+			mockClient.EXPECT().GetContractById(gomock.Any(), from).Return(nil, errors.New("not a contract")).AnyTimes()
+			mockClient.EXPECT().GetAccountById(gomock.Any(), from).Return(&domain.AccountResponse{EvmAddress: from}, nil).AnyTimes()
+			mockClient.EXPECT().GetTokenById(gomock.Any(), gomock.Any()).Return(nil, errors.New("not a token")).AnyTimes()
+			if tc.expectedTo != nil {
+				mockClient.EXPECT().GetContractById(gomock.Any(), result.To).Return(&domain.ContractResponse{EvmAddress: result.To}, nil).AnyTimes()
+				mockClient.EXPECT().GetAccountById(gomock.Any(), result.To).Return(nil, errors.New("not an account")).AnyTimes()
+			}
+			if tc.resolveContract {
+				mockClient.EXPECT().GetContractById(gomock.Any(), result.ContractID).Return(&domain.ContractResponse{EvmAddress: deployedAddress}, nil)
+			}
+			mockClient.EXPECT().GetBlockByHashOrNumber(gomock.Any(), blockHash).Return(&domain.BlockResponse{
+				Hash:      blockHash,
+				Timestamp: domain.Timestamp{From: "123", To: "456"},
+			}, nil)
+			mockClient.EXPECT().GetNetworkFees(gomock.Any(), "123", "").Return(int64(1000000000), nil)
+
+			got, errRpc := s.GetTransactionReceipt(context.Background(), "0xabc")
+			assert.Nil(t, errRpc)
+
+			receipt, ok := got.(domain.TransactionReceipt)
+			if assert.True(t, ok) {
+				assert.Equal(t, tc.expectedTo, receipt.To)
+				assert.Equal(t, tc.expectedContractAddress, receipt.ContractAddress)
+			}
+		})
+	}
+}
+
+func TestGetTransactionReceipt_RevertReason(t *testing.T) {
+	blockHash := "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+	from := "0x00000000000000000000000000000000000004d2"
+	to := "0x00000000000000000000000000000000000007d1"
+	errorStringData := "0x08c379a000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000004626f6f6d00000000000000000000000000000000000000000000000000000000"
+
+	testCases := []struct {
+		name                 string
+		status               string
+		errorMessage         *string
+		callResult           string
+		expectedRevertReason string
+	}{
+		{
+			name:                 "revert payload",
+			status:               "0x0",
+			errorMessage:         &errorStringData,
+			expectedRevertReason: errorStringData,
+		},
+		{
+			name:                 "hedera status is hex encoded",
+			status:               "0x0",
+			errorMessage:         stringPtr("INSUFFICIENT_GAS"),
+			expectedRevertReason: "0x494e53554646494349454e545f474153",
+		},
+		{
+			name:                 "call result without error message",
+			status:               "0x0",
+			callResult:           errorStringData,
+			expectedRevertReason: errorStringData,
+		},
+		{
+			name:         "successful transaction",
+			status:       "0x1",
+			errorMessage: stringPtr(""),
+			callResult:   "0x0000000000000000000000000000000000000000000000000000000000000001",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockClient := mocks.NewMockMirrorClient(ctrl)
+			cacheService := mocks.NewMockCacheService(ctrl)
+			s := service.NewEthService(nil, mockClient, nil, zap.NewNop(), nil, defaultChainId, cacheService, service.Config{})
+
+			cacheService.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("not found")).AnyTimes()
+			cacheService.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			mockClient.EXPECT().GetContractResult(gomock.Any(), "0xabc").Return(&domain.ContractResult{
+				BlockHash:    blockHash,
+				BlockNumber:  123,
+				From:         from,
+				To:           to,
+				Status:       tc.status,
+				Bloom:        "0x",
+				ErrorMessage: tc.errorMessage,
+				CallResult:   tc.callResult,
+			}, nil)
+			for _, address := range []string{from, to} {
+				mockClient.EXPECT().GetContractById(gomock.Any(), address).Return(nil, errors.New("not a contract")).AnyTimes()
+				mockClient.EXPECT().GetAccountById(gomock.Any(), address).Return(&domain.AccountResponse{EvmAddress: address}, nil).AnyTimes()
+			}
+			mockClient.EXPECT().GetTokenById(gomock.Any(), gomock.Any()).Return(nil, errors.New("not a token")).AnyTimes()
+			mockClient.EXPECT().GetBlockByHashOrNumber(gomock.Any(), blockHash).Return(&domain.BlockResponse{
+				Hash:      blockHash,
+				Timestamp: domain.Timestamp{From: "123", To: "456"},
+			}, nil)
+			mockClient.EXPECT().GetNetworkFees(gomock.Any(), "123", "").Return(int64(1000000000), nil)
+
+			got, errRpc := s.GetTransactionReceipt(context.Background(), "0xabc")
+			require.Nil(t, errRpc)
+
+			receipt, ok := got.(domain.TransactionReceipt)
+			if assert.True(t, ok) {
+				assert.Equal(t, tc.expectedRevertReason, receipt.RevertReason)
+			}
+		})
+	}
+}
+
+func TestFeeHistory_PriorityFeeRewards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger, _ := zap.NewDevelopment()
+	cacheService := mocks.NewMockCacheService(ctrl)
+	commonService := mocks.NewMockCommonService(ctrl)
+
+	s := service.NewEthService(nil, nil, commonService, logger, nil, defaultChainId, cacheService, service.Config{MaxPriorityFeePerGas: 1000000000})
+
+	cacheService.EXPECT().Get(gomock.Any(), "eth_blockNumber", gomock.Any()).SetArg(2, "0x64").Return(nil)
+	commonService.EXPECT().GetBlockNumberByNumberOrTag(gomock.Any(), "latest").Return(int64(100), nil)
+	cacheService.EXPECT().Get(gomock.Any(), GetGasPrice, gomock.Any()).SetArg(2, "0xf4240").Return(nil)
+
+	result, errRpc := s.FeeHistory(context.Background(), "0x2", "latest", []string{"25", "75"})
+	assert.Nil(t, errRpc)
+
+	feeHistory, ok := result.(*domain.FeeHistory)
+	if assert.True(t, ok) {
+		assert.Equal(t, [][]string{{"0x3b9aca00", "0x3b9aca00"}, {"0x3b9aca00", "0x3b9aca00"}}, feeHistory.Reward)
+	}
+}
+
+func TestEthService_DelegatesToSubServices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := service.NewEthService(nil, mocks.NewMockMirrorClient(ctrl), nil, zap.NewNop(), nil, defaultChainId, mocks.NewMockCacheService(ctrl), service.Config{})
+
+	blocks := mocks.NewMockBlockService(ctrl)
+	transactions := mocks.NewMockTransactionService(ctrl)
+	accounts := mocks.NewMockAccountService(ctrl)
+	fees := mocks.NewMockFeeService(ctrl)
+	s.BlockService = blocks
+	s.TransactionService = transactions
+	s.AccountService = accounts
+	s.FeeService = fees
+
+	ctx := context.Background()
+	blocks.EXPECT().GetBlockNumber(ctx).Return("0x64", nil)
+	transactions.EXPECT().Call(ctx, gomock.Any(), "latest").Return("0x01", nil)
+	accounts.EXPECT().GetBalance(ctx, "0x742d35Cc6634C0532925a3b844Bc454e4438f44e", "latest").Return("0x0", nil)
+	fees.EXPECT().GetGasPrice(ctx).Return(nil, domain.NewRPCError(domain.ServerError, "Failed to fetch gas price"))
+
+	var ethServicer service.EthServicer = s
+
+	blockNumber, errRpc := ethServicer.GetBlockNumber(ctx)
+	assert.Nil(t, errRpc)
+	assert.Equal(t, "0x64", blockNumber)
+
+	result, errRpc := ethServicer.Call(ctx, map[string]interface{}{"data": "0x18160ddd"}, "latest")
+	assert.Nil(t, errRpc)
+	assert.Equal(t, "0x01", result)
+
+	balance, errRpc := ethServicer.GetBalance(ctx, "0x742d35Cc6634C0532925a3b844Bc454e4438f44e", "latest")
+	assert.Nil(t, errRpc)
+	assert.Equal(t, "0x0", balance)
+
+	_, errRpc = ethServicer.GetGasPrice(ctx)
+	assert.Equal(t, domain.ServerError, errRpc.Code)
+
+	// Methods of the facade itself are not delegated
+	chainId, errRpc := ethServicer.GetChainId()
+	assert.Nil(t, errRpc)
+	assert.Equal(t, defaultChainId, chainId)
 }
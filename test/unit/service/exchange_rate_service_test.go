@@ -0,0 +1,72 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	"github.com/LimeChain/Hederium/internal/service"
+	"github.com/LimeChain/Hederium/test/unit/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func setupExchangeRateTest(t *testing.T) (*mocks.MockMirrorClient, *mocks.MockCacheService, service.ExchangeRateServicer) {
+	ctrl := gomock.NewController(t)
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	cacheService := mocks.NewMockCacheService(ctrl)
+	return mockClient, cacheService, service.NewExchangeRateService(mockClient, cacheService, zap.NewNop())
+}
+
+func TestGetExchangeRate(t *testing.T) {
+	mockClient, cacheService, exchangeRateService := setupExchangeRateTest(t)
+
+	expiration := time.Now().Add(30 * time.Minute).Unix()
+	rate := domain.Rate{HbarEquivalent: 30000, CentEquivalent: 150000, ExpirationTime: expiration}
+	cacheService.EXPECT().Get(gomock.Any(), service.GetExchangeRate, gomock.Any()).Return(errors.New("cache miss"))
+	mockClient.EXPECT().GetExchangeRate(gomock.Any()).Return(&domain.ExchangeRateResponse{CurrentRate: rate}, nil)
+	// Cached until the rate expires
+	cacheService.EXPECT().Set(gomock.Any(), service.GetExchangeRate, rate, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ any, ttl time.Duration) error {
+			assert.InDelta(t, 30*time.Minute, ttl, float64(time.Minute))
+			return nil
+		})
+
+	result, errRpc := exchangeRateService.GetExchangeRate(context.Background())
+
+	require.Nil(t, errRpc)
+	assert.Equal(t, &domain.ExchangeRate{HbarEquivalent: 30000, CentEquivalent: 150000, ExpirationTime: expiration, UsdPerHbar: 0.05}, result)
+}
+
+func TestTinybarsToUSD_Cached(t *testing.T) {
+	_, cacheService, exchangeRateService := setupExchangeRateTest(t)
+
+	cacheService.EXPECT().Get(gomock.Any(), service.GetExchangeRate, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, out any) error {
+			*out.(*domain.Rate) = domain.Rate{HbarEquivalent: 30000, CentEquivalent: 150000}
+			return nil
+		})
+
+	usd, err := exchangeRateService.TinybarsToUSD(context.Background(), 20*domain.TinybarsPerHbar)
+
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0, usd, 1e-9)
+}
+
+func TestGetExchangeRate_MirrorNodeFailure(t *testing.T) {
+	mockClient, cacheService, exchangeRateService := setupExchangeRateTest(t)
+
+	cacheService.EXPECT().Get(gomock.Any(), service.GetExchangeRate, gomock.Any()).Return(errors.New("cache miss"))
+	mockClient.EXPECT().GetExchangeRate(gomock.Any()).Return(nil, hedera.ErrUpstreamUnavailable)
+
+	result, errRpc := exchangeRateService.GetExchangeRate(context.Background())
+
+	assert.Nil(t, result)
+	require.NotNil(t, errRpc)
+	assert.Equal(t, domain.NewMirrorNodeUpstreamFailError().Code, errRpc.Code)
+}
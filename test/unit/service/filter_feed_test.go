@@ -0,0 +1,98 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
+	"github.com/LimeChain/Hederium/internal/service"
+	"github.com/LimeChain/Hederium/test/unit/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func feedTimestamp(number int) domain.Timestamp {
+	return domain.Timestamp{From: fmt.Sprintf("%d.000000000", number), To: fmt.Sprintf("%d.999999999", number)}
+}
+
+func TestGetFilterChanges_FromBlockPoller(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	mockCommon := mocks.NewMockCommonService(ctrl)
+	cacheService := cache.NewMemoryCache(time.Minute, time.Minute)
+
+	var head atomic.Int64
+	head.Store(100)
+	mockClient.EXPECT().GetLatestBlock(gomock.Any()).DoAndReturn(func(ctx context.Context) (map[string]interface{}, error) {
+		return map[string]interface{}{"number": float64(head.Load())}, nil
+	}).AnyTimes()
+	mockClient.EXPECT().GetBlockByHashOrNumber(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, number string) (*domain.BlockResponse, error) {
+		n, err := strconv.Atoi(number)
+		require.NoError(t, err)
+		return &domain.BlockResponse{Number: n, Hash: fmt.Sprintf("0xblock%d", n), Timestamp: feedTimestamp(n)}, nil
+	}).AnyTimes()
+	mockClient.EXPECT().GetContractResults(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, timestamp domain.Timestamp) ([]domain.ContractResult, error) {
+		n := strings.Split(timestamp.From, ".")[0]
+		return []domain.ContractResult{{Hash: "0xtx" + n, Result: "SUCCESS"}}, nil
+	}).AnyTimes()
+	// The blocks the poller skips are fetched once, for the feed; filters never
+	// go to the mirror node
+	mockClient.EXPECT().GetBlocks(gomock.Any(), "101").Return([]map[string]interface{}{
+		{"number": float64(102), "hash": "0xblock102", "timestamp": map[string]interface{}{"from": feedTimestamp(102).From, "to": feedTimestamp(102).To}},
+		{"number": float64(103), "hash": "0xblock103", "timestamp": map[string]interface{}{"from": feedTimestamp(103).From, "to": feedTimestamp(103).To}},
+		{"number": float64(104), "hash": "0xblock104", "timestamp": map[string]interface{}{"from": feedTimestamp(104).From, "to": feedTimestamp(104).To}},
+	}, nil).Times(1)
+	mockCommon.EXPECT().GetBlockNumberByNumberOrTag(gomock.Any(), "latest").Return(int64(100), nil).AnyTimes()
+
+	poller := service.NewBlockPoller(mockClient, zap.NewNop(), 5*time.Millisecond)
+	filterService := service.NewFilterService(mockClient, cacheService, zap.NewNop(), mockCommon, true, 0)
+	filterService.FollowBlockPoller(poller)
+	// Handlers run in order, so blocks seen here are in the feed already
+	var seen atomic.Int64
+	poller.OnNewBlock(func(ctx context.Context, block *domain.BlockResponse) { seen.Store(int64(block.Number)) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go poller.Run(ctx)
+	require.Eventually(t, func() bool { return seen.Load() == 100 }, time.Second, time.Millisecond)
+
+	blockFilter, errRpc := filterService.NewBlockFilter(ctx)
+	require.Nil(t, errRpc)
+	transactionFilter, errRpc := filterService.NewPendingTransactionFilter(ctx)
+	require.Nil(t, errRpc)
+
+	changes, errRpc := filterService.GetFilterChanges(ctx, *blockFilter)
+	require.Nil(t, errRpc)
+	assert.Equal(t, []string{}, changes)
+
+	head.Store(101)
+	require.Eventually(t, func() bool { return seen.Load() == 101 }, time.Second, time.Millisecond)
+	changes, errRpc = filterService.GetFilterChanges(ctx, *blockFilter)
+	require.Nil(t, errRpc)
+	assert.Equal(t, []string{"0xblock101"}, changes)
+
+	// Blocks 102 and 103 close between two polls
+	head.Store(104)
+	require.Eventually(t, func() bool { return seen.Load() == 104 }, time.Second, time.Millisecond)
+	changes, errRpc = filterService.GetFilterChanges(ctx, *blockFilter)
+	require.Nil(t, errRpc)
+	assert.Equal(t, []string{"0xblock102", "0xblock103", "0xblock104"}, changes)
+
+	changes, errRpc = filterService.GetFilterChanges(ctx, *blockFilter)
+	require.Nil(t, errRpc)
+	assert.Equal(t, []string{}, changes)
+
+	changes, errRpc = filterService.GetFilterChanges(ctx, *transactionFilter)
+	require.Nil(t, errRpc)
+	assert.Equal(t, []string{"0xtx101", "0xtx102", "0xtx103", "0xtx104"}, changes)
+}
@@ -0,0 +1,123 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	"github.com/LimeChain/Hederium/internal/service"
+	"github.com/LimeChain/Hederium/test/unit/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	tokenHolder  = "0x05fba803be258049a27b820088bab1cad2058871"
+	tokenAddress = "0x00000000000000000000000000000000000003f1"
+)
+
+func setupHederaTest(t *testing.T) (*mocks.MockMirrorClient, service.HederaServicer) {
+	ctrl := gomock.NewController(t)
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	return mockClient, service.NewHederaService(mockClient, zap.NewNop())
+}
+
+func TestGetTokenBalances(t *testing.T) {
+	mockClient, hederaService := setupHederaTest(t)
+
+	mockClient.EXPECT().GetTokenRelationships(gomock.Any(), tokenHolder).Return([]domain.TokenRelationship{
+		{TokenId: "0.0.1009", Balance: 2500, Decimals: 2, FreezeStatus: "UNFROZEN", KycStatus: "NOT_APPLICABLE", AutomaticAssociation: true},
+		{TokenId: "0.0.1010", Balance: 3, FreezeStatus: "NOT_APPLICABLE", KycStatus: "GRANTED"},
+	}, nil)
+
+	balances, errRpc := hederaService.GetTokenBalances(context.Background(), tokenHolder)
+
+	require.Nil(t, errRpc)
+	assert.Equal(t, []domain.TokenBalance{
+		{Token: tokenAddress, TokenID: "0.0.1009", Balance: "0x9c4", Decimals: "0x2", AutomaticAssociation: true, FreezeStatus: "UNFROZEN", KycStatus: "NOT_APPLICABLE"},
+		{Token: "0x00000000000000000000000000000000000003f2", TokenID: "0.0.1010", Balance: "0x3", Decimals: "0x0", FreezeStatus: "NOT_APPLICABLE", KycStatus: "GRANTED"},
+	}, balances)
+}
+
+func TestGetTokenBalances_UnknownAccount(t *testing.T) {
+	mockClient, hederaService := setupHederaTest(t)
+
+	mockClient.EXPECT().GetTokenRelationships(gomock.Any(), tokenHolder).Return(nil, fmt.Errorf("account: %w", hedera.ErrNotFound))
+
+	balances, errRpc := hederaService.GetTokenBalances(context.Background(), tokenHolder)
+
+	assert.Nil(t, errRpc)
+	assert.NotNil(t, balances)
+	assert.Empty(t, balances)
+}
+
+func TestGetTokenBalances_MirrorNodeFailure(t *testing.T) {
+	mockClient, hederaService := setupHederaTest(t)
+
+	mockClient.EXPECT().GetTokenRelationships(gomock.Any(), tokenHolder).Return(nil, hedera.ErrUpstreamUnavailable)
+
+	balances, errRpc := hederaService.GetTokenBalances(context.Background(), tokenHolder)
+
+	assert.Nil(t, balances)
+	require.NotNil(t, errRpc)
+	assert.Equal(t, domain.NewMirrorNodeUpstreamFailError().Code, errRpc.Code)
+}
+
+func TestGetTokenInfo(t *testing.T) {
+	mockClient, hederaService := setupHederaTest(t)
+
+	mockClient.EXPECT().GetTokenById(gomock.Any(), "0.0.1009").Return(&domain.TokenResponse{
+		TokenId:           "0.0.1009",
+		Name:              "Test Token",
+		Symbol:            "TST",
+		Decimals:          "8",
+		TotalSupply:       "100000000000",
+		MaxSupply:         "0",
+		Type:              "FUNGIBLE_COMMON",
+		SupplyType:        "INFINITE",
+		TreasuryAccountId: "0.0.1002",
+		PauseStatus:       "UNPAUSED",
+	}, nil)
+
+	info, errRpc := hederaService.GetTokenInfo(context.Background(), tokenAddress)
+
+	require.Nil(t, errRpc)
+	assert.Equal(t, &domain.TokenInfo{
+		Address:           tokenAddress,
+		TokenID:           "0.0.1009",
+		Name:              "Test Token",
+		Symbol:            "TST",
+		Decimals:          "0x8",
+		TotalSupply:       "0x174876e800",
+		MaxSupply:         "0x0",
+		Type:              "FUNGIBLE_COMMON",
+		SupplyType:        "INFINITE",
+		TreasuryAccountID: "0.0.1002",
+		PauseStatus:       "UNPAUSED",
+	}, info)
+}
+
+func TestGetTokenInfo_UnknownToken(t *testing.T) {
+	mockClient, hederaService := setupHederaTest(t)
+
+	mockClient.EXPECT().GetTokenById(gomock.Any(), "0.0.1009").Return(nil, hedera.ErrNotFound)
+
+	info, errRpc := hederaService.GetTokenInfo(context.Background(), tokenAddress)
+
+	assert.Nil(t, errRpc)
+	assert.Nil(t, info)
+}
+
+func TestGetTokenInfo_NotATokenAddress(t *testing.T) {
+	_, hederaService := setupHederaTest(t)
+
+	info, errRpc := hederaService.GetTokenInfo(context.Background(), tokenHolder)
+
+	assert.Nil(t, info)
+	require.NotNil(t, errRpc)
+	assert.Equal(t, domain.InvalidParams, errRpc.Code)
+}
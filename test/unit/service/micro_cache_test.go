@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func counterFetch(calls *atomic.Int64) func(context.Context) (interface{}, *domain.RPCError) {
+	return func(context.Context) (interface{}, *domain.RPCError) {
+		return fmt.Sprintf("0x%x", calls.Add(1)), nil
+	}
+}
+
+func TestMicroCache_ServesFreshValue(t *testing.T) {
+	cache := service.NewMicroCache(time.Minute, time.Minute)
+	var calls atomic.Int64
+
+	for range 3 {
+		value, errRpc := cache.Get(context.Background(), counterFetch(&calls))
+		assert.Nil(t, errRpc)
+		assert.Equal(t, "0x1", value)
+	}
+	assert.Equal(t, int64(1), calls.Load())
+}
+
+func TestMicroCache_StaleWhileRevalidate(t *testing.T) {
+	cache := service.NewMicroCache(20*time.Millisecond, time.Minute)
+	var calls atomic.Int64
+
+	value, _ := cache.Get(context.Background(), counterFetch(&calls))
+	assert.Equal(t, "0x1", value)
+
+	time.Sleep(30 * time.Millisecond)
+
+	// The stale value is returned at once and refreshed in the background
+	refreshed := make(chan struct{})
+	value, errRpc := cache.Get(context.Background(), func(ctx context.Context) (interface{}, *domain.RPCError) {
+		defer close(refreshed)
+		return counterFetch(&calls)(ctx)
+	})
+	assert.Nil(t, errRpc)
+	assert.Equal(t, "0x1", value)
+
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("stale value was not refreshed")
+	}
+	require.Eventually(t, func() bool {
+		value, _ := cache.Get(context.Background(), counterFetch(&calls))
+		return value == "0x2"
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestMicroCache_ExpiredValueIsFetched(t *testing.T) {
+	cache := service.NewMicroCache(10*time.Millisecond, 10*time.Millisecond)
+	var calls atomic.Int64
+
+	value, _ := cache.Get(context.Background(), counterFetch(&calls))
+	assert.Equal(t, "0x1", value)
+
+	time.Sleep(30 * time.Millisecond)
+
+	value, _ = cache.Get(context.Background(), counterFetch(&calls))
+	assert.Equal(t, "0x2", value)
+}
+
+func TestMicroCache_ConcurrentMissesShareFetch(t *testing.T) {
+	cache := service.NewMicroCache(time.Minute, time.Minute)
+	var calls atomic.Int64
+	release := make(chan struct{})
+
+	fetch := func(ctx context.Context) (interface{}, *domain.RPCError) {
+		<-release
+		return counterFetch(&calls)(ctx)
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, errRpc := cache.Get(context.Background(), fetch)
+			assert.Nil(t, errRpc)
+			assert.Equal(t, "0x1", value)
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int64(1), calls.Load())
+}
+
+func TestMicroCache_ErrorsAreNotCached(t *testing.T) {
+	cache := service.NewMicroCache(time.Minute, time.Minute)
+
+	_, errRpc := cache.Get(context.Background(), func(context.Context) (interface{}, *domain.RPCError) {
+		return nil, domain.NewMirrorNodeUpstreamFailError()
+	})
+	assert.Equal(t, domain.NewMirrorNodeUpstreamFailError(), errRpc)
+
+	var calls atomic.Int64
+	value, errRpc := cache.Get(context.Background(), counterFetch(&calls))
+	assert.Nil(t, errRpc)
+	assert.Equal(t, "0x1", value)
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const queueSender = "0x96216849c49358B10257cb55b28eA603c874b05E"
+
+func accountNonce(nonce uint64) func() (uint64, error) {
+	return func() (uint64, error) { return nonce, nil }
+}
+
+func TestNonceQueue_OrdersOutOfOrderNonces(t *testing.T) {
+	queue := service.NewNonceQueue(time.Minute)
+
+	submitted := make(chan uint64, 3)
+	done := make(chan struct{})
+	for _, nonce := range []uint64{7, 6} {
+		go func(nonce uint64) {
+			release, err := queue.Acquire(context.Background(), queueSender, nonce, accountNonce(5))
+			if !assert.NoError(t, err) {
+				return
+			}
+			submitted <- nonce
+			release(true)
+			done <- struct{}{}
+		}(nonce)
+	}
+
+	// Neither 6 nor 7 may go before 5
+	select {
+	case nonce := <-submitted:
+		t.Fatalf("nonce %d was submitted before nonce 5", nonce)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release, err := queue.Acquire(context.Background(), queueSender, 5, accountNonce(5))
+	require.NoError(t, err)
+	submitted <- 5
+	release(true)
+
+	for range 2 {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("queued nonces were not submitted")
+		}
+	}
+	close(submitted)
+
+	var order []uint64
+	for nonce := range submitted {
+		order = append(order, nonce)
+	}
+	assert.Equal(t, []uint64{5, 6, 7}, order)
+}
+
+func TestNonceQueue_GapTimeout(t *testing.T) {
+	queue := service.NewNonceQueue(50 * time.Millisecond)
+
+	start := time.Now()
+	release, err := queue.Acquire(context.Background(), queueSender, 9, accountNonce(5))
+	require.NoError(t, err)
+	release(true)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	// The sender now continues from the submitted nonce
+	start = time.Now()
+	release, err = queue.Acquire(context.Background(), queueSender, 10, accountNonce(0))
+	require.NoError(t, err)
+	release(true)
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestNonceQueue_FailedSubmissionKeepsNonce(t *testing.T) {
+	queue := service.NewNonceQueue(50 * time.Millisecond)
+
+	release, err := queue.Acquire(context.Background(), queueSender, 5, accountNonce(5))
+	require.NoError(t, err)
+	release(false)
+
+	// Nonce 5 was not accepted, so a retry of it goes through at once
+	start := time.Now()
+	release, err = queue.Acquire(context.Background(), queueSender, 5, accountNonce(5))
+	require.NoError(t, err)
+	release(true)
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestNonceQueue_ContextCanceled(t *testing.T) {
+	queue := service.NewNonceQueue(time.Minute)
+
+	release, err := queue.Acquire(context.Background(), queueSender, 5, accountNonce(5))
+	require.NoError(t, err)
+	defer release(true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = queue.Acquire(ctx, queueSender, 6, accountNonce(5))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestNonceQueue_SendersAreIndependent(t *testing.T) {
+	queue := service.NewNonceQueue(time.Minute)
+
+	release, err := queue.Acquire(context.Background(), queueSender, 5, accountNonce(5))
+	require.NoError(t, err)
+	defer release(true)
+
+	// Senders the mirror node has no nonce for start from their first transaction
+	other, err := queue.Acquire(context.Background(), "0x05fba803be258049a27b820088bab1cad2058871", 3, func() (uint64, error) {
+		return 0, errors.New("account not found")
+	})
+	require.NoError(t, err)
+	other(true)
+}
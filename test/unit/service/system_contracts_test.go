@@ -0,0 +1,153 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	"github.com/LimeChain/Hederium/internal/service"
+	"github.com/LimeChain/Hederium/test/unit/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	htsAddress          = "0x0000000000000000000000000000000000000167"
+	exchangeRateAddress = "0x0000000000000000000000000000000000000168"
+	// htsTokenAddress is the long-zero address of token 0.0.1234.
+	htsTokenAddress = "0x00000000000000000000000000000000000004d2"
+)
+
+// abiCall encodes a call of selector with address arguments.
+func abiCall(selector string, addresses ...string) string {
+	data := selector
+	for _, address := range addresses {
+		data += strings.Repeat("0", 24) + strings.TrimPrefix(address, "0x")
+	}
+	return data
+}
+
+// abiWords encodes values as consecutive ABI words.
+func abiWords(values ...int64) string {
+	encoded := "0x"
+	for _, value := range values {
+		encoded += fmt.Sprintf("%064x", value)
+	}
+	return encoded
+}
+
+func newSystemContractService(t *testing.T) (*service.EthService, *mocks.MockMirrorClient) {
+	ctrl := gomock.NewController(t)
+	mockClient := mocks.NewMockMirrorClient(ctrl)
+	s := service.NewEthService(nil, mockClient, nil, zap.NewNop(), nil, defaultChainId, mocks.NewMockCacheService(ctrl), service.Config{})
+	return s, mockClient
+}
+
+func TestCall_HTSIsToken(t *testing.T) {
+	s, mockClient := newSystemContractService(t)
+	call := map[string]interface{}{"to": htsAddress, "data": abiCall("0x19f37361", htsTokenAddress)}
+
+	mockClient.EXPECT().GetTokenById(gomock.Any(), "0.0.1234").Return(&domain.TokenResponse{TokenId: "0.0.1234"}, nil)
+	result, errRpc := s.Call(context.Background(), call, "latest")
+	require.Nil(t, errRpc)
+	assert.Equal(t, abiWords(22, 1), result)
+
+	mockClient.EXPECT().GetTokenById(gomock.Any(), "0.0.1234").Return(nil, fmt.Errorf("token: %w", hedera.ErrNotFound))
+	result, errRpc = s.Call(context.Background(), call, nil)
+	require.Nil(t, errRpc)
+	assert.Equal(t, abiWords(22, 0), result)
+
+	// Older state is left to the mirror node
+	mockClient.EXPECT().PostCall(gomock.Any(), gomock.Any()).Return(abiWords(22, 1), nil)
+	result, errRpc = s.Call(context.Background(), call, "0x10")
+	require.Nil(t, errRpc)
+	assert.Equal(t, abiWords(22, 1), result)
+}
+
+func TestCall_HTSGetTokenType(t *testing.T) {
+	s, mockClient := newSystemContractService(t)
+
+	mockClient.EXPECT().GetTokenById(gomock.Any(), "0.0.1234").Return(&domain.TokenResponse{TokenId: "0.0.1234", Type: "NON_FUNGIBLE_UNIQUE"}, nil)
+	result, errRpc := s.Call(context.Background(), map[string]interface{}{"to": htsAddress, "input": abiCall("0x93272baf", htsTokenAddress)}, "latest")
+	require.Nil(t, errRpc)
+	assert.Equal(t, abiWords(22, 1), result)
+}
+
+func TestCall_HTSIsFrozen(t *testing.T) {
+	s, mockClient := newSystemContractService(t)
+	account := "0x00000000000000000000000000000000000003e9"
+
+	mockClient.EXPECT().GetTokenRelationships(gomock.Any(), account).Return([]domain.TokenRelationship{
+		{TokenId: "0.0.1000", FreezeStatus: "UNFROZEN"},
+		{TokenId: "0.0.1234", FreezeStatus: "FROZEN"},
+	}, nil)
+	result, errRpc := s.Call(context.Background(), map[string]interface{}{"to": htsAddress, "data": abiCall("0x46de0fb1", htsTokenAddress, account)}, "latest")
+	require.Nil(t, errRpc)
+	assert.Equal(t, abiWords(22, 1), result)
+}
+
+func TestCall_HTSAllowance(t *testing.T) {
+	s, mockClient := newSystemContractService(t)
+	owner := "0x1111111111111111111111111111111111111111"
+	spender := "0x2222222222222222222222222222222222222222"
+	call := map[string]interface{}{"to": htsAddress, "data": abiCall("0x927da105", htsTokenAddress, owner, spender)}
+
+	mockClient.EXPECT().GetAccountById(gomock.Any(), spender).Return(&domain.AccountResponse{Account: "0.0.1002"}, nil).Times(2)
+	mockClient.EXPECT().GetTokenAllowance(gomock.Any(), owner, "0.0.1002", "0.0.1234").Return(&domain.TokenAllowance{Amount: 500, AmountGranted: 1000}, nil)
+	result, errRpc := s.Call(context.Background(), call, "latest")
+	require.Nil(t, errRpc)
+	assert.Equal(t, abiWords(22, 500), result)
+
+	// No allowance granted
+	mockClient.EXPECT().GetTokenAllowance(gomock.Any(), owner, "0.0.1002", "0.0.1234").Return(nil, nil)
+	result, errRpc = s.Call(context.Background(), call, "latest")
+	require.Nil(t, errRpc)
+	assert.Equal(t, abiWords(22, 0), result)
+}
+
+func TestCall_ExchangeRateSystemContract(t *testing.T) {
+	s, mockClient := newSystemContractService(t)
+	mockClient.EXPECT().GetExchangeRate(gomock.Any()).Return(&domain.ExchangeRateResponse{
+		CurrentRate: domain.Rate{CentEquivalent: 12, HbarEquivalent: 1},
+	}, nil).Times(2)
+
+	// 1200 tinycents are 100 tinybars at 12 cents per HBAR
+	result, errRpc := s.Call(context.Background(), map[string]interface{}{"to": exchangeRateAddress, "data": "0x2e3cff6a" + strings.TrimPrefix(abiWords(1200), "0x")}, "latest")
+	require.Nil(t, errRpc)
+	assert.Equal(t, abiWords(100), result)
+
+	result, errRpc = s.Call(context.Background(), map[string]interface{}{"to": exchangeRateAddress, "data": "0x43a88229" + strings.TrimPrefix(abiWords(100), "0x")}, "latest")
+	require.Nil(t, errRpc)
+	assert.Equal(t, abiWords(1200), result)
+
+	// Other selectors fail without reaching the mirror node
+	_, errRpc = s.Call(context.Background(), map[string]interface{}{"to": exchangeRateAddress, "data": "0x12345678"}, "latest")
+	require.NotNil(t, errRpc)
+	assert.Equal(t, domain.ServerError, errRpc.Code)
+	assert.Equal(t, "Cannot call function 0x12345678 of the exchange rate system contract at "+exchangeRateAddress+": the system contract has no such function", errRpc.Message)
+}
+
+func TestCall_SystemContractErrors(t *testing.T) {
+	s, mockClient := newSystemContractService(t)
+
+	_, errRpc := s.Call(context.Background(), map[string]interface{}{"to": htsAddress, "data": "0x"}, "latest")
+	require.NotNil(t, errRpc)
+	assert.Equal(t, domain.InvalidParams, errRpc.Code)
+
+	// Functions the mirror node cannot simulate are named in the error
+	mockClient.EXPECT().PostCall(gomock.Any(), gomock.Any()).Return(nil, &hedera.ContractCallError{StatusCode: 501, Message: "Not implemented"})
+	_, errRpc = s.Call(context.Background(), map[string]interface{}{"to": htsAddress, "data": abiCall("0xabcdef12", htsTokenAddress)}, "latest")
+	require.NotNil(t, errRpc)
+	assert.Equal(t, "Cannot call function 0xabcdef12 of the HTS system contract at "+htsAddress+": the mirror node cannot simulate it: Not implemented", errRpc.Message)
+
+	// Reverts are reported as such
+	mockClient.EXPECT().PostCall(gomock.Any(), gomock.Any()).Return(nil, &hedera.ContractCallError{StatusCode: 400, Message: "CONTRACT_REVERT_EXECUTED"})
+	_, errRpc = s.Call(context.Background(), map[string]interface{}{"to": htsAddress, "data": abiCall("0xabcdef12", htsTokenAddress)}, "latest")
+	require.NotNil(t, errRpc)
+	assert.Equal(t, domain.ContractRevert, errRpc.Code)
+}
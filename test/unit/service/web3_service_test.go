@@ -3,6 +3,7 @@ package service_test
 import (
 	"testing"
 
+	"github.com/LimeChain/Hederium/internal/domain"
 	"github.com/LimeChain/Hederium/internal/service"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
@@ -22,8 +23,8 @@ func TestWeb3Service_ClientVersion_WithVersion(t *testing.T) {
 	// When calling ClientVersion
 	result := w.ClientVersion()
 
-	// Then it should return "relay/1.0.0"
-	assert.Equal(t, "relay/1.0.0", result)
+	// Then it should return "hederium/1.0.0"
+	assert.Equal(t, "hederium/1.0.0", result)
 }
 
 func TestWeb3Service_ClientVersion_NoVersion(t *testing.T) {
@@ -39,6 +40,22 @@ func TestWeb3Service_ClientVersion_NoVersion(t *testing.T) {
 	// When calling ClientVersion
 	result := w.ClientVersion()
 
-	// Then it should return "relay/unknown"
-	assert.Equal(t, "relay/unknown", result)
+	// Then it should return "hederium/unknown"
+	assert.Equal(t, "hederium/unknown", result)
+}
+
+func TestWeb3Service_Sha3(t *testing.T) {
+	w := service.NewWeb3Service(zap.NewNop(), "1.0.0")
+
+	result, errRpc := w.Sha3("0x68656c6c6f20776f726c64")
+	assert.Nil(t, errRpc)
+	assert.Equal(t, "0x47173285a8d7341e5e972fc677286384f802f8ef42a5ec5f03bbfa254cb01fad", result)
+
+	result, errRpc = w.Sha3("0x")
+	assert.Nil(t, errRpc)
+	assert.Equal(t, "0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470", result)
+
+	_, errRpc = w.Sha3("0xzz")
+	assert.NotNil(t, errRpc)
+	assert.Equal(t, domain.InvalidParams, errRpc.Code)
 }
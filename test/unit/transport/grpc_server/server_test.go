@@ -0,0 +1,272 @@
+package grpc_server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	"github.com/LimeChain/Hederium/internal/transport/grpc_server"
+	"github.com/LimeChain/Hederium/internal/transport/rpc"
+	"github.com/LimeChain/Hederium/pkg/relaypb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeHandler answers every call with the JSON result or the error set for
+// its method, recording the requests it got.
+type fakeHandler struct {
+	results  map[string]string
+	errors   map[string]*domain.RPCError
+	requests []*rpc.JSONRPCRequest
+	apiKeys  []string
+}
+
+func (h *fakeHandler) HandleRequest(ctx context.Context, req *rpc.JSONRPCRequest) *rpc.JSONRPCResponse {
+	h.requests = append(h.requests, req)
+	apiKey, _ := limiter.APIKeyFromContext(ctx)
+	h.apiKeys = append(h.apiKeys, apiKey)
+
+	resp := &rpc.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID}
+	if rpcErr, ok := h.errors[req.Method]; ok {
+		resp.Error = rpcErr
+		return resp
+	}
+	var result interface{}
+	if err := json.Unmarshal([]byte(h.results[req.Method]), &result); err == nil {
+		resp.Result = result
+	}
+	return resp
+}
+
+func newRelayClient(t *testing.T, handler rpc.RPCHandler, enforceAPIKey bool) relaypb.RelayClient {
+	t.Helper()
+	apiKeyStore := limiter.NewAPIKeyStore([]interface{}{
+		map[interface{}]interface{}{"key": "FREE-KEY", "tier": "free"},
+	})
+	tieredLimiter := limiter.NewTieredLimiter(map[string]interface{}{
+		"free": map[interface{}]interface{}{"requestsPerMinute": 1, "hbarLimit": 1},
+	}, 10, 0)
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc_server.NewServer(handler, zap.NewNop(), apiKeyStore, tieredLimiter, enforceAPIKey, nil)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return relaypb.NewRelayClient(conn)
+}
+
+func TestRelay_Quantities(t *testing.T) {
+	handler := &fakeHandler{results: map[string]string{
+		"eth_chainId":    `"0x128"`,
+		"eth_getBalance": `"0xde0b6b3a7640000"`,
+	}}
+	client := newRelayClient(t, handler, false)
+
+	chainID, err := client.ChainId(context.Background(), &relaypb.ChainIdRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "0x128", chainID.GetValue())
+
+	balance, err := client.GetBalance(context.Background(), &relaypb.AccountRequest{Address: "0x00000000000000000000000000000000000003e8"})
+	require.NoError(t, err)
+	assert.Equal(t, "0xde0b6b3a7640000", balance.GetValue())
+
+	require.Len(t, handler.requests, 2)
+	assert.Equal(t, []interface{}{}, handler.requests[0].Params)
+	assert.Equal(t, []interface{}{"0x00000000000000000000000000000000000003e8", "latest"}, handler.requests[1].Params,
+		"an empty block defaults to latest")
+}
+
+func TestRelay_GetBlockByNumber(t *testing.T) {
+	handler := &fakeHandler{results: map[string]string{
+		"eth_getBlockByNumber": `{"number":"0x10","hash":"0xaa","gasUsed":"0x5208","transactions":["0x01","0x02"],"uncles":[],"baseFeePerGas":"0x0"}`,
+	}}
+	client := newRelayClient(t, handler, false)
+
+	resp, err := client.GetBlockByNumber(context.Background(), &relaypb.GetBlockByNumberRequest{Block: "0x10"})
+	require.NoError(t, err)
+	require.NotNil(t, resp.GetBlock())
+	assert.Equal(t, "0x10", resp.GetBlock().GetNumber())
+	assert.Equal(t, "0x5208", resp.GetBlock().GetGasUsed())
+	assert.Equal(t, []string{"0x01", "0x02"}, resp.GetBlock().GetTransactionHashes())
+	assert.Empty(t, resp.GetBlock().GetTransactions())
+	assert.Equal(t, []interface{}{"0x10", false}, handler.requests[0].Params)
+}
+
+func TestRelay_GetBlockByNumber_FullTransactions(t *testing.T) {
+	handler := &fakeHandler{results: map[string]string{
+		"eth_getBlockByNumber": `{"number":"0x10","transactions":[{"hash":"0x01","to":null,"accessList":[{"address":"0xbb","storageKeys":["0x00"]}],"yParity":"0x1"}]}`,
+	}}
+	client := newRelayClient(t, handler, false)
+
+	resp, err := client.GetBlockByNumber(context.Background(), &relaypb.GetBlockByNumberRequest{Block: "latest", FullTransactions: true})
+	require.NoError(t, err)
+	require.Len(t, resp.GetBlock().GetTransactions(), 1)
+	tx := resp.GetBlock().GetTransactions()[0]
+	assert.Equal(t, "0x01", tx.GetHash())
+	assert.Empty(t, tx.GetTo())
+	require.Len(t, tx.GetAccessList(), 1)
+	assert.Equal(t, []string{"0x00"}, tx.GetAccessList()[0].GetStorageKeys())
+}
+
+func TestRelay_NotFound(t *testing.T) {
+	handler := &fakeHandler{results: map[string]string{
+		"eth_getBlockByHash":        `null`,
+		"eth_getTransactionReceipt": `null`,
+	}}
+	client := newRelayClient(t, handler, false)
+
+	block, err := client.GetBlockByHash(context.Background(), &relaypb.GetBlockByHashRequest{Hash: "0xaa"})
+	require.NoError(t, err)
+	assert.Nil(t, block.GetBlock())
+
+	receipt, err := client.GetTransactionReceipt(context.Background(), &relaypb.TransactionHashRequest{Hash: "0xbb"})
+	require.NoError(t, err)
+	assert.Nil(t, receipt.GetReceipt())
+}
+
+func TestRelay_GetLogs(t *testing.T) {
+	handler := &fakeHandler{results: map[string]string{
+		"eth_getLogs": `[{"address":"0xcc","logIndex":"0x0","removed":false,"topics":["0x01"]}]`,
+	}}
+	client := newRelayClient(t, handler, false)
+
+	resp, err := client.GetLogs(context.Background(), &relaypb.GetLogsRequest{
+		FromBlock: "0x1",
+		ToBlock:   "0x2",
+		Addresses: []string{"0xcc"},
+		Topics:    []*relaypb.TopicFilter{{}, {Values: []string{"0x01", "0x02"}}},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetLogs(), 1)
+	assert.Equal(t, []string{"0x01"}, resp.GetLogs()[0].GetTopics())
+
+	filter := handler.requests[0].Params.([]interface{})[0]
+	assert.Equal(t, map[string]interface{}{
+		"fromBlock": "0x1",
+		"toBlock":   "0x2",
+		"address":   []string{"0xcc"},
+		"topics":    []interface{}{nil, []string{"0x01", "0x02"}},
+	}, filter)
+}
+
+func TestRelay_Call(t *testing.T) {
+	handler := &fakeHandler{results: map[string]string{"eth_call": `"0x01"`}}
+	client := newRelayClient(t, handler, false)
+
+	resp, err := client.Call(context.Background(), &relaypb.CallRequest{To: "0xcc", Data: "0x12345678"})
+	require.NoError(t, err)
+	assert.Equal(t, "0x01", resp.GetData())
+	assert.Equal(t, []interface{}{map[string]interface{}{"to": "0xcc", "data": "0x12345678"}, "latest"}, handler.requests[0].Params)
+}
+
+func TestRelay_Invoke(t *testing.T) {
+	handler := &fakeHandler{results: map[string]string{"eth_feeHistory": `{"oldestBlock":"0x1"}`}}
+	client := newRelayClient(t, handler, false)
+
+	resp, err := client.Invoke(context.Background(), &relaypb.InvokeRequest{Method: "eth_feeHistory", ParamsJson: `["0x1","latest",[]]`})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"oldestBlock":"0x1"}`, resp.GetResultJson())
+	assert.Equal(t, []interface{}{"0x1", "latest", []interface{}{}}, handler.requests[0].Params)
+
+	_, err = client.Invoke(context.Background(), &relaypb.InvokeRequest{Method: "eth_feeHistory", ParamsJson: `{}`})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestRelay_Errors(t *testing.T) {
+	handler := &fakeHandler{errors: map[string]*domain.RPCError{
+		"eth_call":         {Code: domain.ContractRevert, Message: "execution reverted", Data: "0x08c379a0"},
+		"eth_getLogs":      domain.NewRPCError(domain.InvalidParams, "Invalid parameter 0"),
+		"eth_gasPrice":     domain.NewInternalError("Internal error"),
+		"eth_blockNumber":  domain.NewRPCError(domain.LimitExceeded, "Too many concurrent eth_blockNumber requests"),
+		"eth_unknownThing": domain.NewRPCError(domain.MethodNotFound, "Unsupported JSON-RPC method: eth_unknownThing"),
+	}}
+	client := newRelayClient(t, handler, false)
+
+	var trailer metadata.MD
+	_, err := client.Call(context.Background(), &relaypb.CallRequest{To: "0xcc"}, grpc.Trailer(&trailer))
+	assert.Equal(t, codes.Aborted, status.Code(err))
+	assert.Equal(t, "execution reverted", status.Convert(err).Message())
+	assert.Equal(t, []string{"3"}, trailer.Get(grpc_server.ErrorCodeMetadata))
+	assert.Equal(t, []string{"0x08c379a0"}, trailer.Get(grpc_server.ErrorDataMetadata))
+
+	_, err = client.GetLogs(context.Background(), &relaypb.GetLogsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.GasPrice(context.Background(), &relaypb.GasPriceRequest{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	_, err = client.BlockNumber(context.Background(), &relaypb.BlockNumberRequest{})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	_, err = client.Invoke(context.Background(), &relaypb.InvokeRequest{Method: "eth_unknownThing"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestRelay_RequestID(t *testing.T) {
+	client := newRelayClient(t, &fakeHandler{results: map[string]string{"eth_chainId": `"0x128"`}}, false)
+
+	var header metadata.MD
+	ctx := metadata.AppendToOutgoingContext(context.Background(), grpc_server.RequestIDMetadata, "client-id-1")
+	_, err := client.ChainId(ctx, &relaypb.ChainIdRequest{}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"client-id-1"}, header.Get(grpc_server.RequestIDMetadata))
+
+	_, err = client.ChainId(context.Background(), &relaypb.ChainIdRequest{}, grpc.Header(&header))
+	require.NoError(t, err)
+	require.Len(t, header.Get(grpc_server.RequestIDMetadata), 1)
+	assert.Len(t, header.Get(grpc_server.RequestIDMetadata)[0], 36)
+}
+
+func TestRelay_EnforceAPIKey(t *testing.T) {
+	handler := &fakeHandler{results: map[string]string{"eth_chainId": `"0x128"`}}
+	client := newRelayClient(t, handler, true)
+
+	_, err := client.ChainId(context.Background(), &relaypb.ChainIdRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), grpc_server.APIKeyMetadata, "UNKNOWN-KEY")
+	_, err = client.ChainId(ctx, &relaypb.ChainIdRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	var header metadata.MD
+	ctx = metadata.AppendToOutgoingContext(context.Background(), grpc_server.APIKeyMetadata, "FREE-KEY")
+	resp, err := client.ChainId(ctx, &relaypb.ChainIdRequest{}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Equal(t, "0x128", resp.GetValue())
+	assert.Equal(t, []string{limiter.HashAPIKey("FREE-KEY")}, handler.apiKeys, "callers are identified by the hash of their key")
+	assert.Equal(t, []string{"1"}, header.Get("x-ratelimit-limit"))
+	assert.Equal(t, []string{"0"}, header.Get("x-ratelimit-remaining"))
+
+	// The free tier allows one request per minute
+	var trailer metadata.MD
+	_, err = client.ChainId(ctx, &relaypb.ChainIdRequest{}, grpc.Trailer(&trailer))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, []string{"-32029"}, trailer.Get(grpc_server.ErrorCodeMetadata))
+	assert.Len(t, handler.requests, 1)
+}
+
+func TestRelay_BearerAPIKey(t *testing.T) {
+	handler := &fakeHandler{results: map[string]string{"eth_chainId": `"0x128"`}}
+	client := newRelayClient(t, handler, true)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), grpc_server.AuthorizationMetadata, "Basic FREE-KEY")
+	_, err := client.ChainId(ctx, &relaypb.ChainIdRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx = metadata.AppendToOutgoingContext(context.Background(), grpc_server.AuthorizationMetadata, "Bearer FREE-KEY")
+	resp, err := client.ChainId(ctx, &relaypb.ChainIdRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "0x128", resp.GetValue())
+}
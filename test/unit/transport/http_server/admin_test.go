@@ -0,0 +1,208 @@
+package http_server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	"github.com/LimeChain/Hederium/internal/transport/http_server"
+	"github.com/LimeChain/Hederium/test/unit/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testAdminKey = "ADMIN-KEY"
+
+type adminFixture struct {
+	router        *gin.Engine
+	tieredLimiter *limiter.TieredLimiter
+	cacheService  *mocks.MockCacheService
+	debugEnabled  bool
+}
+
+func setupAdminRouter(t *testing.T) *adminFixture {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+
+	apiKeyStore := limiter.NewAPIKeyStore([]interface{}{
+		map[interface{}]interface{}{"key": "FREE-KEY", "tier": "free"},
+	})
+	tieredLimiter := limiter.NewTieredLimiter(map[string]interface{}{
+		"free": map[interface{}]interface{}{"requestsPerMinute": 2, "hbarLimit": 1},
+	}, 10, 0)
+
+	f := &adminFixture{
+		router:        gin.New(),
+		tieredLimiter: tieredLimiter,
+		cacheService:  mocks.NewMockCacheService(ctrl),
+	}
+	features := map[string]http_server.FeatureFlag{
+		"debug": {
+			Enabled:    func() bool { return f.debugEnabled },
+			SetEnabled: func(enabled bool) { f.debugEnabled = enabled },
+		},
+	}
+	http_server.NewAdminAPI(testAdminKey, zap.NewNop(), apiKeyStore, tieredLimiter, f.cacheService, nil, features).RegisterRoutes(f.router)
+	return f
+}
+
+func adminRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("X-ADMIN-KEY", testAdminKey)
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestAdmin_RequiresKey(t *testing.T) {
+	f := setupAdminRouter(t)
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/limits", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/limits", nil)
+	req.Header.Set("X-ADMIN-KEY", "wrong")
+	w = httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestAdmin_SetLimits(t *testing.T) {
+	f := setupAdminRouter(t)
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, adminRequest(http.MethodPut, "/admin/limits/free", `{"requestsPerMinute": 5, "hbarLimit": 3}`))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, limiter.TierConfig{RequestsPerMinute: 5, HbarLimit: 3}, f.tieredLimiter.Tiers()["free"])
+
+	w = httptest.NewRecorder()
+	f.router.ServeHTTP(w, adminRequest(http.MethodPut, "/admin/limits/free", `{"requestsPerMinute": 5}`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	f.router.ServeHTTP(w, adminRequest(http.MethodGet, "/admin/limits", ""))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"free": {"requestsPerMinute": 5, "hbarLimit": 3}}`, w.Body.String())
+}
+
+func TestAdmin_SetLimitsKeepsMethodLists(t *testing.T) {
+	f := setupAdminRouter(t)
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, adminRequest(http.MethodPut, "/admin/limits/free", `{"requestsPerMinute": 5, "hbarLimit": 3, "deniedMethods": ["debug_*"]}`))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.False(t, f.tieredLimiter.MethodAllowed("free", "debug_traceTransaction"))
+
+	w = httptest.NewRecorder()
+	f.router.ServeHTTP(w, adminRequest(http.MethodPut, "/admin/limits/free", `{"requestsPerMinute": 10, "hbarLimit": 3}`))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"requestsPerMinute": 10, "hbarLimit": 3, "deniedMethods": ["debug_*"]}`, w.Body.String())
+	assert.False(t, f.tieredLimiter.MethodAllowed("free", "debug_traceTransaction"))
+
+	w = httptest.NewRecorder()
+	f.router.ServeHTTP(w, adminRequest(http.MethodPut, "/admin/limits/free", `{"requestsPerMinute": 10, "hbarLimit": 3, "deniedMethods": []}`))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, f.tieredLimiter.MethodAllowed("free", "debug_traceTransaction"))
+}
+
+func TestAdmin_SetLimitsKeepsBuckets(t *testing.T) {
+	f := setupAdminRouter(t)
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, adminRequest(http.MethodPut, "/admin/limits/free", `{"requestsPerMinute": 5, "hbarLimit": 3, "writeRequestsPerMinute": 2}`))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"requestsPerMinute": 5, "hbarLimit": 3, "writeRequestsPerMinute": 2}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	f.router.ServeHTTP(w, adminRequest(http.MethodPut, "/admin/limits/free", `{"requestsPerMinute": 10, "hbarLimit": 3, "readRequestsPerMinute": 8}`))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, limiter.TierConfig{RequestsPerMinute: 10, HbarLimit: 3, ReadRequestsPerMinute: 8, WriteRequestsPerMinute: 2}, f.tieredLimiter.Tiers()["free"])
+
+	w = httptest.NewRecorder()
+	f.router.ServeHTTP(w, adminRequest(http.MethodPut, "/admin/limits/free", `{"requestsPerMinute": 10, "hbarLimit": 3, "writeRequestsPerMinute": -1}`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAdmin_APIKeysAndHbar(t *testing.T) {
+	f := setupAdminRouter(t)
+	keyHash := limiter.HashAPIKey("FREE-KEY")
+	require.True(t, f.tieredLimiter.CheckLimits(keyHash, "free"))
+	require.True(t, f.tieredLimiter.DeductHbarUsage(keyHash, "free", 500))
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, adminRequest(http.MethodGet, "/admin/apikeys", ""))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"keyHash": "`+keyHash+`", "tier": "free", "active": true, "requestsThisMinute": 1, "hbarSpent": 500}]`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	f.router.ServeHTTP(w, adminRequest(http.MethodGet, "/admin/hbar", ""))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"budget": 1000000000, "remaining": 999999500, "spent": 500}`, w.Body.String())
+}
+
+// fixedExchangeRate prices one HBAR at 10 cents
+type fixedExchangeRate struct{}
+
+func (fixedExchangeRate) GetExchangeRate(ctx context.Context) (*domain.ExchangeRate, *domain.RPCError) {
+	return &domain.ExchangeRate{HbarEquivalent: 1, CentEquivalent: 10, UsdPerHbar: 0.1}, nil
+}
+
+func (fixedExchangeRate) TinybarsToUSD(ctx context.Context, tinybars int64) (float64, error) {
+	return float64(tinybars) / domain.TinybarsPerHbar * 0.1, nil
+}
+
+func TestAdmin_HbarInUSD(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tieredLimiter := limiter.NewTieredLimiter(map[string]interface{}{
+		"free": map[interface{}]interface{}{"requestsPerMinute": 2, "hbarLimit": 1},
+	}, 10, 0)
+	require.True(t, tieredLimiter.DeductHbarUsage("FREE-KEY", "free", domain.TinybarsPerHbar))
+
+	router := gin.New()
+	http_server.NewAdminAPI(testAdminKey, zap.NewNop(), limiter.NewAPIKeyStore(nil), tieredLimiter, nil, fixedExchangeRate{}, nil).RegisterRoutes(router)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, adminRequest(http.MethodGet, "/admin/hbar", ""))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"budget": 1000000000, "remaining": 900000000, "spent": 100000000, "remainingUsd": 0.9, "spentUsd": 0.1}`, w.Body.String())
+}
+
+func TestAdmin_FlushCache(t *testing.T) {
+	f := setupAdminRouter(t)
+	f.cacheService.EXPECT().Delete(gomock.Any(), "eth_blockNumber").Return(nil)
+	f.cacheService.EXPECT().Delete(gomock.Any(), "eth_gasPrice").Return(nil)
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, adminRequest(http.MethodDelete, "/admin/cache?key=eth_blockNumber&key=eth_gasPrice", ""))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	f.router.ServeHTTP(w, adminRequest(http.MethodDelete, "/admin/cache", ""))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAdmin_ToggleFeatures(t *testing.T) {
+	f := setupAdminRouter(t)
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, adminRequest(http.MethodPut, "/admin/features", `{"debug": true}`))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, f.debugEnabled)
+
+	var states map[string]bool
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &states))
+	assert.Equal(t, map[string]bool{"debug": true}, states)
+
+	w = httptest.NewRecorder()
+	f.router.ServeHTTP(w, adminRequest(http.MethodPut, "/admin/features", `{"unknown": true}`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.True(t, f.debugEnabled)
+}
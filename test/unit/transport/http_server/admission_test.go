@@ -0,0 +1,55 @@
+package http_server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	"github.com/LimeChain/Hederium/internal/transport/http_server"
+	"github.com/LimeChain/Hederium/internal/transport/rpc"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmissionMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	queue := limiter.NewAdmissionQueue(limiter.AdmissionConfig{MaxConcurrent: 1, QueueDepth: 1, QueueTimeout: 20 * time.Millisecond})
+
+	serving := make(chan struct{})
+	done := make(chan struct{})
+	router := gin.New()
+	router.POST("/", http_server.AdmissionMiddleware(queue), func(c *gin.Context) {
+		if c.Query("block") != "" {
+			close(serving)
+			<-done
+		}
+		c.Status(http.StatusOK)
+	})
+
+	go router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/?block=1", nil))
+	<-serving
+
+	// Waits for the slot held by the first request, then gives up
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+
+	var response rpc.JSONRPCResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.NotNil(t, response.Error)
+	assert.Equal(t, domain.LimitExceeded, response.Error.Code)
+	assert.Contains(t, response.Error.Message, "server overloaded")
+
+	close(done)
+	require.Eventually(t, func() bool {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+		return w.Code == http.StatusOK
+	}, time.Second, time.Millisecond)
+}
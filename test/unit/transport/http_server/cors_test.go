@@ -0,0 +1,98 @@
+package http_server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/transport/http_server"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupCORSRouter(cfg http_server.CORSConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(http_server.CORSMiddleware(cfg))
+	router.OPTIONS("/", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	router.POST("/", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+	return router
+}
+
+func preflight(origin string) *http.Request {
+	req := httptest.NewRequest(http.MethodOptions, "/", nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	req.Header.Set("Access-Control-Request-Headers", "content-type")
+	return req
+}
+
+func TestCORS_PreflightAllowedOrigin(t *testing.T) {
+	router := setupCORSRouter(http_server.CORSConfig{
+		AllowedOrigins: []string{"https://dapp.example.com"},
+		AllowedMethods: []string{"POST", "OPTIONS"},
+		AllowedHeaders: []string{"Content-Type", "X-API-KEY"},
+		MaxAge:         10 * time.Minute,
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, preflight("https://dapp.example.com"))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "https://dapp.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Origin", w.Header().Get("Vary"))
+	assert.Equal(t, "POST, OPTIONS", w.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Content-Type, X-API-KEY", w.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+}
+
+func TestCORS_PreflightDisallowedOrigin(t *testing.T) {
+	router := setupCORSRouter(http_server.CORSConfig{
+		AllowedOrigins: []string{"https://dapp.example.com"},
+		AllowedMethods: []string{"POST"},
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, preflight("https://evil.example.com"))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_WildcardOriginAndHeaders(t *testing.T) {
+	router := setupCORSRouter(http_server.CORSConfig{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{"POST"},
+		AllowedHeaders: []string{"*"},
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, preflight("https://any.example.com"))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "content-type", w.Header().Get("Access-Control-Allow-Headers"))
+	assert.Empty(t, w.Header().Get("Access-Control-Max-Age"))
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set("Origin", "https://any.example.com")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Methods"))
+}
+
+func TestCORS_Disabled(t *testing.T) {
+	router := setupCORSRouter(http_server.CORSConfig{})
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set("Origin", "https://dapp.example.com")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
@@ -0,0 +1,94 @@
+package http_server_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/timing"
+	"github.com/LimeChain/Hederium/internal/transport/http_server"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func setupDevModeRouter(handler gin.HandlerFunc) (*gin.Engine, *observer.ObservedLogs) {
+	gin.SetMode(gin.TestMode)
+	core, logs := observer.New(zap.DebugLevel)
+
+	router := gin.New()
+	router.POST("/", http_server.DevModeMiddleware(zap.New(core)), handler)
+	return router, logs
+}
+
+func TestDevMode_LogsRequestAndResponse(t *testing.T) {
+	router, logs := setupDevModeRouter(func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		assert.Contains(t, string(body), "eth_chainId", "the handler must still see the request body")
+
+		stop := timing.Track(c.Request.Context(), timing.Mirror)
+		time.Sleep(5 * time.Millisecond)
+		stop()
+
+		c.JSON(http.StatusOK, gin.H{"jsonrpc": "2.0", "id": 1, "result": "0x12a"})
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`)))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+
+	assert.Contains(t, entries[0].Message, "JSON-RPC request\n{\n")
+	assert.Contains(t, entries[0].Message, `"method": "eth_chainId"`)
+
+	response := entries[1]
+	assert.Contains(t, response.Message, `"result": "0x12a"`)
+	fields := response.ContextMap()
+	assert.Equal(t, int64(http.StatusOK), fields["status"])
+	assert.GreaterOrEqual(t, fields[timing.Mirror], 5*time.Millisecond)
+	assert.Equal(t, time.Duration(0), fields[timing.SDK])
+	assert.GreaterOrEqual(t, fields["total"], fields[timing.Mirror])
+}
+
+func TestDevMode_RedactsRawTransactions(t *testing.T) {
+	router, logs := setupDevModeRouter(func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"jsonrpc": "2.0", "id": 1, "result": "0x01"})
+	})
+
+	rawTx := "0xf86c" + strings.Repeat("ab", 108)
+	body := `[
+		{"jsonrpc":"2.0","id":1,"method":"eth_sendRawTransaction","params":["` + rawTx + `"]},
+		{"jsonrpc":"2.0","id":2,"method":"eth_getBalance","params":["0x05fba803be258049a27b820088bab1cad2058871","latest"]}
+	]`
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+
+	request := entries[0].Message
+	assert.NotContains(t, request, rawTx)
+	assert.Contains(t, request, `"<redacted 110 bytes>"`)
+	assert.Contains(t, request, "0x05fba803be258049a27b820088bab1cad2058871")
+}
+
+func TestDevMode_InvalidJSONLoggedAsIs(t *testing.T) {
+	router, logs := setupDevModeRouter(func(c *gin.Context) {
+		c.String(http.StatusBadRequest, "bad request")
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader("not json")))
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "JSON-RPC request\nnot json", entries[0].Message)
+	assert.Equal(t, "JSON-RPC response\nbad request", entries[1].Message)
+	assert.Equal(t, int64(http.StatusBadRequest), entries[1].ContextMap()["status"])
+}
@@ -0,0 +1,113 @@
+package http_server_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
+	"github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	"github.com/LimeChain/Hederium/internal/service"
+	"github.com/LimeChain/Hederium/internal/transport/http_server"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParseListeners(t *testing.T) {
+	listeners, err := http_server.ParseListeners([]interface{}{
+		map[string]interface{}{"address": ":7546"},
+		map[interface{}]interface{}{"network": "tcp6", "address": "[::1]:7547", "certFile": "cert.pem", "keyFile": "key.pem"},
+		map[string]interface{}{"network": "unix", "address": "/run/hederium.sock"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []http_server.ListenerConfig{
+		{Network: "tcp", Address: ":7546"},
+		{Network: "tcp6", Address: "[::1]:7547", CertFile: "cert.pem", KeyFile: "key.pem"},
+		{Network: "unix", Address: "/run/hederium.sock"},
+	}, listeners)
+	assert.False(t, listeners[0].TLS())
+	assert.True(t, listeners[1].TLS())
+
+	listeners, err = http_server.ParseListeners(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, listeners)
+}
+
+func TestParseListeners_Invalid(t *testing.T) {
+	for name, entry := range map[string]interface{}{
+		"unknown network":  map[string]interface{}{"network": "udp", "address": ":7546"},
+		"missing address":  map[string]interface{}{"network": "unix"},
+		"certificate only": map[string]interface{}{"address": ":7546", "certFile": "cert.pem"},
+		"not an object":    ":7546",
+	} {
+		_, err := http_server.ParseListeners([]interface{}{entry})
+		assert.Error(t, err, name)
+	}
+}
+
+func newListenerServer(listeners []http_server.ListenerConfig, tlsConfig http_server.TLSConfig) http_server.Server {
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+	cacheService := cache.NewMemoryCache(time.Minute, time.Minute)
+	return http_server.NewServer(
+		nil,
+		hedera.NewMirrorClient([]string{"http://127.0.0.1:0"}, 1, logger, cacheService),
+		logger,
+		"test",
+		"0x12a",
+		limiter.NewAPIKeyStore(nil),
+		limiter.NewTieredLimiter(nil, 0, 0),
+		false,
+		false,
+		cacheService,
+		service.Config{},
+		http_server.ServerOptions{Listeners: listeners, TLS: tlsConfig},
+	)
+}
+
+func TestServer_UnixSocketListener(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "hederium.sock")
+	// A socket left behind by an earlier run is replaced
+	stale, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	server := newListenerServer([]http_server.ListenerConfig{{Network: "unix", Address: socket}}, http_server.TLSConfig{})
+	go func() { _ = server.Start() }()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+		},
+	}}
+
+	var resp *http.Response
+	require.Eventually(t, func() bool {
+		resp, err = client.Post("http://relay/", "application/json", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`))
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestServer_ListenerFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hederium.sock")
+	require.NoError(t, os.WriteFile(path, []byte("not a socket"), 0o600))
+
+	server := newListenerServer([]http_server.ListenerConfig{{Network: "unix", Address: path}}, http_server.TLSConfig{})
+	assert.ErrorContains(t, server.Start(), "is not a socket")
+
+	server = newListenerServer(nil, http_server.TLSConfig{})
+	assert.Error(t, server.Start())
+}
@@ -0,0 +1,54 @@
+package http_server_test
+
+import (
+	"testing"
+
+	"github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	"github.com/LimeChain/Hederium/internal/transport/http_server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateNetworks(t *testing.T) {
+	network := func(name string, hosts ...string) http_server.Network {
+		return http_server.Network{
+			Name:         name,
+			Hosts:        hosts,
+			ChainID:      "0x128",
+			HederaClient: &hedera.HederaClient{},
+			MirrorClient: &hedera.MirrorClient{},
+		}
+	}
+
+	assert.NoError(t, http_server.ValidateNetworks(nil))
+	assert.NoError(t, http_server.ValidateNetworks([]http_server.Network{
+		network("testnet", "testnet.relay.local"),
+		network("previewnet", "previewnet.relay.local"),
+	}))
+
+	withoutChainID := network("testnet")
+	withoutChainID.ChainID = ""
+	withoutMirror := network("testnet")
+	withoutMirror.MirrorClient = nil
+
+	tests := []struct {
+		name     string
+		networks []http_server.Network
+		err      string
+	}{
+		{"invalid name", []http_server.Network{network("Test/Net")}, "must be lowercase letters"},
+		{"empty name", []http_server.Network{network("")}, "must be lowercase letters"},
+		{"reserved name", []http_server.Network{network("metrics")}, "is reserved"},
+		{"duplicate name", []http_server.Network{network("testnet"), network("testnet")}, "duplicate name"},
+		{"missing chain ID", []http_server.Network{withoutChainID}, "chainId is required"},
+		{"missing client", []http_server.Network{withoutMirror}, "clients are required"},
+		{"duplicate host", []http_server.Network{network("testnet", "relay.local"), network("previewnet", "RELAY.local")}, "already served by network testnet"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := http_server.ValidateNetworks(tt.networks)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package http_server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/LimeChain/Hederium/internal/transport/http_server"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// auditMiddleware records the status of every request it sees.
+func auditMiddleware(statuses *[]int) http_server.Middleware {
+	return http_server.NewMiddleware("audit", func(c *gin.Context) {
+		c.Next()
+		*statuses = append(*statuses, c.Writer.Status())
+	})
+}
+
+func postWithoutAPIKey(handler http.Handler) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId"}`))
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestPipeline_CustomMiddlewareRunsAfterBuiltinStages(t *testing.T) {
+	var statuses []int
+	relay := newRateLimitedRelayWithPipeline(http_server.PipelineConfig{
+		Middlewares: []http_server.Middleware{auditMiddleware(&statuses)},
+	})
+
+	w := postWithoutAPIKey(relay.Handler())
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	// Authentication turned the request away before it reached the audit
+	assert.Empty(t, statuses)
+}
+
+func TestPipeline_OrderRunsListedStagesFirst(t *testing.T) {
+	var statuses []int
+	relay := newRateLimitedRelayWithPipeline(http_server.PipelineConfig{
+		Order:       []string{"audit", "unknown", http_server.MiddlewareAuth},
+		Middlewares: []http_server.Middleware{auditMiddleware(&statuses)},
+	})
+
+	w := postWithoutAPIKey(relay.Handler())
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, []int{http.StatusUnauthorized}, statuses)
+}
+
+func TestPipeline_DuplicateNamesAreSkipped(t *testing.T) {
+	var statuses []int
+	relay := newRateLimitedRelayWithPipeline(http_server.PipelineConfig{
+		Order: []string{"audit"},
+		Middlewares: []http_server.Middleware{
+			auditMiddleware(&statuses),
+			auditMiddleware(&statuses),
+			// A custom stage cannot replace authentication
+			http_server.NewMiddleware(http_server.MiddlewareAuth, func(c *gin.Context) { c.Next() }),
+		},
+	})
+
+	w := postWithoutAPIKey(relay.Handler())
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, []int{http.StatusUnauthorized}, statuses)
+}
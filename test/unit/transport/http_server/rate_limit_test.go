@@ -0,0 +1,168 @@
+package http_server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/cache"
+	"github.com/LimeChain/Hederium/internal/infrastructure/hedera"
+	"github.com/LimeChain/Hederium/internal/infrastructure/limiter"
+	"github.com/LimeChain/Hederium/internal/service"
+	"github.com/LimeChain/Hederium/internal/transport/http_server"
+	"github.com/LimeChain/Hederium/internal/transport/rpc"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newRateLimitedServer() http.Handler {
+	return newRateLimitedRelay().Handler()
+}
+
+func newRateLimitedRelay() http_server.Server {
+	return newRateLimitedRelayWithPipeline(http_server.PipelineConfig{})
+}
+
+func newRateLimitedRelayWithPipeline(pipelineConfig http_server.PipelineConfig) http_server.Server {
+	return newRelayWithFreeTier(map[interface{}]interface{}{"requestsPerMinute": 1, "hbarLimit": 1}, false, pipelineConfig)
+}
+
+func newRelayWithFreeTier(freeTier map[interface{}]interface{}, enableBatchRequests bool, pipelineConfig http_server.PipelineConfig) http_server.Server {
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+	cacheService := cache.NewMemoryCache(time.Minute, time.Minute)
+	apiKeyStore := limiter.NewAPIKeyStore([]interface{}{
+		map[interface{}]interface{}{"key": "FREE-KEY", "tier": "free"},
+	})
+	tieredLimiter := limiter.NewTieredLimiter(map[string]interface{}{"free": freeTier}, 10, 0)
+	return http_server.NewServer(
+		nil,
+		hedera.NewMirrorClient([]string{"http://127.0.0.1:0"}, 1, logger, cacheService),
+		logger,
+		"test",
+		"0x12a",
+		apiKeyStore,
+		tieredLimiter,
+		true,
+		enableBatchRequests,
+		cacheService,
+		service.Config{},
+		http_server.ServerOptions{Pipeline: pipelineConfig},
+	)
+}
+
+func chainIDRequest() *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-KEY", "FREE-KEY")
+	return req
+}
+
+func TestAuth_APIKeySources(t *testing.T) {
+	testCases := []struct {
+		name           string
+		target         string
+		authorization  string
+		expectedStatus int
+	}{
+		{name: "bearer token", target: "/", authorization: "Bearer FREE-KEY", expectedStatus: http.StatusOK},
+		{name: "lower case scheme", target: "/", authorization: "bearer FREE-KEY", expectedStatus: http.StatusOK},
+		{name: "query parameter", target: "/?apikey=FREE-KEY", expectedStatus: http.StatusOK},
+		{name: "other scheme", target: "/", authorization: "Basic FREE-KEY", expectedStatus: http.StatusUnauthorized},
+		{name: "unknown bearer token", target: "/", authorization: "Bearer UNKNOWN-KEY", expectedStatus: http.StatusForbidden},
+		{name: "unknown query parameter", target: "/?apikey=UNKNOWN-KEY", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := newRateLimitedServer()
+			req := httptest.NewRequest(http.MethodPost, tc.target, strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`))
+			req.Header.Set("Content-Type", "application/json")
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			assert.Equal(t, tc.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestRateLimit_Headers(t *testing.T) {
+	handler := newRateLimitedServer()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, chainIDRequest())
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+
+	reset, err := strconv.ParseInt(w.Header().Get("X-RateLimit-Reset"), 10, 64)
+	require.NoError(t, err)
+	assert.InDelta(t, time.Now().Add(time.Minute).Unix(), reset, 2)
+	assert.Empty(t, w.Header().Get("Retry-After"))
+}
+
+func TestRateLimit_Exceeded(t *testing.T) {
+	handler := newRateLimitedServer()
+
+	handler.ServeHTTP(httptest.NewRecorder(), chainIDRequest())
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, chainIDRequest())
+
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+	retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+	require.NoError(t, err)
+	assert.True(t, retryAfter >= 1 && retryAfter <= 60, "Retry-After is %d", retryAfter)
+
+	var resp rpc.JSONRPCResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "2.0", resp.JSONRPC)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, domain.RequestRateLimitExceeded, resp.Error.Code)
+	assert.Contains(t, resp.Error.Message, "1 requests per minute")
+}
+
+func TestRateLimit_ReadAndWriteBuckets(t *testing.T) {
+	handler := newRelayWithFreeTier(map[interface{}]interface{}{
+		"requestsPerMinute":      100,
+		"hbarLimit":              1,
+		"readRequestsPerMinute":  1,
+		"writeRequestsPerMinute": 1,
+	}, true, http_server.PipelineConfig{}).Handler()
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`[
+		{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]},
+		{"jsonrpc":"2.0","id":2,"method":"eth_chainId","params":[]},
+		{"jsonrpc":"2.0","id":3,"method":"eth_sendRawTransaction","params":["0xzz"]},
+		{"jsonrpc":"2.0","id":4,"method":"eth_sendRawTransaction","params":["0xzz"]}
+	]`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-KEY", "FREE-KEY")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resps []rpc.JSONRPCResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resps))
+	require.Len(t, resps, 4)
+
+	// The first read and the first write each have a call left in their own
+	// bucket, the second of each does not
+	assert.Nil(t, resps[0].Error)
+	require.NotNil(t, resps[1].Error)
+	assert.Equal(t, domain.RequestRateLimitExceeded, resps[1].Error.Code)
+	require.NotNil(t, resps[2].Error)
+	assert.NotEqual(t, domain.RequestRateLimitExceeded, resps[2].Error.Code)
+	require.NotNil(t, resps[3].Error)
+	assert.Equal(t, domain.RequestRateLimitExceeded, resps[3].Error.Code)
+}
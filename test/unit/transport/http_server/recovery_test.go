@@ -0,0 +1,51 @@
+package http_server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/metrics"
+	"github.com/LimeChain/Hederium/internal/transport/http_server"
+	"github.com/LimeChain/Hederium/internal/transport/rpc"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRecoveryMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	core, logs := observer.New(zap.ErrorLevel)
+
+	router := gin.New()
+	router.Use(http_server.RecoveryMiddleware(zap.New(core)), http_server.RequestIDMiddleware())
+	router.POST("/", func(c *gin.Context) {
+		panic("boom")
+	})
+	panics := testutil.ToFloat64(metrics.Panics.WithLabelValues("POST /"))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId"}`))
+	req.Header.Set(http_server.RequestIDHeader, "req-1")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	var response rpc.JSONRPCResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.NotNil(t, response.Error)
+	assert.Equal(t, domain.InternalError, response.Error.Code)
+	assert.Equal(t, panics+1, testutil.ToFloat64(metrics.Panics.WithLabelValues("POST /")))
+
+	entries := logs.FilterMessage("Recovered from panic while serving request").All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "req-1", fields["requestId"])
+	assert.Equal(t, "boom", fields["panic"])
+	assert.Contains(t, fields["stack"], "recovery_test.go")
+}
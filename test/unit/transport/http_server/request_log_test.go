@@ -0,0 +1,108 @@
+package http_server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	hederiumlogger "github.com/LimeChain/Hederium/internal/infrastructure/logger"
+	"github.com/LimeChain/Hederium/internal/transport/http_server"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func setupRequestLogRouter(handler gin.HandlerFunc) (*gin.Engine, *observer.ObservedLogs) {
+	gin.SetMode(gin.TestMode)
+	core, logs := observer.New(zap.InfoLevel)
+
+	router := gin.New()
+	router.Use(http_server.RequestIDMiddleware())
+	router.POST("/", http_server.RequestLogMiddleware(zap.New(core)), handler)
+	return router, logs
+}
+
+func TestRequestID_AssignedAndEchoed(t *testing.T) {
+	var seen string
+	router, _ := setupRequestLogRouter(func(c *gin.Context) {
+		seen = hederiumlogger.RequestIDFromContext(c.Request.Context())
+		c.JSON(http.StatusOK, gin.H{"jsonrpc": "2.0", "id": 1, "result": "0x12a"})
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId"}`)))
+
+	id := w.Header().Get(http_server.RequestIDHeader)
+	assert.Regexp(t, uuidPattern, id)
+	assert.Equal(t, id, seen)
+}
+
+func TestRequestID_ClientIDIsKept(t *testing.T) {
+	router, _ := setupRequestLogRouter(func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"jsonrpc": "2.0", "id": 1, "result": "0x12a"})
+	})
+
+	for header, kept := range map[string]bool{
+		"wallet-4711":            true,
+		"contains space":         false,
+		strings.Repeat("a", 129): false,
+		"line\nbreak":            false,
+		strings.Repeat("b", 128): true,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+		req.Header.Set(http_server.RequestIDHeader, header)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if kept {
+			assert.Equal(t, header, w.Header().Get(http_server.RequestIDHeader))
+		} else {
+			assert.Regexp(t, uuidPattern, w.Header().Get(http_server.RequestIDHeader))
+		}
+	}
+}
+
+func TestRequestLog_SingleRequest(t *testing.T) {
+	router, logs := setupRequestLogRouter(func(c *gin.Context) {
+		c.JSON(http.StatusBadRequest, gin.H{"jsonrpc": "2.0", "id": 1, "error": gin.H{"code": -32602, "message": "Invalid params"}})
+	})
+
+	rawTx := "0xf86c" + strings.Repeat("ab", 108)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"eth_sendRawTransaction","params":["`+rawTx+`"]}`)))
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, w.Header().Get(http_server.RequestIDHeader), fields["requestId"])
+	assert.Equal(t, "eth_sendRawTransaction", fields["method"])
+	assert.Equal(t, `["<redacted 110 bytes>"]`, fields["params"])
+	assert.Equal(t, int64(http.StatusBadRequest), fields["status"])
+	assert.Equal(t, int64(w.Body.Len()), fields["responseSize"])
+	assert.Equal(t, int64(-32602), fields["errorCode"])
+	assert.Contains(t, fields, "duration")
+}
+
+func TestRequestLog_BatchRequest(t *testing.T) {
+	router, logs := setupRequestLogRouter(func(c *gin.Context) {
+		c.JSON(http.StatusOK, []gin.H{
+			{"jsonrpc": "2.0", "id": 1, "result": "0x12a"},
+			{"jsonrpc": "2.0", "id": 2, "error": gin.H{"code": -32601, "message": "Method not found"}},
+		})
+	})
+
+	body := `[{"jsonrpc":"2.0","id":1,"method":"eth_chainId"},{"jsonrpc":"2.0","id":2,"method":"eth_sign","params":[]}]`
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, []interface{}{"eth_chainId", "eth_sign"}, fields["methods"])
+	assert.Equal(t, []interface{}{-32601}, fields["errorCodes"])
+}
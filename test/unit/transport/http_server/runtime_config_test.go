@@ -0,0 +1,55 @@
+package http_server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/transport/http_server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyRuntimeConfig(t *testing.T) {
+	server := newRateLimitedRelay()
+	handler := server.Handler()
+
+	handler.ServeHTTP(httptest.NewRecorder(), chainIDRequest())
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, chainIDRequest())
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	server.ApplyRuntimeConfig(http_server.RuntimeConfig{
+		LogLevel: "info",
+		Tiers: map[string]interface{}{
+			"free": map[string]interface{}{"requestsPerMinute": 10, "hbarLimit": 1},
+		},
+		APIKeys: []interface{}{
+			map[string]interface{}{"key": "FREE-KEY", "tier": "free"},
+			map[string]interface{}{"key": "NEW-KEY", "tier": "free"},
+		},
+		BatchRequestsEnabled: true,
+		CallCacheTTL:         time.Second,
+	})
+
+	// The raised limit applies to the window already started
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, chainIDRequest())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "10", w.Header().Get("X-RateLimit-Limit"))
+
+	req := chainIDRequest()
+	req.Header.Set("X-API-KEY", "NEW-KEY")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	batch := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`[{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]},{"jsonrpc":"2.0","id":2,"method":"eth_chainId","params":[]}]`))
+	batch.Header.Set("Content-Type", "application/json")
+	batch.Header.Set("X-API-KEY", "NEW-KEY")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, batch)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
@@ -0,0 +1,133 @@
+package http_server_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/transport/http_server"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type shadowRelay struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []string
+	headers  []http.Header
+}
+
+// newShadowRelay answers every request with response.
+func newShadowRelay(t *testing.T, response string) *shadowRelay {
+	relay := &shadowRelay{}
+	relay.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		relay.mu.Lock()
+		relay.requests = append(relay.requests, string(body))
+		relay.headers = append(relay.headers, r.Header.Clone())
+		relay.mu.Unlock()
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(relay.Close)
+	return relay
+}
+
+func (r *shadowRelay) received() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.requests...)
+}
+
+func setupShadowRouter(config http_server.ShadowConfig, result interface{}) (*gin.Engine, *observer.ObservedLogs) {
+	gin.SetMode(gin.TestMode)
+	core, logs := observer.New(zap.DebugLevel)
+
+	router := gin.New()
+	router.POST("/", http_server.ShadowMiddleware(config, zap.New(core)), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"jsonrpc": "2.0", "id": 1, "result": result})
+	})
+	return router, logs
+}
+
+func shadowPost(router *gin.Engine, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	return w
+}
+
+func TestShadow_LogsDifferingResponses(t *testing.T) {
+	relay := newShadowRelay(t, `{"jsonrpc":"2.0","id":1,"result":{"hash":"0xABC","gasUsed":"0x5","logs":[]}}`)
+	router, logs := setupShadowRouter(http_server.ShadowConfig{
+		Enabled:    true,
+		URL:        relay.URL,
+		Percentage: 100,
+		Headers:    map[string]string{"X-API-KEY": "shadow-key"},
+	}, map[string]interface{}{"hash": "0xabc", "gasUsed": "0x4", "logs": []interface{}{}})
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"eth_getTransactionReceipt","params":["0xabc"]}`
+	w := shadowPost(router, body)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	require.Eventually(t, func() bool {
+		return logs.FilterMessage("Shadow relay response differs").Len() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, []string{body}, relay.received())
+	assert.Equal(t, "shadow-key", relay.headers[0].Get("X-API-KEY"))
+
+	fields := logs.FilterMessage("Shadow relay response differs").All()[0].ContextMap()
+	assert.Equal(t, "eth_getTransactionReceipt", fields["method"])
+	// The hash differs only in case and is not reported
+	assert.Equal(t, []interface{}{`result.gasUsed: "0x4" != "0x5"`}, fields["diffs"])
+}
+
+func TestShadow_MatchingResponsesAreNotLogged(t *testing.T) {
+	relay := newShadowRelay(t, `{"jsonrpc":"2.0","id":1,"result":"0x12a"}`)
+	router, logs := setupShadowRouter(http_server.ShadowConfig{Enabled: true, URL: relay.URL, Percentage: 100}, "0x12a")
+
+	shadowPost(router, `{"jsonrpc":"2.0","id":1,"method":"eth_chainId"}`)
+
+	require.Eventually(t, func() bool { return len(relay.received()) == 1 }, time.Second, 10*time.Millisecond)
+	// Give the comparison time to finish
+	time.Sleep(50 * time.Millisecond)
+	assert.Zero(t, logs.FilterMessage("Shadow relay response differs").Len())
+}
+
+func TestShadow_SkipsUnsafeMethodsAndBatches(t *testing.T) {
+	relay := newShadowRelay(t, `{"jsonrpc":"2.0","id":1,"result":"0x1"}`)
+	router, _ := setupShadowRouter(http_server.ShadowConfig{Enabled: true, URL: relay.URL, Percentage: 100}, "0x2")
+
+	for _, body := range []string{
+		`{"jsonrpc":"2.0","id":1,"method":"eth_sendRawTransaction","params":["0x02"]}`,
+		`{"jsonrpc":"2.0","id":1,"method":"eth_newBlockFilter"}`,
+		`[{"jsonrpc":"2.0","id":1,"method":"eth_chainId"}]`,
+	} {
+		w := shadowPost(router, body)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "0x2", response["result"])
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, relay.received())
+}
+
+func TestShadow_ZeroPercentageMirrorsNothing(t *testing.T) {
+	relay := newShadowRelay(t, `{"jsonrpc":"2.0","id":1,"result":"0x1"}`)
+	router, _ := setupShadowRouter(http_server.ShadowConfig{Enabled: true, URL: relay.URL, Percentage: 0}, "0x1")
+
+	for i := 0; i < 10; i++ {
+		shadowPost(router, `{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"}`)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, relay.received())
+}
@@ -0,0 +1,139 @@
+package http_server_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/transport/http_server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCertificate writes a self-signed certificate with the given serial
+// number and its key, stamping both files with modTime.
+func writeCertificate(t *testing.T, certFile, keyFile string, serial int64, modTime time.Time) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	require.NoError(t, os.Chtimes(certFile, modTime, modTime))
+	require.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+}
+
+func servedSerial(t *testing.T, reloader *http_server.CertReloader) int64 {
+	t.Helper()
+
+	certificate, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+	require.NoError(t, err)
+	return leaf.SerialNumber.Int64()
+}
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	start := time.Now().Add(-time.Minute)
+	writeCertificate(t, certFile, keyFile, 1, start)
+
+	reloader, err := http_server.NewCertReloader(certFile, keyFile)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), servedSerial(t, reloader))
+
+	reloaded, err := reloader.ReloadIfChanged()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+
+	writeCertificate(t, certFile, keyFile, 2, start.Add(time.Second))
+	reloaded, err = reloader.ReloadIfChanged()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.Equal(t, int64(2), servedSerial(t, reloader))
+
+	// A broken pair is rejected and the previous certificate stays in use
+	require.NoError(t, os.WriteFile(keyFile, []byte("not a key"), 0o600))
+	assert.Error(t, reloader.Reload())
+	assert.Equal(t, int64(2), servedSerial(t, reloader))
+}
+
+func TestNewCertReloader_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	_, err := http_server.NewCertReloader(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"))
+	assert.Error(t, err)
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	config := http_server.NewTLSConfig(&http_server.CertReloader{})
+
+	assert.Equal(t, uint16(tls.VersionTLS12), config.MinVersion)
+	for _, suite := range config.CipherSuites {
+		name := tls.CipherSuiteName(suite)
+		assert.True(t, strings.HasPrefix(name, "TLS_ECDHE_"), name)
+		assert.NotContains(t, name, "CBC")
+	}
+}
+
+func TestServer_TLSListenerReloadsCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	start := time.Now().Add(-time.Minute)
+	writeCertificate(t, certFile, keyFile, 1, start)
+
+	// Reserve a free port for the listener
+	probe, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := probe.Addr().String()
+	require.NoError(t, probe.Close())
+
+	server := newListenerServer(
+		[]http_server.ListenerConfig{{Network: "tcp", Address: address, CertFile: certFile, KeyFile: keyFile}},
+		http_server.TLSConfig{ReloadInterval: 10 * time.Millisecond},
+	)
+	go func() { _ = server.Start() }()
+
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+		DisableKeepAlives: true,
+	}}
+	peerSerial := func() int64 {
+		resp, err := client.Post("https://"+address+"/", "application/json", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`))
+		if err != nil {
+			return 0
+		}
+		defer func() { _ = resp.Body.Close() }()
+		return resp.TLS.PeerCertificates[0].SerialNumber.Int64()
+	}
+
+	require.Eventually(t, func() bool { return peerSerial() == 1 }, time.Second, 10*time.Millisecond)
+
+	writeCertificate(t, certFile, keyFile, 2, start.Add(time.Second))
+	require.Eventually(t, func() bool { return peerSerial() == 2 }, time.Second, 10*time.Millisecond)
+}
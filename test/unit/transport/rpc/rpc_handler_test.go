@@ -0,0 +1,111 @@
+package rpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/domain"
+	"github.com/LimeChain/Hederium/internal/infrastructure/metrics"
+	"github.com/LimeChain/Hederium/internal/service"
+	"github.com/LimeChain/Hederium/internal/transport/rpc"
+	"github.com/LimeChain/Hederium/test/unit/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// ethServiceProvider serves only the eth namespace.
+type ethServiceProvider struct {
+	service.ServiceProvider
+	eth *service.EthService
+}
+
+func (p ethServiceProvider) EthService() *service.EthService {
+	return p.eth
+}
+
+func TestHandleRequest_RecoversFromPanic(t *testing.T) {
+	require.NoError(t, rpc.RegisterCustomValidators())
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accounts := mocks.NewMockAccountService(ctrl)
+	accounts.EXPECT().
+		GetBalance(gomock.Any(), "0x742d35cc6634c0532925a3b844bc454e4438f44e", "latest").
+		DoAndReturn(func(context.Context, string, string) (string, *domain.RPCError) {
+			panic("unexpected mirror node response")
+		})
+
+	core, logs := observer.New(zap.ErrorLevel)
+	handler := rpc.NewHandler(zap.New(core), ethServiceProvider{eth: &service.EthService{AccountService: accounts}}, nil, nil, nil, rpc.TimeoutConfig{})
+	panics := testutil.ToFloat64(metrics.Panics.WithLabelValues("eth_getBalance"))
+
+	resp := handler.HandleRequest(context.Background(), &rpc.JSONRPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "eth_getBalance",
+		Params:  []interface{}{"0x742d35cc6634c0532925a3b844bc454e4438f44e", "latest"},
+	})
+
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, domain.InternalError, resp.Error.Code)
+	assert.Equal(t, 1, resp.ID)
+	assert.Equal(t, panics+1, testutil.ToFloat64(metrics.Panics.WithLabelValues("eth_getBalance")))
+
+	entries := logs.FilterMessage("Recovered from panic in JSON-RPC method").All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "eth_getBalance", fields["method"])
+	assert.Contains(t, fields["stack"], "rpc_handler.go")
+}
+
+func TestHandleRequest_MethodTimeout(t *testing.T) {
+	require.NoError(t, rpc.RegisterCustomValidators())
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accounts := mocks.NewMockAccountService(ctrl)
+	accounts.EXPECT().
+		GetBalance(gomock.Any(), "0x742d35cc6634c0532925a3b844bc454e4438f44e", "latest").
+		DoAndReturn(func(ctx context.Context, address, block string) (string, *domain.RPCError) {
+			// The mirror node answers only once the budget is spent
+			<-ctx.Done()
+			return "", domain.NewRequestTimeoutError()
+		})
+	accounts.EXPECT().
+		GetBalance(gomock.Any(), "0x742d35cc6634c0532925a3b844bc454e4438f44e", "0x1").
+		Return("0x64", nil)
+
+	timeouts := rpc.TimeoutConfig{Methods: map[string]time.Duration{"eth_getbalance": 20 * time.Millisecond}}
+	handler := rpc.NewHandler(zap.NewNop(), ethServiceProvider{eth: &service.EthService{AccountService: accounts}}, nil, nil, nil, timeouts)
+	timedOut := testutil.ToFloat64(metrics.MethodTimeouts.WithLabelValues("eth_getBalance"))
+
+	resp := handler.HandleRequest(context.Background(), &rpc.JSONRPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "eth_getBalance",
+		Params:  []interface{}{"0x742d35cc6634c0532925a3b844bc454e4438f44e", "latest"},
+	})
+
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, domain.InternalError, resp.Error.Code)
+	assert.Contains(t, resp.Error.Message, "Request timeout: eth_getBalance did not complete within its budget")
+	assert.Equal(t, timedOut+1, testutil.ToFloat64(metrics.MethodTimeouts.WithLabelValues("eth_getBalance")))
+
+	// Calls completing within the budget are answered as usual
+	resp = handler.HandleRequest(context.Background(), &rpc.JSONRPCRequest{
+		JSONRPC: "2.0",
+		ID:      2,
+		Method:  "eth_getBalance",
+		Params:  []interface{}{"0x742d35cc6634c0532925a3b844bc454e4438f44e", "0x1"},
+	})
+
+	assert.Nil(t, resp.Error)
+	assert.Equal(t, "0x64", resp.Result)
+}
@@ -0,0 +1,24 @@
+package rpc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/LimeChain/Hederium/internal/transport/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeoutConfig_For(t *testing.T) {
+	config := rpc.TimeoutConfig{
+		Default: 15 * time.Second,
+		Methods: map[string]time.Duration{
+			"eth_getlogs": 30 * time.Second,
+			"eth_call":    10 * time.Second,
+		},
+	}
+
+	assert.Equal(t, 30*time.Second, config.For("eth_getLogs"))
+	assert.Equal(t, 10*time.Second, config.For("eth_call"))
+	assert.Equal(t, 15*time.Second, config.For("eth_blockNumber"))
+	assert.Equal(t, time.Duration(0), rpc.TimeoutConfig{}.For("eth_call"))
+}
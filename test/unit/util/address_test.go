@@ -0,0 +1,30 @@
+package util_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/LimeChain/Hederium/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecksumAddress(t *testing.T) {
+	// Test vectors from EIP-55
+	for _, expected := range []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+		"0x52908400098527886E0F7030069857D2E4169EE7",
+		"0xde709f2102306220921060314715629080e2fb77",
+	} {
+		assert.Equal(t, expected, util.ChecksumAddress(strings.ToLower(expected)))
+		assert.Equal(t, expected, util.ChecksumAddress("0x"+strings.ToUpper(expected[2:])))
+	}
+}
+
+func TestChecksumAddress_NotAnAddress(t *testing.T) {
+	for _, value := range []string{"", "0x0", "0.0.1001", "0x" + strings.Repeat("g", 40), "0x" + strings.Repeat("a", 64)} {
+		assert.Equal(t, value, util.ChecksumAddress(value))
+	}
+}